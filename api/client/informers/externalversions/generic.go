@@ -52,6 +52,8 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=operator, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithResource("vlclusters"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VLClusters().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vlogs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VLogs().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmagents"):
@@ -62,8 +64,16 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMAlertmanagers().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmalertmanagerconfigs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMAlertmanagerConfigs().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("vmalertmanagersilences"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMAlertmanagerSilences().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("vmanomalies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMAnomalies().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmauths"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMAuths().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("vmbackupjobs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMBackupJobs().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("vmblackboxexporters"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMBlackboxExporters().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmclusters"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMClusters().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmnodescrapes"):
@@ -72,8 +82,12 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMPodScrapes().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmprobes"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMProbes().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("vmrestorejobs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMRestoreJobs().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmrules"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMRules().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("vmruletests"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMRuleTests().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmscrapeconfigs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMScrapeConfigs().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmservicescrapes"):
@@ -82,6 +96,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMSingles().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmstaticscrapes"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMStaticScrapes().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("vmtenants"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMTenants().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmusers"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMUsers().Informer()}, nil
 
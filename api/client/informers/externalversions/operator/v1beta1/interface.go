@@ -23,6 +23,8 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// VLClusters returns a VLClusterInformer.
+	VLClusters() VLClusterInformer
 	// VLogs returns a VLogsInformer.
 	VLogs() VLogsInformer
 	// VMAgents returns a VMAgentInformer.
@@ -33,8 +35,16 @@ type Interface interface {
 	VMAlertmanagers() VMAlertmanagerInformer
 	// VMAlertmanagerConfigs returns a VMAlertmanagerConfigInformer.
 	VMAlertmanagerConfigs() VMAlertmanagerConfigInformer
+	// VMAlertmanagerSilences returns a VMAlertmanagerSilenceInformer.
+	VMAlertmanagerSilences() VMAlertmanagerSilenceInformer
+	// VMAnomalies returns a VMAnomalyInformer.
+	VMAnomalies() VMAnomalyInformer
 	// VMAuths returns a VMAuthInformer.
 	VMAuths() VMAuthInformer
+	// VMBackupJobs returns a VMBackupJobInformer.
+	VMBackupJobs() VMBackupJobInformer
+	// VMBlackboxExporters returns a VMBlackboxExporterInformer.
+	VMBlackboxExporters() VMBlackboxExporterInformer
 	// VMClusters returns a VMClusterInformer.
 	VMClusters() VMClusterInformer
 	// VMNodeScrapes returns a VMNodeScrapeInformer.
@@ -43,8 +53,12 @@ type Interface interface {
 	VMPodScrapes() VMPodScrapeInformer
 	// VMProbes returns a VMProbeInformer.
 	VMProbes() VMProbeInformer
+	// VMRestoreJobs returns a VMRestoreJobInformer.
+	VMRestoreJobs() VMRestoreJobInformer
 	// VMRules returns a VMRuleInformer.
 	VMRules() VMRuleInformer
+	// VMRuleTests returns a VMRuleTestInformer.
+	VMRuleTests() VMRuleTestInformer
 	// VMScrapeConfigs returns a VMScrapeConfigInformer.
 	VMScrapeConfigs() VMScrapeConfigInformer
 	// VMServiceScrapes returns a VMServiceScrapeInformer.
@@ -53,6 +67,8 @@ type Interface interface {
 	VMSingles() VMSingleInformer
 	// VMStaticScrapes returns a VMStaticScrapeInformer.
 	VMStaticScrapes() VMStaticScrapeInformer
+	// VMTenants returns a VMTenantInformer.
+	VMTenants() VMTenantInformer
 	// VMUsers returns a VMUserInformer.
 	VMUsers() VMUserInformer
 }
@@ -68,6 +84,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// VLClusters returns a VLClusterInformer.
+func (v *version) VLClusters() VLClusterInformer {
+	return &vLClusterInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // VLogs returns a VLogsInformer.
 func (v *version) VLogs() VLogsInformer {
 	return &vLogsInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -93,11 +114,31 @@ func (v *version) VMAlertmanagerConfigs() VMAlertmanagerConfigInformer {
 	return &vMAlertmanagerConfigInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// VMAlertmanagerSilences returns a VMAlertmanagerSilenceInformer.
+func (v *version) VMAlertmanagerSilences() VMAlertmanagerSilenceInformer {
+	return &vMAlertmanagerSilenceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// VMAnomalies returns a VMAnomalyInformer.
+func (v *version) VMAnomalies() VMAnomalyInformer {
+	return &vMAnomalyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // VMAuths returns a VMAuthInformer.
 func (v *version) VMAuths() VMAuthInformer {
 	return &vMAuthInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// VMBackupJobs returns a VMBackupJobInformer.
+func (v *version) VMBackupJobs() VMBackupJobInformer {
+	return &vMBackupJobInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// VMBlackboxExporters returns a VMBlackboxExporterInformer.
+func (v *version) VMBlackboxExporters() VMBlackboxExporterInformer {
+	return &vMBlackboxExporterInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // VMClusters returns a VMClusterInformer.
 func (v *version) VMClusters() VMClusterInformer {
 	return &vMClusterInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -118,11 +159,21 @@ func (v *version) VMProbes() VMProbeInformer {
 	return &vMProbeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// VMRestoreJobs returns a VMRestoreJobInformer.
+func (v *version) VMRestoreJobs() VMRestoreJobInformer {
+	return &vMRestoreJobInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // VMRules returns a VMRuleInformer.
 func (v *version) VMRules() VMRuleInformer {
 	return &vMRuleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// VMRuleTests returns a VMRuleTestInformer.
+func (v *version) VMRuleTests() VMRuleTestInformer {
+	return &vMRuleTestInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // VMScrapeConfigs returns a VMScrapeConfigInformer.
 func (v *version) VMScrapeConfigs() VMScrapeConfigInformer {
 	return &vMScrapeConfigInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -143,6 +194,11 @@ func (v *version) VMStaticScrapes() VMStaticScrapeInformer {
 	return &vMStaticScrapeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// VMTenants returns a VMTenantInformer.
+func (v *version) VMTenants() VMTenantInformer {
+	return &vMTenantInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // VMUsers returns a VMUserInformer.
 func (v *version) VMUsers() VMUserInformer {
 	return &vMUserInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -0,0 +1,89 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by informer-gen-v0.30. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"context"
+	time "time"
+
+	internalinterfaces "github.com/VictoriaMetrics/operator/api/client/informers/externalversions/internalinterfaces"
+	v1beta1 "github.com/VictoriaMetrics/operator/api/client/listers/operator/v1beta1"
+	versioned "github.com/VictoriaMetrics/operator/api/client/versioned"
+	operatorv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// VMAnomalyInformer provides access to a shared informer and lister for
+// VMAnomalies.
+type VMAnomalyInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1beta1.VMAnomalyLister
+}
+
+type vMAnomalyInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewVMAnomalyInformer constructs a new informer for VMAnomaly type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewVMAnomalyInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredVMAnomalyInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredVMAnomalyInformer constructs a new informer for VMAnomaly type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredVMAnomalyInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.OperatorV1beta1().VMAnomalies(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.OperatorV1beta1().VMAnomalies(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&operatorv1beta1.VMAnomaly{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *vMAnomalyInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredVMAnomalyInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *vMAnomalyInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&operatorv1beta1.VMAnomaly{}, f.defaultInformer)
+}
+
+func (f *vMAnomalyInformer) Lister() v1beta1.VMAnomalyLister {
+	return v1beta1.NewVMAnomalyLister(f.Informer().GetIndexer())
+}
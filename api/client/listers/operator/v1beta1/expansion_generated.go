@@ -17,6 +17,14 @@ limitations under the License.
 
 package v1beta1
 
+// VLClusterListerExpansion allows custom methods to be added to
+// VLClusterLister.
+type VLClusterListerExpansion interface{}
+
+// VLClusterNamespaceListerExpansion allows custom methods to be added to
+// VLClusterNamespaceLister.
+type VLClusterNamespaceListerExpansion interface{}
+
 // VLogsListerExpansion allows custom methods to be added to
 // VLogsLister.
 type VLogsListerExpansion interface{}
@@ -57,6 +65,22 @@ type VMAlertmanagerConfigListerExpansion interface{}
 // VMAlertmanagerConfigNamespaceLister.
 type VMAlertmanagerConfigNamespaceListerExpansion interface{}
 
+// VMAlertmanagerSilenceListerExpansion allows custom methods to be added to
+// VMAlertmanagerSilenceLister.
+type VMAlertmanagerSilenceListerExpansion interface{}
+
+// VMAlertmanagerSilenceNamespaceListerExpansion allows custom methods to be added to
+// VMAlertmanagerSilenceNamespaceLister.
+type VMAlertmanagerSilenceNamespaceListerExpansion interface{}
+
+// VMAnomalyListerExpansion allows custom methods to be added to
+// VMAnomalyLister.
+type VMAnomalyListerExpansion interface{}
+
+// VMAnomalyNamespaceListerExpansion allows custom methods to be added to
+// VMAnomalyNamespaceLister.
+type VMAnomalyNamespaceListerExpansion interface{}
+
 // VMAuthListerExpansion allows custom methods to be added to
 // VMAuthLister.
 type VMAuthListerExpansion interface{}
@@ -65,6 +89,22 @@ type VMAuthListerExpansion interface{}
 // VMAuthNamespaceLister.
 type VMAuthNamespaceListerExpansion interface{}
 
+// VMBackupJobListerExpansion allows custom methods to be added to
+// VMBackupJobLister.
+type VMBackupJobListerExpansion interface{}
+
+// VMBackupJobNamespaceListerExpansion allows custom methods to be added to
+// VMBackupJobNamespaceLister.
+type VMBackupJobNamespaceListerExpansion interface{}
+
+// VMBlackboxExporterListerExpansion allows custom methods to be added to
+// VMBlackboxExporterLister.
+type VMBlackboxExporterListerExpansion interface{}
+
+// VMBlackboxExporterNamespaceListerExpansion allows custom methods to be added to
+// VMBlackboxExporterNamespaceLister.
+type VMBlackboxExporterNamespaceListerExpansion interface{}
+
 // VMClusterListerExpansion allows custom methods to be added to
 // VMClusterLister.
 type VMClusterListerExpansion interface{}
@@ -97,6 +137,14 @@ type VMProbeListerExpansion interface{}
 // VMProbeNamespaceLister.
 type VMProbeNamespaceListerExpansion interface{}
 
+// VMRestoreJobListerExpansion allows custom methods to be added to
+// VMRestoreJobLister.
+type VMRestoreJobListerExpansion interface{}
+
+// VMRestoreJobNamespaceListerExpansion allows custom methods to be added to
+// VMRestoreJobNamespaceLister.
+type VMRestoreJobNamespaceListerExpansion interface{}
+
 // VMRuleListerExpansion allows custom methods to be added to
 // VMRuleLister.
 type VMRuleListerExpansion interface{}
@@ -105,6 +153,14 @@ type VMRuleListerExpansion interface{}
 // VMRuleNamespaceLister.
 type VMRuleNamespaceListerExpansion interface{}
 
+// VMRuleTestListerExpansion allows custom methods to be added to
+// VMRuleTestLister.
+type VMRuleTestListerExpansion interface{}
+
+// VMRuleTestNamespaceListerExpansion allows custom methods to be added to
+// VMRuleTestNamespaceLister.
+type VMRuleTestNamespaceListerExpansion interface{}
+
 // VMScrapeConfigListerExpansion allows custom methods to be added to
 // VMScrapeConfigLister.
 type VMScrapeConfigListerExpansion interface{}
@@ -137,6 +193,14 @@ type VMStaticScrapeListerExpansion interface{}
 // VMStaticScrapeNamespaceLister.
 type VMStaticScrapeNamespaceListerExpansion interface{}
 
+// VMTenantListerExpansion allows custom methods to be added to
+// VMTenantLister.
+type VMTenantListerExpansion interface{}
+
+// VMTenantNamespaceListerExpansion allows custom methods to be added to
+// VMTenantNamespaceLister.
+type VMTenantNamespaceListerExpansion interface{}
+
 // VMUserListerExpansion allows custom methods to be added to
 // VMUserLister.
 type VMUserListerExpansion interface{}
@@ -0,0 +1,98 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen-v0.30. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// VLClusterLister helps list VLClusters.
+// All objects returned here must be treated as read-only.
+type VLClusterLister interface {
+	// List lists all VLClusters in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.VLCluster, err error)
+	// VLClusters returns an object that can list and get VLClusters.
+	VLClusters(namespace string) VLClusterNamespaceLister
+	VLClusterListerExpansion
+}
+
+// vLClusterLister implements the VLClusterLister interface.
+type vLClusterLister struct {
+	indexer cache.Indexer
+}
+
+// NewVLClusterLister returns a new VLClusterLister.
+func NewVLClusterLister(indexer cache.Indexer) VLClusterLister {
+	return &vLClusterLister{indexer: indexer}
+}
+
+// List lists all VLClusters in the indexer.
+func (s *vLClusterLister) List(selector labels.Selector) (ret []*v1beta1.VLCluster, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.VLCluster))
+	})
+	return ret, err
+}
+
+// VLClusters returns an object that can list and get VLClusters.
+func (s *vLClusterLister) VLClusters(namespace string) VLClusterNamespaceLister {
+	return vLClusterNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// VLClusterNamespaceLister helps list and get VLClusters.
+// All objects returned here must be treated as read-only.
+type VLClusterNamespaceLister interface {
+	// List lists all VLClusters in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.VLCluster, err error)
+	// Get retrieves the VLCluster from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1beta1.VLCluster, error)
+	VLClusterNamespaceListerExpansion
+}
+
+// vLClusterNamespaceLister implements the VLClusterNamespaceLister
+// interface.
+type vLClusterNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all VLClusters in the indexer for a given namespace.
+func (s vLClusterNamespaceLister) List(selector labels.Selector) (ret []*v1beta1.VLCluster, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.VLCluster))
+	})
+	return ret, err
+}
+
+// Get retrieves the VLCluster from the indexer for a given namespace and name.
+func (s vLClusterNamespaceLister) Get(name string) (*v1beta1.VLCluster, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1beta1.Resource("vlcluster"), name)
+	}
+	return obj.(*v1beta1.VLCluster), nil
+}
@@ -0,0 +1,98 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen-v0.30. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// VMAlertmanagerSilenceLister helps list VMAlertmanagerSilences.
+// All objects returned here must be treated as read-only.
+type VMAlertmanagerSilenceLister interface {
+	// List lists all VMAlertmanagerSilences in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.VMAlertmanagerSilence, err error)
+	// VMAlertmanagerSilences returns an object that can list and get VMAlertmanagerSilences.
+	VMAlertmanagerSilences(namespace string) VMAlertmanagerSilenceNamespaceLister
+	VMAlertmanagerSilenceListerExpansion
+}
+
+// vMAlertmanagerSilenceLister implements the VMAlertmanagerSilenceLister interface.
+type vMAlertmanagerSilenceLister struct {
+	indexer cache.Indexer
+}
+
+// NewVMAlertmanagerSilenceLister returns a new VMAlertmanagerSilenceLister.
+func NewVMAlertmanagerSilenceLister(indexer cache.Indexer) VMAlertmanagerSilenceLister {
+	return &vMAlertmanagerSilenceLister{indexer: indexer}
+}
+
+// List lists all VMAlertmanagerSilences in the indexer.
+func (s *vMAlertmanagerSilenceLister) List(selector labels.Selector) (ret []*v1beta1.VMAlertmanagerSilence, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.VMAlertmanagerSilence))
+	})
+	return ret, err
+}
+
+// VMAlertmanagerSilences returns an object that can list and get VMAlertmanagerSilences.
+func (s *vMAlertmanagerSilenceLister) VMAlertmanagerSilences(namespace string) VMAlertmanagerSilenceNamespaceLister {
+	return vMAlertmanagerSilenceNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// VMAlertmanagerSilenceNamespaceLister helps list and get VMAlertmanagerSilences.
+// All objects returned here must be treated as read-only.
+type VMAlertmanagerSilenceNamespaceLister interface {
+	// List lists all VMAlertmanagerSilences in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.VMAlertmanagerSilence, err error)
+	// Get retrieves the VMAlertmanagerSilence from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1beta1.VMAlertmanagerSilence, error)
+	VMAlertmanagerSilenceNamespaceListerExpansion
+}
+
+// vMAlertmanagerSilenceNamespaceLister implements the VMAlertmanagerSilenceNamespaceLister
+// interface.
+type vMAlertmanagerSilenceNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all VMAlertmanagerSilences in the indexer for a given namespace.
+func (s vMAlertmanagerSilenceNamespaceLister) List(selector labels.Selector) (ret []*v1beta1.VMAlertmanagerSilence, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.VMAlertmanagerSilence))
+	})
+	return ret, err
+}
+
+// Get retrieves the VMAlertmanagerSilence from the indexer for a given namespace and name.
+func (s vMAlertmanagerSilenceNamespaceLister) Get(name string) (*v1beta1.VMAlertmanagerSilence, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1beta1.Resource("vmalertmanagersilence"), name)
+	}
+	return obj.(*v1beta1.VMAlertmanagerSilence), nil
+}
@@ -0,0 +1,98 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen-v0.30. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// VMAnomalyLister helps list VMAnomalies.
+// All objects returned here must be treated as read-only.
+type VMAnomalyLister interface {
+	// List lists all VMAnomalies in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.VMAnomaly, err error)
+	// VMAnomalies returns an object that can list and get VMAnomalies.
+	VMAnomalies(namespace string) VMAnomalyNamespaceLister
+	VMAnomalyListerExpansion
+}
+
+// vMAnomalyLister implements the VMAnomalyLister interface.
+type vMAnomalyLister struct {
+	indexer cache.Indexer
+}
+
+// NewVMAnomalyLister returns a new VMAnomalyLister.
+func NewVMAnomalyLister(indexer cache.Indexer) VMAnomalyLister {
+	return &vMAnomalyLister{indexer: indexer}
+}
+
+// List lists all VMAnomalies in the indexer.
+func (s *vMAnomalyLister) List(selector labels.Selector) (ret []*v1beta1.VMAnomaly, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.VMAnomaly))
+	})
+	return ret, err
+}
+
+// VMAnomalies returns an object that can list and get VMAnomalies.
+func (s *vMAnomalyLister) VMAnomalies(namespace string) VMAnomalyNamespaceLister {
+	return vMAnomalyNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// VMAnomalyNamespaceLister helps list and get VMAnomalies.
+// All objects returned here must be treated as read-only.
+type VMAnomalyNamespaceLister interface {
+	// List lists all VMAnomalies in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.VMAnomaly, err error)
+	// Get retrieves the VMAnomaly from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1beta1.VMAnomaly, error)
+	VMAnomalyNamespaceListerExpansion
+}
+
+// vMAnomalyNamespaceLister implements the VMAnomalyNamespaceLister
+// interface.
+type vMAnomalyNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all VMAnomalies in the indexer for a given namespace.
+func (s vMAnomalyNamespaceLister) List(selector labels.Selector) (ret []*v1beta1.VMAnomaly, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.VMAnomaly))
+	})
+	return ret, err
+}
+
+// Get retrieves the VMAnomaly from the indexer for a given namespace and name.
+func (s vMAnomalyNamespaceLister) Get(name string) (*v1beta1.VMAnomaly, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1beta1.Resource("vmanomaly"), name)
+	}
+	return obj.(*v1beta1.VMAnomaly), nil
+}
@@ -0,0 +1,98 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen-v0.30. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// VMBackupJobLister helps list VMBackupJobs.
+// All objects returned here must be treated as read-only.
+type VMBackupJobLister interface {
+	// List lists all VMBackupJobs in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.VMBackupJob, err error)
+	// VMBackupJobs returns an object that can list and get VMBackupJobs.
+	VMBackupJobs(namespace string) VMBackupJobNamespaceLister
+	VMBackupJobListerExpansion
+}
+
+// vMBackupJobLister implements the VMBackupJobLister interface.
+type vMBackupJobLister struct {
+	indexer cache.Indexer
+}
+
+// NewVMBackupJobLister returns a new VMBackupJobLister.
+func NewVMBackupJobLister(indexer cache.Indexer) VMBackupJobLister {
+	return &vMBackupJobLister{indexer: indexer}
+}
+
+// List lists all VMBackupJobs in the indexer.
+func (s *vMBackupJobLister) List(selector labels.Selector) (ret []*v1beta1.VMBackupJob, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.VMBackupJob))
+	})
+	return ret, err
+}
+
+// VMBackupJobs returns an object that can list and get VMBackupJobs.
+func (s *vMBackupJobLister) VMBackupJobs(namespace string) VMBackupJobNamespaceLister {
+	return vMBackupJobNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// VMBackupJobNamespaceLister helps list and get VMBackupJobs.
+// All objects returned here must be treated as read-only.
+type VMBackupJobNamespaceLister interface {
+	// List lists all VMBackupJobs in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.VMBackupJob, err error)
+	// Get retrieves the VMBackupJob from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1beta1.VMBackupJob, error)
+	VMBackupJobNamespaceListerExpansion
+}
+
+// vMBackupJobNamespaceLister implements the VMBackupJobNamespaceLister
+// interface.
+type vMBackupJobNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all VMBackupJobs in the indexer for a given namespace.
+func (s vMBackupJobNamespaceLister) List(selector labels.Selector) (ret []*v1beta1.VMBackupJob, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.VMBackupJob))
+	})
+	return ret, err
+}
+
+// Get retrieves the VMBackupJob from the indexer for a given namespace and name.
+func (s vMBackupJobNamespaceLister) Get(name string) (*v1beta1.VMBackupJob, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1beta1.Resource("vmbackupjob"), name)
+	}
+	return obj.(*v1beta1.VMBackupJob), nil
+}
@@ -0,0 +1,98 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen-v0.30. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// VMBlackboxExporterLister helps list VMBlackboxExporters.
+// All objects returned here must be treated as read-only.
+type VMBlackboxExporterLister interface {
+	// List lists all VMBlackboxExporters in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.VMBlackboxExporter, err error)
+	// VMBlackboxExporters returns an object that can list and get VMBlackboxExporters.
+	VMBlackboxExporters(namespace string) VMBlackboxExporterNamespaceLister
+	VMBlackboxExporterListerExpansion
+}
+
+// vMBlackboxExporterLister implements the VMBlackboxExporterLister interface.
+type vMBlackboxExporterLister struct {
+	indexer cache.Indexer
+}
+
+// NewVMBlackboxExporterLister returns a new VMBlackboxExporterLister.
+func NewVMBlackboxExporterLister(indexer cache.Indexer) VMBlackboxExporterLister {
+	return &vMBlackboxExporterLister{indexer: indexer}
+}
+
+// List lists all VMBlackboxExporters in the indexer.
+func (s *vMBlackboxExporterLister) List(selector labels.Selector) (ret []*v1beta1.VMBlackboxExporter, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.VMBlackboxExporter))
+	})
+	return ret, err
+}
+
+// VMBlackboxExporters returns an object that can list and get VMBlackboxExporters.
+func (s *vMBlackboxExporterLister) VMBlackboxExporters(namespace string) VMBlackboxExporterNamespaceLister {
+	return vMBlackboxExporterNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// VMBlackboxExporterNamespaceLister helps list and get VMBlackboxExporters.
+// All objects returned here must be treated as read-only.
+type VMBlackboxExporterNamespaceLister interface {
+	// List lists all VMBlackboxExporters in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.VMBlackboxExporter, err error)
+	// Get retrieves the VMBlackboxExporter from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1beta1.VMBlackboxExporter, error)
+	VMBlackboxExporterNamespaceListerExpansion
+}
+
+// vMBlackboxExporterNamespaceLister implements the VMBlackboxExporterNamespaceLister
+// interface.
+type vMBlackboxExporterNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all VMBlackboxExporters in the indexer for a given namespace.
+func (s vMBlackboxExporterNamespaceLister) List(selector labels.Selector) (ret []*v1beta1.VMBlackboxExporter, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.VMBlackboxExporter))
+	})
+	return ret, err
+}
+
+// Get retrieves the VMBlackboxExporter from the indexer for a given namespace and name.
+func (s vMBlackboxExporterNamespaceLister) Get(name string) (*v1beta1.VMBlackboxExporter, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1beta1.Resource("vmblackboxexporter"), name)
+	}
+	return obj.(*v1beta1.VMBlackboxExporter), nil
+}
@@ -0,0 +1,98 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen-v0.30. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// VMRestoreJobLister helps list VMRestoreJobs.
+// All objects returned here must be treated as read-only.
+type VMRestoreJobLister interface {
+	// List lists all VMRestoreJobs in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.VMRestoreJob, err error)
+	// VMRestoreJobs returns an object that can list and get VMRestoreJobs.
+	VMRestoreJobs(namespace string) VMRestoreJobNamespaceLister
+	VMRestoreJobListerExpansion
+}
+
+// vMRestoreJobLister implements the VMRestoreJobLister interface.
+type vMRestoreJobLister struct {
+	indexer cache.Indexer
+}
+
+// NewVMRestoreJobLister returns a new VMRestoreJobLister.
+func NewVMRestoreJobLister(indexer cache.Indexer) VMRestoreJobLister {
+	return &vMRestoreJobLister{indexer: indexer}
+}
+
+// List lists all VMRestoreJobs in the indexer.
+func (s *vMRestoreJobLister) List(selector labels.Selector) (ret []*v1beta1.VMRestoreJob, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.VMRestoreJob))
+	})
+	return ret, err
+}
+
+// VMRestoreJobs returns an object that can list and get VMRestoreJobs.
+func (s *vMRestoreJobLister) VMRestoreJobs(namespace string) VMRestoreJobNamespaceLister {
+	return vMRestoreJobNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// VMRestoreJobNamespaceLister helps list and get VMRestoreJobs.
+// All objects returned here must be treated as read-only.
+type VMRestoreJobNamespaceLister interface {
+	// List lists all VMRestoreJobs in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.VMRestoreJob, err error)
+	// Get retrieves the VMRestoreJob from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1beta1.VMRestoreJob, error)
+	VMRestoreJobNamespaceListerExpansion
+}
+
+// vMRestoreJobNamespaceLister implements the VMRestoreJobNamespaceLister
+// interface.
+type vMRestoreJobNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all VMRestoreJobs in the indexer for a given namespace.
+func (s vMRestoreJobNamespaceLister) List(selector labels.Selector) (ret []*v1beta1.VMRestoreJob, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.VMRestoreJob))
+	})
+	return ret, err
+}
+
+// Get retrieves the VMRestoreJob from the indexer for a given namespace and name.
+func (s vMRestoreJobNamespaceLister) Get(name string) (*v1beta1.VMRestoreJob, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1beta1.Resource("vmrestorejob"), name)
+	}
+	return obj.(*v1beta1.VMRestoreJob), nil
+}
@@ -0,0 +1,98 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen-v0.30. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// VMRuleTestLister helps list VMRuleTests.
+// All objects returned here must be treated as read-only.
+type VMRuleTestLister interface {
+	// List lists all VMRuleTests in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.VMRuleTest, err error)
+	// VMRuleTests returns an object that can list and get VMRuleTests.
+	VMRuleTests(namespace string) VMRuleTestNamespaceLister
+	VMRuleTestListerExpansion
+}
+
+// vMRuleTestLister implements the VMRuleTestLister interface.
+type vMRuleTestLister struct {
+	indexer cache.Indexer
+}
+
+// NewVMRuleTestLister returns a new VMRuleTestLister.
+func NewVMRuleTestLister(indexer cache.Indexer) VMRuleTestLister {
+	return &vMRuleTestLister{indexer: indexer}
+}
+
+// List lists all VMRuleTests in the indexer.
+func (s *vMRuleTestLister) List(selector labels.Selector) (ret []*v1beta1.VMRuleTest, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.VMRuleTest))
+	})
+	return ret, err
+}
+
+// VMRuleTests returns an object that can list and get VMRuleTests.
+func (s *vMRuleTestLister) VMRuleTests(namespace string) VMRuleTestNamespaceLister {
+	return vMRuleTestNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// VMRuleTestNamespaceLister helps list and get VMRuleTests.
+// All objects returned here must be treated as read-only.
+type VMRuleTestNamespaceLister interface {
+	// List lists all VMRuleTests in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.VMRuleTest, err error)
+	// Get retrieves the VMRuleTest from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1beta1.VMRuleTest, error)
+	VMRuleTestNamespaceListerExpansion
+}
+
+// vMRuleTestNamespaceLister implements the VMRuleTestNamespaceLister
+// interface.
+type vMRuleTestNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all VMRuleTests in the indexer for a given namespace.
+func (s vMRuleTestNamespaceLister) List(selector labels.Selector) (ret []*v1beta1.VMRuleTest, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.VMRuleTest))
+	})
+	return ret, err
+}
+
+// Get retrieves the VMRuleTest from the indexer for a given namespace and name.
+func (s vMRuleTestNamespaceLister) Get(name string) (*v1beta1.VMRuleTest, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1beta1.Resource("vmruletest"), name)
+	}
+	return obj.(*v1beta1.VMRuleTest), nil
+}
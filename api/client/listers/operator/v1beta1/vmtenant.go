@@ -0,0 +1,98 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen-v0.30. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// VMTenantLister helps list VMTenants.
+// All objects returned here must be treated as read-only.
+type VMTenantLister interface {
+	// List lists all VMTenants in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.VMTenant, err error)
+	// VMTenants returns an object that can list and get VMTenants.
+	VMTenants(namespace string) VMTenantNamespaceLister
+	VMTenantListerExpansion
+}
+
+// vMTenantLister implements the VMTenantLister interface.
+type vMTenantLister struct {
+	indexer cache.Indexer
+}
+
+// NewVMTenantLister returns a new VMTenantLister.
+func NewVMTenantLister(indexer cache.Indexer) VMTenantLister {
+	return &vMTenantLister{indexer: indexer}
+}
+
+// List lists all VMTenants in the indexer.
+func (s *vMTenantLister) List(selector labels.Selector) (ret []*v1beta1.VMTenant, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.VMTenant))
+	})
+	return ret, err
+}
+
+// VMTenants returns an object that can list and get VMTenants.
+func (s *vMTenantLister) VMTenants(namespace string) VMTenantNamespaceLister {
+	return vMTenantNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// VMTenantNamespaceLister helps list and get VMTenants.
+// All objects returned here must be treated as read-only.
+type VMTenantNamespaceLister interface {
+	// List lists all VMTenants in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.VMTenant, err error)
+	// Get retrieves the VMTenant from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1beta1.VMTenant, error)
+	VMTenantNamespaceListerExpansion
+}
+
+// vMTenantNamespaceLister implements the VMTenantNamespaceLister
+// interface.
+type vMTenantNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all VMTenants in the indexer for a given namespace.
+func (s vMTenantNamespaceLister) List(selector labels.Selector) (ret []*v1beta1.VMTenant, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.VMTenant))
+	})
+	return ret, err
+}
+
+// Get retrieves the VMTenant from the indexer for a given namespace and name.
+func (s vMTenantNamespaceLister) Get(name string) (*v1beta1.VMTenant, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1beta1.Resource("vmtenant"), name)
+	}
+	return obj.(*v1beta1.VMTenant), nil
+}
@@ -27,6 +27,10 @@ type FakeOperatorV1beta1 struct {
 	*testing.Fake
 }
 
+func (c *FakeOperatorV1beta1) VLClusters(namespace string) v1beta1.VLClusterInterface {
+	return &FakeVLClusters{c, namespace}
+}
+
 func (c *FakeOperatorV1beta1) VLogs(namespace string) v1beta1.VLogsInterface {
 	return &FakeVLogs{c, namespace}
 }
@@ -47,10 +51,26 @@ func (c *FakeOperatorV1beta1) VMAlertmanagerConfigs(namespace string) v1beta1.VM
 	return &FakeVMAlertmanagerConfigs{c, namespace}
 }
 
+func (c *FakeOperatorV1beta1) VMAlertmanagerSilences(namespace string) v1beta1.VMAlertmanagerSilenceInterface {
+	return &FakeVMAlertmanagerSilences{c, namespace}
+}
+
+func (c *FakeOperatorV1beta1) VMAnomalies(namespace string) v1beta1.VMAnomalyInterface {
+	return &FakeVMAnomalies{c, namespace}
+}
+
 func (c *FakeOperatorV1beta1) VMAuths(namespace string) v1beta1.VMAuthInterface {
 	return &FakeVMAuths{c, namespace}
 }
 
+func (c *FakeOperatorV1beta1) VMBackupJobs(namespace string) v1beta1.VMBackupJobInterface {
+	return &FakeVMBackupJobs{c, namespace}
+}
+
+func (c *FakeOperatorV1beta1) VMBlackboxExporters(namespace string) v1beta1.VMBlackboxExporterInterface {
+	return &FakeVMBlackboxExporters{c, namespace}
+}
+
 func (c *FakeOperatorV1beta1) VMClusters(namespace string) v1beta1.VMClusterInterface {
 	return &FakeVMClusters{c, namespace}
 }
@@ -67,10 +87,18 @@ func (c *FakeOperatorV1beta1) VMProbes(namespace string) v1beta1.VMProbeInterfac
 	return &FakeVMProbes{c, namespace}
 }
 
+func (c *FakeOperatorV1beta1) VMRestoreJobs(namespace string) v1beta1.VMRestoreJobInterface {
+	return &FakeVMRestoreJobs{c, namespace}
+}
+
 func (c *FakeOperatorV1beta1) VMRules(namespace string) v1beta1.VMRuleInterface {
 	return &FakeVMRules{c, namespace}
 }
 
+func (c *FakeOperatorV1beta1) VMRuleTests(namespace string) v1beta1.VMRuleTestInterface {
+	return &FakeVMRuleTests{c, namespace}
+}
+
 func (c *FakeOperatorV1beta1) VMScrapeConfigs(namespace string) v1beta1.VMScrapeConfigInterface {
 	return &FakeVMScrapeConfigs{c, namespace}
 }
@@ -87,6 +115,10 @@ func (c *FakeOperatorV1beta1) VMStaticScrapes(namespace string) v1beta1.VMStatic
 	return &FakeVMStaticScrapes{c, namespace}
 }
 
+func (c *FakeOperatorV1beta1) VMTenants(namespace string) v1beta1.VMTenantInterface {
+	return &FakeVMTenants{c, namespace}
+}
+
 func (c *FakeOperatorV1beta1) VMUsers(namespace string) v1beta1.VMUserInterface {
 	return &FakeVMUsers{c, namespace}
 }
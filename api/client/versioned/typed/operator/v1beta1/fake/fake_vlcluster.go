@@ -0,0 +1,140 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen-v0.30. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeVLClusters implements VLClusterInterface
+type FakeVLClusters struct {
+	Fake *FakeOperatorV1beta1
+	ns   string
+}
+
+var vlclustersResource = v1beta1.SchemeGroupVersion.WithResource("vlclusters")
+
+var vlclustersKind = v1beta1.SchemeGroupVersion.WithKind("VLCluster")
+
+// Get takes name of the vLCluster, and returns the corresponding vLCluster object, and an error if there is any.
+func (c *FakeVLClusters) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.VLCluster, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(vlclustersResource, c.ns, name), &v1beta1.VLCluster{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VLCluster), err
+}
+
+// List takes label and field selectors, and returns the list of VLClusters that match those selectors.
+func (c *FakeVLClusters) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.VLClusterList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(vlclustersResource, vlclustersKind, c.ns, opts), &v1beta1.VLClusterList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.VLClusterList{ListMeta: obj.(*v1beta1.VLClusterList).ListMeta}
+	for _, item := range obj.(*v1beta1.VLClusterList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested vLClusters.
+func (c *FakeVLClusters) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(vlclustersResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a vLCluster and creates it.  Returns the server's representation of the vLCluster, and an error, if there is any.
+func (c *FakeVLClusters) Create(ctx context.Context, vLCluster *v1beta1.VLCluster, opts v1.CreateOptions) (result *v1beta1.VLCluster, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(vlclustersResource, c.ns, vLCluster), &v1beta1.VLCluster{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VLCluster), err
+}
+
+// Update takes the representation of a vLCluster and updates it. Returns the server's representation of the vLCluster, and an error, if there is any.
+func (c *FakeVLClusters) Update(ctx context.Context, vLCluster *v1beta1.VLCluster, opts v1.UpdateOptions) (result *v1beta1.VLCluster, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(vlclustersResource, c.ns, vLCluster), &v1beta1.VLCluster{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VLCluster), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeVLClusters) UpdateStatus(ctx context.Context, vLCluster *v1beta1.VLCluster, opts v1.UpdateOptions) (*v1beta1.VLCluster, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(vlclustersResource, "status", c.ns, vLCluster), &v1beta1.VLCluster{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VLCluster), err
+}
+
+// Delete takes name of the vLCluster and deletes it. Returns an error if one occurs.
+func (c *FakeVLClusters) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(vlclustersResource, c.ns, name, opts), &v1beta1.VLCluster{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeVLClusters) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(vlclustersResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.VLClusterList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched vLCluster.
+func (c *FakeVLClusters) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.VLCluster, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(vlclustersResource, c.ns, name, pt, data, subresources...), &v1beta1.VLCluster{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VLCluster), err
+}
@@ -0,0 +1,140 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen-v0.30. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeVMAlertmanagerSilences implements VMAlertmanagerSilenceInterface
+type FakeVMAlertmanagerSilences struct {
+	Fake *FakeOperatorV1beta1
+	ns   string
+}
+
+var vmalertmanagersilencesResource = v1beta1.SchemeGroupVersion.WithResource("vmalertmanagersilences")
+
+var vmalertmanagersilencesKind = v1beta1.SchemeGroupVersion.WithKind("VMAlertmanagerSilence")
+
+// Get takes name of the vMAlertmanagerSilence, and returns the corresponding vMAlertmanagerSilence object, and an error if there is any.
+func (c *FakeVMAlertmanagerSilences) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.VMAlertmanagerSilence, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(vmalertmanagersilencesResource, c.ns, name), &v1beta1.VMAlertmanagerSilence{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMAlertmanagerSilence), err
+}
+
+// List takes label and field selectors, and returns the list of VMAlertmanagerSilences that match those selectors.
+func (c *FakeVMAlertmanagerSilences) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.VMAlertmanagerSilenceList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(vmalertmanagersilencesResource, vmalertmanagersilencesKind, c.ns, opts), &v1beta1.VMAlertmanagerSilenceList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.VMAlertmanagerSilenceList{ListMeta: obj.(*v1beta1.VMAlertmanagerSilenceList).ListMeta}
+	for _, item := range obj.(*v1beta1.VMAlertmanagerSilenceList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested vMAlertmanagerSilences.
+func (c *FakeVMAlertmanagerSilences) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(vmalertmanagersilencesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a vMAlertmanagerSilence and creates it.  Returns the server's representation of the vMAlertmanagerSilence, and an error, if there is any.
+func (c *FakeVMAlertmanagerSilences) Create(ctx context.Context, vMAlertmanagerSilence *v1beta1.VMAlertmanagerSilence, opts v1.CreateOptions) (result *v1beta1.VMAlertmanagerSilence, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(vmalertmanagersilencesResource, c.ns, vMAlertmanagerSilence), &v1beta1.VMAlertmanagerSilence{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMAlertmanagerSilence), err
+}
+
+// Update takes the representation of a vMAlertmanagerSilence and updates it. Returns the server's representation of the vMAlertmanagerSilence, and an error, if there is any.
+func (c *FakeVMAlertmanagerSilences) Update(ctx context.Context, vMAlertmanagerSilence *v1beta1.VMAlertmanagerSilence, opts v1.UpdateOptions) (result *v1beta1.VMAlertmanagerSilence, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(vmalertmanagersilencesResource, c.ns, vMAlertmanagerSilence), &v1beta1.VMAlertmanagerSilence{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMAlertmanagerSilence), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeVMAlertmanagerSilences) UpdateStatus(ctx context.Context, vMAlertmanagerSilence *v1beta1.VMAlertmanagerSilence, opts v1.UpdateOptions) (*v1beta1.VMAlertmanagerSilence, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(vmalertmanagersilencesResource, "status", c.ns, vMAlertmanagerSilence), &v1beta1.VMAlertmanagerSilence{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMAlertmanagerSilence), err
+}
+
+// Delete takes name of the vMAlertmanagerSilence and deletes it. Returns an error if one occurs.
+func (c *FakeVMAlertmanagerSilences) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(vmalertmanagersilencesResource, c.ns, name, opts), &v1beta1.VMAlertmanagerSilence{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeVMAlertmanagerSilences) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(vmalertmanagersilencesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.VMAlertmanagerSilenceList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched vMAlertmanagerSilence.
+func (c *FakeVMAlertmanagerSilences) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.VMAlertmanagerSilence, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(vmalertmanagersilencesResource, c.ns, name, pt, data, subresources...), &v1beta1.VMAlertmanagerSilence{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMAlertmanagerSilence), err
+}
@@ -0,0 +1,140 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen-v0.30. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeVMAnomalies implements VMAnomalyInterface
+type FakeVMAnomalies struct {
+	Fake *FakeOperatorV1beta1
+	ns   string
+}
+
+var vmanomaliesResource = v1beta1.SchemeGroupVersion.WithResource("vmanomalies")
+
+var vmanomaliesKind = v1beta1.SchemeGroupVersion.WithKind("VMAnomaly")
+
+// Get takes name of the vMAnomaly, and returns the corresponding vMAnomaly object, and an error if there is any.
+func (c *FakeVMAnomalies) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.VMAnomaly, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(vmanomaliesResource, c.ns, name), &v1beta1.VMAnomaly{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMAnomaly), err
+}
+
+// List takes label and field selectors, and returns the list of VMAnomalies that match those selectors.
+func (c *FakeVMAnomalies) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.VMAnomalyList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(vmanomaliesResource, vmanomaliesKind, c.ns, opts), &v1beta1.VMAnomalyList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.VMAnomalyList{ListMeta: obj.(*v1beta1.VMAnomalyList).ListMeta}
+	for _, item := range obj.(*v1beta1.VMAnomalyList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested vMAnomalies.
+func (c *FakeVMAnomalies) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(vmanomaliesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a vMAnomaly and creates it.  Returns the server's representation of the vMAnomaly, and an error, if there is any.
+func (c *FakeVMAnomalies) Create(ctx context.Context, vMAnomaly *v1beta1.VMAnomaly, opts v1.CreateOptions) (result *v1beta1.VMAnomaly, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(vmanomaliesResource, c.ns, vMAnomaly), &v1beta1.VMAnomaly{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMAnomaly), err
+}
+
+// Update takes the representation of a vMAnomaly and updates it. Returns the server's representation of the vMAnomaly, and an error, if there is any.
+func (c *FakeVMAnomalies) Update(ctx context.Context, vMAnomaly *v1beta1.VMAnomaly, opts v1.UpdateOptions) (result *v1beta1.VMAnomaly, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(vmanomaliesResource, c.ns, vMAnomaly), &v1beta1.VMAnomaly{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMAnomaly), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeVMAnomalies) UpdateStatus(ctx context.Context, vMAnomaly *v1beta1.VMAnomaly, opts v1.UpdateOptions) (*v1beta1.VMAnomaly, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(vmanomaliesResource, "status", c.ns, vMAnomaly), &v1beta1.VMAnomaly{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMAnomaly), err
+}
+
+// Delete takes name of the vMAnomaly and deletes it. Returns an error if one occurs.
+func (c *FakeVMAnomalies) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(vmanomaliesResource, c.ns, name, opts), &v1beta1.VMAnomaly{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeVMAnomalies) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(vmanomaliesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.VMAnomalyList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched vMAnomaly.
+func (c *FakeVMAnomalies) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.VMAnomaly, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(vmanomaliesResource, c.ns, name, pt, data, subresources...), &v1beta1.VMAnomaly{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMAnomaly), err
+}
@@ -0,0 +1,140 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen-v0.30. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeVMBackupJobs implements VMBackupJobInterface
+type FakeVMBackupJobs struct {
+	Fake *FakeOperatorV1beta1
+	ns   string
+}
+
+var vmbackupjobsResource = v1beta1.SchemeGroupVersion.WithResource("vmbackupjobs")
+
+var vmbackupjobsKind = v1beta1.SchemeGroupVersion.WithKind("VMBackupJob")
+
+// Get takes name of the vMBackupJob, and returns the corresponding vMBackupJob object, and an error if there is any.
+func (c *FakeVMBackupJobs) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.VMBackupJob, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(vmbackupjobsResource, c.ns, name), &v1beta1.VMBackupJob{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMBackupJob), err
+}
+
+// List takes label and field selectors, and returns the list of VMBackupJobs that match those selectors.
+func (c *FakeVMBackupJobs) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.VMBackupJobList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(vmbackupjobsResource, vmbackupjobsKind, c.ns, opts), &v1beta1.VMBackupJobList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.VMBackupJobList{ListMeta: obj.(*v1beta1.VMBackupJobList).ListMeta}
+	for _, item := range obj.(*v1beta1.VMBackupJobList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested vMBackupJobs.
+func (c *FakeVMBackupJobs) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(vmbackupjobsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a vMBackupJob and creates it.  Returns the server's representation of the vMBackupJob, and an error, if there is any.
+func (c *FakeVMBackupJobs) Create(ctx context.Context, vMBackupJob *v1beta1.VMBackupJob, opts v1.CreateOptions) (result *v1beta1.VMBackupJob, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(vmbackupjobsResource, c.ns, vMBackupJob), &v1beta1.VMBackupJob{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMBackupJob), err
+}
+
+// Update takes the representation of a vMBackupJob and updates it. Returns the server's representation of the vMBackupJob, and an error, if there is any.
+func (c *FakeVMBackupJobs) Update(ctx context.Context, vMBackupJob *v1beta1.VMBackupJob, opts v1.UpdateOptions) (result *v1beta1.VMBackupJob, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(vmbackupjobsResource, c.ns, vMBackupJob), &v1beta1.VMBackupJob{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMBackupJob), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeVMBackupJobs) UpdateStatus(ctx context.Context, vMBackupJob *v1beta1.VMBackupJob, opts v1.UpdateOptions) (*v1beta1.VMBackupJob, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(vmbackupjobsResource, "status", c.ns, vMBackupJob), &v1beta1.VMBackupJob{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMBackupJob), err
+}
+
+// Delete takes name of the vMBackupJob and deletes it. Returns an error if one occurs.
+func (c *FakeVMBackupJobs) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(vmbackupjobsResource, c.ns, name, opts), &v1beta1.VMBackupJob{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeVMBackupJobs) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(vmbackupjobsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.VMBackupJobList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched vMBackupJob.
+func (c *FakeVMBackupJobs) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.VMBackupJob, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(vmbackupjobsResource, c.ns, name, pt, data, subresources...), &v1beta1.VMBackupJob{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMBackupJob), err
+}
@@ -0,0 +1,140 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen-v0.30. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeVMBlackboxExporters implements VMBlackboxExporterInterface
+type FakeVMBlackboxExporters struct {
+	Fake *FakeOperatorV1beta1
+	ns   string
+}
+
+var vmblackboxexportersResource = v1beta1.SchemeGroupVersion.WithResource("vmblackboxexporters")
+
+var vmblackboxexportersKind = v1beta1.SchemeGroupVersion.WithKind("VMBlackboxExporter")
+
+// Get takes name of the vMBlackboxExporter, and returns the corresponding vMBlackboxExporter object, and an error if there is any.
+func (c *FakeVMBlackboxExporters) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.VMBlackboxExporter, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(vmblackboxexportersResource, c.ns, name), &v1beta1.VMBlackboxExporter{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMBlackboxExporter), err
+}
+
+// List takes label and field selectors, and returns the list of VMBlackboxExporters that match those selectors.
+func (c *FakeVMBlackboxExporters) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.VMBlackboxExporterList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(vmblackboxexportersResource, vmblackboxexportersKind, c.ns, opts), &v1beta1.VMBlackboxExporterList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.VMBlackboxExporterList{ListMeta: obj.(*v1beta1.VMBlackboxExporterList).ListMeta}
+	for _, item := range obj.(*v1beta1.VMBlackboxExporterList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested vMBlackboxExporters.
+func (c *FakeVMBlackboxExporters) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(vmblackboxexportersResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a vMBlackboxExporter and creates it.  Returns the server's representation of the vMBlackboxExporter, and an error, if there is any.
+func (c *FakeVMBlackboxExporters) Create(ctx context.Context, vMBlackboxExporter *v1beta1.VMBlackboxExporter, opts v1.CreateOptions) (result *v1beta1.VMBlackboxExporter, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(vmblackboxexportersResource, c.ns, vMBlackboxExporter), &v1beta1.VMBlackboxExporter{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMBlackboxExporter), err
+}
+
+// Update takes the representation of a vMBlackboxExporter and updates it. Returns the server's representation of the vMBlackboxExporter, and an error, if there is any.
+func (c *FakeVMBlackboxExporters) Update(ctx context.Context, vMBlackboxExporter *v1beta1.VMBlackboxExporter, opts v1.UpdateOptions) (result *v1beta1.VMBlackboxExporter, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(vmblackboxexportersResource, c.ns, vMBlackboxExporter), &v1beta1.VMBlackboxExporter{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMBlackboxExporter), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeVMBlackboxExporters) UpdateStatus(ctx context.Context, vMBlackboxExporter *v1beta1.VMBlackboxExporter, opts v1.UpdateOptions) (*v1beta1.VMBlackboxExporter, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(vmblackboxexportersResource, "status", c.ns, vMBlackboxExporter), &v1beta1.VMBlackboxExporter{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMBlackboxExporter), err
+}
+
+// Delete takes name of the vMBlackboxExporter and deletes it. Returns an error if one occurs.
+func (c *FakeVMBlackboxExporters) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(vmblackboxexportersResource, c.ns, name, opts), &v1beta1.VMBlackboxExporter{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeVMBlackboxExporters) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(vmblackboxexportersResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.VMBlackboxExporterList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched vMBlackboxExporter.
+func (c *FakeVMBlackboxExporters) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.VMBlackboxExporter, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(vmblackboxexportersResource, c.ns, name, pt, data, subresources...), &v1beta1.VMBlackboxExporter{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMBlackboxExporter), err
+}
@@ -0,0 +1,140 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen-v0.30. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeVMRestoreJobs implements VMRestoreJobInterface
+type FakeVMRestoreJobs struct {
+	Fake *FakeOperatorV1beta1
+	ns   string
+}
+
+var vmrestorejobsResource = v1beta1.SchemeGroupVersion.WithResource("vmrestorejobs")
+
+var vmrestorejobsKind = v1beta1.SchemeGroupVersion.WithKind("VMRestoreJob")
+
+// Get takes name of the vMRestoreJob, and returns the corresponding vMRestoreJob object, and an error if there is any.
+func (c *FakeVMRestoreJobs) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.VMRestoreJob, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(vmrestorejobsResource, c.ns, name), &v1beta1.VMRestoreJob{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMRestoreJob), err
+}
+
+// List takes label and field selectors, and returns the list of VMRestoreJobs that match those selectors.
+func (c *FakeVMRestoreJobs) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.VMRestoreJobList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(vmrestorejobsResource, vmrestorejobsKind, c.ns, opts), &v1beta1.VMRestoreJobList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.VMRestoreJobList{ListMeta: obj.(*v1beta1.VMRestoreJobList).ListMeta}
+	for _, item := range obj.(*v1beta1.VMRestoreJobList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested vMRestoreJobs.
+func (c *FakeVMRestoreJobs) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(vmrestorejobsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a vMRestoreJob and creates it.  Returns the server's representation of the vMRestoreJob, and an error, if there is any.
+func (c *FakeVMRestoreJobs) Create(ctx context.Context, vMRestoreJob *v1beta1.VMRestoreJob, opts v1.CreateOptions) (result *v1beta1.VMRestoreJob, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(vmrestorejobsResource, c.ns, vMRestoreJob), &v1beta1.VMRestoreJob{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMRestoreJob), err
+}
+
+// Update takes the representation of a vMRestoreJob and updates it. Returns the server's representation of the vMRestoreJob, and an error, if there is any.
+func (c *FakeVMRestoreJobs) Update(ctx context.Context, vMRestoreJob *v1beta1.VMRestoreJob, opts v1.UpdateOptions) (result *v1beta1.VMRestoreJob, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(vmrestorejobsResource, c.ns, vMRestoreJob), &v1beta1.VMRestoreJob{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMRestoreJob), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeVMRestoreJobs) UpdateStatus(ctx context.Context, vMRestoreJob *v1beta1.VMRestoreJob, opts v1.UpdateOptions) (*v1beta1.VMRestoreJob, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(vmrestorejobsResource, "status", c.ns, vMRestoreJob), &v1beta1.VMRestoreJob{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMRestoreJob), err
+}
+
+// Delete takes name of the vMRestoreJob and deletes it. Returns an error if one occurs.
+func (c *FakeVMRestoreJobs) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(vmrestorejobsResource, c.ns, name, opts), &v1beta1.VMRestoreJob{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeVMRestoreJobs) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(vmrestorejobsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.VMRestoreJobList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched vMRestoreJob.
+func (c *FakeVMRestoreJobs) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.VMRestoreJob, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(vmrestorejobsResource, c.ns, name, pt, data, subresources...), &v1beta1.VMRestoreJob{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMRestoreJob), err
+}
@@ -0,0 +1,140 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen-v0.30. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeVMRuleTests implements VMRuleTestInterface
+type FakeVMRuleTests struct {
+	Fake *FakeOperatorV1beta1
+	ns   string
+}
+
+var vmruletestsResource = v1beta1.SchemeGroupVersion.WithResource("vmruletests")
+
+var vmruletestsKind = v1beta1.SchemeGroupVersion.WithKind("VMRuleTest")
+
+// Get takes name of the vMRuleTest, and returns the corresponding vMRuleTest object, and an error if there is any.
+func (c *FakeVMRuleTests) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.VMRuleTest, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(vmruletestsResource, c.ns, name), &v1beta1.VMRuleTest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMRuleTest), err
+}
+
+// List takes label and field selectors, and returns the list of VMRuleTests that match those selectors.
+func (c *FakeVMRuleTests) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.VMRuleTestList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(vmruletestsResource, vmruletestsKind, c.ns, opts), &v1beta1.VMRuleTestList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.VMRuleTestList{ListMeta: obj.(*v1beta1.VMRuleTestList).ListMeta}
+	for _, item := range obj.(*v1beta1.VMRuleTestList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested vMRuleTests.
+func (c *FakeVMRuleTests) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(vmruletestsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a vMRuleTest and creates it.  Returns the server's representation of the vMRuleTest, and an error, if there is any.
+func (c *FakeVMRuleTests) Create(ctx context.Context, vMRuleTest *v1beta1.VMRuleTest, opts v1.CreateOptions) (result *v1beta1.VMRuleTest, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(vmruletestsResource, c.ns, vMRuleTest), &v1beta1.VMRuleTest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMRuleTest), err
+}
+
+// Update takes the representation of a vMRuleTest and updates it. Returns the server's representation of the vMRuleTest, and an error, if there is any.
+func (c *FakeVMRuleTests) Update(ctx context.Context, vMRuleTest *v1beta1.VMRuleTest, opts v1.UpdateOptions) (result *v1beta1.VMRuleTest, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(vmruletestsResource, c.ns, vMRuleTest), &v1beta1.VMRuleTest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMRuleTest), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeVMRuleTests) UpdateStatus(ctx context.Context, vMRuleTest *v1beta1.VMRuleTest, opts v1.UpdateOptions) (*v1beta1.VMRuleTest, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(vmruletestsResource, "status", c.ns, vMRuleTest), &v1beta1.VMRuleTest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMRuleTest), err
+}
+
+// Delete takes name of the vMRuleTest and deletes it. Returns an error if one occurs.
+func (c *FakeVMRuleTests) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(vmruletestsResource, c.ns, name, opts), &v1beta1.VMRuleTest{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeVMRuleTests) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(vmruletestsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.VMRuleTestList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched vMRuleTest.
+func (c *FakeVMRuleTests) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.VMRuleTest, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(vmruletestsResource, c.ns, name, pt, data, subresources...), &v1beta1.VMRuleTest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMRuleTest), err
+}
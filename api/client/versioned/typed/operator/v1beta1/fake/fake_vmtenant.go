@@ -0,0 +1,140 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen-v0.30. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeVMTenants implements VMTenantInterface
+type FakeVMTenants struct {
+	Fake *FakeOperatorV1beta1
+	ns   string
+}
+
+var vmtenantsResource = v1beta1.SchemeGroupVersion.WithResource("vmtenants")
+
+var vmtenantsKind = v1beta1.SchemeGroupVersion.WithKind("VMTenant")
+
+// Get takes name of the vMTenant, and returns the corresponding vMTenant object, and an error if there is any.
+func (c *FakeVMTenants) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.VMTenant, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(vmtenantsResource, c.ns, name), &v1beta1.VMTenant{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMTenant), err
+}
+
+// List takes label and field selectors, and returns the list of VMTenants that match those selectors.
+func (c *FakeVMTenants) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.VMTenantList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(vmtenantsResource, vmtenantsKind, c.ns, opts), &v1beta1.VMTenantList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.VMTenantList{ListMeta: obj.(*v1beta1.VMTenantList).ListMeta}
+	for _, item := range obj.(*v1beta1.VMTenantList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested vMTenants.
+func (c *FakeVMTenants) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(vmtenantsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a vMTenant and creates it.  Returns the server's representation of the vMTenant, and an error, if there is any.
+func (c *FakeVMTenants) Create(ctx context.Context, vMTenant *v1beta1.VMTenant, opts v1.CreateOptions) (result *v1beta1.VMTenant, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(vmtenantsResource, c.ns, vMTenant), &v1beta1.VMTenant{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMTenant), err
+}
+
+// Update takes the representation of a vMTenant and updates it. Returns the server's representation of the vMTenant, and an error, if there is any.
+func (c *FakeVMTenants) Update(ctx context.Context, vMTenant *v1beta1.VMTenant, opts v1.UpdateOptions) (result *v1beta1.VMTenant, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(vmtenantsResource, c.ns, vMTenant), &v1beta1.VMTenant{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMTenant), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeVMTenants) UpdateStatus(ctx context.Context, vMTenant *v1beta1.VMTenant, opts v1.UpdateOptions) (*v1beta1.VMTenant, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(vmtenantsResource, "status", c.ns, vMTenant), &v1beta1.VMTenant{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMTenant), err
+}
+
+// Delete takes name of the vMTenant and deletes it. Returns an error if one occurs.
+func (c *FakeVMTenants) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(vmtenantsResource, c.ns, name, opts), &v1beta1.VMTenant{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeVMTenants) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(vmtenantsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.VMTenantList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched vMTenant.
+func (c *FakeVMTenants) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.VMTenant, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(vmtenantsResource, c.ns, name, pt, data, subresources...), &v1beta1.VMTenant{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VMTenant), err
+}
@@ -17,6 +17,8 @@ limitations under the License.
 
 package v1beta1
 
+type VLClusterExpansion interface{}
+
 type VLogsExpansion interface{}
 
 type VMAgentExpansion interface{}
@@ -27,8 +29,16 @@ type VMAlertmanagerExpansion interface{}
 
 type VMAlertmanagerConfigExpansion interface{}
 
+type VMAlertmanagerSilenceExpansion interface{}
+
+type VMAnomalyExpansion interface{}
+
 type VMAuthExpansion interface{}
 
+type VMBackupJobExpansion interface{}
+
+type VMBlackboxExporterExpansion interface{}
+
 type VMClusterExpansion interface{}
 
 type VMNodeScrapeExpansion interface{}
@@ -37,8 +47,12 @@ type VMPodScrapeExpansion interface{}
 
 type VMProbeExpansion interface{}
 
+type VMRestoreJobExpansion interface{}
+
 type VMRuleExpansion interface{}
 
+type VMRuleTestExpansion interface{}
+
 type VMScrapeConfigExpansion interface{}
 
 type VMServiceScrapeExpansion interface{}
@@ -47,4 +61,6 @@ type VMSingleExpansion interface{}
 
 type VMStaticScrapeExpansion interface{}
 
+type VMTenantExpansion interface{}
+
 type VMUserExpansion interface{}
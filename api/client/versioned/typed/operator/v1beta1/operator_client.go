@@ -27,21 +27,29 @@ import (
 
 type OperatorV1beta1Interface interface {
 	RESTClient() rest.Interface
+	VLClustersGetter
 	VLogsGetter
 	VMAgentsGetter
 	VMAlertsGetter
 	VMAlertmanagersGetter
 	VMAlertmanagerConfigsGetter
+	VMAlertmanagerSilencesGetter
+	VMAnomaliesGetter
 	VMAuthsGetter
+	VMBackupJobsGetter
+	VMBlackboxExportersGetter
 	VMClustersGetter
 	VMNodeScrapesGetter
 	VMPodScrapesGetter
 	VMProbesGetter
+	VMRestoreJobsGetter
 	VMRulesGetter
+	VMRuleTestsGetter
 	VMScrapeConfigsGetter
 	VMServiceScrapesGetter
 	VMSinglesGetter
 	VMStaticScrapesGetter
+	VMTenantsGetter
 	VMUsersGetter
 }
 
@@ -50,6 +58,10 @@ type OperatorV1beta1Client struct {
 	restClient rest.Interface
 }
 
+func (c *OperatorV1beta1Client) VLClusters(namespace string) VLClusterInterface {
+	return newVLClusters(c, namespace)
+}
+
 func (c *OperatorV1beta1Client) VLogs(namespace string) VLogsInterface {
 	return newVLogs(c, namespace)
 }
@@ -70,10 +82,26 @@ func (c *OperatorV1beta1Client) VMAlertmanagerConfigs(namespace string) VMAlertm
 	return newVMAlertmanagerConfigs(c, namespace)
 }
 
+func (c *OperatorV1beta1Client) VMAlertmanagerSilences(namespace string) VMAlertmanagerSilenceInterface {
+	return newVMAlertmanagerSilences(c, namespace)
+}
+
+func (c *OperatorV1beta1Client) VMAnomalies(namespace string) VMAnomalyInterface {
+	return newVMAnomalies(c, namespace)
+}
+
 func (c *OperatorV1beta1Client) VMAuths(namespace string) VMAuthInterface {
 	return newVMAuths(c, namespace)
 }
 
+func (c *OperatorV1beta1Client) VMBackupJobs(namespace string) VMBackupJobInterface {
+	return newVMBackupJobs(c, namespace)
+}
+
+func (c *OperatorV1beta1Client) VMBlackboxExporters(namespace string) VMBlackboxExporterInterface {
+	return newVMBlackboxExporters(c, namespace)
+}
+
 func (c *OperatorV1beta1Client) VMClusters(namespace string) VMClusterInterface {
 	return newVMClusters(c, namespace)
 }
@@ -90,10 +118,18 @@ func (c *OperatorV1beta1Client) VMProbes(namespace string) VMProbeInterface {
 	return newVMProbes(c, namespace)
 }
 
+func (c *OperatorV1beta1Client) VMRestoreJobs(namespace string) VMRestoreJobInterface {
+	return newVMRestoreJobs(c, namespace)
+}
+
 func (c *OperatorV1beta1Client) VMRules(namespace string) VMRuleInterface {
 	return newVMRules(c, namespace)
 }
 
+func (c *OperatorV1beta1Client) VMRuleTests(namespace string) VMRuleTestInterface {
+	return newVMRuleTests(c, namespace)
+}
+
 func (c *OperatorV1beta1Client) VMScrapeConfigs(namespace string) VMScrapeConfigInterface {
 	return newVMScrapeConfigs(c, namespace)
 }
@@ -110,6 +146,10 @@ func (c *OperatorV1beta1Client) VMStaticScrapes(namespace string) VMStaticScrape
 	return newVMStaticScrapes(c, namespace)
 }
 
+func (c *OperatorV1beta1Client) VMTenants(namespace string) VMTenantInterface {
+	return newVMTenants(c, namespace)
+}
+
 func (c *OperatorV1beta1Client) VMUsers(namespace string) VMUserInterface {
 	return newVMUsers(c, namespace)
 }
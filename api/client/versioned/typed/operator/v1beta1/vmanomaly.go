@@ -0,0 +1,194 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen-v0.30. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"context"
+	"time"
+
+	scheme "github.com/VictoriaMetrics/operator/api/client/versioned/scheme"
+	v1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// VMAnomaliesGetter has a method to return a VMAnomalyInterface.
+// A group's client should implement this interface.
+type VMAnomaliesGetter interface {
+	VMAnomalies(namespace string) VMAnomalyInterface
+}
+
+// VMAnomalyInterface has methods to work with VMAnomaly resources.
+type VMAnomalyInterface interface {
+	Create(ctx context.Context, vMAnomaly *v1beta1.VMAnomaly, opts v1.CreateOptions) (*v1beta1.VMAnomaly, error)
+	Update(ctx context.Context, vMAnomaly *v1beta1.VMAnomaly, opts v1.UpdateOptions) (*v1beta1.VMAnomaly, error)
+	UpdateStatus(ctx context.Context, vMAnomaly *v1beta1.VMAnomaly, opts v1.UpdateOptions) (*v1beta1.VMAnomaly, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.VMAnomaly, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1beta1.VMAnomalyList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.VMAnomaly, err error)
+	VMAnomalyExpansion
+}
+
+// vMAnomalies implements VMAnomalyInterface
+type vMAnomalies struct {
+	client rest.Interface
+	ns     string
+}
+
+// newVMAnomalies returns a VMAnomalies
+func newVMAnomalies(c *OperatorV1beta1Client, namespace string) *vMAnomalies {
+	return &vMAnomalies{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the vMAnomaly, and returns the corresponding vMAnomaly object, and an error if there is any.
+func (c *vMAnomalies) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.VMAnomaly, err error) {
+	result = &v1beta1.VMAnomaly{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("vmanomalies").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of VMAnomalies that match those selectors.
+func (c *vMAnomalies) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.VMAnomalyList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1beta1.VMAnomalyList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("vmanomalies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested vMAnomalies.
+func (c *vMAnomalies) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("vmanomalies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a vMAnomaly and creates it.  Returns the server's representation of the vMAnomaly, and an error, if there is any.
+func (c *vMAnomalies) Create(ctx context.Context, vMAnomaly *v1beta1.VMAnomaly, opts v1.CreateOptions) (result *v1beta1.VMAnomaly, err error) {
+	result = &v1beta1.VMAnomaly{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("vmanomalies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(vMAnomaly).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a vMAnomaly and updates it. Returns the server's representation of the vMAnomaly, and an error, if there is any.
+func (c *vMAnomalies) Update(ctx context.Context, vMAnomaly *v1beta1.VMAnomaly, opts v1.UpdateOptions) (result *v1beta1.VMAnomaly, err error) {
+	result = &v1beta1.VMAnomaly{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("vmanomalies").
+		Name(vMAnomaly.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(vMAnomaly).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *vMAnomalies) UpdateStatus(ctx context.Context, vMAnomaly *v1beta1.VMAnomaly, opts v1.UpdateOptions) (result *v1beta1.VMAnomaly, err error) {
+	result = &v1beta1.VMAnomaly{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("vmanomalies").
+		Name(vMAnomaly.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(vMAnomaly).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the vMAnomaly and deletes it. Returns an error if one occurs.
+func (c *vMAnomalies) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("vmanomalies").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *vMAnomalies) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("vmanomalies").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched vMAnomaly.
+func (c *vMAnomalies) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.VMAnomaly, err error) {
+	result = &v1beta1.VMAnomaly{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("vmanomalies").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
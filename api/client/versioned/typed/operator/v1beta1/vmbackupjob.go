@@ -0,0 +1,194 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen-v0.30. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"context"
+	"time"
+
+	scheme "github.com/VictoriaMetrics/operator/api/client/versioned/scheme"
+	v1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// VMBackupJobsGetter has a method to return a VMBackupJobInterface.
+// A group's client should implement this interface.
+type VMBackupJobsGetter interface {
+	VMBackupJobs(namespace string) VMBackupJobInterface
+}
+
+// VMBackupJobInterface has methods to work with VMBackupJob resources.
+type VMBackupJobInterface interface {
+	Create(ctx context.Context, vMBackupJob *v1beta1.VMBackupJob, opts v1.CreateOptions) (*v1beta1.VMBackupJob, error)
+	Update(ctx context.Context, vMBackupJob *v1beta1.VMBackupJob, opts v1.UpdateOptions) (*v1beta1.VMBackupJob, error)
+	UpdateStatus(ctx context.Context, vMBackupJob *v1beta1.VMBackupJob, opts v1.UpdateOptions) (*v1beta1.VMBackupJob, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.VMBackupJob, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1beta1.VMBackupJobList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.VMBackupJob, err error)
+	VMBackupJobExpansion
+}
+
+// vMBackupJobs implements VMBackupJobInterface
+type vMBackupJobs struct {
+	client rest.Interface
+	ns     string
+}
+
+// newVMBackupJobs returns a VMBackupJobs
+func newVMBackupJobs(c *OperatorV1beta1Client, namespace string) *vMBackupJobs {
+	return &vMBackupJobs{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the vMBackupJob, and returns the corresponding vMBackupJob object, and an error if there is any.
+func (c *vMBackupJobs) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.VMBackupJob, err error) {
+	result = &v1beta1.VMBackupJob{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("vmbackupjobs").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of VMBackupJobs that match those selectors.
+func (c *vMBackupJobs) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.VMBackupJobList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1beta1.VMBackupJobList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("vmbackupjobs").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested vMBackupJobs.
+func (c *vMBackupJobs) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("vmbackupjobs").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a vMBackupJob and creates it.  Returns the server's representation of the vMBackupJob, and an error, if there is any.
+func (c *vMBackupJobs) Create(ctx context.Context, vMBackupJob *v1beta1.VMBackupJob, opts v1.CreateOptions) (result *v1beta1.VMBackupJob, err error) {
+	result = &v1beta1.VMBackupJob{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("vmbackupjobs").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(vMBackupJob).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a vMBackupJob and updates it. Returns the server's representation of the vMBackupJob, and an error, if there is any.
+func (c *vMBackupJobs) Update(ctx context.Context, vMBackupJob *v1beta1.VMBackupJob, opts v1.UpdateOptions) (result *v1beta1.VMBackupJob, err error) {
+	result = &v1beta1.VMBackupJob{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("vmbackupjobs").
+		Name(vMBackupJob.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(vMBackupJob).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *vMBackupJobs) UpdateStatus(ctx context.Context, vMBackupJob *v1beta1.VMBackupJob, opts v1.UpdateOptions) (result *v1beta1.VMBackupJob, err error) {
+	result = &v1beta1.VMBackupJob{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("vmbackupjobs").
+		Name(vMBackupJob.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(vMBackupJob).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the vMBackupJob and deletes it. Returns an error if one occurs.
+func (c *vMBackupJobs) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("vmbackupjobs").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *vMBackupJobs) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("vmbackupjobs").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched vMBackupJob.
+func (c *vMBackupJobs) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.VMBackupJob, err error) {
+	result = &v1beta1.VMBackupJob{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("vmbackupjobs").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,746 @@
+package v1beta1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VLClusterSpec defines the desired state of VLCluster
+// +k8s:openapi-gen=true
+type VLClusterSpec struct {
+	// ParsingError contents error with context if operator was failed to parse json object from kubernetes api server
+	ParsingError string `json:"-" yaml:"-"`
+
+	// ServiceAccountName is the name of the ServiceAccount to use to run the
+	// VLSelect, VLStorage and VLInsert Pods.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// ClusterVersion defines default images tag for all components.
+	// it can be overwritten with component specific image.tag value.
+	// +optional
+	ClusterVersion string `json:"clusterVersion,omitempty"`
+	// ClusterDomainName defines domain name suffix for in-cluster dns addresses
+	// aka .cluster.local
+	// used by vlinsert and vlselect to build vlstorage address
+	// +optional
+	ClusterDomainName string `json:"clusterDomainName,omitempty"`
+
+	// ImagePullSecrets An optional list of references to secrets in the same namespace
+	// to use for pulling images from registries
+	// see https://kubernetes.io/docs/concepts/containers/images/#referring-to-an-imagepullsecrets-on-a-pod
+	// +optional
+	ImagePullSecrets []v1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// +optional
+	VLInsert *VLInsert `json:"vlinsert,omitempty"`
+	// +optional
+	VLSelect *VLSelect `json:"vlselect,omitempty"`
+	// +optional
+	VLStorage *VLStorage `json:"vlstorage,omitempty"`
+	// Paused If set to true all actions on the underlying managed objects are not
+	// going to be performed, except for delete actions.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+	// UseStrictSecurity enables strict security mode for component
+	// it restricts disk writes access
+	// uses non-root user out of the box
+	// drops not needed security permissions
+	// +optional
+	UseStrictSecurity *bool `json:"useStrictSecurity,omitempty"`
+
+	// RequestsLoadBalancer configures load-balancing for vlinsert and vlselect requests
+	// it helps to evenly spread load across pods
+	// usually it's not possible with kubernetes TCP based service
+	RequestsLoadBalancer VMAuthLoadBalancer `json:"requestsLoadBalancer,omitempty"`
+}
+
+// VLInsert defines configuration for vlinsert component of VLCluster
+type VLInsert struct {
+	// PodMetadata configures Labels and Annotations which are propagated to the VLInsert pods.
+	PodMetadata *EmbeddedObjectMetadata `json:"podMetadata,omitempty"`
+	// LogFormat for VLInsert to be configured with.
+	// default or json
+	// +optional
+	// +kubebuilder:validation:Enum=default;json
+	LogFormat string `json:"logFormat,omitempty"`
+	// LogLevel for VLInsert to be configured with.
+	// +optional
+	// +kubebuilder:validation:Enum=INFO;WARN;ERROR;FATAL;PANIC
+	LogLevel string `json:"logLevel,omitempty"`
+
+	// ServiceSpec that will be added to vlinsert service spec
+	// +optional
+	ServiceSpec *AdditionalServiceSpec `json:"serviceSpec,omitempty"`
+	// ServiceScrapeSpec that will be added to vlinsert VMServiceScrape spec
+	// +optional
+	ServiceScrapeSpec *VMServiceScrapeSpec `json:"serviceScrapeSpec,omitempty"`
+
+	// UpdateStrategy - overrides default update strategy.
+	// +kubebuilder:validation:Enum=Recreate;RollingUpdate
+	// +optional
+	UpdateStrategy *appsv1.DeploymentStrategyType `json:"updateStrategy,omitempty"`
+	// RollingUpdate - overrides deployment update params.
+	// +optional
+	RollingUpdate *appsv1.RollingUpdateDeployment `json:"rollingUpdate,omitempty"`
+	// PodDisruptionBudget created by operator
+	// +optional
+	PodDisruptionBudget *EmbeddedPodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+	*EmbeddedProbes     `json:",inline"`
+	// HPA defines kubernetes PodAutoScaling configuration version 2.
+	// +optional
+	HPA *EmbeddedHPA `json:"hpa,omitempty"`
+
+	CommonDefaultableParams           `json:",inline"`
+	CommonApplicationDeploymentParams `json:",inline"`
+}
+
+// VLSelect defines configuration for vlselect component of VLCluster
+type VLSelect struct {
+	// PodMetadata configures Labels and Annotations which are propagated to the VLSelect pods.
+	PodMetadata *EmbeddedObjectMetadata `json:"podMetadata,omitempty"`
+	// LogFormat for VLSelect to be configured with.
+	// default or json
+	// +optional
+	// +kubebuilder:validation:Enum=default;json
+	LogFormat string `json:"logFormat,omitempty"`
+	// LogLevel for VLSelect to be configured with.
+	// +optional
+	// +kubebuilder:validation:Enum=INFO;WARN;ERROR;FATAL;PANIC
+	LogLevel string `json:"logLevel,omitempty"`
+
+	// ServiceSpec that will be added to vlselect service spec
+	// +optional
+	ServiceSpec *AdditionalServiceSpec `json:"serviceSpec,omitempty"`
+	// ServiceScrapeSpec that will be added to vlselect VMServiceScrape spec
+	// +optional
+	ServiceScrapeSpec *VMServiceScrapeSpec `json:"serviceScrapeSpec,omitempty"`
+
+	// UpdateStrategy - overrides default update strategy.
+	// +kubebuilder:validation:Enum=Recreate;RollingUpdate
+	// +optional
+	UpdateStrategy *appsv1.DeploymentStrategyType `json:"updateStrategy,omitempty"`
+	// RollingUpdate - overrides deployment update params.
+	// +optional
+	RollingUpdate *appsv1.RollingUpdateDeployment `json:"rollingUpdate,omitempty"`
+	// PodDisruptionBudget created by operator
+	// +optional
+	PodDisruptionBudget *EmbeddedPodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+	*EmbeddedProbes     `json:",inline"`
+	// HPA defines kubernetes PodAutoScaling configuration version 2.
+	// +optional
+	HPA *EmbeddedHPA `json:"hpa,omitempty"`
+
+	CommonDefaultableParams           `json:",inline"`
+	CommonApplicationDeploymentParams `json:",inline"`
+}
+
+// VLStorage defines configuration for vlstorage component of VLCluster
+type VLStorage struct {
+	// PodMetadata configures Labels and Annotations which are propagated to the VLStorage pods.
+	PodMetadata *EmbeddedObjectMetadata `json:"podMetadata,omitempty"`
+	// LogFormat for VLStorage to be configured with.
+	// default or json
+	// +optional
+	// +kubebuilder:validation:Enum=default;json
+	LogFormat string `json:"logFormat,omitempty"`
+	// LogLevel for VLStorage to be configured with.
+	// +optional
+	// +kubebuilder:validation:Enum=INFO;WARN;ERROR;FATAL;PANIC
+	LogLevel string `json:"logLevel,omitempty"`
+	// RetentionPeriod for the stored logs
+	// https://docs.victoriametrics.com/victorialogs/#retention
+	// +optional
+	RetentionPeriod string `json:"retentionPeriod,omitempty"`
+	// FutureRetention for the stored logs
+	// Log entries with timestamps bigger than now+futureRetention are rejected during data ingestion; see https://docs.victoriametrics.com/victorialogs/#retention
+	// +optional
+	FutureRetention string `json:"futureRetention,omitempty"`
+	// LogNewStreams Whether to log creation of new streams; this can be useful for debugging of high cardinality issues with log streams; see https://docs.victoriametrics.com/victorialogs/keyconcepts/#stream-fields
+	// +optional
+	LogNewStreams bool `json:"logNewStreams,omitempty"`
+	// Whether to log all the ingested log entries; this can be useful for debugging of data ingestion; see https://docs.victoriametrics.com/victorialogs/data-ingestion/
+	// +optional
+	LogIngestedRows bool `json:"logIngestedRows,omitempty"`
+
+	// StorageDataPath - path to storage data
+	// +optional
+	StorageDataPath string `json:"storageDataPath,omitempty"`
+	// Storage configures persistent volume for StorageDataPath
+	// +optional
+	Storage *StorageSpec `json:"storage,omitempty"`
+
+	// ServiceSpec that will be create additional service for vlstorage
+	// +optional
+	ServiceSpec *AdditionalServiceSpec `json:"serviceSpec,omitempty"`
+	// ServiceScrapeSpec that will be added to vlstorage VMServiceScrape spec
+	// +optional
+	ServiceScrapeSpec *VMServiceScrapeSpec `json:"serviceScrapeSpec,omitempty"`
+	// PodDisruptionBudget created by operator
+	// +optional
+	PodDisruptionBudget *EmbeddedPodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+	*EmbeddedProbes     `json:",inline"`
+	// MaintenanceInsertNodeIDs - excludes given node ids from insert requests routing, must contain pod suffixes - for pod-0, id will be 0 and etc.
+	// lets say, you have pod-0, pod-1, pod-2, pod-3. to exclude pod-0 and pod-3 from insert routing, define nodeIDs: [0,3].
+	// +optional
+	MaintenanceInsertNodeIDs []int32 `json:"maintenanceInsertNodeIDs,omitempty"`
+	// MaintenanceSelectNodeIDs - excludes given node ids from select requests routing, must contain pod suffixes - for pod-0, id will be 0 and etc.
+	// +optional
+	MaintenanceSelectNodeIDs []int32 `json:"maintenanceSelectNodeIDs,omitempty"`
+
+	// RollingUpdateStrategy defines strategy for application updates
+	// Default is OnDelete, in this case operator handles update process
+	// Can be changed for RollingUpdate
+	// +optional
+	RollingUpdateStrategy appsv1.StatefulSetUpdateStrategyType `json:"rollingUpdateStrategy,omitempty"`
+
+	// ClaimTemplates allows adding additional VolumeClaimTemplates for StatefulSet
+	// +optional
+	ClaimTemplates []v1.PersistentVolumeClaim `json:"claimTemplates,omitempty"`
+
+	CommonDefaultableParams           `json:",inline"`
+	CommonApplicationDeploymentParams `json:",inline"`
+}
+
+// VLClusterStatus defines the observed state of VLCluster
+type VLClusterStatus struct {
+	// UpdateStatus defines a status of vlcluster instance rollout
+	UpdateStatus UpdateStatus `json:"updateStatus,omitempty"`
+	// Reason defines a reason in case of update failure
+	Reason string `json:"reason,omitempty"`
+	// ObservedGeneration defines current generation picked by operator for the
+	// reconcile
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions defines latest available observations of object state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// VLCluster is fast, cost-effective and scalable logs database.
+// Cluster version with separately scalable vlinsert, vlselect and vlstorage components.
+// +operator-sdk:gen-csv:customresourcedefinitions.displayName="VLCluster App"
+// +operator-sdk:gen-csv:customresourcedefinitions.resources="Deployment,apps"
+// +operator-sdk:gen-csv:customresourcedefinitions.resources="Statefulset,apps"
+// +operator-sdk:gen-csv:customresourcedefinitions.resources="Service,v1"
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=vlclusters,scope=Namespaced
+// +kubebuilder:printcolumn:name="Insert Count",type="string",JSONPath=".spec.vlinsert.replicaCount",description="replicas of VLInsert"
+// +kubebuilder:printcolumn:name="Storage Count",type="string",JSONPath=".spec.vlstorage.replicaCount",description="replicas of VLStorage"
+// +kubebuilder:printcolumn:name="Select Count",type="string",JSONPath=".spec.vlselect.replicaCount",description="replicas of VLSelect"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus",description="Current status of cluster"
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VLCluster struct {
+	// +optional
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              VLClusterSpec `json:"spec"`
+	// ParsedLastAppliedSpec contains last-applied configuration spec
+	ParsedLastAppliedSpec *VLClusterSpec `json:"-" yaml:"-"`
+	// +optional
+	Status VLClusterStatus `json:"status,omitempty"`
+}
+
+// VLClusterList contains a list of VLCluster
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VLClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VLCluster `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VLCluster{}, &VLClusterList{})
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (cr *VLCluster) UnmarshalJSON(src []byte) error {
+	type pcr VLCluster
+	if err := json.Unmarshal(src, (*pcr)(cr)); err != nil {
+		return err
+	}
+	prev, err := parseLastAppliedSpec[VLClusterSpec](cr)
+	if err != nil {
+		return err
+	}
+	cr.ParsedLastAppliedSpec = prev
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (cr *VLClusterSpec) UnmarshalJSON(src []byte) error {
+	type pcr VLClusterSpec
+	if err := json.Unmarshal(src, (*pcr)(cr)); err != nil {
+		cr.ParsingError = fmt.Sprintf("cannot parse vlcluster spec: %s, err: %s", string(src), err)
+		return nil
+	}
+	return nil
+}
+
+func (cr *VLCluster) AsOwner() []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			APIVersion:         cr.APIVersion,
+			Kind:               cr.Kind,
+			Name:               cr.Name,
+			UID:                cr.UID,
+			Controller:         ptr.To(true),
+			BlockOwnerDeletion: ptr.To(true),
+		},
+	}
+}
+
+// VMAuthLBSelectorLabels defines selector labels for vmauth balancer
+func (cr VLCluster) VMAuthLBSelectorLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":      "vlclusterlb-vmauth-balancer",
+		"app.kubernetes.io/instance":  cr.Name,
+		"app.kubernetes.io/component": "monitoring",
+		"managed-by":                  "vm-operator",
+	}
+}
+
+// GetVMAuthLBName returns prefixed name for the loadbalanacer components
+func (cr VLCluster) GetVMAuthLBName() string {
+	return fmt.Sprintf("vlclusterlb-%s", cr.Name)
+}
+
+// GetInsertName returns vlinsert component name
+func (cr VLCluster) GetInsertName() string {
+	return PrefixedName(cr.Name, "vlinsert")
+}
+
+// GetSelectName returns vlselect component name
+func (cr VLCluster) GetSelectName() string {
+	return PrefixedName(cr.Name, "vlselect")
+}
+
+// GetStorageName returns vlstorage component name
+func (cr VLCluster) GetStorageName() string {
+	return PrefixedName(cr.Name, "vlstorage")
+}
+
+// GetInsertLBName returns headless proxy service name for insert component
+func (cr VLCluster) GetInsertLBName() string {
+	return PrefixedName(cr.Name, "vlinsertinternal")
+}
+
+// GetSelectLBName returns headless proxy service name for select component
+func (cr VLCluster) GetSelectLBName() string {
+	return PrefixedName(cr.Name, "vlselectinternal")
+}
+
+func (cr VLCluster) VLSelectSelectorLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":      "vlselect",
+		"app.kubernetes.io/instance":  cr.Name,
+		"app.kubernetes.io/component": "monitoring",
+		"managed-by":                  "vm-operator",
+	}
+}
+
+func (cr VLCluster) VLSelectPodLabels() map[string]string {
+	selectorLabels := cr.VLSelectSelectorLabels()
+	if cr.Spec.VLSelect == nil || cr.Spec.VLSelect.PodMetadata == nil {
+		return selectorLabels
+	}
+	return labels.Merge(cr.Spec.VLSelect.PodMetadata.Labels, selectorLabels)
+}
+
+func (cr VLCluster) VLInsertSelectorLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":      "vlinsert",
+		"app.kubernetes.io/instance":  cr.Name,
+		"app.kubernetes.io/component": "monitoring",
+		"managed-by":                  "vm-operator",
+	}
+}
+
+func (cr VLCluster) VLInsertPodLabels() map[string]string {
+	selectorLabels := cr.VLInsertSelectorLabels()
+	if cr.Spec.VLInsert == nil || cr.Spec.VLInsert.PodMetadata == nil {
+		return selectorLabels
+	}
+	return labels.Merge(cr.Spec.VLInsert.PodMetadata.Labels, selectorLabels)
+}
+
+func (cr VLCluster) VLStorageSelectorLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":      "vlstorage",
+		"app.kubernetes.io/instance":  cr.Name,
+		"app.kubernetes.io/component": "monitoring",
+		"managed-by":                  "vm-operator",
+	}
+}
+
+func (cr VLCluster) VLStoragePodLabels() map[string]string {
+	selectorLabels := cr.VLStorageSelectorLabels()
+	if cr.Spec.VLStorage == nil || cr.Spec.VLStorage.PodMetadata == nil {
+		return selectorLabels
+	}
+	return labels.Merge(cr.Spec.VLStorage.PodMetadata.Labels, selectorLabels)
+}
+
+func (cr VLCluster) VLSelectPodAnnotations() map[string]string {
+	if cr.Spec.VLSelect == nil || cr.Spec.VLSelect.PodMetadata == nil {
+		return make(map[string]string)
+	}
+	return cr.Spec.VLSelect.PodMetadata.Annotations
+}
+
+func (cr VLCluster) VLInsertPodAnnotations() map[string]string {
+	if cr.Spec.VLInsert == nil || cr.Spec.VLInsert.PodMetadata == nil {
+		return make(map[string]string)
+	}
+	return cr.Spec.VLInsert.PodMetadata.Annotations
+}
+
+func (cr VLCluster) VLStoragePodAnnotations() map[string]string {
+	if cr.Spec.VLStorage == nil || cr.Spec.VLStorage.PodMetadata == nil {
+		return make(map[string]string)
+	}
+	return cr.Spec.VLStorage.PodMetadata.Annotations
+}
+
+// AvailableStorageNodeIDs returns ids of the storage nodes for the provided component
+func (cr VLCluster) AvailableStorageNodeIDs(requestsType string) []int32 {
+	var result []int32
+	if cr.Spec.VLStorage == nil || cr.Spec.VLStorage.ReplicaCount == nil {
+		return result
+	}
+	maintenanceNodes := make(map[int32]struct{})
+	switch requestsType {
+	case "select":
+		for _, i := range cr.Spec.VLStorage.MaintenanceSelectNodeIDs {
+			maintenanceNodes[i] = struct{}{}
+		}
+	case "insert":
+		for _, i := range cr.Spec.VLStorage.MaintenanceInsertNodeIDs {
+			maintenanceNodes[i] = struct{}{}
+		}
+	default:
+		panic("BUG unsupported requestsType: " + requestsType)
+	}
+	for i := int32(0); i < *cr.Spec.VLStorage.ReplicaCount; i++ {
+		if _, ok := maintenanceNodes[i]; ok {
+			continue
+		}
+		result = append(result, i)
+	}
+	return result
+}
+
+// FinalLabels adds cluster labels to the base labels and filters by prefix if needed
+func (cr VLCluster) FinalLabels(baseLabels map[string]string) map[string]string {
+	if cr.ObjectMeta.Labels == nil {
+		return baseLabels
+	}
+	crLabels := filterMapKeysByPrefixes(cr.ObjectMeta.Labels, labelFilterPrefixes)
+	return labels.Merge(crLabels, baseLabels)
+}
+
+func (cr VLCluster) AnnotationsFiltered() map[string]string {
+	return filterMapKeysByPrefixes(cr.ObjectMeta.Annotations, annotationFilterPrefixes)
+}
+
+// LastAppliedSpecAsPatch return last applied cluster spec as patch annotation
+func (cr *VLCluster) LastAppliedSpecAsPatch() (client.Patch, error) {
+	data, err := json.Marshal(cr.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("possible bug, cannot serialize cluster specification as json :%w", err)
+	}
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q: %q}}}`, lastAppliedSpecAnnotationName, data)
+	return client.RawPatch(types.MergePatchType, []byte(patch)), nil
+}
+
+// HasSpecChanges compares cluster spec with last applied cluster spec stored in annotation
+func (cr *VLCluster) HasSpecChanges() (bool, error) {
+	lastAppliedClusterJSON := cr.Annotations[lastAppliedSpecAnnotationName]
+	if len(lastAppliedClusterJSON) == 0 {
+		return true, nil
+	}
+
+	instanceSpecData, err := json.Marshal(cr.Spec)
+	if err != nil {
+		return true, err
+	}
+	return !bytes.Equal([]byte(lastAppliedClusterJSON), instanceSpecData), nil
+}
+
+func (cr *VLCluster) Paused() bool {
+	return cr.Spec.Paused
+}
+
+// SetStatusTo changes update status with optional reason of fail
+func (cr *VLCluster) SetUpdateStatusTo(ctx context.Context, r client.Client, status UpdateStatus, maybeErr error) error {
+	currentStatus := cr.Status.UpdateStatus
+	prevStatus := cr.Status.DeepCopy()
+	switch status {
+	case UpdateStatusExpanding:
+	case UpdateStatusFailed:
+		if maybeErr != nil {
+			cr.Status.Reason = maybeErr.Error()
+		}
+	case UpdateStatusOperational:
+		cr.Status.Reason = ""
+	case UpdateStatusPaused:
+		if currentStatus == status {
+			return nil
+		}
+	default:
+		panic(fmt.Sprintf("BUG: not expected status=%q", status))
+	}
+	cr.Status.ObservedGeneration = cr.Generation
+	cr.Status.Conditions = updateConditions(cr.Status.Conditions, cr.Generation, status, maybeErr)
+
+	if equality.Semantic.DeepEqual(&cr.Status, prevStatus) && currentStatus == status {
+		return nil
+	}
+	cr.Status.UpdateStatus = status
+	return statusPatch(ctx, r, cr.DeepCopy(), cr.Status)
+}
+
+func (cr VLCluster) GetServiceAccountName() string {
+	if cr.Spec.ServiceAccountName == "" {
+		return cr.PrefixedName()
+	}
+	return cr.Spec.ServiceAccountName
+}
+
+func (cr VLCluster) IsOwnsServiceAccount() bool {
+	return cr.Spec.ServiceAccountName == ""
+}
+
+func (cr VLCluster) PrefixedName() string {
+	return fmt.Sprintf("vlcluster-%s", cr.Name)
+}
+
+func (cr VLCluster) SelectorLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":      "vlcluster",
+		"app.kubernetes.io/instance":  cr.Name,
+		"app.kubernetes.io/component": "monitoring",
+		"managed-by":                  "vm-operator",
+	}
+}
+
+// AllLabels defines global CR labels
+func (cr VLCluster) AllLabels() map[string]string {
+	selectorLabels := cr.SelectorLabels()
+	// fast path
+	if cr.ObjectMeta.Labels == nil {
+		return selectorLabels
+	}
+	crLabels := filterMapKeysByPrefixes(cr.ObjectMeta.Labels, labelFilterPrefixes)
+	return labels.Merge(crLabels, selectorLabels)
+}
+
+func (cr VLCluster) GetNSName() string {
+	return cr.GetNamespace()
+}
+
+// AsURL implements stub for interface.
+func (cr *VLCluster) AsURL() string {
+	return "unknown"
+}
+
+// VLSelectURL returns url to access vlselect component
+func (cr *VLCluster) VLSelectURL() string {
+	if cr.Spec.VLSelect == nil {
+		return ""
+	}
+	port := cr.Spec.VLSelect.Port
+	if port == "" {
+		port = "9471"
+	}
+	if cr.Spec.VLSelect.ServiceSpec != nil && cr.Spec.VLSelect.ServiceSpec.UseAsDefault {
+		for _, svcPort := range cr.Spec.VLSelect.ServiceSpec.Spec.Ports {
+			if svcPort.Name == "http" {
+				port = fmt.Sprintf("%d", svcPort.Port)
+			}
+		}
+	}
+	return fmt.Sprintf("%s://%s.%s.svc:%s", protoFromFlags(cr.Spec.VLSelect.ExtraArgs), cr.GetSelectName(), cr.Namespace, port)
+}
+
+// VLInsertURL returns url to access vlinsert component
+func (cr *VLCluster) VLInsertURL() string {
+	if cr.Spec.VLInsert == nil {
+		return ""
+	}
+	port := cr.Spec.VLInsert.Port
+	if port == "" {
+		port = "9481"
+	}
+	if cr.Spec.VLInsert.ServiceSpec != nil && cr.Spec.VLInsert.ServiceSpec.UseAsDefault {
+		for _, svcPort := range cr.Spec.VLInsert.ServiceSpec.Spec.Ports {
+			if svcPort.Name == "http" {
+				port = fmt.Sprintf("%d", svcPort.Port)
+			}
+		}
+	}
+	return fmt.Sprintf("%s://%s.%s.svc:%s", protoFromFlags(cr.Spec.VLInsert.ExtraArgs), cr.GetInsertName(), cr.Namespace, port)
+}
+
+func (s VLStorage) BuildPodName(baseName string, podIndex int32, namespace, portName, domain string) string {
+	// The default DNS search path is .svc.<cluster domain>
+	if domain == "" {
+		return fmt.Sprintf("%s-%d.%s.%s:%s,", baseName, podIndex, baseName, namespace, portName)
+	}
+	return fmt.Sprintf("%s-%d.%s.%s.svc.%s:%s,", baseName, podIndex, baseName, namespace, domain, portName)
+}
+
+func (s VLStorage) GetStorageVolumeName() string {
+	if s.Storage != nil && s.Storage.VolumeClaimTemplate.Name != "" {
+		return s.Storage.VolumeClaimTemplate.Name
+	}
+	return "vlstorage-db"
+}
+
+func (cr *VLInsert) Probe() *EmbeddedProbes {
+	return cr.EmbeddedProbes
+}
+
+func (cr *VLInsert) ProbePath() string {
+	return buildPathWithPrefixFlag(cr.ExtraArgs, healthPath)
+}
+
+func (cr *VLInsert) ProbeScheme() string {
+	return strings.ToUpper(protoFromFlags(cr.ExtraArgs))
+}
+
+func (cr *VLInsert) ProbePort() string {
+	return cr.Port
+}
+
+func (cr *VLInsert) ProbeNeedLiveness() bool {
+	return true
+}
+
+// GetMetricPath returns prefixed path for metric requests
+func (cr *VLInsert) GetMetricPath() string {
+	if cr == nil {
+		return healthPath
+	}
+	return buildPathWithPrefixFlag(cr.ExtraArgs, metricPath)
+}
+
+// GetExtraArgs returns additionally configured command-line arguments
+func (cr *VLInsert) GetExtraArgs() map[string]string {
+	return cr.ExtraArgs
+}
+
+// GetServiceScrape returns overrides for serviceScrape builder
+func (cr *VLInsert) GetServiceScrape() *VMServiceScrapeSpec {
+	return cr.ServiceScrapeSpec
+}
+
+// GetAdditionalService returns AdditionalServiceSpec settings
+func (cr *VLInsert) GetAdditionalService() *AdditionalServiceSpec {
+	return cr.ServiceSpec
+}
+
+func (cr *VLSelect) Probe() *EmbeddedProbes {
+	return cr.EmbeddedProbes
+}
+
+func (cr *VLSelect) ProbePath() string {
+	return buildPathWithPrefixFlag(cr.ExtraArgs, healthPath)
+}
+
+func (cr *VLSelect) ProbeScheme() string {
+	return strings.ToUpper(protoFromFlags(cr.ExtraArgs))
+}
+
+func (cr *VLSelect) ProbePort() string {
+	return cr.Port
+}
+
+func (cr *VLSelect) ProbeNeedLiveness() bool {
+	return true
+}
+
+// GetMetricPath returns prefixed path for metric requests
+func (cr *VLSelect) GetMetricPath() string {
+	if cr == nil {
+		return healthPath
+	}
+	return buildPathWithPrefixFlag(cr.ExtraArgs, metricPath)
+}
+
+// GetExtraArgs returns additionally configured command-line arguments
+func (cr *VLSelect) GetExtraArgs() map[string]string {
+	return cr.ExtraArgs
+}
+
+// GetServiceScrape returns overrides for serviceScrape builder
+func (cr *VLSelect) GetServiceScrape() *VMServiceScrapeSpec {
+	return cr.ServiceScrapeSpec
+}
+
+// GetAdditionalService returns AdditionalServiceSpec settings
+func (cr *VLSelect) GetAdditionalService() *AdditionalServiceSpec {
+	return cr.ServiceSpec
+}
+
+func (cr *VLStorage) Probe() *EmbeddedProbes {
+	return cr.EmbeddedProbes
+}
+
+func (cr *VLStorage) ProbePath() string {
+	return buildPathWithPrefixFlag(cr.ExtraArgs, healthPath)
+}
+
+func (cr *VLStorage) ProbeScheme() string {
+	return strings.ToUpper(protoFromFlags(cr.ExtraArgs))
+}
+
+func (cr *VLStorage) ProbePort() string {
+	return cr.Port
+}
+
+// ProbeNeedLiveness implements build.probeCRD interface
+func (cr *VLStorage) ProbeNeedLiveness() bool {
+	return false
+}
+
+// GetMetricPath returns prefixed path for metric requests
+func (cr *VLStorage) GetMetricPath() string {
+	if cr == nil {
+		return healthPath
+	}
+	return buildPathWithPrefixFlag(cr.ExtraArgs, metricPath)
+}
+
+// GetExtraArgs returns additionally configured command-line arguments
+func (cr *VLStorage) GetExtraArgs() map[string]string {
+	return cr.ExtraArgs
+}
+
+// GetServiceScrape returns overrides for serviceScrape builder
+func (cr *VLStorage) GetServiceScrape() *VMServiceScrapeSpec {
+	return cr.ServiceScrapeSpec
+}
+
+// GetAdditionalService returns AdditionalServiceSpec settings
+func (cr *VLStorage) GetAdditionalService() *AdditionalServiceSpec {
+	return cr.ServiceSpec
+}
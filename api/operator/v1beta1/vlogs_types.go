@@ -84,6 +84,9 @@ type VLogsSpec struct {
 	// ServiceSpec that will be added to vlogs service spec
 	// +optional
 	ServiceSpec *AdditionalServiceSpec `json:"serviceSpec,omitempty"`
+	// HTTPRoute enables Gateway API route configuration for VLogs.
+	// +optional
+	HTTPRoute *EmbeddedHTTPRoute `json:"httpRoute,omitempty"`
 	// ServiceScrapeSpec that will be added to vlogs VMServiceScrape spec
 	// +optional
 	ServiceScrapeSpec *VMServiceScrapeSpec `json:"serviceScrapeSpec,omitempty"`
@@ -110,6 +113,14 @@ type VLogsStatus struct {
 	UpdateStatus UpdateStatus `json:"status,omitempty"`
 	// Reason defines a reason in case of update failure
 	Reason string `json:"reason,omitempty"`
+	// ObservedGeneration defines current generation picked by operator for the
+	// reconcile
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions defines latest available observations of object state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // VLogs is fast, cost-effective and scalable logs database.
@@ -344,6 +355,9 @@ func (r *VLogs) SetUpdateStatusTo(ctx context.Context, c client.Client, status U
 	default:
 		panic(fmt.Sprintf("BUG: not expected status=%q", status))
 	}
+	r.Status.ObservedGeneration = r.Generation
+	r.Status.Conditions = updateConditions(r.Status.Conditions, r.Generation, status, maybeErr)
+
 	if equality.Semantic.DeepEqual(&r.Status, prevStatus) && currentStatus == status {
 		return nil
 	}
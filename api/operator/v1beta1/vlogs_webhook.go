@@ -40,6 +40,16 @@ func (r *VLogs) SetupWebhookWithManager(mgr ctrl.Manager) error {
 var _ webhook.Validator = &VLogs{}
 
 func (r *VLogs) sanityCheck() error {
+	if r.Spec.HTTPRoute != nil {
+		if err := r.Spec.HTTPRoute.Validate(); err != nil {
+			return fmt.Errorf("incorrect spec.httpRoute: %w", err)
+		}
+	}
+	if r.Spec.VPA != nil {
+		if err := r.Spec.VPA.sanityCheck(); err != nil {
+			return fmt.Errorf("incorrect spec.vpa: %w", err)
+		}
+	}
 	return nil
 }
 
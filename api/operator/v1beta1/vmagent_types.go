@@ -230,6 +230,11 @@ type VMAgentSpec struct {
 	// see [here](https://docs.victoriametrics.com/vmagent/#scraping-big-number-of-targets)
 	// +optional
 	ShardCount *int `json:"shardCount,omitempty"`
+	// ShardAutoscaling enables automatic adjustment of shards count
+	// based on the number of active scrape targets and scraped series per shard.
+	// If set, shardCount is used as initial number of shards.
+	// +optional
+	ShardAutoscaling *VMAgentShardAutoscaling `json:"shardAutoscaling,omitempty"`
 
 	// UpdateStrategy - overrides default update strategy.
 	// works only for deployments, statefulset always use OnDelete.
@@ -243,6 +248,9 @@ type VMAgentSpec struct {
 	// +optional
 	PodDisruptionBudget *EmbeddedPodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
 	*EmbeddedProbes     `json:",inline"`
+	// NetworkPolicy enables generation of NetworkPolicy for VMAgent pods.
+	// +optional
+	NetworkPolicy *EmbeddedNetworkPolicy `json:"networkPolicy,omitempty"`
 	// ServiceScrapeRelabelTemplate defines relabel config, that will be added to each VMServiceScrape.
 	// it's useful for adding specific labels to all targets
 	// +optional
@@ -285,6 +293,12 @@ type VMAgentSpec struct {
 	// +optional
 	StatefulRollingUpdateStrategy appsv1.StatefulSetUpdateStrategyType `json:"statefulRollingUpdateStrategy,omitempty"`
 
+	// DaemonSetMode enables DaemonSet for `VMAgent` instead of Deployment
+	// each vmagent pod scrapes only targets located at the same kubernetes node.
+	// Only VMPodScrape, VMServiceScrape and VMNodeScrape objects are selected in this mode.
+	// It cannot be used together with statefulMode, shardCount and podDisruptionBudget
+	// +optional
+	DaemonSetMode bool `json:"daemonSetMode,omitempty"`
 	// ClaimTemplates allows adding additional VolumeClaimTemplates for VMAgent in StatefulMode
 	ClaimTemplates []v1.PersistentVolumeClaim `json:"claimTemplates,omitempty"`
 	// IngestOnlyMode switches vmagent into unmanaged mode
@@ -448,6 +462,59 @@ type VMAgentStatus struct {
 	UpdateStatus UpdateStatus `json:"updateStatus,omitempty"`
 	// Reason defines fail reason for update process, effective only for statefulMode
 	Reason string `json:"reason,omitempty"`
+	// ObservedGeneration defines current generation picked by operator for the
+	// reconcile
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions defines latest available observations of object state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// ShardAutoscaling defines latest observations of shard autoscaler
+	// +optional
+	ShardAutoscaling *VMAgentShardAutoscalingStatus `json:"shardAutoscaling,omitempty"`
+}
+
+// VMAgentShardAutoscaling defines params for VMAgent shards autoscaling
+type VMAgentShardAutoscaling struct {
+	// MinShards defines minimal number of shards
+	// +kubebuilder:validation:Minimum=1
+	MinShards int32 `json:"minShards"`
+	// MaxShards defines maximal number of shards
+	// +kubebuilder:validation:Minimum=1
+	MaxShards int32 `json:"maxShards"`
+	// TargetsPerShard defines desired maximum of active scrape targets per shard
+	// +optional
+	TargetsPerShard int64 `json:"targetsPerShard,omitempty"`
+	// SeriesPerShard defines desired maximum of series scraped by shard during single scrape round
+	// +optional
+	SeriesPerShard int64 `json:"seriesPerShard,omitempty"`
+	// ScaleDownThresholdPercent defines hysteresis for scale-down.
+	// Shards are removed only if load per shard after removal stays below given percent of per shard limits.
+	// Defaults to 80
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	ScaleDownThresholdPercent *int32 `json:"scaleDownThresholdPercent,omitempty"`
+	// CooldownPeriod defines minimal interval between changes of shards count.
+	// Defaults to 10m
+	// +optional
+	CooldownPeriod string `json:"cooldownPeriod,omitempty"`
+}
+
+// VMAgentShardAutoscalingStatus defines latest observations of shard autoscaler
+type VMAgentShardAutoscalingStatus struct {
+	// Shards defines current number of shards chosen by autoscaler
+	Shards int32 `json:"shards"`
+	// Targets defines total number of active scrape targets at all shards
+	// +optional
+	Targets int64 `json:"targets,omitempty"`
+	// Series defines total number of series scraped by all shards during latest scrape round
+	// +optional
+	Series int64 `json:"series,omitempty"`
+	// LastScaleTime defines time of the latest change of shards count
+	// +optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
 }
 
 // +genclient
@@ -627,6 +694,11 @@ func (cr *VMAgent) ProbePath() string {
 	return buildPathWithPrefixFlag(cr.Spec.ExtraArgs, healthPath)
 }
 
+// TargetsPath returns path for active scrape targets API
+func (cr *VMAgent) TargetsPath() string {
+	return buildPathWithPrefixFlag(cr.Spec.ExtraArgs, targetsPath)
+}
+
 func (cr *VMAgent) ProbeScheme() string {
 	return strings.ToUpper(protoFromFlags(cr.Spec.ExtraArgs))
 }
@@ -736,10 +808,16 @@ func (cr *VMAgent) SetUpdateStatusTo(ctx context.Context, r client.Client, statu
 	if cr.Spec.ShardCount != nil {
 		shardCnt = int32(*cr.Spec.ShardCount)
 	}
+	if cr.Spec.ShardAutoscaling != nil && cr.Status.ShardAutoscaling != nil {
+		shardCnt = cr.Status.ShardAutoscaling.Shards
+	}
 	cr.Status.Replicas = replicaCount
 	cr.Status.Shards = shardCnt
 	cr.Status.Selector = labels.SelectorFromSet(cr.SelectorLabels()).String()
 
+	cr.Status.ObservedGeneration = cr.Generation
+	cr.Status.Conditions = updateConditions(cr.Status.Conditions, cr.Generation, status, maybeErr)
+
 	if equality.Semantic.DeepEqual(&cr.Status, prevStatus) && currentStatus == status {
 		return nil
 	}
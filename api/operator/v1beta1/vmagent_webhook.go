@@ -18,6 +18,8 @@ package v1beta1
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/VictoriaMetrics/VictoriaMetrics/lib/envtemplate"
 	"github.com/VictoriaMetrics/VictoriaMetrics/lib/promrelabel"
@@ -64,6 +66,11 @@ func checkRelabelConfigs(src []RelabelConfig) error {
 }
 
 func (r *VMAgent) sanityCheck() error {
+	if r.Spec.VPA != nil {
+		if err := r.Spec.VPA.sanityCheck(); err != nil {
+			return fmt.Errorf("incorrect spec.vpa: %w", err)
+		}
+	}
 	if len(r.Spec.RemoteWrite) == 0 {
 		return fmt.Errorf("spec.remoteWrite cannot be empty array, provide at least one remoteWrite")
 	}
@@ -88,10 +95,66 @@ func (r *VMAgent) sanityCheck() error {
 			}
 		}
 	}
+	if r.Spec.DaemonSetMode {
+		if r.Spec.StatefulMode {
+			return fmt.Errorf("daemonSetMode cannot be used with statefulMode")
+		}
+		if r.Spec.ShardCount != nil && *r.Spec.ShardCount > 1 {
+			return fmt.Errorf("daemonSetMode cannot be used with shardCount")
+		}
+		if r.Spec.PodDisruptionBudget != nil {
+			return fmt.Errorf("daemonSetMode cannot be used with podDisruptionBudget")
+		}
+		if r.Spec.ShardAutoscaling != nil {
+			return fmt.Errorf("daemonSetMode cannot be used with shardAutoscaling")
+		}
+	}
+	if sa := r.Spec.ShardAutoscaling; sa != nil {
+		if sa.MinShards < 1 {
+			return fmt.Errorf("shardAutoscaling.minShards must be greater than 0, got: %d", sa.MinShards)
+		}
+		if sa.MaxShards < sa.MinShards {
+			return fmt.Errorf("shardAutoscaling.maxShards=%d cannot be less than minShards=%d", sa.MaxShards, sa.MinShards)
+		}
+		if sa.TargetsPerShard <= 0 && sa.SeriesPerShard <= 0 {
+			return fmt.Errorf("shardAutoscaling requires at least one of targetsPerShard or seriesPerShard")
+		}
+		if sa.CooldownPeriod != "" {
+			if _, err := time.ParseDuration(sa.CooldownPeriod); err != nil {
+				return fmt.Errorf("cannot parse shardAutoscaling.cooldownPeriod=%q: %w", sa.CooldownPeriod, err)
+			}
+		}
+	}
 
 	return nil
 }
 
+// daemonSetModeWarnings returns warnings for selectors of objects, which are ignored at daemonSetMode
+func (r *VMAgent) daemonSetModeWarnings() admission.Warnings {
+	if !r.Spec.DaemonSetMode {
+		return nil
+	}
+	var ignored []string
+	selectAll := r.Spec.SelectAllByDefault
+	if selectAll || r.Spec.ProbeSelector != nil || r.Spec.ProbeNamespaceSelector != nil {
+		ignored = append(ignored, "VMProbe")
+	}
+	if selectAll || r.Spec.StaticScrapeSelector != nil || r.Spec.StaticScrapeNamespaceSelector != nil {
+		ignored = append(ignored, "VMStaticScrape")
+	}
+	if selectAll || r.Spec.ScrapeConfigSelector != nil || r.Spec.ScrapeConfigNamespaceSelector != nil {
+		ignored = append(ignored, "VMScrapeConfig")
+	}
+	var warnings admission.Warnings
+	if len(ignored) > 0 {
+		warnings = append(warnings, fmt.Sprintf("%s objects are not scraped at daemonSetMode, since their targets cannot be bound to the node of vmagent pod", strings.Join(ignored, ", ")))
+	}
+	if selectAll || r.Spec.ServiceScrapeSelector != nil || r.Spec.ServiceScrapeNamespaceSelector != nil {
+		warnings = append(warnings, "VMServiceScrape objects with discoveryRole: service are not scraped at daemonSetMode, use endpoints or endpointslices role instead")
+	}
+	return warnings
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *VMAgent) ValidateCreate() (admission.Warnings, error) {
 	if r.Spec.ParsingError != "" {
@@ -103,7 +166,7 @@ func (r *VMAgent) ValidateCreate() (admission.Warnings, error) {
 	if err := r.sanityCheck(); err != nil {
 		return nil, err
 	}
-	return nil, nil
+	return r.daemonSetModeWarnings(), nil
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -117,7 +180,7 @@ func (r *VMAgent) ValidateUpdate(old runtime.Object) (admission.Warnings, error)
 	if err := r.sanityCheck(); err != nil {
 		return nil, err
 	}
-	return nil, nil
+	return r.daemonSetModeWarnings(), nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
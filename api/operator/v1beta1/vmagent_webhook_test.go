@@ -2,6 +2,8 @@ package v1beta1
 
 import (
 	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestVMAgent_sanityCheck(t *testing.T) {
@@ -31,6 +33,46 @@ func TestVMAgent_sanityCheck(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "daemonset with statefulMode",
+			spec: VMAgentSpec{
+				RemoteWrite:   []VMAgentRemoteWriteSpec{{URL: "http://some-rw"}},
+				DaemonSetMode: true,
+				StatefulMode:  true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid daemonset",
+			spec: VMAgentSpec{
+				RemoteWrite:   []VMAgentRemoteWriteSpec{{URL: "http://some-rw"}},
+				DaemonSetMode: true,
+			},
+		},
+		{
+			name: "shard autoscaling with bad bounds",
+			spec: VMAgentSpec{
+				RemoteWrite: []VMAgentRemoteWriteSpec{{URL: "http://some-rw"}},
+				ShardAutoscaling: &VMAgentShardAutoscaling{
+					MinShards:       3,
+					MaxShards:       2,
+					TargetsPerShard: 1000,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid shard autoscaling",
+			spec: VMAgentSpec{
+				RemoteWrite: []VMAgentRemoteWriteSpec{{URL: "http://some-rw"}},
+				ShardAutoscaling: &VMAgentShardAutoscaling{
+					MinShards:       2,
+					MaxShards:       5,
+					TargetsPerShard: 1000,
+					CooldownPeriod:  "15m",
+				},
+			},
+		},
 		{
 			name: "valid inline cfg",
 			spec: VMAgentSpec{
@@ -87,3 +129,26 @@ func TestVMAgent_sanityCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestVMAgent_daemonSetModeWarnings(t *testing.T) {
+	f := func(spec VMAgentSpec, wantWarnings int) {
+		t.Helper()
+		cr := &VMAgent{Spec: spec}
+		warnings := cr.daemonSetModeWarnings()
+		if len(warnings) != wantWarnings {
+			t.Fatalf("unexpected warnings count, got: %d, want: %d, warnings: %v", len(warnings), wantWarnings, warnings)
+		}
+	}
+	selector := &metav1.LabelSelector{}
+
+	// deployment mode
+	f(VMAgentSpec{SelectAllByDefault: true, ProbeSelector: selector}, 0)
+
+	// pod and node scrapes only
+	f(VMAgentSpec{DaemonSetMode: true, PodScrapeSelector: selector, NodeScrapeSelector: selector}, 0)
+
+	// ignored objects
+	f(VMAgentSpec{DaemonSetMode: true, ProbeSelector: selector, ScrapeConfigNamespaceSelector: selector}, 1)
+	f(VMAgentSpec{DaemonSetMode: true, ServiceScrapeSelector: selector}, 1)
+	f(VMAgentSpec{DaemonSetMode: true, SelectAllByDefault: true}, 2)
+}
@@ -71,6 +71,13 @@ type VMAlertSpec struct {
 	// NamespaceSelector nil - only objects at VMAlert namespace.
 	// +optional
 	RuleNamespaceSelector *metav1.LabelSelector `json:"ruleNamespaceSelector,omitempty"`
+	// ShardCount - numbers of shards of VMAlert
+	// in this case operator will use 1 deployment per shard with
+	// replicas count according to spec.replicas.
+	// Rule groups are distributed across shards by hash of
+	// VMRule namespace, name and group name, so each group is evaluated by a single shard.
+	// +optional
+	ShardCount *int `json:"shardCount,omitempty"`
 
 	// Notifier prometheus alertmanager endpoint spec. Required at least one of notifier or notifiers when there are alerting rules. e.g. http://127.0.0.1:9093
 	// If specified both notifier and notifiers, notifier will be added as last element to notifiers.
@@ -140,6 +147,9 @@ type VMAlertSpec struct {
 	// +optional
 	PodDisruptionBudget *EmbeddedPodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
 	*EmbeddedProbes     `json:",inline"`
+	// NetworkPolicy enables generation of NetworkPolicy for VMAlert pods.
+	// +optional
+	NetworkPolicy *EmbeddedNetworkPolicy `json:"networkPolicy,omitempty"`
 	// License allows to configure license key to be used for enterprise features.
 	// Using license key is supported starting from VictoriaMetrics v1.94.0.
 	// See [here](https://docs.victoriametrics.com/enterprise)
@@ -261,6 +271,25 @@ type VMAlertStatus struct {
 	UpdateStatus UpdateStatus `json:"updateStatus,omitempty"`
 	// Reason defines fail reason for update process, effective only for statefulMode
 	Reason string `json:"reason,omitempty"`
+	// ObservedGeneration defines current generation picked by operator for the
+	// reconcile
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Shards defines rule groups distribution across vmalert shards
+	// +optional
+	Shards []VMAlertShardStatus `json:"shards,omitempty"`
+	// Conditions defines latest available observations of object state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// VMAlertShardStatus defines rule groups assigned to the vmalert shard
+type VMAlertShardStatus struct {
+	// Shard defines number of shard
+	Shard int32 `json:"shard"`
+	// Groups defines total number of rule groups evaluated by shard
+	Groups int32 `json:"groups"`
 }
 
 // VMAlert  executes a list of given alerting or recording rules against configured address.
@@ -453,6 +482,28 @@ func (cr *VMAlert) GetNotifierSelectors() []*DiscoverySelector {
 	return r
 }
 
+// GetShardCount returns shard count for vmalert
+func (cr *VMAlert) GetShardCount() int {
+	if cr == nil || cr.Spec.ShardCount == nil || *cr.Spec.ShardCount <= 1 {
+		return 1
+	}
+	return *cr.Spec.ShardCount
+}
+
+// IsSharded returns true if vmalert rule groups are distributed across shards
+func (cr *VMAlert) IsSharded() bool {
+	return cr.GetShardCount() > 1
+}
+
+// SetShardsStatus updates rule groups distribution across shards
+func (cr *VMAlert) SetShardsStatus(ctx context.Context, r client.Client, shards []VMAlertShardStatus) error {
+	if equality.Semantic.DeepEqual(cr.Status.Shards, shards) {
+		return nil
+	}
+	cr.Status.Shards = shards
+	return statusPatch(ctx, r, cr.DeepCopy(), cr.Status)
+}
+
 // IsUnmanaged checks if object should managed any  config objects
 func (cr *VMAlert) IsUnmanaged() bool {
 	return !cr.Spec.SelectAllByDefault && cr.Spec.RuleSelector == nil && cr.Spec.RuleNamespaceSelector == nil
@@ -504,6 +555,9 @@ func (cr *VMAlert) SetUpdateStatusTo(ctx context.Context, r client.Client, statu
 	default:
 		panic(fmt.Sprintf("BUG: not expected status=%q", status))
 	}
+	cr.Status.ObservedGeneration = cr.Generation
+	cr.Status.Conditions = updateConditions(cr.Status.Conditions, cr.Generation, status, maybeErr)
+
 	if equality.Semantic.DeepEqual(&cr.Status, prevStatus) && currentStatus == status {
 		return nil
 	}
@@ -37,6 +37,11 @@ func (r *VMAlert) SetupWebhookWithManager(mgr ctrl.Manager) error {
 var _ webhook.Validator = &VMAlert{}
 
 func (r *VMAlert) sanityCheck() error {
+	if r.Spec.VPA != nil {
+		if err := r.Spec.VPA.sanityCheck(); err != nil {
+			return fmt.Errorf("incorrect spec.vpa: %w", err)
+		}
+	}
 	if r.Spec.Datasource.URL == "" {
 		return fmt.Errorf("spec.datasource.url cannot be empty")
 	}
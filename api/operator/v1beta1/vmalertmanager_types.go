@@ -134,6 +134,9 @@ type VMAlertmanagerSpec struct {
 	// ServiceSpec that will be added to vmalertmanager service spec
 	// +optional
 	ServiceSpec *AdditionalServiceSpec `json:"serviceSpec,omitempty"`
+	// HTTPRoute enables Gateway API route configuration for VMAlertmanager.
+	// +optional
+	HTTPRoute *EmbeddedHTTPRoute `json:"httpRoute,omitempty"`
 	// ServiceScrapeSpec that will be added to vmalertmanager VMServiceScrape spec
 	// +optional
 	ServiceScrapeSpec *VMServiceScrapeSpec `json:"serviceScrapeSpec,omitempty"`
@@ -141,6 +144,9 @@ type VMAlertmanagerSpec struct {
 	// +optional
 	PodDisruptionBudget *EmbeddedPodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
 	*EmbeddedProbes     `json:",inline"`
+	// NetworkPolicy enables generation of NetworkPolicy for VMAlertmanager pods.
+	// +optional
+	NetworkPolicy *EmbeddedNetworkPolicy `json:"networkPolicy,omitempty"`
 	// SelectAllByDefault changes default behavior for empty CRD selectors, such ConfigSelector.
 	// with selectAllByDefault: true and undefined ConfigSelector and ConfigNamespaceSelector
 	// Operator selects all exist alertManagerConfigs
@@ -245,6 +251,14 @@ type VMAlertmanagerStatus struct {
 	UpdateStatus UpdateStatus `json:"updateStatus,omitempty"`
 	// Reason has non empty reason for update failure
 	Reason string `json:"reason,omitempty"`
+	// ObservedGeneration defines current generation picked by operator for the
+	// reconcile
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions defines latest available observations of object state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 func (cr *VMAlertmanager) AsOwner() []metav1.OwnerReference {
@@ -494,6 +508,9 @@ func (cr *VMAlertmanager) SetUpdateStatusTo(ctx context.Context, r client.Client
 	default:
 		panic(fmt.Sprintf("BUG: not expected status=%q", status))
 	}
+	cr.Status.ObservedGeneration = cr.Generation
+	cr.Status.Conditions = updateConditions(cr.Status.Conditions, cr.Generation, status, maybeErr)
+
 	if equality.Semantic.DeepEqual(&cr.Status, prevStatus) && currentStatus == status {
 		return nil
 	}
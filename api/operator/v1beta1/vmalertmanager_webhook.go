@@ -42,6 +42,16 @@ func (r *VMAlertmanager) SetupWebhookWithManager(mgr ctrl.Manager) error {
 var _ webhook.Validator = &VMAlertmanager{}
 
 func (r *VMAlertmanager) sanityCheck() error {
+	if r.Spec.VPA != nil {
+		if err := r.Spec.VPA.sanityCheck(); err != nil {
+			return fmt.Errorf("incorrect spec.vpa: %w", err)
+		}
+	}
+	if r.Spec.HTTPRoute != nil {
+		if err := r.Spec.HTTPRoute.Validate(); err != nil {
+			return fmt.Errorf("incorrect spec.httpRoute: %w", err)
+		}
+	}
 	for idx, matchers := range r.Spec.EnforcedTopRouteMatchers {
 		_, err := labels.ParseMatchers(matchers)
 		if err != nil {
@@ -280,6 +280,22 @@ type Receiver struct {
 	SNSConfigs []SnsConfig `json:"sns_configs,omitempty" yaml:"sns_configs,omitempty"`
 	// +optional
 	WebexConfigs []WebexConfig `json:"webex_configs,omitempty" yaml:"webex_configs,omitempty"`
+	// JiraConfigs defines jira notification configurations.
+	// Requires alertmanager v0.28.0 or newer.
+	// +optional
+	JiraConfigs []JiraConfig `json:"jira_configs,omitempty" yaml:"jira_configs,omitempty"`
+	// RocketchatConfigs defines rocket.chat notification configurations.
+	// Requires alertmanager v0.28.0 or newer.
+	// +optional
+	RocketchatConfigs []RocketchatConfig `json:"rocketchat_configs,omitempty" yaml:"rocketchat_configs,omitempty"`
+	// MSTeamsV2Configs defines msteams notification configurations via Power Automate workflows.
+	// Requires alertmanager v0.28.0 or newer.
+	// +optional
+	MSTeamsV2Configs []MSTeamsV2Config `json:"msteamsv2_configs,omitempty" yaml:"msteamsv2_configs,omitempty"`
+	// IncidentIOConfigs defines incident.io notification configurations.
+	// Requires alertmanager v0.29.0 or newer.
+	// +optional
+	IncidentIOConfigs []IncidentIOConfig `json:"incidentio_configs,omitempty" yaml:"incidentio_configs,omitempty"`
 }
 
 // TelegramConfig configures notification via telegram
@@ -306,6 +322,10 @@ type TelegramConfig struct {
 	// supported values are MarkdownV2, Markdown, Markdown and empty string for plain text.
 	// +optional
 	ParseMode string `json:"parse_mode,omitempty" yaml:"parse_mode"`
+	// MessageThreadID defines ID of the forum topic, where messages are sent.
+	// Supported since alertmanager v0.28.0
+	// +optional
+	MessageThreadID int `json:"message_thread_id,omitempty" yaml:"message_thread_id,omitempty"`
 	// HTTP client configuration.
 	// +optional
 	HTTPConfig *HTTPConfig `json:"http_config,omitempty" yaml:"http_config,omitempty"`
@@ -877,6 +897,189 @@ type WebexConfig struct {
 	HTTPConfig *HTTPConfig `json:"http_config,omitempty" yaml:"http_config,omitempty"`
 }
 
+// JiraConfig configures notifications via Jira issues.
+// Credentials must be provided with http_config, e.g. basic_auth with user email and API token.
+// Supported since alertmanager v0.28.0
+// See https://prometheus.io/docs/alerting/latest/configuration/#jira_config
+type JiraConfig struct {
+	// SendResolved controls notify about resolved alerts.
+	// +optional
+	SendResolved *bool `json:"send_resolved,omitempty" yaml:"send_resolved,omitempty"`
+	// The URL of Jira REST API, i.e. https://company.atlassian.net/rest/api/2/
+	// fallback to global alertmanager setting if empty
+	// +optional
+	APIURL string `json:"api_url,omitempty" yaml:"api_url,omitempty"`
+	// Project defines the key of the project, where issues are created
+	// +kubebuilder:validation:MinLength=1
+	Project string `json:"project"`
+	// IssueType defines the type of created issues, i.e. Bug
+	// +kubebuilder:validation:MinLength=1
+	IssueType string `json:"issue_type" yaml:"issue_type"`
+	// Summary defines issue summary template
+	// +optional
+	Summary string `json:"summary,omitempty"`
+	// Description defines issue description template
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Labels defines issue labels templates
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+	// Priority defines issue priority template
+	// +optional
+	Priority string `json:"priority,omitempty"`
+	// ReopenTransition defines name of the workflow transition to reopen resolved issue
+	// +optional
+	ReopenTransition string `json:"reopen_transition,omitempty" yaml:"reopen_transition,omitempty"`
+	// ResolveTransition defines name of the workflow transition to resolve issue
+	// +optional
+	ResolveTransition string `json:"resolve_transition,omitempty" yaml:"resolve_transition,omitempty"`
+	// WontFixResolution defines resolution of issues, which must not be reopened
+	// +optional
+	WontFixResolution string `json:"wont_fix_resolution,omitempty" yaml:"wont_fix_resolution,omitempty"`
+	// ReopenDuration defines duration, during which resolved issue is reopened instead of creating new one
+	// +kubebuilder:validation:Pattern:="[0-9]+(ms|s|m|h)"
+	// +optional
+	ReopenDuration string `json:"reopen_duration,omitempty" yaml:"reopen_duration,omitempty"`
+	// Fields defines additional issue fields, i.e. customfield_10000: value
+	// +optional
+	Fields map[string]apiextensionsv1.JSON `json:"fields,omitempty"`
+	// HTTP client configuration.
+	// +optional
+	HTTPConfig *HTTPConfig `json:"http_config,omitempty" yaml:"http_config,omitempty"`
+}
+
+// RocketchatConfig configures notifications via Rocket.Chat.
+// Supported since alertmanager v0.28.0
+// See https://prometheus.io/docs/alerting/latest/configuration/#rocketchat_config
+type RocketchatConfig struct {
+	// SendResolved controls notify about resolved alerts.
+	// +optional
+	SendResolved *bool `json:"send_resolved,omitempty" yaml:"send_resolved,omitempty"`
+	// The Rocket.Chat API URL, i.e. https://open.rocket.chat/
+	// fallback to global alertmanager setting if empty
+	// +optional
+	APIURL string `json:"api_url,omitempty" yaml:"api_url,omitempty"`
+	// Channel defines channel or user to send notifications to
+	// +optional
+	Channel string `json:"channel,omitempty"`
+	// Token defines secret with personal access token
+	// fallback to global alertmanager setting if empty
+	// +optional
+	Token *v1.SecretKeySelector `json:"token,omitempty"`
+	// TokenID defines secret with id of the user, which owns personal access token
+	// fallback to global alertmanager setting if empty
+	// +optional
+	TokenID *v1.SecretKeySelector `json:"token_id,omitempty" yaml:"token_id,omitempty"`
+	// +optional
+	Color string `json:"color,omitempty"`
+	// +optional
+	Emoji string `json:"emoji,omitempty"`
+	// +optional
+	IconURL string `json:"icon_url,omitempty" yaml:"icon_url,omitempty"`
+	// +optional
+	Text string `json:"text,omitempty"`
+	// +optional
+	Title string `json:"title,omitempty"`
+	// +optional
+	TitleLink string `json:"title_link,omitempty" yaml:"title_link,omitempty"`
+	// +optional
+	Fields []RocketchatFieldConfig `json:"fields,omitempty"`
+	// +optional
+	ShortFields *bool `json:"short_fields,omitempty" yaml:"short_fields,omitempty"`
+	// +optional
+	ImageURL string `json:"image_url,omitempty" yaml:"image_url,omitempty"`
+	// +optional
+	ThumbURL string `json:"thumb_url,omitempty" yaml:"thumb_url,omitempty"`
+	// +optional
+	LinkNames *bool `json:"link_names,omitempty" yaml:"link_names,omitempty"`
+	// +optional
+	Actions []RocketchatActionConfig `json:"actions,omitempty"`
+	// HTTP client configuration.
+	// +optional
+	HTTPConfig *HTTPConfig `json:"http_config,omitempty" yaml:"http_config,omitempty"`
+}
+
+// RocketchatFieldConfig defines message field of Rocket.Chat notification
+type RocketchatFieldConfig struct {
+	// +optional
+	Title string `json:"title,omitempty"`
+	// +optional
+	Value string `json:"value,omitempty"`
+	// +optional
+	Short *bool `json:"short,omitempty"`
+}
+
+// RocketchatActionConfig defines button of Rocket.Chat notification
+type RocketchatActionConfig struct {
+	// +optional
+	Type string `json:"type,omitempty"`
+	// +optional
+	Text string `json:"text,omitempty"`
+	// +optional
+	URL string `json:"url,omitempty"`
+	// +optional
+	Msg string `json:"msg,omitempty"`
+}
+
+// MSTeamsV2Config configures notifications via Microsoft Teams Power Automate workflows.
+// Supported since alertmanager v0.28.0
+// See https://prometheus.io/docs/alerting/latest/configuration/#msteamsv2_config
+type MSTeamsV2Config struct {
+	// SendResolved controls notify about resolved alerts.
+	// +optional
+	SendResolved *bool `json:"send_resolved,omitempty" yaml:"send_resolved,omitempty"`
+	// The incoming webhook URL of the workflow
+	// one of `urlSecret` and `url` must be defined.
+	// +optional
+	URL *string `json:"webhook_url,omitempty" yaml:"webhook_url,omitempty"`
+	// URLSecret defines secret name and key at the CRD namespace.
+	// It must contain the webhook URL.
+	// one of `urlSecret` and `url` must be defined.
+	// +optional
+	URLSecret *v1.SecretKeySelector `json:"webhook_url_secret,omitempty" yaml:"webhook_url_secret,omitempty"`
+	// The title of the teams notification.
+	// +optional
+	Title string `json:"title,omitempty"`
+	// The text body of the teams notification.
+	// +optional
+	Text string `json:"text,omitempty"`
+	// HTTP client configuration.
+	// +optional
+	HTTPConfig *HTTPConfig `json:"http_config,omitempty" yaml:"http_config,omitempty"`
+}
+
+// IncidentIOConfig configures notifications via incident.io alert source.
+// Supported since alertmanager v0.29.0
+// See https://prometheus.io/docs/alerting/latest/configuration/#incidentio_config
+type IncidentIOConfig struct {
+	// SendResolved controls notify about resolved alerts.
+	// +optional
+	SendResolved *bool `json:"send_resolved,omitempty" yaml:"send_resolved,omitempty"`
+	// URL of the incident.io alert source
+	// one of `urlSecret` and `url` must be defined.
+	// +optional
+	URL *string `json:"url,omitempty"`
+	// URLSecret defines secret name and key at the CRD namespace.
+	// It must contain the alert source URL.
+	// one of `urlSecret` and `url` must be defined.
+	// +optional
+	URLSecret *v1.SecretKeySelector `json:"url_secret,omitempty" yaml:"url_secret,omitempty"`
+	// AlertSourceToken defines secret with alert source token
+	// +optional
+	AlertSourceToken *v1.SecretKeySelector `json:"alert_source_token,omitempty" yaml:"alert_source_token,omitempty"`
+	// Maximum number of alerts to be sent per incident.io message. When 0, all alerts are included.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxAlerts int32 `json:"max_alerts,omitempty" yaml:"max_alerts,omitempty"`
+	// Timeout defines maximum duration of notification request
+	// +kubebuilder:validation:Pattern:="[0-9]+(ms|s|m|h)"
+	// +optional
+	Timeout string `json:"timeout,omitempty"`
+	// HTTP client configuration.
+	// +optional
+	HTTPConfig *HTTPConfig `json:"http_config,omitempty" yaml:"http_config,omitempty"`
+}
+
 // HTTPConfig defines a client HTTP configuration for VMAlertmanagerConfig objects
 // See https://prometheus.io/docs/alerting/latest/configuration/#http_config
 type HTTPConfig struct {
@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"net"
@@ -387,6 +388,82 @@ func validateReceiver(recv Receiver) error {
 			return fmt.Errorf("at idx=%d for webex_configs incorrect http_config: %w", idx, err)
 		}
 	}
+	for idx, cfg := range recv.JiraConfigs {
+		if cfg.APIURL != "" {
+			if _, err := url.Parse(cfg.APIURL); err != nil {
+				return fmt.Errorf("at idx=%d for jira_configs incorrect api_url=%q: %w", idx, cfg.APIURL, err)
+			}
+		}
+		if cfg.Project == "" {
+			return fmt.Errorf("at idx=%d for jira_configs missing required field 'project'", idx)
+		}
+		if cfg.IssueType == "" {
+			return fmt.Errorf("at idx=%d for jira_configs missing required field 'issue_type'", idx)
+		}
+		for key, value := range cfg.Fields {
+			var v any
+			if err := json.Unmarshal(value.Raw, &v); err != nil {
+				return fmt.Errorf("at idx=%d for jira_configs cannot parse value of field=%q: %w", idx, key, err)
+			}
+		}
+		if err := cfg.HTTPConfig.validate(); err != nil {
+			return fmt.Errorf("at idx=%d for jira_configs incorrect http_config: %w", idx, err)
+		}
+	}
+	for idx, cfg := range recv.RocketchatConfigs {
+		if cfg.APIURL != "" {
+			if _, err := url.Parse(cfg.APIURL); err != nil {
+				return fmt.Errorf("at idx=%d for rocketchat_configs incorrect api_url=%q: %w", idx, cfg.APIURL, err)
+			}
+		}
+		if (cfg.Token == nil) != (cfg.TokenID == nil) {
+			return fmt.Errorf("at idx=%d for rocketchat_configs both 'token' and 'token_id' must be configured", idx)
+		}
+		for _, action := range cfg.Actions {
+			if action.Type != "" && action.Type != "button" {
+				return fmt.Errorf("at idx=%d for rocketchat_configs unsupported action type=%q, only 'button' is supported", idx, action.Type)
+			}
+		}
+		if err := cfg.HTTPConfig.validate(); err != nil {
+			return fmt.Errorf("at idx=%d for rocketchat_configs incorrect http_config: %w", idx, err)
+		}
+	}
+	for idx, cfg := range recv.MSTeamsV2Configs {
+		if cfg.URL == nil && cfg.URLSecret == nil {
+			return fmt.Errorf("at idx=%d for msteamsv2_configs of webhook_url or webhook_url_secret must be configured", idx)
+		}
+		if cfg.URL != nil && cfg.URLSecret != nil {
+			return fmt.Errorf("at idx=%d for msteamsv2_configs at most one of webhook_url or webhook_url_secret must be configured", idx)
+		}
+		if cfg.URL != nil {
+			if _, err := url.Parse(*cfg.URL); err != nil {
+				return fmt.Errorf("at idx=%d for msteamsv2_configs has invalid webhook_url=%q", idx, *cfg.URL)
+			}
+		}
+		if err := cfg.HTTPConfig.validate(); err != nil {
+			return fmt.Errorf("at idx=%d for msteamsv2_configs incorrect http_config: %w", idx, err)
+		}
+	}
+	for idx, cfg := range recv.IncidentIOConfigs {
+		if cfg.URL == nil && cfg.URLSecret == nil {
+			return fmt.Errorf("at idx=%d for incidentio_configs one of url or url_secret must be configured", idx)
+		}
+		if cfg.URL != nil && cfg.URLSecret != nil {
+			return fmt.Errorf("at idx=%d for incidentio_configs at most one of url or url_secret must be configured", idx)
+		}
+		if cfg.URL != nil {
+			if _, err := url.Parse(*cfg.URL); err != nil {
+				return fmt.Errorf("at idx=%d for incidentio_configs has invalid url=%q", idx, *cfg.URL)
+			}
+		}
+		if cfg.AlertSourceToken != nil && cfg.HTTPConfig != nil &&
+			(cfg.HTTPConfig.Authorization != nil || cfg.HTTPConfig.BearerTokenSecret != nil || len(cfg.HTTPConfig.BearerTokenFile) > 0) {
+			return fmt.Errorf("at idx=%d for incidentio_configs at most one of alert_source_token or http_config authorization must be configured", idx)
+		}
+		if err := cfg.HTTPConfig.validate(); err != nil {
+			return fmt.Errorf("at idx=%d for incidentio_configs incorrect http_config: %w", idx, err)
+		}
+	}
 
 	return nil
 }
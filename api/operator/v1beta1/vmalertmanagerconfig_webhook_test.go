@@ -137,6 +137,32 @@ var _ = Describe("VMAlertmanagerConfig Webhook", func() {
               routes:
               - matcher: [nested=env]
         `, `cannot parse nested route for alertmanager config err: cannot parse matchers="bad !~-124 matcher\"" idx=0 for route_receiver=blackhole: matcher value contains unescaped double quote: -124 matcher"`),
+			Entry("jira without project", `
+        apiVersion: v1
+        kind: VMAlertmanagerConfig
+        metadata:
+          name: test-fail
+        spec:
+          receivers:
+          - name: jira
+            jira_configs:
+            - issue_type: Bug
+          route:
+            receiver: jira
+        `, `receiver at idx=0 is invalid: at idx=0 for jira_configs missing required field 'project'`),
+			Entry("incidentio without url", `
+        apiVersion: v1
+        kind: VMAlertmanagerConfig
+        metadata:
+          name: test-fail
+        spec:
+          receivers:
+          - name: incidentio
+            incidentio_configs:
+            - max_alerts: 5
+          route:
+            receiver: incidentio
+        `, `receiver at idx=0 is invalid: at idx=0 for incidentio_configs one of url or url_secret must be configured`),
 		)
 		DescribeTable("should pass validation",
 			func(srcYAML string) {
@@ -419,8 +445,80 @@ var _ = Describe("VMAlertmanagerConfig Webhook", func() {
                 key: secret
               chat_id: 1234
               parse_mode: HTML
+              message_thread_id: 5
           route:
             receiver: tg
+        `),
+			Entry("jira", `
+        apiVersion: v1
+        kind: VMAlertmanagerConfig
+        metadata:
+          name: jira
+        spec:
+          receivers:
+          - name: jira
+            jira_configs:
+            - api_url: https://example.atlassian.net/rest/api/2/
+              project: OPS
+              issue_type: Bug
+              reopen_transition: Reopen
+              resolve_transition: Done
+          route:
+            receiver: jira
+        `),
+			Entry("rocketchat", `
+        apiVersion: v1
+        kind: VMAlertmanagerConfig
+        metadata:
+          name: rocketchat
+        spec:
+          receivers:
+          - name: rc
+            rocketchat_configs:
+            - channel: '#alerts'
+              token:
+                name: rc-access
+                key: token
+              token_id:
+                name: rc-access
+                key: token-id
+              actions:
+              - type: button
+                text: Runbook
+                url: https://runbooks.example.com
+          route:
+            receiver: rc
+        `),
+			Entry("msteamsv2", `
+        apiVersion: v1
+        kind: VMAlertmanagerConfig
+        metadata:
+          name: msteamsv2
+        spec:
+          receivers:
+          - name: teams
+            msteamsv2_configs:
+            - webhook_url_secret:
+                name: teams-access
+                key: url
+          route:
+            receiver: teams
+        `),
+			Entry("incidentio", `
+        apiVersion: v1
+        kind: VMAlertmanagerConfig
+        metadata:
+          name: incidentio
+        spec:
+          receivers:
+          - name: incidentio
+            incidentio_configs:
+            - url: https://api.incident.io/v2/alert_events/http/source-id
+              alert_source_token:
+                name: incidentio-access
+                key: token
+          route:
+            receiver: incidentio
         `),
 		)
 	})
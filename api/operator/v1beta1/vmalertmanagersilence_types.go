@@ -0,0 +1,155 @@
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const defaultSilenceCreatedBy = "vm-operator"
+
+// VMAlertmanagerSilenceSpec defines the desired state of VMAlertmanagerSilence
+type VMAlertmanagerSilenceSpec struct {
+	// Matchers defines a list of matchers for alerts to be silenced,
+	// e.g. alertname="Watchdog" or severity=~"info|warning".
+	// https://prometheus.io/docs/alerting/latest/configuration/#matcher
+	// +kubebuilder:validation:MinItems=1
+	Matchers []string `json:"matchers"`
+	// StartsAt defines time of silence start,
+	// defaults to object creation time
+	// +optional
+	StartsAt *metav1.Time `json:"startsAt,omitempty"`
+	// EndsAt defines time of silence end
+	EndsAt metav1.Time `json:"endsAt"`
+	// Comment describes the reason of silence
+	// +kubebuilder:validation:MinLength=1
+	Comment string `json:"comment"`
+	// CreatedBy defines author of the silence,
+	// defaults to vm-operator
+	// +optional
+	CreatedBy string `json:"createdBy,omitempty"`
+	// AlertmanagerSelector defines VMAlertmanagers to sync silence into.
+	// VMAlertmanagers from the silence namespace are selected by default
+	// +optional
+	AlertmanagerSelector *DiscoverySelector `json:"alertmanagerSelector,omitempty"`
+}
+
+// VMAlertmanagerSilenceStatus defines the observed state of VMAlertmanagerSilence
+type VMAlertmanagerSilenceStatus struct {
+	// UpdateStatus defines a status of silence sync
+	UpdateStatus UpdateStatus `json:"status,omitempty"`
+	// Reason defines a reason of failure
+	Reason string `json:"reason,omitempty"`
+	// Silences contains silences created at alertmanager replicas
+	Silences []VMAlertmanagerSilenceReplicaStatus `json:"silences,omitempty"`
+}
+
+// VMAlertmanagerSilenceReplicaStatus defines silence state at alertmanager replica
+type VMAlertmanagerSilenceReplicaStatus struct {
+	// Alertmanager is a name of VMAlertmanager in namespace/name format
+	Alertmanager string `json:"alertmanager"`
+	// Pod is a name of alertmanager replica pod
+	Pod string `json:"pod"`
+	// ID of the silence at alertmanager
+	ID string `json:"id,omitempty"`
+	// State of the silence at alertmanager: pending, active or expired
+	State string `json:"state,omitempty"`
+}
+
+// VMAlertmanagerSilence is the Schema for the vmalertmanagersilences API
+// It defines silence, which operator syncs to all replicas of selected VMAlertmanagers
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=vmalertmanagersilences,scope=Namespaced
+// +kubebuilder:printcolumn:name="Ends At",type="date",JSONPath=".spec.endsAt"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +genclient
+type VMAlertmanagerSilence struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VMAlertmanagerSilenceSpec   `json:"spec,omitempty"`
+	Status VMAlertmanagerSilenceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VMAlertmanagerSilenceList contains a list of VMAlertmanagerSilence
+type VMAlertmanagerSilenceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMAlertmanagerSilence `json:"items"`
+}
+
+// ParseMatchers returns parsed silence matchers
+func (cr *VMAlertmanagerSilence) ParseMatchers() ([]*labels.Matcher, error) {
+	var result []*labels.Matcher
+	for idx, matchers := range cr.Spec.Matchers {
+		ms, err := labels.ParseMatchers(matchers)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse matchers=%q idx=%d: %w", matchers, idx, err)
+		}
+		result = append(result, ms...)
+	}
+	return result, nil
+}
+
+// StartsAt returns time of silence start
+func (cr *VMAlertmanagerSilence) StartsAt() time.Time {
+	if cr.Spec.StartsAt != nil {
+		return cr.Spec.StartsAt.Time
+	}
+	return cr.CreationTimestamp.Time
+}
+
+// CreatedBy returns author of the silence
+func (cr *VMAlertmanagerSilence) CreatedBy() string {
+	if cr.Spec.CreatedBy != "" {
+		return cr.Spec.CreatedBy
+	}
+	return defaultSilenceCreatedBy
+}
+
+// IsExpired checks if silence end time has passed
+func (cr *VMAlertmanagerSilence) IsExpired(now time.Time) bool {
+	return !cr.Spec.EndsAt.Time.After(now)
+}
+
+// SetUpdateStatusTo changes update status with optional reason of fail
+func (cr *VMAlertmanagerSilence) SetUpdateStatusTo(ctx context.Context, r client.Client, status UpdateStatus, maybeErr error) error {
+	prevStatus := cr.Status.DeepCopy()
+	switch status {
+	case UpdateStatusOperational:
+		cr.Status.Reason = ""
+	case UpdateStatusFailed:
+		if maybeErr != nil {
+			cr.Status.Reason = maybeErr.Error()
+		}
+	default:
+		panic(fmt.Sprintf("BUG: not expected status=%q", status))
+	}
+	cr.Status.UpdateStatus = status
+	if equality.Semantic.DeepEqual(&cr.Status, prevStatus) {
+		return nil
+	}
+	return statusPatch(ctx, r, cr.DeepCopy(), cr.Status)
+}
+
+// SetSilencesStatus updates silence IDs and states per alertmanager replica
+func (cr *VMAlertmanagerSilence) SetSilencesStatus(ctx context.Context, r client.Client, silences []VMAlertmanagerSilenceReplicaStatus) error {
+	if equality.Semantic.DeepEqual(cr.Status.Silences, silences) {
+		return nil
+	}
+	cr.Status.Silences = silences
+	return statusPatch(ctx, r, cr.DeepCopy(), cr.Status)
+}
+
+func init() {
+	SchemeBuilder.Register(&VMAlertmanagerSilence{}, &VMAlertmanagerSilenceList{})
+}
@@ -0,0 +1,79 @@
+package v1beta1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *VMAlertmanagerSilence) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-operator-victoriametrics-com-v1beta1-vmalertmanagersilence,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.victoriametrics.com,resources=vmalertmanagersilences,verbs=create;update,versions=v1beta1,name=vvmalertmanagersilence.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &VMAlertmanagerSilence{}
+
+// Validate performs logical validation
+func (r *VMAlertmanagerSilence) Validate() error {
+	if mustSkipValidation(r) {
+		return nil
+	}
+	if len(r.Spec.Matchers) == 0 {
+		return fmt.Errorf("at least 1 matcher must be provided for spec.matchers")
+	}
+	matchers, err := r.ParseMatchers()
+	if err != nil {
+		return err
+	}
+	// the same check as alertmanager performs for silences
+	var hasNonEmptyMatcher bool
+	for _, m := range matchers {
+		if !m.Matches("") {
+			hasNonEmptyMatcher = true
+			break
+		}
+	}
+	if !hasNonEmptyMatcher {
+		return fmt.Errorf("at least one matcher must not match the empty string")
+	}
+	if r.Spec.Comment == "" {
+		return fmt.Errorf("spec.comment cannot be empty")
+	}
+	if r.Spec.StartsAt != nil && !r.Spec.EndsAt.After(r.Spec.StartsAt.Time) {
+		return fmt.Errorf("spec.endsAt=%s must be after spec.startsAt=%s", r.Spec.EndsAt, r.Spec.StartsAt)
+	}
+	if r.Spec.AlertmanagerSelector != nil {
+		if _, err := r.Spec.AlertmanagerSelector.AsListOptions(); err != nil {
+			return fmt.Errorf("incorrect spec.alertmanagerSelector: %w", err)
+		}
+	}
+	return nil
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *VMAlertmanagerSilence) ValidateCreate() (admission.Warnings, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *VMAlertmanagerSilence) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *VMAlertmanagerSilence) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
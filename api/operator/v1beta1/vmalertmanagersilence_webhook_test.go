@@ -0,0 +1,62 @@
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestVMAlertmanagerSilence_Validate(t *testing.T) {
+	f := func(spec VMAlertmanagerSilenceSpec, wantErr bool) {
+		t.Helper()
+		cr := &VMAlertmanagerSilence{Spec: spec}
+		err := cr.Validate()
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected validation result, wantErr: %v, got err: %v", wantErr, err)
+		}
+	}
+	now := time.Now()
+	endsAt := metav1.NewTime(now.Add(time.Hour))
+
+	// valid silence
+	f(VMAlertmanagerSilenceSpec{
+		Matchers: []string{`alertname="Watchdog"`, `{severity=~"info|warning", team!="infra"}`},
+		EndsAt:   endsAt,
+		Comment:  "maintenance",
+	}, false)
+
+	// no matchers
+	f(VMAlertmanagerSilenceSpec{
+		EndsAt:  endsAt,
+		Comment: "maintenance",
+	}, true)
+
+	// incorrect matcher
+	f(VMAlertmanagerSilenceSpec{
+		Matchers: []string{`alertname=~"Watchdog`},
+		EndsAt:   endsAt,
+		Comment:  "maintenance",
+	}, true)
+
+	// matchers match empty label
+	f(VMAlertmanagerSilenceSpec{
+		Matchers: []string{`alertname=~".*"`},
+		EndsAt:   endsAt,
+		Comment:  "maintenance",
+	}, true)
+
+	// missing comment
+	f(VMAlertmanagerSilenceSpec{
+		Matchers: []string{`alertname="Watchdog"`},
+		EndsAt:   endsAt,
+	}, true)
+
+	// end before start
+	f(VMAlertmanagerSilenceSpec{
+		Matchers: []string{`alertname="Watchdog"`},
+		StartsAt: &endsAt,
+		EndsAt:   metav1.NewTime(now),
+		Comment:  "maintenance",
+	}, true)
+}
@@ -0,0 +1,423 @@
+package v1beta1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VMAnomalySpec defines the desired state of VMAnomaly
+// +k8s:openapi-gen=true
+type VMAnomalySpec struct {
+	// ParsingError contents error with context if operator was failed to parse json object from kubernetes api server
+	ParsingError string `json:"-" yaml:"-"`
+	// PodMetadata configures Labels and Annotations which are propagated to the VMAnomaly pods.
+	PodMetadata *EmbeddedObjectMetadata `json:"podMetadata,omitempty"`
+	// LogLevel for VMAnomaly to be configured with.
+	// +optional
+	// +kubebuilder:validation:Enum=DEBUG;INFO;WARNING;ERROR;CRITICAL
+	LogLevel string `json:"logLevel,omitempty"`
+	// License allows to configure license key to be used for enterprise features.
+	// vmanomaly requires license key to start.
+	// See [here](https://docs.victoriametrics.com/enterprise)
+	License *License `json:"license,omitempty"`
+
+	// ShardCount - numbers of shards of VMAnomaly
+	// in this case operator will use 1 deployment per shard
+	// and distribute configured models across shards.
+	// Each shard receives reader, writer and schedulers configuration
+	// and a subset of models.
+	// +optional
+	ShardCount *int `json:"shardCount,omitempty"`
+
+	// Reader configures vmanomaly reader section.
+	// It defines datasource and queries for anomaly detection.
+	Reader VMAnomalyReaderSpec `json:"reader"`
+	// Writer configures vmanomaly writer section.
+	// It defines datasource for anomaly scores and produced metrics.
+	Writer VMAnomalyWriterSpec `json:"writer"`
+	// Models defines vmanomaly models configuration by model alias.
+	// See [here](https://docs.victoriametrics.com/anomaly-detection/components/models/) for details
+	// +optional
+	Models map[string]apiextensionsv1.JSON `json:"models,omitempty"`
+	// Schedulers defines vmanomaly schedulers configuration by scheduler alias.
+	// See [here](https://docs.victoriametrics.com/anomaly-detection/components/scheduler/) for details
+	// +optional
+	Schedulers map[string]apiextensionsv1.JSON `json:"schedulers,omitempty"`
+	// ConfigRawYaml - raw configuration for vmanomaly,
+	// it's merged with sections generated from spec.
+	// reader, writer, models, schedulers and monitoring sections defined at spec
+	// have priority over the same sections at raw config.
+	// +optional
+	ConfigRawYaml string `json:"configRawYaml,omitempty"`
+
+	// ServiceSpec that will be added to vmanomaly service spec
+	// +optional
+	ServiceSpec *AdditionalServiceSpec `json:"serviceSpec,omitempty"`
+	// ServiceScrapeSpec that will be added to vmanomaly VMServiceScrape spec
+	// +optional
+	ServiceScrapeSpec *VMServiceScrapeSpec `json:"serviceScrapeSpec,omitempty"`
+
+	// UpdateStrategy - overrides default update strategy.
+	// +kubebuilder:validation:Enum=Recreate;RollingUpdate
+	// +optional
+	UpdateStrategy *appsv1.DeploymentStrategyType `json:"updateStrategy,omitempty"`
+	// RollingUpdate - overrides deployment update params.
+	// +optional
+	RollingUpdate *appsv1.RollingUpdateDeployment `json:"rollingUpdate,omitempty"`
+	// PodDisruptionBudget created by operator
+	// +optional
+	PodDisruptionBudget *EmbeddedPodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+	*EmbeddedProbes     `json:",inline"`
+
+	// ServiceAccountName is the name of the ServiceAccount to use to run the pods
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	CommonDefaultableParams           `json:",inline,omitempty"`
+	CommonApplicationDeploymentParams `json:",inline,omitempty"`
+}
+
+// VMAnomalyDatasource defines datasource for vmanomaly reader or writer.
+// Only one of url or datasourceRef could be set.
+// +k8s:openapi-gen=true
+type VMAnomalyDatasource struct {
+	// DatasourceURL defines url of VictoriaMetrics compatible datasource.
+	// e.g. http://vmsingle-main.default.svc:8429
+	// +optional
+	DatasourceURL string `json:"datasourceURL,omitempty"`
+	// DatasourceRef references operator's CRD object,
+	// operator generates access url based on CRD params.
+	// Supported kinds are VMSingle, VMCluster/vmselect for reader
+	// and VMSingle, VMCluster/vminsert for writer.
+	// +optional
+	DatasourceRef *CRDRef `json:"datasourceRef,omitempty"`
+	// TenantID defines tenant for the VMCluster datasource in form of accountID:projectID.
+	// Defaults to 0 for VMCluster datasourceRef.
+	// +optional
+	TenantID string `json:"tenantID,omitempty"`
+	// Timeout for the datasource requests.
+	// +optional
+	Timeout string `json:"timeout,omitempty"`
+	// BasicAuth allow datasource to authenticate over basic authentication
+	// +optional
+	BasicAuth *BasicAuth `json:"basicAuth,omitempty"`
+	// BearerAuth allow datasource to authenticate with bearer token
+	// +optional
+	*BearerAuth `json:",inline,omitempty"`
+	// TLSConfig defines tls configuration for datasource
+	// +optional
+	TLSConfig *TLSConfig `json:"tlsConfig,omitempty"`
+}
+
+// VMAnomalyReaderSpec defines vmanomaly reader configuration
+// +k8s:openapi-gen=true
+type VMAnomalyReaderSpec struct {
+	VMAnomalyDatasource `json:",inline"`
+	// SamplingPeriod defines frequency of the points returned by queries.
+	// +kubebuilder:validation:Pattern:="[0-9]+(ms|s|m|h|d|w)"
+	SamplingPeriod string `json:"samplingPeriod"`
+	// QueryRangePath defines path to the query range API.
+	// +optional
+	QueryRangePath string `json:"queryRangePath,omitempty"`
+	// Queries defines MetricsQL queries by query alias.
+	Queries map[string]VMAnomalyQuerySpec `json:"queries"`
+}
+
+// VMAnomalyQuerySpec defines vmanomaly reader query
+// +k8s:openapi-gen=true
+type VMAnomalyQuerySpec struct {
+	// Expr defines MetricsQL expression
+	Expr string `json:"expr"`
+	// Step overrides reader sampling period for the given query
+	// +optional
+	Step string `json:"step,omitempty"`
+	// TenantID overrides reader tenant for the given query
+	// +optional
+	TenantID string `json:"tenantID,omitempty"`
+}
+
+// VMAnomalyWriterSpec defines vmanomaly writer configuration
+// +k8s:openapi-gen=true
+type VMAnomalyWriterSpec struct {
+	VMAnomalyDatasource `json:",inline"`
+	// MetricFormat defines labels and metric name for the produced metrics.
+	// See [here](https://docs.victoriametrics.com/anomaly-detection/components/writer/#metrics-formatting) for details
+	// +optional
+	MetricFormat map[string]string `json:"metricFormat,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (cr *VMAnomaly) UnmarshalJSON(src []byte) error {
+	type pcr VMAnomaly
+	if err := json.Unmarshal(src, (*pcr)(cr)); err != nil {
+		return err
+	}
+	prev, err := parseLastAppliedSpec[VMAnomalySpec](cr)
+	if err != nil {
+		return err
+	}
+	cr.ParsedLastAppliedSpec = prev
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (cr *VMAnomalySpec) UnmarshalJSON(src []byte) error {
+	type pcr VMAnomalySpec
+	if err := json.Unmarshal(src, (*pcr)(cr)); err != nil {
+		cr.ParsingError = fmt.Sprintf("cannot parse vmanomaly spec: %s, err: %s", string(src), err)
+		return nil
+	}
+	return nil
+}
+
+// VMAnomalyStatus defines the observed state of VMAnomaly
+// +k8s:openapi-gen=true
+type VMAnomalyStatus struct {
+	// Shards represents total number of vmanomaly deployments, models are split between them
+	Shards int32 `json:"shards,omitempty"`
+	// UpdateStatus defines a status for update rollout
+	UpdateStatus UpdateStatus `json:"updateStatus,omitempty"`
+	// Reason defines fail reason for update process
+	Reason string `json:"reason,omitempty"`
+	// ObservedGeneration defines current generation picked by operator for the
+	// reconcile
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions defines latest available observations of object state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// VMAnomaly is the Schema for the vmanomalies API.
+// It runs [vmanomaly](https://docs.victoriametrics.com/anomaly-detection/) for configured models
+// +operator-sdk:gen-csv:customresourcedefinitions.displayName="VMAnomaly App"
+// +operator-sdk:gen-csv:customresourcedefinitions.resources="Deployment,v1"
+// +operator-sdk:gen-csv:customresourcedefinitions.resources="Service,v1"
+// +operator-sdk:gen-csv:customresourcedefinitions.resources="Secret,v1"
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=vmanomalies,scope=Namespaced
+// +kubebuilder:printcolumn:name="Shards Count",type="integer",JSONPath=".status.shards",description="current number of shards"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus",description="Current status of update rollout"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type VMAnomaly struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VMAnomalySpec `json:"spec,omitempty"`
+	// ParsedLastAppliedSpec contains last-applied configuration spec
+	ParsedLastAppliedSpec *VMAnomalySpec `json:"-" yaml:"-"`
+
+	Status VMAnomalyStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VMAnomalyList contains a list of VMAnomaly
+type VMAnomalyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMAnomaly `json:"items"`
+}
+
+func (cr *VMAnomaly) Probe() *EmbeddedProbes {
+	return cr.Spec.EmbeddedProbes
+}
+
+func (cr *VMAnomaly) ProbePath() string {
+	return healthPath
+}
+
+func (cr *VMAnomaly) ProbeScheme() string {
+	return "HTTP"
+}
+
+func (cr VMAnomaly) ProbePort() string {
+	return cr.Spec.Port
+}
+
+func (cr VMAnomaly) ProbeNeedLiveness() bool {
+	return true
+}
+
+func (cr *VMAnomaly) AsOwner() []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			APIVersion:         cr.APIVersion,
+			Kind:               cr.Kind,
+			Name:               cr.Name,
+			UID:                cr.UID,
+			Controller:         ptr.To(true),
+			BlockOwnerDeletion: ptr.To(true),
+		},
+	}
+}
+
+func (cr VMAnomaly) PodAnnotations() map[string]string {
+	annotations := map[string]string{}
+	if cr.Spec.PodMetadata != nil {
+		for annotation, value := range cr.Spec.PodMetadata.Annotations {
+			annotations[annotation] = value
+		}
+	}
+	return annotations
+}
+
+func (cr VMAnomaly) AnnotationsFiltered() map[string]string {
+	return filterMapKeysByPrefixes(cr.ObjectMeta.Annotations, annotationFilterPrefixes)
+}
+
+func (cr VMAnomaly) SelectorLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":      "vmanomaly",
+		"app.kubernetes.io/instance":  cr.Name,
+		"app.kubernetes.io/component": "monitoring",
+		"managed-by":                  "vm-operator",
+	}
+}
+
+func (cr VMAnomaly) PodLabels() map[string]string {
+	lbls := cr.SelectorLabels()
+	if cr.Spec.PodMetadata == nil {
+		return lbls
+	}
+	return labels.Merge(cr.Spec.PodMetadata.Labels, lbls)
+}
+
+func (cr VMAnomaly) AllLabels() map[string]string {
+	selectorLabels := cr.SelectorLabels()
+	// fast path
+	if cr.ObjectMeta.Labels == nil {
+		return selectorLabels
+	}
+	crLabels := filterMapKeysByPrefixes(cr.ObjectMeta.Labels, labelFilterPrefixes)
+	return labels.Merge(crLabels, selectorLabels)
+}
+
+func (cr VMAnomaly) PrefixedName() string {
+	return fmt.Sprintf("vmanomaly-%s", cr.Name)
+}
+
+// GetMetricPath returns prefixed path for metric requests
+func (cr VMAnomaly) GetMetricPath() string {
+	return metricPath
+}
+
+// GetExtraArgs returns additionally configured command-line arguments
+func (cr VMAnomaly) GetExtraArgs() map[string]string {
+	return cr.Spec.ExtraArgs
+}
+
+// GetServiceScrape returns overrides for serviceScrape builder
+func (cr VMAnomaly) GetServiceScrape() *VMServiceScrapeSpec {
+	return cr.Spec.ServiceScrapeSpec
+}
+
+func (cr VMAnomaly) GetServiceAccountName() string {
+	if cr.Spec.ServiceAccountName == "" {
+		return cr.PrefixedName()
+	}
+	return cr.Spec.ServiceAccountName
+}
+
+func (cr VMAnomaly) IsOwnsServiceAccount() bool {
+	return cr.Spec.ServiceAccountName == ""
+}
+
+func (cr VMAnomaly) GetNSName() string {
+	return cr.GetNamespace()
+}
+
+// GetShardCount returns shard count for vmanomaly
+func (cr *VMAnomaly) GetShardCount() int {
+	if cr == nil || cr.Spec.ShardCount == nil || *cr.Spec.ShardCount <= 1 {
+		return 1
+	}
+	return *cr.Spec.ShardCount
+}
+
+// IsSharded returns true if vmanomaly models are distributed across shards
+func (cr *VMAnomaly) IsSharded() bool {
+	return cr.GetShardCount() > 1
+}
+
+// LastAppliedSpecAsPatch return last applied vmanomaly spec as patch annotation
+func (cr *VMAnomaly) LastAppliedSpecAsPatch() (client.Patch, error) {
+	data, err := json.Marshal(cr.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("possible bug, cannot serialize specification as json :%w", err)
+	}
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q: %q}}}`, lastAppliedSpecAnnotationName, data)
+	return client.RawPatch(types.MergePatchType, []byte(patch)), nil
+}
+
+// HasSpecChanges compares spec with last applied vmanomaly spec stored in annotation
+func (cr *VMAnomaly) HasSpecChanges() (bool, error) {
+	lastAppliedJSON := cr.Annotations[lastAppliedSpecAnnotationName]
+	if len(lastAppliedJSON) == 0 {
+		return true, nil
+	}
+	instanceSpecData, err := json.Marshal(cr.Spec)
+	if err != nil {
+		return true, err
+	}
+	return !bytes.Equal([]byte(lastAppliedJSON), instanceSpecData), nil
+}
+
+func (cr *VMAnomaly) Paused() bool {
+	return cr.Spec.Paused
+}
+
+// SetUpdateStatusTo changes update status with optional reason of fail
+func (cr *VMAnomaly) SetUpdateStatusTo(ctx context.Context, r client.Client, status UpdateStatus, maybeErr error) error {
+	currentStatus := cr.Status.UpdateStatus
+	prevStatus := cr.Status.DeepCopy()
+	switch status {
+	case UpdateStatusExpanding:
+	case UpdateStatusFailed:
+		if maybeErr != nil {
+			cr.Status.Reason = maybeErr.Error()
+		}
+	case UpdateStatusOperational:
+		cr.Status.Reason = ""
+	case UpdateStatusPaused:
+		if currentStatus == status {
+			return nil
+		}
+	default:
+		panic(fmt.Sprintf("BUG: not expected status=%q", status))
+	}
+	cr.Status.ObservedGeneration = cr.Generation
+	cr.Status.Shards = int32(cr.GetShardCount())
+	cr.Status.Conditions = updateConditions(cr.Status.Conditions, cr.Generation, status, maybeErr)
+
+	if equality.Semantic.DeepEqual(&cr.Status, prevStatus) && currentStatus == status {
+		return nil
+	}
+	cr.Status.UpdateStatus = status
+	return statusPatch(ctx, r, cr.DeepCopy(), cr.Status)
+}
+
+// GetAdditionalService returns AdditionalServiceSpec settings
+func (cr *VMAnomaly) GetAdditionalService() *AdditionalServiceSpec {
+	return cr.Spec.ServiceSpec
+}
+
+func init() {
+	SchemeBuilder.Register(&VMAnomaly{}, &VMAnomalyList{})
+}
@@ -62,6 +62,12 @@ type VMAuthSpec struct {
 	PodDisruptionBudget *EmbeddedPodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
 	// Ingress enables ingress configuration for VMAuth.
 	Ingress *EmbeddedIngress `json:"ingress,omitempty"`
+	// HTTPRoute enables Gateway API route configuration for VMAuth.
+	// +optional
+	HTTPRoute *EmbeddedHTTPRoute `json:"httpRoute,omitempty"`
+	// NetworkPolicy enables generation of NetworkPolicy for VMAuth pods.
+	// +optional
+	NetworkPolicy *EmbeddedNetworkPolicy `json:"networkPolicy,omitempty"`
 	// LivenessProbe that will be added to VMAuth pod
 	*EmbeddedProbes `json:",inline"`
 	// UnauthorizedAccessConfig configures access for un authorized users
@@ -262,6 +268,14 @@ type VMAuthStatus struct {
 	UpdateStatus UpdateStatus `json:"updateStatus,omitempty"`
 	// Reason defines fail reason for update process, effective only for statefulMode
 	Reason string `json:"reason,omitempty"`
+	// ObservedGeneration defines current generation picked by operator for the
+	// reconcile
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions defines latest available observations of object state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // VMAuth is the Schema for the vmauths API
@@ -462,6 +476,9 @@ func (cr *VMAuth) SetUpdateStatusTo(ctx context.Context, r client.Client, status
 	default:
 		panic(fmt.Sprintf("BUG: not expected status=%q", status))
 	}
+	cr.Status.ObservedGeneration = cr.Generation
+	cr.Status.Conditions = updateConditions(cr.Status.Conditions, cr.Generation, status, maybeErr)
+
 	if equality.Semantic.DeepEqual(&cr.Status, prevStatus) && status == currentStatus {
 		return nil
 	}
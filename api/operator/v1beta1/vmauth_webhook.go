@@ -33,6 +33,16 @@ func (r *VMAuth) SetupWebhookWithManager(mgr ctrl.Manager) error {
 }
 
 func (r *VMAuth) sanityCheck() error {
+	if r.Spec.VPA != nil {
+		if err := r.Spec.VPA.sanityCheck(); err != nil {
+			return fmt.Errorf("incorrect spec.vpa: %w", err)
+		}
+	}
+	if r.Spec.HTTPRoute != nil {
+		if err := r.Spec.HTTPRoute.Validate(); err != nil {
+			return fmt.Errorf("incorrect spec.httpRoute: %w", err)
+		}
+	}
 	if r.Spec.Ingress != nil {
 		// check ingress
 		// TlsHosts and TlsSecretName are both needed if one of them is used
@@ -40,6 +40,39 @@ func TestVMAuth_sanityCheck(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "route without parentRefs",
+			fields: fields{
+				Spec: VMAuthSpec{
+					HTTPRoute: &EmbeddedHTTPRoute{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "tls route with path prefixes",
+			fields: fields{
+				Spec: VMAuthSpec{
+					HTTPRoute: &EmbeddedHTTPRoute{
+						Kind:         TLSRouteKind,
+						ParentRefs:   []GatewayParentReference{{Name: "gw"}},
+						PathPrefixes: []string{"/api"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid route",
+			fields: fields{
+				Spec: VMAuthSpec{
+					HTTPRoute: &EmbeddedHTTPRoute{
+						ParentRefs:   []GatewayParentReference{{Name: "gw"}},
+						PathPrefixes: []string{"/api"},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,388 @@
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// BackupTargetKindVMSingle defines VMSingle as backup or restore target
+	BackupTargetKindVMSingle = "VMSingle"
+	// BackupTargetKindVMCluster defines VMCluster as backup or restore target
+	BackupTargetKindVMCluster = "VMCluster"
+)
+
+// BackupJobPhase defines phase of backup or restore job for single storage node
+type BackupJobPhase string
+
+const (
+	// BackupJobPhasePending means that job is created, but its pod is not started yet
+	BackupJobPhasePending BackupJobPhase = "Pending"
+	// BackupJobPhaseRunning means that job has active pod
+	BackupJobPhaseRunning BackupJobPhase = "Running"
+	// BackupJobPhaseSucceeded means that job completed successfully
+	BackupJobPhaseSucceeded BackupJobPhase = "Succeeded"
+	// BackupJobPhaseFailed means that job failed after all retries
+	BackupJobPhaseFailed BackupJobPhase = "Failed"
+	// BackupJobPhaseScheduled means that cronjob waits for the next scheduled run
+	BackupJobPhaseScheduled BackupJobPhase = "Scheduled"
+)
+
+// BackupTargetRef references VMSingle or VMCluster object
+// at the same namespace as backup or restore object
+type BackupTargetRef struct {
+	// Kind of the target object
+	// +kubebuilder:validation:Enum=VMSingle;VMCluster
+	Kind string `json:"kind"`
+	// Name of the target object
+	Name string `json:"name"`
+}
+
+// BackupJobCommonParams defines common params for vmbackup and vmrestore jobs
+type BackupJobCommonParams struct {
+	// Image - docker image settings
+	// if no specified operator uses default version from operator config
+	// +optional
+	Image Image `json:"image,omitempty"`
+	// ImagePullSecrets An optional list of references to secrets in the same namespace
+	// to use for pulling images from registries
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// Custom S3 endpoint for use with S3-compatible storages (e.g. MinIO). S3 is used if not set
+	// +optional
+	CustomS3Endpoint *string `json:"customS3Endpoint,omitempty"`
+	// CredentialsSecret is secret in the same namespace for access to remote storage
+	// The secret is mounted into /etc/vm/creds.
+	// +optional
+	CredentialsSecret *corev1.SecretKeySelector `json:"credentialsSecret,omitempty"`
+	// Defines number of concurrent workers. Higher concurrency may reduce backup duration (default 10)
+	// +optional
+	Concurrency *int32 `json:"concurrency,omitempty"`
+	// LogLevel for job container.
+	// +optional
+	// +kubebuilder:validation:Enum=INFO;WARN;ERROR;FATAL;PANIC
+	LogLevel *string `json:"logLevel,omitempty"`
+	// LogFormat for job container.
+	// +optional
+	// +kubebuilder:validation:Enum=default;json
+	LogFormat *string `json:"logFormat,omitempty"`
+	// Resources container resource request and limits, https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// ExtraArgs that will be passed to the job container
+	// for example -s3StorageClass=GLACIER
+	// +optional
+	ExtraArgs map[string]string `json:"extraArgs,omitempty"`
+	// ExtraEnvs that will be added to the job container
+	// +optional
+	ExtraEnvs []corev1.EnvVar `json:"extraEnvs,omitempty"`
+	// VolumeMounts allows configuration of additional VolumeMounts on the output Job definition.
+	// +optional
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+	// Volumes allows configuration of additional volumes on the output Job definition.
+	// +optional
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+	// BackoffLimit specifies the number of retries before marking job as failed
+	// +optional
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+	// TTLSecondsAfterFinished limits the lifetime of finished Jobs
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+	// ServiceAccountName is the name of the ServiceAccount to use for job pods
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+}
+
+// BackupJobNodeStatus defines status of the job for single storage node
+type BackupJobNodeStatus struct {
+	// Node is the name of storage pod
+	Node string `json:"node"`
+	// JobName is the name of Job or CronJob created for the node
+	JobName string `json:"jobName"`
+	// Phase of the node job
+	Phase BackupJobPhase `json:"phase"`
+	// Message contains human readable failure reason
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastSuccessTime is the completion time of the last successful job
+	// +optional
+	LastSuccessTime *metav1.Time `json:"lastSuccessTime,omitempty"`
+}
+
+// VMBackupJobSpec defines the desired state of VMBackupJob
+type VMBackupJobSpec struct {
+	// Target defines VMSingle or VMCluster to backup
+	Target BackupTargetRef `json:"target"`
+	// Destination is a remote storage url for backup
+	// e.g. s3://bucket/path/to/backup, operator appends storage pod name to it for VMCluster
+	Destination string `json:"destination"`
+	// DestinationDisableSuffixAdd - disables suffix with storage pod name for VMCluster backups
+	// +optional
+	DestinationDisableSuffixAdd bool `json:"destinationDisableSuffixAdd,omitempty"`
+	// Origin is an optional remote storage url with existing backup,
+	// it allows to perform server-side copy of already existing data
+	// +optional
+	Origin string `json:"origin,omitempty"`
+	// Schedule in Cron format, see https://en.wikipedia.org/wiki/Cron.
+	// Operator creates CronJob per storage node if defined,
+	// otherwise backup is executed once.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+	// Suspend pauses scheduled backups
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	BackupJobCommonParams `json:",inline"`
+}
+
+// VMBackupJobStatus defines the observed state of VMBackupJob
+type VMBackupJobStatus struct {
+	// UpdateStatus defines a status of backup process
+	UpdateStatus UpdateStatus `json:"status,omitempty"`
+	// Reason defines a reason of failure
+	Reason string `json:"reason,omitempty"`
+	// Progress shows number of completed nodes at format completed/total
+	Progress string `json:"progress,omitempty"`
+	// LastSuccessfulBackupTime is the time when backup finished successfully for all nodes
+	// +optional
+	LastSuccessfulBackupTime *metav1.Time `json:"lastSuccessfulBackupTime,omitempty"`
+	// Nodes contains per storage node job status
+	// +optional
+	Nodes []BackupJobNodeStatus `json:"nodes,omitempty"`
+	// ObservedGeneration defines generation of the object used for nodes status
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// VMBackupJob is the Schema for the vmbackupjobs API
+// It runs vmbackup for the referenced VMSingle or VMCluster storage
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=vmbackupjobs,scope=Namespaced
+// +kubebuilder:printcolumn:name="Target",type="string",JSONPath=".spec.target.name"
+// +kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.status"
+// +kubebuilder:printcolumn:name="Progress",type="string",JSONPath=".status.progress"
+// +kubebuilder:printcolumn:name="Last Success",type="date",JSONPath=".status.lastSuccessfulBackupTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +genclient
+type VMBackupJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VMBackupJobSpec   `json:"spec,omitempty"`
+	Status VMBackupJobStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VMBackupJobList contains a list of VMBackupJob
+type VMBackupJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMBackupJob `json:"items"`
+}
+
+// AsOwner returns owner references with current object as owner
+func (cr *VMBackupJob) AsOwner() []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			APIVersion:         cr.APIVersion,
+			Kind:               cr.Kind,
+			Name:               cr.Name,
+			UID:                cr.UID,
+			Controller:         ptr.To(true),
+			BlockOwnerDeletion: ptr.To(true),
+		},
+	}
+}
+
+// PrefixedName returns prefixed name for job objects
+func (cr *VMBackupJob) PrefixedName() string {
+	return fmt.Sprintf("vmbackupjob-%s", cr.Name)
+}
+
+// SelectorLabels returns selector labels for job objects
+func (cr *VMBackupJob) SelectorLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":      "vmbackupjob",
+		"app.kubernetes.io/instance":  cr.Name,
+		"app.kubernetes.io/component": "monitoring",
+		"managed-by":                  "vm-operator",
+	}
+}
+
+// AllLabels returns combined labels for VMBackupJob
+func (cr *VMBackupJob) AllLabels() map[string]string {
+	labels := cr.SelectorLabels()
+	for label, value := range cr.Labels {
+		if _, ok := labels[label]; ok {
+			// forbid changes for selector labels
+			continue
+		}
+		labels[label] = value
+	}
+	return labels
+}
+
+// AnnotationsFiltered returns global annotations to be applied by objects generate for vmbackupjob
+func (cr *VMBackupJob) AnnotationsFiltered() map[string]string {
+	return filterBackupJobAnnotations(cr.Annotations)
+}
+
+// IsScheduled checks if backup must be executed periodically
+func (cr *VMBackupJob) IsScheduled() bool {
+	return cr.Spec.Schedule != ""
+}
+
+// IsFinished checks if one time backup reached terminal phase for all nodes at the current generation
+// jobs of finished backup must not be created again, since they could be removed by ttlSecondsAfterFinished
+func (cr *VMBackupJob) IsFinished() bool {
+	return !cr.IsScheduled() && cr.Status.ObservedGeneration == cr.Generation && backupJobNodesFinished(cr.Status.Nodes)
+}
+
+// SetUpdateStatusTo changes update status with optional reason of fail
+func (cr *VMBackupJob) SetUpdateStatusTo(ctx context.Context, r client.Client, status UpdateStatus, maybeErr error) error {
+	return cr.patchUpdateStatus(ctx, r, cr.Status.DeepCopy(), status, maybeErr)
+}
+
+// patchUpdateStatus sets update status and patches it, if status differs from prevStatus
+func (cr *VMBackupJob) patchUpdateStatus(ctx context.Context, r client.Client, prevStatus *VMBackupJobStatus, status UpdateStatus, maybeErr error) error {
+	switch status {
+	case UpdateStatusExpanding, UpdateStatusOperational:
+		cr.Status.Reason = ""
+	case UpdateStatusFailed:
+		if maybeErr != nil {
+			cr.Status.Reason = maybeErr.Error()
+		}
+	default:
+		panic(fmt.Sprintf("BUG: not expected status=%q", status))
+	}
+	cr.Status.UpdateStatus = status
+	if equality.Semantic.DeepEqual(&cr.Status, prevStatus) {
+		return nil
+	}
+	return statusPatch(ctx, r, cr.DeepCopy(), cr.Status)
+}
+
+// SetNodesStatus updates per node status and progress of backup
+func (cr *VMBackupJob) SetNodesStatus(ctx context.Context, r client.Client, nodes []BackupJobNodeStatus) error {
+	prevStatus := cr.Status.DeepCopy()
+	status, reason := backupJobStatusFromNodes(nodes, cr.IsScheduled())
+	cr.Status.Nodes = nodes
+	cr.Status.ObservedGeneration = cr.Generation
+	cr.Status.Progress = backupJobProgress(nodes)
+	if last := lastBackupJobSuccess(nodes, cr.IsScheduled()); last != nil {
+		cr.Status.LastSuccessfulBackupTime = last
+	}
+	return cr.patchUpdateStatus(ctx, r, prevStatus, status, reason)
+}
+
+// SnapshotCreateURLFor builds snapshot create url for the given storage base url
+// it respects http.pathPrefix and snapshotAuthKey flags of the storage
+func SnapshotCreateURLFor(baseURL string, extraArgs map[string]string) string {
+	return joinBackupAuthKey(strings.TrimSuffix(baseURL, "/")+path.Join(buildPathWithPrefixFlag(extraArgs, snapshotCreate)), extraArgs)
+}
+
+// SnapshotDeleteURLFor builds snapshot delete url for the given storage base url
+// it respects http.pathPrefix and snapshotAuthKey flags of the storage
+func SnapshotDeleteURLFor(baseURL string, extraArgs map[string]string) string {
+	return joinBackupAuthKey(strings.TrimSuffix(baseURL, "/")+path.Join(buildPathWithPrefixFlag(extraArgs, snapshotDelete)), extraArgs)
+}
+
+func filterBackupJobAnnotations(src map[string]string) map[string]string {
+	annotations := make(map[string]string)
+	for annotation, value := range src {
+		if !strings.HasPrefix(annotation, "kubectl.kubernetes.io/") {
+			annotations[annotation] = value
+		}
+	}
+	return annotations
+}
+
+func backupJobStatusFromNodes(nodes []BackupJobNodeStatus, scheduled bool) (UpdateStatus, error) {
+	var failed []string
+	allDone := true
+	for _, node := range nodes {
+		switch node.Phase {
+		case BackupJobPhaseFailed:
+			failed = append(failed, fmt.Sprintf("node=%q: %s", node.Node, node.Message))
+		case BackupJobPhaseSucceeded:
+		case BackupJobPhaseScheduled:
+			if !scheduled {
+				allDone = false
+			}
+		default:
+			allDone = false
+		}
+	}
+	switch {
+	case len(failed) > 0:
+		return UpdateStatusFailed, fmt.Errorf("jobs failed for %s", strings.Join(failed, ","))
+	case allDone:
+		return UpdateStatusOperational, nil
+	default:
+		return UpdateStatusExpanding, nil
+	}
+}
+
+// backupJobNodesFinished checks if jobs of all nodes are either succeeded or failed
+func backupJobNodesFinished(nodes []BackupJobNodeStatus) bool {
+	if len(nodes) == 0 {
+		return false
+	}
+	for _, node := range nodes {
+		if node.Phase != BackupJobPhaseSucceeded && node.Phase != BackupJobPhaseFailed {
+			return false
+		}
+	}
+	return true
+}
+
+func backupJobProgress(nodes []BackupJobNodeStatus) string {
+	var completed int
+	for _, node := range nodes {
+		if node.Phase == BackupJobPhaseSucceeded {
+			completed++
+		}
+	}
+	return fmt.Sprintf("%d/%d", completed, len(nodes))
+}
+
+// lastBackupJobSuccess returns the time when all nodes completed job successfully
+// for scheduled jobs it's the oldest successful run, since nodes could be backed up at different time
+func lastBackupJobSuccess(nodes []BackupJobNodeStatus, scheduled bool) *metav1.Time {
+	if len(nodes) == 0 {
+		return nil
+	}
+	var last *metav1.Time
+	for _, node := range nodes {
+		if node.LastSuccessTime == nil {
+			return nil
+		}
+		if !scheduled && node.Phase != BackupJobPhaseSucceeded {
+			return nil
+		}
+		switch {
+		case last == nil:
+			last = node.LastSuccessTime
+		case scheduled && node.LastSuccessTime.Before(last):
+			last = node.LastSuccessTime
+		case !scheduled && last.Before(node.LastSuccessTime):
+			last = node.LastSuccessTime
+		}
+	}
+	return last
+}
+
+func init() {
+	SchemeBuilder.Register(&VMBackupJob{}, &VMBackupJobList{})
+}
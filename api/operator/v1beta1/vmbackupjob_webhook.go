@@ -0,0 +1,103 @@
+package v1beta1
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/robfig/cron/v3"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *VMBackupJob) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-operator-victoriametrics-com-v1beta1-vmbackupjob,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.victoriametrics.com,resources=vmbackupjobs,verbs=create;update,versions=v1beta1,name=vvmbackupjob.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &VMBackupJob{}
+
+// Validate performs logical validation
+func (r *VMBackupJob) Validate() error {
+	if mustSkipValidation(r) {
+		return nil
+	}
+	if err := validateBackupRemotePath(r.Spec.Destination); err != nil {
+		return fmt.Errorf("incorrect spec.destination: %w", err)
+	}
+	if r.Spec.Origin != "" {
+		if err := validateBackupRemotePath(r.Spec.Origin); err != nil {
+			return fmt.Errorf("incorrect spec.origin: %w", err)
+		}
+	}
+	if r.Spec.Schedule != "" {
+		if err := validateCronSchedule(r.Spec.Schedule); err != nil {
+			return fmt.Errorf("incorrect spec.schedule: %w", err)
+		}
+	}
+	return nil
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *VMBackupJob) ValidateCreate() (admission.Warnings, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *VMBackupJob) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *VMBackupJob) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateBackupRemotePath checks if path is supported by vmbackup and vmrestore
+// see https://docs.victoriametrics.com/vmbackup/#advanced-usage
+func validateBackupRemotePath(remotePath string) error {
+	if remotePath == "" {
+		return fmt.Errorf("remote path cannot be empty")
+	}
+	u, err := url.Parse(remotePath)
+	if err != nil {
+		return fmt.Errorf("cannot parse remote path=%q: %w", remotePath, err)
+	}
+	switch u.Scheme {
+	case "s3", "gs", "azblob":
+		if u.Host == "" {
+			return fmt.Errorf("bucket name is missing at remote path=%q", remotePath)
+		}
+	case "fs":
+		if strings.TrimPrefix(remotePath, "fs://") == "" {
+			return fmt.Errorf("directory is missing at remote path=%q", remotePath)
+		}
+	default:
+		return fmt.Errorf("unsupported scheme=%q at remote path=%q, want one of s3, gs, azblob or fs", u.Scheme, remotePath)
+	}
+	return nil
+}
+
+// validateCronSchedule checks if schedule has format supported by kubernetes CronJob
+// it performs the same checks as kubernetes api server does for CronJob spec.schedule
+func validateCronSchedule(schedule string) error {
+	if strings.Contains(schedule, "TZ") {
+		return fmt.Errorf("TZ and CRON_TZ are not supported at schedule=%q", schedule)
+	}
+	if _, err := cron.ParseStandard(schedule); err != nil {
+		return fmt.Errorf("cannot parse schedule=%q: %w", schedule, err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package v1beta1
+
+import (
+	"testing"
+)
+
+func TestVMBackupJob_Validate(t *testing.T) {
+	f := func(spec VMBackupJobSpec, wantErr bool) {
+		t.Helper()
+		cr := &VMBackupJob{Spec: spec}
+		err := cr.Validate()
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected validation result, wantErr: %v, got err: %v", wantErr, err)
+		}
+	}
+
+	// one time backup
+	f(VMBackupJobSpec{Destination: "s3://bucket/path"}, false)
+	f(VMBackupJobSpec{Destination: "fs:///backups", Origin: "gs://bucket/origin"}, false)
+
+	// scheduled backup
+	f(VMBackupJobSpec{Destination: "azblob://container/path", Schedule: "0 1 * * *"}, false)
+	f(VMBackupJobSpec{Destination: "s3://bucket/path", Schedule: "*/15 0-6,22-23 ? JAN-MAR mon-fri"}, false)
+	f(VMBackupJobSpec{Destination: "s3://bucket/path", Schedule: "@daily"}, false)
+	f(VMBackupJobSpec{Destination: "s3://bucket/path", Schedule: "@every 1h"}, false)
+
+	// bad destination
+	f(VMBackupJobSpec{}, true)
+	f(VMBackupJobSpec{Destination: "/local/path"}, true)
+	f(VMBackupJobSpec{Destination: "http://bucket/path"}, true)
+	f(VMBackupJobSpec{Destination: "s3:///path"}, true)
+	f(VMBackupJobSpec{Destination: "fs://"}, true)
+	f(VMBackupJobSpec{Destination: "s3://bucket/path", Origin: "bucket/origin"}, true)
+
+	// bad schedule
+	f(VMBackupJobSpec{Destination: "s3://bucket/path", Schedule: "0 1 * *"}, true)
+	f(VMBackupJobSpec{Destination: "s3://bucket/path", Schedule: "60 1 * * *"}, true)
+	f(VMBackupJobSpec{Destination: "s3://bucket/path", Schedule: "0 5-1 * * *"}, true)
+	f(VMBackupJobSpec{Destination: "s3://bucket/path", Schedule: "*/0 * * * *"}, true)
+	f(VMBackupJobSpec{Destination: "s3://bucket/path", Schedule: "@every 1x"}, true)
+	f(VMBackupJobSpec{Destination: "s3://bucket/path", Schedule: "@fortnightly"}, true)
+	f(VMBackupJobSpec{Destination: "s3://bucket/path", Schedule: "CRON_TZ=UTC 0 1 * * *"}, true)
+}
+
+func TestVMRestoreJob_Validate(t *testing.T) {
+	f := func(source string, wantErr bool) {
+		t.Helper()
+		cr := &VMRestoreJob{Spec: VMRestoreJobSpec{Source: source}}
+		err := cr.Validate()
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected validation result, wantErr: %v, got err: %v", wantErr, err)
+		}
+	}
+
+	f("s3://bucket/path", false)
+	f("", true)
+	f("bucket/path", true)
+}
@@ -0,0 +1,573 @@
+package v1beta1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VMBlackboxExporterSpec defines the desired state of VMBlackboxExporter
+// +k8s:openapi-gen=true
+type VMBlackboxExporterSpec struct {
+	// ParsingError contents error with context if operator was failed to parse json object from kubernetes api server
+	ParsingError string `json:"-" yaml:"-"`
+	// PodMetadata configures Labels and Annotations which are propagated to the blackbox exporter pods.
+	PodMetadata *EmbeddedObjectMetadata `json:"podMetadata,omitempty"`
+	// LogLevel for blackbox exporter to be configured with.
+	// +optional
+	// +kubebuilder:validation:Enum=debug;info;warn;error
+	LogLevel string `json:"logLevel,omitempty"`
+	// Modules defines probing modules of blackbox exporter.
+	// Module name could be referenced by VMProbe at spec.module
+	// See [here](https://github.com/prometheus/blackbox_exporter/blob/master/CONFIGURATION.md) for details
+	// +kubebuilder:validation:MinProperties=1
+	Modules map[string]BlackboxModule `json:"modules"`
+
+	// ServiceSpec that will be added to blackbox exporter service spec
+	// +optional
+	ServiceSpec *AdditionalServiceSpec `json:"serviceSpec,omitempty"`
+	// ServiceScrapeSpec that will be added to blackbox exporter VMServiceScrape spec
+	// +optional
+	ServiceScrapeSpec *VMServiceScrapeSpec `json:"serviceScrapeSpec,omitempty"`
+
+	// UpdateStrategy - overrides default update strategy.
+	// +kubebuilder:validation:Enum=Recreate;RollingUpdate
+	// +optional
+	UpdateStrategy *appsv1.DeploymentStrategyType `json:"updateStrategy,omitempty"`
+	// RollingUpdate - overrides deployment update params.
+	// +optional
+	RollingUpdate *appsv1.RollingUpdateDeployment `json:"rollingUpdate,omitempty"`
+	// PodDisruptionBudget created by operator
+	// +optional
+	PodDisruptionBudget *EmbeddedPodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+	*EmbeddedProbes     `json:",inline"`
+
+	// ServiceAccountName is the name of the ServiceAccount to use to run the pods
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	CommonDefaultableParams           `json:",inline,omitempty"`
+	CommonConfigReloaderParams        `json:",inline,omitempty"`
+	CommonApplicationDeploymentParams `json:",inline,omitempty"`
+}
+
+// BlackboxModule defines blackbox exporter probing module
+type BlackboxModule struct {
+	// Prober defines protocol used for probing
+	// +kubebuilder:validation:Enum=http;tcp;icmp;dns;grpc
+	Prober string `json:"prober"`
+	// Timeout defines probe timeout, e.g. 5s
+	// +optional
+	Timeout string `json:"timeout,omitempty"`
+	// HTTP configures http prober
+	// +optional
+	HTTP *BlackboxHTTPProbe `json:"http,omitempty"`
+	// TCP configures tcp prober
+	// +optional
+	TCP *BlackboxTCPProbe `json:"tcp,omitempty"`
+	// ICMP configures icmp prober
+	// +optional
+	ICMP *BlackboxICMPProbe `json:"icmp,omitempty"`
+	// DNS configures dns prober
+	// +optional
+	DNS *BlackboxDNSProbe `json:"dns,omitempty"`
+	// GRPC configures grpc prober
+	// +optional
+	GRPC *BlackboxGRPCProbe `json:"grpc,omitempty"`
+}
+
+// BlackboxIPProtocol defines ip protocol settings for prober
+type BlackboxIPProtocol struct {
+	// PreferredIPProtocol defines ip protocol used for target address resolution
+	// +kubebuilder:validation:Enum=ip4;ip6
+	// +optional
+	PreferredIPProtocol string `json:"preferred_ip_protocol,omitempty"`
+	// IPProtocolFallback allows to fallback to another ip protocol
+	// +optional
+	IPProtocolFallback *bool `json:"ip_protocol_fallback,omitempty"`
+}
+
+// BlackboxTLSConfig defines tls settings for prober
+type BlackboxTLSConfig struct {
+	// InsecureSkipVerify disables target certificate validation
+	// +optional
+	InsecureSkipVerify bool `json:"insecure_skip_verify,omitempty"`
+	// ServerName defines name used for certificate validation
+	// +optional
+	ServerName string `json:"server_name,omitempty"`
+	// MinVersion defines minimal accepted TLS version
+	// +kubebuilder:validation:Enum=TLS10;TLS11;TLS12;TLS13
+	// +optional
+	MinVersion string `json:"min_version,omitempty"`
+}
+
+// BlackboxHTTPProbe defines http prober configuration
+type BlackboxHTTPProbe struct {
+	BlackboxIPProtocol `json:",inline"`
+	// ValidStatusCodes defines accepted status codes. Defaults to 2xx
+	// +optional
+	ValidStatusCodes []int `json:"valid_status_codes,omitempty"`
+	// ValidHTTPVersions defines accepted http versions, e.g. HTTP/1.1
+	// +optional
+	ValidHTTPVersions []string `json:"valid_http_versions,omitempty"`
+	// Method defines http request method. Defaults to GET
+	// +optional
+	Method string `json:"method,omitempty"`
+	// Headers defines http request headers
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+	// Body defines http request body
+	// +optional
+	Body string `json:"body,omitempty"`
+	// Compression defines expected response compression, e.g. gzip
+	// +optional
+	Compression string `json:"compression,omitempty"`
+	// FollowRedirects allows to follow redirects. Defaults to true
+	// +optional
+	FollowRedirects *bool `json:"follow_redirects,omitempty"`
+	// FailIfSSL fails probe if ssl is used
+	// +optional
+	FailIfSSL bool `json:"fail_if_ssl,omitempty"`
+	// FailIfNotSSL fails probe if ssl isn't used
+	// +optional
+	FailIfNotSSL bool `json:"fail_if_not_ssl,omitempty"`
+	// FailIfBodyMatchesRegexp fails probe if response body matches any of regexps
+	// +optional
+	FailIfBodyMatchesRegexp []string `json:"fail_if_body_matches_regexp,omitempty"`
+	// FailIfBodyNotMatchesRegexp fails probe if response body doesn't match any of regexps
+	// +optional
+	FailIfBodyNotMatchesRegexp []string `json:"fail_if_body_not_matches_regexp,omitempty"`
+	// FailIfHeaderMatches fails probe if response header matches regexp
+	// +optional
+	FailIfHeaderMatches []BlackboxHeaderMatch `json:"fail_if_header_matches,omitempty"`
+	// FailIfHeaderNotMatches fails probe if response header doesn't match regexp
+	// +optional
+	FailIfHeaderNotMatches []BlackboxHeaderMatch `json:"fail_if_header_not_matches,omitempty"`
+	// TLSConfig defines tls settings for https requests
+	// +optional
+	TLSConfig *BlackboxTLSConfig `json:"tls_config,omitempty"`
+}
+
+// BlackboxHeaderMatch defines regexp check for http response header
+type BlackboxHeaderMatch struct {
+	// Header defines name of response header
+	Header string `json:"header"`
+	// Regexp defines regular expression for header value
+	Regexp string `json:"regexp"`
+	// AllowMissing defines if missing header is accepted
+	// +optional
+	AllowMissing bool `json:"allow_missing,omitempty"`
+}
+
+// BlackboxTCPProbe defines tcp prober configuration
+type BlackboxTCPProbe struct {
+	BlackboxIPProtocol `json:",inline"`
+	// SourceIPAddress defines source address of connection
+	// +optional
+	SourceIPAddress string `json:"source_ip_address,omitempty"`
+	// QueryResponse defines dialog with target
+	// +optional
+	QueryResponse []BlackboxTCPQueryResponse `json:"query_response,omitempty"`
+	// TLS enables tls for connection
+	// +optional
+	TLS bool `json:"tls,omitempty"`
+	// TLSConfig defines tls settings for connection
+	// +optional
+	TLSConfig *BlackboxTLSConfig `json:"tls_config,omitempty"`
+}
+
+// BlackboxTCPQueryResponse defines single step of tcp dialog
+type BlackboxTCPQueryResponse struct {
+	// Expect defines regexp for expected target response
+	// +optional
+	Expect string `json:"expect,omitempty"`
+	// Send defines data sent to target
+	// +optional
+	Send string `json:"send,omitempty"`
+	// StartTLS upgrades connection to tls
+	// +optional
+	StartTLS bool `json:"starttls,omitempty"`
+}
+
+// BlackboxICMPProbe defines icmp prober configuration
+type BlackboxICMPProbe struct {
+	BlackboxIPProtocol `json:",inline"`
+	// SourceIPAddress defines source address of icmp packets
+	// +optional
+	SourceIPAddress string `json:"source_ip_address,omitempty"`
+	// PayloadSize defines size of icmp packet payload
+	// +optional
+	PayloadSize int `json:"payload_size,omitempty"`
+	// DontFragment sets DF flag for ip4 packets
+	// +optional
+	DontFragment bool `json:"dont_fragment,omitempty"`
+	// TTL defines ttl of icmp packets
+	// +optional
+	TTL int `json:"ttl,omitempty"`
+}
+
+// BlackboxDNSProbe defines dns prober configuration
+type BlackboxDNSProbe struct {
+	BlackboxIPProtocol `json:",inline"`
+	// SourceIPAddress defines source address of dns requests
+	// +optional
+	SourceIPAddress string `json:"source_ip_address,omitempty"`
+	// TransportProtocol defines dns transport
+	// +kubebuilder:validation:Enum=udp;tcp
+	// +optional
+	TransportProtocol string `json:"transport_protocol,omitempty"`
+	// DNSOverTLS enables dns over tls, requires tcp transport
+	// +optional
+	DNSOverTLS bool `json:"dns_over_tls,omitempty"`
+	// TLSConfig defines tls settings for dns over tls
+	// +optional
+	TLSConfig *BlackboxTLSConfig `json:"tls_config,omitempty"`
+	// QueryName defines name to resolve
+	QueryName string `json:"query_name"`
+	// QueryType defines type of dns record. Defaults to ANY
+	// +optional
+	QueryType string `json:"query_type,omitempty"`
+	// QueryClass defines class of dns record. Defaults to IN
+	// +optional
+	QueryClass string `json:"query_class,omitempty"`
+	// RecursionDesired sets RD flag for dns request. Defaults to true
+	// +optional
+	RecursionDesired *bool `json:"recursion_desired,omitempty"`
+	// ValidRcodes defines accepted response codes. Defaults to NOERROR
+	// +optional
+	ValidRcodes []string `json:"valid_rcodes,omitempty"`
+	// ValidateAnswerRRs defines checks for answer section of response
+	// +optional
+	ValidateAnswerRRs *BlackboxDNSRRValidator `json:"validate_answer_rrs,omitempty"`
+	// ValidateAuthorityRRs defines checks for authority section of response
+	// +optional
+	ValidateAuthorityRRs *BlackboxDNSRRValidator `json:"validate_authority_rrs,omitempty"`
+	// ValidateAdditionalRRs defines checks for additional section of response
+	// +optional
+	ValidateAdditionalRRs *BlackboxDNSRRValidator `json:"validate_additional_rrs,omitempty"`
+}
+
+// BlackboxDNSRRValidator defines regexp checks for dns response records
+type BlackboxDNSRRValidator struct {
+	// FailIfMatchesRegexp fails probe if any record matches regexp
+	// +optional
+	FailIfMatchesRegexp []string `json:"fail_if_matches_regexp,omitempty"`
+	// FailIfAllMatchRegexp fails probe if all records match regexp
+	// +optional
+	FailIfAllMatchRegexp []string `json:"fail_if_all_match_regexp,omitempty"`
+	// FailIfNotMatchesRegexp fails probe if any record doesn't match regexp
+	// +optional
+	FailIfNotMatchesRegexp []string `json:"fail_if_not_matches_regexp,omitempty"`
+	// FailIfNoneMatchesRegexp fails probe if none of records matches regexp
+	// +optional
+	FailIfNoneMatchesRegexp []string `json:"fail_if_none_matches_regexp,omitempty"`
+}
+
+// BlackboxGRPCProbe defines grpc prober configuration
+type BlackboxGRPCProbe struct {
+	BlackboxIPProtocol `json:",inline"`
+	// Service defines grpc service name for health check
+	// +optional
+	Service string `json:"service,omitempty"`
+	// TLS enables tls for connection
+	// +optional
+	TLS bool `json:"tls,omitempty"`
+	// TLSConfig defines tls settings for connection
+	// +optional
+	TLSConfig *BlackboxTLSConfig `json:"tls_config,omitempty"`
+}
+
+// VMBlackboxExporterRef references VMBlackboxExporter object
+type VMBlackboxExporterRef struct {
+	// Name of VMBlackboxExporter object
+	Name string `json:"name"`
+	// Namespace of VMBlackboxExporter object.
+	// Defaults to the namespace of referencing object
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (cr *VMBlackboxExporter) UnmarshalJSON(src []byte) error {
+	type pcr VMBlackboxExporter
+	if err := json.Unmarshal(src, (*pcr)(cr)); err != nil {
+		return err
+	}
+	prev, err := parseLastAppliedSpec[VMBlackboxExporterSpec](cr)
+	if err != nil {
+		return err
+	}
+	cr.ParsedLastAppliedSpec = prev
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (cr *VMBlackboxExporterSpec) UnmarshalJSON(src []byte) error {
+	type pcr VMBlackboxExporterSpec
+	if err := json.Unmarshal(src, (*pcr)(cr)); err != nil {
+		cr.ParsingError = fmt.Sprintf("cannot parse vmblackboxexporter spec: %s, err: %s", string(src), err)
+		return nil
+	}
+	return nil
+}
+
+// VMBlackboxExporterStatus defines the observed state of VMBlackboxExporter
+// +k8s:openapi-gen=true
+type VMBlackboxExporterStatus struct {
+	// Probes lists VMProbe objects in form of namespace/name, which use this blackbox exporter
+	// +optional
+	Probes []string `json:"probes,omitempty"`
+	// UpdateStatus defines a status for update rollout
+	UpdateStatus UpdateStatus `json:"updateStatus,omitempty"`
+	// Reason defines fail reason for update process
+	Reason string `json:"reason,omitempty"`
+	// ObservedGeneration defines current generation picked by operator for the
+	// reconcile
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions defines latest available observations of object state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// VMBlackboxExporter is the Schema for the vmblackboxexporters API.
+// It runs [blackbox exporter](https://github.com/prometheus/blackbox_exporter) with configured modules,
+// which could be referenced by VMProbe objects
+// +operator-sdk:gen-csv:customresourcedefinitions.displayName="VMBlackboxExporter App"
+// +operator-sdk:gen-csv:customresourcedefinitions.resources="Deployment,v1"
+// +operator-sdk:gen-csv:customresourcedefinitions.resources="Service,v1"
+// +operator-sdk:gen-csv:customresourcedefinitions.resources="ConfigMap,v1"
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=vmblackboxexporters,scope=Namespaced
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus",description="Current status of update rollout"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type VMBlackboxExporter struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VMBlackboxExporterSpec `json:"spec,omitempty"`
+	// ParsedLastAppliedSpec contains last-applied configuration spec
+	ParsedLastAppliedSpec *VMBlackboxExporterSpec `json:"-" yaml:"-"`
+
+	Status VMBlackboxExporterStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VMBlackboxExporterList contains a list of VMBlackboxExporter
+type VMBlackboxExporterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMBlackboxExporter `json:"items"`
+}
+
+func (cr *VMBlackboxExporter) Probe() *EmbeddedProbes {
+	return cr.Spec.EmbeddedProbes
+}
+
+func (cr *VMBlackboxExporter) ProbePath() string {
+	return "/-/healthy"
+}
+
+func (cr *VMBlackboxExporter) ProbeScheme() string {
+	return "HTTP"
+}
+
+func (cr VMBlackboxExporter) ProbePort() string {
+	return cr.Spec.Port
+}
+
+func (cr VMBlackboxExporter) ProbeNeedLiveness() bool {
+	return true
+}
+
+func (cr *VMBlackboxExporter) AsOwner() []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			APIVersion:         cr.APIVersion,
+			Kind:               cr.Kind,
+			Name:               cr.Name,
+			UID:                cr.UID,
+			Controller:         ptr.To(true),
+			BlockOwnerDeletion: ptr.To(true),
+		},
+	}
+}
+
+func (cr VMBlackboxExporter) PodAnnotations() map[string]string {
+	annotations := map[string]string{}
+	if cr.Spec.PodMetadata != nil {
+		for annotation, value := range cr.Spec.PodMetadata.Annotations {
+			annotations[annotation] = value
+		}
+	}
+	return annotations
+}
+
+func (cr VMBlackboxExporter) AnnotationsFiltered() map[string]string {
+	return filterMapKeysByPrefixes(cr.ObjectMeta.Annotations, annotationFilterPrefixes)
+}
+
+func (cr VMBlackboxExporter) SelectorLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":      "vmblackboxexporter",
+		"app.kubernetes.io/instance":  cr.Name,
+		"app.kubernetes.io/component": "monitoring",
+		"managed-by":                  "vm-operator",
+	}
+}
+
+func (cr VMBlackboxExporter) PodLabels() map[string]string {
+	lbls := cr.SelectorLabels()
+	if cr.Spec.PodMetadata == nil {
+		return lbls
+	}
+	return labels.Merge(cr.Spec.PodMetadata.Labels, lbls)
+}
+
+func (cr VMBlackboxExporter) AllLabels() map[string]string {
+	selectorLabels := cr.SelectorLabels()
+	// fast path
+	if cr.ObjectMeta.Labels == nil {
+		return selectorLabels
+	}
+	crLabels := filterMapKeysByPrefixes(cr.ObjectMeta.Labels, labelFilterPrefixes)
+	return labels.Merge(crLabels, selectorLabels)
+}
+
+func (cr VMBlackboxExporter) PrefixedName() string {
+	return fmt.Sprintf("vmblackboxexporter-%s", cr.Name)
+}
+
+// ProberAddress returns address of blackbox exporter service in form of host:port
+func (cr VMBlackboxExporter) ProberAddress() string {
+	port := cr.Spec.Port
+	if port == "" {
+		port = "9115"
+	}
+	return fmt.Sprintf("%s.%s.svc:%s", cr.PrefixedName(), cr.Namespace, port)
+}
+
+// HasModule checks if module with given name is configured
+func (cr VMBlackboxExporter) HasModule(name string) bool {
+	_, ok := cr.Spec.Modules[name]
+	return ok
+}
+
+// GetMetricPath returns prefixed path for metric requests
+func (cr VMBlackboxExporter) GetMetricPath() string {
+	return metricPath
+}
+
+// GetExtraArgs returns additionally configured command-line arguments
+func (cr VMBlackboxExporter) GetExtraArgs() map[string]string {
+	return cr.Spec.ExtraArgs
+}
+
+// GetServiceScrape returns overrides for serviceScrape builder
+func (cr VMBlackboxExporter) GetServiceScrape() *VMServiceScrapeSpec {
+	return cr.Spec.ServiceScrapeSpec
+}
+
+func (cr VMBlackboxExporter) GetServiceAccountName() string {
+	if cr.Spec.ServiceAccountName == "" {
+		return cr.PrefixedName()
+	}
+	return cr.Spec.ServiceAccountName
+}
+
+func (cr VMBlackboxExporter) IsOwnsServiceAccount() bool {
+	return cr.Spec.ServiceAccountName == ""
+}
+
+func (cr VMBlackboxExporter) GetNSName() string {
+	return cr.GetNamespace()
+}
+
+// LastAppliedSpecAsPatch return last applied vmblackboxexporter spec as patch annotation
+func (cr *VMBlackboxExporter) LastAppliedSpecAsPatch() (client.Patch, error) {
+	data, err := json.Marshal(cr.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("possible bug, cannot serialize specification as json :%w", err)
+	}
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q: %q}}}`, lastAppliedSpecAnnotationName, data)
+	return client.RawPatch(types.MergePatchType, []byte(patch)), nil
+}
+
+// HasSpecChanges compares spec with last applied vmblackboxexporter spec stored in annotation
+func (cr *VMBlackboxExporter) HasSpecChanges() (bool, error) {
+	lastAppliedJSON := cr.Annotations[lastAppliedSpecAnnotationName]
+	if len(lastAppliedJSON) == 0 {
+		return true, nil
+	}
+	instanceSpecData, err := json.Marshal(cr.Spec)
+	if err != nil {
+		return true, err
+	}
+	return !bytes.Equal([]byte(lastAppliedJSON), instanceSpecData), nil
+}
+
+func (cr *VMBlackboxExporter) Paused() bool {
+	return cr.Spec.Paused
+}
+
+// SetUpdateStatusTo changes update status with optional reason of fail
+func (cr *VMBlackboxExporter) SetUpdateStatusTo(ctx context.Context, r client.Client, status UpdateStatus, maybeErr error) error {
+	currentStatus := cr.Status.UpdateStatus
+	prevStatus := cr.Status.DeepCopy()
+	switch status {
+	case UpdateStatusExpanding:
+	case UpdateStatusFailed:
+		if maybeErr != nil {
+			cr.Status.Reason = maybeErr.Error()
+		}
+	case UpdateStatusOperational:
+		cr.Status.Reason = ""
+	case UpdateStatusPaused:
+		if currentStatus == status {
+			return nil
+		}
+	default:
+		panic(fmt.Sprintf("BUG: not expected status=%q", status))
+	}
+	cr.Status.ObservedGeneration = cr.Generation
+	cr.Status.Conditions = updateConditions(cr.Status.Conditions, cr.Generation, status, maybeErr)
+
+	if equality.Semantic.DeepEqual(&cr.Status, prevStatus) && currentStatus == status {
+		return nil
+	}
+	cr.Status.UpdateStatus = status
+	return statusPatch(ctx, r, cr.DeepCopy(), cr.Status)
+}
+
+// SetProbesStatus updates list of VMProbe objects, which use blackbox exporter
+func (cr *VMBlackboxExporter) SetProbesStatus(ctx context.Context, r client.Client, probes []string) error {
+	if equality.Semantic.DeepEqual(cr.Status.Probes, probes) {
+		return nil
+	}
+	cr.Status.Probes = probes
+	return statusPatch(ctx, r, cr.DeepCopy(), cr.Status)
+}
+
+// GetAdditionalService returns AdditionalServiceSpec settings
+func (cr *VMBlackboxExporter) GetAdditionalService() *AdditionalServiceSpec {
+	return cr.Spec.ServiceSpec
+}
+
+func init() {
+	SchemeBuilder.Register(&VMBlackboxExporter{}, &VMBlackboxExporterList{})
+}
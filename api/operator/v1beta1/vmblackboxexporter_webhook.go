@@ -0,0 +1,150 @@
+package v1beta1
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/VictoriaMetrics/VictoriaMetrics/lib/promutils"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *VMBlackboxExporter) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-operator-victoriametrics-com-v1beta1-vmblackboxexporter,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.victoriametrics.com,resources=vmblackboxexporters,verbs=create;update,versions=v1beta1,name=vvmblackboxexporter.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &VMBlackboxExporter{}
+
+// Validate performs logical validation
+func (r *VMBlackboxExporter) Validate() error {
+	if r.Spec.ParsingError != "" {
+		return fmt.Errorf(r.Spec.ParsingError)
+	}
+	if mustSkipValidation(r) {
+		return nil
+	}
+	if len(r.Spec.Modules) == 0 {
+		return fmt.Errorf("at least 1 module must be provided for spec.modules")
+	}
+	names := make([]string, 0, len(r.Spec.Modules))
+	for name := range r.Spec.Modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		module := r.Spec.Modules[name]
+		if err := validateBlackboxModule(&module); err != nil {
+			return fmt.Errorf("incorrect module=%q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func validateBlackboxModule(m *BlackboxModule) error {
+	if m.Timeout != "" {
+		if _, err := promutils.ParseDuration(m.Timeout); err != nil {
+			return fmt.Errorf("cannot parse timeout: %w", err)
+		}
+	}
+	sections := map[string]bool{
+		"http": m.HTTP != nil,
+		"tcp":  m.TCP != nil,
+		"icmp": m.ICMP != nil,
+		"dns":  m.DNS != nil,
+		"grpc": m.GRPC != nil,
+	}
+	if _, ok := sections[m.Prober]; !ok {
+		return fmt.Errorf("unsupported prober=%q, must be one of http,tcp,icmp,dns,grpc", m.Prober)
+	}
+	for prober, isSet := range sections {
+		if isSet && prober != m.Prober {
+			return fmt.Errorf("%s section cannot be used with prober=%q", prober, m.Prober)
+		}
+	}
+	switch {
+	case m.HTTP != nil:
+		if err := validateRegexps(m.HTTP.FailIfBodyMatchesRegexp, "fail_if_body_matches_regexp"); err != nil {
+			return err
+		}
+		if err := validateRegexps(m.HTTP.FailIfBodyNotMatchesRegexp, "fail_if_body_not_matches_regexp"); err != nil {
+			return err
+		}
+		for idx, hm := range append(m.HTTP.FailIfHeaderMatches, m.HTTP.FailIfHeaderNotMatches...) {
+			if hm.Header == "" {
+				return fmt.Errorf("header cannot be empty for header match at idx=%d", idx)
+			}
+			if _, err := regexp.Compile(hm.Regexp); err != nil {
+				return fmt.Errorf("cannot parse regexp for header=%q: %w", hm.Header, err)
+			}
+		}
+	case m.TCP != nil:
+		for idx, qr := range m.TCP.QueryResponse {
+			if qr.Expect == "" {
+				continue
+			}
+			if _, err := regexp.Compile(qr.Expect); err != nil {
+				return fmt.Errorf("cannot parse expect for query_response at idx=%d: %w", idx, err)
+			}
+		}
+	case m.Prober == "dns":
+		if m.DNS == nil || m.DNS.QueryName == "" {
+			return fmt.Errorf("query_name must be provided for dns prober")
+		}
+		if m.DNS.DNSOverTLS && m.DNS.TransportProtocol == "udp" {
+			return fmt.Errorf("dns_over_tls requires tcp transport_protocol")
+		}
+		for name, v := range map[string]*BlackboxDNSRRValidator{
+			"validate_answer_rrs":     m.DNS.ValidateAnswerRRs,
+			"validate_authority_rrs":  m.DNS.ValidateAuthorityRRs,
+			"validate_additional_rrs": m.DNS.ValidateAdditionalRRs,
+		} {
+			if v == nil {
+				continue
+			}
+			for _, res := range [][]string{v.FailIfMatchesRegexp, v.FailIfAllMatchRegexp, v.FailIfNotMatchesRegexp, v.FailIfNoneMatchesRegexp} {
+				if err := validateRegexps(res, name); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func validateRegexps(res []string, field string) error {
+	for idx, re := range res {
+		if _, err := regexp.Compile(re); err != nil {
+			return fmt.Errorf("cannot parse %s at idx=%d: %w", field, idx, err)
+		}
+	}
+	return nil
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *VMBlackboxExporter) ValidateCreate() (admission.Warnings, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *VMBlackboxExporter) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *VMBlackboxExporter) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
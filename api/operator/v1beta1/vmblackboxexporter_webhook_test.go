@@ -0,0 +1,52 @@
+package v1beta1
+
+import (
+	"testing"
+)
+
+func TestVMBlackboxExporter_Validate(t *testing.T) {
+	f := func(modules map[string]BlackboxModule, wantErr bool) {
+		t.Helper()
+		cr := &VMBlackboxExporter{Spec: VMBlackboxExporterSpec{Modules: modules}}
+		err := cr.Validate()
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected validation result, wantErr: %v, got err: %v", wantErr, err)
+		}
+	}
+
+	// valid modules
+	f(map[string]BlackboxModule{
+		"http_2xx": {
+			Prober:  "http",
+			Timeout: "5s",
+			HTTP: &BlackboxHTTPProbe{
+				ValidStatusCodes:           []int{200},
+				FailIfBodyNotMatchesRegexp: []string{"OK|ok"},
+				FailIfHeaderMatches:        []BlackboxHeaderMatch{{Header: "Content-Type", Regexp: "text/.+"}},
+			},
+		},
+		"tcp":  {Prober: "tcp", TCP: &BlackboxTCPProbe{QueryResponse: []BlackboxTCPQueryResponse{{Expect: "^+OK"}, {Send: "QUIT"}}}},
+		"icmp": {Prober: "icmp"},
+		"dns":  {Prober: "dns", DNS: &BlackboxDNSProbe{QueryName: "example.com", ValidateAnswerRRs: &BlackboxDNSRRValidator{FailIfNotMatchesRegexp: []string{".*127.0.0.1"}}}},
+	}, false)
+
+	// no modules
+	f(nil, true)
+
+	// section doesn't match prober
+	f(map[string]BlackboxModule{"http": {Prober: "http", TCP: &BlackboxTCPProbe{}}}, true)
+
+	// unsupported prober
+	f(map[string]BlackboxModule{"ssh": {Prober: "ssh"}}, true)
+
+	// bad timeout
+	f(map[string]BlackboxModule{"http": {Prober: "http", Timeout: "5 seconds"}}, true)
+
+	// bad regexp
+	f(map[string]BlackboxModule{"http": {Prober: "http", HTTP: &BlackboxHTTPProbe{FailIfBodyMatchesRegexp: []string{"("}}}}, true)
+	f(map[string]BlackboxModule{"dns": {Prober: "dns", DNS: &BlackboxDNSProbe{QueryName: "example.com", ValidateAuthorityRRs: &BlackboxDNSRRValidator{FailIfMatchesRegexp: []string{"["}}}}}, true)
+
+	// dns without query name
+	f(map[string]BlackboxModule{"dns": {Prober: "dns", DNS: &BlackboxDNSProbe{}}}, true)
+	f(map[string]BlackboxModule{"dns": {Prober: "dns"}}, true)
+}
@@ -6,7 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"path"
+	"strconv"
 	"strings"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
@@ -82,6 +84,234 @@ type VMClusterSpec struct {
 	// it helps to evenly spread load across pods
 	// usually it's not possible with kubernetes TCP based service
 	RequestsLoadBalancer VMAuthLoadBalancer `json:"requestsLoadBalancer,omitempty"`
+	// Zones configures multi-zone cluster topology.
+	// If set, operator creates separate vmstorage, vmselect and vminsert components per each zone
+	// and pins them to the zone nodes with node affinity.
+	// +optional
+	Zones *VMClusterZones `json:"zones,omitempty"`
+	// RolloutPolicy configures staged rollout of vmstorage and vmselect updates.
+	// If set, operator updates canary pods first, waits for bakeTime and checks canary health
+	// before updating the rest of pods.
+	// +optional
+	RolloutPolicy *VMClusterRolloutPolicy `json:"rolloutPolicy,omitempty"`
+	// NetworkPolicy enables generation of NetworkPolicy for vmstorage, vmselect and vminsert pods.
+	// It allows vminsert and vmselect connections to vmstorage.
+	// +optional
+	NetworkPolicy *EmbeddedNetworkPolicy `json:"networkPolicy,omitempty"`
+}
+
+// VMClusterZones defines availability zones for cluster components
+type VMClusterZones struct {
+	// TopologyKey defines node label key, which is used to pin zone components to the zone nodes
+	// +kubebuilder:default:="topology.kubernetes.io/zone"
+	// +optional
+	TopologyKey string `json:"topologyKey,omitempty"`
+	// GlobalReplicationFactor defines how many zones store a copy of each sample.
+	// It's passed to vmselect as -globalReplicationFactor flag.
+	// Defaults to the number of not drained zones.
+	// +optional
+	GlobalReplicationFactor *int32 `json:"globalReplicationFactor,omitempty"`
+	// Items defines list of zones
+	// +kubebuilder:validation:MinItems=1
+	Items []VMClusterZone `json:"items"`
+}
+
+// VMClusterZone defines zone of the cluster
+type VMClusterZone struct {
+	// Name of the zone, it's used as a suffix for components names
+	// and as vmstorage group name for vmselect
+	// +kubebuilder:validation:Pattern:="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	Name string `json:"name"`
+	// TopologyValue defines value of topologyKey node label for the zone.
+	// Defaults to the zone name
+	// +optional
+	TopologyValue string `json:"topologyValue,omitempty"`
+	// Drain excludes zone from requests routing for maintenance.
+	// vmstorage nodes of drained zone are removed from vmselect -storageNode list of all zones,
+	// vmselect and vminsert of drained zone are scaled to 0 replicas.
+	// +optional
+	Drain bool `json:"drain,omitempty"`
+}
+
+// VMClusterRolloutOnFailure defines action for failed canary
+type VMClusterRolloutOnFailure string
+
+const (
+	// VMClusterRolloutHalt keeps canary pods at the new version and stops rollout
+	VMClusterRolloutHalt VMClusterRolloutOnFailure = "Halt"
+	// VMClusterRolloutRollback restores previous version of canary pods and stops rollout
+	VMClusterRolloutRollback VMClusterRolloutOnFailure = "Rollback"
+)
+
+// VMClusterRolloutPolicy defines staged rollout of cluster updates
+type VMClusterRolloutPolicy struct {
+	// CanaryPods defines number of pods per vmstorage and vmselect statefulset updated at canary stage.
+	// For zoned cluster all components of the first zone are updated at canary stage instead.
+	// Defaults to 1
+	// +optional
+	CanaryPods *int32 `json:"canaryPods,omitempty"`
+	// BakeTime defines how long canary pods must stay healthy before the rest of pods are updated, e.g. 30m
+	BakeTime string `json:"bakeTime"`
+	// MaxRestarts defines max number of container restarts of canary pods during bake time
+	// +optional
+	MaxRestarts int32 `json:"maxRestarts,omitempty"`
+	// MaxErrorRate defines max ratio of failed http requests of canary pods, e.g. 0.01.
+	// It's calculated from increase of vm_http_request_errors_total and vm_http_requests_total metrics of canary pods during bake time.
+	// Error rate isn't checked if omitted.
+	// +optional
+	MaxErrorRate string `json:"maxErrorRate,omitempty"`
+	// OnFailure defines action for unhealthy canary, Halt is used by default.
+	// Halt keeps canary pods at the new version, Rollback restores previous version of canary pods.
+	// Failure is reported with CanaryHealthy status condition, rollout is resumed after the next spec change.
+	// +kubebuilder:validation:Enum=Halt;Rollback
+	// +optional
+	OnFailure VMClusterRolloutOnFailure `json:"onFailure,omitempty"`
+}
+
+// GetCanaryPods returns number of canary pods per statefulset
+func (p *VMClusterRolloutPolicy) GetCanaryPods() int32 {
+	if p.CanaryPods == nil || *p.CanaryPods < 1 {
+		return 1
+	}
+	return *p.CanaryPods
+}
+
+// Validate checks rollout policy params
+func (p *VMClusterRolloutPolicy) Validate() error {
+	if p.CanaryPods != nil && *p.CanaryPods < 1 {
+		return fmt.Errorf("canaryPods must be positive, got: %d", *p.CanaryPods)
+	}
+	if _, err := p.GetBakeTime(); err != nil {
+		return err
+	}
+	if p.MaxRestarts < 0 {
+		return fmt.Errorf("maxRestarts cannot be negative, got: %d", p.MaxRestarts)
+	}
+	if _, err := p.GetMaxErrorRate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetBakeTime returns parsed bake time
+func (p *VMClusterRolloutPolicy) GetBakeTime() (time.Duration, error) {
+	d, err := time.ParseDuration(p.BakeTime)
+	if err != nil {
+		return 0, fmt.Errorf("cannot parse bakeTime=%q: %w", p.BakeTime, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("bakeTime cannot be negative, got: %q", p.BakeTime)
+	}
+	return d, nil
+}
+
+// GetMaxErrorRate returns parsed max error rate, negative value means that error rate check is disabled
+func (p *VMClusterRolloutPolicy) GetMaxErrorRate() (float64, error) {
+	if p.MaxErrorRate == "" {
+		return -1, nil
+	}
+	v, err := strconv.ParseFloat(p.MaxErrorRate, 64)
+	if err != nil {
+		return 0, fmt.Errorf("cannot parse maxErrorRate=%q: %w", p.MaxErrorRate, err)
+	}
+	if v < 0 || v > 1 {
+		return 0, fmt.Errorf("maxErrorRate must be in range [0,1], got: %q", p.MaxErrorRate)
+	}
+	return v, nil
+}
+
+// ConditionCanaryHealthy indicates that canary pods of the cluster rollout passed health checks.
+// It's set to False with Halted or RolledBack reason, if rollout was stopped because of canary failure
+const ConditionCanaryHealthy = "CanaryHealthy"
+
+// VMClusterRolloutStage defines stage of cluster rollout
+type VMClusterRolloutStage string
+
+const (
+	// VMClusterRolloutCanary means canary pods are being updated
+	VMClusterRolloutCanary VMClusterRolloutStage = "Canary"
+	// VMClusterRolloutBaking means canary pods are updated and their health is being checked during bake time
+	VMClusterRolloutBaking VMClusterRolloutStage = "Baking"
+	// VMClusterRolloutCompleted means all pods are updated
+	VMClusterRolloutCompleted VMClusterRolloutStage = "Completed"
+	// VMClusterRolloutHalted means canary failed health checks and rollout was stopped
+	VMClusterRolloutHalted VMClusterRolloutStage = "Halted"
+	// VMClusterRolloutRolledBack means canary failed health checks and canary pods were rolled back
+	VMClusterRolloutRolledBack VMClusterRolloutStage = "RolledBack"
+)
+
+// VMClusterRolloutStatus defines progress of staged rollout
+type VMClusterRolloutStatus struct {
+	// Generation of the cluster spec, which is rolled out
+	Generation int64 `json:"generation"`
+	// Stage of the rollout
+	Stage VMClusterRolloutStage `json:"stage"`
+	// LastTransitionTime is the last time the rollout changed its stage
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+	// Reason contains details of canary failure
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// CanaryPods contains names of updated canary pods
+	// +optional
+	CanaryPods []string `json:"canaryPods,omitempty"`
+	// StatefulSets contains revisions of statefulsets updated at canary stage
+	// +optional
+	StatefulSets []VMClusterRolloutStatefulSet `json:"statefulSets,omitempty"`
+	// CanaryMetrics contains request counters of canary pods at the start of bake time.
+	// Error rate of canary pods is calculated from counters increase during bake time
+	// +optional
+	CanaryMetrics []VMClusterRolloutPodMetrics `json:"canaryMetrics,omitempty"`
+}
+
+// VMClusterRolloutPodMetrics defines request counters of canary pod
+type VMClusterRolloutPodMetrics struct {
+	// Pod name
+	Pod string `json:"pod"`
+	// Requests is a value of vm_http_requests_total counter
+	Requests int64 `json:"requests"`
+	// RequestErrors is a value of vm_http_request_errors_total counter
+	RequestErrors int64 `json:"requestErrors"`
+}
+
+// VMClusterRolloutStatefulSet defines revisions of statefulset updated at canary stage
+type VMClusterRolloutStatefulSet struct {
+	// Name of the statefulset
+	Name string `json:"name"`
+	// PreviousRevision is a revision of pods before rollout, it's used for rollback
+	PreviousRevision string `json:"previousRevision"`
+	// UpdateRevision is a revision of canary pods
+	UpdateRevision string `json:"updateRevision"`
+}
+
+// IsInProgress checks if rollout waits for canary stage to finish
+func (s *VMClusterRolloutStatus) IsInProgress() bool {
+	return s != nil && (s.Stage == VMClusterRolloutCanary || s.Stage == VMClusterRolloutBaking)
+}
+
+// GetTopologyValue returns node label value for zone
+func (z *VMClusterZone) GetTopologyValue() string {
+	if z.TopologyValue != "" {
+		return z.TopologyValue
+	}
+	return z.Name
+}
+
+// IsZoned checks if cluster components are spread across zones
+func (cr *VMCluster) IsZoned() bool {
+	return cr.Spec.Zones != nil && len(cr.Spec.Zones.Items) > 0
+}
+
+// ZoneView returns copy of the cluster for the given zone.
+// Components of zone view have zone name suffix and are owned by the origin cluster
+func (cr *VMCluster) ZoneView(zoneName string) *VMCluster {
+	zc := cr.DeepCopy()
+	zc.Name = fmt.Sprintf("%s-%s", cr.Name, zoneName)
+	zc.ParentName = cr.Name
+	zc.ParsedLastAppliedSpec = nil
+	zc.Spec.ServiceAccountName = cr.GetServiceAccountName()
+	zc.Spec.RequestsLoadBalancer = VMAuthLoadBalancer{}
+	zc.Spec.Zones = nil
+	return zc
 }
 
 // VMAuthLBSelectorLabels defines selector labels for vmauth balancer
@@ -148,16 +378,24 @@ type VMCluster struct {
 	Spec              VMClusterSpec `json:"spec"`
 	// ParsedLastAppliedSpec contains last-applied configuration spec
 	ParsedLastAppliedSpec *VMClusterSpec `json:"-" yaml:"-"`
+	// ParentName contains name of the origin cluster for zone view of the cluster
+	ParentName string `json:"-" yaml:"-"`
+	// CanaryPods limits number of vmstorage and vmselect pods updated to the new revision at canary stage of rollout
+	CanaryPods int32 `json:"-" yaml:"-"`
 	// +optional
 	Status VMClusterStatus `json:"status,omitempty"`
 }
 
 func (c *VMCluster) AsOwner() []metav1.OwnerReference {
+	name := c.Name
+	if c.ParentName != "" {
+		name = c.ParentName
+	}
 	return []metav1.OwnerReference{
 		{
 			APIVersion:         c.APIVersion,
 			Kind:               c.Kind,
-			Name:               c.Name,
+			Name:               name,
 			UID:                c.UID,
 			Controller:         ptr.To(true),
 			BlockOwnerDeletion: ptr.To(true),
@@ -173,6 +411,20 @@ type VMClusterStatus struct {
 	LastSync     string       `json:"lastSync,omitempty"`
 	UpdateStatus UpdateStatus `json:"clusterStatus,omitempty"`
 	Reason       string       `json:"reason,omitempty"`
+	// ObservedGeneration defines current generation picked by operator for the
+	// reconcile
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions defines latest available observations of object state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// StorageScaleDown defines progress of vmstorage scale-down
+	// +optional
+	StorageScaleDown *VMStorageScaleDownStatus `json:"storageScaleDown,omitempty"`
+	// Rollout defines progress of staged rollout
+	// +optional
+	Rollout *VMClusterRolloutStatus `json:"rollout,omitempty"`
 }
 
 // VMClusterList contains a list of VMCluster
@@ -406,6 +658,13 @@ type VMStorage struct {
 	MaintenanceInsertNodeIDs []int32 `json:"maintenanceInsertNodeIDs,omitempty"`
 	// MaintenanceInsertNodeIDs - excludes given node ids from select requests routing, must contain pod suffixes - for pod-0, id will be 0 and etc.
 	MaintenanceSelectNodeIDs []int32 `json:"maintenanceSelectNodeIDs,omitempty"`
+	// ScaleDown configures orchestrated scale-down of vmstorage nodes.
+	// If enabled, operator doesn't remove nodes at replicaCount decrease immediately.
+	// Departing nodes are excluded from vminsert routing first,
+	// then after readOnlyPeriod they're removed from vmselect
+	// and only after that statefulset is shrunk.
+	// +optional
+	ScaleDown *VMStorageScaleDown `json:"scaleDown,omitempty"`
 
 	// RollingUpdateStrategy defines strategy for application updates
 	// Default is OnDelete, in this case operator handles update process
@@ -420,6 +679,53 @@ type VMStorage struct {
 	CommonApplicationDeploymentParams `json:",inline"`
 }
 
+// VMStorageScaleDown defines params for vmstorage nodes removal
+type VMStorageScaleDown struct {
+	// Enabled turns on orchestrated scale-down for vmstorage
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// ReadOnlyPeriod defines how long departing nodes serve select requests
+	// after they were excluded from vminsert routing.
+	// Defaults to cluster retentionPeriod, so data at departing nodes expires before removal.
+	// Supports the same format as retentionPeriod, value without suffix means months.
+	// +optional
+	ReadOnlyPeriod string `json:"readOnlyPeriod,omitempty"`
+}
+
+// VMStorageNodeScaleDownPhase defines phase of departing vmstorage node
+type VMStorageNodeScaleDownPhase string
+
+const (
+	// VMStorageNodeReadOnly means node is excluded from vminsert routing and still serves select requests
+	VMStorageNodeReadOnly VMStorageNodeScaleDownPhase = "ReadOnly"
+	// VMStorageNodeDetached means node is excluded from both vminsert and vmselect routing and waits for removal
+	VMStorageNodeDetached VMStorageNodeScaleDownPhase = "Detached"
+)
+
+// VMStorageScaleDownStatus defines progress of vmstorage scale-down
+type VMStorageScaleDownStatus struct {
+	// TargetReplicaCount defines vmstorage replicas count after scale-down is finished
+	TargetReplicaCount int32 `json:"targetReplicaCount"`
+	// Nodes contains departing vmstorage nodes
+	// +optional
+	Nodes []VMStorageNodeScaleDownStatus `json:"nodes,omitempty"`
+}
+
+// VMStorageNodeScaleDownStatus defines state of departing vmstorage node
+type VMStorageNodeScaleDownStatus struct {
+	// ID of the node, it's a pod ordinal - for pod-0, id will be 0 and etc.
+	ID int32 `json:"id"`
+	// Phase of the node removal
+	Phase VMStorageNodeScaleDownPhase `json:"phase"`
+	// LastTransitionTime is the last time the node changed its phase
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
+// IsScaleDownEnabled checks if orchestrated scale-down is enabled for vmstorage
+func (cr *VMStorage) IsScaleDownEnabled() bool {
+	return cr != nil && cr.ScaleDown != nil && cr.ScaleDown.Enabled
+}
+
 type VMBackup struct {
 	// AcceptEULA accepts enterprise feature usage, must be set to true.
 	// otherwise backupmanager cannot be added to single/cluster version.
@@ -934,6 +1240,9 @@ func (cr *VMCluster) SetUpdateStatusTo(ctx context.Context, r client.Client, sta
 	default:
 		panic(fmt.Sprintf("BUG: not expected status=%q", status))
 	}
+	cr.Status.ObservedGeneration = cr.Generation
+	cr.Status.Conditions = updateConditions(cr.Status.Conditions, cr.Generation, status, maybeErr)
+
 	if equality.Semantic.DeepEqual(&cr.Status, prevStatus) && currentStatus == status {
 		return nil
 	}
@@ -19,6 +19,7 @@ package v1beta1
 import (
 	"fmt"
 
+	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -41,6 +42,21 @@ func (r *VMCluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
 var _ webhook.Validator = &VMCluster{}
 
 func (r *VMCluster) sanityCheck() error {
+	if r.Spec.VMStorage != nil && r.Spec.VMStorage.VPA != nil {
+		if err := r.Spec.VMStorage.VPA.sanityCheck(); err != nil {
+			return fmt.Errorf("incorrect spec.vmstorage.vpa: %w", err)
+		}
+	}
+	if r.Spec.VMSelect != nil && r.Spec.VMSelect.VPA != nil {
+		if err := r.Spec.VMSelect.VPA.sanityCheck(); err != nil {
+			return fmt.Errorf("incorrect spec.vmselect.vpa: %w", err)
+		}
+	}
+	if r.Spec.VMInsert != nil && r.Spec.VMInsert.VPA != nil {
+		if err := r.Spec.VMInsert.VPA.sanityCheck(); err != nil {
+			return fmt.Errorf("incorrect spec.vminsert.vpa: %w", err)
+		}
+	}
 	if r.Spec.VMSelect != nil {
 		vms := r.Spec.VMSelect
 		if vms.HPA != nil {
@@ -65,6 +81,35 @@ func (r *VMCluster) sanityCheck() error {
 			return err
 		}
 	}
+	if r.Spec.RolloutPolicy != nil {
+		if err := r.Spec.RolloutPolicy.Validate(); err != nil {
+			return fmt.Errorf("incorrect spec.rolloutPolicy: %w", err)
+		}
+		if r.Spec.VMStorage != nil && r.Spec.VMStorage.RollingUpdateStrategy == appsv1.RollingUpdateStatefulSetStrategyType {
+			return fmt.Errorf("spec.rolloutPolicy cannot be used with vmstorage rollingUpdateStrategy=RollingUpdate")
+		}
+		if r.Spec.VMSelect != nil && r.Spec.VMSelect.RollingUpdateStrategy == appsv1.RollingUpdateStatefulSetStrategyType {
+			return fmt.Errorf("spec.rolloutPolicy cannot be used with vmselect rollingUpdateStrategy=RollingUpdate")
+		}
+	}
+	if r.IsZoned() {
+		if r.Spec.RequestsLoadBalancer.Enabled {
+			return fmt.Errorf("requestsLoadBalancer cannot be used with zones")
+		}
+		if r.Spec.VMStorage.IsScaleDownEnabled() {
+			return fmt.Errorf("vmstorage.scaleDown cannot be used with zones")
+		}
+		zones := make(map[string]struct{}, len(r.Spec.Zones.Items))
+		for _, zone := range r.Spec.Zones.Items {
+			if zone.Name == "" {
+				return fmt.Errorf("zone name cannot be empty")
+			}
+			if _, ok := zones[zone.Name]; ok {
+				return fmt.Errorf("duplicate zone name=%q", zone.Name)
+			}
+			zones[zone.Name] = struct{}{}
+		}
+	}
 
 	return nil
 }
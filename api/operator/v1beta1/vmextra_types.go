@@ -3,6 +3,7 @@ package v1beta1
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"path"
 	"reflect"
@@ -13,6 +14,9 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/api/autoscaling/v2beta2"
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -30,10 +34,33 @@ const (
 	UpdateStatusPaused      UpdateStatus = "paused"
 )
 
+// Status condition types
+const (
+	// ConditionAvailable indicates that all application components are ready to serve requests
+	ConditionAvailable = "Available"
+	// ConditionProgressing indicates that operator applies changes to the application components
+	ConditionProgressing = "Progressing"
+	// ConditionConfigValid indicates that application configuration was successfully built
+	ConditionConfigValid = "ConfigValid"
+	// ConditionChildrenReady indicates that all child workloads finished rollout
+	ConditionChildrenReady = "ChildrenReady"
+)
+
+// Status condition reasons
+const (
+	ConditionReasonSpecChanged        = "SpecChanged"
+	ConditionReasonReconcileSucceeded = "ReconcileSucceeded"
+	ConditionReasonReconcileFailed    = "ReconcileFailed"
+	ConditionReasonPaused             = "Paused"
+	ConditionReasonInvalidConfig      = "InvalidConfig"
+	ConditionReasonRolloutFailed      = "RolloutFailed"
+)
+
 const (
 	vmPathPrefixFlagName = "http.pathPrefix"
 	healthPath           = "/health"
 	metricPath           = "/metrics"
+	targetsPath          = "/api/v1/targets"
 	reloadPath           = "/-/reload"
 	reloadAuthKey        = "reloadAuthKey"
 	snapshotCreate       = "/snapshot/create"
@@ -431,6 +458,126 @@ func (epdbs *EmbeddedPodDisruptionBudgetSpec) SelectorLabelsWithDefaults(default
 	return epdbs.SelectorLabels
 }
 
+// Supported kinds of Gateway API routes
+const (
+	HTTPRouteKind = "HTTPRoute"
+	GRPCRouteKind = "GRPCRoute"
+	TLSRouteKind  = "TLSRoute"
+)
+
+// EmbeddedHTTPRoute describes Gateway API route configuration options.
+// Route is attached to the referenced Gateways and forwards requests to the application service.
+// https://gateway-api.sigs.k8s.io/api-types/httproute/
+type EmbeddedHTTPRoute struct {
+	// EmbeddedObjectMetadata adds labels and annotations for object.
+	EmbeddedObjectMetadata `json:",inline"`
+	// Kind defines kind of the created route object.
+	// TLSRoute forwards TLS traffic without termination and requires Gateway listener in Passthrough mode.
+	// +kubebuilder:validation:Enum=HTTPRoute;GRPCRoute;TLSRoute
+	// +kubebuilder:default=HTTPRoute
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// ParentRefs defines Gateways, route must be attached to
+	// +kubebuilder:validation:MinItems=1
+	ParentRefs []GatewayParentReference `json:"parentRefs"`
+	// Hostnames defines hostnames for route matching
+	// For VMAuth, hostnames are taken from ingress tlsHosts or host if not defined
+	// +optional
+	Hostnames []string `json:"hostnames,omitempty"`
+	// PathPrefixes defines request path prefixes for route matching, "/" by default
+	// Not supported by GRPCRoute and TLSRoute
+	// +optional
+	PathPrefixes []string `json:"pathPrefixes,omitempty"`
+	// ExtraRules - additional rules for route in the Gateway API format,
+	// must be checked for correctness by user.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	ExtraRules []apiextensionsv1.JSON `json:"extraRules,omitempty"`
+}
+
+// GatewayParentReference identifies Gateway, route must be attached to
+type GatewayParentReference struct {
+	// Name of the Gateway
+	Name string `json:"name"`
+	// Namespace of the Gateway, route namespace by default
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// SectionName defines name of Gateway listener
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+	// Port defines port of Gateway listener
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+}
+
+// KindOrDefault returns kind of route object
+func (ehr *EmbeddedHTTPRoute) KindOrDefault() string {
+	if ehr.Kind == "" {
+		return HTTPRouteKind
+	}
+	return ehr.Kind
+}
+
+// Validate performs syntax check for route configuration
+func (ehr *EmbeddedHTTPRoute) Validate() error {
+	switch ehr.KindOrDefault() {
+	case HTTPRouteKind:
+	case GRPCRouteKind, TLSRouteKind:
+		if len(ehr.PathPrefixes) > 0 {
+			return fmt.Errorf("pathPrefixes are not supported by %s", ehr.Kind)
+		}
+	default:
+		return fmt.Errorf("unsupported route kind=%q, supported kinds: %s, %s, %s", ehr.Kind, HTTPRouteKind, GRPCRouteKind, TLSRouteKind)
+	}
+	if len(ehr.ParentRefs) == 0 {
+		return fmt.Errorf("at least one parentRef must be defined")
+	}
+	for i, ref := range ehr.ParentRefs {
+		if ref.Name == "" {
+			return fmt.Errorf("parentRefs[%d].name cannot be empty", i)
+		}
+	}
+	for i, rule := range ehr.ExtraRules {
+		var v map[string]any
+		if err := json.Unmarshal(rule.Raw, &v); err != nil {
+			return fmt.Errorf("cannot parse extraRules[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// EmbeddedNetworkPolicy describes NetworkPolicy generated by operator for application pods.
+// Policy allows only traffic known by operator: requests to application ports,
+// communication between application components and metrics scraping by selected VMAgents.
+// https://kubernetes.io/docs/concepts/services-networking/network-policies/
+type EmbeddedNetworkPolicy struct {
+	// EmbeddedObjectMetadata adds labels and annotations for object.
+	EmbeddedObjectMetadata `json:",inline"`
+	// AllowFrom defines clients allowed to access application ports.
+	// If not defined, application ports are accessible from any source.
+	// +optional
+	AllowFrom []networkingv1.NetworkPolicyPeer `json:"allowFrom,omitempty"`
+	// VMAgentSelector selects VMAgents allowed to scrape application metrics.
+	// Only takes effect if allowFrom is defined.
+	// +optional
+	VMAgentSelector *metav1.LabelSelector `json:"vmAgentSelector,omitempty"`
+	// VMAgentNamespaceSelector defines namespaces for VMAgents selection.
+	// Object namespace is used by default.
+	// +optional
+	VMAgentNamespaceSelector *metav1.LabelSelector `json:"vmAgentNamespaceSelector,omitempty"`
+	// Egress enables restriction of outgoing traffic for components with known destinations:
+	// vminsert, vmselect and vmalert. DNS requests are always allowed.
+	// +optional
+	Egress bool `json:"egress,omitempty"`
+	// ExtraIngress defines additional ingress rules
+	// +optional
+	ExtraIngress []networkingv1.NetworkPolicyIngressRule `json:"extraIngress,omitempty"`
+	// ExtraEgress defines additional egress rules, only takes effect if egress is enabled
+	// +optional
+	ExtraEgress []networkingv1.NetworkPolicyEgressRule `json:"extraEgress,omitempty"`
+}
+
 // EmbeddedProbes - it allows to override some probe params.
 // its not necessary to specify all options,
 // operator will replace missing spec with default values.
@@ -465,6 +612,58 @@ func (cr *EmbeddedHPA) sanityCheck() error {
 	return nil
 }
 
+// EmbeddedVPA embeds VerticalPodAutoscaler spec.
+// https://github.com/kubernetes/autoscaler/tree/master/vertical-pod-autoscaler
+type EmbeddedVPA struct {
+	// UpdateMode defines how VPA applies recommendations to pods.
+	// Off only computes recommendations, Initial applies it at pod creation
+	// and Auto also evicts pods with outdated resources.
+	// Defaults to Auto.
+	// +kubebuilder:validation:Enum=Off;Initial;Auto
+	// +optional
+	UpdateMode string `json:"updateMode,omitempty"`
+	// MinAllowed defines minimal resources, which could be recommended for container
+	// +optional
+	MinAllowed v1.ResourceList `json:"minAllowed,omitempty"`
+	// MaxAllowed defines maximal resources, which could be recommended for container
+	// +optional
+	MaxAllowed v1.ResourceList `json:"maxAllowed,omitempty"`
+	// ControlledResources defines resources managed by VPA.
+	// Defaults to cpu and memory.
+	// +optional
+	ControlledResources []v1.ResourceName `json:"controlledResources,omitempty"`
+	// ControlledValues defines which resource values are managed by VPA.
+	// Defaults to RequestsAndLimits.
+	// +kubebuilder:validation:Enum=RequestsAndLimits;RequestsOnly
+	// +optional
+	ControlledValues string `json:"controlledValues,omitempty"`
+}
+
+// UpdateModeOrDefault returns update mode of VPA
+func (cr *EmbeddedVPA) UpdateModeOrDefault() string {
+	if cr.UpdateMode == "" {
+		return "Auto"
+	}
+	return cr.UpdateMode
+}
+
+// ControlledResourcesOrDefault returns resources managed by VPA
+func (cr *EmbeddedVPA) ControlledResourcesOrDefault() []v1.ResourceName {
+	if len(cr.ControlledResources) == 0 {
+		return []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory}
+	}
+	return cr.ControlledResources
+}
+
+func (cr *EmbeddedVPA) sanityCheck() error {
+	for name, maxValue := range cr.MaxAllowed {
+		if minValue, ok := cr.MinAllowed[name]; ok && minValue.Cmp(maxValue) > 0 {
+			return fmt.Errorf("vpa minAllowed %s=%s cannot be greater than maxAllowed=%s", name, minValue.String(), maxValue.String())
+		}
+	}
+	return nil
+}
+
 // DiscoverySelector can be used at CRD components discovery
 type DiscoverySelector struct {
 	Namespace *NamespaceSelector    `json:"namespaceSelector,omitempty"`
@@ -1170,6 +1369,10 @@ type CommonApplicationDeploymentParams struct {
 	// Defaults to 10.
 	// +optional
 	RevisionHistoryLimitCount *int32 `json:"revisionHistoryLimitCount,omitempty"`
+	// VPA enables generation of VerticalPodAutoscaler for application pods.
+	// Operator doesn't change container resources of running pods, while VPA manages it.
+	// +optional
+	VPA *EmbeddedVPA `json:"vpa,omitempty"`
 
 	// Containers property allows to inject additions sidecars or to patch existing containers.
 	// It can be useful for proxies, backup, etc.
@@ -1274,6 +1477,84 @@ func statusPatch(ctx context.Context, rclient client.Client, object client.Objec
 	return rclient.Status().Patch(ctx, object, pr)
 }
 
+// ConditionError binds reconcile error with status condition type,
+// which must be set to False
+// +kubebuilder:object:generate=false
+type ConditionError struct {
+	// Type of the condition
+	Type string
+	// Reason of the condition
+	Reason string
+	origin error
+}
+
+// Error implements error interface
+func (ce *ConditionError) Error() string {
+	return ce.origin.Error()
+}
+
+// Unwrap implements errors.Unwrap interface
+func (ce *ConditionError) Unwrap() error {
+	return ce.origin
+}
+
+// NewConfigError marks given error as application configuration error
+func NewConfigError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ConditionError{Type: ConditionConfigValid, Reason: ConditionReasonInvalidConfig, origin: err}
+}
+
+// NewRolloutError marks given error as child workload rollout error
+func NewRolloutError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ConditionError{Type: ConditionChildrenReady, Reason: ConditionReasonRolloutFailed, origin: err}
+}
+
+// updateConditions sets status conditions according to the given update status and optional reconcile error
+func updateConditions(conditions []metav1.Condition, generation int64, status UpdateStatus, maybeErr error) []metav1.Condition {
+	set := func(conditionType string, st metav1.ConditionStatus, reason, message string) {
+		meta.SetStatusCondition(&conditions, metav1.Condition{
+			Type:               conditionType,
+			Status:             st,
+			Reason:             reason,
+			Message:            message,
+			ObservedGeneration: generation,
+		})
+	}
+	switch status {
+	case UpdateStatusExpanding:
+		set(ConditionProgressing, metav1.ConditionTrue, ConditionReasonSpecChanged, "applying changes to the application components")
+	case UpdateStatusOperational:
+		set(ConditionProgressing, metav1.ConditionFalse, ConditionReasonReconcileSucceeded, "")
+		set(ConditionConfigValid, metav1.ConditionTrue, ConditionReasonReconcileSucceeded, "")
+		set(ConditionChildrenReady, metav1.ConditionTrue, ConditionReasonReconcileSucceeded, "")
+		set(ConditionAvailable, metav1.ConditionTrue, ConditionReasonReconcileSucceeded, "")
+	case UpdateStatusFailed:
+		var message string
+		if maybeErr != nil {
+			message = maybeErr.Error()
+		}
+		set(ConditionProgressing, metav1.ConditionFalse, ConditionReasonReconcileFailed, message)
+		var ce *ConditionError
+		if errors.As(maybeErr, &ce) {
+			set(ce.Type, metav1.ConditionFalse, ce.Reason, message)
+			if ce.Type == ConditionChildrenReady {
+				set(ConditionAvailable, metav1.ConditionFalse, ce.Reason, message)
+			}
+		}
+		if meta.FindStatusCondition(conditions, ConditionAvailable) == nil {
+			set(ConditionAvailable, metav1.ConditionUnknown, ConditionReasonReconcileFailed, message)
+		}
+	case UpdateStatusPaused:
+		set(ConditionProgressing, metav1.ConditionFalse, ConditionReasonPaused, "reconcile is paused")
+	}
+	return conditions
+}
+
 // ExternalConfig defines external source of configuration
 type ExternalConfig struct {
 	// SecretRef defines selector for externally managed secret which contains configuration
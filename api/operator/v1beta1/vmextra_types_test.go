@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func Test_buildPathWithPrefixFlag(t *testing.T) {
@@ -92,3 +94,91 @@ func TestParsingMatch(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateConditions(t *testing.T) {
+	type step struct {
+		status   UpdateStatus
+		err      error
+		expected map[string]metav1.ConditionStatus
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "successful rollout",
+			steps: []step{
+				{
+					status: UpdateStatusExpanding,
+					expected: map[string]metav1.ConditionStatus{
+						ConditionProgressing: metav1.ConditionTrue,
+					},
+				},
+				{
+					status: UpdateStatusOperational,
+					expected: map[string]metav1.ConditionStatus{
+						ConditionProgressing:   metav1.ConditionFalse,
+						ConditionAvailable:     metav1.ConditionTrue,
+						ConditionConfigValid:   metav1.ConditionTrue,
+						ConditionChildrenReady: metav1.ConditionTrue,
+					},
+				},
+			},
+		},
+		{
+			name: "stuck rollout",
+			steps: []step{
+				{
+					status: UpdateStatusOperational,
+				},
+				{
+					status: UpdateStatusFailed,
+					err:    fmt.Errorf("cannot reconcile deployment: %w", NewRolloutError(fmt.Errorf("deadline exceeded"))),
+					expected: map[string]metav1.ConditionStatus{
+						ConditionProgressing:   metav1.ConditionFalse,
+						ConditionAvailable:     metav1.ConditionFalse,
+						ConditionConfigValid:   metav1.ConditionTrue,
+						ConditionChildrenReady: metav1.ConditionFalse,
+					},
+				},
+			},
+		},
+		{
+			name: "invalid config",
+			steps: []step{
+				{
+					status: UpdateStatusFailed,
+					err:    NewConfigError(fmt.Errorf("bad config")),
+					expected: map[string]metav1.ConditionStatus{
+						ConditionProgressing: metav1.ConditionFalse,
+						ConditionAvailable:   metav1.ConditionUnknown,
+						ConditionConfigValid: metav1.ConditionFalse,
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var conditions []metav1.Condition
+			for i, s := range tt.steps {
+				conditions = updateConditions(conditions, int64(i+1), s.status, s.err)
+				for condType, want := range s.expected {
+					got := meta.FindStatusCondition(conditions, condType)
+					if got == nil {
+						t.Fatalf("step=%d, condition=%q is missing", i, condType)
+					}
+					if got.Status != want {
+						t.Fatalf("step=%d, unexpected status for condition=%q, got: %q, want: %q", i, condType, got.Status, want)
+					}
+					if got.ObservedGeneration != int64(i+1) && condType != ConditionConfigValid {
+						t.Fatalf("step=%d, unexpected observed generation for condition=%q: %d", i, condType, got.ObservedGeneration)
+					}
+				}
+				if s.expected != nil && len(conditions) != len(s.expected) {
+					t.Fatalf("step=%d, unexpected conditions count=%d, want: %d", i, len(conditions), len(s.expected))
+				}
+			}
+		})
+	}
+}
@@ -28,7 +28,7 @@ type VMProbeSpec struct {
 	// The job name assigned to scraped metrics by default.
 	JobName string `json:"jobName,omitempty"`
 	// Specification for the prober to use for probing targets.
-	// The prober.URL parameter is required. Targets cannot be probed if left empty.
+	// The prober.URL parameter is required, if blackboxExporterRef isn't set. Targets cannot be probed if left empty.
 	VMProberSpec VMProberSpec `json:"vmProberSpec"`
 	// The module to use for probing specifying how to probe the target.
 	// Example module configuring in the blackbox exporter:
@@ -78,8 +78,15 @@ type ProbeTargetIngress struct {
 // VMProberSpec contains specification parameters for the Prober used for probing.
 // +k8s:openapi-gen=true
 type VMProberSpec struct {
-	// Mandatory URL of the prober.
-	URL string `json:"url"`
+	// URL of the prober.
+	// Required, if BlackboxExporterRef isn't set.
+	// +optional
+	URL string `json:"url,omitempty"`
+	// BlackboxExporterRef references VMBlackboxExporter object,
+	// which address is used as prober URL.
+	// VMProbe module must be defined at referenced VMBlackboxExporter modules
+	// +optional
+	BlackboxExporterRef *VMBlackboxExporterRef `json:"blackboxExporterRef,omitempty"`
 	// HTTP scheme to use for scraping.
 	// Defaults to `http`.
 	// +optional
@@ -0,0 +1,171 @@
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VMRestoreJobSpec defines the desired state of VMRestoreJob
+type VMRestoreJobSpec struct {
+	// Target defines VMSingle or VMCluster to restore data into.
+	// Target storage must not be running during restore,
+	// the recommended way is to create target object with paused: true
+	// and unpause it after restore is completed.
+	Target BackupTargetRef `json:"target"`
+	// Source is a remote storage url with backup
+	// e.g. s3://bucket/path/to/backup, operator appends storage pod name to it for VMCluster
+	Source string `json:"source"`
+	// SourceDisableSuffixAdd - disables suffix with storage pod name for VMCluster restore
+	// +optional
+	SourceDisableSuffixAdd bool `json:"sourceDisableSuffixAdd,omitempty"`
+	// SkipBackupCompleteCheck allows to restore from incomplete backup
+	// +optional
+	SkipBackupCompleteCheck bool `json:"skipBackupCompleteCheck,omitempty"`
+
+	BackupJobCommonParams `json:",inline"`
+}
+
+// VMRestoreJobStatus defines the observed state of VMRestoreJob
+type VMRestoreJobStatus struct {
+	// UpdateStatus defines a status of restore process
+	UpdateStatus UpdateStatus `json:"status,omitempty"`
+	// Reason defines a reason of failure
+	Reason string `json:"reason,omitempty"`
+	// Progress shows number of completed nodes at format completed/total
+	Progress string `json:"progress,omitempty"`
+	// CompletionTime is the time when restore finished successfully for all nodes
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Nodes contains per storage node job status
+	// +optional
+	Nodes []BackupJobNodeStatus `json:"nodes,omitempty"`
+	// ObservedGeneration defines generation of the object used for nodes status
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// VMRestoreJob is the Schema for the vmrestorejobs API
+// It runs vmrestore for the referenced VMSingle or VMCluster storage
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=vmrestorejobs,scope=Namespaced
+// +kubebuilder:printcolumn:name="Target",type="string",JSONPath=".spec.target.name"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.status"
+// +kubebuilder:printcolumn:name="Progress",type="string",JSONPath=".status.progress"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +genclient
+type VMRestoreJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VMRestoreJobSpec   `json:"spec,omitempty"`
+	Status VMRestoreJobStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VMRestoreJobList contains a list of VMRestoreJob
+type VMRestoreJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMRestoreJob `json:"items"`
+}
+
+// AsOwner returns owner references with current object as owner
+func (cr *VMRestoreJob) AsOwner() []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			APIVersion:         cr.APIVersion,
+			Kind:               cr.Kind,
+			Name:               cr.Name,
+			UID:                cr.UID,
+			Controller:         ptr.To(true),
+			BlockOwnerDeletion: ptr.To(true),
+		},
+	}
+}
+
+// PrefixedName returns prefixed name for job objects
+func (cr *VMRestoreJob) PrefixedName() string {
+	return fmt.Sprintf("vmrestorejob-%s", cr.Name)
+}
+
+// SelectorLabels returns selector labels for job objects
+func (cr *VMRestoreJob) SelectorLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":      "vmrestorejob",
+		"app.kubernetes.io/instance":  cr.Name,
+		"app.kubernetes.io/component": "monitoring",
+		"managed-by":                  "vm-operator",
+	}
+}
+
+// AllLabels returns combined labels for VMRestoreJob
+func (cr *VMRestoreJob) AllLabels() map[string]string {
+	labels := cr.SelectorLabels()
+	for label, value := range cr.Labels {
+		if _, ok := labels[label]; ok {
+			// forbid changes for selector labels
+			continue
+		}
+		labels[label] = value
+	}
+	return labels
+}
+
+// AnnotationsFiltered returns global annotations to be applied by objects generate for vmrestorejob
+func (cr *VMRestoreJob) AnnotationsFiltered() map[string]string {
+	return filterBackupJobAnnotations(cr.Annotations)
+}
+
+// IsFinished checks if restore reached terminal phase for all nodes at the current generation
+// jobs of finished restore must not be created again, since storage could be already started with restored data
+func (cr *VMRestoreJob) IsFinished() bool {
+	return cr.Status.ObservedGeneration == cr.Generation && backupJobNodesFinished(cr.Status.Nodes)
+}
+
+// SetUpdateStatusTo changes update status with optional reason of fail
+func (cr *VMRestoreJob) SetUpdateStatusTo(ctx context.Context, r client.Client, status UpdateStatus, maybeErr error) error {
+	return cr.patchUpdateStatus(ctx, r, cr.Status.DeepCopy(), status, maybeErr)
+}
+
+// patchUpdateStatus sets update status and patches it, if status differs from prevStatus
+func (cr *VMRestoreJob) patchUpdateStatus(ctx context.Context, r client.Client, prevStatus *VMRestoreJobStatus, status UpdateStatus, maybeErr error) error {
+	switch status {
+	case UpdateStatusExpanding, UpdateStatusOperational:
+		cr.Status.Reason = ""
+	case UpdateStatusFailed:
+		if maybeErr != nil {
+			cr.Status.Reason = maybeErr.Error()
+		}
+	default:
+		panic(fmt.Sprintf("BUG: not expected status=%q", status))
+	}
+	cr.Status.UpdateStatus = status
+	if equality.Semantic.DeepEqual(&cr.Status, prevStatus) {
+		return nil
+	}
+	return statusPatch(ctx, r, cr.DeepCopy(), cr.Status)
+}
+
+// SetNodesStatus updates per node status and progress of restore
+func (cr *VMRestoreJob) SetNodesStatus(ctx context.Context, r client.Client, nodes []BackupJobNodeStatus) error {
+	prevStatus := cr.Status.DeepCopy()
+	status, reason := backupJobStatusFromNodes(nodes, false)
+	cr.Status.Nodes = nodes
+	cr.Status.ObservedGeneration = cr.Generation
+	cr.Status.Progress = backupJobProgress(nodes)
+	if last := lastBackupJobSuccess(nodes, false); last != nil {
+		cr.Status.CompletionTime = last
+	}
+	return cr.patchUpdateStatus(ctx, r, prevStatus, status, reason)
+}
+
+func init() {
+	SchemeBuilder.Register(&VMRestoreJob{}, &VMRestoreJobList{})
+}
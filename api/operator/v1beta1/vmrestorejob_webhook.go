@@ -0,0 +1,53 @@
+package v1beta1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *VMRestoreJob) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-operator-victoriametrics-com-v1beta1-vmrestorejob,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.victoriametrics.com,resources=vmrestorejobs,verbs=create;update,versions=v1beta1,name=vvmrestorejob.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &VMRestoreJob{}
+
+// Validate performs logical validation
+func (r *VMRestoreJob) Validate() error {
+	if mustSkipValidation(r) {
+		return nil
+	}
+	if err := validateBackupRemotePath(r.Spec.Source); err != nil {
+		return fmt.Errorf("incorrect spec.source: %w", err)
+	}
+	return nil
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *VMRestoreJob) ValidateCreate() (admission.Warnings, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *VMRestoreJob) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *VMRestoreJob) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,153 @@
+package v1beta1
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/VictoriaMetrics/VictoriaMetrics/lib/promutils"
+	"github.com/VictoriaMetrics/metricsql"
+)
+
+// RuleLintMode defines action for VMRule lint issues found by validating webhook
+type RuleLintMode string
+
+const (
+	// RuleLintModeDisabled disables VMRule linting
+	RuleLintModeDisabled RuleLintMode = "disabled"
+	// RuleLintModeWarn returns lint issues as admission warnings
+	RuleLintModeWarn RuleLintMode = "warn"
+	// RuleLintModeDeny rejects VMRule with lint issues
+	RuleLintModeDeny RuleLintMode = "deny"
+)
+
+// RuleLintPolicy defines checks performed for VMRule by validating webhook
+// +kubebuilder:object:generate=false
+type RuleLintPolicy struct {
+	// Mode defines default action for found issues
+	Mode RuleLintMode
+	// NamespaceModes overrides Mode for the given namespaces
+	NamespaceModes map[string]RuleLintMode
+	// RecordNameRegex defines naming convention for recording rules
+	RecordNameRegex *regexp.Regexp
+	// EnforcedNamespaceLabel defines label, which is set by VMAlert and must not be used by rules
+	EnforcedNamespaceLabel string
+	// MaxLookbehindWindow defines max allowed window for rollup functions
+	MaxLookbehindWindow time.Duration
+}
+
+var ruleLintPolicy = RuleLintPolicy{Mode: RuleLintModeDisabled}
+
+// SetRuleLintPolicy configures global lint policy for VMRule validating webhook
+// cannot be used concurrently and should be called only once at lib init
+func SetRuleLintPolicy(policy RuleLintPolicy) {
+	ruleLintPolicy = policy
+}
+
+// modeFor returns lint mode for the given namespace
+func (p *RuleLintPolicy) modeFor(namespace string) RuleLintMode {
+	if mode, ok := p.NamespaceModes[namespace]; ok {
+		return mode
+	}
+	return p.Mode
+}
+
+// Lint checks rules for semantic issues, which are not detected by vmalert, but likely to be a mistake
+// or lead to expensive queries. It returns list of found issues
+func (r *VMRule) Lint(policy *RuleLintPolicy) []string {
+	var issues []string
+	for _, group := range r.Spec.Groups {
+		// MetricsQL checks are not applicable for graphite and logs datasources
+		isMetricsQL := group.Type == "" || group.Type == "prometheus"
+		if policy.EnforcedNamespaceLabel != "" {
+			if _, ok := group.Labels[policy.EnforcedNamespaceLabel]; ok {
+				issues = append(issues, fmt.Sprintf("group=%q: label %q is overwritten by enforced namespace label", group.Name, policy.EnforcedNamespaceLabel))
+			}
+		}
+		for _, rule := range group.Rules {
+			name := rule.Alert
+			if name == "" {
+				name = rule.Record
+			}
+			addIssue := func(format string, args ...any) {
+				issues = append(issues, fmt.Sprintf("group=%q rule=%q: ", group.Name, name)+fmt.Sprintf(format, args...))
+			}
+			if rule.Alert != "" && isZeroRuleDuration(rule.For) {
+				addIssue("alerting rule has no `for` duration, it fires on the first evaluation of the expression")
+			}
+			if rule.Record != "" && policy.RecordNameRegex != nil && !policy.RecordNameRegex.MatchString(rule.Record) {
+				addIssue("record name doesn't match naming convention %q", policy.RecordNameRegex.String())
+			}
+			if policy.EnforcedNamespaceLabel != "" {
+				if _, ok := rule.Labels[policy.EnforcedNamespaceLabel]; ok {
+					addIssue("label %q is overwritten by enforced namespace label", policy.EnforcedNamespaceLabel)
+				}
+			}
+			if !isMetricsQL {
+				continue
+			}
+			expr, err := metricsql.Parse(rule.Expr)
+			if err != nil {
+				// syntax errors are reported by Validate
+				continue
+			}
+			metricsql.VisitAll(expr, func(e metricsql.Expr) {
+				switch e := e.(type) {
+				case *metricsql.MetricExpr:
+					if !isBoundedMetricExpr(e) {
+						addIssue("selector %s doesn't limit series with any positive label filter, it selects too many series", e.AppendString(nil))
+					}
+				case *metricsql.RollupExpr:
+					if policy.MaxLookbehindWindow <= 0 || e.Window == nil {
+						return
+					}
+					// step doesn't matter for windows without `i` suffix, use evaluation interval of 1m for others
+					window := time.Duration(e.Window.Duration(time.Minute.Milliseconds())) * time.Millisecond
+					if window > policy.MaxLookbehindWindow {
+						addIssue("lookbehind window [%s] exceeds max allowed window %s", e.Window.AppendString(nil), policy.MaxLookbehindWindow)
+					}
+				}
+			})
+		}
+	}
+	return issues
+}
+
+func isZeroRuleDuration(s string) bool {
+	if s == "" {
+		return true
+	}
+	d, err := promutils.ParseDuration(s)
+	return err == nil && d == 0
+}
+
+// isBoundedMetricExpr checks if each or-group of selector has at least one positive filter,
+// which doesn't match every value of label
+func isBoundedMetricExpr(me *metricsql.MetricExpr) bool {
+	if len(me.LabelFilterss) == 0 {
+		return false
+	}
+	for _, lfs := range me.LabelFilterss {
+		var bounded bool
+		for _, lf := range lfs {
+			if lf.IsNegative {
+				continue
+			}
+			if lf.IsRegexp {
+				if lf.Value != "" && lf.Value != ".*" && lf.Value != ".+" {
+					bounded = true
+					break
+				}
+				continue
+			}
+			if lf.Value != "" {
+				bounded = true
+				break
+			}
+		}
+		if !bounded {
+			return false
+		}
+	}
+	return true
+}
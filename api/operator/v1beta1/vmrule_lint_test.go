@@ -0,0 +1,127 @@
+package v1beta1
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestVMRule_Lint(t *testing.T) {
+	policy := &RuleLintPolicy{
+		Mode:                   RuleLintModeWarn,
+		RecordNameRegex:        regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*:[a-zA-Z0-9_:]+$`),
+		EnforcedNamespaceLabel: "namespace",
+		MaxLookbehindWindow:    24 * time.Hour,
+	}
+	f := func(group RuleGroup, wantIssues []string) {
+		t.Helper()
+		cr := &VMRule{Spec: VMRuleSpec{Groups: []RuleGroup{group}}}
+		issues := cr.Lint(policy)
+		if len(issues) != len(wantIssues) {
+			t.Fatalf("unexpected number of issues, want: %d, got: %d: %s", len(wantIssues), len(issues), strings.Join(issues, "\n"))
+		}
+		for i := range issues {
+			if !strings.Contains(issues[i], wantIssues[i]) {
+				t.Fatalf("unexpected issue at idx=%d, want substring: %q, got: %q", i, wantIssues[i], issues[i])
+			}
+		}
+	}
+
+	// no issues
+	f(RuleGroup{
+		Name: "good",
+		Rules: []Rule{
+			{Alert: "TargetDown", Expr: `up{job=~"vm.+"} == 0`, For: "5m"},
+			{Record: "job:up:sum", Expr: `sum(up) by (job)`},
+			{Record: "job:requests:rate1d", Expr: `sum(rate({__name__=~"http_requests_.+"}[1d])) by (job)`},
+		},
+	}, nil)
+
+	// missing for
+	f(RuleGroup{
+		Name: "alerts",
+		Rules: []Rule{
+			{Alert: "NoFor", Expr: `up == 0`},
+			{Alert: "ZeroFor", Expr: `up == 0`, For: "0s"},
+		},
+	}, []string{
+		`rule="NoFor": alerting rule has no `,
+		`rule="ZeroFor": alerting rule has no `,
+	})
+
+	// record naming
+	f(RuleGroup{
+		Name:  "records",
+		Rules: []Rule{{Record: "up_sum", Expr: `sum(up)`}},
+	}, []string{`rule="up_sum": record name doesn't match naming convention`})
+
+	// unbounded selectors
+	f(RuleGroup{
+		Name: "selectors",
+		Rules: []Rule{
+			{Record: "job:all:count", Expr: `count({job=~".*"})`},
+			{Record: "job:other:count", Expr: `count({job!="vmagent"}) + count(up)`},
+		},
+	}, []string{
+		`rule="job:all:count": selector {job=~".*"} doesn't limit series`,
+		`rule="job:other:count": selector {job!="vmagent"} doesn't limit series`,
+	})
+
+	// enforced namespace label collision
+	f(RuleGroup{
+		Name:   "labels",
+		Labels: map[string]string{"namespace": "prod"},
+		Rules: []Rule{
+			{Alert: "TargetDown", Expr: `up == 0`, For: "1m", Labels: map[string]string{"namespace": "dev"}},
+		},
+	}, []string{
+		`group="labels": label "namespace" is overwritten`,
+		`rule="TargetDown": label "namespace" is overwritten`,
+	})
+
+	// expensive window
+	f(RuleGroup{
+		Name:  "windows",
+		Rules: []Rule{{Record: "job:up:avg30d", Expr: `avg_over_time(up[30d])`}},
+	}, []string{`rule="job:up:avg30d": lookbehind window [30d] exceeds max allowed window 24h0m0s`})
+
+	// graphite expressions are not parsed
+	f(RuleGroup{
+		Name:  "graphite",
+		Type:  "graphite",
+		Rules: []Rule{{Record: "job:up:count", Expr: `sumSeries(*.up)`}},
+	}, nil)
+}
+
+func TestVMRule_LintModes(t *testing.T) {
+	policy := &RuleLintPolicy{
+		Mode: RuleLintModeWarn,
+		NamespaceModes: map[string]RuleLintMode{
+			"prod": RuleLintModeDeny,
+			"dev":  RuleLintModeDisabled,
+		},
+	}
+	f := func(namespace string, wantWarnings int, wantErr bool) {
+		t.Helper()
+		cr := &VMRule{
+			ObjectMeta: metav1.ObjectMeta{Name: "rules", Namespace: namespace},
+			Spec: VMRuleSpec{Groups: []RuleGroup{{
+				Name:  "alerts",
+				Rules: []Rule{{Alert: "NoFor", Expr: `up == 0`}},
+			}}},
+		}
+		warnings, err := cr.lint(policy)
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected lint result, wantErr: %v, got err: %v", wantErr, err)
+		}
+		if len(warnings) != wantWarnings {
+			t.Fatalf("unexpected number of warnings, want: %d, got: %d", wantWarnings, len(warnings))
+		}
+	}
+	f("default", 1, false)
+	f("prod", 0, true)
+	f("dev", 0, false)
+}
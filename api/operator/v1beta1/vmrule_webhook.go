@@ -102,7 +102,7 @@ func (r *VMRule) ValidateCreate() (admission.Warnings, error) {
 		return nil, err
 	}
 
-	return nil, nil
+	return r.lint(&ruleLintPolicy)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -111,7 +111,27 @@ func (r *VMRule) ValidateUpdate(old runtime.Object) (admission.Warnings, error)
 		return nil, err
 	}
 
-	return nil, nil
+	return r.lint(&ruleLintPolicy)
+}
+
+// lint applies lint policy configured for the rule namespace
+// issues are returned as admission warnings or as error for deny mode
+func (r *VMRule) lint(policy *RuleLintPolicy) (admission.Warnings, error) {
+	if mustSkipValidation(r) {
+		return nil, nil
+	}
+	mode := policy.modeFor(r.Namespace)
+	if mode == RuleLintModeDisabled {
+		return nil, nil
+	}
+	issues := r.Lint(policy)
+	if len(issues) == 0 {
+		return nil, nil
+	}
+	if mode == RuleLintModeDeny {
+		return nil, fmt.Errorf("VMRule: %s/%s has lint issues: %s", r.Namespace, r.Name, strings.Join(issues, "; "))
+	}
+	return issues, nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -0,0 +1,294 @@
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RuleTestPhase defines phase of single rule test case execution
+type RuleTestPhase string
+
+const (
+	RuleTestPhasePending RuleTestPhase = "Pending"
+	RuleTestPhaseRunning RuleTestPhase = "Running"
+	RuleTestPhasePassed  RuleTestPhase = "Passed"
+	RuleTestPhaseFailed  RuleTestPhase = "Failed"
+)
+
+// VMRuleTestSpec defines the desired state of VMRuleTest
+type VMRuleTestSpec struct {
+	// RuleSelector defines VMRules to be tested.
+	// All VMRules from the test namespace are selected by default
+	// +optional
+	RuleSelector *DiscoverySelector `json:"ruleSelector,omitempty"`
+	// EvaluationInterval defines how often rules are evaluated, 1m by default
+	// +optional
+	EvaluationInterval string `json:"evaluationInterval,omitempty"`
+	// GroupEvalOrder defines the order in which rule groups are evaluated
+	// +optional
+	GroupEvalOrder []string `json:"groupEvalOrder,omitempty"`
+	// DisableAlertGroupLabel disables adding group name as label to generated alerts and time series
+	// +optional
+	DisableAlertGroupLabel bool `json:"disableAlertGroupLabel,omitempty"`
+	// Tests defines test cases, each test case is executed by the dedicated Job
+	// +kubebuilder:validation:MinItems=1
+	Tests []RuleTestCase `json:"tests"`
+
+	// Image - docker image settings for vmalert-tool
+	// if no specified operator uses default version from operator config
+	// +optional
+	Image Image `json:"image,omitempty"`
+	// ImagePullSecrets An optional list of references to secrets in the same namespace
+	// to use for pulling images from registries
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// Resources container resource request and limits, https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// ServiceAccountName is the name of the ServiceAccount to use for test pods
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+}
+
+// RuleTestCase defines input series and expectations for the rules,
+// it follows vmalert-tool unittest format
+// https://docs.victoriametrics.com/vmalert-tool/#test-group
+type RuleTestCase struct {
+	// Name of the test case, must be unique
+	Name string `json:"name"`
+	// Interval defines interval between input series samples, defaults to evaluationInterval
+	// +optional
+	Interval string `json:"interval,omitempty"`
+	// InputSeries defines time series used as input for the rules
+	// +optional
+	InputSeries []RuleTestInputSeries `json:"input_series,omitempty"`
+	// AlertRuleTests defines expected alerts at the given time
+	// +optional
+	AlertRuleTests []AlertRuleTest `json:"alert_rule_test,omitempty"`
+	// MetricsQLExprTests defines expected results of MetricsQL expressions at the given time
+	// +optional
+	MetricsQLExprTests []MetricsQLExprTest `json:"metricsql_expr_test,omitempty"`
+	// ExternalLabels defines labels added to the generated alerts and time series
+	// +optional
+	ExternalLabels map[string]string `json:"external_labels,omitempty"`
+}
+
+// RuleTestInputSeries defines single input time series
+type RuleTestInputSeries struct {
+	// Series in the format of metric{label="value"}
+	Series string `json:"series"`
+	// Values in expanding notation, e.g. 1+1x10 or 0 _ stale
+	Values string `json:"values"`
+}
+
+// AlertRuleTest defines alerts expected at the given evaluation time
+type AlertRuleTest struct {
+	// EvalTime defines offset from time zero at which alerts are checked
+	EvalTime string `json:"eval_time"`
+	// GroupName of the alerting rule
+	GroupName string `json:"groupname"`
+	// AlertName of the alerting rule
+	AlertName string `json:"alertname"`
+	// ExpAlerts defines expected alerts, empty list means no alerts are expected
+	// +optional
+	ExpAlerts []ExpectedAlert `json:"exp_alerts,omitempty"`
+}
+
+// ExpectedAlert defines labels and annotations of the expected alert
+type ExpectedAlert struct {
+	// +optional
+	ExpLabels map[string]string `json:"exp_labels,omitempty"`
+	// +optional
+	ExpAnnotations map[string]string `json:"exp_annotations,omitempty"`
+}
+
+// MetricsQLExprTest defines result expected for MetricsQL expression at the given evaluation time
+type MetricsQLExprTest struct {
+	// Expr is MetricsQL expression to evaluate
+	Expr string `json:"expr"`
+	// EvalTime defines offset from time zero at which expression is evaluated
+	EvalTime string `json:"eval_time"`
+	// ExpSamples defines expected samples, empty list means no samples are expected
+	// +optional
+	ExpSamples []ExpectedSample `json:"exp_samples,omitempty"`
+}
+
+// ExpectedSample defines expected sample of the expression result
+type ExpectedSample struct {
+	// Labels of the sample in the format of metric{label="value"}
+	Labels string `json:"labels"`
+	// Value of the sample, it must be a valid float number
+	Value string `json:"value"`
+}
+
+// RuleTestCaseStatus defines execution state of single test case
+type RuleTestCaseStatus struct {
+	// Name of the test case
+	Name string `json:"name"`
+	// JobName is the name of Job executing test case
+	JobName string `json:"jobName"`
+	// Phase of the test case
+	Phase RuleTestPhase `json:"phase"`
+	// Message contains output of the failed test case
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// VMRuleTestStatus defines the observed state of VMRuleTest
+type VMRuleTestStatus struct {
+	// UpdateStatus defines a status of tests execution
+	UpdateStatus UpdateStatus `json:"status,omitempty"`
+	// Reason defines a reason of failure
+	Reason string `json:"reason,omitempty"`
+	// Result shows number of passed test cases at format passed/total
+	Result string `json:"result,omitempty"`
+	// Rules contains VMRules used for testing in namespace/name format
+	// +optional
+	Rules []string `json:"rules,omitempty"`
+	// Tests contains per test case status
+	// +optional
+	Tests []RuleTestCaseStatus `json:"tests,omitempty"`
+}
+
+// VMRuleTest is the Schema for the vmruletests API
+// It defines unit tests for VMRules, which operator executes with vmalert-tool
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=vmruletests,scope=Namespaced
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.status"
+// +kubebuilder:printcolumn:name="Result",type="string",JSONPath=".status.result"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +genclient
+type VMRuleTest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VMRuleTestSpec   `json:"spec,omitempty"`
+	Status VMRuleTestStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VMRuleTestList contains a list of VMRuleTest
+type VMRuleTestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMRuleTest `json:"items"`
+}
+
+// AsOwner returns owner references with current object as owner
+func (cr *VMRuleTest) AsOwner() []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			APIVersion:         cr.APIVersion,
+			Kind:               cr.Kind,
+			Name:               cr.Name,
+			UID:                cr.UID,
+			Controller:         ptr.To(true),
+			BlockOwnerDeletion: ptr.To(true),
+		},
+	}
+}
+
+// PrefixedName returns prefixed name for test objects
+func (cr *VMRuleTest) PrefixedName() string {
+	return fmt.Sprintf("vmruletest-%s", cr.Name)
+}
+
+// SelectorLabels returns selector labels for test objects
+func (cr *VMRuleTest) SelectorLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":      "vmruletest",
+		"app.kubernetes.io/instance":  cr.Name,
+		"app.kubernetes.io/component": "monitoring",
+		"managed-by":                  "vm-operator",
+	}
+}
+
+// AllLabels returns combined labels for VMRuleTest
+func (cr *VMRuleTest) AllLabels() map[string]string {
+	labels := cr.SelectorLabels()
+	for label, value := range cr.Labels {
+		if _, ok := labels[label]; ok {
+			// forbid changes for selector labels
+			continue
+		}
+		labels[label] = value
+	}
+	return labels
+}
+
+// AnnotationsFiltered returns global annotations to be applied by objects generate for vmruletest
+func (cr *VMRuleTest) AnnotationsFiltered() map[string]string {
+	annotations := make(map[string]string)
+	for annotation, value := range cr.Annotations {
+		if !strings.HasPrefix(annotation, "kubectl.kubernetes.io/") {
+			annotations[annotation] = value
+		}
+	}
+	return annotations
+}
+
+// SetUpdateStatusTo changes update status with optional reason of fail
+func (cr *VMRuleTest) SetUpdateStatusTo(ctx context.Context, r client.Client, status UpdateStatus, maybeErr error) error {
+	return cr.patchUpdateStatus(ctx, r, cr.Status.DeepCopy(), status, maybeErr)
+}
+
+// patchUpdateStatus changes update status and patches it if status differs from prevStatus
+func (cr *VMRuleTest) patchUpdateStatus(ctx context.Context, r client.Client, prevStatus *VMRuleTestStatus, status UpdateStatus, maybeErr error) error {
+	switch status {
+	case UpdateStatusExpanding, UpdateStatusOperational:
+		cr.Status.Reason = ""
+	case UpdateStatusFailed:
+		if maybeErr != nil {
+			cr.Status.Reason = maybeErr.Error()
+		}
+	default:
+		panic(fmt.Sprintf("BUG: not expected status=%q", status))
+	}
+	cr.Status.UpdateStatus = status
+	if equality.Semantic.DeepEqual(&cr.Status, prevStatus) {
+		return nil
+	}
+	return statusPatch(ctx, r, cr.DeepCopy(), cr.Status)
+}
+
+// SetTestsStatus updates per test case status and result of tests execution
+func (cr *VMRuleTest) SetTestsStatus(ctx context.Context, r client.Client, rules []string, tests []RuleTestCaseStatus) error {
+	prevStatus := cr.Status.DeepCopy()
+	cr.Status.Rules = rules
+	cr.Status.Tests = tests
+	var passed int
+	var failed []string
+	allDone := true
+	for _, tc := range tests {
+		switch tc.Phase {
+		case RuleTestPhasePassed:
+			passed++
+		case RuleTestPhaseFailed:
+			failed = append(failed, tc.Name)
+		default:
+			allDone = false
+		}
+	}
+	cr.Status.Result = fmt.Sprintf("%d/%d", passed, len(tests))
+	switch {
+	case len(failed) > 0 && allDone:
+		return cr.patchUpdateStatus(ctx, r, prevStatus, UpdateStatusFailed, fmt.Errorf("test cases failed: %s", strings.Join(failed, ",")))
+	case allDone:
+		return cr.patchUpdateStatus(ctx, r, prevStatus, UpdateStatusOperational, nil)
+	default:
+		return cr.patchUpdateStatus(ctx, r, prevStatus, UpdateStatusExpanding, nil)
+	}
+}
+
+func init() {
+	SchemeBuilder.Register(&VMRuleTest{}, &VMRuleTestList{})
+}
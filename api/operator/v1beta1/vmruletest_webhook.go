@@ -0,0 +1,120 @@
+package v1beta1
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/VictoriaMetrics/VictoriaMetrics/lib/promutils"
+	"github.com/VictoriaMetrics/metricsql"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *VMRuleTest) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-operator-victoriametrics-com-v1beta1-vmruletest,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.victoriametrics.com,resources=vmruletests,verbs=create;update,versions=v1beta1,name=vvmruletest.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &VMRuleTest{}
+
+// Validate performs logical validation
+func (r *VMRuleTest) Validate() error {
+	if mustSkipValidation(r) {
+		return nil
+	}
+	if len(r.Spec.Tests) == 0 {
+		return fmt.Errorf("at least 1 test case must be provided for spec.tests")
+	}
+	if r.Spec.RuleSelector != nil {
+		if _, err := r.Spec.RuleSelector.AsListOptions(); err != nil {
+			return fmt.Errorf("incorrect spec.ruleSelector: %w", err)
+		}
+	}
+	if r.Spec.EvaluationInterval != "" {
+		if _, err := promutils.ParseDuration(r.Spec.EvaluationInterval); err != nil {
+			return fmt.Errorf("cannot parse spec.evaluationInterval: %w", err)
+		}
+	}
+	uniqNames := make(map[string]struct{}, len(r.Spec.Tests))
+	for idx, tc := range r.Spec.Tests {
+		if tc.Name == "" {
+			return fmt.Errorf("name cannot be empty for test case at idx=%d", idx)
+		}
+		if _, ok := uniqNames[tc.Name]; ok {
+			return fmt.Errorf("duplicate test case name=%q", tc.Name)
+		}
+		uniqNames[tc.Name] = struct{}{}
+		if err := validateRuleTestCase(&tc); err != nil {
+			return fmt.Errorf("incorrect test case=%q: %w", tc.Name, err)
+		}
+	}
+	return nil
+}
+
+func validateRuleTestCase(tc *RuleTestCase) error {
+	if tc.Interval != "" {
+		if _, err := promutils.ParseDuration(tc.Interval); err != nil {
+			return fmt.Errorf("cannot parse interval: %w", err)
+		}
+	}
+	if len(tc.AlertRuleTests) == 0 && len(tc.MetricsQLExprTests) == 0 {
+		return fmt.Errorf("at least one of alert_rule_test or metricsql_expr_test must be provided")
+	}
+	for idx, is := range tc.InputSeries {
+		if _, err := metricsql.Parse(is.Series); err != nil {
+			return fmt.Errorf("cannot parse input_series at idx=%d: %w", idx, err)
+		}
+		if is.Values == "" {
+			return fmt.Errorf("values cannot be empty for input_series at idx=%d", idx)
+		}
+	}
+	for idx, at := range tc.AlertRuleTests {
+		if _, err := promutils.ParseDuration(at.EvalTime); err != nil {
+			return fmt.Errorf("cannot parse eval_time for alert_rule_test at idx=%d: %w", idx, err)
+		}
+		if at.GroupName == "" || at.AlertName == "" {
+			return fmt.Errorf("groupname and alertname must be provided for alert_rule_test at idx=%d", idx)
+		}
+	}
+	for idx, et := range tc.MetricsQLExprTests {
+		if _, err := promutils.ParseDuration(et.EvalTime); err != nil {
+			return fmt.Errorf("cannot parse eval_time for metricsql_expr_test at idx=%d: %w", idx, err)
+		}
+		if _, err := metricsql.Parse(et.Expr); err != nil {
+			return fmt.Errorf("cannot parse expr for metricsql_expr_test at idx=%d: %w", idx, err)
+		}
+		for sIdx, s := range et.ExpSamples {
+			if _, err := strconv.ParseFloat(s.Value, 64); err != nil {
+				return fmt.Errorf("cannot parse value of exp_samples at idx=%d for metricsql_expr_test at idx=%d: %w", sIdx, idx, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *VMRuleTest) ValidateCreate() (admission.Warnings, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *VMRuleTest) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *VMRuleTest) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,86 @@
+package v1beta1
+
+import (
+	"testing"
+)
+
+func TestVMRuleTest_Validate(t *testing.T) {
+	f := func(spec VMRuleTestSpec, wantErr bool) {
+		t.Helper()
+		cr := &VMRuleTest{Spec: spec}
+		err := cr.Validate()
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected validation result, wantErr: %v, got err: %v", wantErr, err)
+		}
+	}
+	inputSeries := []RuleTestInputSeries{{Series: `up{job="vmagent"}`, Values: "0+0x10"}}
+
+	// valid test
+	f(VMRuleTestSpec{
+		EvaluationInterval: "30s",
+		Tests: []RuleTestCase{
+			{
+				Name:        "target down",
+				Interval:    "1m",
+				InputSeries: inputSeries,
+				AlertRuleTests: []AlertRuleTest{
+					{
+						EvalTime:  "5m",
+						GroupName: "targets",
+						AlertName: "TargetDown",
+						ExpAlerts: []ExpectedAlert{{ExpLabels: map[string]string{"job": "vmagent"}}},
+					},
+				},
+				MetricsQLExprTests: []MetricsQLExprTest{
+					{
+						Expr:       `up == 0`,
+						EvalTime:   "5m",
+						ExpSamples: []ExpectedSample{{Labels: `up{job="vmagent"}`, Value: "0"}},
+					},
+				},
+			},
+		},
+	}, false)
+
+	// no tests
+	f(VMRuleTestSpec{}, true)
+
+	// duplicate names
+	tc := RuleTestCase{
+		Name:               "expr",
+		InputSeries:        inputSeries,
+		MetricsQLExprTests: []MetricsQLExprTest{{Expr: "up", EvalTime: "1m"}},
+	}
+	f(VMRuleTestSpec{Tests: []RuleTestCase{tc, tc}}, true)
+
+	// no expectations
+	f(VMRuleTestSpec{Tests: []RuleTestCase{{Name: "empty", InputSeries: inputSeries}}}, true)
+
+	// incorrect eval_time
+	f(VMRuleTestSpec{Tests: []RuleTestCase{{
+		Name:               "expr",
+		MetricsQLExprTests: []MetricsQLExprTest{{Expr: "up", EvalTime: "5 minutes"}},
+	}}}, true)
+
+	// incorrect expr
+	f(VMRuleTestSpec{Tests: []RuleTestCase{{
+		Name:               "expr",
+		MetricsQLExprTests: []MetricsQLExprTest{{Expr: "sum(up", EvalTime: "1m"}},
+	}}}, true)
+
+	// incorrect sample value
+	f(VMRuleTestSpec{Tests: []RuleTestCase{{
+		Name: "expr",
+		MetricsQLExprTests: []MetricsQLExprTest{{
+			Expr:       "up",
+			EvalTime:   "1m",
+			ExpSamples: []ExpectedSample{{Labels: "up", Value: "one"}},
+		}},
+	}}}, true)
+
+	// missing alertname
+	f(VMRuleTestSpec{Tests: []RuleTestCase{{
+		Name:           "alert",
+		AlertRuleTests: []AlertRuleTest{{EvalTime: "1m", GroupName: "targets"}},
+	}}}, true)
+}
@@ -73,6 +73,12 @@ type VMSingleSpec struct {
 	// ServiceSpec that will be added to vmsingle service spec
 	// +optional
 	ServiceSpec *AdditionalServiceSpec `json:"serviceSpec,omitempty"`
+	// HTTPRoute enables Gateway API route configuration for VMSingle.
+	// +optional
+	HTTPRoute *EmbeddedHTTPRoute `json:"httpRoute,omitempty"`
+	// NetworkPolicy enables generation of NetworkPolicy for VMSingle pods.
+	// +optional
+	NetworkPolicy *EmbeddedNetworkPolicy `json:"networkPolicy,omitempty"`
 	// ServiceScrapeSpec that will be added to vmsingle VMServiceScrape spec
 	// +optional
 	ServiceScrapeSpec *VMServiceScrapeSpec `json:"serviceScrapeSpec,omitempty"`
@@ -134,6 +140,14 @@ type VMSingleStatus struct {
 	UpdateStatus UpdateStatus `json:"singleStatus,omitempty"`
 	// Reason defines a reason in case of update failure
 	Reason string `json:"reason,omitempty"`
+	// ObservedGeneration defines current generation picked by operator for the
+	// reconcile
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions defines latest available observations of object state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // VMSingle  is fast, cost-effective and scalable time-series database.
@@ -346,6 +360,9 @@ func (cr *VMSingle) SetUpdateStatusTo(ctx context.Context, r client.Client, stat
 	default:
 		panic(fmt.Sprintf("BUG: not expected status=%q", status))
 	}
+	cr.Status.ObservedGeneration = cr.Generation
+	cr.Status.Conditions = updateConditions(cr.Status.Conditions, cr.Generation, status, maybeErr)
+
 	if equality.Semantic.DeepEqual(&cr.Status, prevStatus) && currentStatus == status {
 		return nil
 	}
@@ -41,6 +41,16 @@ func (r *VMSingle) SetupWebhookWithManager(mgr ctrl.Manager) error {
 var _ webhook.Validator = &VMSingle{}
 
 func (r *VMSingle) sanityCheck() error {
+	if r.Spec.VPA != nil {
+		if err := r.Spec.VPA.sanityCheck(); err != nil {
+			return fmt.Errorf("incorrect spec.vpa: %w", err)
+		}
+	}
+	if r.Spec.HTTPRoute != nil {
+		if err := r.Spec.HTTPRoute.Validate(); err != nil {
+			return fmt.Errorf("incorrect spec.httpRoute: %w", err)
+		}
+	}
 	if r.Spec.VMBackup != nil {
 		if err := r.Spec.VMBackup.sanityCheck(r.Spec.License); err != nil {
 			return err
@@ -0,0 +1,222 @@
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VMTenantSpec defines the desired state of VMTenant
+type VMTenantSpec struct {
+	// ClusterRef references VMCluster, which stores tenant data
+	ClusterRef VMTenantClusterRef `json:"clusterRef"`
+	// AccountID of the tenant
+	// +kubebuilder:validation:Minimum=0
+	AccountID int32 `json:"accountID"`
+	// ProjectID of the tenant
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ProjectID int32 `json:"projectID,omitempty"`
+	// Write configures VMUser with access to vminsert of the tenant.
+	// Generated credentials could be used for VMAgent remote write.
+	// +optional
+	Write *VMTenantAccess `json:"write,omitempty"`
+	// Read configures VMUser with access to vmselect of the tenant.
+	// +optional
+	Read *VMTenantAccess `json:"read,omitempty"`
+}
+
+// VMTenantClusterRef references VMCluster object
+type VMTenantClusterRef struct {
+	// Name of the VMCluster object
+	Name string `json:"name"`
+	// Namespace of the VMCluster object,
+	// defaults to VMTenant namespace
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// VMTenantAccess defines VMUser generated for tenant routes
+type VMTenantAccess struct {
+	// UserName basic auth user name,
+	// defaults to VMTenant name with write or read suffix
+	// +optional
+	UserName *string `json:"username,omitempty"`
+	// PasswordRef allows fetching password from user-created secret by its name and key.
+	// If omitted, operator generates password and stores it at VMTenant credentials secret.
+	// +optional
+	PasswordRef *v1.SecretKeySelector `json:"passwordRef,omitempty"`
+	// Paths - matched paths to route, all paths are routed by default.
+	// +optional
+	Paths []string `json:"paths,omitempty"`
+	// MaxConcurrentRequests defines max concurrent requests of the tenant at vmauth
+	// +optional
+	MaxConcurrentRequests *int `json:"max_concurrent_requests,omitempty"`
+	// Limits defines query duration limit of the tenant.
+	// Request rate and ingestion bandwidth limits are not supported.
+	// +optional
+	Limits *VMUserLimits `json:"limits,omitempty"`
+}
+
+// VMTenantStatus defines the observed state of VMTenant
+type VMTenantStatus struct {
+	// UpdateStatus defines a status of tenant routes generation
+	UpdateStatus UpdateStatus `json:"status,omitempty"`
+	// Reason defines a reason of failure
+	Reason string `json:"reason,omitempty"`
+	// TenantID is a tenant identifier at accountID:projectID format
+	TenantID string `json:"tenantID,omitempty"`
+	// InsertURL is an url of vminsert for the tenant data ingestion
+	InsertURL string `json:"insertURL,omitempty"`
+	// SelectURL is an url of vmselect prometheus querying API for the tenant
+	SelectURL string `json:"selectURL,omitempty"`
+	// WriteUser is a name of generated VMUser for write access
+	WriteUser string `json:"writeUser,omitempty"`
+	// ReadUser is a name of generated VMUser for read access
+	ReadUser string `json:"readUser,omitempty"`
+	// CredentialsSecret is a name of secret with generated credentials
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+}
+
+// VMTenant is the Schema for the vmtenants API
+// It declares VMCluster tenant and generates VMUsers with write and read routes for it
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=vmtenants,scope=Namespaced
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterRef.name"
+// +kubebuilder:printcolumn:name="Tenant",type="string",JSONPath=".status.tenantID"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +genclient
+type VMTenant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VMTenantSpec   `json:"spec,omitempty"`
+	Status VMTenantStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VMTenantList contains a list of VMTenant
+type VMTenantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMTenant `json:"items"`
+}
+
+// TenantID returns tenant identifier in accountID:projectID format
+func (cr *VMTenant) TenantID() string {
+	return fmt.Sprintf("%d:%d", cr.Spec.AccountID, cr.Spec.ProjectID)
+}
+
+// ClusterNamespace returns namespace of referenced VMCluster
+func (cr *VMTenant) ClusterNamespace() string {
+	if cr.Spec.ClusterRef.Namespace == "" {
+		return cr.Namespace
+	}
+	return cr.Spec.ClusterRef.Namespace
+}
+
+// PrefixedName returns prefixed name for generated objects
+func (cr *VMTenant) PrefixedName() string {
+	return fmt.Sprintf("vmtenant-%s", cr.Name)
+}
+
+// WriteUserName returns name of generated VMUser with write access
+func (cr *VMTenant) WriteUserName() string {
+	return cr.PrefixedName() + "-write"
+}
+
+// ReadUserName returns name of generated VMUser with read access
+func (cr *VMTenant) ReadUserName() string {
+	return cr.PrefixedName() + "-read"
+}
+
+// AsOwner returns owner references with current object as owner
+func (cr *VMTenant) AsOwner() []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			APIVersion:         cr.APIVersion,
+			Kind:               cr.Kind,
+			Name:               cr.Name,
+			UID:                cr.UID,
+			Controller:         ptr.To(true),
+			BlockOwnerDeletion: ptr.To(true),
+		},
+	}
+}
+
+// SelectorLabels returns selector labels for generated objects
+func (cr *VMTenant) SelectorLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":      "vmtenant",
+		"app.kubernetes.io/instance":  cr.Name,
+		"app.kubernetes.io/component": "monitoring",
+		"managed-by":                  "vm-operator",
+	}
+}
+
+// AllLabels returns combined labels for VMTenant.
+// Generated VMUsers inherit labels of VMTenant, so VMAuth userSelector could match them
+func (cr *VMTenant) AllLabels() map[string]string {
+	labels := cr.SelectorLabels()
+	for label, value := range cr.Labels {
+		if _, ok := labels[label]; ok {
+			// forbid changes for selector labels
+			continue
+		}
+		labels[label] = value
+	}
+	return labels
+}
+
+// AnnotationsFiltered returns global annotations to be applied by objects generate for vmtenant
+func (cr *VMTenant) AnnotationsFiltered() map[string]string {
+	annotations := make(map[string]string)
+	for annotation, value := range cr.Annotations {
+		if !strings.HasPrefix(annotation, "kubectl.kubernetes.io/") {
+			annotations[annotation] = value
+		}
+	}
+	return annotations
+}
+
+// SetUpdateStatusTo changes update status with optional reason of fail
+func (cr *VMTenant) SetUpdateStatusTo(ctx context.Context, r client.Client, status UpdateStatus, maybeErr error) error {
+	prevStatus := cr.Status.DeepCopy()
+	switch status {
+	case UpdateStatusOperational:
+		cr.Status.Reason = ""
+	case UpdateStatusFailed:
+		if maybeErr != nil {
+			cr.Status.Reason = maybeErr.Error()
+		}
+	default:
+		panic(fmt.Sprintf("BUG: not expected status=%q", status))
+	}
+	cr.Status.UpdateStatus = status
+	if equality.Semantic.DeepEqual(&cr.Status, prevStatus) {
+		return nil
+	}
+	return statusPatch(ctx, r, cr.DeepCopy(), cr.Status)
+}
+
+// SetRoutesStatus updates resolved tenant urls and names of generated objects
+func (cr *VMTenant) SetRoutesStatus(ctx context.Context, r client.Client, status *VMTenantStatus) error {
+	if equality.Semantic.DeepEqual(&cr.Status, status) {
+		return nil
+	}
+	cr.Status = *status
+	return statusPatch(ctx, r, cr.DeepCopy(), cr.Status)
+}
+
+func init() {
+	SchemeBuilder.Register(&VMTenant{}, &VMTenantList{})
+}
@@ -3,7 +3,9 @@ package v1beta1
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/VictoriaMetrics/VictoriaMetrics/lib/promutils"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
@@ -35,6 +37,19 @@ type VMUserSpec struct {
 	// BearerToken Authorization header value for accessing protected endpoint.
 	// +optional
 	BearerToken *string `json:"bearerToken,omitempty"`
+	// JWT configures authentication with JSON Web Tokens issued by OIDC provider.
+	// It cannot be used with basic auth or bearer token.
+	// supported only with enterprise version of [vmauth](https://docs.victoriametrics.com/vmauth/)
+	// +optional
+	JWT *VMUserJWT `json:"jwt,omitempty"`
+	// Rotation defines policy for scheduled rotation of generated password.
+	// Only generated password is rotated, rotation of bearer tokens is not supported.
+	// VMUser with rotation is rejected, if it has password, passwordRef, bearerToken or tokenRef.
+	// +optional
+	Rotation *VMUserCredentialRotation `json:"rotation,omitempty"`
+	// Limits defines query duration limit for user
+	// +optional
+	Limits *VMUserLimits `json:"limits,omitempty"`
 	// TargetRefs - reference to endpoints, which user may access.
 	TargetRefs []TargetRef `json:"targetRefs"`
 
@@ -48,6 +63,136 @@ type VMUserSpec struct {
 	DisableSecretCreation bool `json:"disable_secret_creation,omitempty"`
 }
 
+// JWTTenantPlaceholder is replaced with tenant from VMUserJWTTenantMapping at targetRefs
+const JWTTenantPlaceholder = "%TENANT%"
+
+// VMUserJWT defines JSON Web Token authentication settings for VMUser
+type VMUserJWT struct {
+	// Issuer defines OIDC issuer url, which is used for discovery of token verification keys
+	// +optional
+	Issuer string `json:"issuer,omitempty"`
+	// JWKSURL defines url of JSON Web Key Set with token verification keys
+	// +optional
+	JWKSURL string `json:"jwksURL,omitempty"`
+	// PublicKeys defines PEM encoded public keys for token verification
+	// +optional
+	PublicKeys []string `json:"publicKeys,omitempty"`
+	// SkipVerify disables token signature verification, must be used only for testing
+	// +optional
+	SkipVerify bool `json:"skipVerify,omitempty"`
+	// RequiredClaims defines claims, which must be present at token with given values
+	// +optional
+	RequiredClaims map[string]string `json:"requiredClaims,omitempty"`
+	// TenantMapping maps token claims to VMCluster tenants.
+	// Tenant replaces %TENANT% placeholder at targetRefs targetPathSuffix and static urls.
+	// Tokens, which don't match any mapping, are rejected
+	// +optional
+	TenantMapping []VMUserJWTTenantMapping `json:"tenantMapping,omitempty"`
+}
+
+// VMUserJWTTenantMapping defines tenant for tokens with given claims
+type VMUserJWTTenantMapping struct {
+	// Claims defines claims, which token must have to be mapped to tenant
+	Claims map[string]string `json:"claims"`
+	// Tenant defines VMCluster tenant in form of accountID or accountID:projectID
+	Tenant string `json:"tenant"`
+}
+
+// VMUserCredentialRotation defines policy for VMUser credential rotation.
+// Operator generates new password after each interval and keeps previous password
+// valid at vmauth config during overlap window.
+type VMUserCredentialRotation struct {
+	// Interval defines how often password must be rotated, e.g. 90d
+	Interval string `json:"interval"`
+	// OverlapWindow defines for how long previous password is accepted after rotation, e.g. 24h.
+	// Previous password is dropped right after rotation if omitted.
+	// +optional
+	OverlapWindow string `json:"overlapWindow,omitempty"`
+}
+
+// ValidateRotation checks that rotation policy is applicable to user credentials.
+// Only generated password could be rotated
+func (s *VMUserSpec) ValidateRotation() error {
+	if s.Rotation == nil {
+		return nil
+	}
+	if !s.GeneratePassword || s.Password != nil || s.PasswordRef != nil {
+		return fmt.Errorf("spec.rotation requires spec.generatePassword and cannot be used with spec.password or spec.passwordRef")
+	}
+	if s.BearerToken != nil || s.TokenRef != nil {
+		return fmt.Errorf("spec.rotation cannot be used with spec.bearerToken or spec.tokenRef, rotation of bearer tokens is not supported")
+	}
+	if s.DisableSecretCreation {
+		return fmt.Errorf("spec.rotation cannot be used with spec.disable_secret_creation")
+	}
+	if _, _, err := s.Rotation.Durations(); err != nil {
+		return fmt.Errorf("incorrect spec.rotation: %w", err)
+	}
+	return nil
+}
+
+// Durations returns parsed rotation interval and overlap window
+func (r *VMUserCredentialRotation) Durations() (time.Duration, time.Duration, error) {
+	interval, err := promutils.ParseDuration(r.Interval)
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot parse interval: %w", err)
+	}
+	if interval <= 0 {
+		return 0, 0, fmt.Errorf("interval must be positive, got: %q", r.Interval)
+	}
+	var overlap time.Duration
+	if r.OverlapWindow != "" {
+		overlap, err = promutils.ParseDuration(r.OverlapWindow)
+		if err != nil {
+			return 0, 0, fmt.Errorf("cannot parse overlapWindow: %w", err)
+		}
+	}
+	if overlap < 0 || overlap >= interval {
+		return 0, 0, fmt.Errorf("overlapWindow=%q must be less than interval=%q", r.OverlapWindow, r.Interval)
+	}
+	return interval, overlap, nil
+}
+
+// VMUserLimits defines per-user limits.
+// vmauth doesn't support request rate and bandwidth limits,
+// so only limits enforced by backends are supported.
+type VMUserLimits struct {
+	// MaxRequestsPerSecond is not supported, since vmauth has no per-user request rate limit
+	// and vmselect has no per-tenant one. VMUser with this field is rejected.
+	// Use max_concurrent_requests to limit user requests at vmauth.
+	// +optional
+	MaxRequestsPerSecond *int `json:"maxRequestsPerSecond,omitempty"`
+	// MaxIngestionBytesPerSecond is not supported, since vmauth has no per-user bandwidth limit
+	// and vminsert has no per-tenant one. VMUser with this field is rejected.
+	// +optional
+	MaxIngestionBytesPerSecond *int64 `json:"maxIngestionBytesPerSecond,omitempty"`
+	// MaxQueryDuration defines max duration of user queries, e.g. 30s.
+	// It's passed as timeout query arg to VMSingle and VMCluster/vmselect targets.
+	// Backend -search.maxQueryDuration flag still caps the query duration.
+	// +optional
+	MaxQueryDuration string `json:"maxQueryDuration,omitempty"`
+}
+
+// Validate checks limits values
+func (l *VMUserLimits) Validate() error {
+	if l.MaxRequestsPerSecond != nil {
+		return fmt.Errorf("maxRequestsPerSecond is not supported: vmauth has no per-user request rate limit, use max_concurrent_requests instead")
+	}
+	if l.MaxIngestionBytesPerSecond != nil {
+		return fmt.Errorf("maxIngestionBytesPerSecond is not supported: vmauth has no per-user bandwidth limit")
+	}
+	if l.MaxQueryDuration != "" {
+		d, err := promutils.ParseDuration(l.MaxQueryDuration)
+		if err != nil {
+			return fmt.Errorf("cannot parse maxQueryDuration: %w", err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("maxQueryDuration must be positive, got: %q", l.MaxQueryDuration)
+		}
+	}
+	return nil
+}
+
 // TargetRef describes target for user traffic forwarding.
 // one of target types can be chosen:
 // crd or static per targetRef.
@@ -138,6 +283,9 @@ type VMUserStatus struct {
 	LastSyncError string `json:"lastSyncError,omitempty"`
 	// CurrentSyncError holds an error occured during reconcile loop
 	CurrentSyncError string `json:"-"`
+	// Limits contains limits actually applied to the user targets
+	// +optional
+	Limits *VMUserLimits `json:"limits,omitempty"`
 }
 
 // VMUser is the Schema for the vmusers API
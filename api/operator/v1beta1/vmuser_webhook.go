@@ -17,7 +17,10 @@ limitations under the License.
 package v1beta1
 
 import (
+	"encoding/pem"
 	"fmt"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -51,6 +54,19 @@ func (r *VMUser) sanityCheck() error {
 	if len(r.Spec.TargetRefs) == 0 {
 		return fmt.Errorf("at least 1 TargetRef must be provided for spec.targetRefs")
 	}
+	if r.Spec.JWT != nil {
+		if err := r.validateJWT(); err != nil {
+			return fmt.Errorf("incorrect spec.jwt: %w", err)
+		}
+	}
+	if err := r.Spec.ValidateRotation(); err != nil {
+		return err
+	}
+	if r.Spec.Limits != nil {
+		if err := r.Spec.Limits.Validate(); err != nil {
+			return fmt.Errorf("incorrect spec.limits: %w", err)
+		}
+	}
 	isRetryCodesSet := len(r.Spec.RetryStatusCodes) > 0
 	for i := range r.Spec.TargetRefs {
 		targetRef := r.Spec.TargetRefs[i]
@@ -94,6 +110,53 @@ func (r *VMUser) sanityCheck() error {
 	return nil
 }
 
+func (r *VMUser) validateJWT() error {
+	jwt := r.Spec.JWT
+	if r.Spec.UserName != nil || r.Spec.Password != nil || r.Spec.PasswordRef != nil || r.Spec.GeneratePassword ||
+		r.Spec.BearerToken != nil || r.Spec.TokenRef != nil {
+		return fmt.Errorf("jwt cannot be used with basic auth or bearer token")
+	}
+	if jwt.Issuer == "" && jwt.JWKSURL == "" && len(jwt.PublicKeys) == 0 && !jwt.SkipVerify {
+		return fmt.Errorf("one of issuer, jwksURL or publicKeys must be provided")
+	}
+	for _, u := range []string{jwt.Issuer, jwt.JWKSURL} {
+		if u == "" {
+			continue
+		}
+		if _, err := url.ParseRequestURI(u); err != nil {
+			return fmt.Errorf("cannot parse url=%q: %w", u, err)
+		}
+	}
+	for idx, key := range jwt.PublicKeys {
+		if block, _ := pem.Decode([]byte(key)); block == nil {
+			return fmt.Errorf("cannot decode PEM public key at idx=%d", idx)
+		}
+	}
+	for idx, tm := range jwt.TenantMapping {
+		if len(tm.Claims) == 0 {
+			return fmt.Errorf("claims cannot be empty for tenantMapping at idx=%d", idx)
+		}
+		if err := validateTenantID(tm.Tenant); err != nil {
+			return fmt.Errorf("incorrect tenant for tenantMapping at idx=%d: %w", idx, err)
+		}
+	}
+	return nil
+}
+
+// validateTenantID checks if tenant has accountID or accountID:projectID form
+func validateTenantID(tenant string) error {
+	accountID, projectID, hasProject := strings.Cut(tenant, ":")
+	if _, err := strconv.ParseUint(accountID, 10, 32); err != nil {
+		return fmt.Errorf("cannot parse accountID of tenant=%q: %w", tenant, err)
+	}
+	if hasProject {
+		if _, err := strconv.ParseUint(projectID, 10, 32); err != nil {
+			return fmt.Errorf("cannot parse projectID of tenant=%q: %w", tenant, err)
+		}
+	}
+	return nil
+}
+
 func parseHeaders(src []string) error {
 	for idx, s := range src {
 		n := strings.IndexByte(s, ':')
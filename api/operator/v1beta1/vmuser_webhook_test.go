@@ -136,6 +136,166 @@ func TestVMUser_sanityCheck(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "correct rotation",
+			fields: fields{
+				Spec: VMUserSpec{
+					GeneratePassword: true,
+					Rotation:         &VMUserCredentialRotation{Interval: "90d", OverlapWindow: "24h"},
+					TargetRefs:       []TargetRef{{Static: &StaticRef{URL: "http://some-url"}}},
+				},
+			},
+		},
+		{
+			name: "rotation without generated password",
+			fields: fields{
+				Spec: VMUserSpec{
+					Password:   ptr.To("some-password"),
+					Rotation:   &VMUserCredentialRotation{Interval: "90d"},
+					TargetRefs: []TargetRef{{Static: &StaticRef{URL: "http://some-url"}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rotation with bearer token",
+			fields: fields{
+				Spec: VMUserSpec{
+					GeneratePassword: true,
+					BearerToken:      ptr.To("token"),
+					Rotation:         &VMUserCredentialRotation{Interval: "90d"},
+					TargetRefs:       []TargetRef{{Static: &StaticRef{URL: "http://some-url"}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rotation with token ref",
+			fields: fields{
+				Spec: VMUserSpec{
+					GeneratePassword: true,
+					TokenRef:         &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "token"}, Key: "token"},
+					Rotation:         &VMUserCredentialRotation{Interval: "90d"},
+					TargetRefs:       []TargetRef{{Static: &StaticRef{URL: "http://some-url"}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rotation overlap exceeds interval",
+			fields: fields{
+				Spec: VMUserSpec{
+					GeneratePassword: true,
+					Rotation:         &VMUserCredentialRotation{Interval: "1d", OverlapWindow: "48h"},
+					TargetRefs:       []TargetRef{{Static: &StaticRef{URL: "http://some-url"}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "correct limits",
+			fields: fields{
+				Spec: VMUserSpec{
+					UserName:   ptr.To("user"),
+					Limits:     &VMUserLimits{MaxQueryDuration: "30s"},
+					TargetRefs: []TargetRef{{Static: &StaticRef{URL: "http://some-url"}}},
+				},
+			},
+		},
+		{
+			name: "incorrect limits",
+			fields: fields{
+				Spec: VMUserSpec{
+					UserName:   ptr.To("user"),
+					Limits:     &VMUserLimits{MaxQueryDuration: "-1s"},
+					TargetRefs: []TargetRef{{Static: &StaticRef{URL: "http://some-url"}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported request rate limit",
+			fields: fields{
+				Spec: VMUserSpec{
+					UserName:   ptr.To("user"),
+					Limits:     &VMUserLimits{MaxRequestsPerSecond: ptr.To(10), MaxQueryDuration: "30s"},
+					TargetRefs: []TargetRef{{Static: &StaticRef{URL: "http://some-url"}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported ingestion bandwidth limit",
+			fields: fields{
+				Spec: VMUserSpec{
+					UserName:   ptr.To("user"),
+					Limits:     &VMUserLimits{MaxIngestionBytesPerSecond: ptr.To(int64(1024))},
+					TargetRefs: []TargetRef{{Static: &StaticRef{URL: "http://some-url"}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "correct jwt",
+			fields: fields{
+				Spec: VMUserSpec{
+					JWT: &VMUserJWT{
+						Issuer:         "https://sso.example.com/realms/main",
+						RequiredClaims: map[string]string{"aud": "grafana"},
+						TenantMapping: []VMUserJWTTenantMapping{
+							{Claims: map[string]string{"team": "a"}, Tenant: "10"},
+							{Claims: map[string]string{"team": "b"}, Tenant: "20:1"},
+						},
+					},
+					TargetRefs: []TargetRef{{Static: &StaticRef{URL: "http://vmselect:8481"}, TargetPathSuffix: "/select/%TENANT%/prometheus"}},
+				},
+			},
+		},
+		{
+			name: "jwt with basic auth",
+			fields: fields{
+				Spec: VMUserSpec{
+					UserName:   ptr.To("user"),
+					Password:   ptr.To("some-password"),
+					JWT:        &VMUserJWT{JWKSURL: "https://sso.example.com/certs"},
+					TargetRefs: []TargetRef{{Static: &StaticRef{URL: "http://some-url"}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "jwt without verification keys",
+			fields: fields{
+				Spec: VMUserSpec{
+					JWT:        &VMUserJWT{RequiredClaims: map[string]string{"aud": "grafana"}},
+					TargetRefs: []TargetRef{{Static: &StaticRef{URL: "http://some-url"}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "jwt with incorrect public key",
+			fields: fields{
+				Spec: VMUserSpec{
+					JWT:        &VMUserJWT{PublicKeys: []string{"not-a-pem"}},
+					TargetRefs: []TargetRef{{Static: &StaticRef{URL: "http://some-url"}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "jwt with incorrect tenant",
+			fields: fields{
+				Spec: VMUserSpec{
+					JWT: &VMUserJWT{
+						JWKSURL:       "https://sso.example.com/certs",
+						TenantMapping: []VMUserJWTTenantMapping{{Claims: map[string]string{"team": "a"}, Tenant: "team-a"}},
+					},
+					TargetRefs: []TargetRef{{Static: &StaticRef{URL: "http://some-url"}}},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
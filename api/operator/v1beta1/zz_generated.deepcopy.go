@@ -79,6 +79,28 @@ func (in *AdditionalServiceSpec) DeepCopy() *AdditionalServiceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertRuleTest) DeepCopyInto(out *AlertRuleTest) {
+	*out = *in
+	if in.ExpAlerts != nil {
+		in, out := &in.ExpAlerts, &out.ExpAlerts
+		*out = make([]ExpectedAlert, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertRuleTest.
+func (in *AlertRuleTest) DeepCopy() *AlertRuleTest {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertRuleTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AlertmanagerGossipConfig) DeepCopyInto(out *AlertmanagerGossipConfig) {
 	*out = *in
@@ -263,6 +285,125 @@ func (in *AzureSDConfig) DeepCopy() *AzureSDConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupJobCommonParams) DeepCopyInto(out *BackupJobCommonParams) {
+	*out = *in
+	out.Image = in.Image
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.CustomS3Endpoint != nil {
+		in, out := &in.CustomS3Endpoint, &out.CustomS3Endpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.CredentialsSecret != nil {
+		in, out := &in.CredentialsSecret, &out.CredentialsSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LogLevel != nil {
+		in, out := &in.LogLevel, &out.LogLevel
+		*out = new(string)
+		**out = **in
+	}
+	if in.LogFormat != nil {
+		in, out := &in.LogFormat, &out.LogFormat
+		*out = new(string)
+		**out = **in
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExtraEnvs != nil {
+		in, out := &in.ExtraEnvs, &out.ExtraEnvs
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupJobCommonParams.
+func (in *BackupJobCommonParams) DeepCopy() *BackupJobCommonParams {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupJobCommonParams)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupJobNodeStatus) DeepCopyInto(out *BackupJobNodeStatus) {
+	*out = *in
+	if in.LastSuccessTime != nil {
+		in, out := &in.LastSuccessTime, &out.LastSuccessTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupJobNodeStatus.
+func (in *BackupJobNodeStatus) DeepCopy() *BackupJobNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupJobNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupTargetRef) DeepCopyInto(out *BackupTargetRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupTargetRef.
+func (in *BackupTargetRef) DeepCopy() *BackupTargetRef {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupTargetRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
 	*out = *in
@@ -301,134 +442,451 @@ func (in *BearerAuth) DeepCopy() *BearerAuth {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CRDRef) DeepCopyInto(out *CRDRef) {
+func (in *BlackboxDNSProbe) DeepCopyInto(out *BlackboxDNSProbe) {
 	*out = *in
+	in.BlackboxIPProtocol.DeepCopyInto(&out.BlackboxIPProtocol)
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(BlackboxTLSConfig)
+		**out = **in
+	}
+	if in.RecursionDesired != nil {
+		in, out := &in.RecursionDesired, &out.RecursionDesired
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ValidRcodes != nil {
+		in, out := &in.ValidRcodes, &out.ValidRcodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ValidateAnswerRRs != nil {
+		in, out := &in.ValidateAnswerRRs, &out.ValidateAnswerRRs
+		*out = new(BlackboxDNSRRValidator)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ValidateAuthorityRRs != nil {
+		in, out := &in.ValidateAuthorityRRs, &out.ValidateAuthorityRRs
+		*out = new(BlackboxDNSRRValidator)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ValidateAdditionalRRs != nil {
+		in, out := &in.ValidateAdditionalRRs, &out.ValidateAdditionalRRs
+		*out = new(BlackboxDNSRRValidator)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRDRef.
-func (in *CRDRef) DeepCopy() *CRDRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxDNSProbe.
+func (in *BlackboxDNSProbe) DeepCopy() *BlackboxDNSProbe {
 	if in == nil {
 		return nil
 	}
-	out := new(CRDRef)
+	out := new(BlackboxDNSProbe)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Certs) DeepCopyInto(out *Certs) {
+func (in *BlackboxDNSRRValidator) DeepCopyInto(out *BlackboxDNSRRValidator) {
 	*out = *in
-	if in.CertSecretRef != nil {
-		in, out := &in.CertSecretRef, &out.CertSecretRef
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
+	if in.FailIfMatchesRegexp != nil {
+		in, out := &in.FailIfMatchesRegexp, &out.FailIfMatchesRegexp
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.KeySecretRef != nil {
-		in, out := &in.KeySecretRef, &out.KeySecretRef
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
+	if in.FailIfAllMatchRegexp != nil {
+		in, out := &in.FailIfAllMatchRegexp, &out.FailIfAllMatchRegexp
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailIfNotMatchesRegexp != nil {
+		in, out := &in.FailIfNotMatchesRegexp, &out.FailIfNotMatchesRegexp
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailIfNoneMatchesRegexp != nil {
+		in, out := &in.FailIfNoneMatchesRegexp, &out.FailIfNoneMatchesRegexp
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Certs.
-func (in *Certs) DeepCopy() *Certs {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxDNSRRValidator.
+func (in *BlackboxDNSRRValidator) DeepCopy() *BlackboxDNSRRValidator {
 	if in == nil {
 		return nil
 	}
-	out := new(Certs)
+	out := new(BlackboxDNSRRValidator)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CommonApplicationDeploymentParams) DeepCopyInto(out *CommonApplicationDeploymentParams) {
+func (in *BlackboxGRPCProbe) DeepCopyInto(out *BlackboxGRPCProbe) {
 	*out = *in
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.RuntimeClassName != nil {
-		in, out := &in.RuntimeClassName, &out.RuntimeClassName
-		*out = new(string)
+	in.BlackboxIPProtocol.DeepCopyInto(&out.BlackboxIPProtocol)
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(BlackboxTLSConfig)
 		**out = **in
 	}
-	if in.HostAliases != nil {
-		in, out := &in.HostAliases, &out.HostAliases
-		*out = make([]v1.HostAlias, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxGRPCProbe.
+func (in *BlackboxGRPCProbe) DeepCopy() *BlackboxGRPCProbe {
+	if in == nil {
+		return nil
 	}
-	if in.HostAliasesUnderScore != nil {
-		in, out := &in.HostAliasesUnderScore, &out.HostAliasesUnderScore
-		*out = make([]v1.HostAlias, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out := new(BlackboxGRPCProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackboxHTTPProbe) DeepCopyInto(out *BlackboxHTTPProbe) {
+	*out = *in
+	in.BlackboxIPProtocol.DeepCopyInto(&out.BlackboxIPProtocol)
+	if in.ValidStatusCodes != nil {
+		in, out := &in.ValidStatusCodes, &out.ValidStatusCodes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
 	}
-	if in.DNSConfig != nil {
-		in, out := &in.DNSConfig, &out.DNSConfig
-		*out = new(v1.PodDNSConfig)
-		(*in).DeepCopyInto(*out)
+	if in.ValidHTTPVersions != nil {
+		in, out := &in.ValidHTTPVersions, &out.ValidHTTPVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.SecurityContext != nil {
-		in, out := &in.SecurityContext, &out.SecurityContext
-		*out = new(SecurityContext)
-		(*in).DeepCopyInto(*out)
+	if in.FollowRedirects != nil {
+		in, out := &in.FollowRedirects, &out.FollowRedirects
+		*out = new(bool)
+		**out = **in
 	}
-	if in.TopologySpreadConstraints != nil {
-		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
-		*out = make([]v1.TopologySpreadConstraint, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.FailIfBodyMatchesRegexp != nil {
+		in, out := &in.FailIfBodyMatchesRegexp, &out.FailIfBodyMatchesRegexp
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.ImagePullSecrets != nil {
-		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]v1.LocalObjectReference, len(*in))
+	if in.FailIfBodyNotMatchesRegexp != nil {
+		in, out := &in.FailIfBodyNotMatchesRegexp, &out.FailIfBodyNotMatchesRegexp
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.TerminationGracePeriodSeconds != nil {
-		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
-		*out = new(int64)
-		**out = **in
+	if in.FailIfHeaderMatches != nil {
+		in, out := &in.FailIfHeaderMatches, &out.FailIfHeaderMatches
+		*out = make([]BlackboxHeaderMatch, len(*in))
+		copy(*out, *in)
 	}
-	if in.ReadinessGates != nil {
-		in, out := &in.ReadinessGates, &out.ReadinessGates
-		*out = make([]v1.PodReadinessGate, len(*in))
+	if in.FailIfHeaderNotMatches != nil {
+		in, out := &in.FailIfHeaderNotMatches, &out.FailIfHeaderNotMatches
+		*out = make([]BlackboxHeaderMatch, len(*in))
 		copy(*out, *in)
 	}
-	if in.ReplicaCount != nil {
-		in, out := &in.ReplicaCount, &out.ReplicaCount
-		*out = new(int32)
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(BlackboxTLSConfig)
 		**out = **in
 	}
-	if in.RevisionHistoryLimitCount != nil {
-		in, out := &in.RevisionHistoryLimitCount, &out.RevisionHistoryLimitCount
-		*out = new(int32)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxHTTPProbe.
+func (in *BlackboxHTTPProbe) DeepCopy() *BlackboxHTTPProbe {
+	if in == nil {
+		return nil
 	}
-	if in.Containers != nil {
-		in, out := &in.Containers, &out.Containers
-		*out = make([]v1.Container, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out := new(BlackboxHTTPProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackboxHeaderMatch) DeepCopyInto(out *BlackboxHeaderMatch) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxHeaderMatch.
+func (in *BlackboxHeaderMatch) DeepCopy() *BlackboxHeaderMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackboxHeaderMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackboxICMPProbe) DeepCopyInto(out *BlackboxICMPProbe) {
+	*out = *in
+	in.BlackboxIPProtocol.DeepCopyInto(&out.BlackboxIPProtocol)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxICMPProbe.
+func (in *BlackboxICMPProbe) DeepCopy() *BlackboxICMPProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackboxICMPProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackboxIPProtocol) DeepCopyInto(out *BlackboxIPProtocol) {
+	*out = *in
+	if in.IPProtocolFallback != nil {
+		in, out := &in.IPProtocolFallback, &out.IPProtocolFallback
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxIPProtocol.
+func (in *BlackboxIPProtocol) DeepCopy() *BlackboxIPProtocol {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackboxIPProtocol)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackboxModule) DeepCopyInto(out *BlackboxModule) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(BlackboxHTTPProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TCP != nil {
+		in, out := &in.TCP, &out.TCP
+		*out = new(BlackboxTCPProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ICMP != nil {
+		in, out := &in.ICMP, &out.ICMP
+		*out = new(BlackboxICMPProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(BlackboxDNSProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = new(BlackboxGRPCProbe)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxModule.
+func (in *BlackboxModule) DeepCopy() *BlackboxModule {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackboxModule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackboxTCPProbe) DeepCopyInto(out *BlackboxTCPProbe) {
+	*out = *in
+	in.BlackboxIPProtocol.DeepCopyInto(&out.BlackboxIPProtocol)
+	if in.QueryResponse != nil {
+		in, out := &in.QueryResponse, &out.QueryResponse
+		*out = make([]BlackboxTCPQueryResponse, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(BlackboxTLSConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxTCPProbe.
+func (in *BlackboxTCPProbe) DeepCopy() *BlackboxTCPProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackboxTCPProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackboxTCPQueryResponse) DeepCopyInto(out *BlackboxTCPQueryResponse) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxTCPQueryResponse.
+func (in *BlackboxTCPQueryResponse) DeepCopy() *BlackboxTCPQueryResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackboxTCPQueryResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackboxTLSConfig) DeepCopyInto(out *BlackboxTLSConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxTLSConfig.
+func (in *BlackboxTLSConfig) DeepCopy() *BlackboxTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackboxTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CRDRef) DeepCopyInto(out *CRDRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRDRef.
+func (in *CRDRef) DeepCopy() *CRDRef {
+	if in == nil {
+		return nil
+	}
+	out := new(CRDRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Certs) DeepCopyInto(out *Certs) {
+	*out = *in
+	if in.CertSecretRef != nil {
+		in, out := &in.CertSecretRef, &out.CertSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeySecretRef != nil {
+		in, out := &in.KeySecretRef, &out.KeySecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Certs.
+func (in *Certs) DeepCopy() *Certs {
+	if in == nil {
+		return nil
+	}
+	out := new(Certs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommonApplicationDeploymentParams) DeepCopyInto(out *CommonApplicationDeploymentParams) {
+	*out = *in
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make([]v1.HostAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HostAliasesUnderScore != nil {
+		in, out := &in.HostAliasesUnderScore, &out.HostAliasesUnderScore
+		*out = make([]v1.HostAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(v1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ReadinessGates != nil {
+		in, out := &in.ReadinessGates, &out.ReadinessGates
+		*out = make([]v1.PodReadinessGate, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReplicaCount != nil {
+		in, out := &in.ReplicaCount, &out.ReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RevisionHistoryLimitCount != nil {
+		in, out := &in.RevisionHistoryLimitCount, &out.RevisionHistoryLimitCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.VPA != nil {
+		in, out := &in.VPA, &out.VPA
+		*out = new(EmbeddedVPA)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.InitContainers != nil {
 		in, out := &in.InitContainers, &out.InitContainers
@@ -996,36 +1454,76 @@ func (in *EmbeddedHPA) DeepCopy() *EmbeddedHPA {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EmbeddedIngress) DeepCopyInto(out *EmbeddedIngress) {
+func (in *EmbeddedHTTPRoute) DeepCopyInto(out *EmbeddedHTTPRoute) {
 	*out = *in
-	if in.ClassName != nil {
-		in, out := &in.ClassName, &out.ClassName
-		*out = new(string)
-		**out = **in
-	}
 	in.EmbeddedObjectMetadata.DeepCopyInto(&out.EmbeddedObjectMetadata)
-	if in.TlsHosts != nil {
-		in, out := &in.TlsHosts, &out.TlsHosts
+	if in.ParentRefs != nil {
+		in, out := &in.ParentRefs, &out.ParentRefs
+		*out = make([]GatewayParentReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PathPrefixes != nil {
+		in, out := &in.PathPrefixes, &out.PathPrefixes
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	if in.ExtraRules != nil {
 		in, out := &in.ExtraRules, &out.ExtraRules
-		*out = make([]networkingv1.IngressRule, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.ExtraTLS != nil {
-		in, out := &in.ExtraTLS, &out.ExtraTLS
-		*out = make([]networkingv1.IngressTLS, len(*in))
+		*out = make([]apiextensionsv1.JSON, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedIngress.
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedHTTPRoute.
+func (in *EmbeddedHTTPRoute) DeepCopy() *EmbeddedHTTPRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(EmbeddedHTTPRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmbeddedIngress) DeepCopyInto(out *EmbeddedIngress) {
+	*out = *in
+	if in.ClassName != nil {
+		in, out := &in.ClassName, &out.ClassName
+		*out = new(string)
+		**out = **in
+	}
+	in.EmbeddedObjectMetadata.DeepCopyInto(&out.EmbeddedObjectMetadata)
+	if in.TlsHosts != nil {
+		in, out := &in.TlsHosts, &out.TlsHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraRules != nil {
+		in, out := &in.ExtraRules, &out.ExtraRules
+		*out = make([]networkingv1.IngressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraTLS != nil {
+		in, out := &in.ExtraTLS, &out.ExtraTLS
+		*out = make([]networkingv1.IngressTLS, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedIngress.
 func (in *EmbeddedIngress) DeepCopy() *EmbeddedIngress {
 	if in == nil {
 		return nil
@@ -1035,6 +1533,53 @@ func (in *EmbeddedIngress) DeepCopy() *EmbeddedIngress {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmbeddedNetworkPolicy) DeepCopyInto(out *EmbeddedNetworkPolicy) {
+	*out = *in
+	in.EmbeddedObjectMetadata.DeepCopyInto(&out.EmbeddedObjectMetadata)
+	if in.AllowFrom != nil {
+		in, out := &in.AllowFrom, &out.AllowFrom
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VMAgentSelector != nil {
+		in, out := &in.VMAgentSelector, &out.VMAgentSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VMAgentNamespaceSelector != nil {
+		in, out := &in.VMAgentNamespaceSelector, &out.VMAgentNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraIngress != nil {
+		in, out := &in.ExtraIngress, &out.ExtraIngress
+		*out = make([]networkingv1.NetworkPolicyIngressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraEgress != nil {
+		in, out := &in.ExtraEgress, &out.ExtraEgress
+		*out = make([]networkingv1.NetworkPolicyEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedNetworkPolicy.
+func (in *EmbeddedNetworkPolicy) DeepCopy() *EmbeddedNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(EmbeddedNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EmbeddedObjectMetadata) DeepCopyInto(out *EmbeddedObjectMetadata) {
 	*out = *in
@@ -1145,6 +1690,40 @@ func (in *EmbeddedProbes) DeepCopy() *EmbeddedProbes {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmbeddedVPA) DeepCopyInto(out *EmbeddedVPA) {
+	*out = *in
+	if in.MinAllowed != nil {
+		in, out := &in.MinAllowed, &out.MinAllowed
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.MaxAllowed != nil {
+		in, out := &in.MaxAllowed, &out.MaxAllowed
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.ControlledResources != nil {
+		in, out := &in.ControlledResources, &out.ControlledResources
+		*out = make([]v1.ResourceName, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedVPA.
+func (in *EmbeddedVPA) DeepCopy() *EmbeddedVPA {
+	if in == nil {
+		return nil
+	}
+	out := new(EmbeddedVPA)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Endpoint) DeepCopyInto(out *Endpoint) {
 	*out = *in
@@ -1297,6 +1876,50 @@ func (in *EndpointScrapeParams) DeepCopy() *EndpointScrapeParams {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExpectedAlert) DeepCopyInto(out *ExpectedAlert) {
+	*out = *in
+	if in.ExpLabels != nil {
+		in, out := &in.ExpLabels, &out.ExpLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExpAnnotations != nil {
+		in, out := &in.ExpAnnotations, &out.ExpAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExpectedAlert.
+func (in *ExpectedAlert) DeepCopy() *ExpectedAlert {
+	if in == nil {
+		return nil
+	}
+	out := new(ExpectedAlert)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExpectedSample) DeepCopyInto(out *ExpectedSample) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExpectedSample.
+func (in *ExpectedSample) DeepCopy() *ExpectedSample {
+	if in == nil {
+		return nil
+	}
+	out := new(ExpectedSample)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalConfig) DeepCopyInto(out *ExternalConfig) {
 	*out = *in
@@ -1367,6 +1990,26 @@ func (in *GCESDConfig) DeepCopy() *GCESDConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayParentReference) DeepCopyInto(out *GatewayParentReference) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParentReference.
+func (in *GatewayParentReference) DeepCopy() *GatewayParentReference {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayParentReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPAuth) DeepCopyInto(out *HTTPAuth) {
 	*out = *in
@@ -1517,6 +2160,46 @@ func (in *ImageConfig) DeepCopy() *ImageConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IncidentIOConfig) DeepCopyInto(out *IncidentIOConfig) {
+	*out = *in
+	if in.SendResolved != nil {
+		in, out := &in.SendResolved, &out.SendResolved
+		*out = new(bool)
+		**out = **in
+	}
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(string)
+		**out = **in
+	}
+	if in.URLSecret != nil {
+		in, out := &in.URLSecret, &out.URLSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AlertSourceToken != nil {
+		in, out := &in.AlertSourceToken, &out.AlertSourceToken
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTPConfig != nil {
+		in, out := &in.HTTPConfig, &out.HTTPConfig
+		*out = new(HTTPConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IncidentIOConfig.
+func (in *IncidentIOConfig) DeepCopy() *IncidentIOConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IncidentIOConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InhibitRule) DeepCopyInto(out *InhibitRule) {
 	*out = *in
@@ -1562,6 +2245,43 @@ func (in *InsertPorts) DeepCopy() *InsertPorts {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JiraConfig) DeepCopyInto(out *JiraConfig) {
+	*out = *in
+	if in.SendResolved != nil {
+		in, out := &in.SendResolved, &out.SendResolved
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make(map[string]apiextensionsv1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.HTTPConfig != nil {
+		in, out := &in.HTTPConfig, &out.HTTPConfig
+		*out = new(HTTPConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JiraConfig.
+func (in *JiraConfig) DeepCopy() *JiraConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(JiraConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *K8SSelectorConfig) DeepCopyInto(out *K8SSelectorConfig) {
 	*out = *in
@@ -1718,6 +2438,61 @@ func (in *MSTeamsConfig) DeepCopy() *MSTeamsConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MSTeamsV2Config) DeepCopyInto(out *MSTeamsV2Config) {
+	*out = *in
+	if in.SendResolved != nil {
+		in, out := &in.SendResolved, &out.SendResolved
+		*out = new(bool)
+		**out = **in
+	}
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(string)
+		**out = **in
+	}
+	if in.URLSecret != nil {
+		in, out := &in.URLSecret, &out.URLSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTPConfig != nil {
+		in, out := &in.HTTPConfig, &out.HTTPConfig
+		*out = new(HTTPConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MSTeamsV2Config.
+func (in *MSTeamsV2Config) DeepCopy() *MSTeamsV2Config {
+	if in == nil {
+		return nil
+	}
+	out := new(MSTeamsV2Config)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsQLExprTest) DeepCopyInto(out *MetricsQLExprTest) {
+	*out = *in
+	if in.ExpSamples != nil {
+		in, out := &in.ExpSamples, &out.ExpSamples
+		*out = make([]ExpectedSample, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsQLExprTest.
+func (in *MetricsQLExprTest) DeepCopy() *MetricsQLExprTest {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsQLExprTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespaceDiscovery) DeepCopyInto(out *NamespaceDiscovery) {
 	*out = *in
@@ -2211,16 +2986,44 @@ func (in *Receiver) DeepCopyInto(out *Receiver) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Receiver.
-func (in *Receiver) DeepCopy() *Receiver {
-	if in == nil {
-		return nil
+	if in.JiraConfigs != nil {
+		in, out := &in.JiraConfigs, &out.JiraConfigs
+		*out = make([]JiraConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	out := new(Receiver)
-	in.DeepCopyInto(out)
-	return out
+	if in.RocketchatConfigs != nil {
+		in, out := &in.RocketchatConfigs, &out.RocketchatConfigs
+		*out = make([]RocketchatConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MSTeamsV2Configs != nil {
+		in, out := &in.MSTeamsV2Configs, &out.MSTeamsV2Configs
+		*out = make([]MSTeamsV2Config, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IncidentIOConfigs != nil {
+		in, out := &in.IncidentIOConfigs, &out.IncidentIOConfigs
+		*out = make([]IncidentIOConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Receiver.
+func (in *Receiver) DeepCopy() *Receiver {
+	if in == nil {
+		return nil
+	}
+	out := new(Receiver)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -2265,6 +3068,98 @@ func (in *RelabelConfig) DeepCopy() *RelabelConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RocketchatActionConfig) DeepCopyInto(out *RocketchatActionConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RocketchatActionConfig.
+func (in *RocketchatActionConfig) DeepCopy() *RocketchatActionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RocketchatActionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RocketchatConfig) DeepCopyInto(out *RocketchatConfig) {
+	*out = *in
+	if in.SendResolved != nil {
+		in, out := &in.SendResolved, &out.SendResolved
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Token != nil {
+		in, out := &in.Token, &out.Token
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TokenID != nil {
+		in, out := &in.TokenID, &out.TokenID
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]RocketchatFieldConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ShortFields != nil {
+		in, out := &in.ShortFields, &out.ShortFields
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LinkNames != nil {
+		in, out := &in.LinkNames, &out.LinkNames
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]RocketchatActionConfig, len(*in))
+		copy(*out, *in)
+	}
+	if in.HTTPConfig != nil {
+		in, out := &in.HTTPConfig, &out.HTTPConfig
+		*out = new(HTTPConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RocketchatConfig.
+func (in *RocketchatConfig) DeepCopy() *RocketchatConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RocketchatConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RocketchatFieldConfig) DeepCopyInto(out *RocketchatFieldConfig) {
+	*out = *in
+	if in.Short != nil {
+		in, out := &in.Short, &out.Short
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RocketchatFieldConfig.
+func (in *RocketchatFieldConfig) DeepCopy() *RocketchatFieldConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RocketchatFieldConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Route) DeepCopyInto(out *Route) {
 	*out = *in
@@ -2424,6 +3319,77 @@ func (in *RuleGroup) DeepCopy() *RuleGroup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleTestCase) DeepCopyInto(out *RuleTestCase) {
+	*out = *in
+	if in.InputSeries != nil {
+		in, out := &in.InputSeries, &out.InputSeries
+		*out = make([]RuleTestInputSeries, len(*in))
+		copy(*out, *in)
+	}
+	if in.AlertRuleTests != nil {
+		in, out := &in.AlertRuleTests, &out.AlertRuleTests
+		*out = make([]AlertRuleTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MetricsQLExprTests != nil {
+		in, out := &in.MetricsQLExprTests, &out.MetricsQLExprTests
+		*out = make([]MetricsQLExprTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExternalLabels != nil {
+		in, out := &in.ExternalLabels, &out.ExternalLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleTestCase.
+func (in *RuleTestCase) DeepCopy() *RuleTestCase {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleTestCase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleTestCaseStatus) DeepCopyInto(out *RuleTestCaseStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleTestCaseStatus.
+func (in *RuleTestCaseStatus) DeepCopy() *RuleTestCaseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleTestCaseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleTestInputSeries) DeepCopyInto(out *RuleTestInputSeries) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleTestInputSeries.
+func (in *RuleTestInputSeries) DeepCopy() *RuleTestInputSeries {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleTestInputSeries)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScrapeObjectStatus) DeepCopyInto(out *ScrapeObjectStatus) {
 	*out = *in
@@ -3357,31 +4323,31 @@ func (in *UserConfigOption) DeepCopy() *UserConfigOption {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VLogs) DeepCopyInto(out *VLogs) {
+func (in *VLCluster) DeepCopyInto(out *VLCluster) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
 	if in.ParsedLastAppliedSpec != nil {
 		in, out := &in.ParsedLastAppliedSpec, &out.ParsedLastAppliedSpec
-		*out = new(VLogsSpec)
+		*out = new(VLClusterSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLogs.
-func (in *VLogs) DeepCopy() *VLogs {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLCluster.
+func (in *VLCluster) DeepCopy() *VLCluster {
 	if in == nil {
 		return nil
 	}
-	out := new(VLogs)
+	out := new(VLCluster)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VLogs) DeepCopyObject() runtime.Object {
+func (in *VLCluster) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3389,31 +4355,31 @@ func (in *VLogs) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VLogsList) DeepCopyInto(out *VLogsList) {
+func (in *VLClusterList) DeepCopyInto(out *VLClusterList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]VLogs, len(*in))
+		*out = make([]VLCluster, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLogsList.
-func (in *VLogsList) DeepCopy() *VLogsList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLClusterList.
+func (in *VLClusterList) DeepCopy() *VLClusterList {
 	if in == nil {
 		return nil
 	}
-	out := new(VLogsList)
+	out := new(VLClusterList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VLogsList) DeepCopyObject() runtime.Object {
+func (in *VLClusterList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3421,121 +4387,1473 @@ func (in *VLogsList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VLogsSpec) DeepCopyInto(out *VLogsSpec) {
+func (in *VLClusterSpec) DeepCopyInto(out *VLClusterSpec) {
 	*out = *in
-	if in.PodMetadata != nil {
-		in, out := &in.PodMetadata, &out.PodMetadata
-		*out = new(EmbeddedObjectMetadata)
-		(*in).DeepCopyInto(*out)
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
 	}
-	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
-	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
-	if in.Storage != nil {
-		in, out := &in.Storage, &out.Storage
-		*out = new(v1.PersistentVolumeClaimSpec)
+	if in.VLInsert != nil {
+		in, out := &in.VLInsert, &out.VLInsert
+		*out = new(VLInsert)
 		(*in).DeepCopyInto(*out)
 	}
-	in.StorageMetadata.DeepCopyInto(&out.StorageMetadata)
-	if in.ServiceSpec != nil {
-		in, out := &in.ServiceSpec, &out.ServiceSpec
-		*out = new(AdditionalServiceSpec)
+	if in.VLSelect != nil {
+		in, out := &in.VLSelect, &out.VLSelect
+		*out = new(VLSelect)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ServiceScrapeSpec != nil {
-		in, out := &in.ServiceScrapeSpec, &out.ServiceScrapeSpec
-		*out = new(VMServiceScrapeSpec)
+	if in.VLStorage != nil {
+		in, out := &in.VLStorage, &out.VLStorage
+		*out = new(VLStorage)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.EmbeddedProbes != nil {
-		in, out := &in.EmbeddedProbes, &out.EmbeddedProbes
-		*out = new(EmbeddedProbes)
-		(*in).DeepCopyInto(*out)
+	if in.UseStrictSecurity != nil {
+		in, out := &in.UseStrictSecurity, &out.UseStrictSecurity
+		*out = new(bool)
+		**out = **in
 	}
+	in.RequestsLoadBalancer.DeepCopyInto(&out.RequestsLoadBalancer)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLogsSpec.
-func (in *VLogsSpec) DeepCopy() *VLogsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLClusterSpec.
+func (in *VLClusterSpec) DeepCopy() *VLClusterSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VLogsSpec)
+	out := new(VLClusterSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VLogsStatus) DeepCopyInto(out *VLogsStatus) {
+func (in *VLClusterStatus) DeepCopyInto(out *VLClusterStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLogsStatus.
-func (in *VLogsStatus) DeepCopy() *VLogsStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLClusterStatus.
+func (in *VLClusterStatus) DeepCopy() *VLClusterStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VLogsStatus)
+	out := new(VLClusterStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAgent) DeepCopyInto(out *VMAgent) {
+func (in *VLInsert) DeepCopyInto(out *VLInsert) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	if in.ParsedLastAppliedSpec != nil {
-		in, out := &in.ParsedLastAppliedSpec, &out.ParsedLastAppliedSpec
-		*out = new(VMAgentSpec)
+	if in.PodMetadata != nil {
+		in, out := &in.PodMetadata, &out.PodMetadata
+		*out = new(EmbeddedObjectMetadata)
 		(*in).DeepCopyInto(*out)
 	}
-	out.Status = in.Status
+	if in.ServiceSpec != nil {
+		in, out := &in.ServiceSpec, &out.ServiceSpec
+		*out = new(AdditionalServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceScrapeSpec != nil {
+		in, out := &in.ServiceScrapeSpec, &out.ServiceScrapeSpec
+		*out = new(VMServiceScrapeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(appsv1.DeploymentStrategyType)
+		**out = **in
+	}
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(appsv1.RollingUpdateDeployment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(EmbeddedPodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EmbeddedProbes != nil {
+		in, out := &in.EmbeddedProbes, &out.EmbeddedProbes
+		*out = new(EmbeddedProbes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HPA != nil {
+		in, out := &in.HPA, &out.HPA
+		*out = new(EmbeddedHPA)
+		(*in).DeepCopyInto(*out)
+	}
+	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
+	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgent.
-func (in *VMAgent) DeepCopy() *VMAgent {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLInsert.
+func (in *VLInsert) DeepCopy() *VLInsert {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAgent)
+	out := new(VLInsert)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMAgent) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VLSelect) DeepCopyInto(out *VLSelect) {
+	*out = *in
+	if in.PodMetadata != nil {
+		in, out := &in.PodMetadata, &out.PodMetadata
+		*out = new(EmbeddedObjectMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceSpec != nil {
+		in, out := &in.ServiceSpec, &out.ServiceSpec
+		*out = new(AdditionalServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceScrapeSpec != nil {
+		in, out := &in.ServiceScrapeSpec, &out.ServiceScrapeSpec
+		*out = new(VMServiceScrapeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(appsv1.DeploymentStrategyType)
+		**out = **in
+	}
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(appsv1.RollingUpdateDeployment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(EmbeddedPodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EmbeddedProbes != nil {
+		in, out := &in.EmbeddedProbes, &out.EmbeddedProbes
+		*out = new(EmbeddedProbes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HPA != nil {
+		in, out := &in.HPA, &out.HPA
+		*out = new(EmbeddedHPA)
+		(*in).DeepCopyInto(*out)
+	}
+	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
+	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLSelect.
+func (in *VLSelect) DeepCopy() *VLSelect {
+	if in == nil {
+		return nil
+	}
+	out := new(VLSelect)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VLStorage) DeepCopyInto(out *VLStorage) {
+	*out = *in
+	if in.PodMetadata != nil {
+		in, out := &in.PodMetadata, &out.PodMetadata
+		*out = new(EmbeddedObjectMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(StorageSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceSpec != nil {
+		in, out := &in.ServiceSpec, &out.ServiceSpec
+		*out = new(AdditionalServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceScrapeSpec != nil {
+		in, out := &in.ServiceScrapeSpec, &out.ServiceScrapeSpec
+		*out = new(VMServiceScrapeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(EmbeddedPodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EmbeddedProbes != nil {
+		in, out := &in.EmbeddedProbes, &out.EmbeddedProbes
+		*out = new(EmbeddedProbes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceInsertNodeIDs != nil {
+		in, out := &in.MaintenanceInsertNodeIDs, &out.MaintenanceInsertNodeIDs
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaintenanceSelectNodeIDs != nil {
+		in, out := &in.MaintenanceSelectNodeIDs, &out.MaintenanceSelectNodeIDs
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClaimTemplates != nil {
+		in, out := &in.ClaimTemplates, &out.ClaimTemplates
+		*out = make([]v1.PersistentVolumeClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
+	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLStorage.
+func (in *VLStorage) DeepCopy() *VLStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(VLStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VLogs) DeepCopyInto(out *VLogs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.ParsedLastAppliedSpec != nil {
+		in, out := &in.ParsedLastAppliedSpec, &out.ParsedLastAppliedSpec
+		*out = new(VLogsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLogs.
+func (in *VLogs) DeepCopy() *VLogs {
+	if in == nil {
+		return nil
+	}
+	out := new(VLogs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VLogs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VLogsList) DeepCopyInto(out *VLogsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VLogs, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLogsList.
+func (in *VLogsList) DeepCopy() *VLogsList {
+	if in == nil {
+		return nil
+	}
+	out := new(VLogsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VLogsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VLogsSpec) DeepCopyInto(out *VLogsSpec) {
+	*out = *in
+	if in.PodMetadata != nil {
+		in, out := &in.PodMetadata, &out.PodMetadata
+		*out = new(EmbeddedObjectMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
+	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(v1.PersistentVolumeClaimSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.StorageMetadata.DeepCopyInto(&out.StorageMetadata)
+	if in.ServiceSpec != nil {
+		in, out := &in.ServiceSpec, &out.ServiceSpec
+		*out = new(AdditionalServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTPRoute != nil {
+		in, out := &in.HTTPRoute, &out.HTTPRoute
+		*out = new(EmbeddedHTTPRoute)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceScrapeSpec != nil {
+		in, out := &in.ServiceScrapeSpec, &out.ServiceScrapeSpec
+		*out = new(VMServiceScrapeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EmbeddedProbes != nil {
+		in, out := &in.EmbeddedProbes, &out.EmbeddedProbes
+		*out = new(EmbeddedProbes)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLogsSpec.
+func (in *VLogsSpec) DeepCopy() *VLogsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VLogsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VLogsStatus) DeepCopyInto(out *VLogsStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLogsStatus.
+func (in *VLogsStatus) DeepCopy() *VLogsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VLogsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAgent) DeepCopyInto(out *VMAgent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.ParsedLastAppliedSpec != nil {
+		in, out := &in.ParsedLastAppliedSpec, &out.ParsedLastAppliedSpec
+		*out = new(VMAgentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgent.
+func (in *VMAgent) DeepCopy() *VMAgent {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAgent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMAgent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAgentList) DeepCopyInto(out *VMAgentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMAgent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgentList.
+func (in *VMAgentList) DeepCopy() *VMAgentList {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAgentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMAgentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAgentRemoteWriteSettings) DeepCopyInto(out *VMAgentRemoteWriteSettings) {
+	*out = *in
+	if in.MaxBlockSize != nil {
+		in, out := &in.MaxBlockSize, &out.MaxBlockSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxDiskUsagePerURL != nil {
+		in, out := &in.MaxDiskUsagePerURL, &out.MaxDiskUsagePerURL
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Queues != nil {
+		in, out := &in.Queues, &out.Queues
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ShowURL != nil {
+		in, out := &in.ShowURL, &out.ShowURL
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TmpDataPath != nil {
+		in, out := &in.TmpDataPath, &out.TmpDataPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.FlushInterval != nil {
+		in, out := &in.FlushInterval, &out.FlushInterval
+		*out = new(string)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgentRemoteWriteSettings.
+func (in *VMAgentRemoteWriteSettings) DeepCopy() *VMAgentRemoteWriteSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAgentRemoteWriteSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAgentRemoteWriteSpec) DeepCopyInto(out *VMAgentRemoteWriteSpec) {
+	*out = *in
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(BasicAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BearerTokenSecret != nil {
+		in, out := &in.BearerTokenSecret, &out.BearerTokenSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UrlRelabelConfig != nil {
+		in, out := &in.UrlRelabelConfig, &out.UrlRelabelConfig
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InlineUrlRelabelConfig != nil {
+		in, out := &in.InlineUrlRelabelConfig, &out.InlineUrlRelabelConfig
+		*out = make([]RelabelConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		*out = new(OAuth2)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SendTimeout != nil {
+		in, out := &in.SendTimeout, &out.SendTimeout
+		*out = new(string)
+		**out = **in
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StreamAggrConfig != nil {
+		in, out := &in.StreamAggrConfig, &out.StreamAggrConfig
+		*out = new(StreamAggrConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxDiskUsage != nil {
+		in, out := &in.MaxDiskUsage, &out.MaxDiskUsage
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgentRemoteWriteSpec.
+func (in *VMAgentRemoteWriteSpec) DeepCopy() *VMAgentRemoteWriteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAgentRemoteWriteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAgentSecurityEnforcements) DeepCopyInto(out *VMAgentSecurityEnforcements) {
+	*out = *in
+	out.ArbitraryFSAccessThroughSMs = in.ArbitraryFSAccessThroughSMs
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgentSecurityEnforcements.
+func (in *VMAgentSecurityEnforcements) DeepCopy() *VMAgentSecurityEnforcements {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAgentSecurityEnforcements)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAgentShardAutoscaling) DeepCopyInto(out *VMAgentShardAutoscaling) {
+	*out = *in
+	if in.ScaleDownThresholdPercent != nil {
+		in, out := &in.ScaleDownThresholdPercent, &out.ScaleDownThresholdPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgentShardAutoscaling.
+func (in *VMAgentShardAutoscaling) DeepCopy() *VMAgentShardAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAgentShardAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAgentShardAutoscalingStatus) DeepCopyInto(out *VMAgentShardAutoscalingStatus) {
+	*out = *in
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgentShardAutoscalingStatus.
+func (in *VMAgentShardAutoscalingStatus) DeepCopy() *VMAgentShardAutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAgentShardAutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAgentSpec) DeepCopyInto(out *VMAgentSpec) {
+	*out = *in
+	if in.PodMetadata != nil {
+		in, out := &in.PodMetadata, &out.PodMetadata
+		*out = new(EmbeddedObjectMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIServerConfig != nil {
+		in, out := &in.APIServerConfig, &out.APIServerConfig
+		*out = new(APIServerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VMAgentExternalLabelName != nil {
+		in, out := &in.VMAgentExternalLabelName, &out.VMAgentExternalLabelName
+		*out = new(string)
+		**out = **in
+	}
+	if in.ExternalLabels != nil {
+		in, out := &in.ExternalLabels, &out.ExternalLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RemoteWrite != nil {
+		in, out := &in.RemoteWrite, &out.RemoteWrite
+		*out = make([]VMAgentRemoteWriteSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RemoteWriteSettings != nil {
+		in, out := &in.RemoteWriteSettings, &out.RemoteWriteSettings
+		*out = new(VMAgentRemoteWriteSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RelabelConfig != nil {
+		in, out := &in.RelabelConfig, &out.RelabelConfig
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InlineRelabelConfig != nil {
+		in, out := &in.InlineRelabelConfig, &out.InlineRelabelConfig
+		*out = make([]RelabelConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StreamAggrConfig != nil {
+		in, out := &in.StreamAggrConfig, &out.StreamAggrConfig
+		*out = new(StreamAggrConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceScrapeSelector != nil {
+		in, out := &in.ServiceScrapeSelector, &out.ServiceScrapeSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceScrapeNamespaceSelector != nil {
+		in, out := &in.ServiceScrapeNamespaceSelector, &out.ServiceScrapeNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodScrapeSelector != nil {
+		in, out := &in.PodScrapeSelector, &out.PodScrapeSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodScrapeNamespaceSelector != nil {
+		in, out := &in.PodScrapeNamespaceSelector, &out.PodScrapeNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProbeSelector != nil {
+		in, out := &in.ProbeSelector, &out.ProbeSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProbeNamespaceSelector != nil {
+		in, out := &in.ProbeNamespaceSelector, &out.ProbeNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeScrapeSelector != nil {
+		in, out := &in.NodeScrapeSelector, &out.NodeScrapeSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeScrapeNamespaceSelector != nil {
+		in, out := &in.NodeScrapeNamespaceSelector, &out.NodeScrapeNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StaticScrapeSelector != nil {
+		in, out := &in.StaticScrapeSelector, &out.StaticScrapeSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StaticScrapeNamespaceSelector != nil {
+		in, out := &in.StaticScrapeNamespaceSelector, &out.StaticScrapeNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScrapeConfigSelector != nil {
+		in, out := &in.ScrapeConfigSelector, &out.ScrapeConfigSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScrapeConfigNamespaceSelector != nil {
+		in, out := &in.ScrapeConfigNamespaceSelector, &out.ScrapeConfigNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalScrapeConfigs != nil {
+		in, out := &in.AdditionalScrapeConfigs, &out.AdditionalScrapeConfigs
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InsertPorts != nil {
+		in, out := &in.InsertPorts, &out.InsertPorts
+		*out = new(InsertPorts)
+		**out = **in
+	}
+	if in.ServiceSpec != nil {
+		in, out := &in.ServiceSpec, &out.ServiceSpec
+		*out = new(AdditionalServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceScrapeSpec != nil {
+		in, out := &in.ServiceScrapeSpec, &out.ServiceScrapeSpec
+		*out = new(VMServiceScrapeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ShardCount != nil {
+		in, out := &in.ShardCount, &out.ShardCount
+		*out = new(int)
+		**out = **in
+	}
+	if in.ShardAutoscaling != nil {
+		in, out := &in.ShardAutoscaling, &out.ShardAutoscaling
+		*out = new(VMAgentShardAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(appsv1.DeploymentStrategyType)
+		**out = **in
+	}
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(appsv1.RollingUpdateDeployment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(EmbeddedPodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EmbeddedProbes != nil {
+		in, out := &in.EmbeddedProbes, &out.EmbeddedProbes
+		*out = new(EmbeddedProbes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(EmbeddedNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceScrapeRelabelTemplate != nil {
+		in, out := &in.ServiceScrapeRelabelTemplate, &out.ServiceScrapeRelabelTemplate
+		*out = make([]*RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.PodScrapeRelabelTemplate != nil {
+		in, out := &in.PodScrapeRelabelTemplate, &out.PodScrapeRelabelTemplate
+		*out = make([]*RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.NodeScrapeRelabelTemplate != nil {
+		in, out := &in.NodeScrapeRelabelTemplate, &out.NodeScrapeRelabelTemplate
+		*out = make([]*RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.StaticScrapeRelabelTemplate != nil {
+		in, out := &in.StaticScrapeRelabelTemplate, &out.StaticScrapeRelabelTemplate
+		*out = make([]*RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.ProbeScrapeRelabelTemplate != nil {
+		in, out := &in.ProbeScrapeRelabelTemplate, &out.ProbeScrapeRelabelTemplate
+		*out = make([]*RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.ScrapeConfigRelabelTemplate != nil {
+		in, out := &in.ScrapeConfigRelabelTemplate, &out.ScrapeConfigRelabelTemplate
+		*out = make([]*RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.MinScrapeInterval != nil {
+		in, out := &in.MinScrapeInterval, &out.MinScrapeInterval
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxScrapeInterval != nil {
+		in, out := &in.MaxScrapeInterval, &out.MaxScrapeInterval
+		*out = new(string)
+		**out = **in
+	}
+	if in.StatefulStorage != nil {
+		in, out := &in.StatefulStorage, &out.StatefulStorage
+		*out = new(StorageSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClaimTemplates != nil {
+		in, out := &in.ClaimTemplates, &out.ClaimTemplates
+		*out = make([]v1.PersistentVolumeClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.License != nil {
+		in, out := &in.License, &out.License
+		*out = new(License)
+		(*in).DeepCopyInto(*out)
+	}
+	out.VMAgentSecurityEnforcements = in.VMAgentSecurityEnforcements
+	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
+	in.CommonConfigReloaderParams.DeepCopyInto(&out.CommonConfigReloaderParams)
+	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgentSpec.
+func (in *VMAgentSpec) DeepCopy() *VMAgentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAgentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAgentStatus) DeepCopyInto(out *VMAgentStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ShardAutoscaling != nil {
+		in, out := &in.ShardAutoscaling, &out.ShardAutoscaling
+		*out = new(VMAgentShardAutoscalingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgentStatus.
+func (in *VMAgentStatus) DeepCopy() *VMAgentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAgentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlert) DeepCopyInto(out *VMAlert) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.ParsedLastAppliedSpec != nil {
+		in, out := &in.ParsedLastAppliedSpec, &out.ParsedLastAppliedSpec
+		*out = new(VMAlertSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlert.
+func (in *VMAlert) DeepCopy() *VMAlert {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlert)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMAlert) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertDatasourceSpec) DeepCopyInto(out *VMAlertDatasourceSpec) {
+	*out = *in
+	in.HTTPAuth.DeepCopyInto(&out.HTTPAuth)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertDatasourceSpec.
+func (in *VMAlertDatasourceSpec) DeepCopy() *VMAlertDatasourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertDatasourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertList) DeepCopyInto(out *VMAlertList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMAlert, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertList.
+func (in *VMAlertList) DeepCopy() *VMAlertList {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMAlertList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertNotifierSpec) DeepCopyInto(out *VMAlertNotifierSpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(DiscoverySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.HTTPAuth.DeepCopyInto(&out.HTTPAuth)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertNotifierSpec.
+func (in *VMAlertNotifierSpec) DeepCopy() *VMAlertNotifierSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertNotifierSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertRemoteReadSpec) DeepCopyInto(out *VMAlertRemoteReadSpec) {
+	*out = *in
+	if in.Lookback != nil {
+		in, out := &in.Lookback, &out.Lookback
+		*out = new(string)
+		**out = **in
+	}
+	in.HTTPAuth.DeepCopyInto(&out.HTTPAuth)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertRemoteReadSpec.
+func (in *VMAlertRemoteReadSpec) DeepCopy() *VMAlertRemoteReadSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertRemoteReadSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertRemoteWriteSpec) DeepCopyInto(out *VMAlertRemoteWriteSpec) {
+	*out = *in
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FlushInterval != nil {
+		in, out := &in.FlushInterval, &out.FlushInterval
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxBatchSize != nil {
+		in, out := &in.MaxBatchSize, &out.MaxBatchSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxQueueSize != nil {
+		in, out := &in.MaxQueueSize, &out.MaxQueueSize
+		*out = new(int32)
+		**out = **in
+	}
+	in.HTTPAuth.DeepCopyInto(&out.HTTPAuth)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertRemoteWriteSpec.
+func (in *VMAlertRemoteWriteSpec) DeepCopy() *VMAlertRemoteWriteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertRemoteWriteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertShardStatus) DeepCopyInto(out *VMAlertShardStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertShardStatus.
+func (in *VMAlertShardStatus) DeepCopy() *VMAlertShardStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertShardStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertSpec) DeepCopyInto(out *VMAlertSpec) {
+	*out = *in
+	if in.PodMetadata != nil {
+		in, out := &in.PodMetadata, &out.PodMetadata
+		*out = new(EmbeddedObjectMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RuleSelector != nil {
+		in, out := &in.RuleSelector, &out.RuleSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RuleNamespaceSelector != nil {
+		in, out := &in.RuleNamespaceSelector, &out.RuleNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ShardCount != nil {
+		in, out := &in.ShardCount, &out.ShardCount
+		*out = new(int)
+		**out = **in
+	}
+	if in.Notifier != nil {
+		in, out := &in.Notifier, &out.Notifier
+		*out = new(VMAlertNotifierSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notifiers != nil {
+		in, out := &in.Notifiers, &out.Notifiers
+		*out = make([]VMAlertNotifierSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NotifierConfigRef != nil {
+		in, out := &in.NotifierConfigRef, &out.NotifierConfigRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemoteWrite != nil {
+		in, out := &in.RemoteWrite, &out.RemoteWrite
+		*out = new(VMAlertRemoteWriteSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemoteRead != nil {
+		in, out := &in.RemoteRead, &out.RemoteRead
+		*out = new(VMAlertRemoteReadSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RulePath != nil {
+		in, out := &in.RulePath, &out.RulePath
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Datasource.DeepCopyInto(&out.Datasource)
+	if in.ExternalLabels != nil {
+		in, out := &in.ExternalLabels, &out.ExternalLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ServiceSpec != nil {
+		in, out := &in.ServiceSpec, &out.ServiceSpec
+		*out = new(AdditionalServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceScrapeSpec != nil {
+		in, out := &in.ServiceScrapeSpec, &out.ServiceScrapeSpec
+		*out = new(VMServiceScrapeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(appsv1.DeploymentStrategyType)
+		**out = **in
+	}
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(appsv1.RollingUpdateDeployment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(EmbeddedPodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EmbeddedProbes != nil {
+		in, out := &in.EmbeddedProbes, &out.EmbeddedProbes
+		*out = new(EmbeddedProbes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(EmbeddedNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.License != nil {
+		in, out := &in.License, &out.License
+		*out = new(License)
+		(*in).DeepCopyInto(*out)
+	}
+	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
+	in.CommonConfigReloaderParams.DeepCopyInto(&out.CommonConfigReloaderParams)
+	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertSpec.
+func (in *VMAlertSpec) DeepCopy() *VMAlertSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertStatus) DeepCopyInto(out *VMAlertStatus) {
+	*out = *in
+	if in.Shards != nil {
+		in, out := &in.Shards, &out.Shards
+		*out = make([]VMAlertShardStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertStatus.
+func (in *VMAlertStatus) DeepCopy() *VMAlertStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanager) DeepCopyInto(out *VMAlertmanager) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.ParsedLastAppliedSpec != nil {
+		in, out := &in.ParsedLastAppliedSpec, &out.ParsedLastAppliedSpec
+		*out = new(VMAlertmanagerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanager.
+func (in *VMAlertmanager) DeepCopy() *VMAlertmanager {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanager)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMAlertmanager) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerConfig) DeepCopyInto(out *VMAlertmanagerConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerConfig.
+func (in *VMAlertmanagerConfig) DeepCopy() *VMAlertmanagerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMAlertmanagerConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerConfigList) DeepCopyInto(out *VMAlertmanagerConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMAlertmanagerConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerConfigList.
+func (in *VMAlertmanagerConfigList) DeepCopy() *VMAlertmanagerConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMAlertmanagerConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerConfigSpec) DeepCopyInto(out *VMAlertmanagerConfigSpec) {
+	*out = *in
+	if in.Route != nil {
+		in, out := &in.Route, &out.Route
+		*out = new(Route)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Receivers != nil {
+		in, out := &in.Receivers, &out.Receivers
+		*out = make([]Receiver, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InhibitRules != nil {
+		in, out := &in.InhibitRules, &out.InhibitRules
+		*out = make([]InhibitRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TimeIntervals != nil {
+		in, out := &in.TimeIntervals, &out.TimeIntervals
+		*out = make([]TimeIntervals, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerConfigSpec.
+func (in *VMAlertmanagerConfigSpec) DeepCopy() *VMAlertmanagerConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerConfigStatus) DeepCopyInto(out *VMAlertmanagerConfigStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerConfigStatus.
+func (in *VMAlertmanagerConfigStatus) DeepCopy() *VMAlertmanagerConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerList) DeepCopyInto(out *VMAlertmanagerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMAlertmanager, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerList.
+func (in *VMAlertmanagerList) DeepCopy() *VMAlertmanagerList {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMAlertmanagerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerSilence) DeepCopyInto(out *VMAlertmanagerSilence) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerSilence.
+func (in *VMAlertmanagerSilence) DeepCopy() *VMAlertmanagerSilence {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerSilence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMAlertmanagerSilence) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAgentList) DeepCopyInto(out *VMAgentList) {
+func (in *VMAlertmanagerSilenceList) DeepCopyInto(out *VMAlertmanagerSilenceList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]VMAgent, len(*in))
+		*out = make([]VMAlertmanagerSilence, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgentList.
-func (in *VMAgentList) DeepCopy() *VMAgentList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerSilenceList.
+func (in *VMAlertmanagerSilenceList) DeepCopy() *VMAlertmanagerSilenceList {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAgentList)
+	out := new(VMAlertmanagerSilenceList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMAgentList) DeepCopyObject() runtime.Object {
+func (in *VMAlertmanagerSilenceList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3543,264 +5861,363 @@ func (in *VMAgentList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAgentRemoteWriteSettings) DeepCopyInto(out *VMAgentRemoteWriteSettings) {
+func (in *VMAlertmanagerSilenceReplicaStatus) DeepCopyInto(out *VMAlertmanagerSilenceReplicaStatus) {
 	*out = *in
-	if in.MaxBlockSize != nil {
-		in, out := &in.MaxBlockSize, &out.MaxBlockSize
-		*out = new(int32)
-		**out = **in
-	}
-	if in.MaxDiskUsagePerURL != nil {
-		in, out := &in.MaxDiskUsagePerURL, &out.MaxDiskUsagePerURL
-		*out = new(int64)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerSilenceReplicaStatus.
+func (in *VMAlertmanagerSilenceReplicaStatus) DeepCopy() *VMAlertmanagerSilenceReplicaStatus {
+	if in == nil {
+		return nil
 	}
-	if in.Queues != nil {
-		in, out := &in.Queues, &out.Queues
-		*out = new(int32)
-		**out = **in
+	out := new(VMAlertmanagerSilenceReplicaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerSilenceSpec) DeepCopyInto(out *VMAlertmanagerSilenceSpec) {
+	*out = *in
+	if in.Matchers != nil {
+		in, out := &in.Matchers, &out.Matchers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.ShowURL != nil {
-		in, out := &in.ShowURL, &out.ShowURL
-		*out = new(bool)
-		**out = **in
+	if in.StartsAt != nil {
+		in, out := &in.StartsAt, &out.StartsAt
+		*out = (*in).DeepCopy()
 	}
-	if in.TmpDataPath != nil {
-		in, out := &in.TmpDataPath, &out.TmpDataPath
-		*out = new(string)
-		**out = **in
+	in.EndsAt.DeepCopyInto(&out.EndsAt)
+	if in.AlertmanagerSelector != nil {
+		in, out := &in.AlertmanagerSelector, &out.AlertmanagerSelector
+		*out = new(DiscoverySelector)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.FlushInterval != nil {
-		in, out := &in.FlushInterval, &out.FlushInterval
-		*out = new(string)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerSilenceSpec.
+func (in *VMAlertmanagerSilenceSpec) DeepCopy() *VMAlertmanagerSilenceSpec {
+	if in == nil {
+		return nil
 	}
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	out := new(VMAlertmanagerSilenceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerSilenceStatus) DeepCopyInto(out *VMAlertmanagerSilenceStatus) {
+	*out = *in
+	if in.Silences != nil {
+		in, out := &in.Silences, &out.Silences
+		*out = make([]VMAlertmanagerSilenceReplicaStatus, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgentRemoteWriteSettings.
-func (in *VMAgentRemoteWriteSettings) DeepCopy() *VMAgentRemoteWriteSettings {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerSilenceStatus.
+func (in *VMAlertmanagerSilenceStatus) DeepCopy() *VMAlertmanagerSilenceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAgentRemoteWriteSettings)
+	out := new(VMAlertmanagerSilenceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAgentRemoteWriteSpec) DeepCopyInto(out *VMAgentRemoteWriteSpec) {
+func (in *VMAlertmanagerSpec) DeepCopyInto(out *VMAlertmanagerSpec) {
 	*out = *in
-	if in.BasicAuth != nil {
-		in, out := &in.BasicAuth, &out.BasicAuth
-		*out = new(BasicAuth)
+	if in.PodMetadata != nil {
+		in, out := &in.PodMetadata, &out.PodMetadata
+		*out = new(EmbeddedObjectMetadata)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.BearerTokenSecret != nil {
-		in, out := &in.BearerTokenSecret, &out.BearerTokenSecret
-		*out = new(v1.SecretKeySelector)
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make([]ConfigMapKeyReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(StorageSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.UrlRelabelConfig != nil {
-		in, out := &in.UrlRelabelConfig, &out.UrlRelabelConfig
-		*out = new(v1.ConfigMapKeySelector)
+	if in.AdditionalPeers != nil {
+		in, out := &in.AdditionalPeers, &out.AdditionalPeers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceSpec != nil {
+		in, out := &in.ServiceSpec, &out.ServiceSpec
+		*out = new(AdditionalServiceSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.InlineUrlRelabelConfig != nil {
-		in, out := &in.InlineUrlRelabelConfig, &out.InlineUrlRelabelConfig
-		*out = make([]RelabelConfig, len(*in))
+	if in.HTTPRoute != nil {
+		in, out := &in.HTTPRoute, &out.HTTPRoute
+		*out = new(EmbeddedHTTPRoute)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceScrapeSpec != nil {
+		in, out := &in.ServiceScrapeSpec, &out.ServiceScrapeSpec
+		*out = new(VMServiceScrapeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(EmbeddedPodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EmbeddedProbes != nil {
+		in, out := &in.EmbeddedProbes, &out.EmbeddedProbes
+		*out = new(EmbeddedProbes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(EmbeddedNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigSelector != nil {
+		in, out := &in.ConfigSelector, &out.ConfigSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigNamespaceSelector != nil {
+		in, out := &in.ConfigNamespaceSelector, &out.ConfigNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnforcedTopRouteMatchers != nil {
+		in, out := &in.EnforcedTopRouteMatchers, &out.EnforcedTopRouteMatchers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClaimTemplates != nil {
+		in, out := &in.ClaimTemplates, &out.ClaimTemplates
+		*out = make([]v1.PersistentVolumeClaim, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.OAuth2 != nil {
-		in, out := &in.OAuth2, &out.OAuth2
-		*out = new(OAuth2)
+	if in.WebConfig != nil {
+		in, out := &in.WebConfig, &out.WebConfig
+		*out = new(AlertmanagerWebConfig)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.TLSConfig != nil {
-		in, out := &in.TLSConfig, &out.TLSConfig
-		*out = new(TLSConfig)
+	if in.GossipConfig != nil {
+		in, out := &in.GossipConfig, &out.GossipConfig
+		*out = new(AlertmanagerGossipConfig)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.SendTimeout != nil {
-		in, out := &in.SendTimeout, &out.SendTimeout
-		*out = new(string)
+	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
+	in.CommonConfigReloaderParams.DeepCopyInto(&out.CommonConfigReloaderParams)
+	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerSpec.
+func (in *VMAlertmanagerSpec) DeepCopy() *VMAlertmanagerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerStatus) DeepCopyInto(out *VMAlertmanagerStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerStatus.
+func (in *VMAlertmanagerStatus) DeepCopy() *VMAlertmanagerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAnomaly) DeepCopyInto(out *VMAnomaly) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.ParsedLastAppliedSpec != nil {
+		in, out := &in.ParsedLastAppliedSpec, &out.ParsedLastAppliedSpec
+		*out = new(VMAnomalySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAnomaly.
+func (in *VMAnomaly) DeepCopy() *VMAnomaly {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAnomaly)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMAnomaly) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAnomalyDatasource) DeepCopyInto(out *VMAnomalyDatasource) {
+	*out = *in
+	if in.DatasourceRef != nil {
+		in, out := &in.DatasourceRef, &out.DatasourceRef
+		*out = new(CRDRef)
 		**out = **in
 	}
-	if in.Headers != nil {
-		in, out := &in.Headers, &out.Headers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(BasicAuth)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.StreamAggrConfig != nil {
-		in, out := &in.StreamAggrConfig, &out.StreamAggrConfig
-		*out = new(StreamAggrConfig)
+	if in.BearerAuth != nil {
+		in, out := &in.BearerAuth, &out.BearerAuth
+		*out = new(BearerAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(TLSConfig)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.MaxDiskUsage != nil {
-		in, out := &in.MaxDiskUsage, &out.MaxDiskUsage
-		*out = new(string)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAnomalyDatasource.
+func (in *VMAnomalyDatasource) DeepCopy() *VMAnomalyDatasource {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAnomalyDatasource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAnomalyList) DeepCopyInto(out *VMAnomalyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMAnomaly, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAnomalyList.
+func (in *VMAnomalyList) DeepCopy() *VMAnomalyList {
+	if in == nil {
+		return nil
 	}
+	out := new(VMAnomalyList)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgentRemoteWriteSpec.
-func (in *VMAgentRemoteWriteSpec) DeepCopy() *VMAgentRemoteWriteSpec {
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMAnomalyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAnomalyQuerySpec) DeepCopyInto(out *VMAnomalyQuerySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAnomalyQuerySpec.
+func (in *VMAnomalyQuerySpec) DeepCopy() *VMAnomalyQuerySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAgentRemoteWriteSpec)
+	out := new(VMAnomalyQuerySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAgentSecurityEnforcements) DeepCopyInto(out *VMAgentSecurityEnforcements) {
+func (in *VMAnomalyReaderSpec) DeepCopyInto(out *VMAnomalyReaderSpec) {
 	*out = *in
-	out.ArbitraryFSAccessThroughSMs = in.ArbitraryFSAccessThroughSMs
+	in.VMAnomalyDatasource.DeepCopyInto(&out.VMAnomalyDatasource)
+	if in.Queries != nil {
+		in, out := &in.Queries, &out.Queries
+		*out = make(map[string]VMAnomalyQuerySpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgentSecurityEnforcements.
-func (in *VMAgentSecurityEnforcements) DeepCopy() *VMAgentSecurityEnforcements {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAnomalyReaderSpec.
+func (in *VMAnomalyReaderSpec) DeepCopy() *VMAnomalyReaderSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAgentSecurityEnforcements)
+	out := new(VMAnomalyReaderSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAgentSpec) DeepCopyInto(out *VMAgentSpec) {
+func (in *VMAnomalySpec) DeepCopyInto(out *VMAnomalySpec) {
 	*out = *in
 	if in.PodMetadata != nil {
 		in, out := &in.PodMetadata, &out.PodMetadata
 		*out = new(EmbeddedObjectMetadata)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.APIServerConfig != nil {
-		in, out := &in.APIServerConfig, &out.APIServerConfig
-		*out = new(APIServerConfig)
+	if in.License != nil {
+		in, out := &in.License, &out.License
+		*out = new(License)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.VMAgentExternalLabelName != nil {
-		in, out := &in.VMAgentExternalLabelName, &out.VMAgentExternalLabelName
-		*out = new(string)
+	if in.ShardCount != nil {
+		in, out := &in.ShardCount, &out.ShardCount
+		*out = new(int)
 		**out = **in
 	}
-	if in.ExternalLabels != nil {
-		in, out := &in.ExternalLabels, &out.ExternalLabels
-		*out = make(map[string]string, len(*in))
+	in.Reader.DeepCopyInto(&out.Reader)
+	in.Writer.DeepCopyInto(&out.Writer)
+	if in.Models != nil {
+		in, out := &in.Models, &out.Models
+		*out = make(map[string]apiextensionsv1.JSON, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.RemoteWrite != nil {
-		in, out := &in.RemoteWrite, &out.RemoteWrite
-		*out = make([]VMAgentRemoteWriteSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
-	if in.RemoteWriteSettings != nil {
-		in, out := &in.RemoteWriteSettings, &out.RemoteWriteSettings
-		*out = new(VMAgentRemoteWriteSettings)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.RelabelConfig != nil {
-		in, out := &in.RelabelConfig, &out.RelabelConfig
-		*out = new(v1.ConfigMapKeySelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.InlineRelabelConfig != nil {
-		in, out := &in.InlineRelabelConfig, &out.InlineRelabelConfig
-		*out = make([]RelabelConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Schedulers != nil {
+		in, out := &in.Schedulers, &out.Schedulers
+		*out = make(map[string]apiextensionsv1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
-	if in.StreamAggrConfig != nil {
-		in, out := &in.StreamAggrConfig, &out.StreamAggrConfig
-		*out = new(StreamAggrConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ServiceScrapeSelector != nil {
-		in, out := &in.ServiceScrapeSelector, &out.ServiceScrapeSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ServiceScrapeNamespaceSelector != nil {
-		in, out := &in.ServiceScrapeNamespaceSelector, &out.ServiceScrapeNamespaceSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.PodScrapeSelector != nil {
-		in, out := &in.PodScrapeSelector, &out.PodScrapeSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.PodScrapeNamespaceSelector != nil {
-		in, out := &in.PodScrapeNamespaceSelector, &out.PodScrapeNamespaceSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ProbeSelector != nil {
-		in, out := &in.ProbeSelector, &out.ProbeSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ProbeNamespaceSelector != nil {
-		in, out := &in.ProbeNamespaceSelector, &out.ProbeNamespaceSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.NodeScrapeSelector != nil {
-		in, out := &in.NodeScrapeSelector, &out.NodeScrapeSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.NodeScrapeNamespaceSelector != nil {
-		in, out := &in.NodeScrapeNamespaceSelector, &out.NodeScrapeNamespaceSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.StaticScrapeSelector != nil {
-		in, out := &in.StaticScrapeSelector, &out.StaticScrapeSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.StaticScrapeNamespaceSelector != nil {
-		in, out := &in.StaticScrapeNamespaceSelector, &out.StaticScrapeNamespaceSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ScrapeConfigSelector != nil {
-		in, out := &in.ScrapeConfigSelector, &out.ScrapeConfigSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ScrapeConfigNamespaceSelector != nil {
-		in, out := &in.ScrapeConfigNamespaceSelector, &out.ScrapeConfigNamespaceSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.AdditionalScrapeConfigs != nil {
-		in, out := &in.AdditionalScrapeConfigs, &out.AdditionalScrapeConfigs
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.InsertPorts != nil {
-		in, out := &in.InsertPorts, &out.InsertPorts
-		*out = new(InsertPorts)
-		**out = **in
-	}
 	if in.ServiceSpec != nil {
 		in, out := &in.ServiceSpec, &out.ServiceSpec
 		*out = new(AdditionalServiceSpec)
@@ -3811,11 +6228,6 @@ func (in *VMAgentSpec) DeepCopyInto(out *VMAgentSpec) {
 		*out = new(VMServiceScrapeSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ShardCount != nil {
-		in, out := &in.ShardCount, &out.ShardCount
-		*out = new(int)
-		**out = **in
-	}
 	if in.UpdateStrategy != nil {
 		in, out := &in.UpdateStrategy, &out.UpdateStrategy
 		*out = new(appsv1.DeploymentStrategyType)
@@ -3836,156 +6248,91 @@ func (in *VMAgentSpec) DeepCopyInto(out *VMAgentSpec) {
 		*out = new(EmbeddedProbes)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ServiceScrapeRelabelTemplate != nil {
-		in, out := &in.ServiceScrapeRelabelTemplate, &out.ServiceScrapeRelabelTemplate
-		*out = make([]*RelabelConfig, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(RelabelConfig)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
-	if in.PodScrapeRelabelTemplate != nil {
-		in, out := &in.PodScrapeRelabelTemplate, &out.PodScrapeRelabelTemplate
-		*out = make([]*RelabelConfig, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(RelabelConfig)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
-	if in.NodeScrapeRelabelTemplate != nil {
-		in, out := &in.NodeScrapeRelabelTemplate, &out.NodeScrapeRelabelTemplate
-		*out = make([]*RelabelConfig, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(RelabelConfig)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
-	if in.StaticScrapeRelabelTemplate != nil {
-		in, out := &in.StaticScrapeRelabelTemplate, &out.StaticScrapeRelabelTemplate
-		*out = make([]*RelabelConfig, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(RelabelConfig)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
-	if in.ProbeScrapeRelabelTemplate != nil {
-		in, out := &in.ProbeScrapeRelabelTemplate, &out.ProbeScrapeRelabelTemplate
-		*out = make([]*RelabelConfig, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(RelabelConfig)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
-	if in.ScrapeConfigRelabelTemplate != nil {
-		in, out := &in.ScrapeConfigRelabelTemplate, &out.ScrapeConfigRelabelTemplate
-		*out = make([]*RelabelConfig, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(RelabelConfig)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
-	if in.MinScrapeInterval != nil {
-		in, out := &in.MinScrapeInterval, &out.MinScrapeInterval
-		*out = new(string)
-		**out = **in
-	}
-	if in.MaxScrapeInterval != nil {
-		in, out := &in.MaxScrapeInterval, &out.MaxScrapeInterval
-		*out = new(string)
-		**out = **in
-	}
-	if in.StatefulStorage != nil {
-		in, out := &in.StatefulStorage, &out.StatefulStorage
-		*out = new(StorageSpec)
-		(*in).DeepCopyInto(*out)
+	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
+	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAnomalySpec.
+func (in *VMAnomalySpec) DeepCopy() *VMAnomalySpec {
+	if in == nil {
+		return nil
 	}
-	if in.ClaimTemplates != nil {
-		in, out := &in.ClaimTemplates, &out.ClaimTemplates
-		*out = make([]v1.PersistentVolumeClaim, len(*in))
+	out := new(VMAnomalySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAnomalyStatus) DeepCopyInto(out *VMAnomalyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.License != nil {
-		in, out := &in.License, &out.License
-		*out = new(License)
-		(*in).DeepCopyInto(*out)
+		}
 	}
-	out.VMAgentSecurityEnforcements = in.VMAgentSecurityEnforcements
-	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
-	in.CommonConfigReloaderParams.DeepCopyInto(&out.CommonConfigReloaderParams)
-	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgentSpec.
-func (in *VMAgentSpec) DeepCopy() *VMAgentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAnomalyStatus.
+func (in *VMAnomalyStatus) DeepCopy() *VMAnomalyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAgentSpec)
+	out := new(VMAnomalyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAgentStatus) DeepCopyInto(out *VMAgentStatus) {
+func (in *VMAnomalyWriterSpec) DeepCopyInto(out *VMAnomalyWriterSpec) {
 	*out = *in
+	in.VMAnomalyDatasource.DeepCopyInto(&out.VMAnomalyDatasource)
+	if in.MetricFormat != nil {
+		in, out := &in.MetricFormat, &out.MetricFormat
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgentStatus.
-func (in *VMAgentStatus) DeepCopy() *VMAgentStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAnomalyWriterSpec.
+func (in *VMAnomalyWriterSpec) DeepCopy() *VMAnomalyWriterSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAgentStatus)
+	out := new(VMAnomalyWriterSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAlert) DeepCopyInto(out *VMAlert) {
+func (in *VMAuth) DeepCopyInto(out *VMAuth) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
 	if in.ParsedLastAppliedSpec != nil {
 		in, out := &in.ParsedLastAppliedSpec, &out.ParsedLastAppliedSpec
-		*out = new(VMAlertSpec)
+		*out = new(VMAuthSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlert.
-func (in *VMAlert) DeepCopy() *VMAlert {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAuth.
+func (in *VMAuth) DeepCopy() *VMAuth {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAlert)
+	out := new(VMAuth)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMAlert) DeepCopyObject() runtime.Object {
+func (in *VMAuth) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3993,47 +6340,31 @@ func (in *VMAlert) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAlertDatasourceSpec) DeepCopyInto(out *VMAlertDatasourceSpec) {
-	*out = *in
-	in.HTTPAuth.DeepCopyInto(&out.HTTPAuth)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertDatasourceSpec.
-func (in *VMAlertDatasourceSpec) DeepCopy() *VMAlertDatasourceSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(VMAlertDatasourceSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAlertList) DeepCopyInto(out *VMAlertList) {
+func (in *VMAuthList) DeepCopyInto(out *VMAuthList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]VMAlert, len(*in))
+		*out = make([]VMAuth, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertList.
-func (in *VMAlertList) DeepCopy() *VMAlertList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAuthList.
+func (in *VMAuthList) DeepCopy() *VMAuthList {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAlertList)
+	out := new(VMAuthList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMAlertList) DeepCopyObject() runtime.Object {
+func (in *VMAuthList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -4041,141 +6372,81 @@ func (in *VMAlertList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAlertNotifierSpec) DeepCopyInto(out *VMAlertNotifierSpec) {
+func (in *VMAuthLoadBalancer) DeepCopyInto(out *VMAuthLoadBalancer) {
 	*out = *in
-	if in.Selector != nil {
-		in, out := &in.Selector, &out.Selector
-		*out = new(DiscoverySelector)
-		(*in).DeepCopyInto(*out)
-	}
-	in.HTTPAuth.DeepCopyInto(&out.HTTPAuth)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertNotifierSpec.
-func (in *VMAlertNotifierSpec) DeepCopy() *VMAlertNotifierSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAuthLoadBalancer.
+func (in *VMAuthLoadBalancer) DeepCopy() *VMAuthLoadBalancer {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAlertNotifierSpec)
+	out := new(VMAuthLoadBalancer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAlertRemoteReadSpec) DeepCopyInto(out *VMAlertRemoteReadSpec) {
+func (in *VMAuthLoadBalancerSpec) DeepCopyInto(out *VMAuthLoadBalancerSpec) {
 	*out = *in
-	if in.Lookback != nil {
-		in, out := &in.Lookback, &out.Lookback
-		*out = new(string)
-		**out = **in
-	}
-	in.HTTPAuth.DeepCopyInto(&out.HTTPAuth)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertRemoteReadSpec.
-func (in *VMAlertRemoteReadSpec) DeepCopy() *VMAlertRemoteReadSpec {
-	if in == nil {
-		return nil
+	if in.PodMetadata != nil {
+		in, out := &in.PodMetadata, &out.PodMetadata
+		*out = new(EmbeddedObjectMetadata)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(VMAlertRemoteReadSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAlertRemoteWriteSpec) DeepCopyInto(out *VMAlertRemoteWriteSpec) {
-	*out = *in
-	if in.Concurrency != nil {
-		in, out := &in.Concurrency, &out.Concurrency
-		*out = new(int32)
-		**out = **in
+	if in.AdditionalServiceSpec != nil {
+		in, out := &in.AdditionalServiceSpec, &out.AdditionalServiceSpec
+		*out = new(AdditionalServiceSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.FlushInterval != nil {
-		in, out := &in.FlushInterval, &out.FlushInterval
-		*out = new(string)
-		**out = **in
+	if in.ServiceScrapeSpec != nil {
+		in, out := &in.ServiceScrapeSpec, &out.ServiceScrapeSpec
+		*out = new(VMServiceScrapeSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.MaxBatchSize != nil {
-		in, out := &in.MaxBatchSize, &out.MaxBatchSize
-		*out = new(int32)
-		**out = **in
+	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
+	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
+	if in.EmbeddedProbes != nil {
+		in, out := &in.EmbeddedProbes, &out.EmbeddedProbes
+		*out = new(EmbeddedProbes)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.MaxQueueSize != nil {
-		in, out := &in.MaxQueueSize, &out.MaxQueueSize
-		*out = new(int32)
-		**out = **in
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(EmbeddedPodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	in.HTTPAuth.DeepCopyInto(&out.HTTPAuth)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertRemoteWriteSpec.
-func (in *VMAlertRemoteWriteSpec) DeepCopy() *VMAlertRemoteWriteSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAuthLoadBalancerSpec.
+func (in *VMAuthLoadBalancerSpec) DeepCopy() *VMAuthLoadBalancerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAlertRemoteWriteSpec)
+	out := new(VMAuthLoadBalancerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAlertSpec) DeepCopyInto(out *VMAlertSpec) {
+func (in *VMAuthSpec) DeepCopyInto(out *VMAuthSpec) {
 	*out = *in
 	if in.PodMetadata != nil {
 		in, out := &in.PodMetadata, &out.PodMetadata
 		*out = new(EmbeddedObjectMetadata)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.RuleSelector != nil {
-		in, out := &in.RuleSelector, &out.RuleSelector
+	if in.UserSelector != nil {
+		in, out := &in.UserSelector, &out.UserSelector
 		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.RuleNamespaceSelector != nil {
-		in, out := &in.RuleNamespaceSelector, &out.RuleNamespaceSelector
+	if in.UserNamespaceSelector != nil {
+		in, out := &in.UserNamespaceSelector, &out.UserNamespaceSelector
 		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Notifier != nil {
-		in, out := &in.Notifier, &out.Notifier
-		*out = new(VMAlertNotifierSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Notifiers != nil {
-		in, out := &in.Notifiers, &out.Notifiers
-		*out = make([]VMAlertNotifierSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.NotifierConfigRef != nil {
-		in, out := &in.NotifierConfigRef, &out.NotifierConfigRef
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.RemoteWrite != nil {
-		in, out := &in.RemoteWrite, &out.RemoteWrite
-		*out = new(VMAlertRemoteWriteSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.RemoteRead != nil {
-		in, out := &in.RemoteRead, &out.RemoteRead
-		*out = new(VMAlertRemoteReadSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.RulePath != nil {
-		in, out := &in.RulePath, &out.RulePath
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	in.Datasource.DeepCopyInto(&out.Datasource)
-	if in.ExternalLabels != nil {
-		in, out := &in.ExternalLabels, &out.ExternalLabels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
 	if in.ServiceSpec != nil {
 		in, out := &in.ServiceSpec, &out.ServiceSpec
 		*out = new(AdditionalServiceSpec)
@@ -4186,371 +6457,298 @@ func (in *VMAlertSpec) DeepCopyInto(out *VMAlertSpec) {
 		*out = new(VMServiceScrapeSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.UpdateStrategy != nil {
-		in, out := &in.UpdateStrategy, &out.UpdateStrategy
-		*out = new(appsv1.DeploymentStrategyType)
-		**out = **in
-	}
-	if in.RollingUpdate != nil {
-		in, out := &in.RollingUpdate, &out.RollingUpdate
-		*out = new(appsv1.RollingUpdateDeployment)
-		(*in).DeepCopyInto(*out)
-	}
 	if in.PodDisruptionBudget != nil {
 		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
 		*out = new(EmbeddedPodDisruptionBudgetSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.EmbeddedProbes != nil {
-		in, out := &in.EmbeddedProbes, &out.EmbeddedProbes
-		*out = new(EmbeddedProbes)
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(EmbeddedIngress)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.License != nil {
-		in, out := &in.License, &out.License
-		*out = new(License)
+	if in.HTTPRoute != nil {
+		in, out := &in.HTTPRoute, &out.HTTPRoute
+		*out = new(EmbeddedHTTPRoute)
 		(*in).DeepCopyInto(*out)
 	}
-	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
-	in.CommonConfigReloaderParams.DeepCopyInto(&out.CommonConfigReloaderParams)
-	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertSpec.
-func (in *VMAlertSpec) DeepCopy() *VMAlertSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(VMAlertSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAlertStatus) DeepCopyInto(out *VMAlertStatus) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertStatus.
-func (in *VMAlertStatus) DeepCopy() *VMAlertStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(VMAlertStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAlertmanager) DeepCopyInto(out *VMAlertmanager) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	if in.ParsedLastAppliedSpec != nil {
-		in, out := &in.ParsedLastAppliedSpec, &out.ParsedLastAppliedSpec
-		*out = new(VMAlertmanagerSpec)
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(EmbeddedNetworkPolicy)
 		(*in).DeepCopyInto(*out)
 	}
-	out.Status = in.Status
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanager.
-func (in *VMAlertmanager) DeepCopy() *VMAlertmanager {
-	if in == nil {
-		return nil
+	if in.EmbeddedProbes != nil {
+		in, out := &in.EmbeddedProbes, &out.EmbeddedProbes
+		*out = new(EmbeddedProbes)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(VMAlertmanager)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMAlertmanager) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+	if in.UnauthorizedAccessConfig != nil {
+		in, out := &in.UnauthorizedAccessConfig, &out.UnauthorizedAccessConfig
+		*out = make([]UnauthorizedAccessConfigURLMap, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	return nil
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAlertmanagerConfig) DeepCopyInto(out *VMAlertmanagerConfig) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.UserConfigOption.DeepCopyInto(&out.UserConfigOption)
+	if in.License != nil {
+		in, out := &in.License, &out.License
+		*out = new(License)
+		(*in).DeepCopyInto(*out)
+	}
+	in.ExternalConfig.DeepCopyInto(&out.ExternalConfig)
+	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
+	in.CommonConfigReloaderParams.DeepCopyInto(&out.CommonConfigReloaderParams)
+	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerConfig.
-func (in *VMAlertmanagerConfig) DeepCopy() *VMAlertmanagerConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAuthSpec.
+func (in *VMAuthSpec) DeepCopy() *VMAuthSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAlertmanagerConfig)
+	out := new(VMAuthSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMAlertmanagerConfig) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAlertmanagerConfigList) DeepCopyInto(out *VMAlertmanagerConfigList) {
+func (in *VMAuthStatus) DeepCopyInto(out *VMAuthStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]VMAlertmanagerConfig, len(*in))
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerConfigList.
-func (in *VMAlertmanagerConfigList) DeepCopy() *VMAlertmanagerConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAuthStatus.
+func (in *VMAuthStatus) DeepCopy() *VMAuthStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAlertmanagerConfigList)
+	out := new(VMAuthStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMAlertmanagerConfigList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAlertmanagerConfigSpec) DeepCopyInto(out *VMAlertmanagerConfigSpec) {
+func (in *VMBackup) DeepCopyInto(out *VMBackup) {
 	*out = *in
-	if in.Route != nil {
-		in, out := &in.Route, &out.Route
-		*out = new(Route)
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CustomS3Endpoint != nil {
+		in, out := &in.CustomS3Endpoint, &out.CustomS3Endpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.CredentialsSecret != nil {
+		in, out := &in.CredentialsSecret, &out.CredentialsSecret
+		*out = new(v1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Receivers != nil {
-		in, out := &in.Receivers, &out.Receivers
-		*out = make([]Receiver, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.DisableHourly != nil {
+		in, out := &in.DisableHourly, &out.DisableHourly
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DisableDaily != nil {
+		in, out := &in.DisableDaily, &out.DisableDaily
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DisableWeekly != nil {
+		in, out := &in.DisableWeekly, &out.DisableWeekly
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DisableMonthly != nil {
+		in, out := &in.DisableMonthly, &out.DisableMonthly
+		*out = new(bool)
+		**out = **in
+	}
+	out.Image = in.Image
+	if in.LogFormat != nil {
+		in, out := &in.LogFormat, &out.LogFormat
+		*out = new(string)
+		**out = **in
+	}
+	if in.LogLevel != nil {
+		in, out := &in.LogLevel, &out.LogLevel
+		*out = new(string)
+		**out = **in
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
-	if in.InhibitRules != nil {
-		in, out := &in.InhibitRules, &out.InhibitRules
-		*out = make([]InhibitRule, len(*in))
+	if in.ExtraEnvs != nil {
+		in, out := &in.ExtraEnvs, &out.ExtraEnvs
+		*out = make([]v1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.TimeIntervals != nil {
-		in, out := &in.TimeIntervals, &out.TimeIntervals
-		*out = make([]TimeIntervals, len(*in))
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Restore != nil {
+		in, out := &in.Restore, &out.Restore
+		*out = new(VMRestore)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerConfigSpec.
-func (in *VMAlertmanagerConfigSpec) DeepCopy() *VMAlertmanagerConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMBackup.
+func (in *VMBackup) DeepCopy() *VMBackup {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAlertmanagerConfigSpec)
+	out := new(VMBackup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAlertmanagerConfigStatus) DeepCopyInto(out *VMAlertmanagerConfigStatus) {
+func (in *VMBackupJob) DeepCopyInto(out *VMBackupJob) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerConfigStatus.
-func (in *VMAlertmanagerConfigStatus) DeepCopy() *VMAlertmanagerConfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMBackupJob.
+func (in *VMBackupJob) DeepCopy() *VMBackupJob {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAlertmanagerConfigStatus)
+	out := new(VMBackupJob)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMBackupJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAlertmanagerList) DeepCopyInto(out *VMAlertmanagerList) {
+func (in *VMBackupJobList) DeepCopyInto(out *VMBackupJobList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]VMAlertmanager, len(*in))
+		*out = make([]VMBackupJob, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerList.
-func (in *VMAlertmanagerList) DeepCopy() *VMAlertmanagerList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMBackupJobList.
+func (in *VMBackupJobList) DeepCopy() *VMBackupJobList {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAlertmanagerList)
+	out := new(VMBackupJobList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMAlertmanagerList) DeepCopyObject() runtime.Object {
+func (in *VMBackupJobList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
 	return nil
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAlertmanagerSpec) DeepCopyInto(out *VMAlertmanagerSpec) {
-	*out = *in
-	if in.PodMetadata != nil {
-		in, out := &in.PodMetadata, &out.PodMetadata
-		*out = new(EmbeddedObjectMetadata)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Templates != nil {
-		in, out := &in.Templates, &out.Templates
-		*out = make([]ConfigMapKeyReference, len(*in))
-		copy(*out, *in)
-	}
-	if in.Storage != nil {
-		in, out := &in.Storage, &out.Storage
-		*out = new(StorageSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.AdditionalPeers != nil {
-		in, out := &in.AdditionalPeers, &out.AdditionalPeers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.ServiceSpec != nil {
-		in, out := &in.ServiceSpec, &out.ServiceSpec
-		*out = new(AdditionalServiceSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ServiceScrapeSpec != nil {
-		in, out := &in.ServiceScrapeSpec, &out.ServiceScrapeSpec
-		*out = new(VMServiceScrapeSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.PodDisruptionBudget != nil {
-		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
-		*out = new(EmbeddedPodDisruptionBudgetSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.EmbeddedProbes != nil {
-		in, out := &in.EmbeddedProbes, &out.EmbeddedProbes
-		*out = new(EmbeddedProbes)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ConfigSelector != nil {
-		in, out := &in.ConfigSelector, &out.ConfigSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ConfigNamespaceSelector != nil {
-		in, out := &in.ConfigNamespaceSelector, &out.ConfigNamespaceSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.EnforcedTopRouteMatchers != nil {
-		in, out := &in.EnforcedTopRouteMatchers, &out.EnforcedTopRouteMatchers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.ClaimTemplates != nil {
-		in, out := &in.ClaimTemplates, &out.ClaimTemplates
-		*out = make([]v1.PersistentVolumeClaim, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.WebConfig != nil {
-		in, out := &in.WebConfig, &out.WebConfig
-		*out = new(AlertmanagerWebConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.GossipConfig != nil {
-		in, out := &in.GossipConfig, &out.GossipConfig
-		*out = new(AlertmanagerGossipConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
-	in.CommonConfigReloaderParams.DeepCopyInto(&out.CommonConfigReloaderParams)
-	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMBackupJobSpec) DeepCopyInto(out *VMBackupJobSpec) {
+	*out = *in
+	out.Target = in.Target
+	in.BackupJobCommonParams.DeepCopyInto(&out.BackupJobCommonParams)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerSpec.
-func (in *VMAlertmanagerSpec) DeepCopy() *VMAlertmanagerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMBackupJobSpec.
+func (in *VMBackupJobSpec) DeepCopy() *VMBackupJobSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAlertmanagerSpec)
+	out := new(VMBackupJobSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAlertmanagerStatus) DeepCopyInto(out *VMAlertmanagerStatus) {
+func (in *VMBackupJobStatus) DeepCopyInto(out *VMBackupJobStatus) {
 	*out = *in
+	if in.LastSuccessfulBackupTime != nil {
+		in, out := &in.LastSuccessfulBackupTime, &out.LastSuccessfulBackupTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]BackupJobNodeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerStatus.
-func (in *VMAlertmanagerStatus) DeepCopy() *VMAlertmanagerStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMBackupJobStatus.
+func (in *VMBackupJobStatus) DeepCopy() *VMBackupJobStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAlertmanagerStatus)
+	out := new(VMBackupJobStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAuth) DeepCopyInto(out *VMAuth) {
+func (in *VMBlackboxExporter) DeepCopyInto(out *VMBlackboxExporter) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
 	if in.ParsedLastAppliedSpec != nil {
 		in, out := &in.ParsedLastAppliedSpec, &out.ParsedLastAppliedSpec
-		*out = new(VMAuthSpec)
+		*out = new(VMBlackboxExporterSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAuth.
-func (in *VMAuth) DeepCopy() *VMAuth {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMBlackboxExporter.
+func (in *VMBlackboxExporter) DeepCopy() *VMBlackboxExporter {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAuth)
+	out := new(VMBlackboxExporter)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMAuth) DeepCopyObject() runtime.Object {
+func (in *VMBlackboxExporter) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -4558,31 +6756,31 @@ func (in *VMAuth) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAuthList) DeepCopyInto(out *VMAuthList) {
+func (in *VMBlackboxExporterList) DeepCopyInto(out *VMBlackboxExporterList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]VMAuth, len(*in))
+		*out = make([]VMBlackboxExporter, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAuthList.
-func (in *VMAuthList) DeepCopy() *VMAuthList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMBlackboxExporterList.
+func (in *VMBlackboxExporterList) DeepCopy() *VMBlackboxExporterList {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAuthList)
+	out := new(VMBlackboxExporterList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMAuthList) DeepCopyObject() runtime.Object {
+func (in *VMBlackboxExporterList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -4590,31 +6788,37 @@ func (in *VMAuthList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAuthLoadBalancer) DeepCopyInto(out *VMAuthLoadBalancer) {
+func (in *VMBlackboxExporterRef) DeepCopyInto(out *VMBlackboxExporterRef) {
 	*out = *in
-	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAuthLoadBalancer.
-func (in *VMAuthLoadBalancer) DeepCopy() *VMAuthLoadBalancer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMBlackboxExporterRef.
+func (in *VMBlackboxExporterRef) DeepCopy() *VMBlackboxExporterRef {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAuthLoadBalancer)
+	out := new(VMBlackboxExporterRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAuthLoadBalancerSpec) DeepCopyInto(out *VMAuthLoadBalancerSpec) {
+func (in *VMBlackboxExporterSpec) DeepCopyInto(out *VMBlackboxExporterSpec) {
 	*out = *in
 	if in.PodMetadata != nil {
 		in, out := &in.PodMetadata, &out.PodMetadata
 		*out = new(EmbeddedObjectMetadata)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.AdditionalServiceSpec != nil {
-		in, out := &in.AdditionalServiceSpec, &out.AdditionalServiceSpec
+	if in.Modules != nil {
+		in, out := &in.Modules, &out.Modules
+		*out = make(map[string]BlackboxModule, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.ServiceSpec != nil {
+		in, out := &in.ServiceSpec, &out.ServiceSpec
 		*out = new(AdditionalServiceSpec)
 		(*in).DeepCopyInto(*out)
 	}
@@ -4623,11 +6827,14 @@ func (in *VMAuthLoadBalancerSpec) DeepCopyInto(out *VMAuthLoadBalancerSpec) {
 		*out = new(VMServiceScrapeSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
-	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
-	if in.EmbeddedProbes != nil {
-		in, out := &in.EmbeddedProbes, &out.EmbeddedProbes
-		*out = new(EmbeddedProbes)
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(appsv1.DeploymentStrategyType)
+		**out = **in
+	}
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(appsv1.RollingUpdateDeployment)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.PodDisruptionBudget != nil {
@@ -4635,257 +6842,198 @@ func (in *VMAuthLoadBalancerSpec) DeepCopyInto(out *VMAuthLoadBalancerSpec) {
 		*out = new(EmbeddedPodDisruptionBudgetSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.EmbeddedProbes != nil {
+		in, out := &in.EmbeddedProbes, &out.EmbeddedProbes
+		*out = new(EmbeddedProbes)
+		(*in).DeepCopyInto(*out)
+	}
+	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
+	in.CommonConfigReloaderParams.DeepCopyInto(&out.CommonConfigReloaderParams)
+	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAuthLoadBalancerSpec.
-func (in *VMAuthLoadBalancerSpec) DeepCopy() *VMAuthLoadBalancerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMBlackboxExporterSpec.
+func (in *VMBlackboxExporterSpec) DeepCopy() *VMBlackboxExporterSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAuthLoadBalancerSpec)
+	out := new(VMBlackboxExporterSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAuthSpec) DeepCopyInto(out *VMAuthSpec) {
+func (in *VMBlackboxExporterStatus) DeepCopyInto(out *VMBlackboxExporterStatus) {
 	*out = *in
-	if in.PodMetadata != nil {
-		in, out := &in.PodMetadata, &out.PodMetadata
-		*out = new(EmbeddedObjectMetadata)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.UserSelector != nil {
-		in, out := &in.UserSelector, &out.UserSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.UserNamespaceSelector != nil {
-		in, out := &in.UserNamespaceSelector, &out.UserNamespaceSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ServiceSpec != nil {
-		in, out := &in.ServiceSpec, &out.ServiceSpec
-		*out = new(AdditionalServiceSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ServiceScrapeSpec != nil {
-		in, out := &in.ServiceScrapeSpec, &out.ServiceScrapeSpec
-		*out = new(VMServiceScrapeSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.PodDisruptionBudget != nil {
-		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
-		*out = new(EmbeddedPodDisruptionBudgetSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Ingress != nil {
-		in, out := &in.Ingress, &out.Ingress
-		*out = new(EmbeddedIngress)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.EmbeddedProbes != nil {
-		in, out := &in.EmbeddedProbes, &out.EmbeddedProbes
-		*out = new(EmbeddedProbes)
-		(*in).DeepCopyInto(*out)
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.UnauthorizedAccessConfig != nil {
-		in, out := &in.UnauthorizedAccessConfig, &out.UnauthorizedAccessConfig
-		*out = make([]UnauthorizedAccessConfigURLMap, len(*in))
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	in.UserConfigOption.DeepCopyInto(&out.UserConfigOption)
-	if in.License != nil {
-		in, out := &in.License, &out.License
-		*out = new(License)
-		(*in).DeepCopyInto(*out)
-	}
-	in.ExternalConfig.DeepCopyInto(&out.ExternalConfig)
-	in.CommonDefaultableParams.DeepCopyInto(&out.CommonDefaultableParams)
-	in.CommonConfigReloaderParams.DeepCopyInto(&out.CommonConfigReloaderParams)
-	in.CommonApplicationDeploymentParams.DeepCopyInto(&out.CommonApplicationDeploymentParams)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAuthSpec.
-func (in *VMAuthSpec) DeepCopy() *VMAuthSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMBlackboxExporterStatus.
+func (in *VMBlackboxExporterStatus) DeepCopy() *VMBlackboxExporterStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAuthSpec)
+	out := new(VMBlackboxExporterStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAuthStatus) DeepCopyInto(out *VMAuthStatus) {
+func (in *VMCluster) DeepCopyInto(out *VMCluster) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.ParsedLastAppliedSpec != nil {
+		in, out := &in.ParsedLastAppliedSpec, &out.ParsedLastAppliedSpec
+		*out = new(VMClusterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAuthStatus.
-func (in *VMAuthStatus) DeepCopy() *VMAuthStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMCluster.
+func (in *VMCluster) DeepCopy() *VMCluster {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAuthStatus)
+	out := new(VMCluster)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMBackup) DeepCopyInto(out *VMBackup) {
+func (in *VMClusterList) DeepCopyInto(out *VMClusterList) {
 	*out = *in
-	if in.Concurrency != nil {
-		in, out := &in.Concurrency, &out.Concurrency
-		*out = new(int32)
-		**out = **in
-	}
-	if in.CustomS3Endpoint != nil {
-		in, out := &in.CustomS3Endpoint, &out.CustomS3Endpoint
-		*out = new(string)
-		**out = **in
-	}
-	if in.CredentialsSecret != nil {
-		in, out := &in.CredentialsSecret, &out.CredentialsSecret
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.DisableHourly != nil {
-		in, out := &in.DisableHourly, &out.DisableHourly
-		*out = new(bool)
-		**out = **in
-	}
-	if in.DisableDaily != nil {
-		in, out := &in.DisableDaily, &out.DisableDaily
-		*out = new(bool)
-		**out = **in
-	}
-	if in.DisableWeekly != nil {
-		in, out := &in.DisableWeekly, &out.DisableWeekly
-		*out = new(bool)
-		**out = **in
-	}
-	if in.DisableMonthly != nil {
-		in, out := &in.DisableMonthly, &out.DisableMonthly
-		*out = new(bool)
-		**out = **in
-	}
-	out.Image = in.Image
-	if in.LogFormat != nil {
-		in, out := &in.LogFormat, &out.LogFormat
-		*out = new(string)
-		**out = **in
-	}
-	if in.LogLevel != nil {
-		in, out := &in.LogLevel, &out.LogLevel
-		*out = new(string)
-		**out = **in
-	}
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.ExtraArgs != nil {
-		in, out := &in.ExtraArgs, &out.ExtraArgs
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.ExtraEnvs != nil {
-		in, out := &in.ExtraEnvs, &out.ExtraEnvs
-		*out = make([]v1.EnvVar, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMCluster, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.VolumeMounts != nil {
-		in, out := &in.VolumeMounts, &out.VolumeMounts
-		*out = make([]v1.VolumeMount, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMClusterList.
+func (in *VMClusterList) DeepCopy() *VMClusterList {
+	if in == nil {
+		return nil
 	}
-	if in.Restore != nil {
-		in, out := &in.Restore, &out.Restore
-		*out = new(VMRestore)
-		(*in).DeepCopyInto(*out)
+	out := new(VMClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
+	return nil
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMBackup.
-func (in *VMBackup) DeepCopy() *VMBackup {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMClusterRolloutPodMetrics) DeepCopyInto(out *VMClusterRolloutPodMetrics) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMClusterRolloutPodMetrics.
+func (in *VMClusterRolloutPodMetrics) DeepCopy() *VMClusterRolloutPodMetrics {
 	if in == nil {
 		return nil
 	}
-	out := new(VMBackup)
+	out := new(VMClusterRolloutPodMetrics)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMCluster) DeepCopyInto(out *VMCluster) {
+func (in *VMClusterRolloutPolicy) DeepCopyInto(out *VMClusterRolloutPolicy) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	if in.ParsedLastAppliedSpec != nil {
-		in, out := &in.ParsedLastAppliedSpec, &out.ParsedLastAppliedSpec
-		*out = new(VMClusterSpec)
-		(*in).DeepCopyInto(*out)
+	if in.CanaryPods != nil {
+		in, out := &in.CanaryPods, &out.CanaryPods
+		*out = new(int32)
+		**out = **in
 	}
-	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMCluster.
-func (in *VMCluster) DeepCopy() *VMCluster {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMClusterRolloutPolicy.
+func (in *VMClusterRolloutPolicy) DeepCopy() *VMClusterRolloutPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(VMCluster)
+	out := new(VMClusterRolloutPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMCluster) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMClusterRolloutStatefulSet) DeepCopyInto(out *VMClusterRolloutStatefulSet) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMClusterRolloutStatefulSet.
+func (in *VMClusterRolloutStatefulSet) DeepCopy() *VMClusterRolloutStatefulSet {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(VMClusterRolloutStatefulSet)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMClusterList) DeepCopyInto(out *VMClusterList) {
+func (in *VMClusterRolloutStatus) DeepCopyInto(out *VMClusterRolloutStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]VMCluster, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.CanaryPods != nil {
+		in, out := &in.CanaryPods, &out.CanaryPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StatefulSets != nil {
+		in, out := &in.StatefulSets, &out.StatefulSets
+		*out = make([]VMClusterRolloutStatefulSet, len(*in))
+		copy(*out, *in)
+	}
+	if in.CanaryMetrics != nil {
+		in, out := &in.CanaryMetrics, &out.CanaryMetrics
+		*out = make([]VMClusterRolloutPodMetrics, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMClusterList.
-func (in *VMClusterList) DeepCopy() *VMClusterList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMClusterRolloutStatus.
+func (in *VMClusterRolloutStatus) DeepCopy() *VMClusterRolloutStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VMClusterList)
+	out := new(VMClusterRolloutStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMClusterList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMClusterSpec) DeepCopyInto(out *VMClusterSpec) {
 	*out = *in
@@ -4925,6 +7073,21 @@ func (in *VMClusterSpec) DeepCopyInto(out *VMClusterSpec) {
 		**out = **in
 	}
 	in.RequestsLoadBalancer.DeepCopyInto(&out.RequestsLoadBalancer)
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = new(VMClusterZones)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RolloutPolicy != nil {
+		in, out := &in.RolloutPolicy, &out.RolloutPolicy
+		*out = new(VMClusterRolloutPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(EmbeddedNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMClusterSpec.
@@ -4940,6 +7103,23 @@ func (in *VMClusterSpec) DeepCopy() *VMClusterSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMClusterStatus) DeepCopyInto(out *VMClusterStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StorageScaleDown != nil {
+		in, out := &in.StorageScaleDown, &out.StorageScaleDown
+		*out = new(VMStorageScaleDownStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(VMClusterRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMClusterStatus.
@@ -4952,6 +7132,46 @@ func (in *VMClusterStatus) DeepCopy() *VMClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMClusterZone) DeepCopyInto(out *VMClusterZone) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMClusterZone.
+func (in *VMClusterZone) DeepCopy() *VMClusterZone {
+	if in == nil {
+		return nil
+	}
+	out := new(VMClusterZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMClusterZones) DeepCopyInto(out *VMClusterZones) {
+	*out = *in
+	if in.GlobalReplicationFactor != nil {
+		in, out := &in.GlobalReplicationFactor, &out.GlobalReplicationFactor
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMClusterZone, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMClusterZones.
+func (in *VMClusterZones) DeepCopy() *VMClusterZones {
+	if in == nil {
+		return nil
+	}
+	out := new(VMClusterZones)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMInsert) DeepCopyInto(out *VMInsert) {
 	*out = *in
@@ -5248,7 +7468,7 @@ func (in *VMProbeList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMProbeSpec) DeepCopyInto(out *VMProbeSpec) {
 	*out = *in
-	out.VMProberSpec = in.VMProberSpec
+	in.VMProberSpec.DeepCopyInto(&out.VMProberSpec)
 	in.Targets.DeepCopyInto(&out.Targets)
 	if in.MetricRelabelConfigs != nil {
 		in, out := &in.MetricRelabelConfigs, &out.MetricRelabelConfigs
@@ -5341,6 +7561,11 @@ func (in *VMProbeTargets) DeepCopy() *VMProbeTargets {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMProberSpec) DeepCopyInto(out *VMProberSpec) {
 	*out = *in
+	if in.BlackboxExporterRef != nil {
+		in, out := &in.BlackboxExporterRef, &out.BlackboxExporterRef
+		*out = new(VMBlackboxExporterRef)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMProberSpec.
@@ -5373,6 +7598,108 @@ func (in *VMRestore) DeepCopy() *VMRestore {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMRestoreJob) DeepCopyInto(out *VMRestoreJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRestoreJob.
+func (in *VMRestoreJob) DeepCopy() *VMRestoreJob {
+	if in == nil {
+		return nil
+	}
+	out := new(VMRestoreJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMRestoreJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMRestoreJobList) DeepCopyInto(out *VMRestoreJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMRestoreJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRestoreJobList.
+func (in *VMRestoreJobList) DeepCopy() *VMRestoreJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(VMRestoreJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMRestoreJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMRestoreJobSpec) DeepCopyInto(out *VMRestoreJobSpec) {
+	*out = *in
+	out.Target = in.Target
+	in.BackupJobCommonParams.DeepCopyInto(&out.BackupJobCommonParams)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRestoreJobSpec.
+func (in *VMRestoreJobSpec) DeepCopy() *VMRestoreJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMRestoreJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMRestoreJobStatus) DeepCopyInto(out *VMRestoreJobStatus) {
+	*out = *in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]BackupJobNodeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRestoreJobStatus.
+func (in *VMRestoreJobStatus) DeepCopy() *VMRestoreJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMRestoreJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMRestoreOnStartConfig) DeepCopyInto(out *VMRestoreOnStartConfig) {
 	*out = *in
@@ -5468,22 +7795,145 @@ func (in *VMRuleSpec) DeepCopy() *VMRuleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VMRuleSpec)
+	out := new(VMRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMRuleStatus) DeepCopyInto(out *VMRuleStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRuleStatus.
+func (in *VMRuleStatus) DeepCopy() *VMRuleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMRuleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMRuleTest) DeepCopyInto(out *VMRuleTest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRuleTest.
+func (in *VMRuleTest) DeepCopy() *VMRuleTest {
+	if in == nil {
+		return nil
+	}
+	out := new(VMRuleTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMRuleTest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMRuleTestList) DeepCopyInto(out *VMRuleTestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMRuleTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRuleTestList.
+func (in *VMRuleTestList) DeepCopy() *VMRuleTestList {
+	if in == nil {
+		return nil
+	}
+	out := new(VMRuleTestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMRuleTestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMRuleTestSpec) DeepCopyInto(out *VMRuleTestSpec) {
+	*out = *in
+	if in.RuleSelector != nil {
+		in, out := &in.RuleSelector, &out.RuleSelector
+		*out = new(DiscoverySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GroupEvalOrder != nil {
+		in, out := &in.GroupEvalOrder, &out.GroupEvalOrder
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tests != nil {
+		in, out := &in.Tests, &out.Tests
+		*out = make([]RuleTestCase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.Image = in.Image
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRuleTestSpec.
+func (in *VMRuleTestSpec) DeepCopy() *VMRuleTestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMRuleTestSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMRuleStatus) DeepCopyInto(out *VMRuleStatus) {
+func (in *VMRuleTestStatus) DeepCopyInto(out *VMRuleTestStatus) {
 	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tests != nil {
+		in, out := &in.Tests, &out.Tests
+		*out = make([]RuleTestCaseStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRuleStatus.
-func (in *VMRuleStatus) DeepCopy() *VMRuleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRuleTestStatus.
+func (in *VMRuleTestStatus) DeepCopy() *VMRuleTestStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VMRuleStatus)
+	out := new(VMRuleTestStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -5866,7 +8316,7 @@ func (in *VMSingle) DeepCopyInto(out *VMSingle) {
 		*out = new(VMSingleSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSingle.
@@ -5953,6 +8403,16 @@ func (in *VMSingleSpec) DeepCopyInto(out *VMSingleSpec) {
 		*out = new(AdditionalServiceSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HTTPRoute != nil {
+		in, out := &in.HTTPRoute, &out.HTTPRoute
+		*out = new(EmbeddedHTTPRoute)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(EmbeddedNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ServiceScrapeSpec != nil {
 		in, out := &in.ServiceScrapeSpec, &out.ServiceScrapeSpec
 		*out = new(VMServiceScrapeSpec)
@@ -5985,6 +8445,13 @@ func (in *VMSingleSpec) DeepCopy() *VMSingleSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMSingleStatus) DeepCopyInto(out *VMSingleStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSingleStatus.
@@ -6130,6 +8597,11 @@ func (in *VMStorage) DeepCopyInto(out *VMStorage) {
 		*out = make([]int32, len(*in))
 		copy(*out, *in)
 	}
+	if in.ScaleDown != nil {
+		in, out := &in.ScaleDown, &out.ScaleDown
+		*out = new(VMStorageScaleDown)
+		**out = **in
+	}
 	if in.ClaimTemplates != nil {
 		in, out := &in.ClaimTemplates, &out.ClaimTemplates
 		*out = make([]v1.PersistentVolumeClaim, len(*in))
@@ -6152,7 +8624,60 @@ func (in *VMStorage) DeepCopy() *VMStorage {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMUser) DeepCopyInto(out *VMUser) {
+func (in *VMStorageNodeScaleDownStatus) DeepCopyInto(out *VMStorageNodeScaleDownStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMStorageNodeScaleDownStatus.
+func (in *VMStorageNodeScaleDownStatus) DeepCopy() *VMStorageNodeScaleDownStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMStorageNodeScaleDownStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMStorageScaleDown) DeepCopyInto(out *VMStorageScaleDown) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMStorageScaleDown.
+func (in *VMStorageScaleDown) DeepCopy() *VMStorageScaleDown {
+	if in == nil {
+		return nil
+	}
+	out := new(VMStorageScaleDown)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMStorageScaleDownStatus) DeepCopyInto(out *VMStorageScaleDownStatus) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]VMStorageNodeScaleDownStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMStorageScaleDownStatus.
+func (in *VMStorageScaleDownStatus) DeepCopy() *VMStorageScaleDownStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMStorageScaleDownStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMTenant) DeepCopyInto(out *VMTenant) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -6160,6 +8685,161 @@ func (in *VMUser) DeepCopyInto(out *VMUser) {
 	out.Status = in.Status
 }
 
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMTenant.
+func (in *VMTenant) DeepCopy() *VMTenant {
+	if in == nil {
+		return nil
+	}
+	out := new(VMTenant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMTenant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMTenantAccess) DeepCopyInto(out *VMTenantAccess) {
+	*out = *in
+	if in.UserName != nil {
+		in, out := &in.UserName, &out.UserName
+		*out = new(string)
+		**out = **in
+	}
+	if in.PasswordRef != nil {
+		in, out := &in.PasswordRef, &out.PasswordRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxConcurrentRequests != nil {
+		in, out := &in.MaxConcurrentRequests, &out.MaxConcurrentRequests
+		*out = new(int)
+		**out = **in
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(VMUserLimits)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMTenantAccess.
+func (in *VMTenantAccess) DeepCopy() *VMTenantAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(VMTenantAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMTenantClusterRef) DeepCopyInto(out *VMTenantClusterRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMTenantClusterRef.
+func (in *VMTenantClusterRef) DeepCopy() *VMTenantClusterRef {
+	if in == nil {
+		return nil
+	}
+	out := new(VMTenantClusterRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMTenantList) DeepCopyInto(out *VMTenantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMTenant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMTenantList.
+func (in *VMTenantList) DeepCopy() *VMTenantList {
+	if in == nil {
+		return nil
+	}
+	out := new(VMTenantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMTenantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMTenantSpec) DeepCopyInto(out *VMTenantSpec) {
+	*out = *in
+	out.ClusterRef = in.ClusterRef
+	if in.Write != nil {
+		in, out := &in.Write, &out.Write
+		*out = new(VMTenantAccess)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Read != nil {
+		in, out := &in.Read, &out.Read
+		*out = new(VMTenantAccess)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMTenantSpec.
+func (in *VMTenantSpec) DeepCopy() *VMTenantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMTenantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMTenantStatus) DeepCopyInto(out *VMTenantStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMTenantStatus.
+func (in *VMTenantStatus) DeepCopy() *VMTenantStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMTenantStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMUser) DeepCopyInto(out *VMUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMUser.
 func (in *VMUser) DeepCopy() *VMUser {
 	if in == nil {
@@ -6178,6 +8858,21 @@ func (in *VMUser) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMUserCredentialRotation) DeepCopyInto(out *VMUserCredentialRotation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMUserCredentialRotation.
+func (in *VMUserCredentialRotation) DeepCopy() *VMUserCredentialRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(VMUserCredentialRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMUserIPFilters) DeepCopyInto(out *VMUserIPFilters) {
 	*out = *in
@@ -6203,6 +8898,87 @@ func (in *VMUserIPFilters) DeepCopy() *VMUserIPFilters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMUserJWT) DeepCopyInto(out *VMUserJWT) {
+	*out = *in
+	if in.PublicKeys != nil {
+		in, out := &in.PublicKeys, &out.PublicKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequiredClaims != nil {
+		in, out := &in.RequiredClaims, &out.RequiredClaims
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TenantMapping != nil {
+		in, out := &in.TenantMapping, &out.TenantMapping
+		*out = make([]VMUserJWTTenantMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMUserJWT.
+func (in *VMUserJWT) DeepCopy() *VMUserJWT {
+	if in == nil {
+		return nil
+	}
+	out := new(VMUserJWT)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMUserJWTTenantMapping) DeepCopyInto(out *VMUserJWTTenantMapping) {
+	*out = *in
+	if in.Claims != nil {
+		in, out := &in.Claims, &out.Claims
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMUserJWTTenantMapping.
+func (in *VMUserJWTTenantMapping) DeepCopy() *VMUserJWTTenantMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(VMUserJWTTenantMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMUserLimits) DeepCopyInto(out *VMUserLimits) {
+	*out = *in
+	if in.MaxRequestsPerSecond != nil {
+		in, out := &in.MaxRequestsPerSecond, &out.MaxRequestsPerSecond
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxIngestionBytesPerSecond != nil {
+		in, out := &in.MaxIngestionBytesPerSecond, &out.MaxIngestionBytesPerSecond
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMUserLimits.
+func (in *VMUserLimits) DeepCopy() *VMUserLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(VMUserLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMUserList) DeepCopyInto(out *VMUserList) {
 	*out = *in
@@ -6268,6 +9044,21 @@ func (in *VMUserSpec) DeepCopyInto(out *VMUserSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = new(VMUserJWT)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(VMUserCredentialRotation)
+		**out = **in
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(VMUserLimits)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TargetRefs != nil {
 		in, out := &in.TargetRefs, &out.TargetRefs
 		*out = make([]TargetRef, len(*in))
@@ -6298,6 +9089,11 @@ func (in *VMUserSpec) DeepCopy() *VMUserSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMUserStatus) DeepCopyInto(out *VMUserStatus) {
 	*out = *in
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(VMUserLimits)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMUserStatus.
@@ -38,6 +38,23 @@ func main() {
 		cancel()
 	}()
 
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case manager.DryRunCommand:
+			if err := manager.RunDryRun(ctx, os.Args[2:]); err != nil {
+				setupLog.Error(err, "cannot perform dry-run")
+				os.Exit(1)
+			}
+			return
+		case manager.RenderCommand:
+			if err := manager.RunRender(ctx, os.Args[2:]); err != nil {
+				setupLog.Error(err, "cannot render objects")
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	err := manager.RunManager(ctx)
 	if err != nil {
 		setupLog.Error(err, "cannot setup manager")
@@ -122,6 +122,49 @@ type BaseOperatorConf struct {
 		ConfigReloaderMemory string `default:"25Mi"`
 	}
 
+	VMAnomalyDefault struct {
+		Image   string `default:"victoriametrics/vmanomaly"`
+		Version string `default:"v1.18.8"`
+		// ignored
+		ConfigReloadImage   string `ignored:"true"`
+		Port                string `default:"8490"`
+		UseDefaultResources bool   `default:"true"`
+		Resource            struct {
+			Limit struct {
+				Mem string `default:"1024Mi"`
+				Cpu string `default:"1000m"`
+			}
+			Request struct {
+				Mem string `default:"256Mi"`
+				Cpu string `default:"100m"`
+			}
+		}
+		// ignored
+		ConfigReloaderCPU string `ignored:"true"`
+		// ignored
+		ConfigReloaderMemory string `ignored:"true"`
+	}
+
+	VMBlackboxExporterDefault struct {
+		Image               string `default:"prom/blackbox-exporter"`
+		Version             string `default:"v0.25.0"`
+		ConfigReloadImage   string `default:"jimmidyson/configmap-reload:v0.3.0"`
+		Port                string `default:"9115"`
+		UseDefaultResources bool   `default:"true"`
+		Resource            struct {
+			Limit struct {
+				Mem string `default:"128Mi"`
+				Cpu string `default:"100m"`
+			}
+			Request struct {
+				Mem string `default:"32Mi"`
+				Cpu string `default:"10m"`
+			}
+		}
+		ConfigReloaderCPU    string `default:"10m"`
+		ConfigReloaderMemory string `default:"25Mi"`
+	}
+
 	VMAgentDefault struct {
 		Image               string `default:"victoriametrics/vmagent"`
 		Version             string `default:"v1.106.0"`
@@ -216,6 +259,55 @@ type BaseOperatorConf struct {
 		}
 	}
 
+	VLClusterDefault struct {
+		UseDefaultResources bool `default:"true"`
+		VLSelectDefault     struct {
+			Image    string `default:"victoriametrics/victoria-logs"`
+			Version  string `default:"v1.21.0-victorialogs"`
+			Port     string `default:"9471"`
+			Resource struct {
+				Limit struct {
+					Mem string `default:"1024Mi"`
+					Cpu string `default:"1000m"`
+				}
+				Request struct {
+					Mem string `default:"256Mi"`
+					Cpu string `default:"100m"`
+				}
+			}
+		}
+		VLStorageDefault struct {
+			Image    string `default:"victoriametrics/victoria-logs"`
+			Version  string `default:"v1.21.0-victorialogs"`
+			Port     string `default:"9491"`
+			Resource struct {
+				Limit struct {
+					Mem string `default:"2048Mi"`
+					Cpu string `default:"1000m"`
+				}
+				Request struct {
+					Mem string `default:"512Mi"`
+					Cpu string `default:"200m"`
+				}
+			}
+		}
+		VLInsertDefault struct {
+			Image    string `default:"victoriametrics/victoria-logs"`
+			Version  string `default:"v1.21.0-victorialogs"`
+			Port     string `default:"9481"`
+			Resource struct {
+				Limit struct {
+					Mem string `default:"1024Mi"`
+					Cpu string `default:"1000m"`
+				}
+				Request struct {
+					Mem string `default:"256Mi"`
+					Cpu string `default:"100m"`
+				}
+			}
+		}
+	}
+
 	VMAlertManager struct {
 		ConfigReloaderImage          string `default:"jimmidyson/configmap-reload:v0.3.0"`
 		ConfigReloaderCPU            string `default:"100m"`
@@ -253,6 +345,51 @@ type BaseOperatorConf struct {
 			}
 		}
 	}
+	VMBackupJobDefault struct {
+		Image               string `default:"victoriametrics/vmbackup"`
+		Version             string `default:"v1.106.0"`
+		UseDefaultResources bool   `default:"true"`
+		Resource            struct {
+			Limit struct {
+				Mem string `default:"500Mi"`
+				Cpu string `default:"500m"`
+			}
+			Request struct {
+				Mem string `default:"200Mi"`
+				Cpu string `default:"150m"`
+			}
+		}
+	}
+	VMRestoreJobDefault struct {
+		Image               string `default:"victoriametrics/vmrestore"`
+		Version             string `default:"v1.106.0"`
+		UseDefaultResources bool   `default:"true"`
+		Resource            struct {
+			Limit struct {
+				Mem string `default:"500Mi"`
+				Cpu string `default:"500m"`
+			}
+			Request struct {
+				Mem string `default:"200Mi"`
+				Cpu string `default:"150m"`
+			}
+		}
+	}
+	VMRuleTestDefault struct {
+		Image               string `default:"victoriametrics/vmalert-tool"`
+		Version             string `default:"v1.106.0"`
+		UseDefaultResources bool   `default:"true"`
+		Resource            struct {
+			Limit struct {
+				Mem string `default:"500Mi"`
+				Cpu string `default:"500m"`
+			}
+			Request struct {
+				Mem string `default:"100Mi"`
+				Cpu string `default:"100m"`
+			}
+		}
+	}
 	VMAuthDefault struct {
 		Image               string `default:"victoriametrics/vmauth"`
 		Version             string `default:"v1.106.0"`
@@ -280,6 +417,14 @@ type BaseOperatorConf struct {
 		Probe              bool `default:"true"`
 		AlertmanagerConfig bool `default:"true"`
 		ScrapeConfig       bool `default:"true"`
+		// converts Prometheus into VMAgent and VMSingle
+		Prometheus bool `default:"false"`
+		// converts PrometheusAgent into VMAgent
+		PrometheusAgent bool `default:"false"`
+		// converts Alertmanager into VMAlertmanager
+		Alertmanager bool `default:"false"`
+		// converts ThanosRuler into VMAlert
+		ThanosRuler bool `default:"false"`
 	}
 	FilterChildLabelPrefixes      []string `default:""`
 	FilterChildAnnotationPrefixes []string `default:""`
@@ -328,6 +473,26 @@ type BaseOperatorConf struct {
 	//        - all
 	// turn off `EnableStrictSecurity` by default, see https://github.com/VictoriaMetrics/operator/issues/749 for details
 	EnableStrictSecurity bool `default:"false"`
+	// RuleLint configures linting of VMRule objects at validating webhook
+	RuleLint struct {
+		// Defines action for found issues: disabled, warn or deny
+		Mode string `default:"warn"`
+		// Overrides mode for the given namespaces, e.g. prod:deny,dev:disabled
+		NamespaceModes map[string]string `default:""`
+		// Defines naming convention for recording rules, it's level:metric:operations by default
+		RecordNameRegex string `default:"^[a-zA-Z_][a-zA-Z0-9_]*:[a-zA-Z0-9_:]+$"`
+		// Defines label enforced by VMAlert with EnforcedNamespaceLabel, rules must not set it
+		EnforcedNamespaceLabel string `default:""`
+		// Defines max lookbehind window for rollup functions, check is disabled if set to 0
+		MaxLookbehindWindow time.Duration `default:"0s"`
+	}
+	// NetworkPolicy configures access of operator to application pods with NetworkPolicy restricted ingress
+	NetworkPolicy struct {
+		// Defines labels of operator pods, e.g. control-plane:vm-operator
+		OperatorPodLabels map[string]string `default:"control-plane:vm-operator"`
+		// Defines namespace of operator pods. Namespace of operator service account is used by default
+		OperatorNamespace string `default:""`
+	}
 }
 
 // ResyncAfterDuration returns requeue duration for object period reconcile
@@ -423,6 +588,36 @@ func (boc BaseOperatorConf) Validate() error {
 	if err := validateResource("vlogs", Resource(boc.VLogsDefault.Resource)); err != nil {
 		return err
 	}
+	switch boc.RuleLint.Mode {
+	case "disabled", "warn", "deny":
+	default:
+		return fmt.Errorf("unsupported rule lint mode=%q, supported values are: disabled, warn, deny", boc.RuleLint.Mode)
+	}
+	for ns, mode := range boc.RuleLint.NamespaceModes {
+		switch mode {
+		case "disabled", "warn", "deny":
+		default:
+			return fmt.Errorf("unsupported rule lint mode=%q for namespace=%q, supported values are: disabled, warn, deny", mode, ns)
+		}
+	}
+	if _, err := regexp.Compile(boc.RuleLint.RecordNameRegex); err != nil {
+		return fmt.Errorf("cannot parse rule lint record name regex: %w", err)
+	}
+	if err := validateResource("vmanomaly", Resource(boc.VMAnomalyDefault.Resource)); err != nil {
+		return err
+	}
+	if err := validateResource("vmblackboxexporter", Resource(boc.VMBlackboxExporterDefault.Resource)); err != nil {
+		return err
+	}
+	if err := validateResource("vlselect", Resource(boc.VLClusterDefault.VLSelectDefault.Resource)); err != nil {
+		return err
+	}
+	if err := validateResource("vlinsert", Resource(boc.VLClusterDefault.VLInsertDefault.Resource)); err != nil {
+		return err
+	}
+	if err := validateResource("vlstorage", Resource(boc.VLClusterDefault.VLStorageDefault.Resource)); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -469,11 +664,27 @@ func MustGetBaseConfig() *BaseOperatorConf {
 		if err := parseAndSetCustomerConfigReloadImageVersion(c); err != nil {
 			panic(err)
 		}
+		if c.NetworkPolicy.OperatorNamespace == "" {
+			c.NetworkPolicy.OperatorNamespace = getServiceAccountNamespace()
+		}
 		opConf = c
 	})
 	return opConf
 }
 
+// serviceAccountNamespaceFile is mounted by kubernetes into pods with service account token
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// getServiceAccountNamespace returns namespace of operator pod
+// or empty string if operator runs outside of kubernetes
+func getServiceAccountNamespace() string {
+	data, err := os.ReadFile(serviceAccountNamespaceFile)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
 var validNamespaceRegex = regexp.MustCompile(`[a-z0-9]([-a-z0-9]*[a-z0-9])?`)
 
 func getWatchNamespaces() ([]string, error) {
@@ -1,6 +1,8 @@
 package converter
 
 import (
+	"fmt"
+	"sort"
 	"strings"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
@@ -534,3 +536,390 @@ func FilterPrefixes(src map[string]string, filterPrefixes []string) map[string]s
 	}
 	return dst
 }
+
+// ConvertPrometheusCommonFields converts fields shared by Prometheus and PrometheusAgent into VMAgent spec
+func ConvertPrometheusCommonFields(prom *promv1.CommonPrometheusFields) vmv1beta1.VMAgentSpec {
+	spec := vmv1beta1.VMAgentSpec{
+		PodMetadata:              convertEmbeddedObjectMetadata(prom.PodMetadata),
+		LogLevel:                 convertLogLevel(prom.LogLevel),
+		LogFormat:                convertLogFormat(prom.LogFormat),
+		ScrapeInterval:           string(prom.ScrapeInterval),
+		ScrapeTimeout:            string(prom.ScrapeTimeout),
+		VMAgentExternalLabelName: prom.PrometheusExternalLabelName,
+		ExternalLabels:           prom.ExternalLabels,
+		RemoteWrite:              convertRemoteWrite(prom.RemoteWrite),
+		AdditionalScrapeConfigs:  prom.AdditionalScrapeConfigs,
+		CommonDefaultableParams: vmv1beta1.CommonDefaultableParams{
+			Resources: prom.Resources,
+		},
+		CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+			Affinity:                  prom.Affinity,
+			Tolerations:               prom.Tolerations,
+			TopologySpreadConstraints: convertTopologySpreadConstraints(prom.TopologySpreadConstraints),
+			NodeSelector:              prom.NodeSelector,
+			PriorityClassName:         prom.PriorityClassName,
+			ImagePullSecrets:          prom.ImagePullSecrets,
+			Secrets:                   prom.Secrets,
+			ConfigMaps:                prom.ConfigMaps,
+			Volumes:                   prom.Volumes,
+			VolumeMounts:              prom.VolumeMounts,
+			HostAliases:               convertHostAliases(prom.HostAliases),
+			ReplicaCount:              prom.Replicas,
+			Paused:                    prom.Paused,
+		},
+	}
+	spec.ServiceScrapeSelector, spec.ServiceScrapeNamespaceSelector = convertSelectors(prom.ServiceMonitorSelector, prom.ServiceMonitorNamespaceSelector)
+	spec.PodScrapeSelector, spec.PodScrapeNamespaceSelector = convertSelectors(prom.PodMonitorSelector, prom.PodMonitorNamespaceSelector)
+	spec.ProbeSelector, spec.ProbeNamespaceSelector = convertSelectors(prom.ProbeSelector, prom.ProbeNamespaceSelector)
+	spec.ScrapeConfigSelector, spec.ScrapeConfigNamespaceSelector = convertSelectors(prom.ScrapeConfigSelector, prom.ScrapeConfigNamespaceSelector)
+	if prom.SecurityContext != nil {
+		spec.SecurityContext = &vmv1beta1.SecurityContext{PodSecurityContext: prom.SecurityContext}
+	}
+	if prom.MinReadySeconds != nil {
+		spec.MinReadySeconds = int32(*prom.MinReadySeconds)
+	}
+	if prom.Shards != nil && *prom.Shards > 1 {
+		spec.ShardCount = ptr.To(int(*prom.Shards))
+	}
+	return spec
+}
+
+// ConvertPrometheus creates VMAgent and VMSingle from Prometheus
+//
+// VMSingle replaces local Prometheus storage, it's added as an additional remote write target for VMAgent
+func ConvertPrometheus(prom *promv1.Prometheus, conf *config.BaseOperatorConf) (*vmv1beta1.VMAgent, *vmv1beta1.VMSingle) {
+	objectMeta := metav1.ObjectMeta{
+		Name:        prom.Name,
+		Namespace:   prom.Namespace,
+		Labels:      FilterPrefixes(prom.Labels, conf.FilterPrometheusConverterLabelPrefixes),
+		Annotations: FilterPrefixes(prom.Annotations, conf.FilterPrometheusConverterAnnotationPrefixes),
+	}
+	vms := &vmv1beta1.VMSingle{
+		ObjectMeta: *objectMeta.DeepCopy(),
+		Spec: vmv1beta1.VMSingleSpec{
+			LogLevel:        convertLogLevel(prom.Spec.LogLevel),
+			LogFormat:       convertLogFormat(prom.Spec.LogFormat),
+			RetentionPeriod: string(prom.Spec.Retention),
+			CommonDefaultableParams: vmv1beta1.CommonDefaultableParams{
+				Resources: prom.Spec.Resources,
+			},
+			CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+				Affinity:          prom.Spec.Affinity,
+				Tolerations:       prom.Spec.Tolerations,
+				NodeSelector:      prom.Spec.NodeSelector,
+				PriorityClassName: prom.Spec.PriorityClassName,
+				ImagePullSecrets:  prom.Spec.ImagePullSecrets,
+				Paused:            prom.Spec.Paused,
+			},
+		},
+	}
+	if vms.Spec.RetentionPeriod == "" {
+		// matches prometheus-operator default retention
+		vms.Spec.RetentionPeriod = "1d"
+	}
+	if prom.Spec.Storage != nil && prom.Spec.Storage.EmptyDir == nil && prom.Spec.Storage.Ephemeral == nil {
+		vct := prom.Spec.Storage.VolumeClaimTemplate
+		vms.Spec.Storage = vct.Spec.DeepCopy()
+		vms.Spec.StorageMetadata = vmv1beta1.EmbeddedObjectMetadata{
+			Name:        vct.Name,
+			Labels:      vct.Labels,
+			Annotations: vct.Annotations,
+		}
+	}
+
+	vma := &vmv1beta1.VMAgent{
+		ObjectMeta: objectMeta,
+		Spec:       ConvertPrometheusCommonFields(&prom.Spec.CommonPrometheusFields),
+	}
+	vma.Spec.RemoteWrite = append(vma.Spec.RemoteWrite, vmv1beta1.VMAgentRemoteWriteSpec{
+		URL: vms.AsURL() + "/api/v1/write",
+	})
+
+	if conf.EnabledPrometheusConverterOwnerReferences {
+		ownerRefs := []metav1.OwnerReference{
+			{
+				APIVersion:         promv1.SchemeGroupVersion.String(),
+				Kind:               promv1.PrometheusesKind,
+				Name:               prom.Name,
+				UID:                prom.UID,
+				Controller:         ptr.To(true),
+				BlockOwnerDeletion: ptr.To(true),
+			},
+		}
+		vma.OwnerReferences = ownerRefs
+		vms.OwnerReferences = ownerRefs
+	}
+	vma.Annotations = MaybeAddArgoCDIgnoreAnnotations(conf.PrometheusConverterAddArgoCDIgnoreAnnotations, vma.Annotations)
+	vms.Annotations = MaybeAddArgoCDIgnoreAnnotations(conf.PrometheusConverterAddArgoCDIgnoreAnnotations, vms.Annotations)
+	return vma, vms
+}
+
+// ConvertAlertmanager creates VMAlertmanager from Alertmanager
+func ConvertAlertmanager(am *promv1.Alertmanager, conf *config.BaseOperatorConf) *vmv1beta1.VMAlertmanager {
+	cr := &vmv1beta1.VMAlertmanager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        am.Name,
+			Namespace:   am.Namespace,
+			Labels:      FilterPrefixes(am.Labels, conf.FilterPrometheusConverterLabelPrefixes),
+			Annotations: FilterPrefixes(am.Annotations, conf.FilterPrometheusConverterAnnotationPrefixes),
+		},
+		Spec: vmv1beta1.VMAlertmanagerSpec{
+			PodMetadata:             convertEmbeddedObjectMetadata(am.Spec.PodMetadata),
+			ConfigSecret:            am.Spec.ConfigSecret,
+			LogLevel:                am.Spec.LogLevel,
+			LogFormat:               am.Spec.LogFormat,
+			Retention:               string(am.Spec.Retention),
+			Storage:                 ConvertStorageSpec(am.Spec.Storage),
+			ExternalURL:             am.Spec.ExternalURL,
+			RoutePrefix:             am.Spec.RoutePrefix,
+			ListenLocal:             am.Spec.ListenLocal,
+			AdditionalPeers:         am.Spec.AdditionalPeers,
+			ClusterAdvertiseAddress: am.Spec.ClusterAdvertiseAddress,
+			PortName:                am.Spec.PortName,
+			DisableNamespaceMatcher: am.Spec.AlertmanagerConfigMatcherStrategy.Type == "None",
+			CommonDefaultableParams: vmv1beta1.CommonDefaultableParams{
+				Resources: am.Spec.Resources,
+			},
+			CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+				Affinity:                  am.Spec.Affinity,
+				Tolerations:               am.Spec.Tolerations,
+				TopologySpreadConstraints: am.Spec.TopologySpreadConstraints,
+				NodeSelector:              am.Spec.NodeSelector,
+				PriorityClassName:         am.Spec.PriorityClassName,
+				ImagePullSecrets:          am.Spec.ImagePullSecrets,
+				Secrets:                   am.Spec.Secrets,
+				ConfigMaps:                am.Spec.ConfigMaps,
+				Volumes:                   am.Spec.Volumes,
+				VolumeMounts:              am.Spec.VolumeMounts,
+				HostAliases:               convertHostAliases(am.Spec.HostAliases),
+				ReplicaCount:              am.Spec.Replicas,
+				Paused:                    am.Spec.Paused,
+			},
+		},
+	}
+	cr.Spec.ConfigSelector, cr.Spec.ConfigNamespaceSelector = convertSelectors(am.Spec.AlertmanagerConfigSelector, am.Spec.AlertmanagerConfigNamespaceSelector)
+	if am.Spec.SecurityContext != nil {
+		cr.Spec.SecurityContext = &vmv1beta1.SecurityContext{PodSecurityContext: am.Spec.SecurityContext}
+	}
+	if am.Spec.MinReadySeconds != nil {
+		cr.Spec.MinReadySeconds = int32(*am.Spec.MinReadySeconds)
+	}
+	if conf.EnabledPrometheusConverterOwnerReferences {
+		cr.OwnerReferences = []metav1.OwnerReference{
+			{
+				APIVersion:         promv1.SchemeGroupVersion.String(),
+				Kind:               promv1.AlertmanagersKind,
+				Name:               am.Name,
+				UID:                am.UID,
+				Controller:         ptr.To(true),
+				BlockOwnerDeletion: ptr.To(true),
+			},
+		}
+	}
+	cr.Annotations = MaybeAddArgoCDIgnoreAnnotations(conf.PrometheusConverterAddArgoCDIgnoreAnnotations, cr.Annotations)
+	return cr
+}
+
+// ConvertThanosRuler creates VMAlert from ThanosRuler
+//
+// The first of queryEndpoints is used as datasource.
+// If alertmanagersUrl is not defined, VMAlert is started with -notifier.blackhole.
+func ConvertThanosRuler(tr *promv1.ThanosRuler, conf *config.BaseOperatorConf) *vmv1beta1.VMAlert {
+	cr := &vmv1beta1.VMAlert{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        tr.Name,
+			Namespace:   tr.Namespace,
+			Labels:      FilterPrefixes(tr.Labels, conf.FilterPrometheusConverterLabelPrefixes),
+			Annotations: FilterPrefixes(tr.Annotations, conf.FilterPrometheusConverterAnnotationPrefixes),
+		},
+		Spec: vmv1beta1.VMAlertSpec{
+			PodMetadata:            convertEmbeddedObjectMetadata(tr.Spec.PodMetadata),
+			LogLevel:               convertLogLevel(tr.Spec.LogLevel),
+			LogFormat:              convertLogFormat(tr.Spec.LogFormat),
+			EvaluationInterval:     string(tr.Spec.EvaluationInterval),
+			EnforcedNamespaceLabel: tr.Spec.EnforcedNamespaceLabel,
+			ExternalLabels:         tr.Spec.Labels,
+			CommonDefaultableParams: vmv1beta1.CommonDefaultableParams{
+				Resources: tr.Spec.Resources,
+			},
+			CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+				Affinity:                  tr.Spec.Affinity,
+				Tolerations:               tr.Spec.Tolerations,
+				TopologySpreadConstraints: tr.Spec.TopologySpreadConstraints,
+				NodeSelector:              tr.Spec.NodeSelector,
+				PriorityClassName:         tr.Spec.PriorityClassName,
+				ImagePullSecrets:          tr.Spec.ImagePullSecrets,
+				Volumes:                   tr.Spec.Volumes,
+				VolumeMounts:              tr.Spec.VolumeMounts,
+				HostAliases:               convertHostAliases(tr.Spec.HostAliases),
+				ReplicaCount:              tr.Spec.Replicas,
+				Paused:                    tr.Spec.Paused,
+			},
+		},
+	}
+	cr.Spec.RuleSelector, cr.Spec.RuleNamespaceSelector = convertSelectors(tr.Spec.RuleSelector, tr.Spec.RuleNamespaceSelector)
+	if len(tr.Spec.QueryEndpoints) > 0 {
+		cr.Spec.Datasource.URL = convertThanosEndpoint(tr.Spec.QueryEndpoints[0])
+	}
+	for _, url := range tr.Spec.AlertManagersURL {
+		cr.Spec.Notifiers = append(cr.Spec.Notifiers, vmv1beta1.VMAlertNotifierSpec{URL: convertThanosEndpoint(url)})
+	}
+	if len(cr.Spec.Notifiers) == 0 {
+		cr.Spec.ExtraArgs = map[string]string{"notifier.blackhole": "true"}
+	}
+	if tr.Spec.SecurityContext != nil {
+		cr.Spec.SecurityContext = &vmv1beta1.SecurityContext{PodSecurityContext: tr.Spec.SecurityContext}
+	}
+	if tr.Spec.MinReadySeconds != nil {
+		cr.Spec.MinReadySeconds = int32(*tr.Spec.MinReadySeconds)
+	}
+	if conf.EnabledPrometheusConverterOwnerReferences {
+		cr.OwnerReferences = []metav1.OwnerReference{
+			{
+				APIVersion:         promv1.SchemeGroupVersion.String(),
+				Kind:               promv1.ThanosRulerKind,
+				Name:               tr.Name,
+				UID:                tr.UID,
+				Controller:         ptr.To(true),
+				BlockOwnerDeletion: ptr.To(true),
+			},
+		}
+	}
+	cr.Annotations = MaybeAddArgoCDIgnoreAnnotations(conf.PrometheusConverterAddArgoCDIgnoreAnnotations, cr.Annotations)
+	return cr
+}
+
+// ConvertStorageSpec converts prometheus storage spec to VM one
+// Ephemeral volumes are not supported and replaced with emptyDir
+func ConvertStorageSpec(storage *promv1.StorageSpec) *vmv1beta1.StorageSpec {
+	if storage == nil {
+		return nil
+	}
+	if storage.Ephemeral != nil && storage.EmptyDir == nil {
+		return &vmv1beta1.StorageSpec{EmptyDir: &corev1.EmptyDirVolumeSource{}}
+	}
+	vct := storage.VolumeClaimTemplate
+	return &vmv1beta1.StorageSpec{
+		// Unless prometheus deletes DisableMountSubPath, we have to support it for backward compatibility
+		//nolint:staticcheck
+		DisableMountSubPath: storage.DisableMountSubPath,
+		EmptyDir:            storage.EmptyDir,
+		VolumeClaimTemplate: vmv1beta1.EmbeddedPersistentVolumeClaim{
+			TypeMeta: vct.TypeMeta,
+			EmbeddedObjectMetadata: vmv1beta1.EmbeddedObjectMetadata{
+				Name:        vct.Name,
+				Labels:      vct.Labels,
+				Annotations: vct.Annotations,
+			},
+			Spec: vct.Spec,
+		},
+	}
+}
+
+func convertRemoteWrite(promRWs []promv1.RemoteWriteSpec) []vmv1beta1.VMAgentRemoteWriteSpec {
+	if len(promRWs) == 0 {
+		return nil
+	}
+	rws := make([]vmv1beta1.VMAgentRemoteWriteSpec, 0, len(promRWs))
+	for _, promRW := range promRWs {
+		rw := vmv1beta1.VMAgentRemoteWriteSpec{
+			URL:       promRW.URL,
+			BasicAuth: ConvertBasicAuth(promRW.BasicAuth),
+			OAuth2:    ConvertOAuth(promRW.OAuth2),
+			TLSConfig: ConvertTLSConfig(promRW.TLSConfig),
+		}
+		if promRW.RemoteTimeout != "" {
+			rw.SendTimeout = ptr.To(string(promRW.RemoteTimeout))
+		}
+		if promRW.Authorization != nil && (promRW.Authorization.Type == "" || strings.EqualFold(promRW.Authorization.Type, "Bearer")) {
+			rw.BearerTokenSecret = convertBearerToken(promRW.Authorization.Credentials)
+		}
+		headerNames := make([]string, 0, len(promRW.Headers))
+		for name := range promRW.Headers {
+			headerNames = append(headerNames, name)
+		}
+		sort.Strings(headerNames)
+		for _, name := range headerNames {
+			rw.Headers = append(rw.Headers, fmt.Sprintf("%s: %s", name, promRW.Headers[name]))
+		}
+		for _, rc := range ConvertRelabelConfig(promRW.WriteRelabelConfigs) {
+			rw.InlineUrlRelabelConfig = append(rw.InlineUrlRelabelConfig, *rc)
+		}
+		rws = append(rws, rw)
+	}
+	return rws
+}
+
+// convertSelectors converts prometheus-operator object and namespace selectors pair
+// prometheus-operator doesn't select any object if object selector is not defined,
+// while operator selects all objects at namespaces matched by namespace selector.
+func convertSelectors(objectSelector, namespaceSelector *metav1.LabelSelector) (*metav1.LabelSelector, *metav1.LabelSelector) {
+	if objectSelector == nil {
+		return nil, nil
+	}
+	return objectSelector, namespaceSelector
+}
+
+func convertEmbeddedObjectMetadata(meta *promv1.EmbeddedObjectMetadata) *vmv1beta1.EmbeddedObjectMetadata {
+	if meta == nil {
+		return nil
+	}
+	return &vmv1beta1.EmbeddedObjectMetadata{
+		Name:        meta.Name,
+		Labels:      meta.Labels,
+		Annotations: meta.Annotations,
+	}
+}
+
+func convertTopologySpreadConstraints(promTSCs []promv1.TopologySpreadConstraint) []corev1.TopologySpreadConstraint {
+	if len(promTSCs) == 0 {
+		return nil
+	}
+	tscs := make([]corev1.TopologySpreadConstraint, 0, len(promTSCs))
+	for _, tsc := range promTSCs {
+		tscs = append(tscs, corev1.TopologySpreadConstraint(tsc.CoreV1TopologySpreadConstraint))
+	}
+	return tscs
+}
+
+func convertHostAliases(promHAs []promv1.HostAlias) []corev1.HostAlias {
+	if len(promHAs) == 0 {
+		return nil
+	}
+	has := make([]corev1.HostAlias, 0, len(promHAs))
+	for _, ha := range promHAs {
+		has = append(has, corev1.HostAlias{IP: ha.IP, Hostnames: ha.Hostnames})
+	}
+	return has
+}
+
+// convertLogLevel converts prometheus log level to VictoriaMetrics one
+// VictoriaMetrics doesn't have debug level, it's replaced with INFO
+func convertLogLevel(level string) string {
+	switch strings.ToLower(level) {
+	case "debug", "info":
+		return "INFO"
+	case "warn":
+		return "WARN"
+	case "error":
+		return "ERROR"
+	}
+	return ""
+}
+
+// convertLogFormat converts prometheus log format to VictoriaMetrics one
+func convertLogFormat(format string) string {
+	if format == "json" {
+		return format
+	}
+	return ""
+}
+
+// convertThanosEndpoint removes thanos DNS discovery prefix and adds missing scheme
+func convertThanosEndpoint(endpoint string) string {
+	endpoint = strings.TrimPrefix(endpoint, "dns+")
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+	return endpoint
+}
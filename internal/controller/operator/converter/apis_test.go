@@ -6,6 +6,7 @@ import (
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/google/go-cmp/cmp"
 	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -519,3 +520,280 @@ func TestConvertProbe(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertPrometheus(t *testing.T) {
+	type args struct {
+		prom     *promv1.Prometheus
+		ownerRef bool
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantAgent  vmv1beta1.VMAgent
+		wantSingle vmv1beta1.VMSingle
+	}{
+		{
+			name: "with remote write and storage",
+			args: args{
+				prom: &promv1.Prometheus{
+					ObjectMeta: metav1.ObjectMeta{Name: "k8s", Namespace: "monitoring"},
+					Spec: promv1.PrometheusSpec{
+						CommonPrometheusFields: promv1.CommonPrometheusFields{
+							ServiceMonitorSelector:          &metav1.LabelSelector{MatchLabels: map[string]string{"release": "stack"}},
+							ServiceMonitorNamespaceSelector: &metav1.LabelSelector{},
+							PodMonitorNamespaceSelector:     &metav1.LabelSelector{},
+							ExternalLabels:                  map[string]string{"cluster": "main"},
+							Shards:                          ptr.To(int32(2)),
+							Replicas:                        ptr.To(int32(1)),
+							LogLevel:                        "debug",
+							RemoteWrite: []promv1.RemoteWriteSpec{
+								{
+									URL:           "http://remote:8428/api/v1/write",
+									RemoteTimeout: "10s",
+									Headers:       map[string]string{"X-Scope": "1", "X-Org": "2"},
+									Authorization: &promv1.Authorization{
+										SafeAuthorization: promv1.SafeAuthorization{
+											Credentials: &corev1.SecretKeySelector{Key: "token"},
+										},
+									},
+									WriteRelabelConfigs: []promv1.RelabelConfig{
+										{Action: "drop", SourceLabels: []promv1.LabelName{"__name__"}, Regex: "go_.*"},
+									},
+								},
+							},
+							Storage: &promv1.StorageSpec{
+								VolumeClaimTemplate: promv1.EmbeddedPersistentVolumeClaim{
+									Spec: corev1.PersistentVolumeClaimSpec{StorageClassName: ptr.To("ssd")},
+								},
+							},
+						},
+						Retention: "30d",
+					},
+				},
+			},
+			wantAgent: vmv1beta1.VMAgent{
+				ObjectMeta: metav1.ObjectMeta{Name: "k8s", Namespace: "monitoring"},
+				Spec: vmv1beta1.VMAgentSpec{
+					LogLevel:                       "INFO",
+					ExternalLabels:                 map[string]string{"cluster": "main"},
+					ShardCount:                     ptr.To(2),
+					ServiceScrapeSelector:          &metav1.LabelSelector{MatchLabels: map[string]string{"release": "stack"}},
+					ServiceScrapeNamespaceSelector: &metav1.LabelSelector{},
+					RemoteWrite: []vmv1beta1.VMAgentRemoteWriteSpec{
+						{
+							URL:               "http://remote:8428/api/v1/write",
+							SendTimeout:       ptr.To("10s"),
+							Headers:           []string{"X-Org: 2", "X-Scope: 1"},
+							BearerTokenSecret: &corev1.SecretKeySelector{Key: "token"},
+							InlineUrlRelabelConfig: []vmv1beta1.RelabelConfig{
+								{Action: "drop", SourceLabels: []string{"__name__"}, Regex: vmv1beta1.StringOrArray{"go_.*"}},
+							},
+						},
+						{
+							URL: "http://vmsingle-k8s.monitoring.svc:8429/api/v1/write",
+						},
+					},
+					CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+						ReplicaCount: ptr.To(int32(1)),
+					},
+				},
+			},
+			wantSingle: vmv1beta1.VMSingle{
+				ObjectMeta: metav1.ObjectMeta{Name: "k8s", Namespace: "monitoring"},
+				Spec: vmv1beta1.VMSingleSpec{
+					LogLevel:        "INFO",
+					RetentionPeriod: "30d",
+					Storage:         &corev1.PersistentVolumeClaimSpec{StorageClassName: ptr.To("ssd")},
+				},
+			},
+		},
+		{
+			name: "with owner reference",
+			args: args{
+				prom: &promv1.Prometheus{
+					ObjectMeta: metav1.ObjectMeta{Name: "k8s", Namespace: "monitoring", UID: "42"},
+				},
+				ownerRef: true,
+			},
+			wantAgent: vmv1beta1.VMAgent{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "k8s",
+					Namespace: "monitoring",
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion:         "monitoring.coreos.com/v1",
+							Kind:               "Prometheus",
+							Name:               "k8s",
+							UID:                "42",
+							Controller:         ptr.To(true),
+							BlockOwnerDeletion: ptr.To(true),
+						},
+					},
+				},
+				Spec: vmv1beta1.VMAgentSpec{
+					RemoteWrite: []vmv1beta1.VMAgentRemoteWriteSpec{
+						{
+							URL: "http://vmsingle-k8s.monitoring.svc:8429/api/v1/write",
+						},
+					},
+				},
+			},
+			wantSingle: vmv1beta1.VMSingle{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "k8s",
+					Namespace: "monitoring",
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion:         "monitoring.coreos.com/v1",
+							Kind:               "Prometheus",
+							Name:               "k8s",
+							UID:                "42",
+							Controller:         ptr.To(true),
+							BlockOwnerDeletion: ptr.To(true),
+						},
+					},
+				},
+				Spec: vmv1beta1.VMSingleSpec{
+					RetentionPeriod: "1d",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotAgent, gotSingle := ConvertPrometheus(tt.args.prom, &config.BaseOperatorConf{EnabledPrometheusConverterOwnerReferences: tt.args.ownerRef})
+			if !cmp.Equal(*gotAgent, tt.wantAgent) {
+				t.Fatalf("unexpected vmagent, diff: %s", cmp.Diff(*gotAgent, tt.wantAgent))
+			}
+			if !cmp.Equal(*gotSingle, tt.wantSingle) {
+				t.Fatalf("unexpected vmsingle, diff: %s", cmp.Diff(*gotSingle, tt.wantSingle))
+			}
+		})
+	}
+}
+
+func TestConvertAlertmanager(t *testing.T) {
+	type args struct {
+		am *promv1.Alertmanager
+	}
+	tests := []struct {
+		name string
+		args args
+		want vmv1beta1.VMAlertmanager
+	}{
+		{
+			name: "with config selector and storage",
+			args: args{
+				am: &promv1.Alertmanager{
+					ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "monitoring"},
+					Spec: promv1.AlertmanagerSpec{
+						Replicas:                            ptr.To(int32(3)),
+						ConfigSecret:                        "am-config",
+						Retention:                           "120h",
+						AlertmanagerConfigNamespaceSelector: &metav1.LabelSelector{},
+						AlertmanagerConfigMatcherStrategy:   promv1.AlertmanagerConfigMatcherStrategy{Type: "None"},
+						Storage: &promv1.StorageSpec{
+							VolumeClaimTemplate: promv1.EmbeddedPersistentVolumeClaim{
+								Spec: corev1.PersistentVolumeClaimSpec{StorageClassName: ptr.To("ssd")},
+							},
+						},
+						MinReadySeconds: ptr.To(uint32(10)),
+					},
+				},
+			},
+			want: vmv1beta1.VMAlertmanager{
+				ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "monitoring"},
+				Spec: vmv1beta1.VMAlertmanagerSpec{
+					ConfigSecret:            "am-config",
+					Retention:               "120h",
+					DisableNamespaceMatcher: true,
+					Storage: &vmv1beta1.StorageSpec{
+						VolumeClaimTemplate: vmv1beta1.EmbeddedPersistentVolumeClaim{
+							Spec: corev1.PersistentVolumeClaimSpec{StorageClassName: ptr.To("ssd")},
+						},
+					},
+					CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+						ReplicaCount:    ptr.To(int32(3)),
+						MinReadySeconds: 10,
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ConvertAlertmanager(tt.args.am, &config.BaseOperatorConf{})
+			if !cmp.Equal(*got, tt.want) {
+				t.Fatalf("unexpected vmalertmanager, diff: %s", cmp.Diff(*got, tt.want))
+			}
+		})
+	}
+}
+
+func TestConvertThanosRuler(t *testing.T) {
+	type args struct {
+		tr *promv1.ThanosRuler
+	}
+	tests := []struct {
+		name string
+		args args
+		want vmv1beta1.VMAlert
+	}{
+		{
+			name: "with query endpoints and alertmanagers",
+			args: args{
+				tr: &promv1.ThanosRuler{
+					ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "monitoring"},
+					Spec: promv1.ThanosRulerSpec{
+						QueryEndpoints:     []string{"dns+thanos-query:9090", "thanos-query-2:9090"},
+						AlertManagersURL:   []string{"http://alertmanager:9093"},
+						RuleSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"role": "rules"}},
+						EvaluationInterval: "30s",
+						Labels:             map[string]string{"ruler": "thanos"},
+						LogLevel:           "warn",
+					},
+				},
+			},
+			want: vmv1beta1.VMAlert{
+				ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "monitoring"},
+				Spec: vmv1beta1.VMAlertSpec{
+					LogLevel:           "WARN",
+					EvaluationInterval: "30s",
+					RuleSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"role": "rules"}},
+					ExternalLabels:     map[string]string{"ruler": "thanos"},
+					Datasource:         vmv1beta1.VMAlertDatasourceSpec{URL: "http://thanos-query:9090"},
+					Notifiers:          []vmv1beta1.VMAlertNotifierSpec{{URL: "http://alertmanager:9093"}},
+				},
+			},
+		},
+		{
+			name: "without alertmanagers",
+			args: args{
+				tr: &promv1.ThanosRuler{
+					ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "monitoring"},
+					Spec: promv1.ThanosRulerSpec{
+						QueryEndpoints:        []string{"http://thanos-query:9090"},
+						RuleNamespaceSelector: &metav1.LabelSelector{},
+					},
+				},
+			},
+			want: vmv1beta1.VMAlert{
+				ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "monitoring"},
+				Spec: vmv1beta1.VMAlertSpec{
+					Datasource: vmv1beta1.VMAlertDatasourceSpec{URL: "http://thanos-query:9090"},
+					CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+						ExtraArgs: map[string]string{"notifier.blackhole": "true"},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ConvertThanosRuler(tt.args.tr, &config.BaseOperatorConf{})
+			if !cmp.Equal(*got, tt.want) {
+				t.Fatalf("unexpected vmalert, diff: %s", cmp.Diff(*got, tt.want))
+			}
+		})
+	}
+}
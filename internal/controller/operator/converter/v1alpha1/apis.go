@@ -166,6 +166,38 @@ func ConvertScrapeConfig(promscrapeConfig *promv1alpha1.ScrapeConfig, conf *conf
 	return cs
 }
 
+// ConvertPrometheusAgent creates VMAgent from PrometheusAgent
+// PrometheusAgent storage is used as VMAgent persistent queue storage
+func ConvertPrometheusAgent(promAgent *promv1alpha1.PrometheusAgent, conf *config.BaseOperatorConf) *vmv1beta1.VMAgent {
+	cr := &vmv1beta1.VMAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        promAgent.Name,
+			Namespace:   promAgent.Namespace,
+			Labels:      converter.FilterPrefixes(promAgent.Labels, conf.FilterPrometheusConverterLabelPrefixes),
+			Annotations: converter.FilterPrefixes(promAgent.Annotations, conf.FilterPrometheusConverterAnnotationPrefixes),
+		},
+		Spec: converter.ConvertPrometheusCommonFields(&promAgent.Spec.CommonPrometheusFields),
+	}
+	if promAgent.Spec.Storage != nil {
+		cr.Spec.StatefulMode = true
+		cr.Spec.StatefulStorage = converter.ConvertStorageSpec(promAgent.Spec.Storage)
+	}
+	if conf.EnabledPrometheusConverterOwnerReferences {
+		cr.OwnerReferences = []metav1.OwnerReference{
+			{
+				APIVersion:         promv1alpha1.SchemeGroupVersion.String(),
+				Kind:               promv1alpha1.PrometheusAgentsKind,
+				Name:               promAgent.Name,
+				UID:                promAgent.UID,
+				Controller:         ptr.To(true),
+				BlockOwnerDeletion: ptr.To(true),
+			},
+		}
+	}
+	cr.Annotations = converter.MaybeAddArgoCDIgnoreAnnotations(conf.PrometheusConverterAddArgoCDIgnoreAnnotations, cr.Annotations)
+	return cr
+}
+
 func convertKVToMap(src []promv1alpha1.KeyValue) map[string]string {
 	if len(src) == 0 {
 		return nil
@@ -469,6 +501,8 @@ func convertReceivers(promReceivers []promv1alpha1.Receiver) []vmv1beta1.Receive
 			}
 			dst.WebexConfigs = append(dst.WebexConfigs, vo)
 		}
+		// jira, rocketchat, msteamsv2 and incidentio receivers with telegram message_thread_id
+		// are missing at the supported prometheus-operator API version and cannot be converted yet
 
 		vmReceivers = append(vmReceivers, dst)
 	}
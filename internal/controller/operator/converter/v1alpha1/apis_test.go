@@ -343,3 +343,77 @@ func TestConvertScrapeConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertPrometheusAgent(t *testing.T) {
+	type args struct {
+		promAgent *promv1alpha1.PrometheusAgent
+	}
+	tests := []struct {
+		name string
+		args args
+		want vmv1beta1.VMAgent
+	}{
+		{
+			name: "with storage and selectors",
+			args: args{
+				promAgent: &promv1alpha1.PrometheusAgent{
+					ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "monitoring"},
+					Spec: promv1alpha1.PrometheusAgentSpec{
+						CommonPrometheusFields: promv1.CommonPrometheusFields{
+							PodMonitorSelector:            &metav1.LabelSelector{},
+							ProbeNamespaceSelector:        &metav1.LabelSelector{},
+							ScrapeConfigSelector:          &metav1.LabelSelector{MatchLabels: map[string]string{"app": "agent"}},
+							ScrapeConfigNamespaceSelector: &metav1.LabelSelector{},
+							ExternalLabels:                map[string]string{"cluster": "edge"},
+							ScrapeInterval:                "30s",
+							Shards:                        ptr.To(int32(1)),
+							RemoteWrite: []promv1.RemoteWriteSpec{
+								{
+									URL: "http://vminsert:8480/insert/0/prometheus/api/v1/write",
+									BasicAuth: &promv1.BasicAuth{
+										Username: corev1.SecretKeySelector{Key: "user"},
+										Password: corev1.SecretKeySelector{Key: "password"},
+									},
+								},
+							},
+							Storage: &promv1.StorageSpec{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+				},
+			},
+			want: vmv1beta1.VMAgent{
+				ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "monitoring"},
+				Spec: vmv1beta1.VMAgentSpec{
+					ScrapeInterval:                "30s",
+					ExternalLabels:                map[string]string{"cluster": "edge"},
+					PodScrapeSelector:             &metav1.LabelSelector{},
+					ScrapeConfigSelector:          &metav1.LabelSelector{MatchLabels: map[string]string{"app": "agent"}},
+					ScrapeConfigNamespaceSelector: &metav1.LabelSelector{},
+					RemoteWrite: []vmv1beta1.VMAgentRemoteWriteSpec{
+						{
+							URL: "http://vminsert:8480/insert/0/prometheus/api/v1/write",
+							BasicAuth: &vmv1beta1.BasicAuth{
+								Username: corev1.SecretKeySelector{Key: "user"},
+								Password: corev1.SecretKeySelector{Key: "password"},
+							},
+						},
+					},
+					StatefulMode: true,
+					StatefulStorage: &vmv1beta1.StorageSpec{
+						EmptyDir: &corev1.EmptyDirVolumeSource{},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ConvertPrometheusAgent(tt.args.promAgent, &config.BaseOperatorConf{})
+			if !cmp.Equal(*got, tt.want) {
+				t.Fatalf("unexpected vmagent, diff: %s", cmp.Diff(*got, tt.want))
+			}
+		})
+	}
+}
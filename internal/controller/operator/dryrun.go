@@ -0,0 +1,154 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/alertmanager"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vlcluster"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vlogs"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmagent"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmalert"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmanomaly"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmauth"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmcluster"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmsingle"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type dryRunObject struct {
+	newObject func() client.Object
+	newList   func() client.ObjectList
+	reconcile func(ctx context.Context, rclient client.Client, obj client.Object) error
+}
+
+// dryRunObjects contains objects, which child objects could be rendered without applying
+// reconcile functions must perform the same factory calls as object controller
+var dryRunObjects = map[string]dryRunObject{
+	"VMCluster": {
+		newObject: func() client.Object { return &vmv1beta1.VMCluster{} },
+		newList:   func() client.ObjectList { return &vmv1beta1.VMClusterList{} },
+		reconcile: func(ctx context.Context, rclient client.Client, obj client.Object) error {
+			return vmcluster.CreateOrUpdateVMCluster(ctx, obj.(*vmv1beta1.VMCluster), rclient)
+		},
+	},
+	"VMAgent": {
+		newObject: func() client.Object { return &vmv1beta1.VMAgent{} },
+		newList:   func() client.ObjectList { return &vmv1beta1.VMAgentList{} },
+		reconcile: func(ctx context.Context, rclient client.Client, obj client.Object) error {
+			return vmagent.CreateOrUpdateVMAgent(ctx, obj.(*vmv1beta1.VMAgent), rclient)
+		},
+	},
+	"VMAuth": {
+		newObject: func() client.Object { return &vmv1beta1.VMAuth{} },
+		newList:   func() client.ObjectList { return &vmv1beta1.VMAuthList{} },
+		reconcile: func(ctx context.Context, rclient client.Client, obj client.Object) error {
+			return vmauth.CreateOrUpdateVMAuth(ctx, obj.(*vmv1beta1.VMAuth), rclient)
+		},
+	},
+	"VMSingle": {
+		newObject: func() client.Object { return &vmv1beta1.VMSingle{} },
+		newList:   func() client.ObjectList { return &vmv1beta1.VMSingleList{} },
+		reconcile: func(ctx context.Context, rclient client.Client, obj client.Object) error {
+			cr := obj.(*vmv1beta1.VMSingle)
+			if err := vmsingle.CreateOrUpdateVMSingleStreamAggrConfig(ctx, cr, rclient); err != nil {
+				return err
+			}
+			return vmsingle.CreateOrUpdateVMSingle(ctx, cr, rclient)
+		},
+	},
+	"VMAlert": {
+		newObject: func() client.Object { return &vmv1beta1.VMAlert{} },
+		newList:   func() client.ObjectList { return &vmv1beta1.VMAlertList{} },
+		reconcile: func(ctx context.Context, rclient client.Client, obj client.Object) error {
+			cr := obj.(*vmv1beta1.VMAlert)
+			maps, err := vmalert.CreateOrUpdateRuleConfigMaps(ctx, cr, rclient)
+			if err != nil {
+				return err
+			}
+			return vmalert.CreateOrUpdateVMAlert(ctx, cr, rclient, maps)
+		},
+	},
+	"VMAlertmanager": {
+		newObject: func() client.Object { return &vmv1beta1.VMAlertmanager{} },
+		newList:   func() client.ObjectList { return &vmv1beta1.VMAlertmanagerList{} },
+		reconcile: func(ctx context.Context, rclient client.Client, obj client.Object) error {
+			cr := obj.(*vmv1beta1.VMAlertmanager)
+			if err := alertmanager.CreateAMConfig(ctx, cr, rclient); err != nil {
+				return err
+			}
+			return alertmanager.CreateOrUpdateAlertManager(ctx, cr, rclient)
+		},
+	},
+	"VLogs": {
+		newObject: func() client.Object { return &vmv1beta1.VLogs{} },
+		newList:   func() client.ObjectList { return &vmv1beta1.VLogsList{} },
+		reconcile: func(ctx context.Context, rclient client.Client, obj client.Object) error {
+			cr := obj.(*vmv1beta1.VLogs)
+			if cr.Spec.Storage != nil && cr.Spec.StorageDataPath == "" {
+				if err := vlogs.CreateVLogsStorage(ctx, cr, rclient); err != nil {
+					return err
+				}
+			}
+			return vlogs.CreateOrUpdateVLogs(ctx, cr, rclient)
+		},
+	},
+	"VLCluster": {
+		newObject: func() client.Object { return &vmv1beta1.VLCluster{} },
+		newList:   func() client.ObjectList { return &vmv1beta1.VLClusterList{} },
+		reconcile: func(ctx context.Context, rclient client.Client, obj client.Object) error {
+			return vlcluster.CreateOrUpdateVLCluster(ctx, obj.(*vmv1beta1.VLCluster), rclient)
+		},
+	},
+	"VMAnomaly": {
+		newObject: func() client.Object { return &vmv1beta1.VMAnomaly{} },
+		newList:   func() client.ObjectList { return &vmv1beta1.VMAnomalyList{} },
+		reconcile: func(ctx context.Context, rclient client.Client, obj client.Object) error {
+			return vmanomaly.CreateOrUpdateVMAnomaly(ctx, obj.(*vmv1beta1.VMAnomaly), rclient)
+		},
+	},
+}
+
+// DryRunKinds returns sorted list of object kinds supported by dry-run mode
+func DryRunKinds() []string {
+	kinds := make([]string, 0, len(dryRunObjects))
+	for kind := range dryRunObjects {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// NewDryRunList returns an empty list for given kind
+func NewDryRunList(kind string) (client.ObjectList, error) {
+	dro, ok := dryRunObjects[kind]
+	if !ok {
+		return nil, fmt.Errorf("kind=%q is not supported by dry-run, supported kinds: %s", kind, DryRunKinds())
+	}
+	return dro.newList(), nil
+}
+
+// NewDryRunObject returns an empty object for given kind
+func NewDryRunObject(kind string) (client.Object, error) {
+	dro, ok := dryRunObjects[kind]
+	if !ok {
+		return nil, fmt.Errorf("kind=%q is not supported by dry-run, supported kinds: %s", kind, DryRunKinds())
+	}
+	return dro.newObject(), nil
+}
+
+// ReconcileDryRun performs factory calls of object controller for given object
+// rclient must not apply any changes, it's usually k8stools.DryRunClient
+func ReconcileDryRun(ctx context.Context, rclient client.Client, kind string, obj client.Object) error {
+	dro, ok := dryRunObjects[kind]
+	if !ok {
+		return fmt.Errorf("kind=%q is not supported by dry-run, supported kinds: %s", kind, DryRunKinds())
+	}
+	rclient.Scheme().Default(obj)
+	if err := dro.reconcile(ctx, rclient, obj); err != nil {
+		return fmt.Errorf("cannot reconcile %s=%s/%s: %w", kind, obj.GetNamespace(), obj.GetName(), err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+)
+
+func TestReconcileDryRun(t *testing.T) {
+	f := func(kind string, cr client.Object, wantChanges map[string]k8stools.ChangeAction, predefinedObjects ...runtime.Object) {
+		t.Helper()
+		origin := k8stools.GetTestClientWithObjects(append(predefinedObjects, cr))
+		build.AddDefaults(origin.Scheme())
+		obj, err := NewDryRunObject(kind)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		ctx := context.Background()
+		if err := origin.Get(ctx, client.ObjectKeyFromObject(cr), obj); err != nil {
+			t.Fatalf("cannot get object: %s", err)
+		}
+		drc := k8stools.NewDryRunClient(origin)
+		if err := ReconcileDryRun(ctx, drc, kind, obj); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		changes, err := drc.Changes(ctx)
+		if err != nil {
+			t.Fatalf("cannot get changes: %s", err)
+		}
+		got := make(map[string]k8stools.ChangeAction)
+		for _, change := range changes {
+			got[change.GVK.Kind+"/"+change.Key.Name] = change.Action
+		}
+		for name, action := range wantChanges {
+			if got[name] != action {
+				t.Fatalf("unexpected action for %s, got: %q, want: %q, all changes: %v", name, got[name], action, got)
+			}
+		}
+		for _, po := range predefinedObjects {
+			obj := po.(client.Object)
+			originObj := obj.DeepCopyObject().(client.Object)
+			if err := origin.Get(ctx, client.ObjectKeyFromObject(obj), originObj); err != nil {
+				t.Fatalf("cannot get origin object: %s", err)
+			}
+			if originObj.GetResourceVersion() != "999" {
+				t.Fatalf("origin object %s must not be changed", client.ObjectKeyFromObject(obj))
+			}
+		}
+	}
+	cluster := &vmv1beta1.VMCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+		Spec: vmv1beta1.VMClusterSpec{
+			RetentionPeriod: "1",
+			VMStorage: &vmv1beta1.VMStorage{
+				CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{ReplicaCount: ptr.To(int32(2))},
+			},
+			VMSelect: &vmv1beta1.VMSelect{
+				CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{ReplicaCount: ptr.To(int32(1))},
+			},
+			VMInsert: &vmv1beta1.VMInsert{
+				CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{ReplicaCount: ptr.To(int32(1))},
+			},
+		},
+	}
+
+	// changes of the running cluster must be rendered without waiting for rollout
+	f("VMCluster", cluster, map[string]k8stools.ChangeAction{
+		"StatefulSet/vmstorage-main": k8stools.ChangeActionUpdate,
+		"StatefulSet/vmselect-main":  k8stools.ChangeActionCreate,
+		"Deployment/vminsert-main":   k8stools.ChangeActionCreate,
+		"Service/vminsert-main":      k8stools.ChangeActionCreate,
+	}, &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "vmstorage-main", Namespace: "default"},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: ptr.To(int32(2)),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "vmstorage"}},
+		},
+	})
+}
@@ -11,6 +11,9 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
@@ -18,7 +21,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
-const templatesDir = "/etc/vm/templates"
+const (
+	templatesDir    = "/etc/vm/templates"
+	clusterMeshPort = 9094
+)
 
 var badConfigsTotal = prometheus.NewCounter(prometheus.CounterOpts{
 	Name: "operator_alertmanager_bad_objects_count",
@@ -52,12 +58,26 @@ func CreateOrUpdateAlertManager(ctx context.Context, cr *vmv1beta1.VMAlertmanage
 	if err != nil {
 		return err
 	}
+	if cr.Spec.NetworkPolicy != nil {
+		if err := createOrUpdateNetworkPolicy(ctx, rclient, cr, service); err != nil {
+			return err
+		}
+	}
 	if !ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) {
 		err := reconcile.VMServiceScrapeForCRD(ctx, rclient, build.VMServiceScrapeForAlertmanager(service, cr))
 		if err != nil {
 			return err
 		}
 	}
+	if cr.Spec.HTTPRoute != nil {
+		route, err := build.HTTPRoute(cr, cr.Spec.HTTPRoute, cr.Port(), nil)
+		if err != nil {
+			return vmv1beta1.NewConfigError(fmt.Errorf("cannot build route for vmalertmanager: %w", err))
+		}
+		if err := reconcile.HTTPRoute(ctx, rclient, route); err != nil {
+			return fmt.Errorf("cannot create route for vmalertmanager: %w", err)
+		}
+	}
 
 	if cr.Spec.PodDisruptionBudget != nil {
 		if err := reconcile.PDB(ctx, rclient, build.PodDisruptionBudget(cr, cr.Spec.PodDisruptionBudget)); err != nil {
@@ -77,14 +97,57 @@ func CreateOrUpdateAlertManager(ctx context.Context, cr *vmv1beta1.VMAlertmanage
 	}
 	newSts, err := newStsForAlertManager(cr)
 	if err != nil {
-		return fmt.Errorf("cannot generate alertmanager sts, name: %s,err: %w", cr.Name, err)
+		return vmv1beta1.NewConfigError(fmt.Errorf("cannot generate alertmanager sts, name: %s,err: %w", cr.Name, err))
 	}
 
 	stsOpts := reconcile.STSOptions{
 		HasClaim:       len(newSts.Spec.VolumeClaimTemplates) > 0,
 		SelectorLabels: cr.SelectorLabels,
 	}
-	return reconcile.HandleSTSUpdate(ctx, rclient, stsOpts, newSts, prevSts)
+	if err := reconcile.HandleSTSUpdate(ctx, rclient, stsOpts, newSts, prevSts); err != nil {
+		return err
+	}
+	if cr.Spec.VPA != nil {
+		targetRef := autoscalingv1.CrossVersionObjectReference{
+			Name:       newSts.Name,
+			Kind:       "StatefulSet",
+			APIVersion: "apps/v1",
+		}
+		vpa := build.VPA(targetRef, "alertmanager", cr.Spec.VPA, cr.AsOwner(), cr.SelectorLabels(), cr.Namespace)
+		if err := reconcile.VPA(ctx, rclient, vpa); err != nil {
+			return fmt.Errorf("cannot update vpa for vmalertmanager: %w", err)
+		}
+	}
+	return nil
+}
+
+// createOrUpdateNetworkPolicy allows access to alertmanager web port
+// and cluster gossip between alertmanager peers
+func createOrUpdateNetworkPolicy(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlertmanager, svc *corev1.Service) error {
+	peers, err := build.NetworkPolicyScrapePeers(ctx, rclient, cr.Spec.NetworkPolicy, cr.Namespace)
+	if err != nil {
+		return err
+	}
+	var webPorts, meshPorts []networkingv1.NetworkPolicyPort
+	for _, p := range build.NetworkPolicyPortsFromService(svc) {
+		if p.Port.IntValue() == clusterMeshPort {
+			meshPorts = append(meshPorts, p)
+			continue
+		}
+		webPorts = append(webPorts, p)
+	}
+	rules := &build.NetworkPolicyRules{
+		Ports: webPorts,
+		Peers: peers,
+		Ingress: []networkingv1.NetworkPolicyIngressRule{{
+			Ports: meshPorts,
+			From:  []networkingv1.NetworkPolicyPeer{build.NetworkPolicyPeerForPods(cr.SelectorLabels(), cr.Namespace)},
+		}},
+	}
+	if err := reconcile.NetworkPolicy(ctx, rclient, build.NetworkPolicy(cr, cr.Spec.NetworkPolicy, rules)); err != nil {
+		return fmt.Errorf("cannot update network policy for vmalertmanager: %w", err)
+	}
+	return nil
 }
 
 func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VMAlertmanager, rclient client.Client) error {
@@ -95,6 +158,15 @@ func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VMAlertmanager,
 	if err := reconcile.AdditionalServices(ctx, rclient, cr.PrefixedName(), cr.Namespace, prevSvc, currSvc); err != nil {
 		return fmt.Errorf("cannot remove additional service: %w", err)
 	}
+	if err := reconcile.RemoveOrphanedHTTPRoute(ctx, rclient, cr.PrefixedName(), cr.Namespace, cr.ParsedLastAppliedSpec.HTTPRoute, cr.Spec.HTTPRoute); err != nil {
+		return err
+	}
+	if err := reconcile.RemoveOrphanedNetworkPolicy(ctx, rclient, cr.PrefixedName(), cr.Namespace, cr.ParsedLastAppliedSpec.NetworkPolicy, cr.Spec.NetworkPolicy); err != nil {
+		return err
+	}
+	if err := reconcile.RemoveOrphanedVPA(ctx, rclient, cr.PrefixedName(), cr.Namespace, cr.ParsedLastAppliedSpec.VPA, cr.Spec.VPA); err != nil {
+		return err
+	}
 
 	objMeta := metav1.ObjectMeta{Name: cr.PrefixedName(), Namespace: cr.Namespace}
 	if cr.Spec.PodDisruptionBudget == nil && cr.ParsedLastAppliedSpec.PodDisruptionBudget != nil {
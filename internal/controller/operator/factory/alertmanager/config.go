@@ -2,6 +2,7 @@ package alertmanager
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"path"
@@ -11,12 +12,18 @@ import (
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	version "github.com/hashicorp/go-version"
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+var (
+	alertmanagerV028 = version.Must(version.NewVersion("v0.28.0"))
+	alertmanagerV029 = version.Must(version.NewVersion("v0.29.0"))
+)
+
 type parsedConfig struct {
 	data         []byte
 	amcfgs       []*vmv1beta1.VMAlertmanagerConfig
@@ -79,6 +86,8 @@ func buildConfig(ctx context.Context, rclient client.Client, alertmanagerCR *vmv
 	configmapCache := make(map[string]*corev1.ConfigMap)
 	var result parsedConfig
 
+	amVersion := parseAlertmanagerVersion(alertmanagerCR.Spec.Image.Tag)
+
 	var cnt int
 OUTER:
 	for _, amcKey := range amcfgs {
@@ -89,6 +98,11 @@ OUTER:
 		}
 		var receiverCfgs []yaml.MapSlice
 		for _, receiver := range amcKey.Spec.Receivers {
+			if err := checkReceiverVersion(receiver, amVersion); err != nil {
+				result.brokenAMCfgs = append(result.brokenAMCfgs, amcKey)
+				amcKey.Status.CurrentSyncError = err.Error()
+				continue OUTER
+			}
 			receiverCfg, err := buildReceiver(ctx, rclient, amcKey, receiver, &globalConfigOpts, secretCache, configmapCache, tlsAssets)
 			if err != nil {
 				// skip broken configs
@@ -354,6 +368,41 @@ type route struct {
 	ActiveTimeIntervals []string          `yaml:"active_time_intervals,omitempty" json:"active_time_intervals,omitempty"`
 }
 
+// parseAlertmanagerVersion returns version of alertmanager image tag.
+// It returns nil for tags without version, e.g. latest or image digest
+func parseAlertmanagerVersion(tag string) *version.Version {
+	tag, _, _ = strings.Cut(tag, "@")
+	v, err := version.NewVersion(tag)
+	if err != nil {
+		return nil
+	}
+	return v.Core()
+}
+
+// checkReceiverVersion checks if receiver integrations are supported by alertmanager version.
+// Alertmanager fails to start with unknown receiver fields, so such receivers must be rejected
+func checkReceiverVersion(receiver vmv1beta1.Receiver, amVersion *version.Version) error {
+	if amVersion == nil {
+		return nil
+	}
+	check := func(name string, count int, minVersion *version.Version) error {
+		if count > 0 && amVersion.LessThan(minVersion) {
+			return fmt.Errorf("receiver=%q: %s require alertmanager %s or newer, got image version: %s", receiver.Name, name, minVersion.Original(), amVersion)
+		}
+		return nil
+	}
+	if err := check("jira_configs", len(receiver.JiraConfigs), alertmanagerV028); err != nil {
+		return err
+	}
+	if err := check("rocketchat_configs", len(receiver.RocketchatConfigs), alertmanagerV028); err != nil {
+		return err
+	}
+	if err := check("msteamsv2_configs", len(receiver.MSTeamsV2Configs), alertmanagerV028); err != nil {
+		return err
+	}
+	return check("incidentio_configs", len(receiver.IncidentIOConfigs), alertmanagerV029)
+}
+
 func buildReceiver(
 	ctx context.Context,
 	rclient client.Client,
@@ -502,6 +551,30 @@ func (cb *configBuilder) buildCfg(receiver vmv1beta1.Receiver) error {
 		}
 	}
 	cb.finalizeSection("webex_configs")
+	for _, jiraCfg := range receiver.JiraConfigs {
+		if err := cb.buildJira(jiraCfg); err != nil {
+			return err
+		}
+	}
+	cb.finalizeSection("jira_configs")
+	for _, rcCfg := range receiver.RocketchatConfigs {
+		if err := cb.buildRocketchat(rcCfg); err != nil {
+			return err
+		}
+	}
+	cb.finalizeSection("rocketchat_configs")
+	for _, mcCfg := range receiver.MSTeamsV2Configs {
+		if err := cb.buildTeamsV2(mcCfg); err != nil {
+			return err
+		}
+	}
+	cb.finalizeSection("msteamsv2_configs")
+	for _, incCfg := range receiver.IncidentIOConfigs {
+		if err := cb.buildIncidentIO(incCfg); err != nil {
+			return err
+		}
+	}
+	cb.finalizeSection("incidentio_configs")
 	return nil
 }
 
@@ -589,6 +662,222 @@ func (cb *configBuilder) buildDiscord(dc vmv1beta1.DiscordConfig) error {
 	return nil
 }
 
+func (cb *configBuilder) buildTeamsV2(ms vmv1beta1.MSTeamsV2Config) error {
+	var temp yaml.MapSlice
+	if ms.HTTPConfig != nil {
+		c, err := cb.buildHTTPConfig(ms.HTTPConfig)
+		if err != nil {
+			return err
+		}
+		temp = append(temp, yaml.MapItem{Key: "http_config", Value: c})
+	}
+	if ms.SendResolved != nil {
+		temp = append(temp, yaml.MapItem{Key: "send_resolved", Value: *ms.SendResolved})
+	}
+
+	if ms.URLSecret != nil {
+		s, err := cb.fetchSecretValue(ms.URLSecret)
+		if err != nil {
+			return err
+		}
+		if err := parseURL(s); err != nil {
+			return fmt.Errorf("invalid URL %s in key %s from secret %s: %w", s, ms.URLSecret.Key, ms.URLSecret.Name, err)
+		}
+		temp = append(temp, yaml.MapItem{Key: "webhook_url", Value: s})
+	} else if ms.URL != nil {
+		temp = append(temp, yaml.MapItem{Key: "webhook_url", Value: ms.URL})
+	}
+	toYaml := func(key string, src string) {
+		if len(src) > 0 {
+			temp = append(temp, yaml.MapItem{Key: key, Value: src})
+		}
+	}
+	toYaml("title", ms.Title)
+	toYaml("text", ms.Text)
+
+	cb.currentYaml = append(cb.currentYaml, temp)
+	return nil
+}
+
+func (cb *configBuilder) buildJira(jira vmv1beta1.JiraConfig) error {
+	var temp yaml.MapSlice
+	if jira.HTTPConfig != nil {
+		c, err := cb.buildHTTPConfig(jira.HTTPConfig)
+		if err != nil {
+			return err
+		}
+		temp = append(temp, yaml.MapItem{Key: "http_config", Value: c})
+	}
+	if jira.SendResolved != nil {
+		temp = append(temp, yaml.MapItem{Key: "send_resolved", Value: *jira.SendResolved})
+	}
+	toYaml := func(key string, src string) {
+		if len(src) > 0 {
+			temp = append(temp, yaml.MapItem{Key: key, Value: src})
+		}
+	}
+	toYaml("api_url", jira.APIURL)
+	toYaml("project", jira.Project)
+	toYaml("issue_type", jira.IssueType)
+	toYaml("summary", jira.Summary)
+	toYaml("description", jira.Description)
+	if len(jira.Labels) > 0 {
+		temp = append(temp, yaml.MapItem{Key: "labels", Value: jira.Labels})
+	}
+	toYaml("priority", jira.Priority)
+	toYaml("reopen_transition", jira.ReopenTransition)
+	toYaml("resolve_transition", jira.ResolveTransition)
+	toYaml("wont_fix_resolution", jira.WontFixResolution)
+	toYaml("reopen_duration", jira.ReopenDuration)
+	if len(jira.Fields) > 0 {
+		keys := make([]string, 0, len(jira.Fields))
+		for key := range jira.Fields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var fields yaml.MapSlice
+		for _, key := range keys {
+			var value any
+			if err := json.Unmarshal(jira.Fields[key].Raw, &value); err != nil {
+				return fmt.Errorf("cannot parse value of jira field=%q: %w", key, err)
+			}
+			fields = append(fields, yaml.MapItem{Key: key, Value: value})
+		}
+		temp = append(temp, yaml.MapItem{Key: "fields", Value: fields})
+	}
+
+	cb.currentYaml = append(cb.currentYaml, temp)
+	return nil
+}
+
+func (cb *configBuilder) buildRocketchat(rc vmv1beta1.RocketchatConfig) error {
+	var temp yaml.MapSlice
+	if rc.HTTPConfig != nil {
+		c, err := cb.buildHTTPConfig(rc.HTTPConfig)
+		if err != nil {
+			return err
+		}
+		temp = append(temp, yaml.MapItem{Key: "http_config", Value: c})
+	}
+	if rc.SendResolved != nil {
+		temp = append(temp, yaml.MapItem{Key: "send_resolved", Value: *rc.SendResolved})
+	}
+	if rc.Token != nil {
+		s, err := cb.fetchSecretValue(rc.Token)
+		if err != nil {
+			return err
+		}
+		temp = append(temp, yaml.MapItem{Key: "token", Value: s})
+	}
+	if rc.TokenID != nil {
+		s, err := cb.fetchSecretValue(rc.TokenID)
+		if err != nil {
+			return err
+		}
+		temp = append(temp, yaml.MapItem{Key: "token_id", Value: s})
+	}
+	toYaml := func(key string, src string) {
+		if len(src) > 0 {
+			temp = append(temp, yaml.MapItem{Key: key, Value: src})
+		}
+	}
+	toYaml("api_url", rc.APIURL)
+	toYaml("channel", rc.Channel)
+	toYaml("color", rc.Color)
+	toYaml("emoji", rc.Emoji)
+	toYaml("icon_url", rc.IconURL)
+	toYaml("text", rc.Text)
+	toYaml("title", rc.Title)
+	toYaml("title_link", rc.TitleLink)
+	toYaml("image_url", rc.ImageURL)
+	toYaml("thumb_url", rc.ThumbURL)
+	if rc.ShortFields != nil {
+		temp = append(temp, yaml.MapItem{Key: "short_fields", Value: *rc.ShortFields})
+	}
+	if rc.LinkNames != nil {
+		temp = append(temp, yaml.MapItem{Key: "link_names", Value: *rc.LinkNames})
+	}
+	if len(rc.Fields) > 0 {
+		var fields []yaml.MapSlice
+		for _, field := range rc.Fields {
+			var f yaml.MapSlice
+			if len(field.Title) > 0 {
+				f = append(f, yaml.MapItem{Key: "title", Value: field.Title})
+			}
+			if len(field.Value) > 0 {
+				f = append(f, yaml.MapItem{Key: "value", Value: field.Value})
+			}
+			if field.Short != nil {
+				f = append(f, yaml.MapItem{Key: "short", Value: *field.Short})
+			}
+			fields = append(fields, f)
+		}
+		temp = append(temp, yaml.MapItem{Key: "fields", Value: fields})
+	}
+	if len(rc.Actions) > 0 {
+		var actions []yaml.MapSlice
+		for _, action := range rc.Actions {
+			var a yaml.MapSlice
+			toActionYaml := func(key string, src string) {
+				if len(src) > 0 {
+					a = append(a, yaml.MapItem{Key: key, Value: src})
+				}
+			}
+			toActionYaml("type", action.Type)
+			toActionYaml("text", action.Text)
+			toActionYaml("url", action.URL)
+			toActionYaml("msg", action.Msg)
+			actions = append(actions, a)
+		}
+		temp = append(temp, yaml.MapItem{Key: "actions", Value: actions})
+	}
+
+	cb.currentYaml = append(cb.currentYaml, temp)
+	return nil
+}
+
+func (cb *configBuilder) buildIncidentIO(inc vmv1beta1.IncidentIOConfig) error {
+	var temp yaml.MapSlice
+	if inc.HTTPConfig != nil {
+		c, err := cb.buildHTTPConfig(inc.HTTPConfig)
+		if err != nil {
+			return err
+		}
+		temp = append(temp, yaml.MapItem{Key: "http_config", Value: c})
+	}
+	if inc.SendResolved != nil {
+		temp = append(temp, yaml.MapItem{Key: "send_resolved", Value: *inc.SendResolved})
+	}
+	if inc.URLSecret != nil {
+		s, err := cb.fetchSecretValue(inc.URLSecret)
+		if err != nil {
+			return err
+		}
+		if err := parseURL(s); err != nil {
+			return fmt.Errorf("invalid URL %s in key %s from secret %s: %w", s, inc.URLSecret.Key, inc.URLSecret.Name, err)
+		}
+		temp = append(temp, yaml.MapItem{Key: "url", Value: s})
+	} else if inc.URL != nil {
+		temp = append(temp, yaml.MapItem{Key: "url", Value: inc.URL})
+	}
+	if inc.AlertSourceToken != nil {
+		s, err := cb.fetchSecretValue(inc.AlertSourceToken)
+		if err != nil {
+			return err
+		}
+		temp = append(temp, yaml.MapItem{Key: "alert_source_token", Value: s})
+	}
+	if inc.MaxAlerts > 0 {
+		temp = append(temp, yaml.MapItem{Key: "max_alerts", Value: inc.MaxAlerts})
+	}
+	if len(inc.Timeout) > 0 {
+		temp = append(temp, yaml.MapItem{Key: "timeout", Value: inc.Timeout})
+	}
+
+	cb.currentYaml = append(cb.currentYaml, temp)
+	return nil
+}
+
 func (cb *configBuilder) buildSNS(sns vmv1beta1.SnsConfig) error {
 	var temp yaml.MapSlice
 	if sns.HTTPConfig != nil {
@@ -710,6 +999,9 @@ func (cb *configBuilder) buildTelegram(tg vmv1beta1.TelegramConfig) error {
 	toYaml("api_url", tg.APIUrl)
 	toYaml("message", tg.Message)
 	toYaml("parse_mode", tg.ParseMode)
+	if tg.MessageThreadID > 0 {
+		temp = append(temp, yaml.MapItem{Key: "message_thread_id", Value: tg.MessageThreadID})
+	}
 
 	cb.currentYaml = append(cb.currentYaml, temp)
 	return nil
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -777,6 +778,174 @@ receivers:
     chat_id: 125
     message: some-templated message
 templates: []
+`,
+		},
+		{
+			name: "jira, rocketchat, msteamsv2 and incidentio ok",
+			predefinedObjects: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "receivers",
+						Namespace: "default",
+					},
+					Data: map[string][]byte{
+						"jira-user":      []byte("oncall@example.com"),
+						"jira-token":     []byte("jira-api-token"),
+						"rc-token":       []byte("rc-token"),
+						"rc-token-id":    []byte("rc-user-id"),
+						"teams-url":      []byte("https://example.webhook.office.com/workflows/1"),
+						"incident-token": []byte("incident-token"),
+					},
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				baseCfg: []byte(`global:
+ time_out: 1min
+`),
+				amcfgs: []*vmv1beta1.VMAlertmanagerConfig{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "oncall",
+							Namespace: "default",
+						},
+						Spec: vmv1beta1.VMAlertmanagerConfigSpec{
+							Receivers: []vmv1beta1.Receiver{
+								{
+									Name: "oncall",
+									JiraConfigs: []vmv1beta1.JiraConfig{
+										{
+											APIURL:         "https://example.atlassian.net/rest/api/2/",
+											Project:        "OPS",
+											IssueType:      "Bug",
+											Labels:         []string{"alertmanager"},
+											ReopenDuration: "1h",
+											Fields: map[string]apiextensionsv1.JSON{
+												"customfield_10001": {Raw: []byte(`{"value":"prod"}`)},
+												"customfield_10000": {Raw: []byte(`"team-a"`)},
+											},
+											HTTPConfig: &vmv1beta1.HTTPConfig{
+												BasicAuth: &vmv1beta1.BasicAuth{
+													Username: corev1.SecretKeySelector{
+														LocalObjectReference: corev1.LocalObjectReference{Name: "receivers"},
+														Key:                  "jira-user",
+													},
+													Password: corev1.SecretKeySelector{
+														LocalObjectReference: corev1.LocalObjectReference{Name: "receivers"},
+														Key:                  "jira-token",
+													},
+												},
+											},
+										},
+									},
+									RocketchatConfigs: []vmv1beta1.RocketchatConfig{
+										{
+											Channel: "#alerts",
+											Token: &corev1.SecretKeySelector{
+												LocalObjectReference: corev1.LocalObjectReference{Name: "receivers"},
+												Key:                  "rc-token",
+											},
+											TokenID: &corev1.SecretKeySelector{
+												LocalObjectReference: corev1.LocalObjectReference{Name: "receivers"},
+												Key:                  "rc-token-id",
+											},
+											Fields: []vmv1beta1.RocketchatFieldConfig{
+												{Title: "severity", Value: "{{ .CommonLabels.severity }}", Short: ptr.To(true)},
+											},
+											Actions: []vmv1beta1.RocketchatActionConfig{
+												{Type: "button", Text: "Runbook", URL: "https://runbooks.example.com"},
+											},
+										},
+									},
+									MSTeamsV2Configs: []vmv1beta1.MSTeamsV2Config{
+										{
+											URLSecret: &corev1.SecretKeySelector{
+												LocalObjectReference: corev1.LocalObjectReference{Name: "receivers"},
+												Key:                  "teams-url",
+											},
+											Title: "alert",
+										},
+									},
+									IncidentIOConfigs: []vmv1beta1.IncidentIOConfig{
+										{
+											URL: ptr.To("https://api.incident.io/v2/alert_events/http/source-id"),
+											AlertSourceToken: &corev1.SecretKeySelector{
+												LocalObjectReference: corev1.LocalObjectReference{Name: "receivers"},
+												Key:                  "incident-token",
+											},
+											MaxAlerts: 10,
+										},
+									},
+									TelegramConfigs: []vmv1beta1.TelegramConfig{
+										{
+											ChatID:          -100125,
+											MessageThreadID: 12,
+											BotToken: &corev1.SecretKeySelector{
+												LocalObjectReference: corev1.LocalObjectReference{Name: "receivers"},
+												Key:                  "rc-token",
+											},
+										},
+									},
+								},
+							},
+							Route: &vmv1beta1.Route{
+								Receiver: "oncall",
+							},
+						},
+					},
+				},
+			},
+			want: `global:
+  time_out: 1min
+route:
+  receiver: blackhole
+  routes:
+  - matchers:
+    - namespace = "default"
+    receiver: default-oncall-oncall
+    continue: true
+receivers:
+- name: blackhole
+- name: default-oncall-oncall
+  telegram_configs:
+  - bot_token: rc-token
+    chat_id: -100125
+    message_thread_id: 12
+  jira_configs:
+  - http_config:
+      basic_auth:
+        username: oncall@example.com
+        password: jira-api-token
+    api_url: https://example.atlassian.net/rest/api/2/
+    project: OPS
+    issue_type: Bug
+    labels:
+    - alertmanager
+    reopen_duration: 1h
+    fields:
+      customfield_10000: team-a
+      customfield_10001:
+        value: prod
+  rocketchat_configs:
+  - token: rc-token
+    token_id: rc-user-id
+    channel: '#alerts'
+    fields:
+    - title: severity
+      value: '{{ .CommonLabels.severity }}'
+      short: true
+    actions:
+    - type: button
+      text: Runbook
+      url: https://runbooks.example.com
+  msteamsv2_configs:
+  - webhook_url: https://example.webhook.office.com/workflows/1
+    title: alert
+  incidentio_configs:
+  - url: https://api.incident.io/v2/alert_events/http/source-id
+    alert_source_token: incident-token
+    max_alerts: 10
+templates: []
 `,
 		},
 		{
@@ -921,6 +1090,48 @@ receivers:
     chat_id: 125
     message: some-templated message
 templates: []
+`,
+		},
+		{
+			name: "incidentio with old alertmanager version",
+			args: args{
+				ctx: context.Background(),
+				amCR: &vmv1beta1.VMAlertmanager{
+					Spec: vmv1beta1.VMAlertmanagerSpec{
+						CommonDefaultableParams: vmv1beta1.CommonDefaultableParams{
+							Image: vmv1beta1.Image{Tag: "v0.28.1"},
+						},
+					},
+				},
+				baseCfg: []byte(`global:
+ time_out: 1min
+`),
+				amcfgs: []*vmv1beta1.VMAlertmanagerConfig{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "oncall",
+							Namespace: "default",
+						},
+						Spec: vmv1beta1.VMAlertmanagerConfigSpec{
+							Receivers: []vmv1beta1.Receiver{
+								{
+									Name:              "oncall",
+									IncidentIOConfigs: []vmv1beta1.IncidentIOConfig{{URL: ptr.To("https://api.incident.io/v2/alert_events/http/1")}},
+								},
+							},
+							Route: &vmv1beta1.Route{Receiver: "oncall"},
+						},
+					},
+				},
+			},
+			parseError: `receiver="oncall": incidentio_configs require alertmanager v0.29.0 or newer, got image version: 0.28.1`,
+			want: `global:
+  time_out: 1min
+route:
+  receiver: blackhole
+receivers:
+- name: blackhole
+templates: []
 `,
 		},
 	}
@@ -0,0 +1,441 @@
+package alertmanager
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+
+	"github.com/prometheus/alertmanager/pkg/labels"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	silenceRequestTimeout = 10 * time.Second
+
+	silenceStatePending = "pending"
+	silenceStateActive  = "active"
+	silenceStateExpired = "expired"
+)
+
+// silenceHTTPClient is used for requests to alertmanager pods by IP,
+// so certificate verification is skipped for tls enabled alertmanager.
+var silenceHTTPClient = &http.Client{
+	Timeout: silenceRequestTimeout,
+	Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	},
+}
+
+// amSilence is a silence object of alertmanager API v2
+type amSilence struct {
+	ID        string             `json:"id,omitempty"`
+	Matchers  []amSilenceMatcher `json:"matchers"`
+	StartsAt  time.Time          `json:"startsAt"`
+	EndsAt    time.Time          `json:"endsAt"`
+	CreatedBy string             `json:"createdBy"`
+	Comment   string             `json:"comment"`
+	Status    *amSilenceStatus   `json:"status,omitempty"`
+}
+
+type amSilenceMatcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual bool   `json:"isEqual"`
+}
+
+type amSilenceStatus struct {
+	State string `json:"state"`
+}
+
+func (s *amSilence) state() string {
+	if s.Status == nil {
+		return ""
+	}
+	return s.Status.State
+}
+
+// CreateOrUpdateSilence syncs silence to all ready replicas of selected VMAlertmanagers
+// and fills status of the given VMAlertmanagerSilence with silence ID and state per replica.
+//
+// Replicas of VMAlertmanager share silences via gossip, so silence created at one replica
+// is reused at others, if it was already propagated.
+func CreateOrUpdateSilence(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlertmanagerSilence) error {
+	desired, err := buildAMSilence(cr)
+	if err != nil {
+		return err
+	}
+	ams, err := selectSilenceAlertmanagers(ctx, rclient, cr)
+	if err != nil {
+		return err
+	}
+	knownIDs := make(map[string]struct{})
+	prevStatuses := make(map[string]vmv1beta1.VMAlertmanagerSilenceReplicaStatus)
+	for _, st := range cr.Status.Silences {
+		if st.ID != "" {
+			knownIDs[st.ID] = struct{}{}
+		}
+		prevStatuses[st.Alertmanager+"/"+st.Pod] = st
+	}
+
+	now := time.Now()
+	var statuses []vmv1beta1.VMAlertmanagerSilenceReplicaStatus
+	var failed int
+	var lastErr error
+	selected := make(map[string]struct{})
+	for i := range ams {
+		am := &ams[i]
+		amName := am.Namespace + "/" + am.Name
+		selected[amName] = struct{}{}
+		pods, err := listReadyAlertmanagerPods(ctx, rclient, am)
+		if err != nil {
+			return err
+		}
+		for _, pod := range pods {
+			st, err := syncReplicaSilence(ctx, alertmanagerPodURL(am, pod.Status.PodIP), desired, knownIDs, cr.IsExpired(now), now)
+			if err != nil {
+				failed++
+				lastErr = fmt.Errorf("cannot sync silence to pod=%s of vmalertmanager=%s: %w", pod.Name, amName, err)
+				if prev, ok := prevStatuses[amName+"/"+pod.Name]; ok {
+					statuses = append(statuses, prev)
+				}
+				continue
+			}
+			st.Alertmanager = amName
+			st.Pod = pod.Name
+			if st.ID != "" {
+				knownIDs[st.ID] = struct{}{}
+			}
+			statuses = append(statuses, st)
+		}
+	}
+
+	// expire silence at alertmanagers, which are not selected anymore
+	for _, st := range cr.Status.Silences {
+		if _, ok := selected[st.Alertmanager]; ok {
+			continue
+		}
+		selected[st.Alertmanager] = struct{}{}
+		if err := expireSilenceAtAlertmanager(ctx, rclient, st.Alertmanager, knownIDs); err != nil {
+			failed++
+			lastErr = err
+		}
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Alertmanager != statuses[j].Alertmanager {
+			return statuses[i].Alertmanager < statuses[j].Alertmanager
+		}
+		return statuses[i].Pod < statuses[j].Pod
+	})
+	// silence IDs must be persisted even if some replicas failed, otherwise silences are duplicated on the next sync
+	if err := cr.SetSilencesStatus(ctx, rclient, statuses); err != nil {
+		return fmt.Errorf("cannot update silence status: %w", err)
+	}
+	if lastErr != nil {
+		return fmt.Errorf("failed to sync silence at %d alertmanager replicas, last error: %w", failed, lastErr)
+	}
+	return nil
+}
+
+// DeleteSilence expires silence at all replicas of VMAlertmanagers, which it was synced to
+func DeleteSilence(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlertmanagerSilence) error {
+	knownIDs := make(map[string]struct{})
+	for _, st := range cr.Status.Silences {
+		if st.ID != "" {
+			knownIDs[st.ID] = struct{}{}
+		}
+	}
+	if len(knownIDs) == 0 {
+		return nil
+	}
+	seen := make(map[string]struct{})
+	for _, st := range cr.Status.Silences {
+		if _, ok := seen[st.Alertmanager]; ok {
+			continue
+		}
+		seen[st.Alertmanager] = struct{}{}
+		if err := expireSilenceAtAlertmanager(ctx, rclient, st.Alertmanager, knownIDs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func buildAMSilence(cr *vmv1beta1.VMAlertmanagerSilence) (*amSilence, error) {
+	matchers, err := cr.ParseMatchers()
+	if err != nil {
+		return nil, err
+	}
+	s := &amSilence{
+		StartsAt:  cr.StartsAt().UTC(),
+		EndsAt:    cr.Spec.EndsAt.UTC(),
+		CreatedBy: cr.CreatedBy(),
+		Comment:   cr.Spec.Comment,
+	}
+	for _, m := range matchers {
+		s.Matchers = append(s.Matchers, amSilenceMatcher{
+			Name:    m.Name,
+			Value:   m.Value,
+			IsRegex: m.Type == labels.MatchRegexp || m.Type == labels.MatchNotRegexp,
+			IsEqual: m.Type == labels.MatchEqual || m.Type == labels.MatchRegexp,
+		})
+	}
+	return s, nil
+}
+
+// selectSilenceAlertmanagers returns VMAlertmanagers matched by silence alertmanagerSelector.
+// Without namespaceSelector only alertmanagers from the silence namespace are matched
+func selectSilenceAlertmanagers(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlertmanagerSilence) ([]vmv1beta1.VMAlertmanager, error) {
+	sel := cr.Spec.AlertmanagerSelector
+	if sel == nil {
+		sel = &vmv1beta1.DiscoverySelector{}
+	}
+	opts, err := sel.AsListOptions()
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert alertmanagerSelector as ListOptions: %w", err)
+	}
+	var nss []string
+	if sel.Namespace == nil {
+		nss = []string{cr.Namespace}
+	} else {
+		nss = config.MustGetWatchNamespaces()
+	}
+	var ams []vmv1beta1.VMAlertmanager
+	if err := k8stools.ListObjectsByNamespace(ctx, rclient, nss, func(objects *vmv1beta1.VMAlertmanagerList) {
+		for _, item := range objects.Items {
+			if !item.DeletionTimestamp.IsZero() || (sel.Namespace != nil && !sel.Namespace.IsMatch(&item)) {
+				continue
+			}
+			ams = append(ams, item)
+		}
+	}, opts); err != nil {
+		return nil, fmt.Errorf("cannot list vmalertmanagers with alertmanagerSelector: %w", err)
+	}
+	return ams, nil
+}
+
+func listReadyAlertmanagerPods(ctx context.Context, rclient client.Client, am *vmv1beta1.VMAlertmanager) ([]corev1.Pod, error) {
+	var pods corev1.PodList
+	opts := &client.ListOptions{
+		Namespace:     am.Namespace,
+		LabelSelector: k8slabels.SelectorFromSet(am.SelectorLabels()),
+	}
+	if err := rclient.List(ctx, &pods, opts); err != nil {
+		return nil, fmt.Errorf("cannot list pods of vmalertmanager=%s/%s: %w", am.Namespace, am.Name, err)
+	}
+	var ready []corev1.Pod
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.PodIP == "" || !reconcile.PodIsReady(pod, 0) {
+			continue
+		}
+		ready = append(ready, *pod)
+	}
+	sort.Slice(ready, func(i, j int) bool {
+		return ready[i].Name < ready[j].Name
+	})
+	return ready, nil
+}
+
+func alertmanagerPodURL(am *vmv1beta1.VMAlertmanager, podIP string) string {
+	var prefix string
+	if am.Spec.RoutePrefix != "" {
+		prefix = strings.TrimSuffix(path.Join("/", am.Spec.RoutePrefix), "/")
+	}
+	return fmt.Sprintf("%s://%s%s", strings.ToLower(am.ProbeScheme()), net.JoinHostPort(podIP, am.Port()), prefix)
+}
+
+// syncReplicaSilence ensures, that alertmanager replica has desired silence.
+// Known silence is updated if it differs from desired one, otherwise new silence is created.
+// Duplicates of known silence, which may be created before gossip propagation, are expired
+func syncReplicaSilence(ctx context.Context, amURL string, desired *amSilence, knownIDs map[string]struct{}, isExpired bool, now time.Time) (vmv1beta1.VMAlertmanagerSilenceReplicaStatus, error) {
+	var st vmv1beta1.VMAlertmanagerSilenceReplicaStatus
+	silences, err := getAMSilences(ctx, amURL)
+	if err != nil {
+		return st, err
+	}
+	var current *amSilence
+	var duplicates []string
+	for i := range silences {
+		s := &silences[i]
+		if _, ok := knownIDs[s.ID]; !ok {
+			continue
+		}
+		switch {
+		case current == nil:
+			current = s
+		case current.state() == silenceStateExpired:
+			current = s
+		case s.state() == silenceStateExpired:
+		case s.ID < current.ID:
+			duplicates = append(duplicates, current.ID)
+			current = s
+		default:
+			duplicates = append(duplicates, s.ID)
+		}
+	}
+	for _, id := range duplicates {
+		if err := expireAMSilence(ctx, amURL, id); err != nil {
+			return st, err
+		}
+	}
+
+	if current != nil && (isExpired || (current.state() != silenceStateExpired && isSilenceEqual(current, desired, now))) {
+		st.ID = current.ID
+		st.State = current.state()
+		return st, nil
+	}
+	if isExpired {
+		// silence end time has passed, there is no need to create it
+		st.State = silenceStateExpired
+		return st, nil
+	}
+	toPost := *desired
+	if current != nil && current.state() != silenceStateExpired {
+		toPost.ID = current.ID
+	}
+	id, err := postAMSilence(ctx, amURL, &toPost)
+	if err != nil {
+		return st, err
+	}
+	st.ID = id
+	st.State = silenceStateActive
+	if toPost.StartsAt.After(now) {
+		st.State = silenceStatePending
+	}
+	return st, nil
+}
+
+// isSilenceEqual compares silence from alertmanager with desired one.
+// Alertmanager replaces start time in the past with creation time,
+// so start time is compared only for pending silences
+func isSilenceEqual(current, desired *amSilence, now time.Time) bool {
+	if len(current.Matchers) != len(desired.Matchers) {
+		return false
+	}
+	for i := range current.Matchers {
+		if current.Matchers[i] != desired.Matchers[i] {
+			return false
+		}
+	}
+	if desired.StartsAt.After(now) && !current.StartsAt.Equal(desired.StartsAt) {
+		return false
+	}
+	return current.EndsAt.Equal(desired.EndsAt) &&
+		current.CreatedBy == desired.CreatedBy &&
+		current.Comment == desired.Comment
+}
+
+// expireSilenceAtAlertmanager expires known silences at all ready replicas of VMAlertmanager with given namespace/name
+func expireSilenceAtAlertmanager(ctx context.Context, rclient client.Client, amName string, knownIDs map[string]struct{}) error {
+	namespace, name, _ := strings.Cut(amName, "/")
+	var am vmv1beta1.VMAlertmanager
+	if err := rclient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &am); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("cannot get vmalertmanager=%s: %w", amName, err)
+	}
+	pods, err := listReadyAlertmanagerPods(ctx, rclient, &am)
+	if err != nil {
+		return err
+	}
+	for _, pod := range pods {
+		amURL := alertmanagerPodURL(&am, pod.Status.PodIP)
+		silences, err := getAMSilences(ctx, amURL)
+		if err != nil {
+			return fmt.Errorf("cannot expire silence at pod=%s of vmalertmanager=%s: %w", pod.Name, amName, err)
+		}
+		for _, s := range silences {
+			if _, ok := knownIDs[s.ID]; !ok || s.state() == silenceStateExpired {
+				continue
+			}
+			if err := expireAMSilence(ctx, amURL, s.ID); err != nil {
+				return fmt.Errorf("cannot expire silence at pod=%s of vmalertmanager=%s: %w", pod.Name, amName, err)
+			}
+		}
+	}
+	return nil
+}
+
+func getAMSilences(ctx context.Context, amURL string) ([]amSilence, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, amURL+"/api/v2/silences", nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot build request: %w", err)
+	}
+	resp, err := silenceHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot request silences: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code for silences request: %d", resp.StatusCode)
+	}
+	var silences []amSilence
+	if err := json.NewDecoder(resp.Body).Decode(&silences); err != nil {
+		return nil, fmt.Errorf("cannot parse silences response: %w", err)
+	}
+	return silences, nil
+}
+
+func postAMSilence(ctx context.Context, amURL string, s *amSilence) (string, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return "", fmt.Errorf("cannot marshal silence: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, amURL+"/api/v2/silences", bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("cannot build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := silenceHTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("cannot post silence: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code for post silence request: %d", resp.StatusCode)
+	}
+	var postResp struct {
+		SilenceID string `json:"silenceID"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&postResp); err != nil {
+		return "", fmt.Errorf("cannot parse post silence response: %w", err)
+	}
+	return postResp.SilenceID, nil
+}
+
+func expireAMSilence(ctx context.Context, amURL, id string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, amURL+"/api/v2/silence/"+id, nil)
+	if err != nil {
+		return fmt.Errorf("cannot build request: %w", err)
+	}
+	resp, err := silenceHTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("cannot expire silence id=%s: %w", id, err)
+	}
+	defer resp.Body.Close()
+	// silence could be already removed by alertmanager garbage collection
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("unexpected status code for expire silence id=%s request: %d", id, resp.StatusCode)
+	}
+	return nil
+}
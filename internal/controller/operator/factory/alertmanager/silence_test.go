@@ -0,0 +1,250 @@
+package alertmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// fakeSilencesAPI emulates silences API of alertmanager cluster with shared state
+type fakeSilencesAPI struct {
+	mu       sync.Mutex
+	silences map[string]*amSilence
+	posts    int
+}
+
+func (f *fakeSilencesAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v2/silences":
+		result := make([]amSilence, 0, len(f.silences))
+		for _, s := range f.silences {
+			result = append(result, *s)
+		}
+		_ = json.NewEncoder(w).Encode(result)
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v2/silences":
+		var s amSilence
+		if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.posts++
+		if s.ID == "" {
+			s.ID = fmt.Sprintf("silence-%d", len(f.silences))
+		}
+		s.Status = &amSilenceStatus{State: silenceStateActive}
+		f.silences[s.ID] = &s
+		fmt.Fprintf(w, `{"silenceID":%q}`, s.ID)
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/v2/silence/"):
+		s, ok := f.silences[strings.TrimPrefix(r.URL.Path, "/api/v2/silence/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		s.Status.State = silenceStateExpired
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestCreateOrUpdateSilence(t *testing.T) {
+	api := &fakeSilencesAPI{silences: make(map[string]*amSilence)}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+	_, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("cannot parse server address: %s", err)
+	}
+	am := &vmv1beta1.VMAlertmanager{
+		ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+		Spec: vmv1beta1.VMAlertmanagerSpec{
+			CommonDefaultableParams: vmv1beta1.CommonDefaultableParams{Port: port},
+		},
+	}
+	newPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    am.SelectorLabels(),
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				PodIP: "127.0.0.1",
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+				},
+			},
+		}
+	}
+	endsAt := metav1.NewTime(time.Now().Add(time.Hour).Truncate(time.Second))
+	cr := &vmv1beta1.VMAlertmanagerSilence{
+		ObjectMeta: metav1.ObjectMeta{Name: "maintenance", Namespace: "default", CreationTimestamp: metav1.Now()},
+		Spec: vmv1beta1.VMAlertmanagerSilenceSpec{
+			Matchers: []string{`alertname="Watchdog"`, `severity=~"info|warning"`},
+			EndsAt:   endsAt,
+			Comment:  "planned maintenance",
+		},
+	}
+	ctx := context.Background()
+	fclient := k8stools.GetTestClientWithObjects([]runtime.Object{am, cr.DeepCopy(), newPod("vmalertmanager-main-0"), newPod("vmalertmanager-main-1")})
+
+	// silence is created once and shared by replicas
+	if err := CreateOrUpdateSilence(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	assert.Equal(t, []vmv1beta1.VMAlertmanagerSilenceReplicaStatus{
+		{Alertmanager: "default/main", Pod: "vmalertmanager-main-0", ID: "silence-0", State: "active"},
+		{Alertmanager: "default/main", Pod: "vmalertmanager-main-1", ID: "silence-0", State: "active"},
+	}, cr.Status.Silences)
+	assert.Equal(t, 1, api.posts)
+	assert.Equal(t, []amSilenceMatcher{
+		{Name: "alertname", Value: "Watchdog", IsEqual: true},
+		{Name: "severity", Value: "info|warning", IsRegex: true, IsEqual: true},
+	}, api.silences["silence-0"].Matchers)
+	assert.Equal(t, "vm-operator", api.silences["silence-0"].CreatedBy)
+
+	if err := cr.SetUpdateStatusTo(ctx, fclient, vmv1beta1.UpdateStatusOperational, nil); err != nil {
+		t.Fatalf("cannot update status: %s", err)
+	}
+
+	// nothing changed
+	if err := CreateOrUpdateSilence(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	assert.Equal(t, 1, api.posts)
+
+	// the next reconcile reads silence IDs from the stored object
+	var stored vmv1beta1.VMAlertmanagerSilence
+	if err := fclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, &stored); err != nil {
+		t.Fatalf("cannot get silence: %s", err)
+	}
+	assert.Equal(t, cr.Status.Silences, stored.Status.Silences)
+	if err := CreateOrUpdateSilence(ctx, fclient, &stored); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	assert.Equal(t, 1, api.posts)
+	assert.Len(t, api.silences, 1)
+
+	// silence is updated in-place
+	cr.Spec.Comment = "extended maintenance"
+	if err := CreateOrUpdateSilence(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	assert.Equal(t, 2, api.posts)
+	assert.Equal(t, "extended maintenance", api.silences["silence-0"].Comment)
+	assert.Len(t, api.silences, 1)
+
+	// duplicate of known silence is expired
+	dup := *api.silences["silence-0"]
+	dup.ID = "silence-1"
+	dup.Status = &amSilenceStatus{State: silenceStateActive}
+	api.silences[dup.ID] = &dup
+	cr.Status.Silences = append(cr.Status.Silences, vmv1beta1.VMAlertmanagerSilenceReplicaStatus{Alertmanager: "default/main", Pod: "vmalertmanager-main-2", ID: dup.ID})
+	if err := CreateOrUpdateSilence(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	assert.Equal(t, silenceStateExpired, api.silences["silence-1"].state())
+	assert.Equal(t, silenceStateActive, api.silences["silence-0"].state())
+	assert.Len(t, cr.Status.Silences, 2)
+
+	// silence is expired on delete
+	if err := DeleteSilence(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	assert.Equal(t, silenceStateExpired, api.silences["silence-0"].state())
+
+	// silence with passed end time is not created
+	api.silences = make(map[string]*amSilence)
+	cr.Status.Silences = nil
+	cr.Spec.EndsAt = metav1.NewTime(time.Now().Add(-time.Minute))
+	if err := CreateOrUpdateSilence(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	assert.Empty(t, api.silences)
+	assert.Equal(t, "expired", cr.Status.Silences[0].State)
+	assert.Empty(t, cr.Status.Silences[0].ID)
+}
+
+func TestCreateOrUpdateSilenceOperational(t *testing.T) {
+	api := &fakeSilencesAPI{silences: make(map[string]*amSilence)}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+	_, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("cannot parse server address: %s", err)
+	}
+	am := &vmv1beta1.VMAlertmanager{
+		ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+		Spec: vmv1beta1.VMAlertmanagerSpec{
+			CommonDefaultableParams: vmv1beta1.CommonDefaultableParams{Port: port},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "vmalertmanager-main-0", Namespace: "default", Labels: am.SelectorLabels()},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			PodIP:      "127.0.0.1",
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	cr := &vmv1beta1.VMAlertmanagerSilence{
+		ObjectMeta: metav1.ObjectMeta{Name: "maintenance", Namespace: "default", CreationTimestamp: metav1.Now()},
+		Spec: vmv1beta1.VMAlertmanagerSilenceSpec{
+			Matchers: []string{`alertname="Watchdog"`},
+			EndsAt:   metav1.NewTime(time.Now().Add(time.Hour).Truncate(time.Second)),
+		},
+		Status: vmv1beta1.VMAlertmanagerSilenceStatus{
+			UpdateStatus: vmv1beta1.UpdateStatusOperational,
+			Silences: []vmv1beta1.VMAlertmanagerSilenceReplicaStatus{
+				{Alertmanager: "default/main", Pod: "vmalertmanager-main-0", ID: "lost-silence", State: "active"},
+			},
+		},
+	}
+	ctx := context.Background()
+	fclient := k8stools.GetTestClientWithObjects([]runtime.Object{am, cr, pod})
+	reconcileSilence := func() *vmv1beta1.VMAlertmanagerSilence {
+		t.Helper()
+		var stored vmv1beta1.VMAlertmanagerSilence
+		if err := fclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, &stored); err != nil {
+			t.Fatalf("cannot get silence: %s", err)
+		}
+		if err := CreateOrUpdateSilence(ctx, fclient, &stored); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if err := stored.SetUpdateStatusTo(ctx, fclient, vmv1beta1.UpdateStatusOperational, nil); err != nil {
+			t.Fatalf("cannot update status: %s", err)
+		}
+		return &stored
+	}
+
+	// silence is lost by alertmanager, ID of recreated silence must be persisted
+	reconcileSilence()
+	assert.Equal(t, 1, api.posts)
+	stored := reconcileSilence()
+	assert.Equal(t, 1, api.posts)
+	assert.Len(t, api.silences, 1)
+	assert.Equal(t, "silence-0", stored.Status.Silences[0].ID)
+
+	// silence is expired on delete
+	if err := DeleteSilence(ctx, fclient, stored); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	assert.Equal(t, silenceStateExpired, api.silences["silence-0"].state())
+}
@@ -497,7 +497,7 @@ func CreateAMConfig(ctx context.Context, cr *vmv1beta1.VMAlertmanager, rclient c
 		}
 		mergedCfg, err := addConfigTemplates(alertmananagerConfig, templatePaths)
 		if err != nil {
-			return fmt.Errorf("cannot build alertmanager config with templates, err: %w", err)
+			return vmv1beta1.NewConfigError(fmt.Errorf("cannot build alertmanager config with templates, err: %w", err))
 		}
 		alertmananagerConfig = mergedCfg
 	}
@@ -653,7 +653,7 @@ func buildAlertmanagerConfigWithCRDs(ctx context.Context, rclient client.Client,
 
 	parsedCfg, err := buildConfig(ctx, rclient, cr, originConfig, amCfgs, tlsAssets)
 	if err != nil {
-		return nil, err
+		return nil, vmv1beta1.NewConfigError(err)
 	}
 	parsedCfg.brokenAMCfgs = append(parsedCfg.brokenAMCfgs, badCfgs...)
 	l.Info("selected alertmanager configs",
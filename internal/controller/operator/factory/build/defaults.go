@@ -28,6 +28,12 @@ func AddDefaults(scheme *runtime.Scheme) {
 	scheme.AddTypeDefaultingFunc(&vmv1beta1.VMAlertmanager{}, addVMAlertmanagerDefaults)
 	scheme.AddTypeDefaultingFunc(&vmv1beta1.VMCluster{}, addVMClusterDefaults)
 	scheme.AddTypeDefaultingFunc(&vmv1beta1.VLogs{}, addVlogsDefaults)
+	scheme.AddTypeDefaultingFunc(&vmv1beta1.VLCluster{}, addVLClusterDefaults)
+	scheme.AddTypeDefaultingFunc(&vmv1beta1.VMBackupJob{}, addVMBackupJobDefaults)
+	scheme.AddTypeDefaultingFunc(&vmv1beta1.VMRestoreJob{}, addVMRestoreJobDefaults)
+	scheme.AddTypeDefaultingFunc(&vmv1beta1.VMRuleTest{}, addVMRuleTestDefaults)
+	scheme.AddTypeDefaultingFunc(&vmv1beta1.VMAnomaly{}, addVMAnomalyDefaults)
+	scheme.AddTypeDefaultingFunc(&vmv1beta1.VMBlackboxExporter{}, addVMBlackboxExporterDefaults)
 
 }
 
@@ -215,6 +221,23 @@ func addVMAgentDefaults(objI interface{}) {
 	addDefaluesToConfigReloader(&cr.Spec.CommonConfigReloaderParams, ptr.Deref(cr.Spec.UseDefaultResources, false), &cv)
 }
 
+func addVMAnomalyDefaults(objI interface{}) {
+	cr := objI.(*vmv1beta1.VMAnomaly)
+	c := getCfg()
+
+	cv := config.ApplicationDefaults(c.VMAnomalyDefault)
+	addDefaultsToCommonParams(&cr.Spec.CommonDefaultableParams, &cv)
+}
+
+func addVMBlackboxExporterDefaults(objI interface{}) {
+	cr := objI.(*vmv1beta1.VMBlackboxExporter)
+	c := getCfg()
+
+	cv := config.ApplicationDefaults(c.VMBlackboxExporterDefault)
+	addDefaultsToCommonParams(&cr.Spec.CommonDefaultableParams, &cv)
+	addDefaluesToConfigReloader(&cr.Spec.CommonConfigReloaderParams, ptr.Deref(cr.Spec.UseDefaultResources, false), &cv)
+}
+
 func addVMSingleDefaults(objI interface{}) {
 	cr := objI.(*vmv1beta1.VMSingle)
 	c := getCfg()
@@ -497,6 +520,152 @@ func addVMClusterDefaults(objI interface{}) {
 	}
 }
 
+const (
+	vlStorageDefaultDBPath = "vlstorage-data"
+)
+
+func addVLClusterDefaults(objI interface{}) {
+	cr := objI.(*vmv1beta1.VLCluster)
+	c := getCfg()
+
+	// cluster is tricky is has main strictSecurity and per app
+	useStrictSecurity := c.EnableStrictSecurity
+	if cr.Spec.UseStrictSecurity != nil {
+		useStrictSecurity = *cr.Spec.UseStrictSecurity
+	}
+	if cr.Spec.ClusterDomainName == "" {
+		cr.Spec.ClusterDomainName = c.ClusterDomainName
+	}
+	imageTag := func(tag, defaultVersion string) string {
+		if tag != "" {
+			return tag
+		}
+		if cr.Spec.ClusterVersion != "" {
+			return cr.Spec.ClusterVersion
+		}
+		return defaultVersion
+	}
+
+	if cr.Spec.VLStorage != nil {
+		vls := cr.Spec.VLStorage
+		if vls.UseStrictSecurity == nil {
+			vls.UseStrictSecurity = &useStrictSecurity
+		}
+		if vls.DisableSelfServiceScrape == nil {
+			vls.DisableSelfServiceScrape = &c.DisableSelfServiceScrapeCreation
+		}
+		vls.ImagePullSecrets = append(vls.ImagePullSecrets, cr.Spec.ImagePullSecrets...)
+		if vls.Image.Repository == "" {
+			vls.Image.Repository = c.VLClusterDefault.VLStorageDefault.Image
+		}
+		vls.Image.Repository = formatContainerImage(c.ContainerRegistry, vls.Image.Repository)
+		vls.Image.Tag = imageTag(vls.Image.Tag, c.VLClusterDefault.VLStorageDefault.Version)
+		if vls.Port == "" {
+			vls.Port = c.VLClusterDefault.VLStorageDefault.Port
+		}
+		if vls.Image.PullPolicy == "" {
+			vls.Image.PullPolicy = corev1.PullIfNotPresent
+		}
+		if vls.StorageDataPath == "" {
+			vls.StorageDataPath = vlStorageDefaultDBPath
+		}
+		if vls.TerminationGracePeriodSeconds == nil {
+			vls.TerminationGracePeriodSeconds = &defaultTerminationGracePeriod
+		}
+		if vls.UseDefaultResources == nil {
+			vls.UseDefaultResources = &c.VLClusterDefault.UseDefaultResources
+		}
+		vls.Resources = Resources(vls.Resources,
+			config.Resource(c.VLClusterDefault.VLStorageDefault.Resource),
+			*vls.UseDefaultResources,
+		)
+	}
+
+	if cr.Spec.VLInsert != nil {
+		vli := cr.Spec.VLInsert
+		if vli.UseStrictSecurity == nil {
+			vli.UseStrictSecurity = &useStrictSecurity
+		}
+		if vli.DisableSelfServiceScrape == nil {
+			vli.DisableSelfServiceScrape = &c.DisableSelfServiceScrapeCreation
+		}
+		vli.ImagePullSecrets = append(vli.ImagePullSecrets, cr.Spec.ImagePullSecrets...)
+		if vli.Image.Repository == "" {
+			vli.Image.Repository = c.VLClusterDefault.VLInsertDefault.Image
+		}
+		vli.Image.Repository = formatContainerImage(c.ContainerRegistry, vli.Image.Repository)
+		vli.Image.Tag = imageTag(vli.Image.Tag, c.VLClusterDefault.VLInsertDefault.Version)
+		if vli.Port == "" {
+			vli.Port = c.VLClusterDefault.VLInsertDefault.Port
+		}
+		if vli.Image.PullPolicy == "" {
+			vli.Image.PullPolicy = corev1.PullIfNotPresent
+		}
+		if vli.UseDefaultResources == nil {
+			vli.UseDefaultResources = &c.VLClusterDefault.UseDefaultResources
+		}
+		vli.Resources = Resources(vli.Resources,
+			config.Resource(c.VLClusterDefault.VLInsertDefault.Resource),
+			*vli.UseDefaultResources,
+		)
+	}
+
+	if cr.Spec.VLSelect != nil {
+		vlse := cr.Spec.VLSelect
+		if vlse.UseStrictSecurity == nil {
+			vlse.UseStrictSecurity = &useStrictSecurity
+		}
+		if vlse.DisableSelfServiceScrape == nil {
+			vlse.DisableSelfServiceScrape = &c.DisableSelfServiceScrapeCreation
+		}
+		vlse.ImagePullSecrets = append(vlse.ImagePullSecrets, cr.Spec.ImagePullSecrets...)
+		if vlse.Image.Repository == "" {
+			vlse.Image.Repository = c.VLClusterDefault.VLSelectDefault.Image
+		}
+		vlse.Image.Repository = formatContainerImage(c.ContainerRegistry, vlse.Image.Repository)
+		vlse.Image.Tag = imageTag(vlse.Image.Tag, c.VLClusterDefault.VLSelectDefault.Version)
+		if vlse.Port == "" {
+			vlse.Port = c.VLClusterDefault.VLSelectDefault.Port
+		}
+		if vlse.Image.PullPolicy == "" {
+			vlse.Image.PullPolicy = corev1.PullIfNotPresent
+		}
+		if vlse.UseDefaultResources == nil {
+			vlse.UseDefaultResources = &c.VLClusterDefault.UseDefaultResources
+		}
+		vlse.Resources = Resources(vlse.Resources,
+			config.Resource(c.VLClusterDefault.VLSelectDefault.Resource),
+			*vlse.UseDefaultResources,
+		)
+	}
+
+	if cr.Spec.RequestsLoadBalancer.Enabled {
+		spec := &cr.Spec.RequestsLoadBalancer.Spec
+		if spec.UseStrictSecurity == nil {
+			spec.UseStrictSecurity = &useStrictSecurity
+		}
+		if spec.DisableSelfServiceScrape == nil {
+			spec.DisableSelfServiceScrape = &c.DisableSelfServiceScrapeCreation
+		}
+		spec.ImagePullSecrets = append(spec.ImagePullSecrets, cr.Spec.ImagePullSecrets...)
+		cv := config.ApplicationDefaults(c.VMAuthDefault)
+		addDefaultsToCommonParams(&spec.CommonDefaultableParams, &cv)
+		if spec.EmbeddedProbes == nil {
+			spec.EmbeddedProbes = &vmv1beta1.EmbeddedProbes{}
+		}
+		if spec.StartupProbe == nil {
+			spec.StartupProbe = &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					HTTPGet: &corev1.HTTPGetAction{},
+				},
+			}
+		}
+		if spec.AdditionalServiceSpec != nil && !spec.AdditionalServiceSpec.UseAsDefault {
+			spec.AdditionalServiceSpec.UseAsDefault = true
+		}
+	}
+}
+
 func addDefaultsToCommonParams(common *vmv1beta1.CommonDefaultableParams, appDefaults *config.ApplicationDefaults) {
 	c := getCfg()
 
@@ -584,3 +753,81 @@ func addDefaultsToVMBackup(cr *vmv1beta1.VMBackup, useDefaultResources bool, app
 	cr.Resources = Resources(cr.Resources, config.Resource(appDefaults.Resource), useDefaultResources)
 
 }
+
+func addVMBackupJobDefaults(objI interface{}) {
+	cr := objI.(*vmv1beta1.VMBackupJob)
+	c := getCfg()
+	cv := &config.ApplicationDefaults{
+		Image:               c.VMBackupJobDefault.Image,
+		Version:             c.VMBackupJobDefault.Version,
+		UseDefaultResources: c.VMBackupJobDefault.UseDefaultResources,
+		Resource: struct {
+			Limit struct {
+				Mem string
+				Cpu string
+			}
+			Request struct {
+				Mem string
+				Cpu string
+			}
+		}(c.VMBackupJobDefault.Resource),
+	}
+	addDefaultsToBackupJobCommonParams(&cr.Spec.BackupJobCommonParams, cv)
+}
+
+func addVMRestoreJobDefaults(objI interface{}) {
+	cr := objI.(*vmv1beta1.VMRestoreJob)
+	c := getCfg()
+	cv := &config.ApplicationDefaults{
+		Image:               c.VMRestoreJobDefault.Image,
+		Version:             c.VMRestoreJobDefault.Version,
+		UseDefaultResources: c.VMRestoreJobDefault.UseDefaultResources,
+		Resource: struct {
+			Limit struct {
+				Mem string
+				Cpu string
+			}
+			Request struct {
+				Mem string
+				Cpu string
+			}
+		}(c.VMRestoreJobDefault.Resource),
+	}
+	addDefaultsToBackupJobCommonParams(&cr.Spec.BackupJobCommonParams, cv)
+}
+
+func addVMRuleTestDefaults(objI interface{}) {
+	cr := objI.(*vmv1beta1.VMRuleTest)
+	c := getCfg()
+	if cr.Spec.Image.Repository == "" {
+		cr.Spec.Image.Repository = c.VMRuleTestDefault.Image
+	}
+	cr.Spec.Image.Repository = formatContainerImage(c.ContainerRegistry, cr.Spec.Image.Repository)
+	if cr.Spec.Image.Tag == "" {
+		cr.Spec.Image.Tag = c.VMRuleTestDefault.Version
+	}
+	if cr.Spec.Image.PullPolicy == "" {
+		cr.Spec.Image.PullPolicy = corev1.PullIfNotPresent
+	}
+	cr.Spec.Resources = Resources(cr.Spec.Resources, config.Resource(c.VMRuleTestDefault.Resource), c.VMRuleTestDefault.UseDefaultResources)
+}
+
+func addDefaultsToBackupJobCommonParams(cr *vmv1beta1.BackupJobCommonParams, appDefaults *config.ApplicationDefaults) {
+	c := getCfg()
+
+	if cr.Image.Repository == "" {
+		cr.Image.Repository = appDefaults.Image
+	}
+	cr.Image.Repository = formatContainerImage(c.ContainerRegistry, cr.Image.Repository)
+	if cr.Image.Tag == "" {
+		cr.Image.Tag = appDefaults.Version
+	}
+	if cr.Image.PullPolicy == "" {
+		cr.Image.PullPolicy = corev1.PullIfNotPresent
+	}
+	if cr.BackoffLimit == nil {
+		cr.BackoffLimit = ptr.To[int32](3)
+	}
+
+	cr.Resources = Resources(cr.Resources, config.Resource(appDefaults.Resource), appDefaults.UseDefaultResources)
+}
@@ -0,0 +1,110 @@
+package build
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const gatewayAPIGroup = "gateway.networking.k8s.io"
+
+// HTTPRouteGVK returns Gateway API group version kind for given route kind
+func HTTPRouteGVK(kind string) schema.GroupVersionKind {
+	version := "v1"
+	if kind == vmv1beta1.TLSRouteKind {
+		version = "v1alpha2"
+	}
+	return schema.GroupVersionKind{Group: gatewayAPIGroup, Version: version, Kind: kind}
+}
+
+// HTTPRoute creates Gateway API route object for given CRD
+// route forwards requests to the CRD service at given port.
+// defaultHostnames are used if spec has no hostnames
+func HTTPRoute(cr svcBuilderArgs, spec *vmv1beta1.EmbeddedHTTPRoute, port string, defaultHostnames []string) (*unstructured.Unstructured, error) {
+	portNumber, err := strconv.ParseInt(port, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse service port=%q: %w", port, err)
+	}
+	kind := spec.KindOrDefault()
+	parentRefs := make([]any, 0, len(spec.ParentRefs))
+	for _, ref := range spec.ParentRefs {
+		parentRef := map[string]any{
+			"group": gatewayAPIGroup,
+			"kind":  "Gateway",
+			"name":  ref.Name,
+		}
+		if ref.Namespace != "" {
+			parentRef["namespace"] = ref.Namespace
+		}
+		if ref.SectionName != "" {
+			parentRef["sectionName"] = ref.SectionName
+		}
+		if ref.Port != nil {
+			parentRef["port"] = int64(*ref.Port)
+		}
+		parentRefs = append(parentRefs, parentRef)
+	}
+	defaultRule := map[string]any{
+		"backendRefs": []any{
+			map[string]any{
+				"name": cr.PrefixedName(),
+				"port": portNumber,
+			},
+		},
+	}
+	if kind == vmv1beta1.HTTPRouteKind {
+		prefixes := spec.PathPrefixes
+		if len(prefixes) == 0 {
+			prefixes = []string{"/"}
+		}
+		matches := make([]any, 0, len(prefixes))
+		for _, prefix := range prefixes {
+			matches = append(matches, map[string]any{
+				"path": map[string]any{
+					"type":  "PathPrefix",
+					"value": prefix,
+				},
+			})
+		}
+		defaultRule["matches"] = matches
+	}
+	rules := []any{defaultRule}
+	// add user defined rules
+	for i, extraRule := range spec.ExtraRules {
+		var rule map[string]any
+		if err := json.Unmarshal(extraRule.Raw, &rule); err != nil {
+			return nil, fmt.Errorf("cannot parse extraRules[%d]: %w", i, err)
+		}
+		rules = append(rules, rule)
+	}
+	routeSpec := map[string]any{
+		"parentRefs": parentRefs,
+		"rules":      rules,
+	}
+	hostnames := spec.Hostnames
+	if len(hostnames) == 0 {
+		hostnames = defaultHostnames
+	}
+	if len(hostnames) > 0 {
+		hs := make([]any, 0, len(hostnames))
+		for _, h := range hostnames {
+			hs = append(hs, h)
+		}
+		routeSpec["hostnames"] = hs
+	}
+
+	route := &unstructured.Unstructured{Object: map[string]any{"spec": routeSpec}}
+	route.SetGroupVersionKind(HTTPRouteGVK(kind))
+	route.SetName(cr.PrefixedName())
+	route.SetNamespace(cr.GetNSName())
+	route.SetLabels(labels.Merge(spec.Labels, cr.SelectorLabels()))
+	route.SetAnnotations(spec.Annotations)
+	route.SetOwnerReferences(cr.AsOwner())
+	route.SetFinalizers([]string{vmv1beta1.FinalizerName})
+	return route, nil
+}
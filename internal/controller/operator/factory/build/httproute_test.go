@@ -0,0 +1,112 @@
+package build
+
+import (
+	"testing"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+)
+
+func TestHTTPRoute(t *testing.T) {
+	f := func(spec *vmv1beta1.EmbeddedHTTPRoute, defaultHostnames []string, want string) {
+		t.Helper()
+		cr := &vmv1beta1.VMSingle{
+			ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+		}
+		route, err := HTTPRoute(cr, spec, "8429", defaultHostnames)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		delete(route.Object, "metadata")
+		got, err := yaml.Marshal(route.Object)
+		if err != nil {
+			t.Fatalf("cannot marshal route: %s", err)
+		}
+		if string(got) != want {
+			t.Fatalf("unexpected route\ngot:\n%s\nwant:\n%s", got, want)
+		}
+	}
+
+	// default http route with hostnames from ingress
+	f(&vmv1beta1.EmbeddedHTTPRoute{
+		ParentRefs: []vmv1beta1.GatewayParentReference{{Name: "gw"}},
+	}, []string{"vmsingle.example.com"}, `apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+spec:
+  hostnames:
+  - vmsingle.example.com
+  parentRefs:
+  - group: gateway.networking.k8s.io
+    kind: Gateway
+    name: gw
+  rules:
+  - backendRefs:
+    - name: vmsingle-main
+      port: 8429
+    matches:
+    - path:
+        type: PathPrefix
+        value: /
+`)
+
+	// http route with path prefixes and extra rules
+	f(&vmv1beta1.EmbeddedHTTPRoute{
+		ParentRefs: []vmv1beta1.GatewayParentReference{{
+			Name:        "gw",
+			Namespace:   "gateways",
+			SectionName: "https",
+			Port:        ptr.To[int32](443),
+		}},
+		Hostnames:    []string{"metrics.example.com"},
+		PathPrefixes: []string{"/api", "/vmui"},
+		ExtraRules: []apiextensionsv1.JSON{
+			{Raw: []byte(`{"backendRefs":[{"name":"other","port":80}]}`)},
+		},
+	}, []string{"vmsingle.example.com"}, `apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+spec:
+  hostnames:
+  - metrics.example.com
+  parentRefs:
+  - group: gateway.networking.k8s.io
+    kind: Gateway
+    name: gw
+    namespace: gateways
+    port: 443
+    sectionName: https
+  rules:
+  - backendRefs:
+    - name: vmsingle-main
+      port: 8429
+    matches:
+    - path:
+        type: PathPrefix
+        value: /api
+    - path:
+        type: PathPrefix
+        value: /vmui
+  - backendRefs:
+    - name: other
+      port: 80
+`)
+
+	// tls route has no path matches
+	f(&vmv1beta1.EmbeddedHTTPRoute{
+		Kind:       vmv1beta1.TLSRouteKind,
+		ParentRefs: []vmv1beta1.GatewayParentReference{{Name: "gw"}},
+	}, nil, `apiVersion: gateway.networking.k8s.io/v1alpha2
+kind: TLSRoute
+spec:
+  parentRefs:
+  - group: gateway.networking.k8s.io
+    kind: Gateway
+    name: gw
+  rules:
+  - backendRefs:
+    - name: vmsingle-main
+      port: 8429
+`)
+}
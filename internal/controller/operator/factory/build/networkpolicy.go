@@ -0,0 +1,182 @@
+package build
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const namespaceNameLabel = "kubernetes.io/metadata.name"
+
+// NetworkPolicyRules defines traffic known by operator for application pods
+type NetworkPolicyRules struct {
+	// Ports are accessible by clients defined at spec allowFrom
+	Ports []networkingv1.NetworkPolicyPort
+	// Peers are additionally allowed to access Ports, e.g. scraping VMAgents
+	Peers []networkingv1.NetworkPolicyPeer
+	// Ingress defines connections between application components
+	Ingress []networkingv1.NetworkPolicyIngressRule
+	// Egress defines known destinations of application
+	// egress isn't restricted if it's nil
+	Egress []networkingv1.NetworkPolicyEgressRule
+}
+
+// NetworkPolicy creates NetworkPolicy object for pods of given CRD
+func NetworkPolicy(cr svcBuilderArgs, spec *vmv1beta1.EmbeddedNetworkPolicy, rules *NetworkPolicyRules) *networkingv1.NetworkPolicy {
+	np := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cr.PrefixedName(),
+			Namespace:       cr.GetNSName(),
+			Labels:          labels.Merge(spec.Labels, cr.SelectorLabels()),
+			Annotations:     spec.Annotations,
+			OwnerReferences: cr.AsOwner(),
+			Finalizers:      []string{vmv1beta1.FinalizerName},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: cr.SelectorLabels()},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+	if len(rules.Ports) > 0 {
+		clientsRule := networkingv1.NetworkPolicyIngressRule{
+			Ports: rules.Ports,
+		}
+		if len(spec.AllowFrom) > 0 {
+			clientsRule.From = append(clientsRule.From, spec.AllowFrom...)
+			clientsRule.From = append(clientsRule.From, rules.Peers...)
+			// operator performs requests to application pods,
+			// e.g. syncs alertmanager silences and checks health of vmcluster canary pods
+			clientsRule.From = append(clientsRule.From, networkPolicyOperatorPeer())
+		}
+		np.Spec.Ingress = append(np.Spec.Ingress, clientsRule)
+	}
+	np.Spec.Ingress = append(np.Spec.Ingress, rules.Ingress...)
+	np.Spec.Ingress = append(np.Spec.Ingress, spec.ExtraIngress...)
+
+	if spec.Egress && rules.Egress != nil {
+		np.Spec.PolicyTypes = append(np.Spec.PolicyTypes, networkingv1.PolicyTypeEgress)
+		// DNS requests are required for service discovery
+		np.Spec.Egress = append(np.Spec.Egress, networkingv1.NetworkPolicyEgressRule{
+			Ports: []networkingv1.NetworkPolicyPort{
+				{Protocol: ptr.To(corev1.ProtocolUDP), Port: ptr.To(intstr.FromInt32(53))},
+				{Protocol: ptr.To(corev1.ProtocolTCP), Port: ptr.To(intstr.FromInt32(53))},
+			},
+		})
+		np.Spec.Egress = append(np.Spec.Egress, rules.Egress...)
+		np.Spec.Egress = append(np.Spec.Egress, spec.ExtraEgress...)
+	}
+	return np
+}
+
+// NetworkPolicyPortsFromService returns target ports of given service
+func NetworkPolicyPortsFromService(svc *corev1.Service) []networkingv1.NetworkPolicyPort {
+	result := make([]networkingv1.NetworkPolicyPort, 0, len(svc.Spec.Ports))
+	for _, p := range svc.Spec.Ports {
+		protocol := p.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+		port := p.TargetPort
+		if port.IntVal == 0 && port.StrVal == "" {
+			port = intstr.FromInt32(p.Port)
+		}
+		result = append(result, networkingv1.NetworkPolicyPort{
+			Protocol: ptr.To(protocol),
+			Port:     ptr.To(port),
+		})
+	}
+	return result
+}
+
+// NetworkPolicyPeerForPods returns peer, which matches pods with given labels at given namespace
+func NetworkPolicyPeerForPods(selectorLabels map[string]string, namespace string) networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{MatchLabels: selectorLabels},
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{namespaceNameLabel: namespace},
+		},
+	}
+}
+
+// networkPolicyOperatorPeer returns peer, which matches operator pods
+// operator pods are matched at any namespace, if operator namespace is unknown
+func networkPolicyOperatorPeer() networkingv1.NetworkPolicyPeer {
+	cfg := config.MustGetBaseConfig()
+	peer := networkingv1.NetworkPolicyPeer{
+		PodSelector:       &metav1.LabelSelector{MatchLabels: cfg.NetworkPolicy.OperatorPodLabels},
+		NamespaceSelector: &metav1.LabelSelector{},
+	}
+	if ns := cfg.NetworkPolicy.OperatorNamespace; ns != "" {
+		peer.NamespaceSelector.MatchLabels = map[string]string{namespaceNameLabel: ns}
+	}
+	return peer
+}
+
+// NetworkPolicyPortsFromURLs returns destination ports for given urls
+// default port is used for urls without explicit port
+func NetworkPolicyPortsFromURLs(urls ...string) ([]networkingv1.NetworkPolicyPort, error) {
+	var ports []networkingv1.NetworkPolicyPort
+	seen := make(map[string]struct{})
+	for _, u := range urls {
+		if u == "" {
+			continue
+		}
+		pu, err := url.Parse(u)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse url=%q: %w", u, err)
+		}
+		port := pu.Port()
+		if port == "" {
+			port = "80"
+			if pu.Scheme == "https" {
+				port = "443"
+			}
+		}
+		if _, ok := seen[port]; ok {
+			continue
+		}
+		seen[port] = struct{}{}
+		portNumber, err := strconv.ParseInt(port, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse port of url=%q: %w", u, err)
+		}
+		ports = append(ports, networkingv1.NetworkPolicyPort{
+			Protocol: ptr.To(corev1.ProtocolTCP),
+			Port:     ptr.To(intstr.FromInt32(int32(portNumber))),
+		})
+	}
+	return ports, nil
+}
+
+// NetworkPolicyScrapePeers returns peers for VMAgents selected by given spec
+func NetworkPolicyScrapePeers(ctx context.Context, rclient client.Client, spec *vmv1beta1.EmbeddedNetworkPolicy, namespace string) ([]networkingv1.NetworkPolicyPeer, error) {
+	if spec.VMAgentSelector == nil && spec.VMAgentNamespaceSelector == nil {
+		return nil, nil
+	}
+	var peers []networkingv1.NetworkPolicyPeer
+	err := k8stools.VisitObjectsForSelectorsAtNs(ctx, rclient, spec.VMAgentNamespaceSelector, spec.VMAgentSelector, namespace, false,
+		func(list *vmv1beta1.VMAgentList) {
+			for _, item := range list.Items {
+				if !item.DeletionTimestamp.IsZero() {
+					continue
+				}
+				peers = append(peers, NetworkPolicyPeerForPods(item.SelectorLabels(), item.Namespace))
+			}
+		})
+	if err != nil {
+		return nil, fmt.Errorf("cannot select VMAgents for network policy: %w", err)
+	}
+	return peers, nil
+}
@@ -0,0 +1,162 @@
+package build
+
+import (
+	"testing"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/yaml"
+)
+
+func TestNetworkPolicy(t *testing.T) {
+	f := func(spec *vmv1beta1.EmbeddedNetworkPolicy, rules *NetworkPolicyRules, want string) {
+		t.Helper()
+		cr := &vmv1beta1.VMSingle{
+			ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+		}
+		np := NetworkPolicy(cr, spec, rules)
+		got, err := yaml.Marshal(np.Spec)
+		if err != nil {
+			t.Fatalf("cannot marshal network policy: %s", err)
+		}
+		if string(got) != want {
+			t.Fatalf("unexpected network policy\ngot:\n%s\nwant:\n%s", got, want)
+		}
+	}
+	svc := &corev1.Service{
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Name: "http", Port: 8429, TargetPort: intstr.FromInt32(8429)}},
+		},
+	}
+
+	// clients aren't restricted
+	f(&vmv1beta1.EmbeddedNetworkPolicy{}, &NetworkPolicyRules{
+		Ports: NetworkPolicyPortsFromService(svc),
+		Peers: []networkingv1.NetworkPolicyPeer{NetworkPolicyPeerForPods(map[string]string{"app": "vmagent"}, "monitoring")},
+	}, `ingress:
+- ports:
+  - port: 8429
+    protocol: TCP
+podSelector:
+  matchLabels:
+    app.kubernetes.io/component: monitoring
+    app.kubernetes.io/instance: main
+    app.kubernetes.io/name: vmsingle
+    managed-by: vm-operator
+policyTypes:
+- Ingress
+`)
+
+	// clients with scraping vmagent and restricted egress
+	f(&vmv1beta1.EmbeddedNetworkPolicy{
+		AllowFrom: []networkingv1.NetworkPolicyPeer{{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "ops"}},
+		}},
+		Egress: true,
+	}, &NetworkPolicyRules{
+		Ports: NetworkPolicyPortsFromService(svc),
+		Peers: []networkingv1.NetworkPolicyPeer{NetworkPolicyPeerForPods(map[string]string{"app": "vmagent"}, "monitoring")},
+		Egress: []networkingv1.NetworkPolicyEgressRule{{
+			Ports: mustPortsFromURLs(t, "http://vmselect:8481/select/0/prometheus", "https://alertmanager"),
+		}},
+	}, `egress:
+- ports:
+  - port: 53
+    protocol: UDP
+  - port: 53
+    protocol: TCP
+- ports:
+  - port: 8481
+    protocol: TCP
+  - port: 443
+    protocol: TCP
+ingress:
+- from:
+  - namespaceSelector:
+      matchLabels:
+        team: ops
+  - namespaceSelector:
+      matchLabels:
+        kubernetes.io/metadata.name: monitoring
+    podSelector:
+      matchLabels:
+        app: vmagent
+  - namespaceSelector: {}
+    podSelector:
+      matchLabels:
+        control-plane: vm-operator
+  ports:
+  - port: 8429
+    protocol: TCP
+podSelector:
+  matchLabels:
+    app.kubernetes.io/component: monitoring
+    app.kubernetes.io/instance: main
+    app.kubernetes.io/name: vmsingle
+    managed-by: vm-operator
+policyTypes:
+- Ingress
+- Egress
+`)
+
+	// operator pods are allowed from operator namespace
+	cfg := config.MustGetBaseConfig()
+	operatorNamespace := cfg.NetworkPolicy.OperatorNamespace
+	cfg.NetworkPolicy.OperatorNamespace = "vm-system"
+	t.Cleanup(func() {
+		cfg.NetworkPolicy.OperatorNamespace = operatorNamespace
+	})
+	f(&vmv1beta1.EmbeddedNetworkPolicy{
+		AllowFrom: []networkingv1.NetworkPolicyPeer{{
+			PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "grafana"}},
+		}},
+	}, &NetworkPolicyRules{
+		Ports: NetworkPolicyPortsFromService(svc),
+	}, `ingress:
+- from:
+  - podSelector:
+      matchLabels:
+        app: grafana
+  - namespaceSelector:
+      matchLabels:
+        kubernetes.io/metadata.name: vm-system
+    podSelector:
+      matchLabels:
+        control-plane: vm-operator
+  ports:
+  - port: 8429
+    protocol: TCP
+podSelector:
+  matchLabels:
+    app.kubernetes.io/component: monitoring
+    app.kubernetes.io/instance: main
+    app.kubernetes.io/name: vmsingle
+    managed-by: vm-operator
+policyTypes:
+- Ingress
+`)
+
+	// egress isn't restricted without known destinations
+	f(&vmv1beta1.EmbeddedNetworkPolicy{Egress: true}, &NetworkPolicyRules{}, `podSelector:
+  matchLabels:
+    app.kubernetes.io/component: monitoring
+    app.kubernetes.io/instance: main
+    app.kubernetes.io/name: vmsingle
+    managed-by: vm-operator
+policyTypes:
+- Ingress
+`)
+}
+
+func mustPortsFromURLs(t *testing.T, urls ...string) []networkingv1.NetworkPolicyPort {
+	t.Helper()
+	ports, err := NetworkPolicyPortsFromURLs(urls...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return ports
+}
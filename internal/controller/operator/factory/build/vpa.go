@@ -0,0 +1,75 @@
+package build
+
+import (
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// VPAGVK defines group version kind of VerticalPodAutoscaler
+var VPAGVK = schema.GroupVersionKind{Group: "autoscaling.k8s.io", Version: "v1", Kind: "VerticalPodAutoscaler"}
+
+// VPA creates VerticalPodAutoscaler object
+// VPA manages resources only for the given application container,
+// sidecars like config-reloader are excluded from autoscaling
+func VPA(targetRef autoscalingv1.CrossVersionObjectReference, containerName string, spec *vmv1beta1.EmbeddedVPA, or []metav1.OwnerReference, lbls map[string]string, namespace string) *unstructured.Unstructured {
+	containerPolicy := map[string]any{
+		"containerName":       containerName,
+		"controlledResources": resourceNamesToAny(spec.ControlledResourcesOrDefault()),
+	}
+	if len(spec.MinAllowed) > 0 {
+		containerPolicy["minAllowed"] = resourceListToAny(spec.MinAllowed)
+	}
+	if len(spec.MaxAllowed) > 0 {
+		containerPolicy["maxAllowed"] = resourceListToAny(spec.MaxAllowed)
+	}
+	if spec.ControlledValues != "" {
+		containerPolicy["controlledValues"] = spec.ControlledValues
+	}
+	vpa := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"targetRef": map[string]any{
+				"apiVersion": targetRef.APIVersion,
+				"kind":       targetRef.Kind,
+				"name":       targetRef.Name,
+			},
+			"updatePolicy": map[string]any{
+				"updateMode": spec.UpdateModeOrDefault(),
+			},
+			"resourcePolicy": map[string]any{
+				"containerPolicies": []any{
+					containerPolicy,
+					map[string]any{
+						"containerName": "*",
+						"mode":          "Off",
+					},
+				},
+			},
+		},
+	}}
+	vpa.SetGroupVersionKind(VPAGVK)
+	vpa.SetName(targetRef.Name)
+	vpa.SetNamespace(namespace)
+	vpa.SetLabels(lbls)
+	vpa.SetOwnerReferences(or)
+	return vpa
+}
+
+func resourceNamesToAny(src []corev1.ResourceName) []any {
+	dst := make([]any, 0, len(src))
+	for _, name := range src {
+		dst = append(dst, string(name))
+	}
+	return dst
+}
+
+func resourceListToAny(src corev1.ResourceList) map[string]any {
+	dst := make(map[string]any, len(src))
+	for name, value := range src {
+		dst[string(name)] = value.String()
+	}
+	return dst
+}
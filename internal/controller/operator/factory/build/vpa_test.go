@@ -0,0 +1,83 @@
+package build
+
+import (
+	"testing"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/yaml"
+)
+
+func TestVPA(t *testing.T) {
+	f := func(spec *vmv1beta1.EmbeddedVPA, want string) {
+		t.Helper()
+		targetRef := autoscalingv1.CrossVersionObjectReference{
+			Name:       "vmstorage-main",
+			Kind:       "StatefulSet",
+			APIVersion: "apps/v1",
+		}
+		vpa := VPA(targetRef, "vmstorage", spec, nil, map[string]string{"app": "vmstorage"}, "default")
+		if vpa.GetName() != targetRef.Name || vpa.GetNamespace() != "default" {
+			t.Fatalf("unexpected vpa object: %s/%s", vpa.GetNamespace(), vpa.GetName())
+		}
+		got, err := yaml.Marshal(vpa.Object["spec"])
+		if err != nil {
+			t.Fatalf("cannot marshal vpa: %s", err)
+		}
+		if string(got) != want {
+			t.Fatalf("unexpected vpa\ngot:\n%s\nwant:\n%s", got, want)
+		}
+	}
+
+	// defaults
+	f(&vmv1beta1.EmbeddedVPA{}, `resourcePolicy:
+  containerPolicies:
+  - containerName: vmstorage
+    controlledResources:
+    - cpu
+    - memory
+  - containerName: '*'
+    mode: "Off"
+targetRef:
+  apiVersion: apps/v1
+  kind: StatefulSet
+  name: vmstorage-main
+updatePolicy:
+  updateMode: Auto
+`)
+
+	// with resource bounds
+	f(&vmv1beta1.EmbeddedVPA{
+		UpdateMode: "Initial",
+		MinAllowed: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+		},
+		MaxAllowed: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("8Gi"),
+			corev1.ResourceCPU:    resource.MustParse("2"),
+		},
+		ControlledResources: []corev1.ResourceName{corev1.ResourceMemory},
+		ControlledValues:    "RequestsOnly",
+	}, `resourcePolicy:
+  containerPolicies:
+  - containerName: vmstorage
+    controlledResources:
+    - memory
+    controlledValues: RequestsOnly
+    maxAllowed:
+      cpu: "2"
+      memory: 8Gi
+    minAllowed:
+      memory: 512Mi
+  - containerName: '*'
+    mode: "Off"
+targetRef:
+  apiVersion: apps/v1
+  kind: StatefulSet
+  name: vmstorage-main
+updatePolicy:
+  updateMode: Initial
+`)
+}
@@ -6,11 +6,14 @@ import (
 	"fmt"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -77,6 +80,22 @@ func removeFinalizeObjByNameWithOwnerReference(ctx context.Context, rclient clie
 	})
 }
 
+// finalizeHTTPRoute removes finalizer from Gateway API route created for given CRD
+func finalizeHTTPRoute(ctx context.Context, rclient client.Client, crd crdObject, route *vmv1beta1.EmbeddedHTTPRoute) error {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(build.HTTPRouteGVK(route.KindOrDefault()))
+	return removeFinalizeObjByName(ctx, rclient, obj, crd.PrefixedName(), crd.GetNSName())
+}
+
+// newVPAObject returns VerticalPodAutoscaler object with given metadata
+func newVPAObject(objMeta metav1.ObjectMeta) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(build.VPAGVK)
+	obj.SetName(objMeta.Name)
+	obj.SetNamespace(objMeta.Namespace)
+	return obj
+}
+
 // SafeDelete removes object, ignores notfound error.
 func SafeDelete(ctx context.Context, rclient client.Client, r client.Object) error {
 	if err := rclient.Delete(ctx, r); err != nil {
@@ -131,6 +150,10 @@ func finalizePBD(ctx context.Context, rclient client.Client, crd crdObject) erro
 	return removeFinalizeObjByName(ctx, rclient, &policyv1.PodDisruptionBudget{}, crd.PrefixedName(), crd.GetNSName())
 }
 
+func finalizeNetworkPolicy(ctx context.Context, rclient client.Client, crd crdObject) error {
+	return removeFinalizeObjByName(ctx, rclient, &networkingv1.NetworkPolicy{}, crd.PrefixedName(), crd.GetNSName())
+}
+
 func removeConfigReloaderRole(ctx context.Context, rclient client.Client, crd crdObject) error {
 	if err := removeFinalizeObjByName(ctx, rclient, &rbacv1.RoleBinding{}, crd.PrefixedName(), crd.GetNSName()); err != nil {
 		return err
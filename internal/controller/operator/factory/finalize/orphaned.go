@@ -3,7 +3,10 @@ package finalize
 import (
 	"context"
 
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -51,6 +54,70 @@ func discoverDeploymentsByLabels(ctx context.Context, rclient client.Client, ns
 	return resp, nil
 }
 
+// RemoveOrphanedDaemonSets removes daemonsets detached from given object
+func RemoveOrphanedDaemonSets(ctx context.Context, rclient client.Client, cr orphanedCRD, keepDaemonSets map[string]struct{}) error {
+	dssToRemove, err := discoverDaemonSetsByLabels(ctx, rclient, cr.GetNSName(), cr.SelectorLabels())
+	if err != nil {
+		return err
+	}
+	for i := range dssToRemove {
+		ds := dssToRemove[i]
+		if _, ok := keepDaemonSets[ds.Name]; !ok {
+			// need to remove
+			if err := RemoveFinalizer(ctx, rclient, ds); err != nil {
+				return err
+			}
+			if err := SafeDelete(ctx, rclient, ds); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// discoverDaemonSetsByLabels - returns daemonsets with given args.
+func discoverDaemonSetsByLabels(ctx context.Context, rclient client.Client, ns string, selector map[string]string) ([]*appsv1.DaemonSet, error) {
+	var dss appsv1.DaemonSetList
+	opts := client.ListOptions{
+		Namespace:     ns,
+		LabelSelector: labels.SelectorFromSet(selector),
+	}
+	if err := rclient.List(ctx, &dss, &opts); err != nil {
+		return nil, err
+	}
+	resp := make([]*appsv1.DaemonSet, 0, len(dss.Items))
+	for i := range dss.Items {
+		resp = append(resp, &dss.Items[i])
+	}
+	return resp, nil
+}
+
+// RemoveOrphanedVPAs removes verticalPodAutoscalers detached from given object
+func RemoveOrphanedVPAs(ctx context.Context, rclient client.Client, cr orphanedCRD, keepVPAs map[string]struct{}) error {
+	var vpas unstructured.UnstructuredList
+	vpas.SetGroupVersionKind(build.VPAGVK.GroupVersion().WithKind(build.VPAGVK.Kind + "List"))
+	opts := client.ListOptions{
+		Namespace:     cr.GetNSName(),
+		LabelSelector: labels.SelectorFromSet(cr.SelectorLabels()),
+	}
+	if err := rclient.List(ctx, &vpas, &opts); err != nil {
+		if meta.IsNoMatchError(err) {
+			// VerticalPodAutoscaler CRD isn't installed, nothing to remove
+			return nil
+		}
+		return err
+	}
+	for i := range vpas.Items {
+		vpa := &vpas.Items[i]
+		if _, ok := keepVPAs[vpa.GetName()]; !ok {
+			if err := SafeDelete(ctx, rclient, vpa); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // RemoveSvcArgs defines interface for service deletion
 type RemoveSvcArgs struct {
 	PrefixedName   func() string
@@ -0,0 +1,211 @@
+package finalize
+
+import (
+	"context"
+	"fmt"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OnVLInsertDelete removes all objects related to vlinsert component
+func OnVLInsertDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.VLCluster, obj *vmv1beta1.VLInsert) error {
+	objMeta := metav1.ObjectMeta{
+		Namespace: crd.Namespace,
+		Name:      crd.GetInsertName(),
+	}
+	objsToRemove := []client.Object{
+		&appsv1.Deployment{ObjectMeta: objMeta},
+		&v1.Service{ObjectMeta: objMeta},
+	}
+	if obj.ServiceSpec != nil && !obj.ServiceSpec.UseAsDefault {
+		objsToRemove = append(objsToRemove, &v1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: crd.Namespace,
+				Name:      obj.ServiceSpec.NameOrDefault(crd.GetInsertName()),
+			},
+		})
+	}
+	if obj.PodDisruptionBudget != nil {
+		objsToRemove = append(objsToRemove, &policyv1.PodDisruptionBudget{ObjectMeta: objMeta})
+	}
+	if obj.HPA != nil {
+		objsToRemove = append(objsToRemove, &v2.HorizontalPodAutoscaler{ObjectMeta: objMeta})
+	}
+	if obj.VPA != nil {
+		objsToRemove = append(objsToRemove, newVPAObject(objMeta))
+	}
+	if !ptr.Deref(obj.DisableSelfServiceScrape, false) {
+		objsToRemove = append(objsToRemove, &vmv1beta1.VMServiceScrape{ObjectMeta: objMeta})
+		objsToRemove = append(objsToRemove, &vmv1beta1.VMServiceScrape{ObjectMeta: metav1.ObjectMeta{Name: crd.GetInsertLBName(), Namespace: crd.Namespace}})
+	}
+	if crd.Spec.RequestsLoadBalancer.Enabled && !crd.Spec.RequestsLoadBalancer.DisableInsertBalancing {
+		objsToRemove = append(objsToRemove, &v1.Service{ObjectMeta: metav1.ObjectMeta{Name: crd.GetInsertLBName(), Namespace: crd.Namespace}})
+	}
+	for _, objToRemove := range objsToRemove {
+		if err := SafeDeleteWithFinalizer(ctx, rclient, objToRemove); err != nil {
+			return fmt.Errorf("failed to remove object=%s: %w", objToRemove.GetObjectKind().GroupVersionKind(), err)
+		}
+	}
+	return nil
+}
+
+// OnVLSelectDelete removes all objects related to vlselect component
+func OnVLSelectDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.VLCluster, obj *vmv1beta1.VLSelect) error {
+	objMeta := metav1.ObjectMeta{
+		Namespace: crd.Namespace,
+		Name:      crd.GetSelectName(),
+	}
+	objsToRemove := []client.Object{
+		&appsv1.Deployment{ObjectMeta: objMeta},
+		&v1.Service{ObjectMeta: objMeta},
+	}
+	if obj.ServiceSpec != nil && !obj.ServiceSpec.UseAsDefault {
+		objsToRemove = append(objsToRemove, &v1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: crd.Namespace,
+				Name:      obj.ServiceSpec.NameOrDefault(crd.GetSelectName()),
+			},
+		})
+	}
+	if obj.PodDisruptionBudget != nil {
+		objsToRemove = append(objsToRemove, &policyv1.PodDisruptionBudget{ObjectMeta: objMeta})
+	}
+	if obj.HPA != nil {
+		objsToRemove = append(objsToRemove, &v2.HorizontalPodAutoscaler{ObjectMeta: objMeta})
+	}
+	if obj.VPA != nil {
+		objsToRemove = append(objsToRemove, newVPAObject(objMeta))
+	}
+	if !ptr.Deref(obj.DisableSelfServiceScrape, false) {
+		objsToRemove = append(objsToRemove, &vmv1beta1.VMServiceScrape{ObjectMeta: objMeta})
+		objsToRemove = append(objsToRemove, &vmv1beta1.VMServiceScrape{ObjectMeta: metav1.ObjectMeta{Name: crd.GetSelectLBName(), Namespace: crd.Namespace}})
+	}
+	if crd.Spec.RequestsLoadBalancer.Enabled && !crd.Spec.RequestsLoadBalancer.DisableSelectBalancing {
+		objsToRemove = append(objsToRemove, &v1.Service{ObjectMeta: metav1.ObjectMeta{Name: crd.GetSelectLBName(), Namespace: crd.Namespace}})
+	}
+	for _, objToRemove := range objsToRemove {
+		if err := SafeDeleteWithFinalizer(ctx, rclient, objToRemove); err != nil {
+			return fmt.Errorf("failed to remove object=%s: %w", objToRemove.GetObjectKind().GroupVersionKind(), err)
+		}
+	}
+	return nil
+}
+
+// OnVLStorageDelete removes all objects related to vlstorage component
+func OnVLStorageDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.VLCluster, obj *vmv1beta1.VLStorage) error {
+	objMeta := metav1.ObjectMeta{
+		Namespace: crd.Namespace,
+		Name:      crd.GetStorageName(),
+	}
+	objsToRemove := []client.Object{
+		&appsv1.StatefulSet{ObjectMeta: objMeta},
+		&v1.Service{ObjectMeta: objMeta},
+	}
+	if obj.ServiceSpec != nil && !obj.ServiceSpec.UseAsDefault {
+		objsToRemove = append(objsToRemove, &v1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: crd.Namespace,
+				Name:      obj.ServiceSpec.NameOrDefault(crd.GetStorageName()),
+			},
+		})
+	}
+	if obj.PodDisruptionBudget != nil {
+		objsToRemove = append(objsToRemove, &policyv1.PodDisruptionBudget{ObjectMeta: objMeta})
+	}
+	if obj.VPA != nil {
+		objsToRemove = append(objsToRemove, newVPAObject(objMeta))
+	}
+	if !ptr.Deref(obj.DisableSelfServiceScrape, false) {
+		objsToRemove = append(objsToRemove, &vmv1beta1.VMServiceScrape{ObjectMeta: objMeta})
+	}
+	for _, objToRemove := range objsToRemove {
+		if err := SafeDeleteWithFinalizer(ctx, rclient, objToRemove); err != nil {
+			return fmt.Errorf("failed to remove object=%s: %w", objToRemove.GetObjectKind().GroupVersionKind(), err)
+		}
+	}
+	return nil
+}
+
+// OnVLClusterDelete deletes all vlcluster related resources
+func OnVLClusterDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.VLCluster) error {
+	if crd.Spec.VLInsert != nil {
+		if err := OnVLInsertDelete(ctx, rclient, crd, crd.Spec.VLInsert); err != nil {
+			return fmt.Errorf("cannot remove vlinsert component objects: %w", err)
+		}
+	}
+	if crd.Spec.VLSelect != nil {
+		if err := OnVLSelectDelete(ctx, rclient, crd, crd.Spec.VLSelect); err != nil {
+			return fmt.Errorf("cannot remove vlselect component objects: %w", err)
+		}
+	}
+	if crd.Spec.VLStorage != nil {
+		if err := OnVLStorageDelete(ctx, rclient, crd, crd.Spec.VLStorage); err != nil {
+			return fmt.Errorf("cannot remove vlstorage component objects: %w", err)
+		}
+	}
+	if err := deleteSA(ctx, rclient, crd); err != nil {
+		return err
+	}
+	if crd.Spec.RequestsLoadBalancer.Enabled {
+		if err := OnVLClusterLoadBalancerDelete(ctx, rclient, crd); err != nil {
+			return fmt.Errorf("cannot delete vlcluster loadbalancer components: %w", err)
+		}
+	}
+	return removeFinalizeObjByName(ctx, rclient, crd, crd.Name, crd.Namespace)
+}
+
+// OnVLClusterLoadBalancerDelete removes vmauth loadbalancer components for vlcluster
+func OnVLClusterLoadBalancerDelete(ctx context.Context, rclient client.Client, cr *vmv1beta1.VLCluster) error {
+	lbMeta := metav1.ObjectMeta{
+		Namespace: cr.Namespace,
+		Name:      cr.GetVMAuthLBName(),
+	}
+	objsToRemove := []client.Object{
+		&appsv1.Deployment{ObjectMeta: lbMeta},
+		&v1.Secret{ObjectMeta: lbMeta},
+		&v1.Service{ObjectMeta: lbMeta},
+	}
+	if !ptr.Deref(cr.Spec.RequestsLoadBalancer.Spec.DisableSelfServiceScrape, false) {
+		objsToRemove = append(objsToRemove, &vmv1beta1.VMServiceScrape{ObjectMeta: lbMeta})
+	}
+	if cr.Spec.RequestsLoadBalancer.Spec.PodDisruptionBudget != nil {
+		objsToRemove = append(objsToRemove, &policyv1.PodDisruptionBudget{ObjectMeta: lbMeta})
+	}
+	if cr.Spec.RequestsLoadBalancer.Spec.VPA != nil {
+		objsToRemove = append(objsToRemove, newVPAObject(lbMeta))
+	}
+	if cr.Spec.VLSelect != nil {
+		if !ptr.Deref(cr.Spec.VLSelect.DisableSelfServiceScrape, false) {
+			objsToRemove = append(objsToRemove, &vmv1beta1.VMServiceScrape{
+				ObjectMeta: metav1.ObjectMeta{Name: cr.GetSelectLBName(), Namespace: cr.Namespace}})
+		}
+		objsToRemove = append(objsToRemove, &v1.Service{ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.GetSelectLBName(),
+			Namespace: cr.Namespace,
+		}})
+	}
+	if cr.Spec.VLInsert != nil {
+		if !ptr.Deref(cr.Spec.VLInsert.DisableSelfServiceScrape, false) {
+			objsToRemove = append(objsToRemove, &vmv1beta1.VMServiceScrape{
+				ObjectMeta: metav1.ObjectMeta{Name: cr.GetInsertLBName(), Namespace: cr.Namespace}})
+		}
+		objsToRemove = append(objsToRemove, &v1.Service{ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.GetInsertLBName(),
+			Namespace: cr.Namespace,
+		}})
+	}
+	for _, objToRemove := range objsToRemove {
+		if err := SafeDeleteWithFinalizer(ctx, rclient, objToRemove); err != nil {
+			return fmt.Errorf("failed to remove lb object=%s: %w", objToRemove.GetObjectKind().GroupVersionKind(), err)
+		}
+	}
+	return nil
+}
@@ -21,6 +21,9 @@ func OnVMAgentDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.
 	if err := removeFinalizeObjByName(ctx, rclient, &appsv1.StatefulSet{}, crd.PrefixedName(), crd.Namespace); err != nil {
 		return err
 	}
+	if err := removeFinalizeObjByName(ctx, rclient, &appsv1.DaemonSet{}, crd.PrefixedName(), crd.Namespace); err != nil {
+		return err
+	}
 
 	if err := RemoveOrphanedDeployments(ctx, rclient, crd, nil); err != nil {
 		return err
@@ -28,6 +31,9 @@ func OnVMAgentDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.
 	if err := RemoveOrphanedSTSs(ctx, rclient, crd, nil); err != nil {
 		return err
 	}
+	if err := RemoveOrphanedDaemonSets(ctx, rclient, crd, nil); err != nil {
+		return err
+	}
 	// check service
 	if err := removeFinalizeObjByName(ctx, rclient, &corev1.Service{}, crd.PrefixedName(), crd.Namespace); err != nil {
 		return err
@@ -61,6 +67,11 @@ func OnVMAgentDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.
 			return err
 		}
 	}
+	if crd.Spec.NetworkPolicy != nil {
+		if err := finalizeNetworkPolicy(ctx, rclient, crd); err != nil {
+			return err
+		}
+	}
 	// remove vmagents service discovery rbac.
 	if config.IsClusterWideAccessAllowed() {
 		if err := removeFinalizeObjByName(ctx, rclient, &rbacv1.ClusterRoleBinding{}, crd.GetClusterRoleName(), crd.GetNSName()); err != nil {
@@ -17,6 +17,10 @@ func OnVMAlertDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.
 	if err := removeFinalizeObjByName(ctx, rclient, &appsv1.Deployment{}, crd.PrefixedName(), crd.Namespace); err != nil {
 		return err
 	}
+	// check sharded deployments
+	if err := RemoveOrphanedDeployments(ctx, rclient, crd, nil); err != nil {
+		return err
+	}
 	// check service
 	if err := removeFinalizeObjByName(ctx, rclient, &corev1.Service{}, crd.PrefixedName(), crd.Namespace); err != nil {
 		return err
@@ -43,6 +47,11 @@ func OnVMAlertDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.
 			return err
 		}
 	}
+	if crd.Spec.NetworkPolicy != nil {
+		if err := finalizeNetworkPolicy(ctx, rclient, crd); err != nil {
+			return err
+		}
+	}
 	if err := deleteSA(ctx, rclient, crd); err != nil {
 		return err
 	}
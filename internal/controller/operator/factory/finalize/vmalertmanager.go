@@ -19,6 +19,11 @@ func OnVMAlertManagerDelete(ctx context.Context, rclient client.Client, crd *vmv
 	if err := removeFinalizeObjByName(ctx, rclient, &v1.Service{}, crd.PrefixedName(), crd.Namespace); err != nil {
 		return err
 	}
+	if crd.Spec.HTTPRoute != nil {
+		if err := finalizeHTTPRoute(ctx, rclient, crd, crd.Spec.HTTPRoute); err != nil {
+			return err
+		}
+	}
 	if crd.Spec.ServiceSpec != nil {
 		if err := removeFinalizeObjByName(ctx, rclient, &v1.Service{}, crd.Spec.ServiceSpec.NameOrDefault(crd.PrefixedName()), crd.Namespace); err != nil {
 			return err
@@ -42,6 +47,11 @@ func OnVMAlertManagerDelete(ctx context.Context, rclient client.Client, crd *vmv
 			return err
 		}
 	}
+	if crd.Spec.NetworkPolicy != nil {
+		if err := finalizeNetworkPolicy(ctx, rclient, crd); err != nil {
+			return err
+		}
+	}
 
 	if err := deleteSA(ctx, rclient, crd); err != nil {
 		return err
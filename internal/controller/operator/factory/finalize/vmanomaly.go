@@ -0,0 +1,50 @@
+package finalize
+
+import (
+	"context"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OnVMAnomalyDelete deletes all vmanomaly related resources
+func OnVMAnomalyDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.VMAnomaly) error {
+	// check deployment
+	if err := removeFinalizeObjByName(ctx, rclient, &appsv1.Deployment{}, crd.PrefixedName(), crd.Namespace); err != nil {
+		return err
+	}
+	// check sharded deployments
+	if err := RemoveOrphanedDeployments(ctx, rclient, crd, nil); err != nil {
+		return err
+	}
+	// check service
+	if err := removeFinalizeObjByName(ctx, rclient, &corev1.Service{}, crd.PrefixedName(), crd.Namespace); err != nil {
+		return err
+	}
+	// config secret
+	if err := removeFinalizeObjByName(ctx, rclient, &corev1.Secret{}, crd.PrefixedName(), crd.Namespace); err != nil {
+		return err
+	}
+
+	// check PDB
+	if crd.Spec.PodDisruptionBudget != nil {
+		if err := finalizePBD(ctx, rclient, crd); err != nil {
+			return err
+		}
+	}
+	if err := deleteSA(ctx, rclient, crd); err != nil {
+		return err
+	}
+
+	if crd.Spec.ServiceSpec != nil {
+		if err := removeFinalizeObjByName(ctx, rclient, &corev1.Service{}, crd.Spec.ServiceSpec.NameOrDefault(crd.PrefixedName()), crd.Namespace); err != nil {
+			return err
+		}
+	}
+	if err := removeFinalizeObjByName(ctx, rclient, crd, crd.Name, crd.Namespace); err != nil {
+		return err
+	}
+	return nil
+}
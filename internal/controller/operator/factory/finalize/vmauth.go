@@ -39,6 +39,11 @@ func OnVMAuthDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.V
 			return err
 		}
 	}
+	if crd.Spec.NetworkPolicy != nil {
+		if err := finalizeNetworkPolicy(ctx, rclient, crd); err != nil {
+			return err
+		}
+	}
 	if crd.Spec.Ingress != nil {
 		vmauthIngress := &networkingv1.Ingress{
 			ObjectMeta: metav1.ObjectMeta{
@@ -54,6 +59,12 @@ func OnVMAuthDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.V
 		}
 	}
 
+	if crd.Spec.HTTPRoute != nil {
+		if err := finalizeHTTPRoute(ctx, rclient, crd, crd.Spec.HTTPRoute); err != nil {
+			return err
+		}
+	}
+
 	// check ingress
 	if err := removeFinalizeObjByName(ctx, rclient, &networkingv1.Ingress{}, crd.PrefixedName(), crd.Namespace); err != nil {
 		return err
@@ -9,6 +9,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	v2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
@@ -36,9 +37,15 @@ func OnVMInsertDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1
 	if obj.PodDisruptionBudget != nil {
 		objsToRemove = append(objsToRemove, &policyv1.PodDisruptionBudget{ObjectMeta: objMeta})
 	}
+	if crd.Spec.NetworkPolicy != nil {
+		objsToRemove = append(objsToRemove, &networkingv1.NetworkPolicy{ObjectMeta: objMeta})
+	}
 	if obj.HPA != nil {
 		objsToRemove = append(objsToRemove, &v2.HorizontalPodAutoscaler{ObjectMeta: objMeta})
 	}
+	if obj.VPA != nil {
+		objsToRemove = append(objsToRemove, newVPAObject(objMeta))
+	}
 	if !ptr.Deref(obj.DisableSelfServiceScrape, false) {
 		objsToRemove = append(objsToRemove, &vmv1beta1.VMServiceScrape{ObjectMeta: objMeta})
 		objsToRemove = append(objsToRemove, &vmv1beta1.VMServiceScrape{ObjectMeta: metav1.ObjectMeta{Name: crd.GetInsertLBName(), Namespace: crd.Namespace}})
@@ -76,9 +83,15 @@ func OnVMSelectDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1
 	if obj.PodDisruptionBudget != nil {
 		objsToRemove = append(objsToRemove, &policyv1.PodDisruptionBudget{ObjectMeta: objMeta})
 	}
+	if crd.Spec.NetworkPolicy != nil {
+		objsToRemove = append(objsToRemove, &networkingv1.NetworkPolicy{ObjectMeta: objMeta})
+	}
 	if obj.HPA != nil {
 		objsToRemove = append(objsToRemove, &v2.HorizontalPodAutoscaler{ObjectMeta: objMeta})
 	}
+	if obj.VPA != nil {
+		objsToRemove = append(objsToRemove, newVPAObject(objMeta))
+	}
 	if !ptr.Deref(obj.DisableSelfServiceScrape, false) {
 		objsToRemove = append(objsToRemove, &vmv1beta1.VMServiceScrape{ObjectMeta: objMeta})
 		objsToRemove = append(objsToRemove, &vmv1beta1.VMServiceScrape{ObjectMeta: metav1.ObjectMeta{Name: crd.GetSelectLBName(), Namespace: crd.Namespace}})
@@ -115,6 +128,12 @@ func OnVMStorageDelete(ctx context.Context, rclient client.Client, crd *vmv1beta
 	if obj.PodDisruptionBudget != nil {
 		objsToRemove = append(objsToRemove, &policyv1.PodDisruptionBudget{ObjectMeta: objMeta})
 	}
+	if crd.Spec.NetworkPolicy != nil {
+		objsToRemove = append(objsToRemove, &networkingv1.NetworkPolicy{ObjectMeta: objMeta})
+	}
+	if obj.VPA != nil {
+		objsToRemove = append(objsToRemove, newVPAObject(objMeta))
+	}
 	if !ptr.Deref(obj.DisableSelfServiceScrape, false) {
 		objsToRemove = append(objsToRemove, &vmv1beta1.VMServiceScrape{ObjectMeta: objMeta})
 	}
@@ -129,8 +148,30 @@ func OnVMStorageDelete(ctx context.Context, rclient client.Client, crd *vmv1beta
 
 // OnVMClusterDelete deletes all vmcluster related resources
 func OnVMClusterDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.VMCluster) error {
-	// check deployment
+	if err := OnVMClusterComponentsDelete(ctx, rclient, crd); err != nil {
+		return err
+	}
+	if crd.IsZoned() {
+		for _, zone := range crd.Spec.Zones.Items {
+			if err := OnVMClusterComponentsDelete(ctx, rclient, crd.ZoneView(zone.Name)); err != nil {
+				return fmt.Errorf("cannot remove components of zone=%q: %w", zone.Name, err)
+			}
+		}
+	}
 
+	if err := deleteSA(ctx, rclient, crd); err != nil {
+		return err
+	}
+	if crd.Spec.RequestsLoadBalancer.Enabled {
+		if err := OnVMClusterLoadBalancerDelete(ctx, rclient, crd); err != nil {
+			return fmt.Errorf("cannot delete vmcluster loadbalancer components: %w", err)
+		}
+	}
+	return removeFinalizeObjByName(ctx, rclient, crd, crd.Name, crd.Namespace)
+}
+
+// OnVMClusterComponentsDelete removes vminsert, vmselect and vmstorage objects of the cluster
+func OnVMClusterComponentsDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1.VMCluster) error {
 	if crd.Spec.VMInsert != nil {
 		if err := OnVMInsertDelete(ctx, rclient, crd, crd.Spec.VMInsert); err != nil {
 			return fmt.Errorf("cannot remove vminsert component objects: %w", err)
@@ -147,16 +188,7 @@ func OnVMClusterDelete(ctx context.Context, rclient client.Client, crd *vmv1beta
 			return fmt.Errorf("cannot remove vmstorage component objects: %w", err)
 		}
 	}
-
-	if err := deleteSA(ctx, rclient, crd); err != nil {
-		return err
-	}
-	if crd.Spec.RequestsLoadBalancer.Enabled {
-		if err := OnVMClusterLoadBalancerDelete(ctx, rclient, crd); err != nil {
-			return fmt.Errorf("cannot delete vmcluster loadbalancer components: %w", err)
-		}
-	}
-	return removeFinalizeObjByName(ctx, rclient, crd, crd.Name, crd.Namespace)
+	return nil
 }
 
 // OnVMClusterLoadBalancerDelete removes vmauth loadbalancer components for vmcluster
@@ -177,6 +209,9 @@ func OnVMClusterLoadBalancerDelete(ctx context.Context, rclient client.Client, c
 	if cr.Spec.RequestsLoadBalancer.Spec.PodDisruptionBudget != nil {
 		objsToRemove = append(objsToRemove, &policyv1.PodDisruptionBudget{ObjectMeta: lbMeta})
 	}
+	if cr.Spec.RequestsLoadBalancer.Spec.VPA != nil {
+		objsToRemove = append(objsToRemove, newVPAObject(lbMeta))
+	}
 
 	if cr.Spec.VMSelect != nil {
 		if !ptr.Deref(cr.Spec.VMSelect.DisableSelfServiceScrape, false) {
@@ -19,6 +19,16 @@ func OnVMSingleDelete(ctx context.Context, rclient client.Client, crd *vmv1beta1
 	if err := removeFinalizeObjByName(ctx, rclient, &v1.Service{}, crd.PrefixedName(), crd.Namespace); err != nil {
 		return err
 	}
+	if crd.Spec.HTTPRoute != nil {
+		if err := finalizeHTTPRoute(ctx, rclient, crd, crd.Spec.HTTPRoute); err != nil {
+			return err
+		}
+	}
+	if crd.Spec.NetworkPolicy != nil {
+		if err := finalizeNetworkPolicy(ctx, rclient, crd); err != nil {
+			return err
+		}
+	}
 	if crd.Spec.Storage != nil {
 		if err := removeFinalizeObjByNameWithOwnerReference(ctx, rclient, &v1.PersistentVolumeClaim{}, crd.PrefixedName(), crd.Namespace, crd.Spec.RemovePvcAfterDelete); err != nil {
 			return err
@@ -0,0 +1,523 @@
+package k8stools
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/pmezard/go-difflib/difflib"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+)
+
+// ChangeAction defines kind of change recorded by DryRunClient
+type ChangeAction string
+
+// Supported change actions
+const (
+	ChangeActionCreate ChangeAction = "create"
+	ChangeActionUpdate ChangeAction = "update"
+	ChangeActionDelete ChangeAction = "delete"
+)
+
+// ObjectChange describes a single object change recorded by DryRunClient
+type ObjectChange struct {
+	Action ChangeAction
+	GVK    schema.GroupVersionKind
+	Key    types.NamespacedName
+	// Before is a live object state, nil for created objects
+	Before client.Object
+	// After is a desired object state, nil for deleted objects
+	After client.Object
+}
+
+// String implements Stringer interface
+func (oc *ObjectChange) String() string {
+	return fmt.Sprintf("%s %s %s", oc.Action, oc.GVK.Kind, oc.Key)
+}
+
+// Diff returns unified diff between live and desired object states
+// Secret values are replaced with its sha256 sums
+func (oc *ObjectChange) Diff() (string, error) {
+	before, err := renderForDiff(oc.GVK, oc.Before)
+	if err != nil {
+		return "", fmt.Errorf("cannot render live state of %s: %w", oc, err)
+	}
+	after, err := renderForDiff(oc.GVK, oc.After)
+	if err != nil {
+		return "", fmt.Errorf("cannot render desired state of %s: %w", oc, err)
+	}
+	if before == after {
+		return "", nil
+	}
+	path := strings.ToLower(oc.GVK.Kind) + "/" + oc.Key.String()
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(before),
+		B:        difflib.SplitLines(after),
+		FromFile: "live/" + path,
+		ToFile:   "dry-run/" + path,
+		Context:  3,
+	})
+}
+
+type objectKey struct {
+	gvk schema.GroupVersionKind
+	types.NamespacedName
+}
+
+// DryRunClient performs read requests to the origin client
+// and applies write requests to the in-memory copy of objects
+//
+// It allows to execute reconcile functions without changing actual objects
+// and records all changes of objects, which could be applied to the origin client.
+type DryRunClient struct {
+	origin client.Client
+	mem    client.Client
+
+	mu      sync.Mutex
+	live    map[objectKey]client.Object
+	changed map[objectKey]struct{}
+	order   []objectKey
+}
+
+// NewDryRunClient returns DryRunClient for given origin client
+func NewDryRunClient(origin client.Client) *DryRunClient {
+	sc := origin.Scheme()
+	var withStatus []client.Object
+	for gvk, t := range sc.AllKnownTypes() {
+		if strings.HasSuffix(gvk.Kind, "List") || !strings.HasSuffix(gvk.Group, "victoriametrics.com") {
+			continue
+		}
+		if _, ok := t.FieldByName("Status"); !ok {
+			continue
+		}
+		if obj, ok := reflect.New(t).Interface().(client.Object); ok {
+			withStatus = append(withStatus, obj)
+		}
+	}
+	return &DryRunClient{
+		origin:  origin,
+		mem:     fake.NewClientBuilder().WithScheme(sc).WithRESTMapper(origin.RESTMapper()).WithStatusSubresource(withStatus...).Build(),
+		live:    make(map[objectKey]client.Object),
+		changed: make(map[objectKey]struct{}),
+	}
+}
+
+// IsDryRun checks if given client doesn't apply changes to the objects
+func IsDryRun(rclient client.Client) bool {
+	_, ok := rclient.(*DryRunClient)
+	return ok
+}
+
+func (c *DryRunClient) keyFor(obj runtime.Object, nsn types.NamespacedName) (objectKey, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.origin.Scheme())
+	if err != nil {
+		return objectKey{}, err
+	}
+	return objectKey{gvk: gvk, NamespacedName: nsn}, nil
+}
+
+// load copies object from origin client into memory at first access
+func (c *DryRunClient) load(ctx context.Context, obj client.Object, nsn types.NamespacedName) (objectKey, error) {
+	key, err := c.keyFor(obj, nsn)
+	if err != nil {
+		return key, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.live[key]; ok {
+		return key, nil
+	}
+	liveObj := c.newObject(key.gvk)
+	if err := c.origin.Get(ctx, nsn, liveObj); err != nil {
+		if !errors.IsNotFound(err) {
+			return key, err
+		}
+		liveObj = nil
+	}
+	if err := c.storeLocked(key, liveObj); err != nil {
+		return key, err
+	}
+	return key, nil
+}
+
+// newObject returns typed object for given gvk
+// objects, which are not registered at scheme, like Gateway API routes, are returned as unstructured
+func (c *DryRunClient) newObject(gvk schema.GroupVersionKind) client.Object {
+	if ro, err := c.origin.Scheme().New(gvk); err == nil {
+		if obj, ok := ro.(client.Object); ok {
+			return obj
+		}
+	}
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	return u
+}
+
+func (c *DryRunClient) storeLocked(key objectKey, liveObj client.Object) error {
+	c.live[key] = liveObj
+	if liveObj == nil {
+		return nil
+	}
+	toMem := liveObj.DeepCopyObject().(client.Object)
+	toMem.SetResourceVersion("")
+	if err := c.mem.Create(context.Background(), toMem); err != nil {
+		return fmt.Errorf("cannot copy %s %s into memory: %w", key.gvk.Kind, key.NamespacedName, err)
+	}
+	return nil
+}
+
+func (c *DryRunClient) markChanged(key objectKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.changed[key]; ok {
+		return
+	}
+	c.changed[key] = struct{}{}
+	c.order = append(c.order, key)
+}
+
+// Get implements client.Client interface
+func (c *DryRunClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if _, err := c.load(ctx, obj, key); err != nil {
+		return err
+	}
+	return c.mem.Get(ctx, key, obj, opts...)
+}
+
+// List implements client.Client interface
+func (c *DryRunClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	liveList := list.DeepCopyObject().(client.ObjectList)
+	if err := c.origin.List(ctx, liveList, opts...); err != nil {
+		return err
+	}
+	items, err := meta.ExtractList(liveList)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok {
+			c.mu.Unlock()
+			return fmt.Errorf("BUG: unexpected list item type: %T", item)
+		}
+		key, err := c.keyFor(obj, client.ObjectKeyFromObject(obj))
+		if err != nil {
+			c.mu.Unlock()
+			return err
+		}
+		if _, ok := c.live[key]; ok {
+			continue
+		}
+		if err := c.storeLocked(key, obj.DeepCopyObject().(client.Object)); err != nil {
+			c.mu.Unlock()
+			return err
+		}
+	}
+	c.mu.Unlock()
+	return c.mem.List(ctx, list, opts...)
+}
+
+// Create implements client.Client interface
+func (c *DryRunClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	key, err := c.load(ctx, obj, client.ObjectKeyFromObject(obj))
+	if err != nil {
+		return err
+	}
+	if err := c.mem.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.markChanged(key)
+	return nil
+}
+
+// Delete implements client.Client interface
+func (c *DryRunClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	key, err := c.load(ctx, obj, client.ObjectKeyFromObject(obj))
+	if err != nil {
+		return err
+	}
+	if err := c.mem.Delete(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.markChanged(key)
+	return nil
+}
+
+// Update implements client.Client interface
+func (c *DryRunClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	key, err := c.load(ctx, obj, client.ObjectKeyFromObject(obj))
+	if err != nil {
+		return err
+	}
+	if err := c.syncResourceVersion(ctx, obj); err != nil {
+		return err
+	}
+	if err := c.mem.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.markChanged(key)
+	return nil
+}
+
+// syncResourceVersion sets resourceVersion of in-memory object
+// objects from origin client could be passed to the Update call and its version mismatch in-memory version
+func (c *DryRunClient) syncResourceVersion(ctx context.Context, obj client.Object) error {
+	current := obj.DeepCopyObject().(client.Object)
+	if err := c.mem.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	obj.SetResourceVersion(current.GetResourceVersion())
+	return nil
+}
+
+// Patch implements client.Client interface
+func (c *DryRunClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	key, err := c.load(ctx, obj, client.ObjectKeyFromObject(obj))
+	if err != nil {
+		return err
+	}
+	if err := c.mem.Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+	c.markChanged(key)
+	return nil
+}
+
+// DeleteAllOf implements client.Client interface
+func (c *DryRunClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	return fmt.Errorf("DeleteAllOf is not supported by dry-run client")
+}
+
+// Status implements client.StatusClient interface
+func (c *DryRunClient) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+// SubResource implements client.SubResourceClientConstructor interface
+func (c *DryRunClient) SubResource(subResource string) client.SubResourceClient {
+	return &dryRunSubResourceClient{c: c, subResource: subResource}
+}
+
+// Scheme implements client.Client interface
+func (c *DryRunClient) Scheme() *runtime.Scheme {
+	return c.origin.Scheme()
+}
+
+// RESTMapper implements client.Client interface
+func (c *DryRunClient) RESTMapper() meta.RESTMapper {
+	return c.origin.RESTMapper()
+}
+
+// GroupVersionKindFor implements client.Client interface
+func (c *DryRunClient) GroupVersionKindFor(obj runtime.Object) (schema.GroupVersionKind, error) {
+	return c.origin.GroupVersionKindFor(obj)
+}
+
+// IsObjectNamespaced implements client.Client interface
+func (c *DryRunClient) IsObjectNamespaced(obj runtime.Object) (bool, error) {
+	return c.origin.IsObjectNamespaced(obj)
+}
+
+// Changes returns all recorded changes in order of its first appearance
+// Objects with changed status only are omitted
+func (c *DryRunClient) Changes(ctx context.Context) ([]ObjectChange, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var changes []ObjectChange
+	for _, key := range c.order {
+		before := c.live[key]
+		after := c.newObject(key.gvk)
+		if err := c.mem.Get(ctx, key.NamespacedName, after); err != nil {
+			if !errors.IsNotFound(err) {
+				return nil, err
+			}
+			after = nil
+		}
+		oc := ObjectChange{GVK: key.gvk, Key: key.NamespacedName, Before: before, After: after}
+		switch {
+		case before == nil && after == nil:
+			continue
+		case before == nil:
+			oc.Action = ChangeActionCreate
+		case after == nil:
+			oc.Action = ChangeActionDelete
+		default:
+			diff, err := oc.Diff()
+			if err != nil {
+				return nil, err
+			}
+			if len(diff) == 0 {
+				continue
+			}
+			oc.Action = ChangeActionUpdate
+		}
+		changes = append(changes, oc)
+	}
+	return changes, nil
+}
+
+// renderForDiff returns yaml representation of the object for diff
+func renderForDiff(gvk schema.GroupVersionKind, obj client.Object) (string, error) {
+	if obj == nil {
+		return "", nil
+	}
+	u, err := toCleanUnstructured(gvk, obj)
+	if err != nil {
+		return "", err
+	}
+	if gvk.Group == "" && gvk.Kind == "Secret" {
+		maskSecretValues(u, "data")
+		maskSecretValues(u, "stringData")
+	}
+	out, err := yaml.Marshal(u)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// RenderObject returns yaml manifest of the given object
+// Secret data is rendered as stringData with decompressed gzip values,
+// binary values are kept at data
+func RenderObject(gvk schema.GroupVersionKind, obj client.Object) ([]byte, error) {
+	u, err := toCleanUnstructured(gvk, obj)
+	if err != nil {
+		return nil, err
+	}
+	if secret, ok := obj.(*corev1.Secret); ok && len(secret.Data) > 0 {
+		stringData := make(map[string]any)
+		binaryData := make(map[string]any)
+		for k, v := range secret.Data {
+			if strings.HasSuffix(k, ".gz") {
+				if decompressed, err := gunzip(v); err == nil {
+					k = strings.TrimSuffix(k, ".gz")
+					v = decompressed
+				}
+			}
+			if utf8.Valid(v) {
+				stringData[k] = string(v)
+				continue
+			}
+			binaryData[k] = base64.StdEncoding.EncodeToString(v)
+		}
+		delete(u, "data")
+		if len(binaryData) > 0 {
+			u["data"] = binaryData
+		}
+		if len(stringData) > 0 {
+			u["stringData"] = stringData
+		}
+	}
+	return yaml.Marshal(u)
+}
+
+func gunzip(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// toCleanUnstructured converts object into unstructured form
+// without status and server-side populated metadata fields
+func toCleanUnstructured(gvk schema.GroupVersionKind, obj client.Object) (map[string]any, error) {
+	data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{Object: data}
+	u.SetGroupVersionKind(gvk)
+	for _, field := range []string{"resourceVersion", "uid", "generation", "creationTimestamp", "managedFields"} {
+		unstructured.RemoveNestedField(u.Object, "metadata", field)
+	}
+	unstructured.RemoveNestedField(u.Object, "status")
+	return u.Object, nil
+}
+
+func maskSecretValues(obj map[string]any, field string) {
+	values, ok := obj[field].(map[string]any)
+	if !ok {
+		return
+	}
+	for k, v := range values {
+		values[k] = fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(fmt.Sprint(v))))
+	}
+}
+
+type dryRunSubResourceClient struct {
+	c           *DryRunClient
+	subResource string
+}
+
+// Get implements client.SubResourceClient interface
+func (sc *dryRunSubResourceClient) Get(ctx context.Context, obj, subResource client.Object, opts ...client.SubResourceGetOption) error {
+	if _, err := sc.c.load(ctx, obj, client.ObjectKeyFromObject(obj)); err != nil {
+		return err
+	}
+	return sc.c.mem.SubResource(sc.subResource).Get(ctx, obj, subResource, opts...)
+}
+
+// Create implements client.SubResourceClient interface
+// subresources, like pod eviction, have no effect on dry-run
+func (sc *dryRunSubResourceClient) Create(_ context.Context, _, _ client.Object, _ ...client.SubResourceCreateOption) error {
+	return nil
+}
+
+// Update implements client.SubResourceClient interface
+func (sc *dryRunSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	if sc.subResource != "status" {
+		return nil
+	}
+	key, err := sc.c.load(ctx, obj, client.ObjectKeyFromObject(obj))
+	if err != nil {
+		return err
+	}
+	if err := sc.c.syncResourceVersion(ctx, obj); err != nil {
+		return err
+	}
+	if err := sc.c.mem.Status().Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	sc.c.markChanged(key)
+	return nil
+}
+
+// Patch implements client.SubResourceClient interface
+func (sc *dryRunSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	if sc.subResource != "status" {
+		return nil
+	}
+	key, err := sc.c.load(ctx, obj, client.ObjectKeyFromObject(obj))
+	if err != nil {
+		return err
+	}
+	if err := sc.c.mem.Status().Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+	sc.c.markChanged(key)
+	return nil
+}
@@ -0,0 +1,205 @@
+package k8stools
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"strings"
+	"testing"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+func TestDryRunClient(t *testing.T) {
+	ctx := context.Background()
+	origin := GetTestClientWithObjects([]runtime.Object{
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "vmagent-main", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(1))},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "vmagent-main", Namespace: "default"},
+			Data:       map[string][]byte{"config": []byte("old")},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "vmagent-stale", Namespace: "default", Labels: map[string]string{"app": "vmagent"}},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "vmagent-main", Namespace: "default", Labels: map[string]string{"app": "vmagent"}},
+		},
+		&vmv1beta1.VMAgent{
+			ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+		},
+	})
+	drc := NewDryRunClient(origin)
+	if !IsDryRun(drc) || IsDryRun(origin) {
+		t.Fatalf("unexpected IsDryRun result")
+	}
+
+	// update
+	var dep appsv1.Deployment
+	if err := drc.Get(ctx, types.NamespacedName{Name: "vmagent-main", Namespace: "default"}, &dep); err != nil {
+		t.Fatalf("cannot get deployment: %s", err)
+	}
+	dep.Spec.Replicas = ptr.To(int32(2))
+	if err := drc.Update(ctx, &dep); err != nil {
+		t.Fatalf("cannot update deployment: %s", err)
+	}
+	// update with object from origin client
+	var secret corev1.Secret
+	if err := origin.Get(ctx, types.NamespacedName{Name: "vmagent-main", Namespace: "default"}, &secret); err != nil {
+		t.Fatalf("cannot get secret: %s", err)
+	}
+	secret.Data["config"] = []byte("new")
+	if err := drc.Update(ctx, &secret); err != nil {
+		t.Fatalf("cannot update secret: %s", err)
+	}
+	// create
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "vmagent-main", Namespace: "default"}, Data: map[string]string{"key": "value"}}
+	if err := drc.Create(ctx, cm); err != nil {
+		t.Fatalf("cannot create configmap: %s", err)
+	}
+	if err := drc.Create(ctx, &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "vmagent-main", Namespace: "default"}}); !errors.IsAlreadyExists(err) {
+		t.Fatalf("expected already exists error for live object, got: %v", err)
+	}
+	// delete
+	if err := drc.Delete(ctx, &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "vmagent-stale", Namespace: "default"}}); err != nil {
+		t.Fatalf("cannot delete service: %s", err)
+	}
+	var svcs corev1.ServiceList
+	if err := drc.List(ctx, &svcs, client.MatchingLabels{"app": "vmagent"}); err != nil {
+		t.Fatalf("cannot list services: %s", err)
+	}
+	if len(svcs.Items) != 1 || svcs.Items[0].Name != "vmagent-main" {
+		t.Fatalf("expected deleted service to be excluded from list, got: %v", svcs.Items)
+	}
+	// status only changes are omitted
+	var vmagent vmv1beta1.VMAgent
+	if err := drc.Get(ctx, types.NamespacedName{Name: "main", Namespace: "default"}, &vmagent); err != nil {
+		t.Fatalf("cannot get vmagent: %s", err)
+	}
+	vmagent.Status.UpdateStatus = vmv1beta1.UpdateStatusOperational
+	if err := drc.Status().Update(ctx, &vmagent); err != nil {
+		t.Fatalf("cannot update vmagent status: %s", err)
+	}
+
+	// origin objects must be kept
+	if err := origin.Get(ctx, types.NamespacedName{Name: "vmagent-main", Namespace: "default"}, &dep); err != nil {
+		t.Fatalf("cannot get origin deployment: %s", err)
+	}
+	if *dep.Spec.Replicas != 1 {
+		t.Fatalf("origin deployment must not be changed, got replicas: %d", *dep.Spec.Replicas)
+	}
+	if err := origin.Get(ctx, types.NamespacedName{Name: "vmagent-main", Namespace: "default"}, &corev1.ConfigMap{}); !errors.IsNotFound(err) {
+		t.Fatalf("configmap must not be created at origin, got: %v", err)
+	}
+	if err := origin.Get(ctx, types.NamespacedName{Name: "vmagent-stale", Namespace: "default"}, &corev1.Service{}); err != nil {
+		t.Fatalf("service must not be deleted at origin, got: %v", err)
+	}
+
+	changes, err := drc.Changes(ctx)
+	if err != nil {
+		t.Fatalf("cannot get changes: %s", err)
+	}
+	var got []string
+	for _, change := range changes {
+		got = append(got, change.String())
+	}
+	want := []string{
+		"update Deployment default/vmagent-main",
+		"update Secret default/vmagent-main",
+		"create ConfigMap default/vmagent-main",
+		"delete Service default/vmagent-stale",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected changes\ngot:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	diff, err := changes[0].Diff()
+	if err != nil {
+		t.Fatalf("cannot get diff: %s", err)
+	}
+	if !strings.Contains(diff, "-  replicas: 1") || !strings.Contains(diff, "+  replicas: 2") {
+		t.Fatalf("unexpected deployment diff:\n%s", diff)
+	}
+	diff, err = changes[1].Diff()
+	if err != nil {
+		t.Fatalf("cannot get diff: %s", err)
+	}
+	if strings.Contains(diff, "bmV3") || !strings.Contains(diff, "+  config: sha256:") {
+		t.Fatalf("secret values must be masked at diff:\n%s", diff)
+	}
+}
+
+func TestRenderObject(t *testing.T) {
+	f := func(obj client.Object, want string) {
+		t.Helper()
+		gvk, err := apiutil.GVKForObject(obj, testGetScheme())
+		if err != nil {
+			t.Fatalf("cannot get gvk: %s", err)
+		}
+		got, err := RenderObject(gvk, obj)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if string(got) != want {
+			t.Fatalf("unexpected output\ngot:\n%s\nwant:\n%s", got, want)
+		}
+	}
+	var gzipped bytes.Buffer
+	w := gzip.NewWriter(&gzipped)
+	if _, err := w.Write([]byte("global: {}\n")); err != nil {
+		t.Fatalf("cannot compress data: %s", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("cannot compress data: %s", err)
+	}
+
+	// secret with compressed and binary values
+	f(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vmagent-main", Namespace: "default", ResourceVersion: "10", UID: "uid"},
+		Data: map[string][]byte{
+			"vmagent.yaml.gz": gzipped.Bytes(),
+			"ca.crt":          []byte("cert"),
+			"binary":          {0xff, 0xfe},
+		},
+	}, `apiVersion: v1
+data:
+  binary: //4=
+kind: Secret
+metadata:
+  name: vmagent-main
+  namespace: default
+stringData:
+  ca.crt: cert
+  vmagent.yaml: |
+    global: {}
+`)
+
+	// status is omitted
+	f(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "vmagent-main", Namespace: "default", Generation: 2},
+		Status:     appsv1.DeploymentStatus{Replicas: 1},
+	}, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: vmagent-main
+  namespace: default
+spec:
+  selector: null
+  strategy: {}
+  template:
+    metadata:
+      creationTimestamp: null
+    spec:
+      containers: null
+`)
+}
@@ -37,6 +37,14 @@ func testGetScheme() *runtime.Scheme {
 		&vmv1beta1.VMScrapeConfigList{},
 		&vmv1beta1.VMClusterList{},
 		&vmv1beta1.VLogsList{},
+		&vmv1beta1.VMBackupJobList{},
+		&vmv1beta1.VMRestoreJobList{},
+		&vmv1beta1.VLClusterList{},
+		&vmv1beta1.VMAnomalyList{},
+		&vmv1beta1.VMTenantList{},
+		&vmv1beta1.VMAlertmanagerSilenceList{},
+		&vmv1beta1.VMRuleTestList{},
+		&vmv1beta1.VMBlackboxExporterList{},
 	)
 	s.AddKnownTypes(vmv1beta1.GroupVersion,
 		&vmv1beta1.VMPodScrape{},
@@ -56,6 +64,14 @@ func testGetScheme() *runtime.Scheme {
 		&vmv1beta1.VMScrapeConfig{},
 		&vmv1beta1.VMCluster{},
 		&vmv1beta1.VLogs{},
+		&vmv1beta1.VMBackupJob{},
+		&vmv1beta1.VMRestoreJob{},
+		&vmv1beta1.VLCluster{},
+		&vmv1beta1.VMAnomaly{},
+		&vmv1beta1.VMTenant{},
+		&vmv1beta1.VMAlertmanagerSilence{},
+		&vmv1beta1.VMRuleTest{},
+		&vmv1beta1.VMBlackboxExporter{},
 	)
 	return s
 }
@@ -84,6 +100,14 @@ func GetTestClientWithObjects(predefinedObjects []runtime.Object) client.Client
 			&vmv1beta1.VMScrapeConfig{},
 			&vmv1beta1.VMStaticScrape{},
 			&vmv1beta1.VMNodeScrape{},
+			&vmv1beta1.VMBackupJob{},
+			&vmv1beta1.VMRestoreJob{},
+			&vmv1beta1.VLCluster{},
+			&vmv1beta1.VMAnomaly{},
+			&vmv1beta1.VMTenant{},
+			&vmv1beta1.VMAlertmanagerSilence{},
+			&vmv1beta1.VMRuleTest{},
+			&vmv1beta1.VMBlackboxExporter{},
 		).
 		WithObjects(obj...).Build()
 	withStats := TestClientWithStatsTrack{
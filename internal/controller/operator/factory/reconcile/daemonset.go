@@ -0,0 +1,100 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DaemonSet performs an update or create operator for daemonset and waits until it's pods is ready
+func DaemonSet(ctx context.Context, rclient client.Client, newDs, prevDs *appsv1.DaemonSet) error {
+
+	var isPrevEqual bool
+	if prevDs != nil {
+		isPrevEqual = equality.Semantic.DeepDerivative(prevDs.Spec, newDs.Spec)
+	}
+	rclient.Scheme().Default(newDs)
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var currentDs appsv1.DaemonSet
+		err := rclient.Get(ctx, types.NamespacedName{Name: newDs.Name, Namespace: newDs.Namespace}, &currentDs)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				if err := rclient.Create(ctx, newDs); err != nil {
+					return fmt.Errorf("cannot create new daemonset for app: %s, err: %w", newDs.Name, err)
+				}
+				return waitDaemonSetReady(ctx, rclient, newDs, appWaitReadyDeadline)
+			}
+			return fmt.Errorf("cannot get daemonset for app: %s err: %w", newDs.Name, err)
+		}
+		if err := finalize.FreeIfNeeded(ctx, rclient, &currentDs); err != nil {
+			return err
+		}
+		newDs.Spec.Template.Annotations = labels.Merge(currentDs.Spec.Template.Annotations, newDs.Spec.Template.Annotations)
+		newDs.Status = currentDs.Status
+		newDs.Annotations = labels.Merge(currentDs.Annotations, newDs.Annotations)
+		vmv1beta1.AddFinalizer(newDs, &currentDs)
+
+		isEqual := equality.Semantic.DeepDerivative(newDs.Spec, currentDs.Spec)
+		if isEqual &&
+			isPrevEqual &&
+			equality.Semantic.DeepEqual(newDs.Labels, currentDs.Labels) &&
+			equality.Semantic.DeepEqual(newDs.Annotations, currentDs.Annotations) {
+			return waitDaemonSetReady(ctx, rclient, newDs, appWaitReadyDeadline)
+		}
+		logger.WithContext(ctx).Info("updating daemonset configuration",
+			"is_prev_equal", isPrevEqual, "is_current_equal", isEqual,
+			"is_prev_nil", prevDs == nil)
+
+		if err := rclient.Update(ctx, newDs); err != nil {
+			return fmt.Errorf("cannot update daemonset for app: %s, err: %w", newDs.Name, err)
+		}
+
+		return waitDaemonSetReady(ctx, rclient, newDs, appWaitReadyDeadline)
+	})
+}
+
+// waitDaemonSetReady waits until daemonset rollouts and all new pods is ready
+func waitDaemonSetReady(ctx context.Context, rclient client.Client, ds *appsv1.DaemonSet, deadline time.Duration) error {
+	if k8stools.IsDryRun(rclient) {
+		return nil
+	}
+	err := wait.PollUntilContextTimeout(ctx, time.Second, deadline, false, func(ctx context.Context) (done bool, err error) {
+		var actualDs appsv1.DaemonSet
+		if err := rclient.Get(ctx, types.NamespacedName{Namespace: ds.Namespace, Name: ds.Name}, &actualDs); err != nil {
+			return false, fmt.Errorf("cannot fetch actual daemonset state: %w", err)
+		}
+		// this function uses the daemonset readiness detection algorithm from `kubectl rollout status` command
+		// (https://github.com/kubernetes/kubectl/blob/6e4fe32a45fdcbf61e5c30ebdc511d75e7242432/pkg/polymorphichelpers/rollout_status.go#L95)
+		if actualDs.Generation > actualDs.Status.ObservedGeneration {
+			// Waiting for daemonset spec update to be observed...
+			return false, nil
+		}
+		if actualDs.Status.UpdatedNumberScheduled < actualDs.Status.DesiredNumberScheduled {
+			// Waiting for daemonset rollout to finish: part of new pods have been updated...
+			return false, nil
+		}
+		if actualDs.Status.NumberAvailable < actualDs.Status.DesiredNumberScheduled {
+			// Waiting for daemonset rollout to finish: part of updated pods are available...
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return vmv1beta1.NewRolloutError(reportFirstNotReadyPodOnError(ctx, rclient, fmt.Errorf("cannot wait for daemonset to become ready: %w", err), ds.Namespace, labels.SelectorFromSet(ds.Spec.Selector.MatchLabels), ds.Spec.MinReadySeconds))
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package reconcile
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestDaemonSetOk(t *testing.T) {
+	f := func(ds *appsv1.DaemonSet) {
+		t.Helper()
+		ctx := context.Background()
+		rclient := k8stools.GetTestClientWithObjects(nil)
+		clientStats := rclient.(*k8stools.TestClientWithStatsTrack)
+
+		waitTimeout := 5 * time.Second
+		prevDs := ds.DeepCopy()
+		createErr := make(chan error)
+		go func() {
+			err := DaemonSet(ctx, rclient, ds, nil)
+			select {
+			case createErr <- err:
+			default:
+			}
+		}()
+
+		err := wait.PollUntilContextTimeout(ctx, time.Millisecond*50,
+			waitTimeout, false, func(ctx context.Context) (done bool, err error) {
+				var createdDs appsv1.DaemonSet
+				if err := rclient.Get(ctx, types.NamespacedName{Name: ds.Name, Namespace: ds.Namespace}, &createdDs); err != nil {
+					if errors.IsNotFound(err) {
+						return false, nil
+					}
+					return false, err
+				}
+				createdDs.Status.DesiredNumberScheduled = 2
+				createdDs.Status.UpdatedNumberScheduled = 2
+				createdDs.Status.NumberAvailable = 2
+				createdDs.Status.NumberReady = 2
+				if err := rclient.Status().Update(ctx, &createdDs); err != nil {
+					return false, err
+				}
+				return true, nil
+			})
+		if err != nil {
+			t.Fatalf("failed to wait daemonset created: %s", err)
+		}
+
+		err = <-createErr
+		if err != nil {
+			t.Fatalf("failed to create daemonset: %s", err)
+		}
+		// expect 1 create
+		assert.Equal(t, int64(1), clientStats.CreateCalls.Load())
+		// expect 0 update
+		if err := rclient.Get(ctx, types.NamespacedName{Name: ds.Name, Namespace: ds.Namespace}, ds); err != nil {
+			t.Fatalf("cannot reload created daemonset: %s", err)
+		}
+		if err := DaemonSet(ctx, rclient, ds, prevDs); err != nil {
+			t.Fatalf("failed to update daemonset: %s", err)
+		}
+		assert.Equal(t, int64(0), clientStats.UpdateCalls.Load())
+	}
+
+	f(&appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vmagent-test-1",
+			Namespace: "default",
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "vmagent"},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": "vmagent"},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "vmagent",
+							Image: "vmagent:latest",
+						},
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,67 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// HTTPRoute creates or updates Gateway API route object
+func HTTPRoute(ctx context.Context, rclient client.Client, newRoute *unstructured.Unstructured) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		currentRoute := &unstructured.Unstructured{}
+		currentRoute.SetGroupVersionKind(newRoute.GroupVersionKind())
+		err := rclient.Get(ctx, types.NamespacedName{Namespace: newRoute.GetNamespace(), Name: newRoute.GetName()}, currentRoute)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				logger.WithContext(ctx).Info("creating new route", "route_name", newRoute.GetName(), "kind", newRoute.GetKind())
+				return rclient.Create(ctx, newRoute)
+			}
+			return fmt.Errorf("cannot get existing %s: %s, err: %w", newRoute.GetKind(), newRoute.GetName(), err)
+		}
+		if err := finalize.FreeIfNeeded(ctx, rclient, currentRoute); err != nil {
+			return err
+		}
+		newRoute.SetAnnotations(labels.Merge(currentRoute.GetAnnotations(), newRoute.GetAnnotations()))
+		vmv1beta1.AddFinalizer(newRoute, currentRoute)
+		// gateway controller populates defaults for route spec, so compare only fields defined by operator
+		if equality.Semantic.DeepDerivative(newRoute.Object["spec"], currentRoute.Object["spec"]) &&
+			equality.Semantic.DeepEqual(newRoute.GetLabels(), currentRoute.GetLabels()) &&
+			equality.Semantic.DeepEqual(newRoute.GetAnnotations(), currentRoute.GetAnnotations()) {
+			return nil
+		}
+		newRoute.SetResourceVersion(currentRoute.GetResourceVersion())
+		logger.WithContext(ctx).Info("updating route configuration", "route_name", newRoute.GetName(), "kind", newRoute.GetKind())
+		return rclient.Update(ctx, newRoute)
+	})
+}
+
+// RemoveOrphanedHTTPRoute removes route object created for previous state
+// if route was removed from spec or its kind was changed
+func RemoveOrphanedHTTPRoute(ctx context.Context, rclient client.Client, name, namespace string, prevRoute, currRoute *vmv1beta1.EmbeddedHTTPRoute) error {
+	if prevRoute == nil {
+		return nil
+	}
+	if currRoute != nil && currRoute.KindOrDefault() == prevRoute.KindOrDefault() {
+		return nil
+	}
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(build.HTTPRouteGVK(prevRoute.KindOrDefault()))
+	route.SetName(name)
+	route.SetNamespace(namespace)
+	if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, route); err != nil {
+		return fmt.Errorf("cannot remove %s from prev state: %w", prevRoute.KindOrDefault(), err)
+	}
+	return nil
+}
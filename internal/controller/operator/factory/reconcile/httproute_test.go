@@ -0,0 +1,71 @@
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestHTTPRoute(t *testing.T) {
+	ctx := context.Background()
+	rclient := k8stools.GetTestClientWithObjects(nil)
+	newRoute := func(kind, hostname string) *unstructured.Unstructured {
+		route := &unstructured.Unstructured{Object: map[string]any{
+			"spec": map[string]any{
+				"hostnames": []any{hostname},
+			},
+		}}
+		route.SetGroupVersionKind(build.HTTPRouteGVK(kind))
+		route.SetName("vmsingle-main")
+		route.SetNamespace("default")
+		route.SetFinalizers([]string{vmv1beta1.FinalizerName})
+		return route
+	}
+	getRoute := func(kind string) (*unstructured.Unstructured, error) {
+		route := &unstructured.Unstructured{}
+		route.SetGroupVersionKind(build.HTTPRouteGVK(kind))
+		err := rclient.Get(ctx, types.NamespacedName{Name: "vmsingle-main", Namespace: "default"}, route)
+		return route, err
+	}
+	f := func(kind, hostname string) {
+		t.Helper()
+		if err := HTTPRoute(ctx, rclient, newRoute(kind, hostname)); err != nil {
+			t.Fatalf("cannot reconcile route: %s", err)
+		}
+		got, err := getRoute(kind)
+		if err != nil {
+			t.Fatalf("cannot get route: %s", err)
+		}
+		hostnames, _, _ := unstructured.NestedStringSlice(got.Object, "spec", "hostnames")
+		if len(hostnames) != 1 || hostnames[0] != hostname {
+			t.Fatalf("unexpected hostnames: %v, want: %s", hostnames, hostname)
+		}
+	}
+
+	// create
+	f(vmv1beta1.HTTPRouteKind, "vmsingle.example.com")
+	// update
+	f(vmv1beta1.HTTPRouteKind, "metrics.example.com")
+	// no changes
+	f(vmv1beta1.HTTPRouteKind, "metrics.example.com")
+
+	// kind changed
+	prev := &vmv1beta1.EmbeddedHTTPRoute{Kind: vmv1beta1.HTTPRouteKind}
+	curr := &vmv1beta1.EmbeddedHTTPRoute{Kind: vmv1beta1.TLSRouteKind}
+	f(vmv1beta1.TLSRouteKind, "metrics.example.com")
+	if err := RemoveOrphanedHTTPRoute(ctx, rclient, "vmsingle-main", "default", prev, curr); err != nil {
+		t.Fatalf("cannot remove orphaned route: %s", err)
+	}
+	if _, err := getRoute(vmv1beta1.HTTPRouteKind); !errors.IsNotFound(err) {
+		t.Fatalf("expected HTTPRoute to be removed, got: %v", err)
+	}
+	if _, err := getRoute(vmv1beta1.TLSRouteKind); err != nil {
+		t.Fatalf("expected TLSRoute to be kept, got: %v", err)
+	}
+}
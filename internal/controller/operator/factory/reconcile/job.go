@@ -0,0 +1,82 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// JobGenerationAnnotation holds generation of parent object used for job creation
+const JobGenerationAnnotation = "operator.victoriametrics.com/parent-generation"
+
+// JobContentHashAnnotation holds hash of external content used by job, e.g. mounted configmap data
+const JobContentHashAnnotation = "operator.victoriametrics.com/content-hash"
+
+// Job creates job if it doesn't exist
+// Job spec is immutable, so it recreates job if JobGenerationAnnotation or JobContentHashAnnotation of existing job doesn't match
+// and returns actual state of job. Nil job is returned, if job is pending for re-creation
+func Job(ctx context.Context, rclient client.Client, newJob *batchv1.Job) (*batchv1.Job, error) {
+	var existJob batchv1.Job
+	if err := rclient.Get(ctx, types.NamespacedName{Name: newJob.Name, Namespace: newJob.Namespace}, &existJob); err != nil {
+		if errors.IsNotFound(err) {
+			logger.WithContext(ctx).Info("creating new job", "job_name", newJob.Name)
+			if err := rclient.Create(ctx, newJob); err != nil {
+				return nil, fmt.Errorf("cannot create new job: %w", err)
+			}
+			return newJob, nil
+		}
+		return nil, fmt.Errorf("cannot get exist job: %w", err)
+	}
+	if !existJob.DeletionTimestamp.IsZero() {
+		// wait until previous job will be removed
+		return nil, nil
+	}
+	if existJob.Annotations[JobGenerationAnnotation] == newJob.Annotations[JobGenerationAnnotation] &&
+		existJob.Annotations[JobContentHashAnnotation] == newJob.Annotations[JobContentHashAnnotation] {
+		return &existJob, nil
+	}
+	logger.WithContext(ctx).Info("recreating job with changed spec", "job_name", newJob.Name)
+	if err := rclient.Delete(ctx, &existJob, &client.DeleteOptions{PropagationPolicy: ptr.To(metav1.DeletePropagationBackground)}); err != nil && !errors.IsNotFound(err) {
+		return nil, fmt.Errorf("cannot delete outdated job: %w", err)
+	}
+	return nil, nil
+}
+
+// CronJob creates or updates cronjob object
+func CronJob(ctx context.Context, rclient client.Client, newCJ *batchv1.CronJob) (*batchv1.CronJob, error) {
+	var result *batchv1.CronJob
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var existCJ batchv1.CronJob
+		if err := rclient.Get(ctx, types.NamespacedName{Name: newCJ.Name, Namespace: newCJ.Namespace}, &existCJ); err != nil {
+			if errors.IsNotFound(err) {
+				logger.WithContext(ctx).Info("creating new cronjob", "cronjob_name", newCJ.Name)
+				result = newCJ
+				return rclient.Create(ctx, newCJ)
+			}
+			return fmt.Errorf("cannot get exist cronjob: %w", err)
+		}
+		result = &existCJ
+		newCJ.Annotations = labels.Merge(existCJ.Annotations, newCJ.Annotations)
+		newCJ.ResourceVersion = existCJ.ResourceVersion
+		newCJ.Status = existCJ.Status
+		if equality.Semantic.DeepDerivative(newCJ.Spec, existCJ.Spec) &&
+			equality.Semantic.DeepEqual(newCJ.Labels, existCJ.Labels) &&
+			equality.Semantic.DeepEqual(newCJ.Annotations, existCJ.Annotations) {
+			return nil
+		}
+		logger.WithContext(ctx).Info("updating cronjob configuration", "cronjob_name", newCJ.Name)
+		result = newCJ
+		return rclient.Update(ctx, newCJ)
+	})
+	return result, err
+}
@@ -0,0 +1,61 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NetworkPolicy creates or updates NetworkPolicy
+func NetworkPolicy(ctx context.Context, rclient client.Client, np *networkingv1.NetworkPolicy) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var currentNp networkingv1.NetworkPolicy
+		err := rclient.Get(ctx, types.NamespacedName{Namespace: np.Namespace, Name: np.Name}, &currentNp)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				logger.WithContext(ctx).Info("creating new network policy", "network_policy_name", np.Name)
+				return rclient.Create(ctx, np)
+			}
+			return fmt.Errorf("cannot get existing network policy: %s, err: %w", np.Name, err)
+		}
+		if err := finalize.FreeIfNeeded(ctx, rclient, &currentNp); err != nil {
+			return err
+		}
+		np.Annotations = labels.Merge(currentNp.Annotations, np.Annotations)
+		np.ResourceVersion = currentNp.ResourceVersion
+		vmv1beta1.AddFinalizer(np, &currentNp)
+
+		if equality.Semantic.DeepEqual(np.Spec, currentNp.Spec) &&
+			equality.Semantic.DeepEqual(np.Labels, currentNp.Labels) &&
+			equality.Semantic.DeepEqual(np.Annotations, currentNp.Annotations) &&
+			equality.Semantic.DeepEqual(np.Finalizers, currentNp.Finalizers) {
+			return nil
+		}
+		logger.WithContext(ctx).Info("updating network policy configuration", "network_policy_name", np.Name)
+
+		return rclient.Update(ctx, np)
+	})
+}
+
+// RemoveOrphanedNetworkPolicy removes network policy if it was disabled at the current spec
+func RemoveOrphanedNetworkPolicy(ctx context.Context, rclient client.Client, name, ns string, prev, curr *vmv1beta1.EmbeddedNetworkPolicy) error {
+	if prev == nil || curr != nil {
+		return nil
+	}
+	np := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns}}
+	if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, np); err != nil {
+		return fmt.Errorf("cannot remove network policy: %w", err)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package reconcile
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strconv"
@@ -10,6 +11,7 @@ import (
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -32,9 +34,16 @@ type STSOptions struct {
 	SelectorLabels     func() map[string]string
 	HPA                *vmv1beta1.EmbeddedHPA
 	UpdateReplicaCount func(count *int32)
+	// CanaryPods limits number of pods updated to the new revision,
+	// the rest of pods keep previous revision until the next update.
+	// Zero means no limit
+	CanaryPods int32
 }
 
 func waitForStatefulSetReady(ctx context.Context, rclient client.Client, newSts *appsv1.StatefulSet) error {
+	if k8stools.IsDryRun(rclient) {
+		return nil
+	}
 	err := wait.PollUntilContextTimeout(ctx, podWaitReadyIntervalCheck, appWaitReadyDeadline, false, func(ctx context.Context) (done bool, err error) {
 		// fast path
 		if newSts.Spec.Replicas == nil {
@@ -96,6 +105,10 @@ func HandleSTSUpdate(ctx context.Context, rclient client.Client, cr STSOptions,
 		newSts.Spec.Template.Annotations = labels.Merge(currentSts.Spec.Template.Annotations, newSts.Spec.Template.Annotations)
 		vmv1beta1.AddFinalizer(newSts, &currentSts)
 
+		if cr.CanaryPods > 0 && stsPodsMustRecreate(newSts, &currentSts) {
+			return fmt.Errorf("sts=%s must be re-created with all of its pods due to volumeClaimTemplates or serviceName change, it cannot be updated with canaryPods=%d", newSts.Name, cr.CanaryPods)
+		}
+
 		stsRecreated, podMustRecreate, err := recreateSTSIfNeed(ctx, rclient, newSts, &currentSts)
 		if err != nil {
 			return err
@@ -124,12 +137,12 @@ func HandleSTSUpdate(ctx context.Context, rclient client.Client, cr STSOptions,
 
 		// perform manual update only with OnDelete policy, which is default.
 		if newSts.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
-			if err := performRollingUpdateOnSts(ctx, podMustRecreate, rclient, newSts.Name, newSts.Namespace, cr.SelectorLabels()); err != nil {
-				return fmt.Errorf("cannot handle rolling-update on sts: %s, err: %w", newSts.Name, err)
+			if err := performRollingUpdateOnSts(ctx, podMustRecreate, rclient, newSts.Name, newSts.Namespace, cr.SelectorLabels(), cr.CanaryPods); err != nil {
+				return vmv1beta1.NewRolloutError(fmt.Errorf("cannot handle rolling-update on sts: %s, err: %w", newSts.Name, err))
 			}
 		} else {
 			if err := waitForStatefulSetReady(ctx, rclient, newSts); err != nil {
-				return fmt.Errorf("cannot ensure that statefulset is ready with strategy=%q: %w", newSts.Spec.UpdateStrategy.Type, err)
+				return vmv1beta1.NewRolloutError(fmt.Errorf("cannot ensure that statefulset is ready with strategy=%q: %w", newSts.Spec.UpdateStrategy.Type, err))
 			}
 		}
 
@@ -171,7 +184,13 @@ func getLatestStsState(ctx context.Context, rclient client.Client, targetSTS typ
 //
 // we always check if sts.Status.CurrentRevision needs update, to keep it equal to UpdateRevision
 // see https://github.com/kubernetes/kube-state-metrics/issues/1324#issuecomment-1779751992
-func performRollingUpdateOnSts(ctx context.Context, podMustRecreate bool, rclient client.Client, stsName string, ns string, podLabels map[string]string) error {
+//
+// if canaryPods is set, only given number of pods is updated and CurrentRevision is kept unchanged
+func performRollingUpdateOnSts(ctx context.Context, podMustRecreate bool, rclient client.Client, stsName string, ns string, podLabels map[string]string, canaryPods int32) error {
+	// pods are not managed at dry-run
+	if k8stools.IsDryRun(rclient) {
+		return nil
+	}
 	time.Sleep(podWaitReadyIntervalCheck)
 	sts, err := getLatestStsState(ctx, rclient, types.NamespacedName{Name: stsName, Namespace: ns})
 	if err != nil {
@@ -245,11 +264,24 @@ func performRollingUpdateOnSts(ctx context.Context, podMustRecreate bool, rclien
 		}
 	}
 
+	var isPartialUpdate bool
+	if canaryPods > 0 {
+		allowedForUpdate := int(canaryPods) - (len(podList.Items) - len(podsForUpdate))
+		if allowedForUpdate < 0 {
+			allowedForUpdate = 0
+		}
+		if len(podsForUpdate) > allowedForUpdate {
+			l.Info("limiting pods update with canary pods count", "canary_pods", canaryPods, "outdated_pods", len(podsForUpdate))
+			podsForUpdate = podsForUpdate[:allowedForUpdate]
+			isPartialUpdate = true
+		}
+	}
+
 	updatedNeeded := len(podsForUpdate) != 0 || len(updatedPods) != 0
 
 	if !updatedNeeded {
 		l.Info("no pod needs to be updated")
-		if sts.Status.UpdateRevision != sts.Status.CurrentRevision {
+		if !isPartialUpdate && sts.Status.UpdateRevision != sts.Status.CurrentRevision {
 			logger.WithContext(ctx).Info("update sts.Status.CurrentRevision", "sts", sts.Name, "currentRevision", sts.Status.CurrentRevision, "desiredRevision", sts.Status.UpdateRevision)
 			sts.Status.CurrentRevision = sts.Status.UpdateRevision
 			if err := rclient.Status().Update(ctx, sts); err != nil {
@@ -286,7 +318,7 @@ func performRollingUpdateOnSts(ctx context.Context, podMustRecreate bool, rclien
 		l.Info("pod was updated successfully", "pod", pod.Name)
 	}
 
-	if sts.Status.CurrentRevision != sts.Status.UpdateRevision {
+	if !isPartialUpdate && sts.Status.CurrentRevision != sts.Status.UpdateRevision {
 		logger.WithContext(ctx).Info("update sts.Status.CurrentRevision", "sts", sts.Name, "currentRevision", sts.Status.CurrentRevision, "desiredRevision", sts.Status.UpdateRevision)
 		sts.Status.CurrentRevision = sts.Status.UpdateRevision
 		if err := rclient.Status().Update(ctx, sts); err != nil {
@@ -297,6 +329,48 @@ func performRollingUpdateOnSts(ctx context.Context, podMustRecreate bool, rclien
 	return nil
 }
 
+// RollbackSTS restores pod template of statefulset from the given controller revision
+// and re-creates pods with other revisions
+func RollbackSTS(ctx context.Context, rclient client.Client, stsName, ns, revision string, podLabels map[string]string) error {
+	if err := RestoreSTSTemplate(ctx, rclient, stsName, ns, revision); err != nil {
+		return err
+	}
+	return performRollingUpdateOnSts(ctx, false, rclient, stsName, ns, podLabels, 0)
+}
+
+// RestoreSTSTemplate restores pod template of statefulset from the given controller revision.
+// Pods aren't changed, but pods re-created by kubernetes, e.g. after eviction, get the restored template
+func RestoreSTSTemplate(ctx context.Context, rclient client.Client, stsName, ns, revision string) error {
+	var cr appsv1.ControllerRevision
+	if err := rclient.Get(ctx, types.NamespacedName{Namespace: ns, Name: revision}, &cr); err != nil {
+		return fmt.Errorf("cannot get controller revision=%q for sts rollback: %w", revision, err)
+	}
+	// controller revision stores pod template as a patch for statefulset spec
+	var patch struct {
+		Spec struct {
+			Template corev1.PodTemplateSpec `json:"template"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(cr.Data.Raw, &patch); err != nil {
+		return fmt.Errorf("cannot parse controller revision=%q data: %w", revision, err)
+	}
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var sts appsv1.StatefulSet
+		if err := rclient.Get(ctx, types.NamespacedName{Namespace: ns, Name: stsName}, &sts); err != nil {
+			return fmt.Errorf("cannot get sts for rollback: %w", err)
+		}
+		if equality.Semantic.DeepEqual(sts.Spec.Template, patch.Spec.Template) {
+			return nil
+		}
+		sts.Spec.Template = patch.Spec.Template
+		return rclient.Update(ctx, &sts)
+	}); err != nil {
+		return fmt.Errorf("cannot restore sts=%s pod template from revision=%q: %w", stsName, revision, err)
+	}
+	logger.WithContext(ctx).Info("restored statefulset pod template", "sts_name", stsName, "revision", revision)
+	return nil
+}
+
 // PodIsReady check is pod is ready
 func PodIsReady(pod *corev1.Pod, minReadySeconds int32) bool {
 	if pod.ObjectMeta.DeletionTimestamp != nil {
@@ -137,6 +137,20 @@ func recreateSTSIfNeed(ctx context.Context, rclient client.Client, newSTS, exist
 	return false, false, nil
 }
 
+// stsPodsMustRecreate checks if statefulset must be re-created with all of its pods
+// it must be in sync with recreateSTSIfNeed
+func stsPodsMustRecreate(newSTS, existingSTS *appsv1.StatefulSet) bool {
+	if len(newSTS.Spec.VolumeClaimTemplates) != len(existingSTS.Spec.VolumeClaimTemplates) {
+		return true
+	}
+	for _, newVCT := range newSTS.Spec.VolumeClaimTemplates {
+		if getPVCFromSTS(newVCT.Name, existingSTS) == nil {
+			return true
+		}
+	}
+	return newSTS.Spec.ServiceName != existingSTS.Spec.ServiceName
+}
+
 func isPVClaimPolicyEqual(left, right *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy) bool {
 	// current kubernetes version doesn't support claim retention feature gate
 	// https://kubernetes.io/docs/concepts/workloads/controllers/statefulset/#persistentvolumeclaim-retention
@@ -206,6 +206,9 @@ func Test_reCreateSTS(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			cl := k8stools.GetTestClientWithObjects([]runtime.Object{tt.args.existingSTS})
+			if got := stsPodsMustRecreate(tt.args.newSTS, tt.args.existingSTS); got != tt.mustRecreatePod {
+				t.Fatalf("%s: \n expect `stsPodsMustRecreate`: %v, got: %v", tt.name, tt.mustRecreatePod, got)
+			}
 			stsRecreated, mustRecreatePod, err := recreateSTSIfNeed(tt.args.ctx, cl, tt.args.newSTS, tt.args.existingSTS)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("%s: \nwasCreatedSTS() error = %v, wantErr %v", tt.name, err, tt.wantErr)
@@ -309,13 +309,123 @@ func Test_performRollingUpdateOnSts(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fclient := k8stools.GetTestClientWithObjects(tt.predefinedObjects)
 
-			if err := performRollingUpdateOnSts(context.Background(), false, fclient, tt.args.stsName, tt.args.ns, tt.args.podLabels); (err != nil) != tt.wantErr {
+			if err := performRollingUpdateOnSts(context.Background(), false, fclient, tt.args.stsName, tt.args.ns, tt.args.podLabels, 0); (err != nil) != tt.wantErr {
 				t.Errorf("performRollingUpdateOnSts() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
+func Test_performRollingUpdateOnStsWithCanary(t *testing.T) {
+	stsPod := func(name, revision string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "default",
+				Labels:          map[string]string{"app": "vmstorage", podRevisionLabel: revision},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "StatefulSet", Name: "vmstorage"}},
+			},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: "True"}},
+			},
+		}
+	}
+	fclient := k8stools.GetTestClientWithObjects([]runtime.Object{
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "vmstorage", Namespace: "default"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(int32(3))},
+			Status: appsv1.StatefulSetStatus{
+				CurrentRevision: "rev1",
+				UpdateRevision:  "rev2",
+			},
+		},
+		stsPod("vmstorage-0", "rev2"),
+		stsPod("vmstorage-1", "rev1"),
+		stsPod("vmstorage-2", "rev1"),
+	})
+	ctx := context.Background()
+	// canary pod is already updated, the rest of pods must be kept as is
+	if err := performRollingUpdateOnSts(ctx, false, fclient, "vmstorage", "default", map[string]string{"app": "vmstorage"}, 1); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var pods corev1.PodList
+	if err := fclient.List(ctx, &pods); err != nil {
+		t.Fatalf("cannot list pods: %s", err)
+	}
+	assert.Len(t, pods.Items, 3)
+	var sts appsv1.StatefulSet
+	if err := fclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "vmstorage"}, &sts); err != nil {
+		t.Fatalf("cannot get sts: %s", err)
+	}
+	assert.Equal(t, "rev1", sts.Status.CurrentRevision)
+}
+
+func TestRollbackSTS(t *testing.T) {
+	fclient := k8stools.GetTestClientWithObjects([]runtime.Object{
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "vmstorage", Namespace: "default"},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: ptr.To(int32(0)),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "vmstorage", Image: "vmstorage:v2"}}},
+				},
+			},
+		},
+		&appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{Name: "vmstorage-rev1", Namespace: "default"},
+			Data: runtime.RawExtension{
+				Raw: []byte(`{"spec":{"template":{"spec":{"containers":[{"name":"vmstorage","image":"vmstorage:v1"}]},"$patch":"replace"}}}`),
+			},
+		},
+	})
+	ctx := context.Background()
+	if err := RollbackSTS(ctx, fclient, "vmstorage", "default", "vmstorage-rev1", map[string]string{"app": "vmstorage"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var sts appsv1.StatefulSet
+	if err := fclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "vmstorage"}, &sts); err != nil {
+		t.Fatalf("cannot get sts: %s", err)
+	}
+	assert.Equal(t, "vmstorage:v1", sts.Spec.Template.Spec.Containers[0].Image)
+
+	// missing revision
+	if err := RollbackSTS(ctx, fclient, "vmstorage", "default", "vmstorage-rev0", map[string]string{"app": "vmstorage"}); err == nil {
+		t.Fatalf("expected error for missing revision")
+	}
+}
+
+func TestHandleSTSUpdateWithCanaryPodsMustRecreate(t *testing.T) {
+	existingSts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "vmstorage", Namespace: "default"},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    ptr.To(int32(2)),
+			ServiceName: "vmstorage",
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type: appsv1.OnDeleteStatefulSetStrategyType,
+			},
+		},
+	}
+	fclient := k8stools.GetTestClientWithObjects([]runtime.Object{existingSts})
+	ctx := context.Background()
+	newSts := existingSts.DeepCopy()
+	newSts.ResourceVersion = ""
+	newSts.Spec.ServiceName = "vmstorage-new"
+	opts := STSOptions{
+		SelectorLabels: func() map[string]string { return map[string]string{"app": "vmstorage"} },
+		CanaryPods:     1,
+	}
+	// all pods must be re-created, it's not allowed with canary pods limit
+	if err := HandleSTSUpdate(ctx, fclient, opts, newSts, existingSts); err == nil {
+		t.Fatalf("expected error for sts re-creation with canary pods")
+	}
+	var sts appsv1.StatefulSet
+	if err := fclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "vmstorage"}, &sts); err != nil {
+		t.Fatalf("cannot get sts: %s", err)
+	}
+	assert.Equal(t, "vmstorage", sts.Spec.ServiceName)
+}
+
 func TestSortPodsByID(t *testing.T) {
 	f := func(unorderedPods []corev1.Pod, expectedOrder []corev1.Pod) {
 		t.Helper()
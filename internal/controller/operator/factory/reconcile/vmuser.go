@@ -0,0 +1,51 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VMUser creates or updates given object
+func VMUser(ctx context.Context, rclient client.Client, vmu *vmv1beta1.VMUser) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var existVMU vmv1beta1.VMUser
+		err := rclient.Get(ctx, types.NamespacedName{Namespace: vmu.Namespace, Name: vmu.Name}, &existVMU)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return rclient.Create(ctx, vmu)
+			}
+			return err
+		}
+		// VMUser with the same name could be created by user or by other object
+		if owner := metav1.GetControllerOfNoCopy(vmu); owner != nil {
+			existOwner := metav1.GetControllerOfNoCopy(&existVMU)
+			if existOwner == nil || existOwner.UID != owner.UID {
+				return fmt.Errorf("VMUser=%s/%s already exists and isn't controlled by %s=%s", vmu.Namespace, vmu.Name, owner.Kind, owner.Name)
+			}
+		}
+
+		existVMU.Annotations = labels.Merge(existVMU.Annotations, vmu.Annotations)
+		if equality.Semantic.DeepEqual(vmu.Spec, existVMU.Spec) &&
+			equality.Semantic.DeepEqual(vmu.Labels, existVMU.Labels) &&
+			equality.Semantic.DeepEqual(vmu.OwnerReferences, existVMU.OwnerReferences) {
+			return nil
+		}
+		existVMU.Spec = vmu.Spec
+		existVMU.Labels = vmu.Labels
+		existVMU.OwnerReferences = vmu.OwnerReferences
+		logger.WithContext(ctx).Info("updating vmuser for CRD object", "vmuser", vmu.Name)
+
+		return rclient.Update(ctx, &existVMU)
+	})
+}
@@ -0,0 +1,64 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VPA creates or update verticalPodAutoscaler object
+func VPA(ctx context.Context, rclient client.Client, targetVPA *unstructured.Unstructured) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existVPA := &unstructured.Unstructured{}
+		existVPA.SetGroupVersionKind(targetVPA.GroupVersionKind())
+		if err := rclient.Get(ctx, types.NamespacedName{Name: targetVPA.GetName(), Namespace: targetVPA.GetNamespace()}, existVPA); err != nil {
+			if errors.IsNotFound(err) {
+				return rclient.Create(ctx, targetVPA)
+			}
+			return fmt.Errorf("cannot get exist vpa object: %w", err)
+		}
+		if err := finalize.FreeIfNeeded(ctx, rclient, existVPA); err != nil {
+			return err
+		}
+
+		targetVPA.SetAnnotations(labels.Merge(existVPA.GetAnnotations(), targetVPA.GetAnnotations()))
+		targetVPA.SetResourceVersion(existVPA.GetResourceVersion())
+		if status, ok := existVPA.Object["status"]; ok {
+			targetVPA.Object["status"] = status
+		}
+		if equality.Semantic.DeepEqual(targetVPA.Object["spec"], existVPA.Object["spec"]) &&
+			equality.Semantic.DeepEqual(targetVPA.GetLabels(), existVPA.GetLabels()) &&
+			equality.Semantic.DeepEqual(targetVPA.GetAnnotations(), existVPA.GetAnnotations()) {
+			return nil
+		}
+		logger.WithContext(ctx).Info("updating VPA configuration", "vpa_name", targetVPA.GetName())
+
+		return rclient.Update(ctx, targetVPA)
+	})
+}
+
+// RemoveOrphanedVPA removes VPA object if it was disabled at the current spec
+func RemoveOrphanedVPA(ctx context.Context, rclient client.Client, name, ns string, prev, curr *vmv1beta1.EmbeddedVPA) error {
+	if prev == nil || curr != nil {
+		return nil
+	}
+	vpa := &unstructured.Unstructured{}
+	vpa.SetGroupVersionKind(build.VPAGVK)
+	vpa.SetName(name)
+	vpa.SetNamespace(ns)
+	if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, vpa); err != nil {
+		return fmt.Errorf("cannot remove VPA from prev state: %w", err)
+	}
+	return nil
+}
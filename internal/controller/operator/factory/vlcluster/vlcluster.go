@@ -0,0 +1,308 @@
+package vlcluster
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+	v2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	vmauthLBServiceProxyJobNameLabel = "operator.victoriametrics.com/vmauthlb-proxy-job-name"
+	vmauthLBServiceProxyTargetLabel  = "operator.victoriametrics.com/vmauthlb-proxy-name"
+)
+
+// CreateOrUpdateVLCluster reconciles cluster object with order
+// first vlstorage is updated and operator waits for its readiness
+// then vlselect and vlinsert
+func CreateOrUpdateVLCluster(ctx context.Context, cr *vmv1beta1.VLCluster, rclient client.Client) error {
+	if cr.IsOwnsServiceAccount() {
+		if err := reconcile.ServiceAccount(ctx, rclient, build.ServiceAccount(cr)); err != nil {
+			return fmt.Errorf("failed create service account: %w", err)
+		}
+	}
+	if cr.Spec.RequestsLoadBalancer.Enabled {
+		if err := createOrUpdateVMAuthLB(ctx, rclient, cr); err != nil {
+			return err
+		}
+	}
+	if cr.Spec.VLStorage != nil {
+		if err := createOrUpdateVLStorage(ctx, rclient, cr); err != nil {
+			return err
+		}
+	}
+	if cr.Spec.VLSelect != nil {
+		if err := createOrUpdateVLSelect(ctx, rclient, cr); err != nil {
+			return err
+		}
+	}
+	if cr.Spec.VLInsert != nil {
+		if err := createOrUpdateVLInsert(ctx, rclient, cr); err != nil {
+			return err
+		}
+	}
+	if err := deletePrevStateResources(ctx, cr, rclient); err != nil {
+		return fmt.Errorf("failed to remove objects from previous cluster state: %w", err)
+	}
+	return nil
+}
+
+type clusterSvcBuilder struct {
+	*vmv1beta1.VLCluster
+	prefixedName      string
+	finalLabels       map[string]string
+	selectorLabels    map[string]string
+	additionalService *vmv1beta1.AdditionalServiceSpec
+}
+
+// PrefixedName implements build.svcBuilderArgs interface
+func (csb *clusterSvcBuilder) PrefixedName() string {
+	return csb.prefixedName
+}
+
+// AllLabels implements build.svcBuilderArgs interface
+func (csb *clusterSvcBuilder) AllLabels() map[string]string {
+	return csb.finalLabels
+}
+
+// SelectorLabels implements build.svcBuilderArgs interface
+func (csb *clusterSvcBuilder) SelectorLabels() map[string]string {
+	return csb.selectorLabels
+}
+
+// GetAdditionalService implements build.svcBuilderArgs interface
+func (csb *clusterSvcBuilder) GetAdditionalService() *vmv1beta1.AdditionalServiceSpec {
+	return csb.additionalService
+}
+
+// reconcileAdditionalService creates additional service for the given component if it's defined
+func reconcileAdditionalService(ctx context.Context, rclient client.Client, svc *corev1.Service, s *vmv1beta1.AdditionalServiceSpec, component string) error {
+	return s.IsSomeAndThen(func(s *vmv1beta1.AdditionalServiceSpec) error {
+		additionalService := build.AdditionalServiceFromDefault(svc, s)
+		if additionalService.Name == svc.Name {
+			return fmt.Errorf("%s additional service name: %q cannot be the same as crd.prefixedname: %q", component, additionalService.Name, svc.Name)
+		}
+		if err := reconcile.Service(ctx, rclient, additionalService, nil); err != nil {
+			return fmt.Errorf("cannot reconcile %s additional service: %w", component, err)
+		}
+		return nil
+	})
+}
+
+// addSecretsAndConfigMapsVolumes mounts given secrets and configmaps into the default directories
+func addSecretsAndConfigMapsVolumes(volumes []corev1.Volume, mounts []corev1.VolumeMount, secrets, configMaps []string) ([]corev1.Volume, []corev1.VolumeMount) {
+	for _, s := range secrets {
+		volumes = append(volumes, corev1.Volume{
+			Name: k8stools.SanitizeVolumeName("secret-" + s),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: s,
+				},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      k8stools.SanitizeVolumeName("secret-" + s),
+			ReadOnly:  true,
+			MountPath: path.Join(vmv1beta1.SecretsDir, s),
+		})
+	}
+	for _, c := range configMaps {
+		volumes = append(volumes, corev1.Volume{
+			Name: k8stools.SanitizeVolumeName("configmap-" + c),
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: c,
+					},
+				},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      k8stools.SanitizeVolumeName("configmap-" + c),
+			ReadOnly:  true,
+			MountPath: path.Join(vmv1beta1.ConfigMapsDir, c),
+		})
+	}
+	return volumes, mounts
+}
+
+func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VLCluster, rclient client.Client) error {
+	if cr.ParsedLastAppliedSpec == nil {
+		// fast path
+		return nil
+	}
+	prevSpec := cr.ParsedLastAppliedSpec
+	vls, prevVLS := cr.Spec.VLStorage, prevSpec.VLStorage
+	vlse, prevVLSe := cr.Spec.VLSelect, prevSpec.VLSelect
+	vli, prevVLI := cr.Spec.VLInsert, prevSpec.VLInsert
+
+	if prevVLS != nil {
+		if vls == nil {
+			if err := finalize.OnVLStorageDelete(ctx, rclient, cr, prevVLS); err != nil {
+				return fmt.Errorf("cannot remove storage from prev state: %w", err)
+			}
+		} else {
+			commonObjMeta := metav1.ObjectMeta{Namespace: cr.Namespace, Name: cr.GetStorageName()}
+			if vls.PodDisruptionBudget == nil && prevVLS.PodDisruptionBudget != nil {
+				if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &policyv1.PodDisruptionBudget{ObjectMeta: commonObjMeta}); err != nil {
+					return fmt.Errorf("cannot remove PDB from prev storage: %w", err)
+				}
+			}
+			if ptr.Deref(vls.DisableSelfServiceScrape, false) && !ptr.Deref(prevVLS.DisableSelfServiceScrape, false) {
+				if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMServiceScrape{ObjectMeta: commonObjMeta}); err != nil {
+					return fmt.Errorf("cannot remove serviceScrape from prev storage: %w", err)
+				}
+			}
+			if err := reconcile.AdditionalServices(ctx, rclient, cr.GetStorageName(), cr.Namespace, prevVLS.ServiceSpec, vls.ServiceSpec); err != nil {
+				return fmt.Errorf("cannot remove vlstorage additional service: %w", err)
+			}
+			if err := reconcile.RemoveOrphanedVPA(ctx, rclient, cr.GetStorageName(), cr.Namespace, prevVLS.VPA, vls.VPA); err != nil {
+				return fmt.Errorf("cannot remove VPA from prev vlstorage: %w", err)
+			}
+		}
+	}
+
+	if prevVLSe != nil {
+		if vlse == nil {
+			if err := finalize.OnVLSelectDelete(ctx, rclient, cr, prevVLSe); err != nil {
+				return fmt.Errorf("cannot remove select from prev state: %w", err)
+			}
+		} else {
+			commonObjMeta := metav1.ObjectMeta{Namespace: cr.Namespace, Name: cr.GetSelectName()}
+			if vlse.PodDisruptionBudget == nil && prevVLSe.PodDisruptionBudget != nil {
+				if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &policyv1.PodDisruptionBudget{ObjectMeta: commonObjMeta}); err != nil {
+					return fmt.Errorf("cannot remove PDB from prev select: %w", err)
+				}
+			}
+			if vlse.HPA == nil && prevVLSe.HPA != nil {
+				if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &v2.HorizontalPodAutoscaler{ObjectMeta: commonObjMeta}); err != nil {
+					return fmt.Errorf("cannot remove HPA from prev select: %w", err)
+				}
+			}
+			if ptr.Deref(vlse.DisableSelfServiceScrape, false) && !ptr.Deref(prevVLSe.DisableSelfServiceScrape, false) {
+				if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMServiceScrape{ObjectMeta: commonObjMeta}); err != nil {
+					return fmt.Errorf("cannot remove serviceScrape from prev select: %w", err)
+				}
+			}
+			if err := reconcile.AdditionalServices(ctx, rclient, cr.GetSelectName(), cr.Namespace, prevVLSe.ServiceSpec, vlse.ServiceSpec); err != nil {
+				return fmt.Errorf("cannot remove vlselect additional service: %w", err)
+			}
+			if err := reconcile.RemoveOrphanedVPA(ctx, rclient, cr.GetSelectName(), cr.Namespace, prevVLSe.VPA, vlse.VPA); err != nil {
+				return fmt.Errorf("cannot remove VPA from prev vlselect: %w", err)
+			}
+			if err := deletePrevLBComponentResources(ctx, rclient, cr, cr.GetSelectName(), cr.GetSelectLBName(), vlse.DisableSelfServiceScrape,
+				isLBEnabledFor(prevSpec, prevSpec.RequestsLoadBalancer.DisableSelectBalancing),
+				isLBEnabledFor(&cr.Spec, cr.Spec.RequestsLoadBalancer.DisableSelectBalancing)); err != nil {
+				return fmt.Errorf("cannot remove vlselect lb resources: %w", err)
+			}
+		}
+	}
+
+	if prevVLI != nil {
+		if vli == nil {
+			if err := finalize.OnVLInsertDelete(ctx, rclient, cr, prevVLI); err != nil {
+				return fmt.Errorf("cannot remove insert from prev state: %w", err)
+			}
+		} else {
+			commonObjMeta := metav1.ObjectMeta{Namespace: cr.Namespace, Name: cr.GetInsertName()}
+			if vli.PodDisruptionBudget == nil && prevVLI.PodDisruptionBudget != nil {
+				if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &policyv1.PodDisruptionBudget{ObjectMeta: commonObjMeta}); err != nil {
+					return fmt.Errorf("cannot remove PDB from prev insert: %w", err)
+				}
+			}
+			if vli.HPA == nil && prevVLI.HPA != nil {
+				if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &v2.HorizontalPodAutoscaler{ObjectMeta: commonObjMeta}); err != nil {
+					return fmt.Errorf("cannot remove HPA from prev insert: %w", err)
+				}
+			}
+			if ptr.Deref(vli.DisableSelfServiceScrape, false) && !ptr.Deref(prevVLI.DisableSelfServiceScrape, false) {
+				if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMServiceScrape{ObjectMeta: commonObjMeta}); err != nil {
+					return fmt.Errorf("cannot remove serviceScrape from prev insert: %w", err)
+				}
+			}
+			if err := reconcile.AdditionalServices(ctx, rclient, cr.GetInsertName(), cr.Namespace, prevVLI.ServiceSpec, vli.ServiceSpec); err != nil {
+				return fmt.Errorf("cannot remove vlinsert additional service: %w", err)
+			}
+			if err := reconcile.RemoveOrphanedVPA(ctx, rclient, cr.GetInsertName(), cr.Namespace, prevVLI.VPA, vli.VPA); err != nil {
+				return fmt.Errorf("cannot remove VPA from prev vlinsert: %w", err)
+			}
+			if err := deletePrevLBComponentResources(ctx, rclient, cr, cr.GetInsertName(), cr.GetInsertLBName(), vli.DisableSelfServiceScrape,
+				isLBEnabledFor(prevSpec, prevSpec.RequestsLoadBalancer.DisableInsertBalancing),
+				isLBEnabledFor(&cr.Spec, cr.Spec.RequestsLoadBalancer.DisableInsertBalancing)); err != nil {
+				return fmt.Errorf("cannot remove vlinsert lb resources: %w", err)
+			}
+		}
+	}
+
+	if prevSpec.RequestsLoadBalancer.Enabled && !cr.Spec.RequestsLoadBalancer.Enabled {
+		prevCR := cr.DeepCopy()
+		prevCR.Spec = *prevSpec
+		if err := finalize.OnVLClusterLoadBalancerDelete(ctx, rclient, prevCR); err != nil {
+			return fmt.Errorf("failed to remove loadbalancer components enabled at prev state: %w", err)
+		}
+	}
+	if cr.Spec.RequestsLoadBalancer.Enabled &&
+		prevSpec.RequestsLoadBalancer.Spec.PodDisruptionBudget != nil &&
+		cr.Spec.RequestsLoadBalancer.Spec.PodDisruptionBudget == nil {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cr.GetVMAuthLBName(),
+				Namespace: cr.Namespace,
+			},
+		}); err != nil {
+			return fmt.Errorf("cannot delete PodDisruptionBudget for cluster lb: %w", err)
+		}
+	}
+	if cr.Spec.RequestsLoadBalancer.Enabled {
+		if err := reconcile.RemoveOrphanedVPA(ctx, rclient, cr.GetVMAuthLBName(), cr.Namespace, prevSpec.RequestsLoadBalancer.Spec.VPA, cr.Spec.RequestsLoadBalancer.Spec.VPA); err != nil {
+			return fmt.Errorf("cannot delete VPA for cluster lb: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func isLBEnabledFor(spec *vmv1beta1.VLClusterSpec, disabledForComponent bool) bool {
+	return spec.RequestsLoadBalancer.Enabled && !disabledForComponent
+}
+
+// deletePrevLBComponentResources removes services and service scrapes
+// left after load-balancing mode transition for the given component
+func deletePrevLBComponentResources(ctx context.Context, rclient client.Client, cr *vmv1beta1.VLCluster, name, lbName string, disableSelfServiceScrape *bool, prevLBEnabled, lbEnabled bool) error {
+	switch {
+	case !prevLBEnabled && lbEnabled:
+		// component service was renamed, service scrape must be re-created
+		if !ptr.Deref(disableSelfServiceScrape, false) {
+			if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMServiceScrape{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: cr.Namespace},
+			}); err != nil {
+				return fmt.Errorf("cannot delete vmservicescrape for non-lb svc: %w", err)
+			}
+		}
+	case prevLBEnabled && !lbEnabled:
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: lbName, Namespace: cr.Namespace},
+		}); err != nil {
+			return fmt.Errorf("cannot remove lb service: %w", err)
+		}
+		if !ptr.Deref(disableSelfServiceScrape, false) {
+			if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMServiceScrape{
+				ObjectMeta: metav1.ObjectMeta{Name: lbName, Namespace: cr.Namespace},
+			}); err != nil {
+				return fmt.Errorf("cannot delete vmservicescrape for lb svc: %w", err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package vlcluster
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCreateOrUpdateVLCluster(t *testing.T) {
+	f := func(cr *vmv1beta1.VLCluster, validate func(rclient client.Client) error, predefinedObjects ...runtime.Object) {
+		t.Helper()
+		fclient := k8stools.GetTestClientWithObjects(predefinedObjects)
+		build.AddDefaults(fclient.Scheme())
+		fclient.Scheme().Default(cr)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var wg sync.WaitGroup
+		eventuallyUpdateStatusToOk := func(cb func() error) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				tc := time.NewTicker(time.Millisecond * 100)
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-tc.C:
+						if err := cb(); err != nil {
+							if errors.IsNotFound(err) {
+								continue
+							}
+							t.Errorf("callback error: %s", err)
+							return
+						}
+						return
+					}
+				}
+			}()
+		}
+		deploymentReady := func(name string) {
+			eventuallyUpdateStatusToOk(func() error {
+				var dep appsv1.Deployment
+				if err := fclient.Get(ctx, types.NamespacedName{Name: name, Namespace: cr.Namespace}, &dep); err != nil {
+					return err
+				}
+				dep.Status.Conditions = append(dep.Status.Conditions, appsv1.DeploymentCondition{
+					Type:   appsv1.DeploymentProgressing,
+					Reason: "NewReplicaSetAvailable",
+					Status: "True",
+				})
+				dep.Status.Replicas = ptr.Deref(dep.Spec.Replicas, 0)
+				dep.Status.UpdatedReplicas = dep.Status.Replicas
+				dep.Status.AvailableReplicas = dep.Status.Replicas
+				return fclient.Status().Update(ctx, &dep)
+			})
+		}
+		if cr.Spec.RequestsLoadBalancer.Enabled {
+			deploymentReady(cr.GetVMAuthLBName())
+		}
+		if cr.Spec.VLInsert != nil {
+			deploymentReady(cr.GetInsertName())
+		}
+		if cr.Spec.VLSelect != nil {
+			deploymentReady(cr.GetSelectName())
+		}
+		if cr.Spec.VLStorage != nil {
+			eventuallyUpdateStatusToOk(func() error {
+				var sts appsv1.StatefulSet
+				if err := fclient.Get(ctx, types.NamespacedName{Name: cr.GetStorageName(), Namespace: cr.Namespace}, &sts); err != nil {
+					return err
+				}
+				sts.Status.ReadyReplicas = *cr.Spec.VLStorage.ReplicaCount
+				sts.Status.UpdatedReplicas = *cr.Spec.VLStorage.ReplicaCount
+				return fclient.Status().Update(ctx, &sts)
+			})
+		}
+		if err := CreateOrUpdateVLCluster(ctx, cr, fclient); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		cancel()
+		wg.Wait()
+		if err := validate(fclient); err != nil {
+			t.Fatalf("validation for cluster failed: %s", err)
+		}
+	}
+	storagePod := func(idx int) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      fmt.Sprintf("vlstorage-cluster-1-%d", idx),
+				Labels: map[string]string{
+					"app.kubernetes.io/component": "monitoring",
+					"app.kubernetes.io/name":      "vlstorage",
+					"app.kubernetes.io/instance":  "cluster-1",
+					"managed-by":                  "vm-operator",
+				},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning, Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: "True"}}},
+		}
+	}
+	getContainerArgs := func(rclient client.Client, name string) ([]string, error) {
+		var dep appsv1.Deployment
+		if err := rclient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: name}, &dep); err != nil {
+			return nil, err
+		}
+		return dep.Spec.Template.Spec.Containers[0].Args, nil
+	}
+
+	// base cluster with maintenance nodes
+	f(&vmv1beta1.VLCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "cluster-1",
+		},
+		Spec: vmv1beta1.VLClusterSpec{
+			VLStorage: &vmv1beta1.VLStorage{
+				RetentionPeriod:          "14d",
+				MaintenanceInsertNodeIDs: []int32{1},
+				CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+					ReplicaCount: ptr.To(int32(2)),
+				},
+			},
+			VLSelect: &vmv1beta1.VLSelect{
+				CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+					ReplicaCount: ptr.To(int32(1)),
+				},
+			},
+			VLInsert: &vmv1beta1.VLInsert{
+				CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+					ReplicaCount: ptr.To(int32(1)),
+				},
+			},
+		},
+	}, func(rclient client.Client) error {
+		ctx := context.Background()
+		var sts appsv1.StatefulSet
+		if err := rclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "vlstorage-cluster-1"}, &sts); err != nil {
+			return err
+		}
+		stsArgs := sts.Spec.Template.Spec.Containers[0].Args
+		if !slices.Contains(stsArgs, "-retentionPeriod=14d") {
+			return fmt.Errorf("expected retentionPeriod arg at vlstorage, got: %v", stsArgs)
+		}
+		selectArgs, err := getContainerArgs(rclient, "vlselect-cluster-1")
+		if err != nil {
+			return err
+		}
+		wantSelect := "-storageNode=vlstorage-cluster-1-0.vlstorage-cluster-1.default:9491,vlstorage-cluster-1-1.vlstorage-cluster-1.default:9491"
+		if !slices.Contains(selectArgs, wantSelect) {
+			return fmt.Errorf("expected %q arg at vlselect, got: %v", wantSelect, selectArgs)
+		}
+		insertArgs, err := getContainerArgs(rclient, "vlinsert-cluster-1")
+		if err != nil {
+			return err
+		}
+		wantInsert := "-storageNode=vlstorage-cluster-1-0.vlstorage-cluster-1.default:9491"
+		if !slices.Contains(insertArgs, wantInsert) {
+			return fmt.Errorf("expected %q arg at vlinsert, got: %v", wantInsert, insertArgs)
+		}
+		var svc corev1.Service
+		if err := rclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "vlstorage-cluster-1"}, &svc); err != nil {
+			return err
+		}
+		if svc.Spec.ClusterIP != corev1.ClusterIPNone {
+			return fmt.Errorf("expected headless service for vlstorage, got clusterIP: %q", svc.Spec.ClusterIP)
+		}
+		return nil
+	}, storagePod(0), storagePod(1))
+
+	// with requests load balancer
+	f(&vmv1beta1.VLCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "cluster-1",
+		},
+		Spec: vmv1beta1.VLClusterSpec{
+			RequestsLoadBalancer: vmv1beta1.VMAuthLoadBalancer{
+				Enabled:                true,
+				DisableInsertBalancing: true,
+			},
+			VLSelect: &vmv1beta1.VLSelect{
+				CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+					ReplicaCount: ptr.To(int32(1)),
+				},
+			},
+			VLInsert: &vmv1beta1.VLInsert{
+				CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+					ReplicaCount: ptr.To(int32(1)),
+				},
+			},
+		},
+	}, func(rclient client.Client) error {
+		ctx := context.Background()
+		var secret corev1.Secret
+		if err := rclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "vlclusterlb-cluster-1"}, &secret); err != nil {
+			return fmt.Errorf("cannot get vmauth lb secret: %w", err)
+		}
+		var svc corev1.Service
+		if err := rclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "vlselectinternal-cluster-1"}, &svc); err != nil {
+			return fmt.Errorf("cannot get vlselect internal service: %w", err)
+		}
+		if svc.Spec.ClusterIP != corev1.ClusterIPNone {
+			return fmt.Errorf("expected headless internal service for vlselect, got clusterIP: %q", svc.Spec.ClusterIP)
+		}
+		if err := rclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "vlselect-cluster-1"}, &svc); err != nil {
+			return fmt.Errorf("cannot get vlselect proxy service: %w", err)
+		}
+		if svc.Spec.Selector["app.kubernetes.io/name"] != "vlclusterlb-vmauth-balancer" {
+			return fmt.Errorf("expected vlselect service to point to the load balancer, got selector: %v", svc.Spec.Selector)
+		}
+		if err := rclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "vlinsertinternal-cluster-1"}, &svc); !errors.IsNotFound(err) {
+			return fmt.Errorf("expected vlinsert internal service to be missing with disabled insert balancing, got err: %v", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,239 @@
+package vlcluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func createOrUpdateVLInsert(ctx context.Context, rclient client.Client, cr *vmv1beta1.VLCluster) error {
+	if cr.Spec.VLInsert.PodDisruptionBudget != nil {
+		pdb := build.PodDisruptionBudget(buildVLInsertSvcBuilder(cr), cr.Spec.VLInsert.PodDisruptionBudget)
+		if err := reconcile.PDB(ctx, rclient, pdb); err != nil {
+			return fmt.Errorf("cannot reconcile PodDisruptionBudget for vlinsert: %w", err)
+		}
+	}
+	var prevDeploy *appsv1.Deployment
+	if cr.ParsedLastAppliedSpec != nil && cr.ParsedLastAppliedSpec.VLInsert != nil {
+		prevCR := cr.DeepCopy()
+		prevCR.Spec = *cr.ParsedLastAppliedSpec
+		var err error
+		prevDeploy, err = buildVLInsertDeployment(prevCR)
+		if err != nil {
+			return fmt.Errorf("cannot generate prev deploy spec: %w", err)
+		}
+	}
+	newDeploy, err := buildVLInsertDeployment(cr)
+	if err != nil {
+		return vmv1beta1.NewConfigError(err)
+	}
+	if err := reconcile.Deployment(ctx, rclient, newDeploy, prevDeploy, cr.Spec.VLInsert.HPA != nil); err != nil {
+		return err
+	}
+
+	svc, err := createOrUpdateVLInsertService(ctx, rclient, cr)
+	if err != nil {
+		return err
+	}
+	if cr.Spec.VLInsert.HPA != nil {
+		targetRef := v2beta2.CrossVersionObjectReference{
+			Name:       cr.GetInsertName(),
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		}
+		hpa := build.HPA(targetRef, cr.Spec.VLInsert.HPA, cr.AsOwner(), cr.VLInsertSelectorLabels(), cr.Namespace)
+		if err := reconcile.HPA(ctx, rclient, hpa); err != nil {
+			return fmt.Errorf("cannot reconcile HPA for vlinsert: %w", err)
+		}
+	}
+	if cr.Spec.VLInsert.VPA != nil {
+		targetRef := autoscalingv1.CrossVersionObjectReference{
+			Name:       cr.GetInsertName(),
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		}
+		vpa := build.VPA(targetRef, "vlinsert", cr.Spec.VLInsert.VPA, cr.AsOwner(), cr.VLInsertSelectorLabels(), cr.Namespace)
+		if err := reconcile.VPA(ctx, rclient, vpa); err != nil {
+			return fmt.Errorf("cannot reconcile VPA for vlinsert: %w", err)
+		}
+	}
+	if !ptr.Deref(cr.Spec.VLInsert.DisableSelfServiceScrape, false) {
+		svs := build.VMServiceScrapeForServiceWithSpec(svc, cr.Spec.VLInsert)
+		if isLBEnabledFor(&cr.Spec, cr.Spec.RequestsLoadBalancer.DisableInsertBalancing) {
+			svs.Spec.JobLabel = vmauthLBServiceProxyJobNameLabel
+		}
+		if err := reconcile.VMServiceScrapeForCRD(ctx, rclient, svs); err != nil {
+			return fmt.Errorf("cannot create VMServiceScrape for vlinsert: %w", err)
+		}
+	}
+	return nil
+}
+
+func buildVLInsertSvcBuilder(cr *vmv1beta1.VLCluster) *clusterSvcBuilder {
+	return &clusterSvcBuilder{
+		cr,
+		cr.GetInsertName(),
+		cr.FinalLabels(cr.VLInsertSelectorLabels()),
+		cr.VLInsertSelectorLabels(),
+		cr.Spec.VLInsert.ServiceSpec,
+	}
+}
+
+func buildVLInsertService(cr *vmv1beta1.VLCluster) *corev1.Service {
+	svc := build.Service(buildVLInsertSvcBuilder(cr), cr.Spec.VLInsert.Port, nil)
+	if isLBEnabledFor(&cr.Spec, cr.Spec.RequestsLoadBalancer.DisableInsertBalancing) {
+		svc.Name = cr.GetInsertLBName()
+		svc.Spec.ClusterIP = corev1.ClusterIPNone
+		svc.Spec.Type = corev1.ServiceTypeClusterIP
+		svc.Labels[vmauthLBServiceProxyJobNameLabel] = cr.GetInsertName()
+	}
+	return svc
+}
+
+func createOrUpdateVLInsertService(ctx context.Context, rclient client.Client, cr *vmv1beta1.VLCluster) (*corev1.Service, error) {
+	newService := buildVLInsertService(cr)
+	if err := reconcileAdditionalService(ctx, rclient, newService, cr.Spec.VLInsert.ServiceSpec, "vlinsert"); err != nil {
+		return nil, err
+	}
+	var prevService *corev1.Service
+	if cr.ParsedLastAppliedSpec != nil && cr.ParsedLastAppliedSpec.VLInsert != nil {
+		prevCR := cr.DeepCopy()
+		prevCR.Spec = *cr.ParsedLastAppliedSpec
+		prevService = buildVLInsertService(prevCR)
+	}
+	if err := reconcile.Service(ctx, rclient, newService, prevService); err != nil {
+		return nil, fmt.Errorf("cannot reconcile vlinsert service: %w", err)
+	}
+	if isLBEnabledFor(&cr.Spec, cr.Spec.RequestsLoadBalancer.DisableInsertBalancing) {
+		if err := createOrUpdateLBProxyService(ctx, rclient, cr, cr.GetInsertName(), cr.Spec.VLInsert.Port, "vlinsert"); err != nil {
+			return nil, fmt.Errorf("cannot create lb svc for vlinsert: %w", err)
+		}
+	}
+	return newService, nil
+}
+
+func buildVLInsertDeployment(cr *vmv1beta1.VLCluster) (*appsv1.Deployment, error) {
+	podSpec, err := buildVLInsertPodSpec(cr)
+	if err != nil {
+		return nil, err
+	}
+	strategyType := appsv1.RollingUpdateDeploymentStrategyType
+	if cr.Spec.VLInsert.UpdateStrategy != nil {
+		strategyType = *cr.Spec.VLInsert.UpdateStrategy
+	}
+	depSpec := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cr.GetInsertName(),
+			Namespace:       cr.Namespace,
+			Labels:          cr.FinalLabels(cr.VLInsertSelectorLabels()),
+			Annotations:     cr.AnnotationsFiltered(),
+			OwnerReferences: cr.AsOwner(),
+			Finalizers:      []string{vmv1beta1.FinalizerName},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Strategy: appsv1.DeploymentStrategy{
+				Type:          strategyType,
+				RollingUpdate: cr.Spec.VLInsert.RollingUpdate,
+			},
+			Selector: &metav1.LabelSelector{
+				MatchLabels: cr.VLInsertSelectorLabels(),
+			},
+			Template: *podSpec,
+		},
+	}
+	build.DeploymentAddCommonParams(depSpec, ptr.Deref(cr.Spec.VLInsert.UseStrictSecurity, false), &cr.Spec.VLInsert.CommonApplicationDeploymentParams)
+	return depSpec, nil
+}
+
+func buildVLInsertPodSpec(cr *vmv1beta1.VLCluster) (*corev1.PodTemplateSpec, error) {
+	spec := cr.Spec.VLInsert
+	args := []string{
+		fmt.Sprintf("-httpListenAddr=:%s", spec.Port),
+	}
+	if spec.LogLevel != "" {
+		args = append(args, fmt.Sprintf("-loggerLevel=%s", spec.LogLevel))
+	}
+	if spec.LogFormat != "" {
+		args = append(args, fmt.Sprintf("-loggerFormat=%s", spec.LogFormat))
+	}
+	if cr.Spec.VLStorage != nil && cr.Spec.VLStorage.ReplicaCount != nil {
+		storageArg := "-storageNode="
+		for _, i := range cr.AvailableStorageNodeIDs("insert") {
+			storageArg += cr.Spec.VLStorage.BuildPodName(cr.GetStorageName(), i, cr.Namespace, cr.Spec.VLStorage.Port, cr.Spec.ClusterDomainName)
+		}
+		args = append(args, strings.TrimSuffix(storageArg, ","))
+	}
+	if len(spec.ExtraEnvs) > 0 {
+		args = append(args, "-envflag.enable=true")
+	}
+
+	ports := []corev1.ContainerPort{
+		{
+			Name:          "http",
+			Protocol:      "TCP",
+			ContainerPort: intstr.Parse(spec.Port).IntVal,
+		},
+	}
+	volumes := make([]corev1.Volume, 0)
+	volumes = append(volumes, spec.Volumes...)
+	vmMounts := make([]corev1.VolumeMount, 0)
+	vmMounts = append(vmMounts, spec.VolumeMounts...)
+	volumes, vmMounts = addSecretsAndConfigMapsVolumes(volumes, vmMounts, spec.Secrets, spec.ConfigMaps)
+
+	args = build.AddExtraArgsOverrideDefaults(args, spec.ExtraArgs, "-")
+	sort.Strings(args)
+	vlinsertContainer := corev1.Container{
+		Name:                     "vlinsert",
+		Image:                    fmt.Sprintf("%s:%s", spec.Image.Repository, spec.Image.Tag),
+		ImagePullPolicy:          spec.Image.PullPolicy,
+		Ports:                    ports,
+		Args:                     args,
+		VolumeMounts:             vmMounts,
+		Resources:                spec.Resources,
+		Env:                      spec.ExtraEnvs,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+	vlinsertContainer = build.Probe(vlinsertContainer, spec)
+
+	operatorContainers := []corev1.Container{vlinsertContainer}
+	build.AddStrictSecuritySettingsToContainers(spec.SecurityContext, operatorContainers, ptr.Deref(spec.UseStrictSecurity, false))
+	containers, err := k8stools.MergePatchContainers(operatorContainers, spec.Containers)
+	if err != nil {
+		return nil, fmt.Errorf("cannot patch vlinsert containers: %w", err)
+	}
+
+	for i := range spec.TopologySpreadConstraints {
+		if spec.TopologySpreadConstraints[i].LabelSelector == nil {
+			spec.TopologySpreadConstraints[i].LabelSelector = &metav1.LabelSelector{
+				MatchLabels: cr.VLInsertSelectorLabels(),
+			}
+		}
+	}
+
+	return &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      cr.VLInsertPodLabels(),
+			Annotations: cr.VLInsertPodAnnotations(),
+		},
+		Spec: corev1.PodSpec{
+			Volumes:            volumes,
+			InitContainers:     spec.InitContainers,
+			Containers:         containers,
+			ServiceAccountName: cr.GetServiceAccountName(),
+		},
+	}, nil
+}
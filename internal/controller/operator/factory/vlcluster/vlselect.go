@@ -0,0 +1,239 @@
+package vlcluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func createOrUpdateVLSelect(ctx context.Context, rclient client.Client, cr *vmv1beta1.VLCluster) error {
+	if cr.Spec.VLSelect.PodDisruptionBudget != nil {
+		pdb := build.PodDisruptionBudget(buildVLSelectSvcBuilder(cr), cr.Spec.VLSelect.PodDisruptionBudget)
+		if err := reconcile.PDB(ctx, rclient, pdb); err != nil {
+			return fmt.Errorf("cannot reconcile PodDisruptionBudget for vlselect: %w", err)
+		}
+	}
+	var prevDeploy *appsv1.Deployment
+	if cr.ParsedLastAppliedSpec != nil && cr.ParsedLastAppliedSpec.VLSelect != nil {
+		prevCR := cr.DeepCopy()
+		prevCR.Spec = *cr.ParsedLastAppliedSpec
+		var err error
+		prevDeploy, err = buildVLSelectDeployment(prevCR)
+		if err != nil {
+			return fmt.Errorf("cannot generate prev deploy spec: %w", err)
+		}
+	}
+	newDeploy, err := buildVLSelectDeployment(cr)
+	if err != nil {
+		return vmv1beta1.NewConfigError(err)
+	}
+	if err := reconcile.Deployment(ctx, rclient, newDeploy, prevDeploy, cr.Spec.VLSelect.HPA != nil); err != nil {
+		return err
+	}
+
+	svc, err := createOrUpdateVLSelectService(ctx, rclient, cr)
+	if err != nil {
+		return err
+	}
+	if cr.Spec.VLSelect.HPA != nil {
+		targetRef := v2beta2.CrossVersionObjectReference{
+			Name:       cr.GetSelectName(),
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		}
+		hpa := build.HPA(targetRef, cr.Spec.VLSelect.HPA, cr.AsOwner(), cr.VLSelectSelectorLabels(), cr.Namespace)
+		if err := reconcile.HPA(ctx, rclient, hpa); err != nil {
+			return fmt.Errorf("cannot reconcile HPA for vlselect: %w", err)
+		}
+	}
+	if cr.Spec.VLSelect.VPA != nil {
+		targetRef := autoscalingv1.CrossVersionObjectReference{
+			Name:       cr.GetSelectName(),
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		}
+		vpa := build.VPA(targetRef, "vlselect", cr.Spec.VLSelect.VPA, cr.AsOwner(), cr.VLSelectSelectorLabels(), cr.Namespace)
+		if err := reconcile.VPA(ctx, rclient, vpa); err != nil {
+			return fmt.Errorf("cannot reconcile VPA for vlselect: %w", err)
+		}
+	}
+	if !ptr.Deref(cr.Spec.VLSelect.DisableSelfServiceScrape, false) {
+		svs := build.VMServiceScrapeForServiceWithSpec(svc, cr.Spec.VLSelect)
+		if isLBEnabledFor(&cr.Spec, cr.Spec.RequestsLoadBalancer.DisableSelectBalancing) {
+			svs.Spec.JobLabel = vmauthLBServiceProxyJobNameLabel
+		}
+		if err := reconcile.VMServiceScrapeForCRD(ctx, rclient, svs); err != nil {
+			return fmt.Errorf("cannot create VMServiceScrape for vlselect: %w", err)
+		}
+	}
+	return nil
+}
+
+func buildVLSelectSvcBuilder(cr *vmv1beta1.VLCluster) *clusterSvcBuilder {
+	return &clusterSvcBuilder{
+		cr,
+		cr.GetSelectName(),
+		cr.FinalLabels(cr.VLSelectSelectorLabels()),
+		cr.VLSelectSelectorLabels(),
+		cr.Spec.VLSelect.ServiceSpec,
+	}
+}
+
+func buildVLSelectService(cr *vmv1beta1.VLCluster) *corev1.Service {
+	svc := build.Service(buildVLSelectSvcBuilder(cr), cr.Spec.VLSelect.Port, nil)
+	if isLBEnabledFor(&cr.Spec, cr.Spec.RequestsLoadBalancer.DisableSelectBalancing) {
+		svc.Name = cr.GetSelectLBName()
+		svc.Spec.ClusterIP = corev1.ClusterIPNone
+		svc.Spec.Type = corev1.ServiceTypeClusterIP
+		svc.Labels[vmauthLBServiceProxyJobNameLabel] = cr.GetSelectName()
+	}
+	return svc
+}
+
+func createOrUpdateVLSelectService(ctx context.Context, rclient client.Client, cr *vmv1beta1.VLCluster) (*corev1.Service, error) {
+	newService := buildVLSelectService(cr)
+	if err := reconcileAdditionalService(ctx, rclient, newService, cr.Spec.VLSelect.ServiceSpec, "vlselect"); err != nil {
+		return nil, err
+	}
+	var prevService *corev1.Service
+	if cr.ParsedLastAppliedSpec != nil && cr.ParsedLastAppliedSpec.VLSelect != nil {
+		prevCR := cr.DeepCopy()
+		prevCR.Spec = *cr.ParsedLastAppliedSpec
+		prevService = buildVLSelectService(prevCR)
+	}
+	if err := reconcile.Service(ctx, rclient, newService, prevService); err != nil {
+		return nil, fmt.Errorf("cannot reconcile vlselect service: %w", err)
+	}
+	if isLBEnabledFor(&cr.Spec, cr.Spec.RequestsLoadBalancer.DisableSelectBalancing) {
+		if err := createOrUpdateLBProxyService(ctx, rclient, cr, cr.GetSelectName(), cr.Spec.VLSelect.Port, "vlselect"); err != nil {
+			return nil, fmt.Errorf("cannot create lb svc for vlselect: %w", err)
+		}
+	}
+	return newService, nil
+}
+
+func buildVLSelectDeployment(cr *vmv1beta1.VLCluster) (*appsv1.Deployment, error) {
+	podSpec, err := buildVLSelectPodSpec(cr)
+	if err != nil {
+		return nil, err
+	}
+	strategyType := appsv1.RollingUpdateDeploymentStrategyType
+	if cr.Spec.VLSelect.UpdateStrategy != nil {
+		strategyType = *cr.Spec.VLSelect.UpdateStrategy
+	}
+	depSpec := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cr.GetSelectName(),
+			Namespace:       cr.Namespace,
+			Labels:          cr.FinalLabels(cr.VLSelectSelectorLabels()),
+			Annotations:     cr.AnnotationsFiltered(),
+			OwnerReferences: cr.AsOwner(),
+			Finalizers:      []string{vmv1beta1.FinalizerName},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Strategy: appsv1.DeploymentStrategy{
+				Type:          strategyType,
+				RollingUpdate: cr.Spec.VLSelect.RollingUpdate,
+			},
+			Selector: &metav1.LabelSelector{
+				MatchLabels: cr.VLSelectSelectorLabels(),
+			},
+			Template: *podSpec,
+		},
+	}
+	build.DeploymentAddCommonParams(depSpec, ptr.Deref(cr.Spec.VLSelect.UseStrictSecurity, false), &cr.Spec.VLSelect.CommonApplicationDeploymentParams)
+	return depSpec, nil
+}
+
+func buildVLSelectPodSpec(cr *vmv1beta1.VLCluster) (*corev1.PodTemplateSpec, error) {
+	spec := cr.Spec.VLSelect
+	args := []string{
+		fmt.Sprintf("-httpListenAddr=:%s", spec.Port),
+	}
+	if spec.LogLevel != "" {
+		args = append(args, fmt.Sprintf("-loggerLevel=%s", spec.LogLevel))
+	}
+	if spec.LogFormat != "" {
+		args = append(args, fmt.Sprintf("-loggerFormat=%s", spec.LogFormat))
+	}
+	if cr.Spec.VLStorage != nil && cr.Spec.VLStorage.ReplicaCount != nil {
+		storageArg := "-storageNode="
+		for _, i := range cr.AvailableStorageNodeIDs("select") {
+			storageArg += cr.Spec.VLStorage.BuildPodName(cr.GetStorageName(), i, cr.Namespace, cr.Spec.VLStorage.Port, cr.Spec.ClusterDomainName)
+		}
+		args = append(args, strings.TrimSuffix(storageArg, ","))
+	}
+	if len(spec.ExtraEnvs) > 0 {
+		args = append(args, "-envflag.enable=true")
+	}
+
+	ports := []corev1.ContainerPort{
+		{
+			Name:          "http",
+			Protocol:      "TCP",
+			ContainerPort: intstr.Parse(spec.Port).IntVal,
+		},
+	}
+	volumes := make([]corev1.Volume, 0)
+	volumes = append(volumes, spec.Volumes...)
+	vmMounts := make([]corev1.VolumeMount, 0)
+	vmMounts = append(vmMounts, spec.VolumeMounts...)
+	volumes, vmMounts = addSecretsAndConfigMapsVolumes(volumes, vmMounts, spec.Secrets, spec.ConfigMaps)
+
+	args = build.AddExtraArgsOverrideDefaults(args, spec.ExtraArgs, "-")
+	sort.Strings(args)
+	vlselectContainer := corev1.Container{
+		Name:                     "vlselect",
+		Image:                    fmt.Sprintf("%s:%s", spec.Image.Repository, spec.Image.Tag),
+		ImagePullPolicy:          spec.Image.PullPolicy,
+		Ports:                    ports,
+		Args:                     args,
+		VolumeMounts:             vmMounts,
+		Resources:                spec.Resources,
+		Env:                      spec.ExtraEnvs,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+	vlselectContainer = build.Probe(vlselectContainer, spec)
+
+	operatorContainers := []corev1.Container{vlselectContainer}
+	build.AddStrictSecuritySettingsToContainers(spec.SecurityContext, operatorContainers, ptr.Deref(spec.UseStrictSecurity, false))
+	containers, err := k8stools.MergePatchContainers(operatorContainers, spec.Containers)
+	if err != nil {
+		return nil, fmt.Errorf("cannot patch vlselect containers: %w", err)
+	}
+
+	for i := range spec.TopologySpreadConstraints {
+		if spec.TopologySpreadConstraints[i].LabelSelector == nil {
+			spec.TopologySpreadConstraints[i].LabelSelector = &metav1.LabelSelector{
+				MatchLabels: cr.VLSelectSelectorLabels(),
+			}
+		}
+	}
+
+	return &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      cr.VLSelectPodLabels(),
+			Annotations: cr.VLSelectPodAnnotations(),
+		},
+		Spec: corev1.PodSpec{
+			Volumes:            volumes,
+			InitContainers:     spec.InitContainers,
+			Containers:         containers,
+			ServiceAccountName: cr.GetServiceAccountName(),
+		},
+	}, nil
+}
@@ -0,0 +1,225 @@
+package vlcluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func createOrUpdateVLStorage(ctx context.Context, rclient client.Client, cr *vmv1beta1.VLCluster) error {
+	if cr.Spec.VLStorage.PodDisruptionBudget != nil {
+		pdb := build.PodDisruptionBudget(buildVLStorageSvcBuilder(cr), cr.Spec.VLStorage.PodDisruptionBudget)
+		if err := reconcile.PDB(ctx, rclient, pdb); err != nil {
+			return fmt.Errorf("cannot reconcile PodDisruptionBudget for vlstorage: %w", err)
+		}
+	}
+	var prevSts *appsv1.StatefulSet
+	if cr.ParsedLastAppliedSpec != nil && cr.ParsedLastAppliedSpec.VLStorage != nil {
+		prevCR := cr.DeepCopy()
+		prevCR.Spec = *cr.ParsedLastAppliedSpec
+		var err error
+		prevSts, err = buildVLStorageSTSSpec(prevCR)
+		if err != nil {
+			return fmt.Errorf("cannot build prev storage spec: %w", err)
+		}
+	}
+	newSts, err := buildVLStorageSTSSpec(cr)
+	if err != nil {
+		return vmv1beta1.NewConfigError(err)
+	}
+	stsOpts := reconcile.STSOptions{
+		HasClaim:       len(newSts.Spec.VolumeClaimTemplates) > 0,
+		SelectorLabels: cr.VLStorageSelectorLabels,
+	}
+	if err := reconcile.HandleSTSUpdate(ctx, rclient, stsOpts, newSts, prevSts); err != nil {
+		return err
+	}
+	if cr.Spec.VLStorage.VPA != nil {
+		targetRef := autoscalingv1.CrossVersionObjectReference{
+			Name:       cr.GetStorageName(),
+			Kind:       "StatefulSet",
+			APIVersion: "apps/v1",
+		}
+		vpa := build.VPA(targetRef, "vlstorage", cr.Spec.VLStorage.VPA, cr.AsOwner(), cr.VLStorageSelectorLabels(), cr.Namespace)
+		if err := reconcile.VPA(ctx, rclient, vpa); err != nil {
+			return fmt.Errorf("cannot reconcile VPA for vlstorage: %w", err)
+		}
+	}
+
+	svc, err := createOrUpdateVLStorageService(ctx, rclient, cr)
+	if err != nil {
+		return err
+	}
+	if !ptr.Deref(cr.Spec.VLStorage.DisableSelfServiceScrape, false) {
+		if err := reconcile.VMServiceScrapeForCRD(ctx, rclient, build.VMServiceScrapeForServiceWithSpec(svc, cr.Spec.VLStorage)); err != nil {
+			return fmt.Errorf("cannot create VMServiceScrape for vlstorage: %w", err)
+		}
+	}
+	return nil
+}
+
+func buildVLStorageSvcBuilder(cr *vmv1beta1.VLCluster) *clusterSvcBuilder {
+	return &clusterSvcBuilder{
+		cr,
+		cr.GetStorageName(),
+		cr.FinalLabels(cr.VLStorageSelectorLabels()),
+		cr.VLStorageSelectorLabels(),
+		cr.Spec.VLStorage.ServiceSpec,
+	}
+}
+
+func buildVLStorageService(cr *vmv1beta1.VLCluster) *corev1.Service {
+	return build.Service(buildVLStorageSvcBuilder(cr), cr.Spec.VLStorage.Port, func(svc *corev1.Service) {
+		svc.Spec.ClusterIP = corev1.ClusterIPNone
+	})
+}
+
+func createOrUpdateVLStorageService(ctx context.Context, rclient client.Client, cr *vmv1beta1.VLCluster) (*corev1.Service, error) {
+	newHeadless := buildVLStorageService(cr)
+	if err := reconcileAdditionalService(ctx, rclient, newHeadless, cr.Spec.VLStorage.ServiceSpec, "vlstorage"); err != nil {
+		return nil, err
+	}
+	var prevService *corev1.Service
+	if cr.ParsedLastAppliedSpec != nil && cr.ParsedLastAppliedSpec.VLStorage != nil {
+		prevCR := cr.DeepCopy()
+		prevCR.Spec = *cr.ParsedLastAppliedSpec
+		prevService = buildVLStorageService(prevCR)
+	}
+	if err := reconcile.Service(ctx, rclient, newHeadless, prevService); err != nil {
+		return nil, fmt.Errorf("cannot reconcile vlstorage service: %w", err)
+	}
+	return newHeadless, nil
+}
+
+func buildVLStorageSTSSpec(cr *vmv1beta1.VLCluster) (*appsv1.StatefulSet, error) {
+	podSpec, err := buildVLStoragePodSpec(cr)
+	if err != nil {
+		return nil, err
+	}
+	stsSpec := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cr.GetStorageName(),
+			Namespace:       cr.Namespace,
+			Labels:          cr.FinalLabels(cr.VLStorageSelectorLabels()),
+			Annotations:     cr.AnnotationsFiltered(),
+			OwnerReferences: cr.AsOwner(),
+			Finalizers:      []string{vmv1beta1.FinalizerName},
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: cr.VLStorageSelectorLabels(),
+			},
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type: cr.Spec.VLStorage.RollingUpdateStrategy,
+			},
+			Template:    *podSpec,
+			ServiceName: cr.GetStorageName(),
+		},
+	}
+	build.StatefulSetAddCommonParams(stsSpec, ptr.Deref(cr.Spec.VLStorage.UseStrictSecurity, false), &cr.Spec.VLStorage.CommonApplicationDeploymentParams)
+	cr.Spec.VLStorage.Storage.IntoSTSVolume(cr.Spec.VLStorage.GetStorageVolumeName(), &stsSpec.Spec)
+	stsSpec.Spec.VolumeClaimTemplates = append(stsSpec.Spec.VolumeClaimTemplates, cr.Spec.VLStorage.ClaimTemplates...)
+	return stsSpec, nil
+}
+
+func buildVLStoragePodSpec(cr *vmv1beta1.VLCluster) (*corev1.PodTemplateSpec, error) {
+	spec := cr.Spec.VLStorage
+	args := []string{
+		fmt.Sprintf("-httpListenAddr=:%s", spec.Port),
+		fmt.Sprintf("-storageDataPath=%s", spec.StorageDataPath),
+	}
+	if spec.RetentionPeriod != "" {
+		args = append(args, fmt.Sprintf("-retentionPeriod=%s", spec.RetentionPeriod))
+	}
+	if spec.FutureRetention != "" {
+		args = append(args, fmt.Sprintf("-futureRetention=%s", spec.FutureRetention))
+	}
+	if spec.LogNewStreams {
+		args = append(args, "-logNewStreams")
+	}
+	if spec.LogIngestedRows {
+		args = append(args, "-logIngestedRows")
+	}
+	if spec.LogLevel != "" {
+		args = append(args, fmt.Sprintf("-loggerLevel=%s", spec.LogLevel))
+	}
+	if spec.LogFormat != "" {
+		args = append(args, fmt.Sprintf("-loggerFormat=%s", spec.LogFormat))
+	}
+	if len(spec.ExtraEnvs) > 0 {
+		args = append(args, "-envflag.enable=true")
+	}
+
+	ports := []corev1.ContainerPort{
+		{
+			Name:          "http",
+			Protocol:      "TCP",
+			ContainerPort: intstr.Parse(spec.Port).IntVal,
+		},
+	}
+	volumes := make([]corev1.Volume, 0)
+	volumes = append(volumes, spec.Volumes...)
+	vmMounts := []corev1.VolumeMount{
+		{
+			Name:      spec.GetStorageVolumeName(),
+			MountPath: spec.StorageDataPath,
+		},
+	}
+	vmMounts = append(vmMounts, spec.VolumeMounts...)
+	volumes, vmMounts = addSecretsAndConfigMapsVolumes(volumes, vmMounts, spec.Secrets, spec.ConfigMaps)
+
+	args = build.AddExtraArgsOverrideDefaults(args, spec.ExtraArgs, "-")
+	sort.Strings(args)
+	vlstorageContainer := corev1.Container{
+		Name:                     "vlstorage",
+		Image:                    fmt.Sprintf("%s:%s", spec.Image.Repository, spec.Image.Tag),
+		ImagePullPolicy:          spec.Image.PullPolicy,
+		Ports:                    ports,
+		Args:                     args,
+		VolumeMounts:             vmMounts,
+		Resources:                spec.Resources,
+		Env:                      spec.ExtraEnvs,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+	vlstorageContainer = build.Probe(vlstorageContainer, spec)
+
+	operatorContainers := []corev1.Container{vlstorageContainer}
+	build.AddStrictSecuritySettingsToContainers(spec.SecurityContext, operatorContainers, ptr.Deref(spec.UseStrictSecurity, false))
+	containers, err := k8stools.MergePatchContainers(operatorContainers, spec.Containers)
+	if err != nil {
+		return nil, fmt.Errorf("cannot patch vlstorage containers: %w", err)
+	}
+
+	for i := range spec.TopologySpreadConstraints {
+		if spec.TopologySpreadConstraints[i].LabelSelector == nil {
+			spec.TopologySpreadConstraints[i].LabelSelector = &metav1.LabelSelector{
+				MatchLabels: cr.VLStorageSelectorLabels(),
+			}
+		}
+	}
+
+	return &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      cr.VLStoragePodLabels(),
+			Annotations: cr.VLStoragePodAnnotations(),
+		},
+		Spec: corev1.PodSpec{
+			Volumes:            volumes,
+			InitContainers:     spec.InitContainers,
+			Containers:         containers,
+			ServiceAccountName: cr.GetServiceAccountName(),
+		},
+	}, nil
+}
@@ -0,0 +1,252 @@
+package vlcluster
+
+import (
+	"context"
+	"fmt"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func createOrUpdateVMAuthLB(ctx context.Context, rclient client.Client, cr *vmv1beta1.VLCluster) error {
+	if err := reconcile.Secret(ctx, rclient, buildVMauthLBSecret(cr)); err != nil {
+		return fmt.Errorf("cannot reconcile vmauth lb secret: %w", err)
+	}
+	lbDep, err := buildVMauthLBDeployment(cr)
+	if err != nil {
+		return vmv1beta1.NewConfigError(fmt.Errorf("cannot build deployment for vmauth loadbalancing: %w", err))
+	}
+	var prevLB *appsv1.Deployment
+	if cr.ParsedLastAppliedSpec != nil && cr.ParsedLastAppliedSpec.RequestsLoadBalancer.Enabled {
+		prevCR := cr.DeepCopy()
+		prevCR.Spec = *cr.ParsedLastAppliedSpec
+		prevLB, err = buildVMauthLBDeployment(prevCR)
+		if err != nil {
+			return fmt.Errorf("cannot build prev deployment for vmauth loadbalancing: %w", err)
+		}
+	}
+	if err := reconcile.Deployment(ctx, rclient, lbDep, prevLB, false); err != nil {
+		return fmt.Errorf("cannot reconcile vmauth lb deployment: %w", err)
+	}
+	if vpa := cr.Spec.RequestsLoadBalancer.Spec.VPA; vpa != nil {
+		targetRef := autoscalingv1.CrossVersionObjectReference{
+			Name:       cr.GetVMAuthLBName(),
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		}
+		if err := reconcile.VPA(ctx, rclient, build.VPA(targetRef, "vmauth", vpa, cr.AsOwner(), cr.VMAuthLBSelectorLabels(), cr.Namespace)); err != nil {
+			return fmt.Errorf("cannot reconcile vmauth lb vpa: %w", err)
+		}
+	}
+	if err := createOrUpdateVMAuthLBService(ctx, rclient, cr); err != nil {
+		return err
+	}
+	if cr.Spec.RequestsLoadBalancer.Spec.PodDisruptionBudget != nil {
+		t := &clusterSvcBuilder{
+			cr,
+			cr.GetVMAuthLBName(),
+			cr.FinalLabels(cr.VMAuthLBSelectorLabels()),
+			cr.VMAuthLBSelectorLabels(),
+			nil,
+		}
+		pdb := build.PodDisruptionBudget(t, cr.Spec.RequestsLoadBalancer.Spec.PodDisruptionBudget)
+		if err := reconcile.PDB(ctx, rclient, pdb); err != nil {
+			return fmt.Errorf("cannot create or update PodDisruptionBudget for vmauth lb: %w", err)
+		}
+	}
+	return nil
+}
+
+func buildVMauthLBSecret(cr *vmv1beta1.VLCluster) *corev1.Secret {
+	targetHostSuffix := fmt.Sprintf("%s.svc", cr.Namespace)
+	if cr.Spec.ClusterDomainName != "" {
+		targetHostSuffix += fmt.Sprintf(".%s", cr.Spec.ClusterDomainName)
+	}
+	insertPort := "9481"
+	selectPort := "9471"
+	if cr.Spec.VLSelect != nil {
+		selectPort = cr.Spec.VLSelect.Port
+	}
+	if cr.Spec.VLInsert != nil {
+		insertPort = cr.Spec.VLInsert.Port
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       cr.Namespace,
+			Name:            cr.GetVMAuthLBName(),
+			Labels:          cr.FinalLabels(cr.VMAuthLBSelectorLabels()),
+			Annotations:     cr.AnnotationsFiltered(),
+			OwnerReferences: cr.AsOwner(),
+		},
+		StringData: map[string]string{"config.yaml": fmt.Sprintf(`
+unauthorized_user:
+  url_map:
+  - src_paths:
+    - "/insert/.*"
+    url_prefix: "http://srv+%s.%s:%s"
+    discover_backend_ips: true
+  - src_paths:
+    - "/.*"
+    url_prefix: "http://srv+%s.%s:%s"
+    discover_backend_ips: true
+      `, cr.GetInsertLBName(), targetHostSuffix, insertPort,
+			cr.GetSelectLBName(), targetHostSuffix, selectPort,
+		)},
+	}
+}
+
+func buildVMauthLBDeployment(cr *vmv1beta1.VLCluster) (*appsv1.Deployment, error) {
+	spec := cr.Spec.RequestsLoadBalancer.Spec
+	const configMountName = "vmauth-lb-config"
+	volumes := []corev1.Volume{
+		{
+			Name: configMountName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: cr.GetVMAuthLBName(),
+				},
+			},
+		},
+	}
+	volumes = append(volumes, spec.Volumes...)
+	vmounts := []corev1.VolumeMount{
+		{
+			MountPath: "/opt/vmauth-config/",
+			Name:      configMountName,
+		},
+	}
+	vmounts = append(vmounts, spec.VolumeMounts...)
+
+	args := []string{
+		"-auth.config=/opt/vmauth-config/config.yaml",
+		"-configCheckInterval=30s",
+	}
+	if spec.LogLevel != "" {
+		args = append(args, fmt.Sprintf("-loggerLevel=%s", spec.LogLevel))
+	}
+	if spec.LogFormat != "" {
+		args = append(args, fmt.Sprintf("-loggerFormat=%s", spec.LogFormat))
+	}
+	args = append(args, fmt.Sprintf("-httpListenAddr=:%s", spec.Port))
+	if len(spec.ExtraEnvs) > 0 {
+		args = append(args, "-envflag.enable=true")
+	}
+	args = build.AddExtraArgsOverrideDefaults(args, spec.ExtraArgs, "-")
+	vmauthLBCnt := corev1.Container{
+		Name: "vmauth",
+		Ports: []corev1.ContainerPort{
+			{
+				Protocol:      corev1.ProtocolTCP,
+				Name:          "http",
+				ContainerPort: intstr.Parse(spec.Port).IntVal,
+			},
+		},
+		Args:            args,
+		Env:             spec.ExtraEnvs,
+		Resources:       spec.Resources,
+		Image:           fmt.Sprintf("%s:%s", spec.Image.Repository, spec.Image.Tag),
+		ImagePullPolicy: spec.Image.PullPolicy,
+		VolumeMounts:    vmounts,
+	}
+	vmauthLBCnt = build.Probe(vmauthLBCnt, &spec)
+	containers := []corev1.Container{
+		vmauthLBCnt,
+	}
+	var err error
+	build.AddStrictSecuritySettingsToContainers(spec.SecurityContext, containers, ptr.Deref(spec.UseStrictSecurity, false))
+	containers, err = k8stools.MergePatchContainers(containers, spec.Containers)
+	if err != nil {
+		return nil, fmt.Errorf("cannot patch containers: %w", err)
+	}
+	lbDep := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       cr.Namespace,
+			Name:            cr.GetVMAuthLBName(),
+			Labels:          cr.FinalLabels(cr.VMAuthLBSelectorLabels()),
+			Annotations:     cr.AnnotationsFiltered(),
+			OwnerReferences: cr.AsOwner(),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: cr.VMAuthLBSelectorLabels(),
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: cr.VMAuthLBSelectorLabels(),
+				},
+				Spec: corev1.PodSpec{
+					Volumes:        volumes,
+					InitContainers: spec.InitContainers,
+					Containers:     containers,
+				},
+			},
+		},
+	}
+	build.DeploymentAddCommonParams(lbDep, ptr.Deref(spec.UseStrictSecurity, false), &spec.CommonApplicationDeploymentParams)
+	return lbDep, nil
+}
+
+func createOrUpdateVMAuthLBService(ctx context.Context, rclient client.Client, cr *vmv1beta1.VLCluster) error {
+	lbls := cr.VMAuthLBSelectorLabels()
+
+	// add proxy label directly to the service.labels
+	// it'll be used below for vmservicescrape matcher
+	// otherwise, it could lead to the multiple targets discovery
+	// for the single pod
+	fls := labels.Merge(cr.FinalLabels(lbls), map[string]string{vmauthLBServiceProxyTargetLabel: "vmauth"})
+	t := &clusterSvcBuilder{
+		VLCluster:         cr,
+		prefixedName:      cr.GetVMAuthLBName(),
+		finalLabels:       fls,
+		selectorLabels:    lbls,
+		additionalService: cr.Spec.RequestsLoadBalancer.Spec.AdditionalServiceSpec,
+	}
+	svc := build.Service(t, cr.Spec.RequestsLoadBalancer.Spec.Port, nil)
+	var prevSvc *corev1.Service
+	if cr.ParsedLastAppliedSpec != nil && cr.ParsedLastAppliedSpec.RequestsLoadBalancer.Enabled {
+		prevCR := cr.DeepCopy()
+		prevCR.Spec = *cr.ParsedLastAppliedSpec
+		t.additionalService = prevCR.Spec.RequestsLoadBalancer.Spec.AdditionalServiceSpec
+		prevSvc = build.Service(t, prevCR.Spec.RequestsLoadBalancer.Spec.Port, nil)
+	}
+	if err := reconcile.Service(ctx, rclient, svc, prevSvc); err != nil {
+		return fmt.Errorf("cannot reconcile vmauthlb service: %w", err)
+	}
+	if !ptr.Deref(cr.Spec.RequestsLoadBalancer.Spec.DisableSelfServiceScrape, false) {
+		svs := build.VMServiceScrapeForServiceWithSpec(svc, &cr.Spec.RequestsLoadBalancer.Spec, "http")
+		svs.Spec.Selector.MatchLabels[vmauthLBServiceProxyTargetLabel] = "vmauth"
+		if err := reconcile.VMServiceScrapeForCRD(ctx, rclient, svs); err != nil {
+			return fmt.Errorf("cannot reconcile vmauthlb vmservicescrape: %w", err)
+		}
+	}
+	return nil
+}
+
+// createOrUpdateLBProxyService creates service with component name, which points to the vmauth load balancer
+func createOrUpdateLBProxyService(ctx context.Context, rclient client.Client, cr *vmv1beta1.VLCluster, svcName, port, targetName string) error {
+	fls := labels.Merge(cr.FinalLabels(cr.VMAuthLBSelectorLabels()), map[string]string{vmauthLBServiceProxyTargetLabel: targetName})
+	t := &clusterSvcBuilder{
+		cr,
+		svcName,
+		fls,
+		cr.VMAuthLBSelectorLabels(),
+		nil,
+	}
+	svc := build.Service(t, cr.Spec.RequestsLoadBalancer.Spec.Port, func(svc *corev1.Service) {
+		svc.Spec.Ports[0].Port = intstr.Parse(port).IntVal
+	})
+	if err := reconcile.Service(ctx, rclient, svc, svc); err != nil {
+		return fmt.Errorf("cannot reconcile lb service: %w", err)
+	}
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -78,6 +79,15 @@ func CreateOrUpdateVLogs(ctx context.Context, r *vmv1beta1.VLogs, rclient client
 			return fmt.Errorf("cannot create serviceScrape for vlogs: %w", err)
 		}
 	}
+	if r.Spec.HTTPRoute != nil {
+		route, err := build.HTTPRoute(r, r.Spec.HTTPRoute, r.Spec.Port, nil)
+		if err != nil {
+			return vmv1beta1.NewConfigError(fmt.Errorf("cannot build route for vlogs: %w", err))
+		}
+		if err := reconcile.HTTPRoute(ctx, rclient, route); err != nil {
+			return fmt.Errorf("cannot create route for vlogs: %w", err)
+		}
+	}
 
 	var prevDeploy *appsv1.Deployment
 
@@ -92,10 +102,24 @@ func CreateOrUpdateVLogs(ctx context.Context, r *vmv1beta1.VLogs, rclient client
 
 	newDeploy, err := newDeployForVLogs(r)
 	if err != nil {
-		return fmt.Errorf("cannot generate new deploy for vlogs: %w", err)
+		return vmv1beta1.NewConfigError(fmt.Errorf("cannot generate new deploy for vlogs: %w", err))
 	}
 
-	return reconcile.Deployment(ctx, rclient, newDeploy, prevDeploy, false)
+	if err := reconcile.Deployment(ctx, rclient, newDeploy, prevDeploy, false); err != nil {
+		return err
+	}
+	if r.Spec.VPA != nil {
+		targetRef := autoscalingv1.CrossVersionObjectReference{
+			Name:       newDeploy.Name,
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		}
+		vpa := build.VPA(targetRef, "vlogs", r.Spec.VPA, r.AsOwner(), r.SelectorLabels(), r.Namespace)
+		if err := reconcile.VPA(ctx, rclient, vpa); err != nil {
+			return fmt.Errorf("cannot update vpa for vlogs: %w", err)
+		}
+	}
+	return nil
 }
 
 func newDeployForVLogs(r *vmv1beta1.VLogs) (*appsv1.Deployment, error) {
@@ -309,6 +333,12 @@ func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VLogs, rclient
 	if err := reconcile.AdditionalServices(ctx, rclient, cr.PrefixedName(), cr.Namespace, prevSvc, currSvc); err != nil {
 		return fmt.Errorf("cannot remove additional service: %w", err)
 	}
+	if err := reconcile.RemoveOrphanedHTTPRoute(ctx, rclient, cr.PrefixedName(), cr.Namespace, cr.ParsedLastAppliedSpec.HTTPRoute, cr.Spec.HTTPRoute); err != nil {
+		return err
+	}
+	if err := reconcile.RemoveOrphanedVPA(ctx, rclient, cr.PrefixedName(), cr.Namespace, cr.ParsedLastAppliedSpec.VPA, cr.Spec.VPA); err != nil {
+		return err
+	}
 
 	objMeta := metav1.ObjectMeta{Name: cr.PrefixedName(), Namespace: cr.Namespace}
 	if ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) && !ptr.Deref(cr.ParsedLastAppliedSpec.DisableSelfServiceScrape, false) {
@@ -14,6 +14,10 @@ import (
 )
 
 func selectScrapeConfig(ctx context.Context, cr *vmv1beta1.VMAgent, rclient client.Client) ([]*vmv1beta1.VMScrapeConfig, error) {
+	if cr.Spec.DaemonSetMode {
+		// targets cannot be bound to the node of vmagent pod
+		return nil, nil
+	}
 	var scrapeConfigsCombined []*vmv1beta1.VMScrapeConfig
 	var namespacedNames []string
 
@@ -65,6 +69,10 @@ func selectPodScrapes(ctx context.Context, cr *vmv1beta1.VMAgent, rclient client
 }
 
 func selectVMProbes(ctx context.Context, cr *vmv1beta1.VMAgent, rclient client.Client) ([]*vmv1beta1.VMProbe, error) {
+	if cr.Spec.DaemonSetMode {
+		// targets cannot be bound to the node of vmagent pod
+		return nil, nil
+	}
 	var probesCombined []*vmv1beta1.VMProbe
 	var namespacedNames []string
 	if err := k8stools.VisitObjectsForSelectorsAtNs(ctx, rclient, cr.Spec.ProbeNamespaceSelector, cr.Spec.ProbeSelector, cr.Namespace, cr.Spec.SelectAllByDefault,
@@ -123,6 +131,10 @@ func selectVMNodeScrapes(ctx context.Context, cr *vmv1beta1.VMAgent, rclient cli
 }
 
 func selectStaticScrapes(ctx context.Context, cr *vmv1beta1.VMAgent, rclient client.Client) ([]*vmv1beta1.VMStaticScrape, error) {
+	if cr.Spec.DaemonSetMode {
+		// targets cannot be bound to the node of vmagent pod
+		return nil, nil
+	}
 	var staticScrapesCombined []*vmv1beta1.VMStaticScrape
 	var namespacedNames []string
 	if err := k8stools.VisitObjectsForSelectorsAtNs(ctx, rclient, cr.Spec.StaticScrapeNamespaceSelector, cr.Spec.StaticScrapeSelector, cr.Namespace, cr.Spec.SelectAllByDefault,
@@ -149,12 +161,18 @@ func selectStaticScrapes(ctx context.Context, cr *vmv1beta1.VMAgent, rclient cli
 func selectServiceScrapes(ctx context.Context, cr *vmv1beta1.VMAgent, rclient client.Client) ([]*vmv1beta1.VMServiceScrape, error) {
 	var servScrapesCombined []*vmv1beta1.VMServiceScrape
 	var serviceScrapeNamespacedNames []string
+	var skippedNamespacedNames []string
 	if err := k8stools.VisitObjectsForSelectorsAtNs(ctx, rclient, cr.Spec.ServiceScrapeNamespaceSelector, cr.Spec.ServiceScrapeSelector, cr.Namespace, cr.Spec.SelectAllByDefault,
 		func(list *vmv1beta1.VMServiceScrapeList) {
 			for _, item := range list.Items {
 				if !item.DeletionTimestamp.IsZero() {
 					continue
 				}
+				// service targets cannot be bound to the node of vmagent pod
+				if cr.Spec.DaemonSetMode && item.Spec.DiscoveryRole == kubernetesSDRoleService {
+					skippedNamespacedNames = append(skippedNamespacedNames, fmt.Sprintf("%s/%s", item.Namespace, item.Name))
+					continue
+				}
 				item := item
 				serviceScrapeNamespacedNames = append(serviceScrapeNamespacedNames, fmt.Sprintf("%s/%s", item.Namespace, item.Name))
 				servScrapesCombined = append(servScrapesCombined, &item)
@@ -162,6 +180,9 @@ func selectServiceScrapes(ctx context.Context, cr *vmv1beta1.VMAgent, rclient cl
 		}); err != nil {
 		return nil, err
 	}
+	if len(skippedNamespacedNames) > 0 {
+		logger.WithContext(ctx).Info("skipping VMServiceScrapes with service discovery role, it's not supported at daemonSetMode", "vmservicescrapes", strings.Join(skippedNamespacedNames, ","))
+	}
 
 	// filter out all service scrapes that access
 	// the file system.
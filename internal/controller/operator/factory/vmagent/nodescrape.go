@@ -27,7 +27,7 @@ func generateNodeScrapeConfig(
 
 	setScrapeIntervalToWithLimit(ctx, &nodeSpec.EndpointScrapeParams, vmagentCR)
 
-	cfg = append(cfg, generateK8SSDConfig(nil, apiserverConfig, ssCache, kubernetesSDRoleNode, nil))
+	cfg = append(cfg, generateK8SSDConfig(nil, apiserverConfig, ssCache, kubernetesSDRoleNode, nil, vmagentCR.Spec.DaemonSetMode))
 
 	cfg = addCommonScrapeParamsTo(cfg, nodeSpec.EndpointScrapeParams, se)
 
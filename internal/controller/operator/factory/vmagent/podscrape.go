@@ -30,7 +30,7 @@ func generatePodScrapeConfig(
 	if ep.AttachMetadata.Node == nil && m.Spec.AttachMetadata.Node != nil {
 		ep.AttachMetadata = m.Spec.AttachMetadata
 	}
-	cfg = append(cfg, generatePodK8SSDConfig(selectedNamespaces, m.Spec.Selector, apiserverConfig, ssCache, kubernetesSDRolePod, &ep.AttachMetadata))
+	cfg = append(cfg, generatePodK8SSDConfig(selectedNamespaces, m.Spec.Selector, apiserverConfig, ssCache, kubernetesSDRolePod, &ep.AttachMetadata, vmagentCR.Spec.DaemonSetMode))
 
 	// set defaults
 	if ep.SampleLimit == 0 {
@@ -155,8 +155,8 @@ func generatePodScrapeConfig(
 	return cfg
 }
 
-func generatePodK8SSDConfig(namespaces []string, labelSelector metav1.LabelSelector, apiserverConfig *vmv1beta1.APIServerConfig, ssCache *scrapesSecretsCache, role string, am *vmv1beta1.AttachMetadata) yaml.MapItem {
-	cfg := generateK8SSDConfig(namespaces, apiserverConfig, ssCache, role, am)
+func generatePodK8SSDConfig(namespaces []string, labelSelector metav1.LabelSelector, apiserverConfig *vmv1beta1.APIServerConfig, ssCache *scrapesSecretsCache, role string, am *vmv1beta1.AttachMetadata, nodeLocal bool) yaml.MapItem {
+	cfg := generateK8SSDConfig(namespaces, apiserverConfig, ssCache, role, am, nodeLocal)
 
 	if len(labelSelector.MatchLabels) != 0 {
 		k8sSDs, flag := cfg.Value.([]yaml.MapSlice)
@@ -175,6 +175,25 @@ func generatePodK8SSDConfig(namespaces []string, labelSelector metav1.LabelSelec
 		})
 
 		for i := range k8sSDs {
+			// node local pod selector could be already defined
+			// kubernetes_sd_configs doesn't allow multiple selectors with the same role
+			var merged bool
+			for j, item := range k8sSDs[i] {
+				if item.Key != "selectors" {
+					continue
+				}
+				selectors := item.Value.([]yaml.MapSlice)
+				for k := range selectors {
+					if selectors[k][0].Value == role {
+						selectors[k] = append(selectors[k], selector[1])
+						merged = true
+					}
+				}
+				k8sSDs[i][j].Value = selectors
+			}
+			if merged {
+				continue
+			}
 			k8sSDs[i] = append(k8sSDs[i], yaml.MapItem{
 				Key: "selectors",
 				Value: []yaml.MapSlice{
@@ -212,6 +212,67 @@ relabel_configs:
   replacement: default/test-1
 - target_label: endpoint
   replacement: web
+`,
+		},
+		{
+			name: "daemonset mode with label selector",
+			args: args{
+				cr: vmv1beta1.VMAgent{
+					Spec: vmv1beta1.VMAgentSpec{DaemonSetMode: true},
+				},
+				m: &vmv1beta1.VMPodScrape{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-1",
+						Namespace: "default",
+					},
+					Spec: vmv1beta1.VMPodScrapeSpec{
+						Selector: metav1.LabelSelector{
+							MatchLabels: map[string]string{"app": "web"},
+						},
+					},
+				},
+				ep: vmv1beta1.PodMetricsEndpoint{
+					Port: "web",
+				},
+				ssCache: &scrapesSecretsCache{},
+			},
+			want: `job_name: podScrape/default/test-1/0
+kubernetes_sd_configs:
+- role: pod
+  namespaces:
+    names:
+    - default
+  selectors:
+  - role: pod
+    field: spec.nodeName=%{KUBE_NODE_NAME}
+    label: app=web
+honor_labels: false
+relabel_configs:
+- action: drop
+  source_labels:
+  - __meta_kubernetes_pod_phase
+  regex: (Failed|Succeeded)
+- action: keep
+  source_labels:
+  - __meta_kubernetes_pod_label_app
+  regex: web
+- action: keep
+  source_labels:
+  - __meta_kubernetes_pod_container_port_name
+  regex: web
+- source_labels:
+  - __meta_kubernetes_namespace
+  target_label: namespace
+- source_labels:
+  - __meta_kubernetes_pod_container_name
+  target_label: container
+- source_labels:
+  - __meta_kubernetes_pod_name
+  target_label: pod
+- target_label: job
+  replacement: default/test-1
+- target_label: endpoint
+  replacement: web
 `,
 		},
 	}
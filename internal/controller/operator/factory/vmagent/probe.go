@@ -5,7 +5,10 @@ import (
 	"fmt"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
 	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func generateProbeConfig(
@@ -72,7 +75,7 @@ func generateProbeConfig(
 
 		relabelings = addSelectorToRelabelingFor(relabelings, "ingress", cr.Spec.Targets.Ingress.Selector)
 		selectedNamespaces := getNamespacesFromNamespaceSelector(&cr.Spec.Targets.Ingress.NamespaceSelector, cr.Namespace, se.IgnoreNamespaceSelectors)
-		cfg = append(cfg, generateK8SSDConfig(selectedNamespaces, apiserverConfig, ssCache, kubernetesSDRoleIngress, nil))
+		cfg = append(cfg, generateK8SSDConfig(selectedNamespaces, apiserverConfig, ssCache, kubernetesSDRoleIngress, nil, false))
 
 		// Relabelings for ingress SD.
 		relabelings = append(relabelings, []yaml.MapSlice{
@@ -143,3 +146,25 @@ func generateProbeConfig(
 
 	return cfg
 }
+
+// resolveProbeBlackboxExporter sets prober url to the address of referenced VMBlackboxExporter
+// and checks if probe module is defined by it
+func resolveProbeBlackboxExporter(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMProbe) error {
+	ref := cr.Spec.VMProberSpec.BlackboxExporterRef
+	if ref == nil {
+		return nil
+	}
+	nsn := types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}
+	if nsn.Namespace == "" {
+		nsn.Namespace = cr.Namespace
+	}
+	var be vmv1beta1.VMBlackboxExporter
+	if err := rclient.Get(ctx, nsn, &be); err != nil {
+		return fmt.Errorf("cannot get vmblackboxexporter=%q: %w", nsn.String(), err)
+	}
+	if cr.Spec.Module != "" && !be.HasModule(cr.Spec.Module) {
+		return k8stools.NewKeyNotFoundError(cr.Spec.Module, nsn.String(), "vmblackboxexporter")
+	}
+	cr.Spec.VMProberSpec.URL = be.ProberAddress()
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
 )
 
@@ -253,3 +254,43 @@ basic_auth:
 		})
 	}
 }
+
+func Test_resolveProbeBlackboxExporter(t *testing.T) {
+	be := &vmv1beta1.VMBlackboxExporter{
+		ObjectMeta: metav1.ObjectMeta{Name: "probes", Namespace: "monitoring"},
+		Spec: vmv1beta1.VMBlackboxExporterSpec{
+			Modules: map[string]vmv1beta1.BlackboxModule{"http_2xx": {Prober: "http"}},
+			CommonDefaultableParams: vmv1beta1.CommonDefaultableParams{
+				Port: "9115",
+			},
+		},
+	}
+	f := func(ref *vmv1beta1.VMBlackboxExporterRef, module, wantURL string, wantErr bool) {
+		t.Helper()
+		fclient := k8stools.GetTestClientWithObjects([]runtime.Object{be})
+		cr := &vmv1beta1.VMProbe{
+			ObjectMeta: metav1.ObjectMeta{Name: "probe", Namespace: "default"},
+			Spec: vmv1beta1.VMProbeSpec{
+				Module:       module,
+				VMProberSpec: vmv1beta1.VMProberSpec{URL: "blackbox:9115", BlackboxExporterRef: ref},
+			},
+		}
+		err := resolveProbeBlackboxExporter(context.Background(), fclient, cr)
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+		assert.Equal(t, wantURL, cr.Spec.VMProberSpec.URL)
+	}
+
+	// no reference
+	f(nil, "http_2xx", "blackbox:9115", false)
+
+	// module exists
+	f(&vmv1beta1.VMBlackboxExporterRef{Name: "probes", Namespace: "monitoring"}, "http_2xx", "vmblackboxexporter-probes.monitoring.svc:9115", false)
+
+	// unknown module
+	f(&vmv1beta1.VMBlackboxExporterRef{Name: "probes", Namespace: "monitoring"}, "tcp_connect", "blackbox:9115", true)
+
+	// missing blackbox exporter at probe namespace
+	f(&vmv1beta1.VMBlackboxExporterRef{Name: "probes"}, "http_2xx", "blackbox:9115", true)
+}
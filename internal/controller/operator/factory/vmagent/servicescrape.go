@@ -33,7 +33,7 @@ func generateServiceScrapeConfig(
 	if ep.AttachMetadata.Node == nil && m.Spec.AttachMetadata.Node != nil {
 		ep.AttachMetadata = m.Spec.AttachMetadata
 	}
-	cfg = append(cfg, generateK8SSDConfig(selectedNamespaces, apiserverConfig, ssCache, m.Spec.DiscoveryRole, &ep.AttachMetadata))
+	cfg = append(cfg, generateK8SSDConfig(selectedNamespaces, apiserverConfig, ssCache, m.Spec.DiscoveryRole, &ep.AttachMetadata, vmagentCR.Spec.DaemonSetMode))
 
 	if ep.SampleLimit == 0 {
 		ep.SampleLimit = m.Spec.SampleLimit
@@ -47,6 +47,9 @@ func generateServiceScrapeConfig(
 	cfg = addCommonScrapeParamsTo(cfg, ep.EndpointScrapeParams, se)
 
 	var relabelings []yaml.MapSlice
+	if vmagentCR.Spec.DaemonSetMode {
+		relabelings = addNodeLocalRelabelingTo(relabelings)
+	}
 
 	// Filter targets by services selected by the scrape.
 
@@ -0,0 +1,233 @@
+package vmagent
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultShardScaleDownThresholdPercent = 80
+	defaultShardAutoscalingCooldown       = 10 * time.Minute
+	shardStatsRequestTimeout              = 5 * time.Second
+)
+
+// shardStatsHTTPClient is used for requests to vmagent pods by IP,
+// so certificate verification is skipped for tls enabled vmagent.
+var shardStatsHTTPClient = &http.Client{
+	Timeout: shardStatsRequestTimeout,
+	Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	},
+}
+
+// shardStats holds load of vmagent shard
+type shardStats struct {
+	targets int64
+	series  int64
+}
+
+// handleShardAutoscaling adjusts number of vmagent shards according to observed scrape load
+// and returns a copy of vmagent with shardCount chosen by autoscaler.
+//
+// Shards are added once load per shard exceeds configured limits.
+// Shards are removed only if load per shard after removal stays below scaleDownThresholdPercent of limits.
+// Shards count changes no more often than once per cooldownPeriod.
+func handleShardAutoscaling(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAgent) (*vmv1beta1.VMAgent, error) {
+	sa := cr.Spec.ShardAutoscaling
+	if sa == nil || cr.Spec.DaemonSetMode {
+		return cr, updateShardAutoscalingStatus(ctx, rclient, cr, nil)
+	}
+	cooldown, err := getShardAutoscalingCooldown(sa)
+	if err != nil {
+		return nil, vmv1beta1.NewConfigError(err)
+	}
+	status := &vmv1beta1.VMAgentShardAutoscalingStatus{}
+	if cr.Status.ShardAutoscaling != nil {
+		status = cr.Status.ShardAutoscaling.DeepCopy()
+	} else {
+		status.Shards = int32(ptr.Deref(cr.Spec.ShardCount, int(sa.MinShards)))
+	}
+	// bounds changes are applied immediately
+	currentShards := clampShards(sa, status.Shards)
+	status.Shards = currentShards
+
+	if !k8stools.IsDryRun(rclient) {
+		stats, err := collectShardStats(ctx, rclient, cr, currentShards)
+		if err != nil {
+			logger.WithContext(ctx).Error(err, "cannot collect vmagent shards stats, keeping current shards count", "shards", currentShards)
+		} else {
+			status.Targets = stats.targets
+			status.Series = stats.series
+			desiredShards := desiredShardsCount(sa, stats, currentShards)
+			now := metav1.Now()
+			if desiredShards != currentShards && (status.LastScaleTime == nil || now.Sub(status.LastScaleTime.Time) >= cooldown) {
+				logger.WithContext(ctx).Info("changing vmagent shards count", "current", currentShards, "desired", desiredShards,
+					"targets", stats.targets, "series", stats.series)
+				status.Shards = desiredShards
+				status.LastScaleTime = &now
+			}
+		}
+	}
+	if err := updateShardAutoscalingStatus(ctx, rclient, cr, status); err != nil {
+		return nil, err
+	}
+	effective := cr.DeepCopy()
+	effective.Spec.ShardCount = ptr.To(int(status.Shards))
+	return effective, nil
+}
+
+// desiredShardsCount returns shards count required for given load
+func desiredShardsCount(sa *vmv1beta1.VMAgentShardAutoscaling, stats shardStats, currentShards int32) int32 {
+	desired := shardsForLoad(sa, stats, 100)
+	if desired < currentShards {
+		threshold := ptr.Deref(sa.ScaleDownThresholdPercent, defaultShardScaleDownThresholdPercent)
+		desired = min(shardsForLoad(sa, stats, int64(threshold)), currentShards)
+	}
+	return clampShards(sa, desired)
+}
+
+// shardsForLoad returns minimal shards count, which keeps load per shard below given percent of limits
+func shardsForLoad(sa *vmv1beta1.VMAgentShardAutoscaling, stats shardStats, percent int64) int32 {
+	ceilDiv := func(load, limit int64) int64 {
+		capacity := limit * percent
+		return (load*100 + capacity - 1) / capacity
+	}
+	var shards int64
+	if sa.TargetsPerShard > 0 {
+		shards = max(shards, ceilDiv(stats.targets, sa.TargetsPerShard))
+	}
+	if sa.SeriesPerShard > 0 {
+		shards = max(shards, ceilDiv(stats.series, sa.SeriesPerShard))
+	}
+	return int32(min(shards, int64(sa.MaxShards)))
+}
+
+func clampShards(sa *vmv1beta1.VMAgentShardAutoscaling, shards int32) int32 {
+	return max(sa.MinShards, min(shards, sa.MaxShards))
+}
+
+// collectShardStats sums load of all shards
+// it takes stats from the single ready pod per shard, since replicas of the same shard scrape the same targets
+func collectShardStats(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAgent, shardsCount int32) (shardStats, error) {
+	var result shardStats
+	var pods corev1.PodList
+	opts := &client.ListOptions{
+		Namespace:     cr.Namespace,
+		LabelSelector: labels.SelectorFromSet(cr.SelectorLabels()),
+	}
+	if err := rclient.List(ctx, &pods, opts); err != nil {
+		return result, fmt.Errorf("cannot list vmagent pods: %w", err)
+	}
+	seenShards := make(map[int32]struct{})
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		shardNum := int32(0)
+		if v, ok := pod.Labels["shard-num"]; ok {
+			n, err := strconv.ParseInt(v, 10, 32)
+			if err != nil {
+				continue
+			}
+			shardNum = int32(n)
+		}
+		if _, ok := seenShards[shardNum]; ok || shardNum >= shardsCount {
+			continue
+		}
+		if pod.Status.PodIP == "" || !reconcile.PodIsReady(pod, 0) {
+			continue
+		}
+		stats, err := fetchShardStats(ctx, cr, pod.Status.PodIP)
+		if err != nil {
+			logger.WithContext(ctx).Error(err, "cannot fetch vmagent shard stats", "pod", pod.Name)
+			continue
+		}
+		result.targets += stats.targets
+		result.series += stats.series
+		seenShards[shardNum] = struct{}{}
+	}
+	// partial stats underestimate load and may lead to incorrect scale-down
+	if len(seenShards) < int(shardsCount) {
+		return result, fmt.Errorf("stats are available only for %d of %d shards", len(seenShards), shardsCount)
+	}
+	return result, nil
+}
+
+// fetchShardStats requests active targets of vmagent pod
+func fetchShardStats(ctx context.Context, cr *vmv1beta1.VMAgent, podIP string) (shardStats, error) {
+	var result shardStats
+	u := fmt.Sprintf("%s://%s%s?state=active", strings.ToLower(cr.ProbeScheme()), net.JoinHostPort(podIP, cr.Spec.Port), cr.TargetsPath())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return result, fmt.Errorf("cannot build request: %w", err)
+	}
+	resp, err := shardStatsHTTPClient.Do(req)
+	if err != nil {
+		return result, fmt.Errorf("cannot request targets: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return result, fmt.Errorf("unexpected status code for targets request: %d", resp.StatusCode)
+	}
+	var targetsResp struct {
+		Data struct {
+			ActiveTargets []struct {
+				LastSamplesScraped int64 `json:"lastSamplesScraped"`
+			} `json:"activeTargets"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&targetsResp); err != nil {
+		return result, fmt.Errorf("cannot parse targets response: %w", err)
+	}
+	result.targets = int64(len(targetsResp.Data.ActiveTargets))
+	for _, target := range targetsResp.Data.ActiveTargets {
+		result.series += target.LastSamplesScraped
+	}
+	return result, nil
+}
+
+// updateShardAutoscalingStatus patches vmagent status with given autoscaler observations if it was changed
+func updateShardAutoscalingStatus(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAgent, status *vmv1beta1.VMAgentShardAutoscalingStatus) error {
+	if equality.Semantic.DeepEqual(cr.Status.ShardAutoscaling, status) {
+		return nil
+	}
+	data, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("BUG: cannot serialize shard autoscaling status: %w", err)
+	}
+	pt := client.RawPatch(types.MergePatchType, []byte(fmt.Sprintf(`{"status": {"shardAutoscaling": %s}}`, data)))
+	if err := rclient.Status().Patch(ctx, cr.DeepCopy(), pt); err != nil {
+		return fmt.Errorf("cannot patch shard autoscaling status: %w", err)
+	}
+	cr.Status.ShardAutoscaling = status
+	return nil
+}
+
+// getShardAutoscalingCooldown returns minimal interval between shards count changes
+func getShardAutoscalingCooldown(sa *vmv1beta1.VMAgentShardAutoscaling) (time.Duration, error) {
+	if sa.CooldownPeriod == "" {
+		return defaultShardAutoscalingCooldown, nil
+	}
+	d, err := time.ParseDuration(sa.CooldownPeriod)
+	if err != nil {
+		return 0, fmt.Errorf("cannot parse shardAutoscaling cooldownPeriod=%q: %w", sa.CooldownPeriod, err)
+	}
+	return d, nil
+}
@@ -0,0 +1,160 @@
+package vmagent
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+func TestDesiredShardsCount(t *testing.T) {
+	f := func(sa *vmv1beta1.VMAgentShardAutoscaling, stats shardStats, current, want int32) {
+		t.Helper()
+		got := desiredShardsCount(sa, stats, current)
+		if got != want {
+			t.Fatalf("unexpected shards count, got: %d, want: %d", got, want)
+		}
+	}
+	sa := &vmv1beta1.VMAgentShardAutoscaling{
+		MinShards:       2,
+		MaxShards:       5,
+		TargetsPerShard: 100,
+	}
+
+	// load fits current shards
+	f(sa, shardStats{targets: 190}, 2, 2)
+	// scale up
+	f(sa, shardStats{targets: 201}, 2, 3)
+	// scale up is limited by maxShards
+	f(sa, shardStats{targets: 10000}, 2, 5)
+	// scale down is limited by minShards
+	f(sa, shardStats{targets: 10}, 4, 2)
+	// no scale down within hysteresis
+	f(sa, shardStats{targets: 290}, 4, 4)
+	// scale down below hysteresis
+	f(sa, shardStats{targets: 230}, 4, 3)
+
+	// series limit
+	f(&vmv1beta1.VMAgentShardAutoscaling{
+		MinShards:       1,
+		MaxShards:       10,
+		TargetsPerShard: 100,
+		SeriesPerShard:  1000,
+	}, shardStats{targets: 10, series: 3500}, 2, 4)
+
+	// custom threshold
+	f(&vmv1beta1.VMAgentShardAutoscaling{
+		MinShards:                 1,
+		MaxShards:                 10,
+		TargetsPerShard:           100,
+		ScaleDownThresholdPercent: ptr.To[int32](50),
+	}, shardStats{targets: 160}, 4, 4)
+}
+
+func TestHandleShardAutoscaling(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/targets" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		targets := make([]string, 0, 150)
+		for range 150 {
+			targets = append(targets, `{"health":"up","lastSamplesScraped":10}`)
+		}
+		fmt.Fprintf(w, `{"status":"success","data":{"activeTargets":[%s]}}`, strings.Join(targets, ","))
+	}))
+	defer srv.Close()
+	_, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("cannot parse server address: %s", err)
+	}
+	newPod := func(name, shardNum string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					"app.kubernetes.io/name":      "vmagent",
+					"app.kubernetes.io/instance":  "example",
+					"app.kubernetes.io/component": "monitoring",
+					"managed-by":                  "vm-operator",
+					"shard-num":                   shardNum,
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				PodIP: "127.0.0.1",
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+				},
+			},
+		}
+	}
+
+	f := func(status *vmv1beta1.VMAgentShardAutoscalingStatus, predefinedObjects []runtime.Object, wantShards int32, wantScaled bool) {
+		t.Helper()
+		cr := &vmv1beta1.VMAgent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "example",
+				Namespace: "default",
+			},
+			Spec: vmv1beta1.VMAgentSpec{
+				RemoteWrite: []vmv1beta1.VMAgentRemoteWriteSpec{{URL: "http://remote-write"}},
+				ShardCount:  ptr.To(2),
+				ShardAutoscaling: &vmv1beta1.VMAgentShardAutoscaling{
+					MinShards:       1,
+					MaxShards:       4,
+					TargetsPerShard: 100,
+				},
+				CommonDefaultableParams: vmv1beta1.CommonDefaultableParams{
+					Port: port,
+				},
+			},
+			Status: vmv1beta1.VMAgentStatus{ShardAutoscaling: status},
+		}
+		ctx := context.Background()
+		fclient := k8stools.GetTestClientWithObjects(append(predefinedObjects, cr))
+		got, err := handleShardAutoscaling(ctx, fclient, cr)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if ptr.Deref(got.Spec.ShardCount, 0) != int(wantShards) {
+			t.Fatalf("unexpected shardCount, got: %d, want: %d", ptr.Deref(got.Spec.ShardCount, 0), wantShards)
+		}
+		if ptr.Deref(cr.Spec.ShardCount, 0) != 2 {
+			t.Fatalf("original object spec must not be changed")
+		}
+		if cr.Status.ShardAutoscaling == nil || cr.Status.ShardAutoscaling.Shards != wantShards {
+			t.Fatalf("unexpected status: %v", cr.Status.ShardAutoscaling)
+		}
+		scaled := cr.Status.ShardAutoscaling.LastScaleTime != nil && (status == nil || status.LastScaleTime == nil || !cr.Status.ShardAutoscaling.LastScaleTime.Equal(status.LastScaleTime))
+		if scaled != wantScaled {
+			t.Fatalf("unexpected scale event, got: %v, want: %v", scaled, wantScaled)
+		}
+	}
+
+	pods := []runtime.Object{newPod("vmagent-example-0-1", "0"), newPod("vmagent-example-1-1", "1")}
+
+	// 300 targets require 3 shards
+	f(nil, pods, 3, true)
+
+	// scale is delayed by cooldown
+	f(&vmv1beta1.VMAgentShardAutoscalingStatus{
+		Shards:        2,
+		LastScaleTime: ptr.To(metav1.NewTime(time.Now().Add(-time.Minute))),
+	}, pods, 2, false)
+
+	// stats of one shard are missing
+	f(&vmv1beta1.VMAgentShardAutoscalingStatus{Shards: 2}, pods[:1], 2, false)
+}
@@ -18,6 +18,7 @@ import (
 
 	"gopkg.in/yaml.v2"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -42,6 +43,7 @@ const (
 	vmagentGzippedFilename = "vmagent.yaml.gz"
 	configEnvsubstFilename = "vmagent.env.yaml"
 	defaultMaxDiskUsage    = "1073741824"
+	vmAgentNodeNameEnv     = "KUBE_NODE_NAME"
 )
 
 // To save compatibility in the single-shard version still need to fill in %SHARD_NUM% placeholder
@@ -90,6 +92,10 @@ func createOrUpdateVMAgentService(ctx context.Context, cr *vmv1beta1.VMAgent, rc
 // CreateOrUpdateVMAgent creates deployment for vmagent and configures it
 // waits for healthy state
 func CreateOrUpdateVMAgent(ctx context.Context, cr *vmv1beta1.VMAgent, rclient client.Client) error {
+	cr, err := handleShardAutoscaling(ctx, rclient, cr)
+	if err != nil {
+		return err
+	}
 	if err := deletePrevStateResources(ctx, cr, rclient); err != nil {
 		return fmt.Errorf("cannot delete objects from prev state: %w", err)
 	}
@@ -109,6 +115,11 @@ func CreateOrUpdateVMAgent(ctx context.Context, cr *vmv1beta1.VMAgent, rclient c
 	if err != nil {
 		return err
 	}
+	if cr.Spec.NetworkPolicy != nil {
+		if err := createOrUpdateNetworkPolicy(ctx, rclient, cr, svc); err != nil {
+			return err
+		}
+	}
 
 	if !ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) {
 		err = reconcile.VMServiceScrapeForCRD(ctx, rclient, build.VMServiceScrapeForServiceWithSpec(svc, cr, "http"))
@@ -149,12 +160,13 @@ func CreateOrUpdateVMAgent(ctx context.Context, cr *vmv1beta1.VMAgent, rclient c
 	}
 	newDeploy, err := newDeployForVMAgent(cr, ssCache)
 	if err != nil {
-		return fmt.Errorf("cannot build new deploy for vmagent: %w", err)
+		return vmv1beta1.NewConfigError(fmt.Errorf("cannot build new deploy for vmagent: %w", err))
 	}
 
 	deploymentNames := make(map[string]struct{})
 	stsNames := make(map[string]struct{})
-	if cr.Spec.ShardCount != nil && *cr.Spec.ShardCount > 1 {
+	dsNames := make(map[string]struct{})
+	if cr.Spec.ShardCount != nil && *cr.Spec.ShardCount > 1 && !cr.Spec.DaemonSetMode {
 		shardsCount := *cr.Spec.ShardCount
 		logger.WithContext(ctx).Info("using cluster version of VMAgent with", "shards", shardsCount)
 		for shardNum := 0; shardNum < shardsCount; shardNum++ {
@@ -268,6 +280,26 @@ func CreateOrUpdateVMAgent(ctx context.Context, cr *vmv1beta1.VMAgent, rclient c
 				return err
 			}
 			stsNames[newDeploy.Name] = struct{}{}
+		case *appsv1.DaemonSet:
+			var prevDs *appsv1.DaemonSet
+			if prevObjectSpec != nil {
+				prevAppObject, ok := prevObjectSpec.(*appsv1.DaemonSet)
+				if ok {
+					prevDs = prevAppObject
+					prevDs, err = k8stools.RenderPlaceholders(prevDs, defaultPlaceholders)
+					if err != nil {
+						return fmt.Errorf("cannot fill placeholders for prev daemonset in vmagent: %w", err)
+					}
+				}
+			}
+			newDeploy, err = k8stools.RenderPlaceholders(newDeploy, defaultPlaceholders)
+			if err != nil {
+				return fmt.Errorf("cannot fill placeholders for daemonset in vmagent: %w", err)
+			}
+			if err := reconcile.DaemonSet(ctx, rclient, newDeploy, prevDs); err != nil {
+				return err
+			}
+			dsNames[newDeploy.Name] = struct{}{}
 		}
 	}
 	if err := finalize.RemoveOrphanedDeployments(ctx, rclient, cr, deploymentNames); err != nil {
@@ -276,10 +308,52 @@ func CreateOrUpdateVMAgent(ctx context.Context, cr *vmv1beta1.VMAgent, rclient c
 	if err := finalize.RemoveOrphanedSTSs(ctx, rclient, cr, stsNames); err != nil {
 		return err
 	}
+	if err := finalize.RemoveOrphanedDaemonSets(ctx, rclient, cr, dsNames); err != nil {
+		return err
+	}
+	if err := createOrUpdateVPAs(ctx, rclient, cr, deploymentNames, stsNames, dsNames); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// createOrUpdateVPAs reconciles VerticalPodAutoscaler per each vmagent workload
+// and removes autoscalers of workloads, which are no longer exist
+func createOrUpdateVPAs(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAgent, deploymentNames, stsNames, dsNames map[string]struct{}) error {
+	var prevVPA *vmv1beta1.EmbeddedVPA
+	if cr.ParsedLastAppliedSpec != nil {
+		prevVPA = cr.ParsedLastAppliedSpec.VPA
+	}
+	if cr.Spec.VPA == nil && prevVPA == nil {
+		return nil
+	}
+	vpaNames := make(map[string]struct{})
+	if cr.Spec.VPA != nil {
+		for kind, names := range map[string]map[string]struct{}{
+			"Deployment":  deploymentNames,
+			"StatefulSet": stsNames,
+			"DaemonSet":   dsNames,
+		} {
+			for name := range names {
+				targetRef := autoscalingv1.CrossVersionObjectReference{
+					Name:       name,
+					Kind:       kind,
+					APIVersion: "apps/v1",
+				}
+				if err := reconcile.VPA(ctx, rclient, build.VPA(targetRef, "vmagent", cr.Spec.VPA, cr.AsOwner(), cr.SelectorLabels(), cr.Namespace)); err != nil {
+					return fmt.Errorf("cannot reconcile VPA for vmagent: %w", err)
+				}
+				vpaNames[name] = struct{}{}
+			}
+		}
+	}
+	if err := finalize.RemoveOrphanedVPAs(ctx, rclient, cr, vpaNames); err != nil {
+		return fmt.Errorf("cannot remove orphaned VPA for vmagent: %w", err)
+	}
+	return nil
+}
+
 // newDeployForVMAgent builds vmagent deployment spec.
 func newDeployForVMAgent(cr *vmv1beta1.VMAgent, ssCache *scrapesSecretsCache) (runtime.Object, error) {
 
@@ -289,6 +363,36 @@ func newDeployForVMAgent(cr *vmv1beta1.VMAgent, ssCache *scrapesSecretsCache) (r
 	}
 	useStrictSecurity := ptr.Deref(cr.Spec.UseStrictSecurity, false)
 
+	if cr.Spec.DaemonSetMode {
+		dsSpec := &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            cr.PrefixedName(),
+				Namespace:       cr.Namespace,
+				Labels:          cr.AllLabels(),
+				Annotations:     cr.AnnotationsFiltered(),
+				OwnerReferences: cr.AsOwner(),
+				Finalizers:      []string{vmv1beta1.FinalizerName},
+			},
+			Spec: appsv1.DaemonSetSpec{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: cr.SelectorLabels(),
+				},
+				UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+					Type: appsv1.RollingUpdateDaemonSetStrategyType,
+				},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels:      cr.PodLabels(),
+						Annotations: cr.PodAnnotations(),
+					},
+					Spec: *podSpec,
+				},
+			},
+		}
+		build.DaemonSetAddCommonParams(dsSpec, useStrictSecurity, &cr.Spec.CommonApplicationDeploymentParams)
+		return dsSpec, nil
+	}
+
 	// fast path, use sts
 	if cr.Spec.StatefulMode {
 		stsSpec := &appsv1.StatefulSet{
@@ -390,6 +494,15 @@ func makeSpecForVMAgent(cr *vmv1beta1.VMAgent, ssCache *scrapesSecretsCache) (*c
 	args = cr.Spec.License.MaybeAddToArgs(args, vmv1beta1.SecretsDir)
 
 	var envs []corev1.EnvVar
+	if cr.Spec.DaemonSetMode {
+		// node name is used by kubernetes_sd_configs selectors to discover only node local targets
+		envs = append(envs, corev1.EnvVar{
+			Name: vmAgentNodeNameEnv,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"},
+			},
+		})
+	}
 	envs = append(envs, cr.Spec.ExtraEnvs...)
 
 	var ports []corev1.ContainerPort
@@ -696,7 +809,7 @@ func buildVMAgentRelabelingsAssets(ctx context.Context, cr *vmv1beta1.VMAgent, r
 		rcs := addRelabelConfigs(nil, cr.Spec.InlineRelabelConfig)
 		data, err := yaml.Marshal(rcs)
 		if err != nil {
-			return nil, fmt.Errorf("cannot serialize relabelConfig as yaml: %w", err)
+			return nil, vmv1beta1.NewConfigError(fmt.Errorf("cannot serialize relabelConfig as yaml: %w", err))
 		}
 		if len(data) > 0 {
 			cfgCM.Data[globalRelabelingName] = string(data)
@@ -721,7 +834,7 @@ func buildVMAgentRelabelingsAssets(ctx context.Context, cr *vmv1beta1.VMAgent, r
 			rcs := addRelabelConfigs(nil, rw.InlineUrlRelabelConfig)
 			data, err := yaml.Marshal(rcs)
 			if err != nil {
-				return nil, fmt.Errorf("cannot serialize urlRelabelConfig as yaml: %w", err)
+				return nil, vmv1beta1.NewConfigError(fmt.Errorf("cannot serialize urlRelabelConfig as yaml: %w", err))
 			}
 			if len(data) > 0 {
 				cfgCM.Data[fmt.Sprintf(urlRelabelingName, i)] = string(data)
@@ -771,7 +884,7 @@ func buildVMAgentStreamAggrConfig(ctx context.Context, cr *vmv1beta1.VMAgent, rc
 		if len(cr.Spec.StreamAggrConfig.Rules) > 0 {
 			data, err := yaml.Marshal(cr.Spec.StreamAggrConfig.Rules)
 			if err != nil {
-				return nil, fmt.Errorf("cannot serialize relabelConfig as yaml: %w", err)
+				return nil, vmv1beta1.NewConfigError(fmt.Errorf("cannot serialize relabelConfig as yaml: %w", err))
 			}
 			if len(data) > 0 {
 				cfgCM.Data[globalAggregationConfigName] = string(data)
@@ -796,7 +909,7 @@ func buildVMAgentStreamAggrConfig(ctx context.Context, cr *vmv1beta1.VMAgent, rc
 			if len(rw.StreamAggrConfig.Rules) > 0 {
 				data, err := yaml.Marshal(rw.StreamAggrConfig.Rules)
 				if err != nil {
-					return nil, fmt.Errorf("cannot serialize relabelConfig as yaml: %w", err)
+					return nil, vmv1beta1.NewConfigError(fmt.Errorf("cannot serialize relabelConfig as yaml: %w", err))
 				}
 				if len(data) > 0 {
 					cfgCM.Data[rw.AsConfigMapKey(i, "stream-aggr-conf")] = string(data)
@@ -1453,6 +1566,23 @@ func buildInitConfigContainer(useCustomConfigReloader bool, baseImage string, re
 	return []corev1.Container{initReloader}
 }
 
+// createOrUpdateNetworkPolicy allows access to vmagent service ports
+// vmagent pods are allowed to scrape each other for self-monitoring
+func createOrUpdateNetworkPolicy(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAgent, svc *corev1.Service) error {
+	peers, err := build.NetworkPolicyScrapePeers(ctx, rclient, cr.Spec.NetworkPolicy, cr.Namespace)
+	if err != nil {
+		return err
+	}
+	rules := &build.NetworkPolicyRules{
+		Ports: build.NetworkPolicyPortsFromService(svc),
+		Peers: append(peers, build.NetworkPolicyPeerForPods(cr.SelectorLabels(), cr.Namespace)),
+	}
+	if err := reconcile.NetworkPolicy(ctx, rclient, build.NetworkPolicy(cr, cr.Spec.NetworkPolicy, rules)); err != nil {
+		return fmt.Errorf("cannot update network policy for vmagent: %w", err)
+	}
+	return nil
+}
+
 func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VMAgent, rclient client.Client) error {
 	if cr.ParsedLastAppliedSpec == nil {
 		return nil
@@ -1469,6 +1599,9 @@ func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VMAgent, rclien
 			return fmt.Errorf("cannot delete PDB from prev state: %w", err)
 		}
 	}
+	if err := reconcile.RemoveOrphanedNetworkPolicy(ctx, rclient, cr.PrefixedName(), cr.Namespace, cr.ParsedLastAppliedSpec.NetworkPolicy, cr.Spec.NetworkPolicy); err != nil {
+		return err
+	}
 
 	if ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) && !ptr.Deref(cr.ParsedLastAppliedSpec.DisableSelfServiceScrape, false) {
 		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMServiceScrape{ObjectMeta: objMeta}); err != nil {
@@ -131,7 +131,7 @@ func createOrUpdateConfigurationSecret(ctx context.Context, cr *vmv1beta1.VMAgen
 		additionalScrapeConfigs,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("generating config for vmagent failed: %w", err)
+		return nil, vmv1beta1.NewConfigError(fmt.Errorf("generating config for vmagent failed: %w", err))
 	}
 
 	s := makeConfigSecret(cr, ssCache)
@@ -375,6 +375,9 @@ func loadScrapeSecrets(
 	badObjects = append(badObjects, tempBo...)
 
 	sos.prss, tempBo, err = forEachCollectSkipNotFound(sos.prss, func(probe *vmv1beta1.VMProbe) error {
+		if err := resolveProbeBlackboxExporter(ctx, rclient, probe); err != nil {
+			return err
+		}
 		if err := loadSecretsToCacheFrom(ctx, rclient, &probe.Spec.EndpointAuth, probe.AsMapKey(), probe.Namespace, ssCache); err != nil {
 			return err
 		}
@@ -1198,7 +1201,9 @@ func combineSelectorStr(kvs map[string]string) string {
 	return strings.Join(kvsSlice, ",")
 }
 
-func generateK8SSDConfig(namespaces []string, apiserverConfig *vmv1beta1.APIServerConfig, ssCache *scrapesSecretsCache, role string, am *vmv1beta1.AttachMetadata) yaml.MapItem {
+// generateK8SSDConfig builds kubernetes_sd_configs section
+// if nodeLocal is set, discovery of pods and nodes is limited to the node of vmagent pod
+func generateK8SSDConfig(namespaces []string, apiserverConfig *vmv1beta1.APIServerConfig, ssCache *scrapesSecretsCache, role string, am *vmv1beta1.AttachMetadata, nodeLocal bool) yaml.MapItem {
 	k8sSDConfig := yaml.MapSlice{
 		{
 			Key:   "role",
@@ -1220,6 +1225,28 @@ func generateK8SSDConfig(namespaces []string, apiserverConfig *vmv1beta1.APIServ
 			},
 		})
 	}
+	if nodeLocal {
+		// vmagent replaces %{ENV} placeholders at config file with env var values
+		var selector yaml.MapSlice
+		switch role {
+		case kubernetesSDRolePod, kubernetesSDRoleEndpoint, kubernetesSDRoleEndpointSlices:
+			selector = yaml.MapSlice{
+				{Key: "role", Value: kubernetesSDRolePod},
+				{Key: "field", Value: fmt.Sprintf("spec.nodeName=%%{%s}", vmAgentNodeNameEnv)},
+			}
+		case kubernetesSDRoleNode:
+			selector = yaml.MapSlice{
+				{Key: "role", Value: kubernetesSDRoleNode},
+				{Key: "field", Value: fmt.Sprintf("metadata.name=%%{%s}", vmAgentNodeNameEnv)},
+			}
+		}
+		if len(selector) > 0 {
+			k8sSDConfig = append(k8sSDConfig, yaml.MapItem{
+				Key:   "selectors",
+				Value: []yaml.MapSlice{selector},
+			})
+		}
+	}
 
 	if apiserverConfig != nil {
 		k8sSDConfig = append(k8sSDConfig, yaml.MapItem{
@@ -1260,6 +1287,16 @@ func generateK8SSDConfig(namespaces []string, apiserverConfig *vmv1beta1.APIServ
 	}
 }
 
+// addNodeLocalRelabelingTo keeps only targets located at the node of vmagent pod
+// endpoints could reference pods from any node, so it's not enough to use pod selector for it
+func addNodeLocalRelabelingTo(relabelings []yaml.MapSlice) []yaml.MapSlice {
+	return append(relabelings, yaml.MapSlice{
+		{Key: "action", Value: "keep"},
+		{Key: "source_labels", Value: []string{"__meta_kubernetes_pod_node_name"}},
+		{Key: "regex", Value: fmt.Sprintf("%%{%s}", vmAgentNodeNameEnv)},
+	})
+}
+
 func enforceNamespaceLabel(relabelings []yaml.MapSlice, namespace, enforcedNamespaceLabel string) []yaml.MapSlice {
 	if enforcedNamespaceLabel == "" {
 		return relabelings
@@ -20,6 +20,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -671,6 +672,109 @@ func TestCreateOrUpdateVMAgent(t *testing.T) {
 	}
 }
 
+func TestCreateOrUpdateVMAgentDaemonSet(t *testing.T) {
+	f := func(cr *vmv1beta1.VMAgent, predefinedObjects []runtime.Object) {
+		t.Helper()
+		ctx := context.Background()
+		fclient := k8stools.GetTestClientWithObjects(predefinedObjects)
+		build.AddDefaults(fclient.Scheme())
+		fclient.Scheme().Default(cr)
+		if err := CreateOrUpdateVMAgent(ctx, cr, fclient); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		var got appsv1.DaemonSet
+		if err := fclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.PrefixedName()}, &got); err != nil {
+			t.Fatalf("cannot get daemonset: %s", err)
+		}
+		var hasNodeNameEnv bool
+		for _, c := range got.Spec.Template.Spec.Containers {
+			if c.Name != "vmagent" {
+				continue
+			}
+			for _, env := range c.Env {
+				if env.Name == vmAgentNodeNameEnv && env.ValueFrom != nil && env.ValueFrom.FieldRef.FieldPath == "spec.nodeName" {
+					hasNodeNameEnv = true
+				}
+			}
+		}
+		if !hasNodeNameEnv {
+			t.Fatalf("expected %s env at vmagent container", vmAgentNodeNameEnv)
+		}
+		// deployment from previous mode must be removed
+		var deps appsv1.DeploymentList
+		if err := fclient.List(ctx, &deps); err != nil {
+			t.Fatalf("cannot list deployments: %s", err)
+		}
+		if len(deps.Items) != 0 {
+			t.Fatalf("expected no deployments, got: %d", len(deps.Items))
+		}
+	}
+
+	cr := &vmv1beta1.VMAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "example-agent",
+			Namespace: "default",
+		},
+		Spec: vmv1beta1.VMAgentSpec{
+			RemoteWrite: []vmv1beta1.VMAgentRemoteWriteSpec{
+				{URL: "http://remote-write"},
+			},
+			DaemonSetMode: true,
+		},
+	}
+	prevDeploy := k8stools.NewReadyDeployment("vmagent-example-agent", "default")
+	prevDeploy.Labels = cr.SelectorLabels()
+	f(cr, []runtime.Object{
+		prevDeploy,
+		// zero desired pods makes daemonset ready
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "vmagent-example-agent", Namespace: "default"}},
+	})
+}
+
+func TestCreateOrUpdateVPAs(t *testing.T) {
+	ctx := context.Background()
+	fclient := k8stools.GetTestClientWithObjects(nil)
+	cr := &vmv1beta1.VMAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "example-agent",
+			Namespace: "default",
+		},
+		Spec: vmv1beta1.VMAgentSpec{
+			CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+				VPA: &vmv1beta1.EmbeddedVPA{UpdateMode: "Initial"},
+			},
+		},
+	}
+	f := func(stsNames map[string]struct{}, wantVPAs []string) {
+		t.Helper()
+		if err := createOrUpdateVPAs(ctx, fclient, cr, nil, stsNames, nil); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		var vpas unstructured.UnstructuredList
+		vpas.SetGroupVersionKind(build.VPAGVK.GroupVersion().WithKind("VerticalPodAutoscalerList"))
+		if err := fclient.List(ctx, &vpas); err != nil {
+			t.Fatalf("cannot list vpas: %s", err)
+		}
+		var gotVPAs []string
+		for _, vpa := range vpas.Items {
+			gotVPAs = append(gotVPAs, vpa.GetName())
+		}
+		sort.Strings(gotVPAs)
+		assert.Equal(t, wantVPAs, gotVPAs)
+	}
+
+	// vpa per each shard
+	f(map[string]struct{}{"vmagent-example-agent-0": {}, "vmagent-example-agent-1": {}}, []string{"vmagent-example-agent-0", "vmagent-example-agent-1"})
+
+	// scale down shards
+	f(map[string]struct{}{"vmagent-example-agent-0": {}}, []string{"vmagent-example-agent-0"})
+
+	// disable vpa
+	cr.ParsedLastAppliedSpec = cr.Spec.DeepCopy()
+	cr.Spec.VPA = nil
+	f(map[string]struct{}{"vmagent-example-agent-0": {}}, nil)
+}
+
 func Test_loadTLSAssets(t *testing.T) {
 	type args struct {
 		servicescrapes []*vmv1beta1.VMServiceScrape
@@ -59,8 +59,9 @@ groups:
          back: "error rate is ok at vmalert "
 `
 
-// CreateOrUpdateRuleConfigMaps conditionally selects vmrules and stores content at configmaps
-func CreateOrUpdateRuleConfigMaps(ctx context.Context, cr *vmv1beta1.VMAlert, rclient client.Client) ([]string, error) {
+// CreateOrUpdateRuleConfigMaps conditionally selects vmrules and stores content at configmaps.
+// It returns names of configmaps for each vmalert shard
+func CreateOrUpdateRuleConfigMaps(ctx context.Context, cr *vmv1beta1.VMAlert, rclient client.Client) ([][]string, error) {
 	// fast path
 	if cr.IsUnmanaged() {
 		return nil, nil
@@ -71,7 +72,25 @@ func CreateOrUpdateRuleConfigMaps(ctx context.Context, cr *vmv1beta1.VMAlert, rc
 		return nil, err
 	}
 
-	newConfigMaps := makeRulesConfigMaps(cr, newRules)
+	var newConfigMaps []corev1.ConfigMap
+	var shardsStatus []vmv1beta1.VMAlertShardStatus
+	shardConfigMapNames := make([][]string, 0, len(newRules))
+	for shardNum, shard := range newRules {
+		shardConfigMaps := makeRulesConfigMaps(cr, shardNum, shard.files)
+		names := make([]string, 0, len(shardConfigMaps))
+		for _, cm := range shardConfigMaps {
+			names = append(names, cm.Name)
+		}
+		shardConfigMapNames = append(shardConfigMapNames, names)
+		newConfigMaps = append(newConfigMaps, shardConfigMaps...)
+		if cr.IsSharded() {
+			shardsStatus = append(shardsStatus, vmv1beta1.VMAlertShardStatus{Shard: int32(shardNum), Groups: int32(shard.groups)})
+		}
+	}
+	if err := cr.SetShardsStatus(ctx, rclient, shardsStatus); err != nil {
+		return nil, fmt.Errorf("cannot update shards status for vmalert: %w", err)
+	}
+
 	currentCMs := make([]corev1.ConfigMap, len(newConfigMaps))
 	for idx, cm := range newConfigMaps {
 		var existCM corev1.ConfigMap
@@ -84,11 +103,6 @@ func CreateOrUpdateRuleConfigMaps(ctx context.Context, cr *vmv1beta1.VMAlert, rc
 		currentCMs[idx] = existCM
 	}
 
-	newConfigMapNames := make([]string, 0, len(newConfigMaps))
-	for _, cm := range newConfigMaps {
-		newConfigMapNames = append(newConfigMapNames, cm.Name)
-	}
-
 	if len(currentCMs) == 0 {
 		l.Info("no Rule configmap found, creating new one")
 		for _, cm := range newConfigMaps {
@@ -97,11 +111,10 @@ func CreateOrUpdateRuleConfigMaps(ctx context.Context, cr *vmv1beta1.VMAlert, rc
 				return nil, fmt.Errorf("failed to create Configmap: %s, err: %w", cm.Name, err)
 			}
 		}
-		return newConfigMapNames, nil
+		return shardConfigMapNames, nil
 	}
 
 	// sort
-	sort.Strings(newConfigMapNames)
 	sort.Slice(currentCMs, func(i, j int) bool {
 		return currentCMs[i].Name < currentCMs[j].Name
 	})
@@ -138,7 +151,28 @@ func CreateOrUpdateRuleConfigMaps(ctx context.Context, cr *vmv1beta1.VMAlert, rc
 		}
 	}
 
-	return newConfigMapNames, nil
+	return shardConfigMapNames, nil
+}
+
+// removeOrphanedRuleConfigMaps removes rules configmaps, which are no longer used by vmalert shards
+func removeOrphanedRuleConfigMaps(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlert, keepConfigMaps map[string]struct{}) error {
+	var cmList corev1.ConfigMapList
+	if err := rclient.List(ctx, &cmList, cr.RulesConfigMapSelector()); err != nil {
+		return fmt.Errorf("cannot list rules configmaps: %w", err)
+	}
+	for i := range cmList.Items {
+		cm := &cmList.Items[i]
+		if _, ok := keepConfigMaps[cm.Name]; ok {
+			continue
+		}
+		if err := finalize.RemoveFinalizer(ctx, rclient, cm); err != nil {
+			return err
+		}
+		if err := finalize.SafeDelete(ctx, rclient, cm); err != nil {
+			return fmt.Errorf("cannot delete orphaned rules configmap=%q: %w", cm.Name, err)
+		}
+	}
+	return nil
 }
 
 // rulesCMDiff - calculates diff between existing at k8s (current) configmaps with rules
@@ -175,7 +209,13 @@ func rulesCMDiff(currentCMs []corev1.ConfigMap, newCMs []corev1.ConfigMap) (toCr
 	return toCreate, toUpdate
 }
 
-func selectRulesUpdateStatus(ctx context.Context, cr *vmv1beta1.VMAlert, rclient client.Client) (map[string]string, error) {
+// shardRules contains rule files and total number of rule groups for vmalert shard
+type shardRules struct {
+	files  map[string]string
+	groups int
+}
+
+func selectRulesUpdateStatus(ctx context.Context, cr *vmv1beta1.VMAlert, rclient client.Client) ([]shardRules, error) {
 	var vmRules []*vmv1beta1.VMRule
 	if err := k8stools.VisitObjectsForSelectorsAtNs(ctx, rclient, cr.Spec.RuleNamespaceSelector, cr.Spec.RuleSelector, cr.Namespace, cr.Spec.SelectAllByDefault,
 		func(list *vmv1beta1.VMRuleList) {
@@ -189,13 +229,18 @@ func selectRulesUpdateStatus(ctx context.Context, cr *vmv1beta1.VMAlert, rclient
 		return nil, err
 	}
 
-	rules := make(map[string]string, len(vmRules))
+	shardCount := cr.GetShardCount()
+	rules := make([]shardRules, shardCount)
+	for i := range rules {
+		rules[i].files = make(map[string]string)
+	}
 
 	if cr.NeedDedupRules() {
 		logger.WithContext(ctx).Info("deduplicating vmalert rules")
 		vmRules = deduplicateRules(ctx, vmRules)
 	}
 	var badRules []*vmv1beta1.VMRule
+	var ruleNames []string
 	var cnt int
 	for _, pRule := range vmRules {
 		if err := pRule.Validate(); err != nil {
@@ -203,27 +248,43 @@ func selectRulesUpdateStatus(ctx context.Context, cr *vmv1beta1.VMAlert, rclient
 			badRules = append(badRules, pRule)
 			continue
 		}
-		content, err := generateContent(pRule.Spec, cr.Spec.EnforcedNamespaceLabel, pRule.Namespace)
-		if err != nil {
-			pRule.Status.CurrentSyncError = fmt.Sprintf("cannot generate content for rule: %s, err :%s", pRule.Name, err)
+		shardedSpecs := splitRuleGroupsByShards(pRule, shardCount)
+		contents := make([]string, shardCount)
+		var genErr error
+		for shardNum, spec := range shardedSpecs {
+			if spec == nil {
+				continue
+			}
+			contents[shardNum], genErr = generateContent(*spec, cr.Spec.EnforcedNamespaceLabel, pRule.Namespace)
+			if genErr != nil {
+				break
+			}
+		}
+		if genErr != nil {
+			pRule.Status.CurrentSyncError = fmt.Sprintf("cannot generate content for rule: %s, err :%s", pRule.Name, genErr)
 			badRules = append(badRules, pRule)
 			continue
 		}
 		vmRules[cnt] = pRule
 		cnt++
-		rules[fmt.Sprintf("%s-%s.yaml", pRule.Namespace, pRule.Name)] = content
+		fileName := fmt.Sprintf("%s-%s.yaml", pRule.Namespace, pRule.Name)
+		for shardNum, spec := range shardedSpecs {
+			if spec == nil {
+				continue
+			}
+			rules[shardNum].files[fileName] = contents[shardNum]
+			rules[shardNum].groups += len(spec.Groups)
+		}
+		ruleNames = append(ruleNames, fileName)
 	}
 	vmRules = vmRules[:cnt]
 
-	ruleNames := make([]string, 0, len(rules))
-	for name := range rules {
-		ruleNames = append(ruleNames, name)
-	}
-
-	if len(rules) == 0 {
-		// inject default rule
-		// it's needed to start vmalert.
-		rules["default-vmalert.yaml"] = defAlert
+	for i := range rules {
+		if len(rules[i].files) == 0 {
+			// inject default rule
+			// it's needed to start vmalert.
+			rules[i].files["default-vmalert.yaml"] = defAlert
+		}
 	}
 	var errors []string
 	for _, bRule := range badRules {
@@ -261,6 +322,35 @@ func selectRulesUpdateStatus(ctx context.Context, cr *vmv1beta1.VMAlert, rclient
 	return rules, nil
 }
 
+// splitRuleGroupsByShards distributes groups of VMRule across vmalert shards.
+// It returns nil spec for shards without groups of the given VMRule
+func splitRuleGroupsByShards(rule *vmv1beta1.VMRule, shardCount int) []*vmv1beta1.VMRuleSpec {
+	if shardCount <= 1 {
+		return []*vmv1beta1.VMRuleSpec{&rule.Spec}
+	}
+	specs := make([]*vmv1beta1.VMRuleSpec, shardCount)
+	for _, group := range rule.Spec.Groups {
+		shardNum := calculateGroupShard(rule.Namespace, rule.Name, group.Name, shardCount)
+		if specs[shardNum] == nil {
+			specs[shardNum] = &vmv1beta1.VMRuleSpec{}
+		}
+		specs[shardNum].Groups = append(specs[shardNum].Groups, group)
+	}
+	return specs
+}
+
+// calculateGroupShard returns shard number for rule group.
+// Only group identity is hashed, so changes of group rules don't move group to another shard
+func calculateGroupShard(ns, ruleName, groupName string, shardCount int) int {
+	h := fnv.New64a()
+	h.Write([]byte(ns))        //nolint:errcheck
+	h.Write([]byte("\xff"))    //nolint:errcheck
+	h.Write([]byte(ruleName))  //nolint:errcheck
+	h.Write([]byte("\xff"))    //nolint:errcheck
+	h.Write([]byte(groupName)) //nolint:errcheck
+	return int(h.Sum64() % uint64(shardCount))
+}
+
 func generateContent(promRule vmv1beta1.VMRuleSpec, enforcedNsLabel, ns string) (string, error) {
 	if enforcedNsLabel != "" {
 		for gi, group := range promRule.Groups {
@@ -285,8 +375,9 @@ func generateContent(promRule vmv1beta1.VMRuleSpec, enforcedNsLabel, ns string)
 // they are split up via the simple first-fit [1] bin packing algorithm. In the
 // future this can be replaced by a more sophisticated algorithm, but for now
 // simplicity should be sufficient.
+// For sharded vmalert configmap names contain shard number.
 // [1] https://en.wikipedia.org/wiki/Bin_packing_problem#First-fit_algorithm
-func makeRulesConfigMaps(cr *vmv1beta1.VMAlert, ruleFiles map[string]string) []corev1.ConfigMap {
+func makeRulesConfigMaps(cr *vmv1beta1.VMAlert, shardNum int, ruleFiles map[string]string) []corev1.ConfigMap {
 	buckets := []map[string]string{
 		{},
 	}
@@ -312,6 +403,9 @@ func makeRulesConfigMaps(cr *vmv1beta1.VMAlert, ruleFiles map[string]string) []c
 	ruleFileConfigMaps := make([]corev1.ConfigMap, 0, len(buckets))
 	for i, bucket := range buckets {
 		cm := makeRulesConfigMap(cr, bucket)
+		if cr.IsSharded() {
+			cm.Name = cm.Name + "-shard-" + strconv.Itoa(shardNum)
+		}
 		cm.Name = cm.Name + "-" + strconv.Itoa(i)
 		ruleFileConfigMaps = append(ruleFileConfigMaps, cm)
 	}
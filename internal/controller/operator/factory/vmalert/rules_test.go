@@ -2,7 +2,9 @@ package vmalert
 
 import (
 	"context"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -12,6 +14,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -279,7 +282,7 @@ groups:
 				t.Errorf("SelectRules() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			for ruleName, content := range got {
+			for ruleName, content := range got[0].files {
 				if !assert.Equal(t, tt.want[ruleName], content) {
 					t.Errorf("SelectRules() got = %v, want %v", content, tt.want[ruleName])
 				}
@@ -316,7 +319,7 @@ func TestCreateOrUpdateRuleConfigMaps(t *testing.T) {
 	tests := []struct {
 		name              string
 		args              args
-		want              []string
+		want              [][]string
 		wantErr           bool
 		predefinedObjects []runtime.Object
 	}{
@@ -339,7 +342,7 @@ func TestCreateOrUpdateRuleConfigMaps(t *testing.T) {
 				},
 				Spec: vmv1beta1.VMAlertSpec{SelectAllByDefault: true},
 			}},
-			want: []string{"vm-base-vmalert-rulefiles-0"},
+			want: [][]string{{"vm-base-vmalert-rulefiles-0"}},
 		},
 	}
 	for _, tt := range tests {
@@ -357,6 +360,78 @@ func TestCreateOrUpdateRuleConfigMaps(t *testing.T) {
 	}
 }
 
+func TestCreateOrUpdateRuleConfigMapsSharded(t *testing.T) {
+	cr := &vmv1beta1.VMAlert{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "sharded"},
+		Spec: vmv1beta1.VMAlertSpec{
+			SelectAllByDefault: true,
+			ShardCount:         ptr.To(3),
+		},
+	}
+	var predefinedObjects []runtime.Object
+	var totalGroups int
+	for _, name := range []string{"app", "db", "infra"} {
+		rule := &vmv1beta1.VMRule{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		}
+		for i := 0; i < 4; i++ {
+			rule.Spec.Groups = append(rule.Spec.Groups, vmv1beta1.RuleGroup{
+				Name:  fmt.Sprintf("%s-group-%d", name, i),
+				Rules: []vmv1beta1.Rule{{Record: fmt.Sprintf("%s:up:sum%d", name, i), Expr: "sum(up)"}},
+			})
+			totalGroups++
+		}
+		predefinedObjects = append(predefinedObjects, rule)
+	}
+	predefinedObjects = append(predefinedObjects, cr)
+	ctx := context.Background()
+	fclient := k8stools.GetTestClientWithObjects(predefinedObjects)
+	got, err := CreateOrUpdateRuleConfigMaps(ctx, cr, fclient)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	assert.Equal(t, [][]string{
+		{"vm-sharded-rulefiles-shard-0-0"},
+		{"vm-sharded-rulefiles-shard-1-0"},
+		{"vm-sharded-rulefiles-shard-2-0"},
+	}, got)
+
+	// each group must be evaluated by a single shard
+	shardContents := make([]string, len(got))
+	for shardNum, names := range got {
+		var cm v1.ConfigMap
+		if err := fclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: names[0]}, &cm); err != nil {
+			t.Fatalf("cannot get configmap: %s", err)
+		}
+		for _, content := range cm.Data {
+			shardContents[shardNum] += content
+		}
+	}
+	for _, name := range []string{"app", "db", "infra"} {
+		for i := 0; i < 4; i++ {
+			groupName := fmt.Sprintf("%s-group-%d", name, i)
+			wantShard := calculateGroupShard("default", name, groupName, 3)
+			for shardNum, content := range shardContents {
+				assert.Equal(t, shardNum == wantShard, strings.Contains(content, "name: "+groupName+"\n"), "group=%s shard=%d", groupName, shardNum)
+			}
+		}
+	}
+
+	// status reports groups per shard
+	var gotGroups int32
+	assert.Len(t, cr.Status.Shards, 3)
+	for shardNum, shard := range cr.Status.Shards {
+		assert.Equal(t, int32(shardNum), shard.Shard)
+		gotGroups += shard.Groups
+	}
+	assert.Equal(t, int32(totalGroups), gotGroups)
+	var gotCR vmv1beta1.VMAlert
+	if err := fclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "sharded"}, &gotCR); err != nil {
+		t.Fatalf("cannot get vmalert: %s", err)
+	}
+	assert.Equal(t, cr.Status.Shards, gotCR.Status.Shards)
+}
+
 func Test_deduplicateRules(t *testing.T) {
 	type args struct {
 		origin []*vmv1beta1.VMRule
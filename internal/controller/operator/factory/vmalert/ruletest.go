@@ -0,0 +1,329 @@
+package vmalert
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"path"
+	"sort"
+	"strconv"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+	"github.com/ghodss/yaml"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const ruleTestDir = "/etc/vm/ruletest"
+
+// ruleTestFile is vmalert-tool unittest file
+// https://docs.victoriametrics.com/vmalert-tool/#test-file-format
+type ruleTestFile struct {
+	RuleFiles          []string        `json:"rule_files"`
+	EvaluationInterval string          `json:"evaluation_interval,omitempty"`
+	GroupEvalOrder     []string        `json:"group_eval_order,omitempty"`
+	Tests              []ruleTestGroup `json:"tests"`
+}
+
+type ruleTestGroup struct {
+	vmv1beta1.RuleTestCase
+	// overrides field of test case, since vmalert-tool expects float values for samples
+	MetricsQLExprTests []ruleTestExpr `json:"metricsql_expr_test,omitempty"`
+}
+
+type ruleTestExpr struct {
+	Expr       string           `json:"expr"`
+	EvalTime   string           `json:"eval_time"`
+	ExpSamples []ruleTestSample `json:"exp_samples,omitempty"`
+}
+
+type ruleTestSample struct {
+	Labels string  `json:"labels"`
+	Value  float64 `json:"value"`
+}
+
+// CreateOrUpdateVMRuleTest creates vmalert-tool Job per test case for the selected VMRules
+// and updates status of the given VMRuleTest
+func CreateOrUpdateVMRuleTest(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMRuleTest) error {
+	ruleFiles, ruleNames, err := selectRuleTestRules(ctx, rclient, cr)
+	if err != nil {
+		return err
+	}
+	data := make(map[string]string, len(ruleFiles)+len(cr.Spec.Tests))
+	ruleFileNames := make([]string, 0, len(ruleFiles))
+	for name, content := range ruleFiles {
+		data[name] = content
+		ruleFileNames = append(ruleFileNames, name)
+	}
+	sort.Strings(ruleFileNames)
+	h := fnv.New64a()
+	for _, name := range ruleFileNames {
+		h.Write([]byte(name))
+		h.Write([]byte(ruleFiles[name]))
+	}
+	rulesHash := h.Sum64()
+
+	testFiles := make([]string, 0, len(cr.Spec.Tests))
+	for idx := range cr.Spec.Tests {
+		content, err := generateRuleTestContent(cr, &cr.Spec.Tests[idx], ruleFileNames)
+		if err != nil {
+			return fmt.Errorf("cannot generate content for test case=%q: %w", cr.Spec.Tests[idx].Name, err)
+		}
+		name := fmt.Sprintf("test-%d.yaml", idx)
+		data[name] = content
+		testFiles = append(testFiles, name)
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cr.PrefixedName(),
+			Namespace:       cr.Namespace,
+			Labels:          cr.AllLabels(),
+			Annotations:     cr.AnnotationsFiltered(),
+			OwnerReferences: cr.AsOwner(),
+		},
+		Data: data,
+	}
+	if err := reconcile.ConfigMap(ctx, rclient, cm); err != nil {
+		return fmt.Errorf("cannot reconcile configmap for vmruletest: %w", err)
+	}
+
+	keepJobs := make(map[string]struct{}, len(cr.Spec.Tests))
+	statuses := make([]vmv1beta1.RuleTestCaseStatus, 0, len(cr.Spec.Tests))
+	for idx, tc := range cr.Spec.Tests {
+		name := fmt.Sprintf("%s-%d", cr.PrefixedName(), idx)
+		keepJobs[name] = struct{}{}
+		h := fnv.New64a()
+		h.Write([]byte(data[testFiles[idx]]))
+		contentHash := strconv.FormatUint(rulesHash^h.Sum64(), 10)
+		job, err := reconcile.Job(ctx, rclient, buildRuleTestJob(cr, name, testFiles[idx], contentHash))
+		if err != nil {
+			return fmt.Errorf("cannot reconcile job for test case=%q: %w", tc.Name, err)
+		}
+		st, err := ruleTestCaseStatus(ctx, rclient, tc.Name, name, job)
+		if err != nil {
+			return err
+		}
+		statuses = append(statuses, st)
+	}
+	if err := removeOrphanedRuleTestJobs(ctx, rclient, cr, keepJobs); err != nil {
+		return err
+	}
+	return cr.SetTestsStatus(ctx, rclient, ruleNames, statuses)
+}
+
+// selectRuleTestRules returns rule files content for VMRules matched by ruleSelector.
+// Without namespaceSelector only rules from the test namespace are matched
+func selectRuleTestRules(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMRuleTest) (map[string]string, []string, error) {
+	sel := cr.Spec.RuleSelector
+	if sel == nil {
+		sel = &vmv1beta1.DiscoverySelector{}
+	}
+	opts, err := sel.AsListOptions()
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot convert ruleSelector as ListOptions: %w", err)
+	}
+	var nss []string
+	if sel.Namespace == nil {
+		nss = []string{cr.Namespace}
+	} else {
+		nss = config.MustGetWatchNamespaces()
+	}
+	var rules []*vmv1beta1.VMRule
+	if err := k8stools.ListObjectsByNamespace(ctx, rclient, nss, func(objects *vmv1beta1.VMRuleList) {
+		for _, item := range objects.Items {
+			if !item.DeletionTimestamp.IsZero() || (sel.Namespace != nil && !sel.Namespace.IsMatch(item)) {
+				continue
+			}
+			rules = append(rules, item)
+		}
+	}, opts); err != nil {
+		return nil, nil, fmt.Errorf("cannot list vmrules with ruleSelector: %w", err)
+	}
+	if len(rules) == 0 {
+		return nil, nil, fmt.Errorf("no vmrules matched ruleSelector")
+	}
+	ruleFiles := make(map[string]string, len(rules))
+	ruleNames := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, nil, fmt.Errorf("vmrule=%s/%s is not valid: %w", rule.Namespace, rule.Name, err)
+		}
+		content, err := generateContent(rule.Spec, "", rule.Namespace)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot generate content for vmrule=%s/%s: %w", rule.Namespace, rule.Name, err)
+		}
+		ruleFiles[fmt.Sprintf("%s-%s.yaml", rule.Namespace, rule.Name)] = content
+		ruleNames = append(ruleNames, fmt.Sprintf("%s/%s", rule.Namespace, rule.Name))
+	}
+	sort.Strings(ruleNames)
+	return ruleFiles, ruleNames, nil
+}
+
+func generateRuleTestContent(cr *vmv1beta1.VMRuleTest, tc *vmv1beta1.RuleTestCase, ruleFiles []string) (string, error) {
+	tg := ruleTestGroup{RuleTestCase: *tc}
+	for _, et := range tc.MetricsQLExprTests {
+		expr := ruleTestExpr{
+			Expr:     et.Expr,
+			EvalTime: et.EvalTime,
+		}
+		for _, s := range et.ExpSamples {
+			v, err := strconv.ParseFloat(s.Value, 64)
+			if err != nil {
+				return "", fmt.Errorf("cannot parse value of sample=%q: %w", s.Labels, err)
+			}
+			expr.ExpSamples = append(expr.ExpSamples, ruleTestSample{Labels: s.Labels, Value: v})
+		}
+		tg.MetricsQLExprTests = append(tg.MetricsQLExprTests, expr)
+	}
+	content, err := yaml.Marshal(ruleTestFile{
+		RuleFiles:          ruleFiles,
+		EvaluationInterval: cr.Spec.EvaluationInterval,
+		GroupEvalOrder:     cr.Spec.GroupEvalOrder,
+		Tests:              []ruleTestGroup{tg},
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+func buildRuleTestJob(cr *vmv1beta1.VMRuleTest, name, testFile, contentHash string) *batchv1.Job {
+	annotations := cr.AnnotationsFiltered()
+	annotations[reconcile.JobGenerationAnnotation] = strconv.FormatInt(cr.Generation, 10)
+	annotations[reconcile.JobContentHashAnnotation] = contentHash
+	args := []string{"unittest", fmt.Sprintf("--files=%s", path.Join(ruleTestDir, testFile))}
+	if cr.Spec.DisableAlertGroupLabel {
+		args = append(args, "--disableAlertgroupLabel")
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       cr.Namespace,
+			Labels:          cr.AllLabels(),
+			Annotations:     annotations,
+			OwnerReferences: cr.AsOwner(),
+		},
+		Spec: batchv1.JobSpec{
+			// test results are deterministic, there is no need to retry
+			BackoffLimit: ptr.To[int32](0),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: cr.SelectorLabels(),
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: cr.Spec.ServiceAccountName,
+					ImagePullSecrets:   cr.Spec.ImagePullSecrets,
+					Volumes: []corev1.Volume{
+						{
+							Name: "ruletest",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{Name: cr.PrefixedName()},
+								},
+							},
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:            "vmalert-tool",
+							Image:           fmt.Sprintf("%s:%s", cr.Spec.Image.Repository, cr.Spec.Image.Tag),
+							ImagePullPolicy: cr.Spec.Image.PullPolicy,
+							Args:            args,
+							Resources:       cr.Spec.Resources,
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "ruletest",
+									MountPath: ruleTestDir,
+									ReadOnly:  true,
+								},
+							},
+							// output of failed test is exposed with termination message
+							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ruleTestCaseStatus converts job state into test case status
+// output of failed test case is taken from termination message of job pod
+func ruleTestCaseStatus(ctx context.Context, rclient client.Client, testName, jobName string, job *batchv1.Job) (vmv1beta1.RuleTestCaseStatus, error) {
+	st := vmv1beta1.RuleTestCaseStatus{
+		Name:    testName,
+		JobName: jobName,
+		Phase:   vmv1beta1.RuleTestPhasePending,
+	}
+	if job == nil {
+		return st, nil
+	}
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			st.Phase = vmv1beta1.RuleTestPhasePassed
+			return st, nil
+		case batchv1.JobFailed:
+			st.Phase = vmv1beta1.RuleTestPhaseFailed
+			st.Message = cond.Message
+			msg, err := jobTerminationMessage(ctx, rclient, job)
+			if err != nil {
+				return st, err
+			}
+			if msg != "" {
+				st.Message = msg
+			}
+			return st, nil
+		}
+	}
+	if job.Status.Active > 0 {
+		st.Phase = vmv1beta1.RuleTestPhaseRunning
+	}
+	return st, nil
+}
+
+func jobTerminationMessage(ctx context.Context, rclient client.Client, job *batchv1.Job) (string, error) {
+	var pods corev1.PodList
+	if err := rclient.List(ctx, &pods, client.InNamespace(job.Namespace), client.MatchingLabels{"job-name": job.Name}); err != nil {
+		return "", fmt.Errorf("cannot list pods of job=%q: %w", job.Name, err)
+	}
+	for _, pod := range pods.Items {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.State.Terminated != nil && cs.State.Terminated.ExitCode != 0 && cs.State.Terminated.Message != "" {
+				return cs.State.Terminated.Message, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// removeOrphanedRuleTestJobs deletes jobs of removed test cases
+func removeOrphanedRuleTestJobs(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMRuleTest, keepJobs map[string]struct{}) error {
+	var jobs batchv1.JobList
+	if err := rclient.List(ctx, &jobs, client.InNamespace(cr.Namespace), client.MatchingLabels(cr.SelectorLabels())); err != nil {
+		return fmt.Errorf("cannot list jobs: %w", err)
+	}
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if _, ok := keepJobs[job.Name]; ok {
+			continue
+		}
+		logger.WithContext(ctx).Info("removing orphaned job", "job_name", job.Name)
+		if err := rclient.Delete(ctx, job, &client.DeleteOptions{PropagationPolicy: ptr.To(metav1.DeletePropagationBackground)}); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("cannot delete orphaned job=%q: %w", job.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package vmalert
+
+import (
+	"context"
+	"testing"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestCreateOrUpdateVMRuleTest(t *testing.T) {
+	rule := &vmv1beta1.VMRule{
+		ObjectMeta: metav1.ObjectMeta{Name: "targets", Namespace: "default"},
+		Spec: vmv1beta1.VMRuleSpec{
+			Groups: []vmv1beta1.RuleGroup{
+				{
+					Name: "targets",
+					Rules: []vmv1beta1.Rule{
+						{Alert: "TargetDown", Expr: "up == 0", For: "5m"},
+					},
+				},
+			},
+		},
+	}
+	cr := &vmv1beta1.VMRuleTest{
+		ObjectMeta: metav1.ObjectMeta{Name: "targets", Namespace: "default", Generation: 1},
+		Spec: vmv1beta1.VMRuleTestSpec{
+			Tests: []vmv1beta1.RuleTestCase{
+				{
+					Name:        "target down",
+					Interval:    "1m",
+					InputSeries: []vmv1beta1.RuleTestInputSeries{{Series: `up{job="vmagent"}`, Values: "0x10"}},
+					AlertRuleTests: []vmv1beta1.AlertRuleTest{
+						{
+							EvalTime:  "10m",
+							GroupName: "targets",
+							AlertName: "TargetDown",
+							ExpAlerts: []vmv1beta1.ExpectedAlert{{ExpLabels: map[string]string{"job": "vmagent"}}},
+						},
+					},
+				},
+				{
+					Name:        "expr",
+					InputSeries: []vmv1beta1.RuleTestInputSeries{{Series: `up{job="vmagent"}`, Values: "1x10"}},
+					MetricsQLExprTests: []vmv1beta1.MetricsQLExprTest{
+						{
+							Expr:       "sum(up)",
+							EvalTime:   "5m",
+							ExpSamples: []vmv1beta1.ExpectedSample{{Labels: "{}", Value: "2"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	ctx := context.Background()
+	fclient := k8stools.GetTestClientWithObjects([]runtime.Object{rule, cr})
+
+	// jobs are created for each test case
+	if err := CreateOrUpdateVMRuleTest(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var cm corev1.ConfigMap
+	if err := fclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "vmruletest-targets"}, &cm); err != nil {
+		t.Fatalf("cannot get configmap: %s", err)
+	}
+	assert.Contains(t, cm.Data, "default-targets.yaml")
+	assert.Equal(t, `rule_files:
+- default-targets.yaml
+tests:
+- alert_rule_test:
+  - alertname: TargetDown
+    eval_time: 10m
+    exp_alerts:
+    - exp_labels:
+        job: vmagent
+    groupname: targets
+  input_series:
+  - series: up{job="vmagent"}
+    values: "0x10"
+  interval: 1m
+  name: target down
+`, cm.Data["test-0.yaml"])
+	assert.Equal(t, `rule_files:
+- default-targets.yaml
+tests:
+- input_series:
+  - series: up{job="vmagent"}
+    values: 1x10
+  metricsql_expr_test:
+  - eval_time: 5m
+    exp_samples:
+    - labels: '{}'
+      value: 2
+    expr: sum(up)
+  name: expr
+`, cm.Data["test-1.yaml"])
+	var job batchv1.Job
+	if err := fclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "vmruletest-targets-1"}, &job); err != nil {
+		t.Fatalf("cannot get job: %s", err)
+	}
+	assert.Equal(t, []string{"unittest", "--files=/etc/vm/ruletest/test-1.yaml"}, job.Spec.Template.Spec.Containers[0].Args)
+	assert.Equal(t, []string{"default/targets"}, cr.Status.Rules)
+	assert.Equal(t, "0/2", cr.Status.Result)
+	assert.Equal(t, vmv1beta1.UpdateStatusExpanding, cr.Status.UpdateStatus)
+
+	// job results are reflected at status
+	setJobCondition := func(name string, condType batchv1.JobConditionType) {
+		t.Helper()
+		var job batchv1.Job
+		if err := fclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: name}, &job); err != nil {
+			t.Fatalf("cannot get job: %s", err)
+		}
+		job.Status.Conditions = []batchv1.JobCondition{{Type: condType, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"}}
+		if err := fclient.Status().Update(ctx, &job); err != nil {
+			t.Fatalf("cannot update job status: %s", err)
+		}
+	}
+	setJobCondition("vmruletest-targets-0", batchv1.JobComplete)
+	setJobCondition("vmruletest-targets-1", batchv1.JobFailed)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vmruletest-targets-1-abcde",
+			Namespace: "default",
+			Labels:    map[string]string{"job-name": "vmruletest-targets-1"},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "vmalert-tool",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Message: "FAILED: unexpected sample value"},
+					},
+				},
+			},
+		},
+	}
+	if err := fclient.Create(ctx, pod); err != nil {
+		t.Fatalf("cannot create pod: %s", err)
+	}
+	if err := CreateOrUpdateVMRuleTest(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	assert.Equal(t, []vmv1beta1.RuleTestCaseStatus{
+		{Name: "target down", JobName: "vmruletest-targets-0", Phase: vmv1beta1.RuleTestPhasePassed},
+		{Name: "expr", JobName: "vmruletest-targets-1", Phase: vmv1beta1.RuleTestPhaseFailed, Message: "FAILED: unexpected sample value"},
+	}, cr.Status.Tests)
+	assert.Equal(t, "1/2", cr.Status.Result)
+	assert.Equal(t, vmv1beta1.UpdateStatusFailed, cr.Status.UpdateStatus)
+
+	// tests are executed again after rule change
+	rule.Spec.Groups[0].Rules[0].For = "1m"
+	if err := fclient.Update(ctx, rule); err != nil {
+		t.Fatalf("cannot update rule: %s", err)
+	}
+	if err := CreateOrUpdateVMRuleTest(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	assert.Equal(t, vmv1beta1.RuleTestPhasePending, cr.Status.Tests[0].Phase)
+	assert.Equal(t, vmv1beta1.RuleTestPhasePending, cr.Status.Tests[1].Phase)
+	var jobs batchv1.JobList
+	if err := fclient.List(ctx, &jobs); err != nil {
+		t.Fatalf("cannot list jobs: %s", err)
+	}
+	assert.Empty(t, jobs.Items)
+
+	// jobs of removed test cases are deleted
+	if err := CreateOrUpdateVMRuleTest(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cr.Spec.Tests = cr.Spec.Tests[:1]
+	if err := CreateOrUpdateVMRuleTest(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := fclient.List(ctx, &jobs); err != nil {
+		t.Fatalf("cannot list jobs: %s", err)
+	}
+	assert.Len(t, jobs.Items, 1)
+	assert.Equal(t, "vmruletest-targets-0", jobs.Items[0].Name)
+	assert.Len(t, cr.Status.Tests, 1)
+
+	// status is persisted, even if update status is not changed
+	var stored vmv1beta1.VMRuleTest
+	if err := fclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "targets"}, &stored); err != nil {
+		t.Fatalf("cannot get vmruletest: %s", err)
+	}
+	assert.Equal(t, vmv1beta1.UpdateStatusExpanding, stored.Status.UpdateStatus)
+	assert.Equal(t, cr.Status.Tests, stored.Status.Tests)
+	assert.Equal(t, "0/1", stored.Status.Result)
+
+	// no rules selected
+	cr.Spec.RuleSelector = &vmv1beta1.DiscoverySelector{Labels: &metav1.LabelSelector{MatchLabels: map[string]string{"missing": "label"}}}
+	assert.Error(t, CreateOrUpdateVMRuleTest(ctx, fclient, cr))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"path"
 	"sort"
+	"strconv"
 	"strings"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
@@ -15,12 +16,15 @@ import (
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 )
 
 const (
@@ -120,7 +124,7 @@ func createOrUpdateVMAlertSecret(ctx context.Context, rclient client.Client, cr
 }
 
 // CreateOrUpdateVMAlert creates vmalert deployment for given CRD
-func CreateOrUpdateVMAlert(ctx context.Context, cr *vmv1beta1.VMAlert, rclient client.Client, cmNames []string) error {
+func CreateOrUpdateVMAlert(ctx context.Context, cr *vmv1beta1.VMAlert, rclient client.Client, cmNames [][]string) error {
 	if err := deletePrevStateResources(ctx, cr, rclient); err != nil {
 		return fmt.Errorf("cannot delete objects from previous state: %w", err)
 	}
@@ -146,6 +150,11 @@ func CreateOrUpdateVMAlert(ctx context.Context, cr *vmv1beta1.VMAlert, rclient c
 	if err != nil {
 		return err
 	}
+	if cr.Spec.NetworkPolicy != nil {
+		if err := createOrUpdateNetworkPolicy(ctx, rclient, cr, svc); err != nil {
+			return err
+		}
+	}
 
 	if !ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) {
 		err := reconcile.VMServiceScrapeForCRD(ctx, rclient, build.VMServiceScrapeForServiceWithSpec(svc, cr))
@@ -164,22 +173,98 @@ func CreateOrUpdateVMAlert(ctx context.Context, cr *vmv1beta1.VMAlert, rclient c
 	if err != nil {
 		return err
 	}
-	var prevDeploy *appsv1.Deployment
-	if cr.ParsedLastAppliedSpec != nil {
-		prevCR := cr.DeepCopy()
-		prevCR.Spec = *cr.ParsedLastAppliedSpec
-		prevDeploy, err = newDeployForVMAlert(prevCR, cmNames, remoteSecrets)
+	deploymentNames := make(map[string]struct{})
+	shardCount := cr.GetShardCount()
+	if cr.IsSharded() {
+		logger.WithContext(ctx).Info("using sharded version of VMAlert with", "shards", shardCount)
+	}
+	for shardNum := 0; shardNum < shardCount; shardNum++ {
+		var shardCMNames []string
+		if shardNum < len(cmNames) {
+			shardCMNames = cmNames[shardNum]
+		}
+		var prevDeploy *appsv1.Deployment
+		if cr.ParsedLastAppliedSpec != nil {
+			prevCR := cr.DeepCopy()
+			prevCR.Spec = *cr.ParsedLastAppliedSpec
+			prevDeploy, err = newDeployForVMAlert(prevCR, shardCMNames, remoteSecrets)
+			if err != nil {
+				return fmt.Errorf("cannot generate prev deploy spec: %w", err)
+			}
+			if prevCR.IsSharded() {
+				addShardSettingsToDeployment(shardNum, prevDeploy)
+			}
+		}
+
+		newDeploy, err := newDeployForVMAlert(cr, shardCMNames, remoteSecrets)
 		if err != nil {
-			return fmt.Errorf("cannot generate prev deploy spec: %w", err)
+			return vmv1beta1.NewConfigError(fmt.Errorf("cannot generate new deploy for vmalert: %w", err))
+		}
+		if cr.IsSharded() {
+			addShardSettingsToDeployment(shardNum, newDeploy)
+		}
+
+		if err := reconcile.Deployment(ctx, rclient, newDeploy, prevDeploy, false); err != nil {
+			return err
+		}
+		deploymentNames[newDeploy.Name] = struct{}{}
+	}
+	if err := finalize.RemoveOrphanedDeployments(ctx, rclient, cr, deploymentNames); err != nil {
+		return err
+	}
+	if !cr.IsUnmanaged() {
+		configMapNames := make(map[string]struct{})
+		for _, names := range cmNames {
+			for _, name := range names {
+				configMapNames[name] = struct{}{}
+			}
+		}
+		if err := removeOrphanedRuleConfigMaps(ctx, rclient, cr, configMapNames); err != nil {
+			return err
 		}
 	}
+	if err := createOrUpdateVPAs(ctx, rclient, cr, deploymentNames); err != nil {
+		return err
+	}
+	return nil
+}
 
-	newDeploy, err := newDeployForVMAlert(cr, cmNames, remoteSecrets)
-	if err != nil {
-		return fmt.Errorf("cannot generate new deploy for vmalert: %w", err)
+// createOrUpdateVPAs reconciles VerticalPodAutoscaler per each vmalert shard
+// and removes autoscalers of shards, which are no longer exist
+func createOrUpdateVPAs(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlert, deploymentNames map[string]struct{}) error {
+	var prevVPA *vmv1beta1.EmbeddedVPA
+	if cr.ParsedLastAppliedSpec != nil {
+		prevVPA = cr.ParsedLastAppliedSpec.VPA
+	}
+	if cr.Spec.VPA == nil && prevVPA == nil {
+		return nil
+	}
+	vpaNames := make(map[string]struct{})
+	if cr.Spec.VPA != nil {
+		for name := range deploymentNames {
+			targetRef := autoscalingv1.CrossVersionObjectReference{
+				Name:       name,
+				Kind:       "Deployment",
+				APIVersion: "apps/v1",
+			}
+			vpa := build.VPA(targetRef, "vmalert", cr.Spec.VPA, cr.AsOwner(), cr.SelectorLabels(), cr.Namespace)
+			if err := reconcile.VPA(ctx, rclient, vpa); err != nil {
+				return fmt.Errorf("cannot update vpa for vmalert: %w", err)
+			}
+			vpaNames[name] = struct{}{}
+		}
+	}
+	if err := finalize.RemoveOrphanedVPAs(ctx, rclient, cr, vpaNames); err != nil {
+		return fmt.Errorf("cannot remove orphaned VPA for vmalert: %w", err)
 	}
+	return nil
+}
 
-	return reconcile.Deployment(ctx, rclient, newDeploy, prevDeploy, false)
+// addShardSettingsToDeployment adds shard number to the name and selector labels of vmalert deployment
+func addShardSettingsToDeployment(shardNum int, dep *appsv1.Deployment) {
+	dep.Name = fmt.Sprintf("%s-%d", dep.Name, shardNum)
+	dep.Spec.Selector.MatchLabels["shard-num"] = strconv.Itoa(shardNum)
+	dep.Spec.Template.Labels["shard-num"] = strconv.Itoa(shardNum)
 }
 
 // newDeployForCR returns a busybox pod with the same name/namespace as the cr
@@ -998,6 +1083,109 @@ func discoverNotifierIfNeeded(ctx context.Context, rclient client.Client, cr *vm
 	return nil
 }
 
+// createOrUpdateNetworkPolicy allows access to vmalert service ports
+// and restricts egress to datasource, remote storage and notifiers ports
+func createOrUpdateNetworkPolicy(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlert, svc *corev1.Service) error {
+	peers, err := build.NetworkPolicyScrapePeers(ctx, rclient, cr.Spec.NetworkPolicy, cr.Namespace)
+	if err != nil {
+		return err
+	}
+	urls, err := networkPolicyEgressURLs(ctx, rclient, cr)
+	if err != nil {
+		return err
+	}
+	egressPorts, err := build.NetworkPolicyPortsFromURLs(urls...)
+	if err != nil {
+		return vmv1beta1.NewConfigError(fmt.Errorf("cannot build network policy for vmalert: %w", err))
+	}
+	rules := &build.NetworkPolicyRules{
+		Ports:  build.NetworkPolicyPortsFromService(svc),
+		Peers:  peers,
+		Egress: []networkingv1.NetworkPolicyEgressRule{{Ports: egressPorts}},
+	}
+	if err := reconcile.NetworkPolicy(ctx, rclient, build.NetworkPolicy(cr, cr.Spec.NetworkPolicy, rules)); err != nil {
+		return fmt.Errorf("cannot update network policy for vmalert: %w", err)
+	}
+	return nil
+}
+
+// networkPolicyEgressURLs returns urls of vmalert destinations
+// notifiers discovered by selectors are already resolved into urls
+func networkPolicyEgressURLs(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlert) ([]string, error) {
+	urls := []string{cr.Spec.Datasource.URL}
+	if cr.Spec.RemoteWrite != nil {
+		urls = append(urls, cr.Spec.RemoteWrite.URL)
+	}
+	if cr.Spec.RemoteRead != nil {
+		urls = append(urls, cr.Spec.RemoteRead.URL)
+	}
+	for _, n := range cr.Spec.Notifiers {
+		urls = append(urls, n.URL)
+	}
+	// flags with urls could be overridden or added with extraArgs, e.g. notifier.url or datasource.oauth2.tokenUrl
+	var urlFlags []string
+	for flag := range cr.Spec.ExtraArgs {
+		if strings.HasSuffix(strings.ToLower(flag), "url") {
+			urlFlags = append(urlFlags, flag)
+		}
+	}
+	sort.Strings(urlFlags)
+	for _, flag := range urlFlags {
+		for _, u := range strings.Split(cr.Spec.ExtraArgs[flag], ",") {
+			urls = append(urls, strings.TrimSpace(u))
+		}
+	}
+	if cr.Spec.NotifierConfigRef != nil {
+		data, err := k8stools.GetCredFromSecret(ctx, rclient, cr.Namespace, cr.Spec.NotifierConfigRef, cr.Spec.NotifierConfigRef.Name, map[string]*corev1.Secret{})
+		if err != nil {
+			return nil, fmt.Errorf("cannot fetch notifier config for network policy: %w", err)
+		}
+		notifierURLs, err := notifierConfigURLs(data)
+		if err != nil {
+			return nil, vmv1beta1.NewConfigError(fmt.Errorf("cannot parse notifier config from secret=%q: %w", cr.Spec.NotifierConfigRef.Name, err))
+		}
+		urls = append(urls, notifierURLs...)
+	}
+	return urls, nil
+}
+
+// notifierConfig defines fields of vmalert notifier config with known destination ports
+// see https://docs.victoriametrics.com/victoriametrics/vmalert/#notifier-configuration-file
+type notifierConfig struct {
+	Scheme        string `json:"scheme,omitempty"`
+	StaticConfigs []struct {
+		Targets []string `json:"targets,omitempty"`
+	} `json:"static_configs,omitempty"`
+	DNSSDConfigs []struct {
+		Port *int `json:"port,omitempty"`
+	} `json:"dns_sd_configs,omitempty"`
+}
+
+// notifierConfigURLs returns urls of static targets and dns discovered targets with defined port
+// ports of consul targets and SRV records are unknown and must be allowed with extraEgress
+func notifierConfigURLs(data string) ([]string, error) {
+	var nc notifierConfig
+	if err := yaml.Unmarshal([]byte(data), &nc); err != nil {
+		return nil, err
+	}
+	scheme := nc.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	var urls []string
+	for _, sc := range nc.StaticConfigs {
+		for _, target := range sc.Targets {
+			urls = append(urls, fmt.Sprintf("%s://%s", scheme, target))
+		}
+	}
+	for _, dc := range nc.DNSSDConfigs {
+		if dc.Port != nil {
+			urls = append(urls, fmt.Sprintf("%s://dns:%d", scheme, *dc.Port))
+		}
+	}
+	return urls, nil
+}
+
 func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VMAlert, rclient client.Client) error {
 	if cr.ParsedLastAppliedSpec == nil {
 		return nil
@@ -1013,6 +1201,12 @@ func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VMAlert, rclien
 			return fmt.Errorf("cannot delete PDB from prev state: %w", err)
 		}
 	}
+	if err := reconcile.RemoveOrphanedNetworkPolicy(ctx, rclient, cr.PrefixedName(), cr.Namespace, cr.ParsedLastAppliedSpec.NetworkPolicy, cr.Spec.NetworkPolicy); err != nil {
+		return err
+	}
+	if err := reconcile.RemoveOrphanedVPA(ctx, rclient, cr.PrefixedName(), cr.Namespace, cr.ParsedLastAppliedSpec.VPA, cr.Spec.VPA); err != nil {
+		return err
+	}
 
 	if ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) && !ptr.Deref(cr.ParsedLastAppliedSpec.DisableSelfServiceScrape, false) {
 		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMServiceScrape{ObjectMeta: objMeta}); err != nil {
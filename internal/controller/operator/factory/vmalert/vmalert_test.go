@@ -16,6 +16,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -138,7 +139,7 @@ func TestCreateOrUpdateVMAlert(t *testing.T) {
 	type args struct {
 		cr      *vmv1beta1.VMAlert
 		c       *config.BaseOperatorConf
-		cmNames []string
+		cmNames [][]string
 	}
 	tests := []struct {
 		name              string
@@ -580,6 +581,86 @@ func TestCreateOrUpdateVMAlert(t *testing.T) {
 	}
 }
 
+func TestCreateOrUpdateVMAlertSharded(t *testing.T) {
+	readyDeploy := func(name string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Status: appsv1.DeploymentStatus{
+				Conditions: []appsv1.DeploymentCondition{
+					{Reason: "NewReplicaSetAvailable", Type: appsv1.DeploymentProgressing, Status: "True"},
+				},
+			},
+		}
+	}
+	rulesCM := func(name string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				Labels:    map[string]string{"vmalert-name": "sharded", "managed-by": "vm-operator"},
+			},
+		}
+	}
+	cr := &vmv1beta1.VMAlert{
+		ObjectMeta: metav1.ObjectMeta{Name: "sharded", Namespace: "default"},
+		Spec: vmv1beta1.VMAlertSpec{
+			SelectAllByDefault: true,
+			ShardCount:         ptr.To(2),
+			Notifier:           &vmv1beta1.VMAlertNotifierSpec{URL: "http://some-alertmanager"},
+			Datasource:         vmv1beta1.VMAlertDatasourceSpec{URL: "http://some-vm-datasource"},
+		},
+	}
+	ctx := context.Background()
+	fclient := k8stools.GetTestClientWithObjects([]runtime.Object{
+		readyDeploy("vmalert-sharded"),
+		readyDeploy("vmalert-sharded-0"),
+		readyDeploy("vmalert-sharded-1"),
+		rulesCM("vm-sharded-rulefiles-0"),
+		rulesCM("vm-sharded-rulefiles-shard-0-0"),
+		rulesCM("vm-sharded-rulefiles-shard-1-0"),
+	})
+	// mark previous non-sharded deployment as owned by vmalert
+	var prevDeploy appsv1.Deployment
+	if err := fclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "vmalert-sharded"}, &prevDeploy); err != nil {
+		t.Fatalf("cannot get deployment: %s", err)
+	}
+	prevDeploy.Labels = cr.SelectorLabels()
+	if err := fclient.Update(ctx, &prevDeploy); err != nil {
+		t.Fatalf("cannot update deployment: %s", err)
+	}
+
+	cmNames := [][]string{{"vm-sharded-rulefiles-shard-0-0"}, {"vm-sharded-rulefiles-shard-1-0"}}
+	if err := CreateOrUpdateVMAlert(ctx, cr, fclient, cmNames); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for shardNum, names := range cmNames {
+		var dep appsv1.Deployment
+		if err := fclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: fmt.Sprintf("vmalert-sharded-%d", shardNum)}, &dep); err != nil {
+			t.Fatalf("cannot get shard deployment: %s", err)
+		}
+		assert.Equal(t, fmt.Sprintf("%d", shardNum), dep.Spec.Selector.MatchLabels["shard-num"])
+		assert.Equal(t, fmt.Sprintf("%d", shardNum), dep.Spec.Template.Labels["shard-num"])
+		assert.Contains(t, dep.Spec.Template.Spec.Containers[0].Args, fmt.Sprintf("-rule=%q", "/etc/vmalert/config/"+names[0]+"/*.yaml"))
+		for _, vol := range dep.Spec.Template.Spec.Volumes {
+			if vol.ConfigMap != nil && strings.HasPrefix(vol.ConfigMap.Name, "vm-sharded-rulefiles") {
+				assert.Equal(t, names[0], vol.ConfigMap.Name)
+			}
+		}
+	}
+
+	// non-sharded deployment and rules configmaps are removed
+	var deploys appsv1.DeploymentList
+	if err := fclient.List(ctx, &deploys); err != nil {
+		t.Fatalf("cannot list deployments: %s", err)
+	}
+	assert.Len(t, deploys.Items, 2)
+	var cms corev1.ConfigMapList
+	if err := fclient.List(ctx, &cms, cr.RulesConfigMapSelector()); err != nil {
+		t.Fatalf("cannot list configmaps: %s", err)
+	}
+	assert.Len(t, cms.Items, 2)
+}
+
 func TestBuildNotifiers(t *testing.T) {
 	type args struct {
 		cr          *vmv1beta1.VMAlert
@@ -779,3 +860,64 @@ func Test_buildVMAlertArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestNetworkPolicyEgressURLs(t *testing.T) {
+	f := func(cr *vmv1beta1.VMAlert, predefinedObjects []runtime.Object, want []string) {
+		t.Helper()
+		fclient := k8stools.GetTestClientWithObjects(predefinedObjects)
+		got, err := networkPolicyEgressURLs(context.TODO(), fclient, cr)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		assert.Equal(t, want, got)
+	}
+
+	// urls from spec and extraArgs
+	f(&vmv1beta1.VMAlert{
+		ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+		Spec: vmv1beta1.VMAlertSpec{
+			Datasource: vmv1beta1.VMAlertDatasourceSpec{URL: "http://vmselect:8481"},
+			Notifiers:  []vmv1beta1.VMAlertNotifierSpec{{URL: "http://alertmanager:9093"}},
+			CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+				ExtraArgs: map[string]string{
+					"notifier.url":               "http://am-0:9094, https://am-1",
+					"datasource.oauth2.tokenUrl": "https://auth:8443/token",
+					"rule.evalDelay":             "30s",
+				},
+			},
+		},
+	}, nil, []string{
+		"http://vmselect:8481",
+		"http://alertmanager:9093",
+		"https://auth:8443/token",
+		"http://am-0:9094",
+		"https://am-1",
+	})
+
+	// notifiers from notifier config
+	f(&vmv1beta1.VMAlert{
+		ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+		Spec: vmv1beta1.VMAlertSpec{
+			Datasource:        vmv1beta1.VMAlertDatasourceSpec{URL: "http://vmselect:8481"},
+			NotifierConfigRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "notifier"}, Key: "config.yaml"},
+		},
+	}, []runtime.Object{
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "notifier", Namespace: "default"},
+			Data: map[string][]byte{"config.yaml": []byte(`
+scheme: https
+static_configs:
+- targets: [am-0:9093, am-1]
+dns_sd_configs:
+- names: [alertmanager.default.svc]
+  type: A
+  port: 9095
+`)},
+		},
+	}, []string{
+		"http://vmselect:8481",
+		"https://am-0:9093",
+		"https://am-1",
+		"https://dns:9095",
+	})
+}
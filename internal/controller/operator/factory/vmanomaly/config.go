@@ -0,0 +1,310 @@
+package vmanomaly
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	configDir         = "/etc/vmanomaly/config"
+	defaultConfigKey  = "config.yaml"
+	defaultTenantID   = "0"
+	readerDatasource  = "reader"
+	writerDatasource  = "writer"
+	vmDatasourceClass = "vm"
+)
+
+func shardConfigKey(shardNum string) string {
+	return fmt.Sprintf("config_%s.yaml", shardNum)
+}
+
+// buildConfigSecretData renders vmanomaly configuration
+// and returns it with tls assets as content of the config secret
+func buildConfigSecretData(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAnomaly) (map[string][]byte, error) {
+	var base yaml.MapSlice
+	if len(cr.Spec.ConfigRawYaml) > 0 {
+		if err := yaml.Unmarshal([]byte(cr.Spec.ConfigRawYaml), &base); err != nil {
+			return nil, fmt.Errorf("cannot parse configRawYaml: %w", err)
+		}
+	}
+	cb := build.TLSConfigBuilder{
+		Ctx:                ctx,
+		Client:             rclient,
+		CurrentCRName:      cr.Name,
+		CurrentCRNamespace: cr.Namespace,
+		SecretCache:        make(map[string]*corev1.Secret),
+		ConfigmapCache:     make(map[string]*corev1.ConfigMap),
+		TLSAssets:          make(map[string]string),
+	}
+
+	reader, err := buildReaderConfig(ctx, rclient, cr, &cb)
+	if err != nil {
+		return nil, fmt.Errorf("cannot build reader config: %w", err)
+	}
+	writer, err := buildWriterConfig(ctx, rclient, cr, &cb)
+	if err != nil {
+		return nil, fmt.Errorf("cannot build writer config: %w", err)
+	}
+	schedulers, err := jsonSectionsToYAML(cr.Spec.Schedulers)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse schedulers: %w", err)
+	}
+
+	base = setSection(base, "reader", reader)
+	base = setSection(base, "writer", writer)
+	if len(schedulers) > 0 {
+		base = setSection(base, "schedulers", schedulers)
+	}
+	base = setSection(base, "monitoring", yaml.MapSlice{
+		{Key: "pull", Value: yaml.MapSlice{{Key: "port", Value: cr.Spec.Port}}},
+	})
+
+	data := make(map[string][]byte)
+	shardedModels, err := splitModelsByShards(cr)
+	if err != nil {
+		return nil, err
+	}
+	for shardNum, models := range shardedModels {
+		cfg := base
+		if len(models) > 0 {
+			cfg = setSection(append(yaml.MapSlice{}, base...), "models", models)
+		}
+		rendered, err := yaml.Marshal(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("cannot serialize vmanomaly config: %w", err)
+		}
+		key := defaultConfigKey
+		if cr.IsSharded() {
+			key = shardConfigKey(strconv.Itoa(shardNum))
+		}
+		data[key] = rendered
+	}
+	for assetKey, value := range cb.TLSAssets {
+		data[assetKey] = []byte(value)
+	}
+	return data, nil
+}
+
+// splitModelsByShards distributes models across shards by sorted model alias
+func splitModelsByShards(cr *vmv1beta1.VMAnomaly) ([]yaml.MapSlice, error) {
+	shardCount := cr.GetShardCount()
+	if cr.IsSharded() && shardCount > len(cr.Spec.Models) {
+		return nil, fmt.Errorf("shardCount=%d cannot be greater than number of models=%d", shardCount, len(cr.Spec.Models))
+	}
+	models, err := jsonSectionsToYAML(cr.Spec.Models)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse models: %w", err)
+	}
+	shards := make([]yaml.MapSlice, shardCount)
+	for idx, model := range models {
+		shardNum := idx % shardCount
+		shards[shardNum] = append(shards[shardNum], model)
+	}
+	return shards, nil
+}
+
+// jsonSectionsToYAML converts raw json sections into yaml items sorted by alias
+func jsonSectionsToYAML(src map[string]apiextensionsv1.JSON) (yaml.MapSlice, error) {
+	aliases := make([]string, 0, len(src))
+	for alias := range src {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	dst := make(yaml.MapSlice, 0, len(aliases))
+	for _, alias := range aliases {
+		var value yaml.MapSlice
+		if err := yaml.Unmarshal(src[alias].Raw, &value); err != nil {
+			return nil, fmt.Errorf("cannot parse section=%q: %w", alias, err)
+		}
+		dst = append(dst, yaml.MapItem{Key: alias, Value: value})
+	}
+	return dst, nil
+}
+
+func setSection(dst yaml.MapSlice, key string, value interface{}) yaml.MapSlice {
+	for i := range dst {
+		if dst[i].Key == key {
+			dst[i].Value = value
+			return dst
+		}
+	}
+	return append(dst, yaml.MapItem{Key: key, Value: value})
+}
+
+func buildReaderConfig(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAnomaly, cb *build.TLSConfigBuilder) (yaml.MapSlice, error) {
+	spec := cr.Spec.Reader
+	cfg, err := buildDatasourceConfig(ctx, rclient, cr, &spec.VMAnomalyDatasource, readerDatasource, cb)
+	if err != nil {
+		return nil, err
+	}
+	cfg = append(cfg, yaml.MapItem{Key: "sampling_period", Value: spec.SamplingPeriod})
+	if len(spec.QueryRangePath) > 0 {
+		cfg = append(cfg, yaml.MapItem{Key: "query_range_path", Value: spec.QueryRangePath})
+	}
+	aliases := make([]string, 0, len(spec.Queries))
+	for alias := range spec.Queries {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	var queries yaml.MapSlice
+	for _, alias := range aliases {
+		q := spec.Queries[alias]
+		query := yaml.MapSlice{{Key: "expr", Value: q.Expr}}
+		if len(q.Step) > 0 {
+			query = append(query, yaml.MapItem{Key: "step", Value: q.Step})
+		}
+		if len(q.TenantID) > 0 {
+			query = append(query, yaml.MapItem{Key: "tenant_id", Value: q.TenantID})
+		}
+		queries = append(queries, yaml.MapItem{Key: alias, Value: query})
+	}
+	cfg = append(cfg, yaml.MapItem{Key: "queries", Value: queries})
+	return cfg, nil
+}
+
+func buildWriterConfig(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAnomaly, cb *build.TLSConfigBuilder) (yaml.MapSlice, error) {
+	spec := cr.Spec.Writer
+	cfg, err := buildDatasourceConfig(ctx, rclient, cr, &spec.VMAnomalyDatasource, writerDatasource, cb)
+	if err != nil {
+		return nil, err
+	}
+	if len(spec.MetricFormat) > 0 {
+		keys := make([]string, 0, len(spec.MetricFormat))
+		for k := range spec.MetricFormat {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var mf yaml.MapSlice
+		for _, k := range keys {
+			mf = append(mf, yaml.MapItem{Key: k, Value: spec.MetricFormat[k]})
+		}
+		cfg = append(cfg, yaml.MapItem{Key: "metric_format", Value: mf})
+	}
+	return cfg, nil
+}
+
+func buildDatasourceConfig(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAnomaly, ds *vmv1beta1.VMAnomalyDatasource, source string, cb *build.TLSConfigBuilder) (yaml.MapSlice, error) {
+	url := ds.DatasourceURL
+	tenantID := ds.TenantID
+	switch {
+	case ds.DatasourceRef != nil && len(url) > 0:
+		return nil, fmt.Errorf("only one of datasourceURL or datasourceRef could be set for %s", source)
+	case ds.DatasourceRef != nil:
+		refURL, isCluster, err := fetchDatasourceRefURL(ctx, rclient, cr, ds.DatasourceRef, source)
+		if err != nil {
+			return nil, err
+		}
+		url = refURL
+		if isCluster && len(tenantID) == 0 {
+			tenantID = defaultTenantID
+		}
+	case len(url) == 0:
+		return nil, fmt.Errorf("one of datasourceURL or datasourceRef must be set for %s", source)
+	}
+	cfg := yaml.MapSlice{
+		{Key: "class", Value: vmDatasourceClass},
+		{Key: "datasource_url", Value: url},
+	}
+	if len(tenantID) > 0 {
+		cfg = append(cfg, yaml.MapItem{Key: "tenant_id", Value: tenantID})
+	}
+	if len(ds.Timeout) > 0 {
+		cfg = append(cfg, yaml.MapItem{Key: "timeout", Value: ds.Timeout})
+	}
+	if ds.BasicAuth != nil {
+		creds, err := k8stools.LoadBasicAuthSecret(ctx, rclient, cr.Namespace, ds.BasicAuth, cb.SecretCache)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load basicAuth for %s: %w", source, err)
+		}
+		cfg = append(cfg, yaml.MapItem{Key: "user", Value: creds.Username})
+		if len(creds.Password) > 0 {
+			cfg = append(cfg, yaml.MapItem{Key: "password", Value: creds.Password})
+		}
+	}
+	if ds.BearerAuth != nil && ds.BearerAuth.TokenSecret != nil {
+		token, err := k8stools.GetCredFromSecret(ctx, rclient, cr.Namespace, ds.BearerAuth.TokenSecret, fmt.Sprintf("%s/%s", cr.Namespace, ds.BearerAuth.TokenSecret.Name), cb.SecretCache)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load bearer token for %s: %w", source, err)
+		}
+		cfg = append(cfg, yaml.MapItem{Key: "bearer_token", Value: token})
+	}
+	if ds.TLSConfig != nil {
+		tlsCfg, err := cb.BuildTLSConfig(ds.TLSConfig, configDir)
+		if err != nil {
+			return nil, fmt.Errorf("cannot build tls config for %s: %w", source, err)
+		}
+		switch {
+		case ds.TLSConfig.InsecureSkipVerify:
+			cfg = append(cfg, yaml.MapItem{Key: "verify_tls", Value: false})
+		case tlsCfg["ca_file"] != nil:
+			cfg = append(cfg, yaml.MapItem{Key: "verify_tls", Value: tlsCfg["ca_file"]})
+		}
+		if v, ok := tlsCfg["cert_file"]; ok {
+			cfg = append(cfg, yaml.MapItem{Key: "tls_cert_file", Value: v})
+		}
+		if v, ok := tlsCfg["key_file"]; ok {
+			cfg = append(cfg, yaml.MapItem{Key: "tls_key_file", Value: v})
+		}
+	}
+	return cfg, nil
+}
+
+// fetchDatasourceRefURL returns url of referenced CRD object
+// and flag if it's a cluster version of VictoriaMetrics
+func fetchDatasourceRefURL(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAnomaly, ref *vmv1beta1.CRDRef, source string) (string, bool, error) {
+	nsn := types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}
+	if len(nsn.Namespace) == 0 {
+		nsn.Namespace = cr.Namespace
+	}
+	var url string
+	var isCluster bool
+	getObj := func(obj client.Object) error {
+		if err := rclient.Get(ctx, nsn, obj); err != nil {
+			if errors.IsNotFound(err) {
+				return fmt.Errorf("cannot find %s datasourceRef object kind=%q,namespace=%q,name=%q: %w", source, ref.Kind, nsn.Namespace, nsn.Name, err)
+			}
+			return fmt.Errorf("cannot get %s datasourceRef object kind=%q,namespace=%q,name=%q: %w", source, ref.Kind, nsn.Namespace, nsn.Name, err)
+		}
+		return nil
+	}
+	switch {
+	case ref.Kind == "VMSingle":
+		var vms vmv1beta1.VMSingle
+		if err := getObj(&vms); err != nil {
+			return "", false, err
+		}
+		url = vms.AsURL()
+	case ref.Kind == "VMCluster/vmselect" && source == readerDatasource:
+		var vmc vmv1beta1.VMCluster
+		if err := getObj(&vmc); err != nil {
+			return "", false, err
+		}
+		url = vmc.VMSelectURL()
+		isCluster = true
+	case ref.Kind == "VMCluster/vminsert" && source == writerDatasource:
+		var vmc vmv1beta1.VMCluster
+		if err := getObj(&vmc); err != nil {
+			return "", false, err
+		}
+		url = vmc.VMInsertURL()
+		isCluster = true
+	default:
+		return "", false, fmt.Errorf("unsupported kind=%q for %s datasourceRef", ref.Kind, source)
+	}
+	if len(url) == 0 {
+		return "", false, fmt.Errorf("%s datasourceRef kind=%q,namespace=%q,name=%q has no url", source, ref.Kind, nsn.Namespace, nsn.Name)
+	}
+	return url, isCluster, nil
+}
@@ -0,0 +1,407 @@
+package vmanomaly
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"path"
+	"sort"
+	"strconv"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	shardNumPlaceholder = "%SHARD_NUM%"
+	configVolumeName    = "config"
+	// configHashAnnotation holds hash of config secret content at pod template
+	// vmanomaly doesn't support config hot reload, so pods must be restarted on config change
+	configHashAnnotation = "operator.victoriametrics.com/config-hash"
+)
+
+// CreateOrUpdateVMAnomaly creates vmanomaly deployments for given CRD
+func CreateOrUpdateVMAnomaly(ctx context.Context, cr *vmv1beta1.VMAnomaly, rclient client.Client) error {
+	if err := deletePrevStateResources(ctx, cr, rclient); err != nil {
+		return fmt.Errorf("cannot delete objects from previous state: %w", err)
+	}
+	if !cr.Spec.License.IsProvided() {
+		return vmv1beta1.NewConfigError(fmt.Errorf("license must be provided for vmanomaly"))
+	}
+	if cr.IsOwnsServiceAccount() {
+		if err := reconcile.ServiceAccount(ctx, rclient, build.ServiceAccount(cr)); err != nil {
+			return fmt.Errorf("failed create service account: %w", err)
+		}
+	}
+	configHash, err := createOrUpdateConfigSecret(ctx, rclient, cr)
+	if err != nil {
+		return err
+	}
+
+	svc, err := createOrUpdateService(ctx, rclient, cr)
+	if err != nil {
+		return err
+	}
+	if !ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) {
+		err := reconcile.VMServiceScrapeForCRD(ctx, rclient, build.VMServiceScrapeForServiceWithSpec(svc, cr))
+		if err != nil {
+			return fmt.Errorf("cannot create vmservicescrape: %w", err)
+		}
+	}
+	if cr.Spec.PodDisruptionBudget != nil {
+		if err := reconcile.PDB(ctx, rclient, build.PodDisruptionBudget(cr, cr.Spec.PodDisruptionBudget)); err != nil {
+			return fmt.Errorf("cannot update pod disruption budget for vmanomaly: %w", err)
+		}
+	}
+
+	var prevDeploy *appsv1.Deployment
+	if cr.ParsedLastAppliedSpec != nil {
+		prevCR := cr.DeepCopy()
+		prevCR.Spec = *cr.ParsedLastAppliedSpec
+		prevDeploy, err = newDeployForVMAnomaly(prevCR)
+		if err != nil {
+			return fmt.Errorf("cannot generate prev deploy spec: %w", err)
+		}
+	}
+	newDeploy, err := newDeployForVMAnomaly(cr)
+	if err != nil {
+		return vmv1beta1.NewConfigError(fmt.Errorf("cannot generate new deploy for vmanomaly: %w", err))
+	}
+	newDeploy.Spec.Template.Annotations = labels.Merge(newDeploy.Spec.Template.Annotations, map[string]string{configHashAnnotation: configHash})
+
+	deploymentNames := make(map[string]struct{})
+	shardsCount := cr.GetShardCount()
+	if cr.IsSharded() {
+		logger.WithContext(ctx).Info("using sharded version of VMAnomaly with", "shards", shardsCount)
+	}
+	for shardNum := 0; shardNum < shardsCount; shardNum++ {
+		placeholders := map[string]string{shardNumPlaceholder: strconv.Itoa(shardNum)}
+		shardedDeploy := newDeploy.DeepCopy()
+		if cr.IsSharded() {
+			addShardSettingsToDeployment(shardNum, shardedDeploy)
+		}
+		shardedDeploy, err = k8stools.RenderPlaceholders(shardedDeploy, placeholders)
+		if err != nil {
+			return fmt.Errorf("cannot fill placeholders for vmanomaly deployment: %w", err)
+		}
+		var prevShardedDeploy *appsv1.Deployment
+		if prevDeploy != nil {
+			prevShardedDeploy = prevDeploy.DeepCopy()
+			if cr.ParsedLastAppliedSpec != nil && ptr.Deref(cr.ParsedLastAppliedSpec.ShardCount, 0) > 1 {
+				addShardSettingsToDeployment(shardNum, prevShardedDeploy)
+			}
+			prevShardedDeploy, err = k8stools.RenderPlaceholders(prevShardedDeploy, placeholders)
+			if err != nil {
+				return fmt.Errorf("cannot fill placeholders for prev vmanomaly deployment: %w", err)
+			}
+		}
+		if err := reconcile.Deployment(ctx, rclient, shardedDeploy, prevShardedDeploy, false); err != nil {
+			return err
+		}
+		deploymentNames[shardedDeploy.Name] = struct{}{}
+	}
+	if err := finalize.RemoveOrphanedDeployments(ctx, rclient, cr, deploymentNames); err != nil {
+		return err
+	}
+	if err := createOrUpdateVPAs(ctx, rclient, cr, deploymentNames); err != nil {
+		return err
+	}
+	return nil
+}
+
+// createOrUpdateVPAs reconciles VerticalPodAutoscaler per each vmanomaly shard
+// and removes autoscalers of shards, which are no longer exist
+func createOrUpdateVPAs(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAnomaly, deploymentNames map[string]struct{}) error {
+	var prevVPA *vmv1beta1.EmbeddedVPA
+	if cr.ParsedLastAppliedSpec != nil {
+		prevVPA = cr.ParsedLastAppliedSpec.VPA
+	}
+	if cr.Spec.VPA == nil && prevVPA == nil {
+		return nil
+	}
+	vpaNames := make(map[string]struct{})
+	if cr.Spec.VPA != nil {
+		for name := range deploymentNames {
+			targetRef := autoscalingv1.CrossVersionObjectReference{
+				Name:       name,
+				Kind:       "Deployment",
+				APIVersion: "apps/v1",
+			}
+			if err := reconcile.VPA(ctx, rclient, build.VPA(targetRef, "vmanomaly", cr.Spec.VPA, cr.AsOwner(), cr.SelectorLabels(), cr.Namespace)); err != nil {
+				return fmt.Errorf("cannot reconcile VPA for vmanomaly: %w", err)
+			}
+			vpaNames[name] = struct{}{}
+		}
+	}
+	if err := finalize.RemoveOrphanedVPAs(ctx, rclient, cr, vpaNames); err != nil {
+		return fmt.Errorf("cannot remove orphaned VPA for vmanomaly: %w", err)
+	}
+	return nil
+}
+
+// createOrUpdateConfigSecret reconciles vmanomaly config secret and returns hash of its content
+func createOrUpdateConfigSecret(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAnomaly) (string, error) {
+	data, err := buildConfigSecretData(ctx, rclient, cr)
+	if err != nil {
+		return "", vmv1beta1.NewConfigError(fmt.Errorf("cannot build vmanomaly config: %w", err))
+	}
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cr.PrefixedName(),
+			Annotations:     cr.AnnotationsFiltered(),
+			Labels:          cr.AllLabels(),
+			Namespace:       cr.Namespace,
+			OwnerReferences: cr.AsOwner(),
+			Finalizers:      []string{vmv1beta1.FinalizerName},
+		},
+		Data: data,
+	}
+	if err := reconcile.Secret(ctx, rclient, s); err != nil {
+		return "", err
+	}
+	return configDataHash(data), nil
+}
+
+// configDataHash returns hash of the given secret data, which doesn't depend on keys order
+func configDataHash(data map[string][]byte) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	h := fnv.New64a()
+	for _, key := range keys {
+		h.Write([]byte(key))
+		h.Write(data[key])
+	}
+	return strconv.FormatUint(h.Sum64(), 10)
+}
+
+// createOrUpdateService creates service for vmanomaly
+func createOrUpdateService(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAnomaly) (*corev1.Service, error) {
+	newService := build.Service(cr, cr.Spec.Port, nil)
+	if err := cr.Spec.ServiceSpec.IsSomeAndThen(func(s *vmv1beta1.AdditionalServiceSpec) error {
+		additionalSvc := build.AdditionalServiceFromDefault(newService, s)
+		if additionalSvc.Name == newService.Name {
+			logger.WithContext(ctx).Error(fmt.Errorf("vmanomaly additional service name: %q cannot be the same as crd.prefixedname: %q", additionalSvc.Name, cr.PrefixedName()), "cannot create additional service")
+		} else if err := reconcile.Service(ctx, rclient, additionalSvc, nil); err != nil {
+			return fmt.Errorf("cannot reconcile additional service for vmanomaly: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	var prevService *corev1.Service
+	if cr.ParsedLastAppliedSpec != nil {
+		prevCR := cr.DeepCopy()
+		prevCR.Spec = *cr.ParsedLastAppliedSpec
+		prevService = build.Service(prevCR, prevCR.Spec.Port, nil)
+	}
+	if err := reconcile.Service(ctx, rclient, newService, prevService); err != nil {
+		return nil, fmt.Errorf("cannot reconcile service for vmanomaly: %w", err)
+	}
+	return newService, nil
+}
+
+func newDeployForVMAnomaly(cr *vmv1beta1.VMAnomaly) (*appsv1.Deployment, error) {
+	podSpec, err := newPodSpecForVMAnomaly(cr)
+	if err != nil {
+		return nil, err
+	}
+	strategyType := appsv1.RollingUpdateDeploymentStrategyType
+	if cr.Spec.UpdateStrategy != nil {
+		strategyType = *cr.Spec.UpdateStrategy
+	}
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cr.PrefixedName(),
+			Namespace:       cr.Namespace,
+			Labels:          cr.AllLabels(),
+			Annotations:     cr.AnnotationsFiltered(),
+			OwnerReferences: cr.AsOwner(),
+			Finalizers:      []string{vmv1beta1.FinalizerName},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: cr.SelectorLabels(),
+			},
+			Strategy: appsv1.DeploymentStrategy{
+				Type:          strategyType,
+				RollingUpdate: cr.Spec.RollingUpdate,
+			},
+			Template: *podSpec,
+		},
+	}
+	build.DeploymentAddCommonParams(deploy, ptr.Deref(cr.Spec.UseStrictSecurity, false), &cr.Spec.CommonApplicationDeploymentParams)
+	return deploy, nil
+}
+
+func buildArgs(cr *vmv1beta1.VMAnomaly) []string {
+	var args []string
+	if cr.Spec.LogLevel != "" {
+		args = append(args, fmt.Sprintf("--loggerLevel=%s", cr.Spec.LogLevel))
+	}
+	// vmanomaly uses double dash prefix for flags
+	for _, arg := range cr.Spec.License.MaybeAddToArgs(nil, vmv1beta1.SecretsDir) {
+		args = append(args, "-"+arg)
+	}
+	args = build.AddExtraArgsOverrideDefaults(args, cr.Spec.ExtraArgs, "--")
+	sort.Strings(args)
+
+	configKey := defaultConfigKey
+	if cr.IsSharded() {
+		configKey = shardConfigKey(shardNumPlaceholder)
+	}
+	// config file is a positional argument and must go first
+	return append([]string{path.Join(configDir, configKey)}, args...)
+}
+
+func newPodSpecForVMAnomaly(cr *vmv1beta1.VMAnomaly) (*corev1.PodTemplateSpec, error) {
+	args := buildArgs(cr)
+
+	var volumes []corev1.Volume
+	volumes = append(volumes, cr.Spec.Volumes...)
+	volumes = append(volumes, corev1.Volume{
+		Name: configVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: cr.PrefixedName(),
+			},
+		},
+	})
+	var volumeMounts []corev1.VolumeMount
+	volumeMounts = append(volumeMounts, cr.Spec.VolumeMounts...)
+	volumeMounts = append(volumeMounts, corev1.VolumeMount{
+		Name:      configVolumeName,
+		ReadOnly:  true,
+		MountPath: configDir,
+	})
+	volumes, volumeMounts = cr.Spec.License.MaybeAddToVolumes(volumes, volumeMounts, vmv1beta1.SecretsDir)
+
+	for _, s := range cr.Spec.Secrets {
+		volumes = append(volumes, corev1.Volume{
+			Name: k8stools.SanitizeVolumeName("secret-" + s),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: s,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      k8stools.SanitizeVolumeName("secret-" + s),
+			ReadOnly:  true,
+			MountPath: path.Join(vmv1beta1.SecretsDir, s),
+		})
+	}
+	for _, c := range cr.Spec.ConfigMaps {
+		volumes = append(volumes, corev1.Volume{
+			Name: k8stools.SanitizeVolumeName("configmap-" + c),
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: c,
+					},
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      k8stools.SanitizeVolumeName("configmap-" + c),
+			ReadOnly:  true,
+			MountPath: path.Join(vmv1beta1.ConfigMapsDir, c),
+		})
+	}
+
+	// sort for consistency
+	sort.Slice(volumes, func(i, j int) bool {
+		return volumes[i].Name < volumes[j].Name
+	})
+	sort.Slice(volumeMounts, func(i, j int) bool {
+		return volumeMounts[i].Name < volumeMounts[j].Name
+	})
+
+	ports := []corev1.ContainerPort{{Name: "http", Protocol: "TCP", ContainerPort: intstr.Parse(cr.Spec.Port).IntVal}}
+	container := corev1.Container{
+		Args:                     args,
+		Name:                     "vmanomaly",
+		Image:                    fmt.Sprintf("%s:%s", cr.Spec.Image.Repository, cr.Spec.Image.Tag),
+		ImagePullPolicy:          cr.Spec.Image.PullPolicy,
+		Ports:                    ports,
+		VolumeMounts:             volumeMounts,
+		Resources:                cr.Spec.Resources,
+		Env:                      cr.Spec.ExtraEnvs,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+	container = build.Probe(container, cr)
+	operatorContainers := []corev1.Container{container}
+
+	build.AddStrictSecuritySettingsToContainers(cr.Spec.SecurityContext, operatorContainers, ptr.Deref(cr.Spec.UseStrictSecurity, false))
+	containers, err := k8stools.MergePatchContainers(operatorContainers, cr.Spec.Containers)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range cr.Spec.TopologySpreadConstraints {
+		if cr.Spec.TopologySpreadConstraints[i].LabelSelector == nil {
+			cr.Spec.TopologySpreadConstraints[i].LabelSelector = &metav1.LabelSelector{
+				MatchLabels: cr.SelectorLabels(),
+			}
+		}
+	}
+
+	return &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      cr.PodLabels(),
+			Annotations: cr.PodAnnotations(),
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: cr.GetServiceAccountName(),
+			InitContainers:     cr.Spec.InitContainers,
+			Containers:         containers,
+			Volumes:            volumes,
+		},
+	}, nil
+}
+
+func addShardSettingsToDeployment(shardNum int, dep *appsv1.Deployment) {
+	dep.Name = fmt.Sprintf("%s-%d", dep.Name, shardNum)
+	dep.Spec.Selector.MatchLabels["shard-num"] = strconv.Itoa(shardNum)
+	dep.Spec.Template.Labels["shard-num"] = strconv.Itoa(shardNum)
+}
+
+func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VMAnomaly, rclient client.Client) error {
+	if cr.ParsedLastAppliedSpec == nil {
+		return nil
+	}
+	prevSvc, currSvc := cr.ParsedLastAppliedSpec.ServiceSpec, cr.Spec.ServiceSpec
+	if err := reconcile.AdditionalServices(ctx, rclient, cr.PrefixedName(), cr.Namespace, prevSvc, currSvc); err != nil {
+		return fmt.Errorf("cannot remove additional service: %w", err)
+	}
+
+	objMeta := metav1.ObjectMeta{Name: cr.PrefixedName(), Namespace: cr.Namespace}
+	if cr.Spec.PodDisruptionBudget == nil && cr.ParsedLastAppliedSpec.PodDisruptionBudget != nil {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &policyv1.PodDisruptionBudget{ObjectMeta: objMeta}); err != nil {
+			return fmt.Errorf("cannot delete PDB from prev state: %w", err)
+		}
+	}
+
+	if ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) && !ptr.Deref(cr.ParsedLastAppliedSpec.DisableSelfServiceScrape, false) {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMServiceScrape{ObjectMeta: objMeta}); err != nil {
+			return fmt.Errorf("cannot remove serviceScrape: %w", err)
+		}
+	}
+
+	return nil
+}
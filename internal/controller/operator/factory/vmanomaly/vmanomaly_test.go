@@ -0,0 +1,238 @@
+package vmanomaly
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCreateOrUpdateVMAnomaly(t *testing.T) {
+	f := func(cr *vmv1beta1.VMAnomaly, wantErr bool, validate func(rclient client.Client) error, predefinedObjects ...runtime.Object) {
+		t.Helper()
+		fclient := k8stools.GetTestClientWithObjects(predefinedObjects)
+		build.AddDefaults(fclient.Scheme())
+		fclient.Scheme().Default(cr)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var wg sync.WaitGroup
+		for i := 0; i < cr.GetShardCount(); i++ {
+			name := cr.PrefixedName()
+			if cr.IsSharded() {
+				name = fmt.Sprintf("%s-%d", name, i)
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				tc := time.NewTicker(time.Millisecond * 100)
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-tc.C:
+						var dep appsv1.Deployment
+						if err := fclient.Get(ctx, types.NamespacedName{Name: name, Namespace: cr.Namespace}, &dep); err != nil {
+							if errors.IsNotFound(err) {
+								continue
+							}
+							t.Errorf("cannot get deployment: %s", err)
+							return
+						}
+						dep.Status.Conditions = append(dep.Status.Conditions, appsv1.DeploymentCondition{
+							Type:   appsv1.DeploymentProgressing,
+							Reason: "NewReplicaSetAvailable",
+							Status: "True",
+						})
+						dep.Status.Replicas = ptr.Deref(dep.Spec.Replicas, 0)
+						dep.Status.UpdatedReplicas = dep.Status.Replicas
+						dep.Status.AvailableReplicas = dep.Status.Replicas
+						if err := fclient.Status().Update(ctx, &dep); err != nil {
+							t.Errorf("cannot update deployment status: %s", err)
+						}
+						return
+					}
+				}
+			}()
+		}
+		err := CreateOrUpdateVMAnomaly(ctx, cr, fclient)
+		cancel()
+		wg.Wait()
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+		if validate != nil {
+			if err := validate(fclient); err != nil {
+				t.Fatalf("validation failed: %s", err)
+			}
+		}
+	}
+	getSecret := func(rclient client.Client, name string) (*corev1.Secret, error) {
+		var s corev1.Secret
+		if err := rclient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: name}, &s); err != nil {
+			return nil, err
+		}
+		return &s, nil
+	}
+	model := func(src string) apiextensionsv1.JSON {
+		return apiextensionsv1.JSON{Raw: []byte(src)}
+	}
+
+	// missing license
+	f(&vmv1beta1.VMAnomaly{
+		ObjectMeta: metav1.ObjectMeta{Name: "base", Namespace: "default"},
+		Spec: vmv1beta1.VMAnomalySpec{
+			Reader: vmv1beta1.VMAnomalyReaderSpec{
+				VMAnomalyDatasource: vmv1beta1.VMAnomalyDatasource{DatasourceURL: "http://vmsingle:8429"},
+				SamplingPeriod:      "1m",
+			},
+			Writer: vmv1beta1.VMAnomalyWriterSpec{
+				VMAnomalyDatasource: vmv1beta1.VMAnomalyDatasource{DatasourceURL: "http://vmsingle:8429"},
+			},
+		},
+	}, true, nil)
+
+	// with vmsingle ref
+	f(&vmv1beta1.VMAnomaly{
+		ObjectMeta: metav1.ObjectMeta{Name: "base", Namespace: "default"},
+		Spec: vmv1beta1.VMAnomalySpec{
+			License: &vmv1beta1.License{Key: ptr.To("license-key")},
+			Reader: vmv1beta1.VMAnomalyReaderSpec{
+				VMAnomalyDatasource: vmv1beta1.VMAnomalyDatasource{
+					DatasourceRef: &vmv1beta1.CRDRef{Kind: "VMSingle", Name: "main"},
+				},
+				SamplingPeriod: "1m",
+				Queries: map[string]vmv1beta1.VMAnomalyQuerySpec{
+					"q1": {Expr: "sum(up)"},
+				},
+			},
+			Writer: vmv1beta1.VMAnomalyWriterSpec{
+				VMAnomalyDatasource: vmv1beta1.VMAnomalyDatasource{
+					DatasourceRef: &vmv1beta1.CRDRef{Kind: "VMSingle", Name: "main"},
+				},
+			},
+			Models: map[string]apiextensionsv1.JSON{
+				"zscore": model(`{"class":"zscore","z_threshold":2.5}`),
+			},
+		},
+	}, false, func(rclient client.Client) error {
+		s, err := getSecret(rclient, "vmanomaly-base")
+		if err != nil {
+			return err
+		}
+		cfg := string(s.Data["config.yaml"])
+		for _, want := range []string{
+			"datasource_url: http://vmsingle-main.default.svc:8429",
+			"sampling_period: 1m",
+			"expr: sum(up)",
+			"class: zscore",
+			"port: \"8490\"",
+		} {
+			if !strings.Contains(cfg, want) {
+				return fmt.Errorf("expected config to contain %q, got:\n%s", want, cfg)
+			}
+		}
+		if strings.Contains(cfg, "tenant_id") {
+			return fmt.Errorf("unexpected tenant_id for vmsingle datasource:\n%s", cfg)
+		}
+		var dep appsv1.Deployment
+		if err := rclient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "vmanomaly-base"}, &dep); err != nil {
+			return err
+		}
+		args := dep.Spec.Template.Spec.Containers[0].Args
+		wantArgs := []string{"/etc/vmanomaly/config/config.yaml", "--license=license-key"}
+		if strings.Join(args, ",") != strings.Join(wantArgs, ",") {
+			return fmt.Errorf("unexpected args, got: %v, want: %v", args, wantArgs)
+		}
+		// pods must be restarted on config change
+		if got, want := dep.Spec.Template.Annotations[configHashAnnotation], configDataHash(s.Data); got != want {
+			return fmt.Errorf("unexpected config hash annotation, got: %q, want: %q", got, want)
+		}
+		return nil
+	}, &vmv1beta1.VMSingle{ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"}})
+
+	// sharded with vmcluster ref
+	f(&vmv1beta1.VMAnomaly{
+		ObjectMeta: metav1.ObjectMeta{Name: "sharded", Namespace: "default"},
+		Spec: vmv1beta1.VMAnomalySpec{
+			License:    &vmv1beta1.License{Key: ptr.To("license-key")},
+			ShardCount: ptr.To(2),
+			Reader: vmv1beta1.VMAnomalyReaderSpec{
+				VMAnomalyDatasource: vmv1beta1.VMAnomalyDatasource{
+					DatasourceRef: &vmv1beta1.CRDRef{Kind: "VMCluster/vmselect", Name: "main"},
+				},
+				SamplingPeriod: "1m",
+				Queries: map[string]vmv1beta1.VMAnomalyQuerySpec{
+					"q1": {Expr: "sum(up)"},
+				},
+			},
+			Writer: vmv1beta1.VMAnomalyWriterSpec{
+				VMAnomalyDatasource: vmv1beta1.VMAnomalyDatasource{
+					DatasourceRef: &vmv1beta1.CRDRef{Kind: "VMCluster/vminsert", Name: "main"},
+					TenantID:      "1:1",
+				},
+			},
+			Models: map[string]apiextensionsv1.JSON{
+				"m1": model(`{"class":"zscore"}`),
+				"m2": model(`{"class":"mad"}`),
+				"m3": model(`{"class":"prophet"}`),
+			},
+		},
+	}, false, func(rclient client.Client) error {
+		s, err := getSecret(rclient, "vmanomaly-sharded")
+		if err != nil {
+			return err
+		}
+		shard0, shard1 := string(s.Data["config_0.yaml"]), string(s.Data["config_1.yaml"])
+		if !strings.Contains(shard0, "m1:") || !strings.Contains(shard0, "m3:") || strings.Contains(shard0, "m2:") {
+			return fmt.Errorf("unexpected models for shard 0:\n%s", shard0)
+		}
+		if !strings.Contains(shard1, "m2:") || strings.Contains(shard1, "m1:") {
+			return fmt.Errorf("unexpected models for shard 1:\n%s", shard1)
+		}
+		for _, want := range []string{
+			"datasource_url: http://vmselect-main.default.svc:8481",
+			"tenant_id: \"0\"",
+			"datasource_url: http://vminsert-main.default.svc:8480",
+			"tenant_id: \"1:1\"",
+		} {
+			if !strings.Contains(shard1, want) {
+				return fmt.Errorf("expected config to contain %q, got:\n%s", want, shard1)
+			}
+		}
+		for i := 0; i < 2; i++ {
+			var dep appsv1.Deployment
+			if err := rclient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: fmt.Sprintf("vmanomaly-sharded-%d", i)}, &dep); err != nil {
+				return err
+			}
+			wantConfig := fmt.Sprintf("/etc/vmanomaly/config/config_%d.yaml", i)
+			if dep.Spec.Template.Spec.Containers[0].Args[0] != wantConfig {
+				return fmt.Errorf("unexpected config path for shard %d, got: %v", i, dep.Spec.Template.Spec.Containers[0].Args)
+			}
+			if dep.Spec.Selector.MatchLabels["shard-num"] != fmt.Sprintf("%d", i) {
+				return fmt.Errorf("expected shard-num label at selector, got: %v", dep.Spec.Selector.MatchLabels)
+			}
+		}
+		return nil
+	}, &vmv1beta1.VMCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+		Spec: vmv1beta1.VMClusterSpec{
+			VMSelect: &vmv1beta1.VMSelect{},
+			VMInsert: &vmv1beta1.VMInsert{},
+		},
+	})
+}
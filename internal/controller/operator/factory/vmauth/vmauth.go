@@ -17,6 +17,7 @@ import (
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
@@ -55,9 +56,23 @@ func CreateOrUpdateVMAuth(ctx context.Context, cr *vmv1beta1.VMAuth, rclient cli
 	if err != nil {
 		return fmt.Errorf("cannot create or update vmauth service :%w", err)
 	}
+	if cr.Spec.NetworkPolicy != nil {
+		if err := createOrUpdateNetworkPolicy(ctx, rclient, cr, svc); err != nil {
+			return err
+		}
+	}
 	if err := createOrUpdateVMAuthIngress(ctx, rclient, cr); err != nil {
 		return fmt.Errorf("cannot create or update ingress for vmauth: %w", err)
 	}
+	if cr.Spec.HTTPRoute != nil {
+		route, err := build.HTTPRoute(cr, cr.Spec.HTTPRoute, cr.Spec.Port, vmauthRouteHostnames(cr))
+		if err != nil {
+			return vmv1beta1.NewConfigError(fmt.Errorf("cannot build route for vmauth: %w", err))
+		}
+		if err := reconcile.HTTPRoute(ctx, rclient, route); err != nil {
+			return fmt.Errorf("cannot create or update route for vmauth: %w", err)
+		}
+	}
 	if !ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) {
 		if err := reconcile.VMServiceScrapeForCRD(ctx, rclient, build.VMServiceScrapeForServiceWithSpec(svc, cr)); err != nil {
 			return err
@@ -85,11 +100,22 @@ func CreateOrUpdateVMAuth(ctx context.Context, cr *vmv1beta1.VMAuth, rclient cli
 
 	newDeploy, err := newDeployForVMAuth(cr)
 	if err != nil {
-		return fmt.Errorf("cannot build new deploy for vmauth: %w", err)
+		return vmv1beta1.NewConfigError(fmt.Errorf("cannot build new deploy for vmauth: %w", err))
 	}
 	if err := reconcile.Deployment(ctx, rclient, newDeploy, prevDeploy, false); err != nil {
 		return fmt.Errorf("cannot reconcile vmauth deployment: %w", err)
 	}
+	if cr.Spec.VPA != nil {
+		targetRef := autoscalingv1.CrossVersionObjectReference{
+			Name:       newDeploy.Name,
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		}
+		vpa := build.VPA(targetRef, "vmauth", cr.Spec.VPA, cr.AsOwner(), cr.SelectorLabels(), cr.Namespace)
+		if err := reconcile.VPA(ctx, rclient, vpa); err != nil {
+			return fmt.Errorf("cannot update vpa for vmauth: %w", err)
+		}
+	}
 	if err := deletePrevStateResources(ctx, cr, rclient); err != nil {
 		return err
 	}
@@ -353,6 +379,20 @@ func makeVMAuthConfigSecret(cr *vmv1beta1.VMAuth) *corev1.Secret {
 	}
 }
 
+// vmauthRouteHostnames returns route hostnames defined at ingress spec
+func vmauthRouteHostnames(cr *vmv1beta1.VMAuth) []string {
+	if cr.Spec.Ingress == nil {
+		return nil
+	}
+	if cr.Spec.Ingress.TlsSecretName != "" {
+		return cr.Spec.Ingress.TlsHosts
+	}
+	if cr.Spec.Ingress.Host != "" {
+		return []string{cr.Spec.Ingress.Host}
+	}
+	return nil
+}
+
 // createOrUpdateVMAuthIngress handles ingress for vmauth.
 func createOrUpdateVMAuthIngress(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAuth) error {
 	if cr.Spec.Ingress == nil {
@@ -576,6 +616,22 @@ func createOrUpdateVMAuthService(ctx context.Context, cr *vmv1beta1.VMAuth, rcli
 	return newService, nil
 }
 
+// createOrUpdateNetworkPolicy allows access to vmauth service ports
+func createOrUpdateNetworkPolicy(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAuth, svc *corev1.Service) error {
+	peers, err := build.NetworkPolicyScrapePeers(ctx, rclient, cr.Spec.NetworkPolicy, cr.Namespace)
+	if err != nil {
+		return err
+	}
+	rules := &build.NetworkPolicyRules{
+		Ports: build.NetworkPolicyPortsFromService(svc),
+		Peers: peers,
+	}
+	if err := reconcile.NetworkPolicy(ctx, rclient, build.NetworkPolicy(cr, cr.Spec.NetworkPolicy, rules)); err != nil {
+		return fmt.Errorf("cannot update network policy for vmauth: %w", err)
+	}
+	return nil
+}
+
 func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VMAuth, rclient client.Client) error {
 	if cr.ParsedLastAppliedSpec == nil {
 		return nil
@@ -599,6 +655,15 @@ func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VMAuth, rclient
 			return fmt.Errorf("cannot delete ingress from prev state: %w", err)
 		}
 	}
+	if err := reconcile.RemoveOrphanedHTTPRoute(ctx, rclient, cr.PrefixedName(), cr.Namespace, prevCR.Spec.HTTPRoute, cr.Spec.HTTPRoute); err != nil {
+		return err
+	}
+	if err := reconcile.RemoveOrphanedNetworkPolicy(ctx, rclient, cr.PrefixedName(), cr.Namespace, prevCR.Spec.NetworkPolicy, cr.Spec.NetworkPolicy); err != nil {
+		return err
+	}
+	if err := reconcile.RemoveOrphanedVPA(ctx, rclient, cr.PrefixedName(), cr.Namespace, prevCR.Spec.VPA, cr.Spec.VPA); err != nil {
+		return err
+	}
 	if ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) && !ptr.Deref(prevCR.Spec.DisableSelfServiceScrape, false) {
 		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMServiceScrape{ObjectMeta: objMeta}); err != nil {
 			return fmt.Errorf("cannot remove serviceScrape: %w", err)
@@ -2,6 +2,8 @@ package vmauth
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
@@ -11,9 +13,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestCreateOrUpdateVMAuth(t *testing.T) {
@@ -447,3 +452,31 @@ serviceaccountname: vmauth-auth
 `)
 	})
 }
+
+type conflictOnSecretCreateClient struct {
+	client.Client
+}
+
+func (c *conflictOnSecretCreateClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if _, ok := obj.(*corev1.Secret); ok {
+		return k8serrors.NewConflict(schema.GroupResource{Resource: "secrets"}, obj.GetName(), fmt.Errorf("object was modified"))
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func TestCreateOrUpdateVMAuthAPIError(t *testing.T) {
+	cr := &vmv1beta1.VMAuth{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+	}
+	rclient := &conflictOnSecretCreateClient{Client: k8stools.GetTestClientWithObjects([]runtime.Object{cr})}
+	build.AddDefaults(rclient.Scheme())
+	rclient.Scheme().Default(cr)
+	err := CreateOrUpdateVMAuth(context.TODO(), cr, rclient)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	var ce *vmv1beta1.ConditionError
+	if errors.As(err, &ce) {
+		t.Fatalf("api error must not be reported as config error: %s", err)
+	}
+}
@@ -0,0 +1,194 @@
+package vmauth
+
+import (
+	"context"
+	"fmt"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CreateOrUpdateVMTenant generates VMUsers with write and read routes to the tenant of referenced VMCluster
+// and fills status of the given VMTenant with resolved tenant urls
+func CreateOrUpdateVMTenant(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMTenant) error {
+	var vmc vmv1beta1.VMCluster
+	if err := rclient.Get(ctx, types.NamespacedName{Namespace: cr.ClusterNamespace(), Name: cr.Spec.ClusterRef.Name}, &vmc); err != nil {
+		return fmt.Errorf("cannot get VMCluster=%s/%s: %w", cr.ClusterNamespace(), cr.Spec.ClusterRef.Name, err)
+	}
+	if cr.Spec.Write != nil && vmc.Spec.VMInsert == nil {
+		return fmt.Errorf("VMCluster=%s/%s has no vminsert for tenant write access", vmc.Namespace, vmc.Name)
+	}
+	if cr.Spec.Read != nil && vmc.Spec.VMSelect == nil {
+		return fmt.Errorf("VMCluster=%s/%s has no vmselect for tenant read access", vmc.Namespace, vmc.Name)
+	}
+
+	// status is patched at once, since controller compares update status with already changed fields otherwise
+	status := cr.Status.DeepCopy()
+	credsSecret, err := buildVMTenantCredentialsSecret(ctx, rclient, cr)
+	if err != nil {
+		return err
+	}
+	status.CredentialsSecret = ""
+	if credsSecret != nil {
+		if err := reconcile.Secret(ctx, rclient, credsSecret); err != nil {
+			return fmt.Errorf("cannot reconcile credentials secret for vmtenant: %w", err)
+		}
+		status.CredentialsSecret = credsSecret.Name
+	} else if err := finalize.SafeDelete(ctx, rclient, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: cr.PrefixedName(), Namespace: cr.Namespace}}); err != nil {
+		return fmt.Errorf("cannot remove credentials secret for vmtenant: %w", err)
+	}
+
+	status.TenantID = cr.TenantID()
+	status.InsertURL = ""
+	status.SelectURL = ""
+	if vmc.Spec.VMInsert != nil {
+		status.InsertURL = fmt.Sprintf("%s/insert/%s/", vmc.VMInsertURL(), cr.TenantID())
+	}
+	if vmc.Spec.VMSelect != nil {
+		status.SelectURL = fmt.Sprintf("%s/select/%s/prometheus", vmc.VMSelectURL(), cr.TenantID())
+	}
+
+	status.WriteUser = ""
+	if err := reconcileVMTenantUser(ctx, rclient, cr, cr.WriteUserName(), cr.Spec.Write, "write", "VMCluster/vminsert", "/insert/"+cr.TenantID()); err != nil {
+		return err
+	}
+	if cr.Spec.Write != nil {
+		status.WriteUser = cr.WriteUserName()
+	}
+	status.ReadUser = ""
+	if err := reconcileVMTenantUser(ctx, rclient, cr, cr.ReadUserName(), cr.Spec.Read, "read", "VMCluster/vmselect", "/select/"+cr.TenantID()); err != nil {
+		return err
+	}
+	if cr.Spec.Read != nil {
+		status.ReadUser = cr.ReadUserName()
+	}
+	if err := cr.SetRoutesStatus(ctx, rclient, status); err != nil {
+		return fmt.Errorf("cannot update vmtenant status: %w", err)
+	}
+	return nil
+}
+
+// reconcileVMTenantUser creates VMUser for given access or removes it, if access is not defined
+func reconcileVMTenantUser(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMTenant, name string, access *vmv1beta1.VMTenantAccess, accessType, kind, pathSuffix string) error {
+	if access == nil {
+		var existVMU vmv1beta1.VMUser
+		if err := rclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: name}, &existVMU); err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("cannot get %s VMUser for vmtenant: %w", accessType, err)
+		}
+		// VMUser with the same name could be created by user
+		if !metav1.IsControlledBy(&existVMU, cr) {
+			return nil
+		}
+		if err := finalize.SafeDelete(ctx, rclient, &existVMU); err != nil {
+			return fmt.Errorf("cannot remove %s VMUser for vmtenant: %w", accessType, err)
+		}
+		return nil
+	}
+	if access.Limits != nil {
+		if err := access.Limits.Validate(); err != nil {
+			return fmt.Errorf("incorrect %s limits of vmtenant: %w", accessType, err)
+		}
+	}
+	passwordRef := access.PasswordRef
+	if passwordRef == nil {
+		passwordRef = &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: cr.PrefixedName()},
+			Key:                  accessType + "-password",
+		}
+	}
+	vmu := &vmv1beta1.VMUser{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       cr.Namespace,
+			Labels:          cr.AllLabels(),
+			Annotations:     cr.AnnotationsFiltered(),
+			OwnerReferences: cr.AsOwner(),
+		},
+		Spec: vmv1beta1.VMUserSpec{
+			UserName:    ptr.To(vmTenantUserName(cr, access, accessType)),
+			PasswordRef: passwordRef,
+			TargetRefs: []vmv1beta1.TargetRef{
+				{
+					CRD: &vmv1beta1.CRDRef{
+						Kind:      kind,
+						Name:      cr.Spec.ClusterRef.Name,
+						Namespace: cr.ClusterNamespace(),
+					},
+					Paths:            access.Paths,
+					TargetPathSuffix: pathSuffix,
+				},
+			},
+			UserConfigOption: vmv1beta1.UserConfigOption{
+				MaxConcurrentRequests: access.MaxConcurrentRequests,
+			},
+			Limits: access.Limits,
+			// credentials are stored at VMTenant secret
+			DisableSecretCreation: true,
+		},
+	}
+	if err := reconcile.VMUser(ctx, rclient, vmu); err != nil {
+		return fmt.Errorf("cannot reconcile %s VMUser for vmtenant: %w", accessType, err)
+	}
+	return nil
+}
+
+func vmTenantUserName(cr *vmv1beta1.VMTenant, access *vmv1beta1.VMTenantAccess, accessType string) string {
+	if access.UserName != nil {
+		return *access.UserName
+	}
+	return fmt.Sprintf("%s-%s", cr.Name, accessType)
+}
+
+// buildVMTenantCredentialsSecret returns secret with generated credentials for tenant accesses without passwordRef.
+// Previously generated passwords are preserved
+func buildVMTenantCredentialsSecret(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMTenant) (*corev1.Secret, error) {
+	var existSecret corev1.Secret
+	if err := rclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.PrefixedName()}, &existSecret); err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("cannot get credentials secret for vmtenant: %w", err)
+		}
+	}
+	data := make(map[string][]byte)
+	for accessType, access := range map[string]*vmv1beta1.VMTenantAccess{
+		"write": cr.Spec.Write,
+		"read":  cr.Spec.Read,
+	} {
+		if access == nil || access.PasswordRef != nil {
+			continue
+		}
+		passwordKey := accessType + "-password"
+		password := existSecret.Data[passwordKey]
+		if len(password) == 0 {
+			pwd, err := genPassword()
+			if err != nil {
+				return nil, fmt.Errorf("cannot generate %s password for vmtenant: %w", accessType, err)
+			}
+			password = []byte(pwd)
+		}
+		data[accessType+"-username"] = []byte(vmTenantUserName(cr, access, accessType))
+		data[passwordKey] = password
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cr.PrefixedName(),
+			Namespace:       cr.Namespace,
+			Labels:          cr.AllLabels(),
+			Annotations:     cr.AnnotationsFiltered(),
+			OwnerReferences: cr.AsOwner(),
+		},
+		Data: data,
+	}, nil
+}
@@ -0,0 +1,163 @@
+package vmauth
+
+import (
+	"context"
+	"testing"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+func TestCreateOrUpdateVMTenant(t *testing.T) {
+	ctx := context.Background()
+	vmc := &vmv1beta1.VMCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "vm"},
+		Spec: vmv1beta1.VMClusterSpec{
+			VMInsert: &vmv1beta1.VMInsert{},
+			VMSelect: &vmv1beta1.VMSelect{},
+		},
+	}
+	cr := &vmv1beta1.VMTenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "default", UID: "team-a-uid", Labels: map[string]string{"vmauth": "main"}},
+		Spec: vmv1beta1.VMTenantSpec{
+			ClusterRef: vmv1beta1.VMTenantClusterRef{Name: "main", Namespace: "vm"},
+			AccountID:  10,
+			ProjectID:  2,
+			Write:      &vmv1beta1.VMTenantAccess{},
+			Read: &vmv1beta1.VMTenantAccess{
+				UserName: ptr.To("grafana"),
+				PasswordRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "grafana-creds"},
+					Key:                  "password",
+				},
+				MaxConcurrentRequests: ptr.To(5),
+			},
+		},
+	}
+	fclient := k8stools.GetTestClientWithObjects([]runtime.Object{vmc, cr.DeepCopy()})
+	getUser := func(name string) *vmv1beta1.VMUser {
+		t.Helper()
+		var vmu vmv1beta1.VMUser
+		if err := fclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: name}, &vmu); err != nil {
+			t.Fatalf("cannot get vmuser=%s: %s", name, err)
+		}
+		return &vmu
+	}
+	getPassword := func() string {
+		t.Helper()
+		var s corev1.Secret
+		if err := fclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.PrefixedName()}, &s); err != nil {
+			t.Fatalf("cannot get credentials secret: %s", err)
+		}
+		assert.Equal(t, "team-a-write", string(s.Data["write-username"]))
+		if _, ok := s.Data["read-password"]; ok {
+			t.Fatalf("read password must not be generated with passwordRef")
+		}
+		return string(s.Data["write-password"])
+	}
+
+	getStatus := func() vmv1beta1.VMTenantStatus {
+		t.Helper()
+		var got vmv1beta1.VMTenant
+		if err := fclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, &got); err != nil {
+			t.Fatalf("cannot get vmtenant: %s", err)
+		}
+		return got.Status
+	}
+
+	if err := CreateOrUpdateVMTenant(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	wantStatus := vmv1beta1.VMTenantStatus{
+		TenantID:          "10:2",
+		InsertURL:         "http://vminsert-main.vm.svc:8480/insert/10:2/",
+		SelectURL:         "http://vmselect-main.vm.svc:8481/select/10:2/prometheus",
+		WriteUser:         "vmtenant-team-a-write",
+		ReadUser:          "vmtenant-team-a-read",
+		CredentialsSecret: "vmtenant-team-a",
+	}
+	assert.Equal(t, wantStatus, cr.Status)
+	assert.Equal(t, wantStatus, getStatus())
+
+	writeUser := getUser(cr.WriteUserName())
+	assert.Equal(t, "main", writeUser.Labels["vmauth"])
+	assert.Equal(t, "team-a-write", *writeUser.Spec.UserName)
+	assert.Equal(t, &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "vmtenant-team-a"},
+		Key:                  "write-password",
+	}, writeUser.Spec.PasswordRef)
+	assert.Equal(t, []vmv1beta1.TargetRef{{
+		CRD:              &vmv1beta1.CRDRef{Kind: "VMCluster/vminsert", Name: "main", Namespace: "vm"},
+		TargetPathSuffix: "/insert/10:2",
+	}}, writeUser.Spec.TargetRefs)
+
+	readUser := getUser(cr.ReadUserName())
+	assert.Equal(t, "grafana", *readUser.Spec.UserName)
+	assert.Equal(t, "grafana-creds", readUser.Spec.PasswordRef.Name)
+	assert.Equal(t, ptr.To(5), readUser.Spec.MaxConcurrentRequests)
+	assert.Equal(t, "/select/10:2", readUser.Spec.TargetRefs[0].TargetPathSuffix)
+
+	// generated password must be preserved
+	password := getPassword()
+	if len(password) == 0 {
+		t.Fatalf("expected generated write password")
+	}
+	if err := CreateOrUpdateVMTenant(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	assert.Equal(t, password, getPassword())
+
+	// disabled read access removes user
+	cr.Spec.Read = nil
+	if err := CreateOrUpdateVMTenant(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	assert.Empty(t, cr.Status.ReadUser)
+	assert.Empty(t, getStatus().ReadUser)
+	err := fclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.ReadUserName()}, &vmv1beta1.VMUser{})
+	if !errors.IsNotFound(err) {
+		t.Fatalf("expected read vmuser to be removed, got err: %v", err)
+	}
+
+	// VMUser created by user with the same name must not be removed or changed
+	foreignUser := &vmv1beta1.VMUser{
+		ObjectMeta: metav1.ObjectMeta{Name: cr.ReadUserName(), Namespace: cr.Namespace},
+		Spec:       vmv1beta1.VMUserSpec{UserName: ptr.To("foreign")},
+	}
+	if err := fclient.Create(ctx, foreignUser); err != nil {
+		t.Fatalf("cannot create vmuser: %s", err)
+	}
+	if err := CreateOrUpdateVMTenant(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	assert.Equal(t, "foreign", *getUser(cr.ReadUserName()).Spec.UserName)
+	cr.Spec.Read = &vmv1beta1.VMTenantAccess{}
+	if err := CreateOrUpdateVMTenant(ctx, fclient, cr); err == nil {
+		t.Fatalf("expected error for VMUser owned by other object")
+	}
+	assert.Equal(t, "foreign", *getUser(cr.ReadUserName()).Spec.UserName)
+	cr.Spec.Read = nil
+
+	// unsupported limits are rejected
+	cr.Spec.Write.Limits = &vmv1beta1.VMUserLimits{MaxIngestionBytesPerSecond: ptr.To(int64(1024))}
+	if err := CreateOrUpdateVMTenant(ctx, fclient, cr); err == nil {
+		t.Fatalf("expected error for unsupported limits")
+	}
+	cr.Spec.Write.Limits = nil
+
+	// cluster without vminsert
+	vmc.Spec.VMInsert = nil
+	if err := fclient.Update(ctx, vmc); err != nil {
+		t.Fatalf("cannot update cluster: %s", err)
+	}
+	if err := CreateOrUpdateVMTenant(ctx, fclient, cr); err == nil {
+		t.Fatalf("expected error for cluster without vminsert")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"net/url"
@@ -18,6 +19,7 @@ import (
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -32,6 +34,8 @@ type skipableVMUsers struct {
 	stopIter      bool
 	users         []*vmv1beta1.VMUser
 	brokenVMUsers []*vmv1beta1.VMUser
+	// previousPasswords holds rotated passwords, which are still valid during overlap window
+	previousPasswords map[*vmv1beta1.VMUser]string
 }
 
 // visitAll visits all users objects
@@ -111,7 +115,7 @@ func buildVMAuthConfig(ctx context.Context, rclient client.Client, vmauth *vmv1b
 	// generate yaml config for vmauth.
 	cfg, err := generateVMAuthConfig(vmauth, sus, crdCache, tlsAssets, rclient)
 	if err != nil {
-		return nil, err
+		return nil, vmv1beta1.NewConfigError(err)
 	}
 
 	// inject generated password into secrets, that we want to create.
@@ -136,6 +140,9 @@ func buildVMAuthConfig(ctx context.Context, rclient client.Client, vmauth *vmv1b
 				return nil, fmt.Errorf("failed to patch status of vmuser=%q: %w", user.Name, err)
 			}
 		}
+		if err := patchVMUserLimitsStatus(ctx, rclient, user); err != nil {
+			return nil, err
+		}
 	}
 	var errContexts []string
 	for _, brokenUser := range sus.brokenVMUsers {
@@ -157,6 +164,22 @@ func buildVMAuthConfig(ctx context.Context, rclient client.Client, vmauth *vmv1b
 	return cfg, nil
 }
 
+// patchVMUserLimitsStatus reports limits applied to the user targets at status
+func patchVMUserLimitsStatus(ctx context.Context, rclient client.Client, user *vmv1beta1.VMUser) error {
+	limits := appliedLimits(user)
+	if equality.Semantic.DeepEqual(user.Status.Limits, limits) {
+		return nil
+	}
+	data, err := json.Marshal(map[string]any{"status": map[string]any{"limits": limits}})
+	if err != nil {
+		return fmt.Errorf("cannot marshal limits status: %w", err)
+	}
+	if err := rclient.Status().Patch(ctx, user, client.RawPatch(types.MergePatchType, data)); err != nil {
+		return fmt.Errorf("failed to patch limits status of vmuser=%q: %w", user.Name, err)
+	}
+	return nil
+}
+
 func createVMUserSecrets(ctx context.Context, rclient client.Client, secrets []*corev1.Secret) error {
 	for i := range secrets {
 		secret := secrets[i]
@@ -181,6 +204,10 @@ func filterNonUniqUsers(sus *skipableVMUsers) {
 		if user.Spec.BearerToken != nil {
 			at = "bearerToken:" + *user.Spec.BearerToken
 		}
+		if user.Spec.JWT != nil {
+			// jwt users are matched by token claims
+			at = "jwt:" + user.Namespace + "/" + user.Name
+		}
 		return at, user.CreationTimestamp.Time
 	})
 }
@@ -210,6 +237,7 @@ func getAsURLObject(ctx context.Context, rclient client.Client, objT objectWithU
 
 func addAuthCredentialsBuildSecrets(ctx context.Context, rclient client.Client, sus *skipableVMUsers) (needToCreateSecrets []*corev1.Secret, needToUpdateSecrets []*corev1.Secret, resultErr error) {
 	dst := make(map[string]*corev1.Secret)
+	now := time.Now()
 
 	sus.visitAll(func(user *vmv1beta1.VMUser) bool {
 		switch {
@@ -234,7 +262,8 @@ func addAuthCredentialsBuildSecrets(ctx context.Context, rclient client.Client,
 			user.Spec.BearerToken = ptr.To(v)
 		}
 
-		if !user.Spec.DisableSecretCreation {
+		// jwt users don't have static credentials
+		if !user.Spec.DisableSecretCreation && user.Spec.JWT == nil {
 			var vmus corev1.Secret
 			if err := rclient.Get(ctx, types.NamespacedName{Namespace: user.Namespace, Name: user.SecretName()}, &vmus); err != nil {
 				if !errors.IsNotFound(err) {
@@ -247,11 +276,21 @@ func addAuthCredentialsBuildSecrets(ctx context.Context, rclient client.Client,
 					user.Status.CurrentSyncError = fmt.Sprintf("cannot build user secret with password: %q", err)
 					return false
 				}
+				if _, err := rotatePassword(userSecret, user, sus, now); err != nil {
+					user.Status.CurrentSyncError = fmt.Sprintf("cannot rotate user password: %q", err)
+					return false
+				}
 				needToCreateSecrets = append(needToCreateSecrets, userSecret)
 
 			} else {
 				// secret exists, check it's state
-				if injectAuthSettings(&vmus, user) {
+				needUpdate := injectAuthSettings(&vmus, user)
+				rotated, err := rotatePassword(&vmus, user, sus, now)
+				if err != nil {
+					user.Status.CurrentSyncError = fmt.Sprintf("cannot rotate user password: %q", err)
+					return false
+				}
+				if needUpdate || rotated {
 					needToUpdateSecrets = append(needToUpdateSecrets, &vmus)
 				}
 			}
@@ -340,6 +379,111 @@ func injectAuthSettings(secret *corev1.Secret, vmuser *vmv1beta1.VMUser) bool {
 	return needUpdate
 }
 
+const (
+	passwordRotatedAtAnnotation = "operator.victoriametrics.com/password-rotated-at"
+	previousPasswordKey         = "previousPassword"
+)
+
+// rotatePassword replaces generated password of vmuser with a new one after rotation interval.
+// Previous password is kept at secret and registered as valid at vmauth config until overlap window passes.
+// It returns true if secret must be updated
+func rotatePassword(secret *corev1.Secret, user *vmv1beta1.VMUser, sus *skipableVMUsers, now time.Time) (bool, error) {
+	if user.Spec.Rotation == nil || !user.Spec.GeneratePassword {
+		// cleanup state of disabled rotation
+		if _, ok := secret.Data[previousPasswordKey]; ok {
+			delete(secret.Data, previousPasswordKey)
+			return true, nil
+		}
+		return false, nil
+	}
+	interval, overlap, err := user.Spec.Rotation.Durations()
+	if err != nil {
+		return false, err
+	}
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	rotatedAt, err := time.Parse(time.RFC3339, secret.Annotations[passwordRotatedAtAnnotation])
+	if err != nil {
+		// rotation was enabled for existing password, start counting from now
+		secret.Annotations[passwordRotatedAtAnnotation] = now.Format(time.RFC3339)
+		return true, nil
+	}
+	var needUpdate bool
+	if now.Sub(rotatedAt) >= interval {
+		pwd, err := genPassword()
+		if err != nil {
+			return false, fmt.Errorf("cannot generate password for user=%q: %w", user.Name, err)
+		}
+		secret.Data[previousPasswordKey] = secret.Data["password"]
+		secret.Data["password"] = []byte(pwd)
+		secret.Annotations[passwordRotatedAtAnnotation] = now.Format(time.RFC3339)
+		user.Spec.Password = ptr.To(pwd)
+		rotatedAt = now
+		needUpdate = true
+	}
+	prevPassword := secret.Data[previousPasswordKey]
+	if len(prevPassword) == 0 {
+		return needUpdate, nil
+	}
+	if now.Sub(rotatedAt) >= overlap {
+		delete(secret.Data, previousPasswordKey)
+		return true, nil
+	}
+	if sus.previousPasswords == nil {
+		sus.previousPasswords = make(map[*vmv1beta1.VMUser]string)
+	}
+	sus.previousPasswords[user] = string(prevPassword)
+	return needUpdate, nil
+}
+
+// nextPasswordRotation returns time of the next password rotation or the end of overlap window
+// for the given user secret. It returns zero time if rotation is not configured or not started yet
+func nextPasswordRotation(secret *corev1.Secret, user *vmv1beta1.VMUser) (time.Time, error) {
+	if user.Spec.Rotation == nil || !user.Spec.GeneratePassword {
+		return time.Time{}, nil
+	}
+	interval, overlap, err := user.Spec.Rotation.Durations()
+	if err != nil {
+		return time.Time{}, err
+	}
+	rotatedAt, err := time.Parse(time.RFC3339, secret.Annotations[passwordRotatedAtAnnotation])
+	if err != nil {
+		return time.Time{}, nil
+	}
+	if len(secret.Data[previousPasswordKey]) > 0 {
+		return rotatedAt.Add(overlap), nil
+	}
+	return rotatedAt.Add(interval), nil
+}
+
+// PasswordRotationRequeueAfter returns duration until the next password rotation step of the given user.
+// VMUser must be reconciled after it in order to apply rotation to vmauth config without waiting for resync.
+// It returns 0 if rotation is not configured or user secret doesn't exist yet
+func PasswordRotationRequeueAfter(ctx context.Context, rclient client.Client, user *vmv1beta1.VMUser, now time.Time) (time.Duration, error) {
+	if user.Spec.Rotation == nil || !user.Spec.GeneratePassword || user.Spec.DisableSecretCreation {
+		return 0, nil
+	}
+	var secret corev1.Secret
+	if err := rclient.Get(ctx, types.NamespacedName{Namespace: user.Namespace, Name: user.SecretName()}, &secret); err != nil {
+		if errors.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("cannot get vmuser secret: %w", err)
+	}
+	next, err := nextPasswordRotation(&secret, user)
+	if err != nil || next.IsZero() {
+		return 0, err
+	}
+	// requeue right after deadline, since rotation checks deadline with seconds precision
+	// deadline may already pass, if vmauth config reconcile was throttled
+	requeueAfter := next.Sub(now) + time.Second
+	if requeueAfter < time.Second {
+		requeueAfter = time.Second
+	}
+	return requeueAfter, nil
+}
+
 var crdNameToObject = map[string]objectWithURL{
 	"VMAgent":  &vmv1beta1.VMAgent{},
 	"VMAlert":  &vmv1beta1.VMAlert{},
@@ -439,12 +583,25 @@ func generateVMAuthConfig(cr *vmv1beta1.VMAuth, sus *skipableVMUsers, crdCache m
 	var cfgUsers []yaml.MapSlice
 
 	sus.visitAll(func(user *vmv1beta1.VMUser) bool {
+		if user.Spec.JWT != nil {
+			jwtCfgs, err := genJWTUserCfgs(user, crdCache, cb)
+			if err != nil {
+				user.Status.CurrentSyncError = err.Error()
+				return false
+			}
+			cfgUsers = append(cfgUsers, jwtCfgs...)
+			return true
+		}
 		userCfg, err := genUserCfg(user, crdCache, cb)
 		if err != nil {
 			user.Status.CurrentSyncError = err.Error()
 			return false
 		}
 		cfgUsers = append(cfgUsers, userCfg)
+		if prevPassword, ok := sus.previousPasswords[user]; ok {
+			// keep previous password valid during rotation overlap window
+			cfgUsers = append(cfgUsers, withPassword(userCfg, prevPassword))
+		}
 		return true
 	})
 
@@ -489,6 +646,18 @@ func generateVMAuthConfig(cr *vmv1beta1.VMAuth, sus *skipableVMUsers, crdCache m
 	return ac, nil
 }
 
+// withPassword returns copy of user config with replaced password
+func withPassword(userCfg yaml.MapSlice, password string) yaml.MapSlice {
+	dst := make(yaml.MapSlice, 0, len(userCfg))
+	for _, item := range userCfg {
+		if item.Key == "password" {
+			item.Value = password
+		}
+		dst = append(dst, item)
+	}
+	return dst
+}
+
 func appendIfNotNull(src []string, key string, origin yaml.MapSlice) yaml.MapSlice {
 	if len(src) > 0 {
 		return append(origin, yaml.MapItem{
@@ -775,7 +944,22 @@ func genURLMaps(userName string, refs []vmv1beta1.TargetRef, result yaml.MapSlic
 func genUserCfg(user *vmv1beta1.VMUser, crdURLCache map[string]string, cb build.TLSConfigBuilder) (yaml.MapSlice, error) {
 	var r yaml.MapSlice
 
-	r, err := genURLMaps(user.Name, user.Spec.TargetRefs, r, crdURLCache)
+	// rotation is validated by webhook, but it could be disabled
+	if err := user.Spec.ValidateRotation(); err != nil {
+		return nil, err
+	}
+	refs := user.Spec.TargetRefs
+	if user.Spec.Limits != nil {
+		if err := user.Spec.Limits.Validate(); err != nil {
+			return nil, fmt.Errorf("incorrect limits: %w", err)
+		}
+		var err error
+		refs, err = addQueryTimeoutToRefs(refs, user.Spec.Limits.MaxQueryDuration)
+		if err != nil {
+			return nil, err
+		}
+	}
+	r, err := genURLMaps(user.Name, refs, r, crdURLCache)
 	if err != nil {
 		return nil, fmt.Errorf("cannot generate urlMaps for user: %w", err)
 	}
@@ -813,6 +997,11 @@ func genUserCfg(user *vmv1beta1.VMUser, crdURLCache map[string]string, cb build.
 		})
 	}
 
+	if user.Spec.JWT != nil {
+		r = addJWTToYaml(r, user.Spec.JWT)
+		return r, nil
+	}
+
 	// fast path.
 	if token != "" {
 		r = append(r, yaml.MapItem{
@@ -841,6 +1030,109 @@ func genUserCfg(user *vmv1beta1.VMUser, crdURLCache map[string]string, cb build.
 	return r, nil
 }
 
+// appliedLimits returns user limits, which are enforced by at least one of user targets
+func appliedLimits(user *vmv1beta1.VMUser) *vmv1beta1.VMUserLimits {
+	if user.Spec.Limits == nil || user.Spec.Limits.MaxQueryDuration == "" {
+		return nil
+	}
+	for _, ref := range user.Spec.TargetRefs {
+		if isQueryTimeoutSupported(&ref) {
+			return &vmv1beta1.VMUserLimits{MaxQueryDuration: user.Spec.Limits.MaxQueryDuration}
+		}
+	}
+	return nil
+}
+
+// isQueryTimeoutSupported checks if target limits query duration with timeout query arg
+func isQueryTimeoutSupported(ref *vmv1beta1.TargetRef) bool {
+	return ref.CRD != nil && (ref.CRD.Kind == "VMSingle" || ref.CRD.Kind == "VMCluster/vmselect")
+}
+
+// addQueryTimeoutToRefs returns copy of refs with timeout query arg at VMSingle and VMCluster/vmselect targets.
+// It limits query duration at backend side, since vmselect doesn't allow to exceed requested timeout
+func addQueryTimeoutToRefs(refs []vmv1beta1.TargetRef, timeout string) ([]vmv1beta1.TargetRef, error) {
+	if timeout == "" {
+		return refs, nil
+	}
+	result := make([]vmv1beta1.TargetRef, 0, len(refs))
+	for _, ref := range refs {
+		if isQueryTimeoutSupported(&ref) {
+			suffix, err := url.Parse(ref.TargetPathSuffix)
+			if err != nil {
+				return nil, fmt.Errorf("cannot parse targetPath: %q, err: %w", ref.TargetPathSuffix, err)
+			}
+			q := suffix.Query()
+			q.Set("timeout", timeout)
+			suffix.RawQuery = q.Encode()
+			ref.TargetPathSuffix = suffix.String()
+		}
+		result = append(result, ref)
+	}
+	return result, nil
+}
+
+// genJWTUserCfgs generates user config per each tenant mapping of jwt user
+func genJWTUserCfgs(user *vmv1beta1.VMUser, crdURLCache map[string]string, cb build.TLSConfigBuilder) ([]yaml.MapSlice, error) {
+	jwt := user.Spec.JWT
+	if len(jwt.TenantMapping) == 0 {
+		userCfg, err := genUserCfg(user, crdURLCache, cb)
+		if err != nil {
+			return nil, err
+		}
+		return []yaml.MapSlice{userCfg}, nil
+	}
+	var userCfgs []yaml.MapSlice
+	for _, tm := range jwt.TenantMapping {
+		tenantUser := user.DeepCopy()
+		for i := range tenantUser.Spec.TargetRefs {
+			ref := &tenantUser.Spec.TargetRefs[i]
+			ref.TargetPathSuffix = strings.ReplaceAll(ref.TargetPathSuffix, vmv1beta1.JWTTenantPlaceholder, tm.Tenant)
+			if ref.Static != nil {
+				ref.Static.URL = strings.ReplaceAll(ref.Static.URL, vmv1beta1.JWTTenantPlaceholder, tm.Tenant)
+				for j := range ref.Static.URLs {
+					ref.Static.URLs[j] = strings.ReplaceAll(ref.Static.URLs[j], vmv1beta1.JWTTenantPlaceholder, tm.Tenant)
+				}
+			}
+		}
+		claims := make(map[string]string, len(jwt.RequiredClaims)+len(tm.Claims))
+		for k, v := range jwt.RequiredClaims {
+			claims[k] = v
+		}
+		for k, v := range tm.Claims {
+			claims[k] = v
+		}
+		tenantUser.Spec.JWT.RequiredClaims = claims
+		userCfg, err := genUserCfg(tenantUser, crdURLCache, cb)
+		if err != nil {
+			return nil, fmt.Errorf("cannot generate config for tenant=%q: %w", tm.Tenant, err)
+		}
+		userCfgs = append(userCfgs, userCfg)
+	}
+	return userCfgs, nil
+}
+
+// addJWTToYaml adds token verification settings and claims matchers to user config
+func addJWTToYaml(dst yaml.MapSlice, jwt *vmv1beta1.VMUserJWT) yaml.MapSlice {
+	var jwtCfg yaml.MapSlice
+	if jwt.Issuer != "" {
+		jwtCfg = append(jwtCfg, yaml.MapItem{Key: "oidc", Value: yaml.MapSlice{{Key: "issuer", Value: jwt.Issuer}}})
+	}
+	if jwt.JWKSURL != "" {
+		jwtCfg = append(jwtCfg, yaml.MapItem{Key: "jwks_url", Value: jwt.JWKSURL})
+	}
+	if len(jwt.PublicKeys) > 0 {
+		jwtCfg = append(jwtCfg, yaml.MapItem{Key: "public_keys", Value: jwt.PublicKeys})
+	}
+	if jwt.SkipVerify {
+		jwtCfg = append(jwtCfg, yaml.MapItem{Key: "skip_verify", Value: true})
+	}
+	dst = append(dst, yaml.MapItem{Key: "jwt", Value: jwtCfg})
+	if len(jwt.RequiredClaims) > 0 {
+		dst = append(dst, yaml.MapItem{Key: "match_claims", Value: jwt.RequiredClaims})
+	}
+	return dst
+}
+
 // simple password generation.
 // its kubernetes, strong security does not work there.
 var (
@@ -14,6 +14,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 )
 
@@ -455,6 +456,83 @@ username: basic
 password: pass
 `,
 		},
+		{
+			name: "with limits",
+			args: args{
+				user: &vmv1beta1.VMUser{
+					Spec: vmv1beta1.VMUserSpec{
+						UserName: ptr.To("grafana"),
+						Password: ptr.To("pass"),
+						Limits: &vmv1beta1.VMUserLimits{
+							MaxQueryDuration: "30s",
+						},
+						TargetRefs: []vmv1beta1.TargetRef{
+							{
+								CRD: &vmv1beta1.CRDRef{
+									Kind:      "VMCluster/vmselect",
+									Name:      "main",
+									Namespace: "monitoring",
+								},
+								Paths:            []string{"/api/v1/query.*"},
+								TargetPathSuffix: "/select/1/prometheus?extra_label=team=a",
+							},
+							{
+								CRD: &vmv1beta1.CRDRef{
+									Kind:      "VMCluster/vminsert",
+									Name:      "main",
+									Namespace: "monitoring",
+								},
+								Paths:            []string{"/api/v1/write"},
+								TargetPathSuffix: "/insert/1/prometheus",
+							},
+						},
+					},
+				},
+				crdURLCache: map[string]string{
+					"VMCluster/vmselect/monitoring/main": "http://vmselect-main.monitoring.svc:8481",
+					"VMCluster/vminsert/monitoring/main": "http://vminsert-main.monitoring.svc:8480",
+				},
+			},
+			want: `url_map:
+- url_prefix:
+  - http://vmselect-main.monitoring.svc:8481/select/1/prometheus?extra_label=team%3Da&timeout=30s
+  src_paths:
+  - /api/v1/query.*
+- url_prefix:
+  - http://vminsert-main.monitoring.svc:8480/insert/1/prometheus
+  src_paths:
+  - /api/v1/write
+username: grafana
+password: pass
+`,
+		},
+		{
+			name: "with incorrect limits",
+			args: args{
+				user: &vmv1beta1.VMUser{
+					Spec: vmv1beta1.VMUserSpec{
+						UserName:   ptr.To("grafana"),
+						Limits:     &vmv1beta1.VMUserLimits{MaxQueryDuration: "30x"},
+						TargetRefs: []vmv1beta1.TargetRef{{Static: &vmv1beta1.StaticRef{URL: "http://vmsingle:8429"}}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "with rotation of bearer token",
+			args: args{
+				user: &vmv1beta1.VMUser{
+					Spec: vmv1beta1.VMUserSpec{
+						GeneratePassword: true,
+						BearerToken:      ptr.To("token"),
+						Rotation:         &vmv1beta1.VMUserCredentialRotation{Interval: "90d"},
+						TargetRefs:       []vmv1beta1.TargetRef{{Static: &vmv1beta1.StaticRef{URL: "http://vmsingle:8429"}}},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -463,6 +541,9 @@ password: pass
 				t.Errorf("genUserCfg() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantErr {
+				return
+			}
 			szd, err := yaml.Marshal(got)
 			if err != nil {
 				t.Fatalf("cannot serialize resutl: %v", err)
@@ -500,6 +581,239 @@ func Test_genPassword(t *testing.T) {
 	}
 }
 
+func Test_rotatePassword(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	f := func(rotatedAt time.Time, data map[string]string, wantUpdate, wantRotated bool, wantPrevPassword string) {
+		t.Helper()
+		user := &vmv1beta1.VMUser{
+			ObjectMeta: metav1.ObjectMeta{Name: "user", Namespace: "default"},
+			Spec: vmv1beta1.VMUserSpec{
+				GeneratePassword: true,
+				Password:         ptr.To(data["password"]),
+				Rotation:         &vmv1beta1.VMUserCredentialRotation{Interval: "90d", OverlapWindow: "1d"},
+			},
+		}
+		secret := &corev1.Secret{Data: map[string][]byte{}}
+		for k, v := range data {
+			secret.Data[k] = []byte(v)
+		}
+		if !rotatedAt.IsZero() {
+			secret.Annotations = map[string]string{passwordRotatedAtAnnotation: rotatedAt.Format(time.RFC3339)}
+		}
+		sus := &skipableVMUsers{users: []*vmv1beta1.VMUser{user}}
+		needUpdate, err := rotatePassword(secret, user, sus, now)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		assert.Equal(t, wantUpdate, needUpdate)
+		if wantRotated {
+			assert.NotEqual(t, data["password"], *user.Spec.Password)
+			assert.Equal(t, *user.Spec.Password, string(secret.Data["password"]))
+			assert.Equal(t, data["password"], string(secret.Data[previousPasswordKey]))
+			assert.Equal(t, now.Format(time.RFC3339), secret.Annotations[passwordRotatedAtAnnotation])
+		} else {
+			assert.Equal(t, data["password"], *user.Spec.Password)
+		}
+		assert.Equal(t, wantPrevPassword, sus.previousPasswords[user])
+	}
+
+	// rotation enabled for existing secret
+	f(time.Time{}, map[string]string{"password": "old"}, true, false, "")
+
+	// rotation interval not passed
+	f(now.Add(-24*time.Hour), map[string]string{"password": "old"}, false, false, "")
+
+	// rotation interval passed
+	f(now.Add(-91*24*time.Hour), map[string]string{"password": "old"}, true, true, "old")
+
+	// previous password is valid during overlap
+	f(now.Add(-time.Hour), map[string]string{"password": "new", previousPasswordKey: "old"}, false, false, "old")
+
+	// previous password is dropped after overlap
+	f(now.Add(-25*time.Hour), map[string]string{"password": "new", previousPasswordKey: "old"}, true, false, "")
+}
+
+func TestPasswordRotationRequeueAfter(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	f := func(rotation *vmv1beta1.VMUserCredentialRotation, secret *corev1.Secret, want time.Duration) {
+		t.Helper()
+		user := &vmv1beta1.VMUser{
+			ObjectMeta: metav1.ObjectMeta{Name: "user", Namespace: "default"},
+			Spec: vmv1beta1.VMUserSpec{
+				GeneratePassword: true,
+				Rotation:         rotation,
+			},
+		}
+		var objects []runtime.Object
+		if secret != nil {
+			secret.Name = user.SecretName()
+			secret.Namespace = user.Namespace
+			objects = append(objects, secret)
+		}
+		got, err := PasswordRotationRequeueAfter(context.Background(), k8stools.GetTestClientWithObjects(objects), user, now)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		assert.Equal(t, want, got)
+	}
+	rotation := &vmv1beta1.VMUserCredentialRotation{Interval: "90d", OverlapWindow: "1d"}
+	rotatedAt := func(d time.Duration) map[string]string {
+		return map[string]string{passwordRotatedAtAnnotation: now.Add(-d).Format(time.RFC3339)}
+	}
+
+	// rotation is not configured
+	f(nil, &corev1.Secret{}, 0)
+
+	// secret is not created yet
+	f(rotation, nil, 0)
+
+	// rotation is not started yet
+	f(rotation, &corev1.Secret{}, 0)
+
+	// next rotation
+	f(rotation, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Annotations: rotatedAt(24 * time.Hour)},
+		Data:       map[string][]byte{"password": []byte("new")},
+	}, 89*24*time.Hour+time.Second)
+
+	// end of overlap window
+	f(rotation, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Annotations: rotatedAt(time.Hour)},
+		Data:       map[string][]byte{"password": []byte("new"), previousPasswordKey: []byte("old")},
+	}, 23*time.Hour+time.Second)
+
+	// deadline is already passed
+	f(rotation, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Annotations: rotatedAt(91 * 24 * time.Hour)},
+		Data:       map[string][]byte{"password": []byte("new")},
+	}, time.Second)
+}
+
+func Test_generateVMAuthConfigWithRotatedPassword(t *testing.T) {
+	user := &vmv1beta1.VMUser{
+		ObjectMeta: metav1.ObjectMeta{Name: "user", Namespace: "default"},
+		Spec: vmv1beta1.VMUserSpec{
+			UserName:   ptr.To("user"),
+			Password:   ptr.To("new"),
+			TargetRefs: []vmv1beta1.TargetRef{{Static: &vmv1beta1.StaticRef{URL: "http://vmsingle:8429"}}},
+		},
+	}
+	sus := &skipableVMUsers{
+		users:             []*vmv1beta1.VMUser{user},
+		previousPasswords: map[*vmv1beta1.VMUser]string{user: "old"},
+	}
+	cr := &vmv1beta1.VMAuth{ObjectMeta: metav1.ObjectMeta{Name: "vmauth", Namespace: "default"}}
+	cfg, err := generateVMAuthConfig(cr, sus, nil, nil, k8stools.GetTestClientWithObjects(nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	assert.Equal(t, `users:
+- url_prefix:
+  - http://vmsingle:8429
+  username: user
+  password: new
+- url_prefix:
+  - http://vmsingle:8429
+  username: user
+  password: old
+`, string(cfg))
+}
+
+func Test_generateVMAuthConfigWithJWT(t *testing.T) {
+	user := &vmv1beta1.VMUser{
+		ObjectMeta: metav1.ObjectMeta{Name: "grafana", Namespace: "default"},
+		Spec: vmv1beta1.VMUserSpec{
+			JWT: &vmv1beta1.VMUserJWT{
+				Issuer:         "https://sso.example.com/realms/main",
+				RequiredClaims: map[string]string{"aud": "grafana"},
+				TenantMapping: []vmv1beta1.VMUserJWTTenantMapping{
+					{Claims: map[string]string{"team": "a"}, Tenant: "10"},
+					{Claims: map[string]string{"team": "b"}, Tenant: "20:1"},
+				},
+			},
+			TargetRefs: []vmv1beta1.TargetRef{{
+				Static:           &vmv1beta1.StaticRef{URL: "http://vmselect:8481"},
+				TargetPathSuffix: "/select/%TENANT%/prometheus",
+			}},
+		},
+	}
+	sus := &skipableVMUsers{users: []*vmv1beta1.VMUser{user}}
+	cr := &vmv1beta1.VMAuth{ObjectMeta: metav1.ObjectMeta{Name: "vmauth", Namespace: "default"}}
+	cfg, err := generateVMAuthConfig(cr, sus, nil, nil, k8stools.GetTestClientWithObjects(nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	assert.Equal(t, `users:
+- url_prefix:
+  - http://vmselect:8481/select/10/prometheus
+  jwt:
+    oidc:
+      issuer: https://sso.example.com/realms/main
+  match_claims:
+    aud: grafana
+    team: a
+- url_prefix:
+  - http://vmselect:8481/select/20:1/prometheus
+  jwt:
+    oidc:
+      issuer: https://sso.example.com/realms/main
+  match_claims:
+    aud: grafana
+    team: b
+`, string(cfg))
+	// original user spec must not be modified
+	assert.Equal(t, map[string]string{"aud": "grafana"}, user.Spec.JWT.RequiredClaims)
+	assert.Equal(t, "/select/%TENANT%/prometheus", user.Spec.TargetRefs[0].TargetPathSuffix)
+}
+
+func Test_patchVMUserLimitsStatus(t *testing.T) {
+	user := &vmv1beta1.VMUser{
+		ObjectMeta: metav1.ObjectMeta{Name: "grafana", Namespace: "default"},
+		Spec: vmv1beta1.VMUserSpec{
+			Limits: &vmv1beta1.VMUserLimits{MaxQueryDuration: "1m"},
+			TargetRefs: []vmv1beta1.TargetRef{
+				{CRD: &vmv1beta1.CRDRef{Kind: "VMCluster/vmselect", Name: "main", Namespace: "default"}},
+				{CRD: &vmv1beta1.CRDRef{Kind: "VMCluster/vminsert", Name: "main", Namespace: "default"}},
+			},
+		},
+	}
+	ctx := context.Background()
+	fclient := k8stools.GetTestClientWithObjects([]runtime.Object{user})
+	if err := patchVMUserLimitsStatus(ctx, fclient, user); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var got vmv1beta1.VMUser
+	if err := fclient.Get(ctx, types.NamespacedName{Namespace: user.Namespace, Name: user.Name}, &got); err != nil {
+		t.Fatalf("cannot get vmuser: %s", err)
+	}
+	assert.Equal(t, user.Spec.Limits, got.Status.Limits)
+
+	// limits are not reported, if targets don't support it
+	got.Spec.TargetRefs = got.Spec.TargetRefs[1:]
+	if err := patchVMUserLimitsStatus(ctx, fclient, &got); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := fclient.Get(ctx, types.NamespacedName{Namespace: user.Namespace, Name: user.Name}, &got); err != nil {
+		t.Fatalf("cannot get vmuser: %s", err)
+	}
+	assert.Nil(t, got.Status.Limits)
+
+	// limits removal must be reflected at status
+	got.Spec.TargetRefs = user.Spec.TargetRefs
+	if err := patchVMUserLimitsStatus(ctx, fclient, &got); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	assert.Equal(t, user.Spec.Limits, got.Status.Limits)
+	got.Spec.Limits = nil
+	if err := patchVMUserLimitsStatus(ctx, fclient, &got); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := fclient.Get(ctx, types.NamespacedName{Namespace: user.Namespace, Name: user.Name}, &got); err != nil {
+		t.Fatalf("cannot get vmuser: %s", err)
+	}
+	assert.Nil(t, got.Status.Limits)
+}
+
 func Test_selectVMUserSecrets(t *testing.T) {
 	type args struct {
 		vmUsers *skipableVMUsers
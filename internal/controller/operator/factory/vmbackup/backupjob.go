@@ -0,0 +1,109 @@
+package vmbackup
+
+import (
+	"context"
+	"fmt"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CreateOrUpdateVMBackupJob creates vmbackup Job or CronJob per storage node of the target
+// and updates status of the given VMBackupJob
+func CreateOrUpdateVMBackupJob(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMBackupJob) error {
+	if cr.IsFinished() {
+		// finished jobs could be already removed, backup must not be started again
+		logger.WithContext(ctx).Info("skipping reconcile of finished backup", "generation", cr.Generation)
+		return nil
+	}
+	nodes, err := getStorageNodes(ctx, rclient, cr.Spec.Target, cr.Namespace)
+	if err != nil {
+		return err
+	}
+	finished := finishedNodes(cr.Generation, cr.Status.ObservedGeneration, cr.Status.Nodes)
+	keepJobs := make(map[string]struct{})
+	keepCronJobs := make(map[string]struct{})
+	nodeStatuses := make([]vmv1beta1.BackupJobNodeStatus, 0, len(nodes))
+	for idx, node := range nodes {
+		name := jobName(cr, idx)
+		jobSpec := buildVMBackupJobSpec(cr, node)
+		if cr.IsScheduled() {
+			keepCronJobs[name] = struct{}{}
+			cj, err := reconcile.CronJob(ctx, rclient, buildVMBackupCronJob(cr, name, jobSpec))
+			if err != nil {
+				return fmt.Errorf("cannot reconcile backup cronjob for node=%q: %w", node.podName, err)
+			}
+			nodeStatuses = append(nodeStatuses, cronJobNodeStatus(node, cj))
+			continue
+		}
+		keepJobs[name] = struct{}{}
+		if st, ok := finished[name]; ok {
+			nodeStatuses = append(nodeStatuses, st)
+			continue
+		}
+		job, err := reconcile.Job(ctx, rclient, &batchv1.Job{
+			ObjectMeta: jobMeta(cr, name),
+			Spec:       jobSpec,
+		})
+		if err != nil {
+			return fmt.Errorf("cannot reconcile backup job for node=%q: %w", node.podName, err)
+		}
+		nodeStatuses = append(nodeStatuses, jobNodeStatus(node, name, job))
+	}
+	if err := removeOrphanedJobs(ctx, rclient, cr, keepJobs, keepCronJobs); err != nil {
+		return err
+	}
+	return cr.SetNodesStatus(ctx, rclient, nodeStatuses)
+}
+
+func buildVMBackupCronJob(cr *vmv1beta1.VMBackupJob, name string, jobSpec batchv1.JobSpec) *batchv1.CronJob {
+	return &batchv1.CronJob{
+		ObjectMeta: jobMeta(cr, name),
+		Spec: batchv1.CronJobSpec{
+			Schedule: cr.Spec.Schedule,
+			Suspend:  ptr.To(cr.Spec.Suspend),
+			// backup of the same node must not be executed in parallel
+			ConcurrencyPolicy: batchv1.ForbidConcurrent,
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: cr.SelectorLabels(),
+				},
+				Spec: jobSpec,
+			},
+		},
+	}
+}
+
+func buildVMBackupJobSpec(cr *vmv1beta1.VMBackupJob, node storageNode) batchv1.JobSpec {
+	params := &cr.Spec.BackupJobCommonParams
+	args := []string{
+		fmt.Sprintf("-dst=%s", nodeRemotePath(cr.Spec.Destination, node, cr.Spec.DestinationDisableSuffixAdd)),
+		fmt.Sprintf("-snapshot.createURL=%s", vmv1beta1.SnapshotCreateURLFor(node.baseURL, node.extraArgs)),
+		fmt.Sprintf("-snapshot.deleteURL=%s", vmv1beta1.SnapshotDeleteURLFor(node.baseURL, node.extraArgs)),
+	}
+	if cr.Spec.Origin != "" {
+		args = append(args, fmt.Sprintf("-origin=%s", nodeRemotePath(cr.Spec.Origin, node, cr.Spec.DestinationDisableSuffixAdd)))
+	}
+	args = buildArgs(args, params, node.storagePath)
+
+	template := buildPodTemplate(cr, params, node, "vmbackup", args, true)
+	// vmbackup reads snapshot from the storage volume
+	// so it must be scheduled at the same kubernetes node as storage pod
+	template.Spec.Affinity = &corev1.Affinity{
+		PodAffinity: &corev1.PodAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+				{
+					LabelSelector: &metav1.LabelSelector{MatchLabels: node.podLabels},
+					TopologyKey:   "kubernetes.io/hostname",
+				},
+			},
+		},
+	}
+	return buildJobSpec(params, template)
+}
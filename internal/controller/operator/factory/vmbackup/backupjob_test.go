@@ -0,0 +1,402 @@
+package vmbackup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+func testBackupVMCluster() *vmv1beta1.VMCluster {
+	return &vmv1beta1.VMCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+		Spec: vmv1beta1.VMClusterSpec{
+			RetentionPeriod: "1",
+			VMStorage: &vmv1beta1.VMStorage{
+				CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+					ReplicaCount: ptr.To(int32(2)),
+				},
+				Storage: &vmv1beta1.StorageSpec{
+					VolumeClaimTemplate: vmv1beta1.EmbeddedPersistentVolumeClaim{
+						Spec: corev1.PersistentVolumeClaimSpec{
+							Resources: corev1.VolumeResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCreateOrUpdateVMBackupJob(t *testing.T) {
+	type opts struct {
+		cr                *vmv1beta1.VMBackupJob
+		predefinedObjects []runtime.Object
+		wantErr           bool
+		validate          func(t *testing.T, jobs []batchv1.Job, cronJobs []batchv1.CronJob)
+	}
+	f := func(o opts) {
+		t.Helper()
+		fclient := k8stools.GetTestClientWithObjects(append(o.predefinedObjects, o.cr))
+		ctx := context.TODO()
+		build.AddDefaults(fclient.Scheme())
+		fclient.Scheme().Default(o.cr)
+		err := CreateOrUpdateVMBackupJob(ctx, fclient, o.cr)
+		if (err != nil) != o.wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, o.wantErr)
+		}
+		if o.wantErr {
+			return
+		}
+		var jobs batchv1.JobList
+		if err := fclient.List(ctx, &jobs); err != nil {
+			t.Fatalf("cannot list jobs: %s", err)
+		}
+		var cronJobs batchv1.CronJobList
+		if err := fclient.List(ctx, &cronJobs); err != nil {
+			t.Fatalf("cannot list cronjobs: %s", err)
+		}
+		o.validate(t, jobs.Items, cronJobs.Items)
+	}
+
+	// vmcluster with 2 storage nodes
+	f(opts{
+		cr: &vmv1beta1.VMBackupJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "daily", Namespace: "default", Generation: 1},
+			Spec: vmv1beta1.VMBackupJobSpec{
+				Target:      vmv1beta1.BackupTargetRef{Kind: vmv1beta1.BackupTargetKindVMCluster, Name: "main"},
+				Destination: "s3://bucket/main",
+			},
+		},
+		predefinedObjects: []runtime.Object{testBackupVMCluster()},
+		validate: func(t *testing.T, jobs []batchv1.Job, cronJobs []batchv1.CronJob) {
+			if len(jobs) != 2 || len(cronJobs) != 0 {
+				t.Fatalf("unexpected jobs count=%d and cronjobs count=%d", len(jobs), len(cronJobs))
+			}
+			job := jobs[0]
+			if job.Name != "vmbackupjob-daily-0" {
+				t.Fatalf("unexpected job name=%q", job.Name)
+			}
+			if job.Annotations[reconcile.JobGenerationAnnotation] != "1" {
+				t.Fatalf("unexpected generation annotation=%q", job.Annotations[reconcile.JobGenerationAnnotation])
+			}
+			container := job.Spec.Template.Spec.Containers[0]
+			wantArgs := []string{
+				"-dst=s3://bucket/main/vmstorage-main-0/",
+				"-snapshot.createURL=http://vmstorage-main-0.vmstorage-main.default:8482/snapshot/create",
+				"-snapshot.deleteURL=http://vmstorage-main-0.vmstorage-main.default:8482/snapshot/delete",
+				"-storageDataPath=vmstorage-data",
+			}
+			if len(container.Args) != len(wantArgs) {
+				t.Fatalf("unexpected args: %v", container.Args)
+			}
+			for i := range wantArgs {
+				if container.Args[i] != wantArgs[i] {
+					t.Fatalf("unexpected arg at idx=%d, got: %q, want: %q", i, container.Args[i], wantArgs[i])
+				}
+			}
+			claim := job.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim
+			if claim == nil || claim.ClaimName != "vmstorage-db-vmstorage-main-0" {
+				t.Fatalf("unexpected data volume: %v", job.Spec.Template.Spec.Volumes[0])
+			}
+			if !container.VolumeMounts[0].ReadOnly {
+				t.Fatalf("data volume must be mounted as read-only")
+			}
+		},
+	})
+
+	// scheduled backup for vmsingle
+	f(opts{
+		cr: &vmv1beta1.VMBackupJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "daily", Namespace: "default"},
+			Spec: vmv1beta1.VMBackupJobSpec{
+				Target:      vmv1beta1.BackupTargetRef{Kind: vmv1beta1.BackupTargetKindVMSingle, Name: "single"},
+				Destination: "s3://bucket/single",
+				Schedule:    "0 1 * * *",
+			},
+		},
+		predefinedObjects: []runtime.Object{
+			&vmv1beta1.VMSingle{
+				ObjectMeta: metav1.ObjectMeta{Name: "single", Namespace: "default"},
+				Spec: vmv1beta1.VMSingleSpec{
+					Storage: &corev1.PersistentVolumeClaimSpec{},
+				},
+			},
+			// must be removed
+			&batchv1.CronJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "vmbackupjob-daily-1",
+					Namespace: "default",
+					Labels:    map[string]string{"app.kubernetes.io/name": "vmbackupjob", "app.kubernetes.io/instance": "daily", "app.kubernetes.io/component": "monitoring", "managed-by": "vm-operator"},
+				},
+			},
+		},
+		validate: func(t *testing.T, jobs []batchv1.Job, cronJobs []batchv1.CronJob) {
+			if len(jobs) != 0 || len(cronJobs) != 1 {
+				t.Fatalf("unexpected jobs count=%d and cronjobs count=%d", len(jobs), len(cronJobs))
+			}
+			cj := cronJobs[0]
+			if cj.Name != "vmbackupjob-daily-0" || cj.Spec.Schedule != "0 1 * * *" {
+				t.Fatalf("unexpected cronjob name=%q with schedule=%q", cj.Name, cj.Spec.Schedule)
+			}
+			if cj.Spec.ConcurrencyPolicy != batchv1.ForbidConcurrent {
+				t.Fatalf("unexpected concurrency policy=%q", cj.Spec.ConcurrencyPolicy)
+			}
+			args := cj.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Args
+			if args[0] != "-dst=s3://bucket/single" {
+				t.Fatalf("unexpected destination arg=%q", args[0])
+			}
+		},
+	})
+
+	// vmsingle without persistent storage
+	f(opts{
+		cr: &vmv1beta1.VMBackupJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "daily", Namespace: "default"},
+			Spec: vmv1beta1.VMBackupJobSpec{
+				Target:      vmv1beta1.BackupTargetRef{Kind: vmv1beta1.BackupTargetKindVMSingle, Name: "single"},
+				Destination: "s3://bucket/single",
+			},
+		},
+		predefinedObjects: []runtime.Object{
+			&vmv1beta1.VMSingle{
+				ObjectMeta: metav1.ObjectMeta{Name: "single", Namespace: "default"},
+			},
+		},
+		wantErr: true,
+	})
+}
+
+func TestCreateOrUpdateVMBackupJobStatus(t *testing.T) {
+	cr := &vmv1beta1.VMBackupJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "daily", Namespace: "default"},
+		Spec: vmv1beta1.VMBackupJobSpec{
+			Target:      vmv1beta1.BackupTargetRef{Kind: vmv1beta1.BackupTargetKindVMCluster, Name: "main"},
+			Destination: "s3://bucket/main",
+			Schedule:    "0 1 * * *",
+		},
+	}
+	ctx := context.TODO()
+	fclient := k8stools.GetTestClientWithObjects([]runtime.Object{cr, testBackupVMCluster()})
+	build.AddDefaults(fclient.Scheme())
+	fclient.Scheme().Default(cr)
+	getStatus := func() vmv1beta1.VMBackupJobStatus {
+		t.Helper()
+		var got vmv1beta1.VMBackupJob
+		if err := fclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "daily"}, &got); err != nil {
+			t.Fatalf("cannot get backup job: %s", err)
+		}
+		return got.Status
+	}
+
+	if err := CreateOrUpdateVMBackupJob(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	status := getStatus()
+	if status.UpdateStatus != vmv1beta1.UpdateStatusOperational || status.LastSuccessfulBackupTime != nil {
+		t.Fatalf("unexpected status after the first reconcile: %+v", status)
+	}
+
+	// scheduled backups completed, status remains operational
+	lastSuccess := metav1.NewTime(time.Now().Truncate(time.Second))
+	for _, name := range []string{"vmbackupjob-daily-0", "vmbackupjob-daily-1"} {
+		var cj batchv1.CronJob
+		if err := fclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: name}, &cj); err != nil {
+			t.Fatalf("cannot get cronjob: %s", err)
+		}
+		cj.Status.LastSuccessfulTime = &lastSuccess
+		if err := fclient.Status().Update(ctx, &cj); err != nil {
+			t.Fatalf("cannot update cronjob status: %s", err)
+		}
+	}
+	if err := CreateOrUpdateVMBackupJob(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	status = getStatus()
+	if status.LastSuccessfulBackupTime == nil || !status.LastSuccessfulBackupTime.Equal(&lastSuccess) {
+		t.Fatalf("last successful backup time must be updated, got: %+v", status)
+	}
+	for _, node := range status.Nodes {
+		if node.LastSuccessTime == nil {
+			t.Fatalf("last success time must be set for node=%q", node.Node)
+		}
+	}
+}
+
+func TestCreateOrUpdateVMRestoreJob(t *testing.T) {
+	cr := &vmv1beta1.VMRestoreJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "restore", Namespace: "default"},
+		Spec: vmv1beta1.VMRestoreJobSpec{
+			Target: vmv1beta1.BackupTargetRef{Kind: vmv1beta1.BackupTargetKindVMCluster, Name: "main"},
+			Source: "s3://bucket/main",
+		},
+	}
+	runningPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vmstorage-main-1",
+			Namespace: "default",
+			Labels:    map[string]string{podNameLabelName: "vmstorage-main-1"},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	ctx := context.TODO()
+	fclient := k8stools.GetTestClientWithObjects([]runtime.Object{cr, testBackupVMCluster(), runningPod})
+	build.AddDefaults(fclient.Scheme())
+	fclient.Scheme().Default(cr)
+
+	// restore must wait until storage is stopped
+	if err := CreateOrUpdateVMRestoreJob(ctx, fclient, cr); err == nil {
+		t.Fatalf("expected error for running storage pod")
+	}
+	if err := fclient.Delete(ctx, runningPod); err != nil {
+		t.Fatalf("cannot delete pod: %s", err)
+	}
+	if err := CreateOrUpdateVMRestoreJob(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var jobs batchv1.JobList
+	if err := fclient.List(ctx, &jobs); err != nil {
+		t.Fatalf("cannot list jobs: %s", err)
+	}
+	if len(jobs.Items) != 2 {
+		t.Fatalf("unexpected jobs count=%d", len(jobs.Items))
+	}
+	args := jobs.Items[1].Spec.Template.Spec.Containers[0].Args
+	if args[0] != "-src=s3://bucket/main/vmstorage-main-1/" {
+		t.Fatalf("unexpected source arg=%q", args[0])
+	}
+	var pvc corev1.PersistentVolumeClaim
+	if err := fclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "vmstorage-db-vmstorage-main-1"}, &pvc); err != nil {
+		t.Fatalf("restore claim must be created: %s", err)
+	}
+	var got vmv1beta1.VMRestoreJob
+	if err := fclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "restore"}, &got); err != nil {
+		t.Fatalf("cannot get restore job: %s", err)
+	}
+	if got.Status.Progress != "0/2" || len(got.Status.Nodes) != 2 {
+		t.Fatalf("unexpected status: %+v", got.Status)
+	}
+
+	// restore jobs completed and removed by ttl controller, storage started with restored data
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue, LastTransitionTime: metav1.Now()}}
+		if err := fclient.Status().Update(ctx, job); err != nil {
+			t.Fatalf("cannot update job status: %s", err)
+		}
+	}
+	if err := CreateOrUpdateVMRestoreJob(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for i := range jobs.Items {
+		if err := fclient.Delete(ctx, &jobs.Items[i]); err != nil {
+			t.Fatalf("cannot delete job: %s", err)
+		}
+	}
+	runningPod.ResourceVersion = ""
+	if err := fclient.Create(ctx, runningPod); err != nil {
+		t.Fatalf("cannot create pod: %s", err)
+	}
+	if err := CreateOrUpdateVMRestoreJob(ctx, fclient, cr); err != nil {
+		t.Fatalf("finished restore must not check storage state: %s", err)
+	}
+	if cr.Status.UpdateStatus != vmv1beta1.UpdateStatusOperational {
+		t.Fatalf("unexpected status: %+v", cr.Status)
+	}
+	if err := fclient.List(ctx, &jobs); err != nil {
+		t.Fatalf("cannot list jobs: %s", err)
+	}
+	if len(jobs.Items) != 0 {
+		t.Fatalf("finished restore must not be started again, got jobs count=%d", len(jobs.Items))
+	}
+}
+
+func TestCreateOrUpdateVMBackupJobFinished(t *testing.T) {
+	cr := &vmv1beta1.VMBackupJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "once", Namespace: "default", Generation: 1},
+		Spec: vmv1beta1.VMBackupJobSpec{
+			Target:      vmv1beta1.BackupTargetRef{Kind: vmv1beta1.BackupTargetKindVMCluster, Name: "main"},
+			Destination: "s3://bucket/main",
+		},
+	}
+	ctx := context.TODO()
+	fclient := k8stools.GetTestClientWithObjects([]runtime.Object{cr, testBackupVMCluster()})
+	build.AddDefaults(fclient.Scheme())
+	fclient.Scheme().Default(cr)
+	listJobs := func() []batchv1.Job {
+		t.Helper()
+		var jobs batchv1.JobList
+		if err := fclient.List(ctx, &jobs); err != nil {
+			t.Fatalf("cannot list jobs: %s", err)
+		}
+		return jobs.Items
+	}
+
+	if err := CreateOrUpdateVMBackupJob(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	jobs := listJobs()
+	if len(jobs) != 2 {
+		t.Fatalf("unexpected jobs count=%d", len(jobs))
+	}
+	// the first job completed and removed by ttl controller before the second one completed
+	now := metav1.Now()
+	completeJob := func(job *batchv1.Job) {
+		t.Helper()
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue, LastTransitionTime: now}}
+		if err := fclient.Status().Update(ctx, job); err != nil {
+			t.Fatalf("cannot update job status: %s", err)
+		}
+	}
+	completeJob(&jobs[0])
+	if err := CreateOrUpdateVMBackupJob(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := fclient.Delete(ctx, &jobs[0]); err != nil {
+		t.Fatalf("cannot delete job: %s", err)
+	}
+	if err := CreateOrUpdateVMBackupJob(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if jobs := listJobs(); len(jobs) != 1 {
+		t.Fatalf("completed job must not be re-created, got jobs count=%d", len(jobs))
+	}
+	completeJob(&jobs[1])
+	if err := CreateOrUpdateVMBackupJob(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if cr.Status.UpdateStatus != vmv1beta1.UpdateStatusOperational || !cr.IsFinished() {
+		t.Fatalf("backup must be finished, got status: %+v", cr.Status)
+	}
+	if err := fclient.Delete(ctx, &jobs[1]); err != nil {
+		t.Fatalf("cannot delete job: %s", err)
+	}
+	if err := CreateOrUpdateVMBackupJob(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if jobs := listJobs(); len(jobs) != 0 {
+		t.Fatalf("finished backup must not be started again, got jobs count=%d", len(jobs))
+	}
+
+	// spec change starts backup again
+	cr.Generation++
+	if err := CreateOrUpdateVMBackupJob(ctx, fclient, cr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if jobs := listJobs(); len(jobs) != 2 {
+		t.Fatalf("backup must be started for the new generation, got jobs count=%d", len(jobs))
+	}
+}
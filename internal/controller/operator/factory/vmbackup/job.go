@@ -0,0 +1,252 @@
+package vmbackup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// jobOwner is a common interface for VMBackupJob and VMRestoreJob
+type jobOwner interface {
+	client.Object
+	AsOwner() []metav1.OwnerReference
+	AllLabels() map[string]string
+	SelectorLabels() map[string]string
+	AnnotationsFiltered() map[string]string
+	PrefixedName() string
+}
+
+func jobName(cr jobOwner, idx int) string {
+	return fmt.Sprintf("%s-%d", cr.PrefixedName(), idx)
+}
+
+func jobMeta(cr jobOwner, name string) metav1.ObjectMeta {
+	annotations := cr.AnnotationsFiltered()
+	annotations[reconcile.JobGenerationAnnotation] = strconv.FormatInt(cr.GetGeneration(), 10)
+	return metav1.ObjectMeta{
+		Name:            name,
+		Namespace:       cr.GetNamespace(),
+		Labels:          cr.AllLabels(),
+		Annotations:     annotations,
+		OwnerReferences: cr.AsOwner(),
+	}
+}
+
+// buildArgs adds common flags for vmbackup and vmrestore
+func buildArgs(args []string, params *vmv1beta1.BackupJobCommonParams, storagePath string) []string {
+	args = append(args, fmt.Sprintf("-storageDataPath=%s", storagePath))
+	if params.LogLevel != nil {
+		args = append(args, fmt.Sprintf("-loggerLevel=%s", *params.LogLevel))
+	}
+	if params.LogFormat != nil {
+		args = append(args, fmt.Sprintf("-loggerFormat=%s", *params.LogFormat))
+	}
+	if params.Concurrency != nil {
+		args = append(args, fmt.Sprintf("-concurrency=%d", *params.Concurrency))
+	}
+	if params.CustomS3Endpoint != nil {
+		args = append(args, fmt.Sprintf("-customS3Endpoint=%s", *params.CustomS3Endpoint))
+	}
+	if params.CredentialsSecret != nil {
+		args = append(args, fmt.Sprintf("-credsFilePath=%s/%s", vmBackuperCreds, params.CredentialsSecret.Key))
+	}
+	if len(params.ExtraEnvs) > 0 {
+		args = append(args, "-envflag.enable=true")
+	}
+	for arg, value := range params.ExtraArgs {
+		args = append(args, fmt.Sprintf("-%s=%s", arg, value))
+	}
+	sort.Strings(args)
+	return args
+}
+
+// buildPodTemplate builds pod template with vmbackup or vmrestore container for the given storage node
+func buildPodTemplate(cr jobOwner, params *vmv1beta1.BackupJobCommonParams, node storageNode, containerName string, args []string, readOnlyData bool) corev1.PodTemplateSpec {
+	volumes := []corev1.Volume{
+		{
+			Name: dataVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: node.claim.Name,
+				},
+			},
+		},
+	}
+	mounts := []corev1.VolumeMount{
+		{
+			Name:      dataVolumeName,
+			MountPath: node.storagePath,
+			ReadOnly:  readOnlyData,
+		},
+	}
+	if params.CredentialsSecret != nil {
+		volumeName := k8stools.SanitizeVolumeName("secret-" + params.CredentialsSecret.Name)
+		volumes = append(volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: params.CredentialsSecret.Name,
+				},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: vmBackuperCreds,
+			ReadOnly:  true,
+		})
+	}
+	volumes = append(volumes, params.Volumes...)
+	mounts = append(mounts, params.VolumeMounts...)
+
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: cr.SelectorLabels(),
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:      corev1.RestartPolicyNever,
+			ServiceAccountName: params.ServiceAccountName,
+			ImagePullSecrets:   params.ImagePullSecrets,
+			Volumes:            volumes,
+			Containers: []corev1.Container{
+				{
+					Name:                     containerName,
+					Image:                    fmt.Sprintf("%s:%s", params.Image.Repository, params.Image.Tag),
+					ImagePullPolicy:          params.Image.PullPolicy,
+					Args:                     args,
+					Env:                      params.ExtraEnvs,
+					VolumeMounts:             mounts,
+					Resources:                params.Resources,
+					TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+				},
+			},
+		},
+	}
+}
+
+func buildJobSpec(params *vmv1beta1.BackupJobCommonParams, template corev1.PodTemplateSpec) batchv1.JobSpec {
+	return batchv1.JobSpec{
+		BackoffLimit:            params.BackoffLimit,
+		TTLSecondsAfterFinished: params.TTLSecondsAfterFinished,
+		Template:                template,
+	}
+}
+
+// jobNodeStatus converts job state into node status
+func jobNodeStatus(node storageNode, name string, job *batchv1.Job) vmv1beta1.BackupJobNodeStatus {
+	st := vmv1beta1.BackupJobNodeStatus{
+		Node:    node.podName,
+		JobName: name,
+		Phase:   vmv1beta1.BackupJobPhasePending,
+	}
+	if job == nil {
+		return st
+	}
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			st.Phase = vmv1beta1.BackupJobPhaseSucceeded
+			st.LastSuccessTime = job.Status.CompletionTime
+			if st.LastSuccessTime == nil {
+				st.LastSuccessTime = &cond.LastTransitionTime
+			}
+			return st
+		case batchv1.JobFailed:
+			st.Phase = vmv1beta1.BackupJobPhaseFailed
+			st.Message = cond.Message
+			return st
+		}
+	}
+	if job.Status.Active > 0 {
+		st.Phase = vmv1beta1.BackupJobPhaseRunning
+	}
+	return st
+}
+
+// finishedNodes returns node statuses with terminal phase recorded for the current generation, keyed by job name
+// jobs of such nodes are not reconciled anymore
+func finishedNodes(generation, observedGeneration int64, nodes []vmv1beta1.BackupJobNodeStatus) map[string]vmv1beta1.BackupJobNodeStatus {
+	finished := make(map[string]vmv1beta1.BackupJobNodeStatus)
+	if generation != observedGeneration {
+		return finished
+	}
+	for _, node := range nodes {
+		if node.Phase == vmv1beta1.BackupJobPhaseSucceeded || node.Phase == vmv1beta1.BackupJobPhaseFailed {
+			finished[node.JobName] = node
+		}
+	}
+	return finished
+}
+
+// cronJobNodeStatus converts cronjob state into node status
+func cronJobNodeStatus(node storageNode, cj *batchv1.CronJob) vmv1beta1.BackupJobNodeStatus {
+	st := vmv1beta1.BackupJobNodeStatus{
+		Node:            node.podName,
+		JobName:         cj.Name,
+		Phase:           vmv1beta1.BackupJobPhaseScheduled,
+		LastSuccessTime: cj.Status.LastSuccessfulTime,
+	}
+	if len(cj.Status.Active) > 0 {
+		st.Phase = vmv1beta1.BackupJobPhaseRunning
+	}
+	return st
+}
+
+// removeOrphanedJobs deletes jobs and cronjobs created for the given object, which are not in use anymore
+func removeOrphanedJobs(ctx context.Context, rclient client.Client, cr jobOwner, keepJobs, keepCronJobs map[string]struct{}) error {
+	opts := []client.ListOption{client.InNamespace(cr.GetNamespace()), client.MatchingLabels(cr.SelectorLabels())}
+	var jobs batchv1.JobList
+	if err := rclient.List(ctx, &jobs, opts...); err != nil {
+		return fmt.Errorf("cannot list jobs: %w", err)
+	}
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if _, ok := keepJobs[job.Name]; ok || !isOwnedBy(job, cr) {
+			continue
+		}
+		logger.WithContext(ctx).Info("removing orphaned job", "job_name", job.Name)
+		if err := rclient.Delete(ctx, job, &client.DeleteOptions{PropagationPolicy: ptr.To(metav1.DeletePropagationBackground)}); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("cannot delete orphaned job=%q: %w", job.Name, err)
+		}
+	}
+	var cronJobs batchv1.CronJobList
+	if err := rclient.List(ctx, &cronJobs, opts...); err != nil {
+		return fmt.Errorf("cannot list cronjobs: %w", err)
+	}
+	for i := range cronJobs.Items {
+		cj := &cronJobs.Items[i]
+		if _, ok := keepCronJobs[cj.Name]; ok {
+			continue
+		}
+		logger.WithContext(ctx).Info("removing orphaned cronjob", "cronjob_name", cj.Name)
+		if err := rclient.Delete(ctx, cj, &client.DeleteOptions{PropagationPolicy: ptr.To(metav1.DeletePropagationBackground)}); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("cannot delete orphaned cronjob=%q: %w", cj.Name, err)
+		}
+	}
+	return nil
+}
+
+// isOwnedBy checks if job was created by given object directly
+// jobs created by cronjob have the same labels, but must be managed by cronjob controller
+func isOwnedBy(obj client.Object, owner client.Object) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == owner.GetUID() {
+			return true
+		}
+	}
+	return false
+}
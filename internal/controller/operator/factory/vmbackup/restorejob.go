@@ -0,0 +1,116 @@
+package vmbackup
+
+import (
+	"context"
+	"fmt"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CreateOrUpdateVMRestoreJob creates vmrestore Job per storage node of the target
+// and updates status of the given VMRestoreJob
+//
+// Restore requires stopped storage, so it waits until storage pods are removed.
+// Missing persistent volume claims are created in advance, it allows to restore data
+// for VMSingle or VMCluster created with paused: true at the new namespace.
+func CreateOrUpdateVMRestoreJob(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMRestoreJob) error {
+	if cr.IsFinished() {
+		// finished jobs could be already removed and storage started with restored data
+		logger.WithContext(ctx).Info("skipping reconcile of finished restore", "generation", cr.Generation)
+		return nil
+	}
+	nodes, err := getStorageNodes(ctx, rclient, cr.Spec.Target, cr.Namespace)
+	if err != nil {
+		return err
+	}
+	finished := finishedNodes(cr.Generation, cr.Status.ObservedGeneration, cr.Status.Nodes)
+	keepJobs := make(map[string]struct{})
+	nodeStatuses := make([]vmv1beta1.BackupJobNodeStatus, 0, len(nodes))
+	for idx, node := range nodes {
+		name := jobName(cr, idx)
+		keepJobs[name] = struct{}{}
+		if st, ok := finished[name]; ok {
+			nodeStatuses = append(nodeStatuses, st)
+			continue
+		}
+		var existJob batchv1.Job
+		err := rclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: name}, &existJob)
+		switch {
+		case err == nil:
+		case errors.IsNotFound(err):
+			// check preconditions only before job creation
+			// storage pod could be started after successful restore
+			if err := ensureStorageStopped(ctx, rclient, cr.Namespace, node); err != nil {
+				return err
+			}
+			if err := ensureRestoreClaim(ctx, rclient, node); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("cannot get restore job=%q: %w", name, err)
+		}
+		job, err := reconcile.Job(ctx, rclient, &batchv1.Job{
+			ObjectMeta: jobMeta(cr, name),
+			Spec:       buildVMRestoreJobSpec(cr, node),
+		})
+		if err != nil {
+			return fmt.Errorf("cannot reconcile restore job for node=%q: %w", node.podName, err)
+		}
+		nodeStatuses = append(nodeStatuses, jobNodeStatus(node, name, job))
+	}
+	if err := removeOrphanedJobs(ctx, rclient, cr, keepJobs, nil); err != nil {
+		return err
+	}
+	return cr.SetNodesStatus(ctx, rclient, nodeStatuses)
+}
+
+// ensureStorageStopped checks that storage pod doesn't use volume
+func ensureStorageStopped(ctx context.Context, rclient client.Client, ns string, node storageNode) error {
+	var pods corev1.PodList
+	if err := rclient.List(ctx, &pods, client.InNamespace(ns), client.MatchingLabels(node.podLabels)); err != nil {
+		return fmt.Errorf("cannot list storage pods: %w", err)
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		return fmt.Errorf("storage pod=%q is running, restore requires stopped storage. Create target with paused: true or scale it down", pod.Name)
+	}
+	return nil
+}
+
+// ensureRestoreClaim creates persistent volume claim for storage node if it's missing
+func ensureRestoreClaim(ctx context.Context, rclient client.Client, node storageNode) error {
+	var pvc corev1.PersistentVolumeClaim
+	if err := rclient.Get(ctx, types.NamespacedName{Namespace: node.claim.Namespace, Name: node.claim.Name}, &pvc); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("cannot get pvc=%q: %w", node.claim.Name, err)
+		}
+		logger.WithContext(ctx).Info("creating pvc for restore", "pvc_name", node.claim.Name)
+		if err := rclient.Create(ctx, node.claim); err != nil {
+			return fmt.Errorf("cannot create pvc=%q: %w", node.claim.Name, err)
+		}
+	}
+	return nil
+}
+
+func buildVMRestoreJobSpec(cr *vmv1beta1.VMRestoreJob, node storageNode) batchv1.JobSpec {
+	params := &cr.Spec.BackupJobCommonParams
+	args := []string{
+		fmt.Sprintf("-src=%s", nodeRemotePath(cr.Spec.Source, node, cr.Spec.SourceDisableSuffixAdd)),
+	}
+	if cr.Spec.SkipBackupCompleteCheck {
+		args = append(args, "-skipBackupCompleteCheck")
+	}
+	args = buildArgs(args, params, node.storagePath)
+
+	template := buildPodTemplate(cr, params, node, "vmrestore", args, false)
+	return buildJobSpec(params, template)
+}
@@ -0,0 +1,137 @@
+package vmbackup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// vmSingleDataDir must be in sync with vmsingle factory
+	vmSingleDataDir  = "/victoria-metrics-data"
+	dataVolumeName   = "data"
+	vmBackuperCreds  = "/etc/vm/creds"
+	podNameLabelName = "statefulset.kubernetes.io/pod-name"
+)
+
+// storageNode describes single storage pod of backup target
+type storageNode struct {
+	// podName is a name of storage pod
+	podName string
+	// claim is a persistent volume claim of storage pod
+	claim *corev1.PersistentVolumeClaim
+	// storagePath is a mount path of storage data
+	storagePath string
+	// baseURL is an http address of storage pod
+	baseURL   string
+	extraArgs map[string]string
+	// podLabels allows to select storage pod
+	podLabels map[string]string
+	isCluster bool
+}
+
+// getStorageNodes returns storage nodes of the referenced VMSingle or VMCluster
+func getStorageNodes(ctx context.Context, rclient client.Client, ref vmv1beta1.BackupTargetRef, ns string) ([]storageNode, error) {
+	nsn := types.NamespacedName{Name: ref.Name, Namespace: ns}
+	switch ref.Kind {
+	case vmv1beta1.BackupTargetKindVMSingle:
+		var cr vmv1beta1.VMSingle
+		if err := rclient.Get(ctx, nsn, &cr); err != nil {
+			return nil, fmt.Errorf("cannot get target vmsingle=%q: %w", ref.Name, err)
+		}
+		rclient.Scheme().Default(&cr)
+		return vmSingleStorageNodes(&cr)
+	case vmv1beta1.BackupTargetKindVMCluster:
+		var cr vmv1beta1.VMCluster
+		if err := rclient.Get(ctx, nsn, &cr); err != nil {
+			return nil, fmt.Errorf("cannot get target vmcluster=%q: %w", ref.Name, err)
+		}
+		rclient.Scheme().Default(&cr)
+		return vmClusterStorageNodes(&cr)
+	default:
+		return nil, fmt.Errorf("unsupported target kind=%q, supported kinds: %s,%s", ref.Kind, vmv1beta1.BackupTargetKindVMSingle, vmv1beta1.BackupTargetKindVMCluster)
+	}
+}
+
+func vmSingleStorageNodes(cr *vmv1beta1.VMSingle) ([]storageNode, error) {
+	if cr.Spec.Storage == nil || cr.Spec.StorageDataPath != "" {
+		return nil, fmt.Errorf("vmsingle=%q must have persistent storage configured with spec.storage", cr.Name)
+	}
+	node := storageNode{
+		podName: cr.PrefixedName(),
+		claim: &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        cr.PrefixedName(),
+				Namespace:   cr.Namespace,
+				Labels:      labels.Merge(cr.Spec.StorageMetadata.Labels, cr.SelectorLabels()),
+				Annotations: cr.Spec.StorageMetadata.Annotations,
+			},
+			Spec: *cr.Spec.Storage.DeepCopy(),
+		},
+		storagePath: vmSingleDataDir,
+		baseURL:     cr.AsURL(),
+		extraArgs:   cr.Spec.ExtraArgs,
+		podLabels:   cr.SelectorLabels(),
+	}
+	return []storageNode{node}, nil
+}
+
+func vmClusterStorageNodes(cr *vmv1beta1.VMCluster) ([]storageNode, error) {
+	storage := cr.Spec.VMStorage
+	if storage == nil {
+		return nil, fmt.Errorf("vmcluster=%q has no vmstorage configured", cr.Name)
+	}
+	if storage.Storage == nil || storage.Storage.EmptyDir != nil {
+		return nil, fmt.Errorf("vmcluster=%q must have persistent storage configured with spec.vmstorage.storage.volumeClaimTemplate", cr.Name)
+	}
+	stsName := storage.GetNameWithPrefix(cr.Name)
+	volumeName := storage.GetStorageVolumeName()
+	claimTemplate := storage.Storage.VolumeClaimTemplate
+	var replicas int32 = 1
+	if storage.ReplicaCount != nil {
+		replicas = *storage.ReplicaCount
+	}
+	nodes := make([]storageNode, 0, replicas)
+	for i := int32(0); i < replicas; i++ {
+		podName := fmt.Sprintf("%s-%d", stsName, i)
+		claim := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				// must be in sync with statefulset controller naming
+				Name:        fmt.Sprintf("%s-%s", volumeName, podName),
+				Namespace:   cr.Namespace,
+				Labels:      labels.Merge(claimTemplate.Labels, cr.VMStorageSelectorLabels()),
+				Annotations: claimTemplate.Annotations,
+			},
+			Spec: *claimTemplate.Spec.DeepCopy(),
+		}
+		if claim.Spec.AccessModes == nil {
+			claim.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+		}
+		addr := strings.TrimSuffix(storage.BuildPodName(stsName, i, cr.Namespace, storage.Port, cr.Spec.ClusterDomainName), ",")
+		nodes = append(nodes, storageNode{
+			podName:     podName,
+			claim:       claim,
+			storagePath: storage.StorageDataPath,
+			baseURL:     "http://" + addr,
+			extraArgs:   storage.ExtraArgs,
+			podLabels:   map[string]string{podNameLabelName: podName},
+			isCluster:   true,
+		})
+	}
+	return nodes, nil
+}
+
+// nodeRemotePath builds remote storage path for the given node
+func nodeRemotePath(base string, node storageNode, disableSuffix bool) string {
+	if !node.isCluster || disableSuffix {
+		return base
+	}
+	return strings.TrimSuffix(base, "/") + "/" + node.podName + "/"
+}
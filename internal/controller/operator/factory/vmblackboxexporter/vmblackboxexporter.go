@@ -0,0 +1,403 @@
+package vmblackboxexporter
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+	"github.com/ghodss/yaml"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	configVolumeName = "config"
+	configDir        = "/etc/blackbox_exporter"
+	configFileName   = "config.yml"
+)
+
+// CreateOrUpdateVMBlackboxExporter creates blackbox exporter deployment for given CRD
+func CreateOrUpdateVMBlackboxExporter(ctx context.Context, cr *vmv1beta1.VMBlackboxExporter, rclient client.Client) error {
+	if err := deletePrevStateResources(ctx, cr, rclient); err != nil {
+		return fmt.Errorf("cannot delete objects from previous state: %w", err)
+	}
+	if cr.IsOwnsServiceAccount() {
+		if err := reconcile.ServiceAccount(ctx, rclient, build.ServiceAccount(cr)); err != nil {
+			return fmt.Errorf("failed create service account: %w", err)
+		}
+	}
+	if err := createOrUpdateConfigMap(ctx, rclient, cr); err != nil {
+		return err
+	}
+
+	svc, err := createOrUpdateService(ctx, rclient, cr)
+	if err != nil {
+		return err
+	}
+	if !ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) {
+		err := reconcile.VMServiceScrapeForCRD(ctx, rclient, build.VMServiceScrapeForServiceWithSpec(svc, cr))
+		if err != nil {
+			return fmt.Errorf("cannot create vmservicescrape: %w", err)
+		}
+	}
+	if cr.Spec.PodDisruptionBudget != nil {
+		if err := reconcile.PDB(ctx, rclient, build.PodDisruptionBudget(cr, cr.Spec.PodDisruptionBudget)); err != nil {
+			return fmt.Errorf("cannot update pod disruption budget for vmblackboxexporter: %w", err)
+		}
+	}
+
+	var prevDeploy *appsv1.Deployment
+	if cr.ParsedLastAppliedSpec != nil {
+		prevCR := cr.DeepCopy()
+		prevCR.Spec = *cr.ParsedLastAppliedSpec
+		prevDeploy, err = newDeployForVMBlackboxExporter(prevCR)
+		if err != nil {
+			return fmt.Errorf("cannot generate prev deploy spec: %w", err)
+		}
+	}
+	newDeploy, err := newDeployForVMBlackboxExporter(cr)
+	if err != nil {
+		return vmv1beta1.NewConfigError(fmt.Errorf("cannot generate new deploy for vmblackboxexporter: %w", err))
+	}
+	if err := reconcile.Deployment(ctx, rclient, newDeploy, prevDeploy, false); err != nil {
+		return err
+	}
+	if cr.Spec.VPA != nil {
+		targetRef := autoscalingv1.CrossVersionObjectReference{
+			Name:       newDeploy.Name,
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		}
+		vpa := build.VPA(targetRef, "blackbox-exporter", cr.Spec.VPA, cr.AsOwner(), cr.SelectorLabels(), cr.Namespace)
+		if err := reconcile.VPA(ctx, rclient, vpa); err != nil {
+			return fmt.Errorf("cannot update vpa for vmblackboxexporter: %w", err)
+		}
+	}
+
+	probes, err := selectProbes(ctx, rclient, cr)
+	if err != nil {
+		return err
+	}
+	if err := cr.SetProbesStatus(ctx, rclient, probes); err != nil {
+		return fmt.Errorf("cannot update probes status: %w", err)
+	}
+	return nil
+}
+
+// selectProbes returns sorted list of VMProbe objects, which reference given blackbox exporter
+func selectProbes(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMBlackboxExporter) ([]string, error) {
+	var probes []string
+	if err := k8stools.ListObjectsByNamespace(ctx, rclient, config.MustGetWatchNamespaces(), func(l *vmv1beta1.VMProbeList) {
+		for _, item := range l.Items {
+			ref := item.Spec.VMProberSpec.BlackboxExporterRef
+			if ref == nil || ref.Name != cr.Name {
+				continue
+			}
+			namespace := ref.Namespace
+			if namespace == "" {
+				namespace = item.Namespace
+			}
+			if namespace != cr.Namespace {
+				continue
+			}
+			probes = append(probes, fmt.Sprintf("%s/%s", item.Namespace, item.Name))
+		}
+	}); err != nil {
+		return nil, fmt.Errorf("cannot list vmprobes: %w", err)
+	}
+	sort.Strings(probes)
+	return probes, nil
+}
+
+func buildConfig(cr *vmv1beta1.VMBlackboxExporter) ([]byte, error) {
+	return yaml.Marshal(struct {
+		Modules map[string]vmv1beta1.BlackboxModule `json:"modules"`
+	}{
+		Modules: cr.Spec.Modules,
+	})
+}
+
+func createOrUpdateConfigMap(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMBlackboxExporter) error {
+	data, err := buildConfig(cr)
+	if err != nil {
+		return vmv1beta1.NewConfigError(fmt.Errorf("cannot build vmblackboxexporter config: %w", err))
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cr.PrefixedName(),
+			Annotations:     cr.AnnotationsFiltered(),
+			Labels:          cr.AllLabels(),
+			Namespace:       cr.Namespace,
+			OwnerReferences: cr.AsOwner(),
+			Finalizers:      []string{vmv1beta1.FinalizerName},
+		},
+		Data: map[string]string{configFileName: string(data)},
+	}
+	return reconcile.ConfigMap(ctx, rclient, cm)
+}
+
+// createOrUpdateService creates service for vmblackboxexporter
+func createOrUpdateService(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMBlackboxExporter) (*corev1.Service, error) {
+	newService := build.Service(cr, cr.Spec.Port, nil)
+	if err := cr.Spec.ServiceSpec.IsSomeAndThen(func(s *vmv1beta1.AdditionalServiceSpec) error {
+		additionalSvc := build.AdditionalServiceFromDefault(newService, s)
+		if additionalSvc.Name == newService.Name {
+			logger.WithContext(ctx).Error(fmt.Errorf("vmblackboxexporter additional service name: %q cannot be the same as crd.prefixedname: %q", additionalSvc.Name, cr.PrefixedName()), "cannot create additional service")
+		} else if err := reconcile.Service(ctx, rclient, additionalSvc, nil); err != nil {
+			return fmt.Errorf("cannot reconcile additional service for vmblackboxexporter: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	var prevService *corev1.Service
+	if cr.ParsedLastAppliedSpec != nil {
+		prevCR := cr.DeepCopy()
+		prevCR.Spec = *cr.ParsedLastAppliedSpec
+		prevService = build.Service(prevCR, prevCR.Spec.Port, nil)
+	}
+	if err := reconcile.Service(ctx, rclient, newService, prevService); err != nil {
+		return nil, fmt.Errorf("cannot reconcile service for vmblackboxexporter: %w", err)
+	}
+	return newService, nil
+}
+
+func newDeployForVMBlackboxExporter(cr *vmv1beta1.VMBlackboxExporter) (*appsv1.Deployment, error) {
+	podSpec, err := newPodSpecForVMBlackboxExporter(cr)
+	if err != nil {
+		return nil, err
+	}
+	strategyType := appsv1.RollingUpdateDeploymentStrategyType
+	if cr.Spec.UpdateStrategy != nil {
+		strategyType = *cr.Spec.UpdateStrategy
+	}
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cr.PrefixedName(),
+			Namespace:       cr.Namespace,
+			Labels:          cr.AllLabels(),
+			Annotations:     cr.AnnotationsFiltered(),
+			OwnerReferences: cr.AsOwner(),
+			Finalizers:      []string{vmv1beta1.FinalizerName},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: cr.SelectorLabels(),
+			},
+			Strategy: appsv1.DeploymentStrategy{
+				Type:          strategyType,
+				RollingUpdate: cr.Spec.RollingUpdate,
+			},
+			Template: *podSpec,
+		},
+	}
+	build.DeploymentAddCommonParams(deploy, ptr.Deref(cr.Spec.UseStrictSecurity, false), &cr.Spec.CommonApplicationDeploymentParams)
+	return deploy, nil
+}
+
+func buildArgs(cr *vmv1beta1.VMBlackboxExporter) []string {
+	args := []string{
+		fmt.Sprintf("--config.file=%s", path.Join(configDir, configFileName)),
+		fmt.Sprintf("--web.listen-address=:%s", cr.Spec.Port),
+	}
+	if cr.Spec.LogLevel != "" {
+		args = append(args, fmt.Sprintf("--log.level=%s", cr.Spec.LogLevel))
+	}
+	args = build.AddExtraArgsOverrideDefaults(args, cr.Spec.ExtraArgs, "--")
+	sort.Strings(args)
+	return args
+}
+
+func newPodSpecForVMBlackboxExporter(cr *vmv1beta1.VMBlackboxExporter) (*corev1.PodTemplateSpec, error) {
+	args := buildArgs(cr)
+
+	var volumes []corev1.Volume
+	volumes = append(volumes, cr.Spec.Volumes...)
+	volumes = append(volumes, corev1.Volume{
+		Name: configVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: cr.PrefixedName(),
+				},
+			},
+		},
+	})
+	var volumeMounts []corev1.VolumeMount
+	volumeMounts = append(volumeMounts, cr.Spec.VolumeMounts...)
+	volumeMounts = append(volumeMounts, corev1.VolumeMount{
+		Name:      configVolumeName,
+		ReadOnly:  true,
+		MountPath: configDir,
+	})
+
+	for _, s := range cr.Spec.Secrets {
+		volumes = append(volumes, corev1.Volume{
+			Name: k8stools.SanitizeVolumeName("secret-" + s),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: s,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      k8stools.SanitizeVolumeName("secret-" + s),
+			ReadOnly:  true,
+			MountPath: path.Join(vmv1beta1.SecretsDir, s),
+		})
+	}
+	for _, c := range cr.Spec.ConfigMaps {
+		volumes = append(volumes, corev1.Volume{
+			Name: k8stools.SanitizeVolumeName("configmap-" + c),
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: c,
+					},
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      k8stools.SanitizeVolumeName("configmap-" + c),
+			ReadOnly:  true,
+			MountPath: path.Join(vmv1beta1.ConfigMapsDir, c),
+		})
+	}
+
+	// sort for consistency
+	sort.Slice(volumes, func(i, j int) bool {
+		return volumes[i].Name < volumes[j].Name
+	})
+	sort.Slice(volumeMounts, func(i, j int) bool {
+		return volumeMounts[i].Name < volumeMounts[j].Name
+	})
+
+	ports := []corev1.ContainerPort{{Name: "http", Protocol: "TCP", ContainerPort: intstr.Parse(cr.Spec.Port).IntVal}}
+	container := corev1.Container{
+		Args:                     args,
+		Name:                     "blackbox-exporter",
+		Image:                    fmt.Sprintf("%s:%s", cr.Spec.Image.Repository, cr.Spec.Image.Tag),
+		ImagePullPolicy:          cr.Spec.Image.PullPolicy,
+		Ports:                    ports,
+		VolumeMounts:             volumeMounts,
+		Resources:                cr.Spec.Resources,
+		Env:                      cr.Spec.ExtraEnvs,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+	container = build.Probe(container, cr)
+	operatorContainers := []corev1.Container{container, buildConfigReloaderContainer(cr)}
+
+	build.AddStrictSecuritySettingsToContainers(cr.Spec.SecurityContext, operatorContainers, ptr.Deref(cr.Spec.UseStrictSecurity, false))
+	containers, err := k8stools.MergePatchContainers(operatorContainers, cr.Spec.Containers)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range cr.Spec.TopologySpreadConstraints {
+		if cr.Spec.TopologySpreadConstraints[i].LabelSelector == nil {
+			cr.Spec.TopologySpreadConstraints[i].LabelSelector = &metav1.LabelSelector{
+				MatchLabels: cr.SelectorLabels(),
+			}
+		}
+	}
+
+	return &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      cr.PodLabels(),
+			Annotations: cr.PodAnnotations(),
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: cr.GetServiceAccountName(),
+			InitContainers:     cr.Spec.InitContainers,
+			Containers:         containers,
+			Volumes:            volumes,
+		},
+	}, nil
+}
+
+// buildConfigReloaderContainer builds sidecar, which triggers blackbox exporter config reload on configmap change
+func buildConfigReloaderContainer(cr *vmv1beta1.VMBlackboxExporter) corev1.Container {
+	volumeWatchArg := "-volume-dir"
+	reloadURLArg := "-webhook-url"
+	useCustomConfigReloader := ptr.Deref(cr.Spec.UseVMConfigReloader, false)
+	if useCustomConfigReloader {
+		volumeWatchArg = "--watched-dir"
+		reloadURLArg = "--reload-url"
+	}
+	confReloadArgs := []string{
+		fmt.Sprintf("%s=http://localhost:%s/-/reload", reloadURLArg, cr.Spec.Port),
+		fmt.Sprintf("%s=%s", volumeWatchArg, configDir),
+	}
+	if len(cr.Spec.ConfigReloaderExtraArgs) > 0 {
+		for idx, arg := range confReloadArgs {
+			cleanArg := strings.Split(strings.TrimLeft(arg, "-"), "=")[0]
+			if replacement, ok := cr.Spec.ConfigReloaderExtraArgs[cleanArg]; ok {
+				delete(cr.Spec.ConfigReloaderExtraArgs, cleanArg)
+				confReloadArgs[idx] = fmt.Sprintf(`--%s=%s`, cleanArg, replacement)
+			}
+		}
+		for k, v := range cr.Spec.ConfigReloaderExtraArgs {
+			confReloadArgs = append(confReloadArgs, fmt.Sprintf(`--%s=%s`, k, v))
+		}
+		sort.Strings(confReloadArgs)
+	}
+	configReloaderContainer := corev1.Container{
+		Name:                     "config-reloader",
+		Image:                    cr.Spec.ConfigReloaderImageTag,
+		Args:                     confReloadArgs,
+		Resources:                cr.Spec.ConfigReloaderResources,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      configVolumeName,
+				ReadOnly:  true,
+				MountPath: configDir,
+			},
+		},
+	}
+	build.AddsPortProbesToConfigReloaderContainer(useCustomConfigReloader, &configReloaderContainer)
+	return configReloaderContainer
+}
+
+func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VMBlackboxExporter, rclient client.Client) error {
+	if cr.ParsedLastAppliedSpec == nil {
+		return nil
+	}
+	prevSvc, currSvc := cr.ParsedLastAppliedSpec.ServiceSpec, cr.Spec.ServiceSpec
+	if err := reconcile.AdditionalServices(ctx, rclient, cr.PrefixedName(), cr.Namespace, prevSvc, currSvc); err != nil {
+		return fmt.Errorf("cannot remove additional service: %w", err)
+	}
+	if err := reconcile.RemoveOrphanedVPA(ctx, rclient, cr.PrefixedName(), cr.Namespace, cr.ParsedLastAppliedSpec.VPA, cr.Spec.VPA); err != nil {
+		return err
+	}
+
+	objMeta := metav1.ObjectMeta{Name: cr.PrefixedName(), Namespace: cr.Namespace}
+	if cr.Spec.PodDisruptionBudget == nil && cr.ParsedLastAppliedSpec.PodDisruptionBudget != nil {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &policyv1.PodDisruptionBudget{ObjectMeta: objMeta}); err != nil {
+			return fmt.Errorf("cannot delete PDB from prev state: %w", err)
+		}
+	}
+
+	if ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) && !ptr.Deref(cr.ParsedLastAppliedSpec.DisableSelfServiceScrape, false) {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, &vmv1beta1.VMServiceScrape{ObjectMeta: objMeta}); err != nil {
+			return fmt.Errorf("cannot remove serviceScrape: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package vmblackboxexporter
+
+import (
+	"context"
+	"testing"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestCreateOrUpdateVMBlackboxExporter(t *testing.T) {
+	cr := &vmv1beta1.VMBlackboxExporter{
+		ObjectMeta: metav1.ObjectMeta{Name: "probes", Namespace: "default"},
+		Spec: vmv1beta1.VMBlackboxExporterSpec{
+			LogLevel: "debug",
+			Modules: map[string]vmv1beta1.BlackboxModule{
+				"http_2xx": {
+					Prober:  "http",
+					Timeout: "5s",
+					HTTP: &vmv1beta1.BlackboxHTTPProbe{
+						BlackboxIPProtocol: vmv1beta1.BlackboxIPProtocol{PreferredIPProtocol: "ip4"},
+						ValidStatusCodes:   []int{200},
+					},
+				},
+				"dns": {
+					Prober: "dns",
+					DNS:    &vmv1beta1.BlackboxDNSProbe{QueryName: "example.com", QueryType: "A"},
+				},
+			},
+		},
+	}
+	probes := []runtime.Object{
+		&vmv1beta1.VMProbe{
+			ObjectMeta: metav1.ObjectMeta{Name: "same-ns", Namespace: "default"},
+			Spec: vmv1beta1.VMProbeSpec{
+				VMProberSpec: vmv1beta1.VMProberSpec{BlackboxExporterRef: &vmv1beta1.VMBlackboxExporterRef{Name: "probes"}},
+			},
+		},
+		&vmv1beta1.VMProbe{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-ns", Namespace: "monitoring"},
+			Spec: vmv1beta1.VMProbeSpec{
+				VMProberSpec: vmv1beta1.VMProberSpec{BlackboxExporterRef: &vmv1beta1.VMBlackboxExporterRef{Name: "probes", Namespace: "default"}},
+			},
+		},
+		&vmv1beta1.VMProbe{
+			ObjectMeta: metav1.ObjectMeta{Name: "wrong-ns", Namespace: "monitoring"},
+			Spec: vmv1beta1.VMProbeSpec{
+				VMProberSpec: vmv1beta1.VMProberSpec{BlackboxExporterRef: &vmv1beta1.VMBlackboxExporterRef{Name: "probes"}},
+			},
+		},
+		&vmv1beta1.VMProbe{
+			ObjectMeta: metav1.ObjectMeta{Name: "url", Namespace: "default"},
+			Spec: vmv1beta1.VMProbeSpec{
+				VMProberSpec: vmv1beta1.VMProberSpec{URL: "blackbox:9115"},
+			},
+		},
+		// predefine deployment as ready
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "vmblackboxexporter-probes", Namespace: "default"},
+			Status: appsv1.DeploymentStatus{
+				Replicas:          1,
+				UpdatedReplicas:   1,
+				AvailableReplicas: 1,
+				Conditions: []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentProgressing, Reason: "NewReplicaSetAvailable", Status: "True"},
+				},
+			},
+		},
+	}
+	ctx := context.Background()
+	fclient := k8stools.GetTestClientWithObjects(append(probes, cr))
+	build.AddDefaults(fclient.Scheme())
+	fclient.Scheme().Default(cr)
+
+	if err := CreateOrUpdateVMBlackboxExporter(ctx, cr, fclient); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var cm corev1.ConfigMap
+	if err := fclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "vmblackboxexporter-probes"}, &cm); err != nil {
+		t.Fatalf("cannot get configmap: %s", err)
+	}
+	assert.Equal(t, `modules:
+  dns:
+    dns:
+      query_name: example.com
+      query_type: A
+    prober: dns
+  http_2xx:
+    http:
+      preferred_ip_protocol: ip4
+      valid_status_codes:
+      - 200
+    prober: http
+    timeout: 5s
+`, cm.Data["config.yml"])
+
+	var dep appsv1.Deployment
+	if err := fclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "vmblackboxexporter-probes"}, &dep); err != nil {
+		t.Fatalf("cannot get deployment: %s", err)
+	}
+	containers := dep.Spec.Template.Spec.Containers
+	assert.Len(t, containers, 2)
+	assert.Equal(t, []string{
+		"--config.file=/etc/blackbox_exporter/config.yml",
+		"--log.level=debug",
+		"--web.listen-address=:9115",
+	}, containers[0].Args)
+	assert.Equal(t, "config-reloader", containers[1].Name)
+	assert.Contains(t, containers[1].Args, "-webhook-url=http://localhost:9115/-/reload")
+
+	var svc corev1.Service
+	if err := fclient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "vmblackboxexporter-probes"}, &svc); err != nil {
+		t.Fatalf("cannot get service: %s", err)
+	}
+	assert.Equal(t, []string{"default/same-ns", "monitoring/other-ns"}, cr.Status.Probes)
+	assert.Equal(t, "vmblackboxexporter-probes.default.svc:9115", cr.ProberAddress())
+}
@@ -0,0 +1,151 @@
+package vmcluster
+
+import (
+	"context"
+	"fmt"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// createOrUpdateVMStorageNetworkPolicy allows vminsert and vmselect connections to vmstorage
+// and clients access to http and vmbackupmanager ports
+func createOrUpdateVMStorageNetworkPolicy(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMCluster, svc *corev1.Service) error {
+	vmst := cr.Spec.VMStorage
+	peers, err := build.NetworkPolicyScrapePeers(ctx, rclient, cr.Spec.NetworkPolicy, cr.Namespace)
+	if err != nil {
+		return err
+	}
+	insertPort := intstr.Parse(vmst.VMInsertPort)
+	selectPort := intstr.Parse(vmst.VMSelectPort)
+	var clientPorts []networkingv1.NetworkPolicyPort
+	for _, p := range build.NetworkPolicyPortsFromService(svc) {
+		if p.Port.String() == insertPort.String() || p.Port.String() == selectPort.String() {
+			continue
+		}
+		clientPorts = append(clientPorts, p)
+	}
+	rules := &build.NetworkPolicyRules{
+		Ports: clientPorts,
+		Peers: peers,
+		Ingress: []networkingv1.NetworkPolicyIngressRule{
+			{
+				Ports: []networkingv1.NetworkPolicyPort{{Protocol: ptr.To(corev1.ProtocolTCP), Port: &insertPort}},
+				From:  []networkingv1.NetworkPolicyPeer{build.NetworkPolicyPeerForPods(componentPeerLabels(cr, cr.VMInsertSelectorLabels()), cr.Namespace)},
+			},
+			{
+				Ports: []networkingv1.NetworkPolicyPort{{Protocol: ptr.To(corev1.ProtocolTCP), Port: &selectPort}},
+				From:  []networkingv1.NetworkPolicyPeer{build.NetworkPolicyPeerForPods(componentPeerLabels(cr, cr.VMSelectSelectorLabels()), cr.Namespace)},
+			},
+		},
+	}
+	t := &clusterSvcBuilder{
+		cr,
+		vmst.GetNameWithPrefix(cr.Name),
+		cr.FinalLabels(cr.VMStorageSelectorLabels()),
+		cr.VMStorageSelectorLabels(),
+		nil,
+	}
+	if err := reconcile.NetworkPolicy(ctx, rclient, build.NetworkPolicy(t, cr.Spec.NetworkPolicy, rules)); err != nil {
+		return fmt.Errorf("cannot update network policy for vmstorage: %w", err)
+	}
+	return nil
+}
+
+// createOrUpdateVMSelectNetworkPolicy allows clients access to vmselect
+// and restricts egress to vmstorage vmselect port
+func createOrUpdateVMSelectNetworkPolicy(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMCluster, svc *corev1.Service) error {
+	peers, err := vmauthLBNetworkPolicyPeers(ctx, rclient, cr, !cr.Spec.RequestsLoadBalancer.DisableSelectBalancing)
+	if err != nil {
+		return err
+	}
+	rules := &build.NetworkPolicyRules{
+		Ports: build.NetworkPolicyPortsFromService(svc),
+		Peers: peers,
+	}
+	if cr.Spec.VMStorage != nil {
+		rules.Egress = []networkingv1.NetworkPolicyEgressRule{
+			storageEgressRule(cr, cr.Spec.VMStorage.VMSelectPort),
+		}
+	}
+	t := &clusterSvcBuilder{
+		cr,
+		cr.GetSelectName(),
+		cr.FinalLabels(cr.VMSelectSelectorLabels()),
+		cr.VMSelectSelectorLabels(),
+		nil,
+	}
+	if err := reconcile.NetworkPolicy(ctx, rclient, build.NetworkPolicy(t, cr.Spec.NetworkPolicy, rules)); err != nil {
+		return fmt.Errorf("cannot update network policy for vmselect: %w", err)
+	}
+	return nil
+}
+
+// createOrUpdateVMInsertNetworkPolicy allows clients access to vminsert
+// and restricts egress to vmstorage vminsert port
+func createOrUpdateVMInsertNetworkPolicy(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMCluster, svc *corev1.Service) error {
+	peers, err := vmauthLBNetworkPolicyPeers(ctx, rclient, cr, !cr.Spec.RequestsLoadBalancer.DisableInsertBalancing)
+	if err != nil {
+		return err
+	}
+	rules := &build.NetworkPolicyRules{
+		Ports: build.NetworkPolicyPortsFromService(svc),
+		Peers: peers,
+	}
+	if cr.Spec.VMStorage != nil {
+		rules.Egress = []networkingv1.NetworkPolicyEgressRule{
+			storageEgressRule(cr, cr.Spec.VMStorage.VMInsertPort),
+		}
+	}
+	t := &clusterSvcBuilder{
+		cr,
+		cr.GetInsertName(),
+		cr.FinalLabels(cr.VMInsertSelectorLabels()),
+		cr.VMInsertSelectorLabels(),
+		nil,
+	}
+	if err := reconcile.NetworkPolicy(ctx, rclient, build.NetworkPolicy(t, cr.Spec.NetworkPolicy, rules)); err != nil {
+		return fmt.Errorf("cannot update network policy for vminsert: %w", err)
+	}
+	return nil
+}
+
+// vmauthLBNetworkPolicyPeers returns scrape peers and vmauth balancer pods, if requests are proxied by it
+func vmauthLBNetworkPolicyPeers(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMCluster, isBalanced bool) ([]networkingv1.NetworkPolicyPeer, error) {
+	peers, err := build.NetworkPolicyScrapePeers(ctx, rclient, cr.Spec.NetworkPolicy, cr.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if cr.Spec.RequestsLoadBalancer.Enabled && isBalanced {
+		peers = append(peers, build.NetworkPolicyPeerForPods(cr.VMAuthLBSelectorLabels(), cr.Namespace))
+	}
+	return peers, nil
+}
+
+// storageEgressRule allows connections to vmstorage pods at given port
+func storageEgressRule(cr *vmv1beta1.VMCluster, port string) networkingv1.NetworkPolicyEgressRule {
+	p := intstr.Parse(port)
+	return networkingv1.NetworkPolicyEgressRule{
+		Ports: []networkingv1.NetworkPolicyPort{{Protocol: ptr.To(corev1.ProtocolTCP), Port: &p}},
+		To:    []networkingv1.NetworkPolicyPeer{build.NetworkPolicyPeerForPods(componentPeerLabels(cr, cr.VMStorageSelectorLabels()), cr.Namespace)},
+	}
+}
+
+// componentPeerLabels returns labels matching component pods of the cluster.
+// Components of zone clusters are matched at all zones, since vmselect queries vmstorage nodes of all zones
+func componentPeerLabels(cr *vmv1beta1.VMCluster, selectorLabels map[string]string) map[string]string {
+	if cr.ParentName == "" {
+		return selectorLabels
+	}
+	return map[string]string{
+		"app.kubernetes.io/name": selectorLabels["app.kubernetes.io/name"],
+		"managed-by":             selectorLabels["managed-by"],
+		zoneClusterLabel:         cr.ParentName,
+	}
+}
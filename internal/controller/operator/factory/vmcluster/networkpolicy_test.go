@@ -0,0 +1,83 @@
+package vmcluster
+
+import (
+	"context"
+	"testing"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+func TestCreateOrUpdateVMStorageNetworkPolicy(t *testing.T) {
+	f := func(cr *vmv1beta1.VMCluster, wantSelectPeer map[string]string) {
+		t.Helper()
+		fclient := k8stools.GetTestClientWithObjects(nil)
+		build.AddDefaults(fclient.Scheme())
+		fclient.Scheme().Default(cr)
+		ctx := context.Background()
+		svc, err := createOrUpdateVMStorageService(ctx, cr, fclient)
+		if err != nil {
+			t.Fatalf("cannot create vmstorage service: %s", err)
+		}
+		if err := createOrUpdateVMStorageNetworkPolicy(ctx, fclient, cr, svc); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		var np networkingv1.NetworkPolicy
+		if err := fclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Spec.VMStorage.GetNameWithPrefix(cr.Name)}, &np); err != nil {
+			t.Fatalf("cannot get network policy: %s", err)
+		}
+		if len(np.Spec.Ingress) != 3 {
+			t.Fatalf("expected clients, vminsert and vmselect ingress rules, got: %v", np.Spec.Ingress)
+		}
+		for _, p := range np.Spec.Ingress[0].Ports {
+			if p.Port.String() == cr.Spec.VMStorage.VMInsertPort || p.Port.String() == cr.Spec.VMStorage.VMSelectPort {
+				t.Fatalf("unexpected cluster internal port=%s at clients rule", p.Port)
+			}
+		}
+		insertRule := np.Spec.Ingress[1]
+		if insertRule.Ports[0].Port.String() != cr.Spec.VMStorage.VMInsertPort {
+			t.Fatalf("unexpected vminsert port: %s", insertRule.Ports[0].Port)
+		}
+		selectRule := np.Spec.Ingress[2]
+		if selectRule.Ports[0].Port.String() != cr.Spec.VMStorage.VMSelectPort {
+			t.Fatalf("unexpected vmselect port: %s", selectRule.Ports[0].Port)
+		}
+		if !equality.Semantic.DeepEqual(selectRule.From[0].PodSelector.MatchLabels, wantSelectPeer) {
+			t.Fatalf("unexpected vmselect peer labels: %v", selectRule.From[0].PodSelector.MatchLabels)
+		}
+	}
+	newCluster := func() *vmv1beta1.VMCluster {
+		return &vmv1beta1.VMCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+			Spec: vmv1beta1.VMClusterSpec{
+				VMStorage: &vmv1beta1.VMStorage{
+					CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{ReplicaCount: ptr.To(int32(2))},
+				},
+				NetworkPolicy: &vmv1beta1.EmbeddedNetworkPolicy{},
+			},
+		}
+	}
+
+	// single cluster
+	f(newCluster(), map[string]string{
+		"app.kubernetes.io/name":      "vmselect",
+		"app.kubernetes.io/instance":  "main",
+		"app.kubernetes.io/component": "monitoring",
+		"managed-by":                  "vm-operator",
+	})
+
+	// zone cluster allows vmselect of all zones
+	zc := newCluster().ZoneView("a")
+	zc.Spec.VMStorage.VMBackup = &vmv1beta1.VMBackup{Port: "8300"}
+	f(zc, map[string]string{
+		"app.kubernetes.io/name": "vmselect",
+		"managed-by":             "vm-operator",
+		zoneClusterLabel:         "main",
+	})
+}
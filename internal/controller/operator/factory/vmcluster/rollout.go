@@ -0,0 +1,581 @@
+package vmcluster
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	podRevisionLabel              = "controller-revision-hash"
+	rolloutMetricsRequestTimeout  = 5 * time.Second
+	rolloutRequestsMetricName     = "vm_http_requests_total"
+	rolloutRequestErrorMetricName = "vm_http_request_errors_total"
+)
+
+// rolloutHTTPClient is used for metrics requests to canary pods by IP,
+// so certificate verification is skipped for tls enabled components.
+var rolloutHTTPClient = &http.Client{
+	Timeout: rolloutMetricsRequestTimeout,
+	Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	},
+}
+
+// rolloutTarget is a statefulset, which pods are updated at canary stage
+type rolloutTarget struct {
+	stsName    string
+	podLabels  map[string]string
+	port       string
+	scheme     string
+	metricPath string
+}
+
+// clusterRolloutTargets returns vmstorage and vmselect statefulsets of the given cluster
+func clusterRolloutTargets(cr *vmv1beta1.VMCluster) []rolloutTarget {
+	var targets []rolloutTarget
+	if vms := cr.Spec.VMStorage; vms != nil {
+		targets = append(targets, rolloutTarget{
+			stsName:    vms.GetNameWithPrefix(cr.Name),
+			podLabels:  cr.VMStorageSelectorLabels(),
+			port:       vms.Port,
+			scheme:     vms.ProbeScheme(),
+			metricPath: vms.GetMetricPath(),
+		})
+	}
+	if vmse := cr.Spec.VMSelect; vmse != nil {
+		targets = append(targets, rolloutTarget{
+			stsName:    cr.GetSelectName(),
+			podLabels:  cr.VMSelectSelectorLabels(),
+			port:       vmse.Port,
+			scheme:     vmse.ProbeScheme(),
+			metricPath: vmse.GetMetricPath(),
+		})
+	}
+	return targets
+}
+
+// createOrUpdateWithRollout reconciles cluster according to staged rollout policy.
+//
+// Each spec generation is rolled out with the following stages:
+// Canary - canary pods are updated with canaryStage, the rest of pods and components keep previous version.
+// Pod template of partially updated statefulsets is restored to the previous version,
+// so non-canary pods re-created by kubernetes, e.g. after eviction, keep previous version.
+// Baking - canary pods health is checked until bakeTime passes.
+// Canary stage is repeated, if canary pod was re-created with previous version.
+// Completed - the rest of pods and components are updated with fullStage.
+// Halted or RolledBack - canary failed health checks, rollout is resumed after the next spec change.
+func createOrUpdateWithRollout(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMCluster, targets []rolloutTarget, canaryStage, fullStage func() error) error {
+	policy := cr.Spec.RolloutPolicy
+	if policy == nil {
+		if err := updateRolloutStatus(ctx, rclient, cr, nil); err != nil {
+			return err
+		}
+		return fullStage()
+	}
+	bakeTime, err := policy.GetBakeTime()
+	if err != nil {
+		return vmv1beta1.NewConfigError(err)
+	}
+	maxErrorRate, err := policy.GetMaxErrorRate()
+	if err != nil {
+		return vmv1beta1.NewConfigError(err)
+	}
+	status := cr.Status.Rollout.DeepCopy()
+	// bakingStatus holds canary of the previous generation, which is still baking
+	var bakingStatus *vmv1beta1.VMClusterRolloutStatus
+	if status == nil || status.Generation != cr.Generation {
+		if status != nil && status.Stage == vmv1beta1.VMClusterRolloutBaking {
+			bakingStatus = status
+		}
+		status = &vmv1beta1.VMClusterRolloutStatus{
+			Generation:         cr.Generation,
+			Stage:              vmv1beta1.VMClusterRolloutCanary,
+			LastTransitionTime: metav1.Now(),
+		}
+	}
+	l := logger.WithContext(ctx).WithValues("rollout_generation", status.Generation)
+	switch status.Stage {
+	case vmv1beta1.VMClusterRolloutCompleted:
+		return fullStage()
+	case vmv1beta1.VMClusterRolloutHalted, vmv1beta1.VMClusterRolloutRolledBack:
+		// canary failure is reported at status and CanaryHealthy condition.
+		// cluster is kept as is until the next spec change
+		return nil
+	case vmv1beta1.VMClusterRolloutCanary:
+		if err := updateRolloutStatus(ctx, rclient, cr, status); err != nil {
+			return err
+		}
+		prevRevisions, err := getCurrentRevisions(ctx, rclient, cr.Namespace, targets)
+		if err != nil {
+			return err
+		}
+		canaryErr := canaryStage()
+		if canaryErr != nil && !isRolloutError(canaryErr) {
+			return fmt.Errorf("cannot update canary pods: %w", canaryErr)
+		}
+		canarySts, canaryPods, heldSts, err := collectCanary(ctx, rclient, cr.Namespace, targets, prevRevisions)
+		if err != nil {
+			return err
+		}
+		status = status.DeepCopy()
+		if canaryErr != nil {
+			if len(canaryPods) == 0 {
+				return fmt.Errorf("cannot update canary pods: %w", canaryErr)
+			}
+			// canary pods cannot become ready with the new version
+			status.CanaryPods = canaryPods
+			status.StatefulSets = canarySts
+			return handleCanaryFailure(ctx, rclient, cr, targets, status, canaryErr.Error())
+		}
+		status.LastTransitionTime = metav1.Now()
+		if len(canaryPods) == 0 {
+			// pods weren't changed by the new spec, nothing to bake
+			status.Stage = vmv1beta1.VMClusterRolloutCompleted
+			if err := updateRolloutStatus(ctx, rclient, cr, status); err != nil {
+				return err
+			}
+			return fullStage()
+		}
+		for _, sts := range heldSts {
+			if err := reconcile.RestoreSTSTemplate(ctx, rclient, sts.Name, cr.Namespace, sts.PreviousRevision); err != nil {
+				return fmt.Errorf("cannot restore pod template for non-canary pods: %w", err)
+			}
+		}
+		if bakingStatus != nil && equality.Semantic.DeepEqual(bakingStatus.StatefulSets, canarySts) && equality.Semantic.DeepEqual(bakingStatus.CanaryPods, canaryPods) {
+			// spec change doesn't affect canary pods, there is no need to restart bake time
+			l.Info("canary pods weren't changed by the new spec, continuing bake time", "canary_pods", strings.Join(canaryPods, ","))
+			bakingStatus.Generation = cr.Generation
+			return updateRolloutStatus(ctx, rclient, cr, bakingStatus)
+		}
+		status.CanaryPods = canaryPods
+		status.StatefulSets = canarySts
+		canaryMetrics, reason, err := fetchCanaryMetrics(ctx, rclient, cr.Namespace, targets, canaryPods, maxErrorRate)
+		if err != nil {
+			return err
+		}
+		if reason != "" {
+			return handleCanaryFailure(ctx, rclient, cr, targets, status, reason)
+		}
+		l.Info("canary pods are updated, waiting for bake time", "canary_pods", strings.Join(canaryPods, ","), "bake_time", bakeTime.String())
+		status.Stage = vmv1beta1.VMClusterRolloutBaking
+		status.CanaryMetrics = canaryMetrics
+		return updateRolloutStatus(ctx, rclient, cr, status)
+	case vmv1beta1.VMClusterRolloutBaking:
+		revertedPod, err := findRevertedCanaryPod(ctx, rclient, cr.Namespace, status)
+		if err != nil {
+			return err
+		}
+		if revertedPod != "" {
+			l.Info("canary pod was re-created with previous version, repeating canary stage", "pod", revertedPod)
+			status = status.DeepCopy()
+			status.Stage = vmv1beta1.VMClusterRolloutCanary
+			status.LastTransitionTime = metav1.Now()
+			status.CanaryMetrics = nil
+			return updateRolloutStatus(ctx, rclient, cr, status)
+		}
+		bakeFinished := time.Since(status.LastTransitionTime.Time) >= bakeTime
+		reason, err := checkCanaryHealth(ctx, rclient, cr.Namespace, targets, status, policy, maxErrorRate, bakeFinished)
+		if err != nil {
+			return err
+		}
+		status = status.DeepCopy()
+		if reason != "" {
+			return handleCanaryFailure(ctx, rclient, cr, targets, status, reason)
+		}
+		if !bakeFinished {
+			return updateRolloutStatus(ctx, rclient, cr, status)
+		}
+		l.Info("canary pods are healthy, updating the rest of pods")
+		status.Stage = vmv1beta1.VMClusterRolloutCompleted
+		status.LastTransitionTime = metav1.Now()
+		status.CanaryMetrics = nil
+		if err := updateRolloutStatus(ctx, rclient, cr, status); err != nil {
+			return err
+		}
+		return fullStage()
+	default:
+		return fmt.Errorf("BUG: unexpected rollout stage=%q", status.Stage)
+	}
+}
+
+// handleCanaryFailure stops rollout and rolls back canary pods if it's requested by policy.
+// Failure is terminal for the current generation, so it's reported at status instead of reconcile error
+func handleCanaryFailure(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMCluster, targets []rolloutTarget, status *vmv1beta1.VMClusterRolloutStatus, reason string) error {
+	logger.WithContext(ctx).Info("canary pods failed health check, stopping rollout", "reason", reason, "on_failure", cr.Spec.RolloutPolicy.OnFailure)
+	status.Reason = reason
+	status.LastTransitionTime = metav1.Now()
+	status.Stage = vmv1beta1.VMClusterRolloutHalted
+	status.CanaryMetrics = nil
+	if cr.Spec.RolloutPolicy.OnFailure != vmv1beta1.VMClusterRolloutRollback {
+		// halted rollout keeps canary pods, but the rest of pods must keep previous version
+		for _, sts := range status.StatefulSets {
+			if err := reconcile.RestoreSTSTemplate(ctx, rclient, sts.Name, cr.Namespace, sts.PreviousRevision); err != nil {
+				return fmt.Errorf("cannot restore pod template for non-canary pods: %w", err)
+			}
+		}
+	} else {
+		podLabels := make(map[string]map[string]string, len(targets))
+		for _, t := range targets {
+			podLabels[t.stsName] = t.podLabels
+		}
+		for _, sts := range status.StatefulSets {
+			if err := reconcile.RollbackSTS(ctx, rclient, sts.Name, cr.Namespace, sts.PreviousRevision, podLabels[sts.Name]); err != nil {
+				return fmt.Errorf("cannot rollback canary pods: %w", err)
+			}
+		}
+		status.Stage = vmv1beta1.VMClusterRolloutRolledBack
+	}
+	return updateRolloutStatus(ctx, rclient, cr, status)
+}
+
+// isRolloutError checks if error is caused by failed rollout of child workload
+func isRolloutError(err error) bool {
+	var ce *vmv1beta1.ConditionError
+	return stderrors.As(err, &ce) && ce.Reason == vmv1beta1.ConditionReasonRolloutFailed
+}
+
+// getCurrentRevisions returns current pods revision of existing target statefulsets
+func getCurrentRevisions(ctx context.Context, rclient client.Client, ns string, targets []rolloutTarget) (map[string]string, error) {
+	revisions := make(map[string]string, len(targets))
+	for _, t := range targets {
+		var sts appsv1.StatefulSet
+		if err := rclient.Get(ctx, types.NamespacedName{Namespace: ns, Name: t.stsName}, &sts); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("cannot get statefulset=%s: %w", t.stsName, err)
+		}
+		revisions[t.stsName] = sts.Status.CurrentRevision
+	}
+	return revisions, nil
+}
+
+// collectCanary returns statefulsets with changed revision and names of its pods updated to the new revision.
+// It also returns statefulsets, which have pods with previous revision
+func collectCanary(ctx context.Context, rclient client.Client, ns string, targets []rolloutTarget, prevRevisions map[string]string) ([]vmv1beta1.VMClusterRolloutStatefulSet, []string, []vmv1beta1.VMClusterRolloutStatefulSet, error) {
+	var canarySts, heldSts []vmv1beta1.VMClusterRolloutStatefulSet
+	var canaryPods []string
+	for _, t := range targets {
+		prevRevision, ok := prevRevisions[t.stsName]
+		if !ok || prevRevision == "" {
+			// statefulset was created, there is no previous version
+			continue
+		}
+		var sts appsv1.StatefulSet
+		if err := rclient.Get(ctx, types.NamespacedName{Namespace: ns, Name: t.stsName}, &sts); err != nil {
+			return nil, nil, nil, fmt.Errorf("cannot get statefulset=%s: %w", t.stsName, err)
+		}
+		if sts.Status.UpdateRevision == prevRevision {
+			continue
+		}
+		pods, err := listTargetPods(ctx, rclient, ns, t)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		var updated, held bool
+		for _, pod := range pods {
+			if pod.Labels[podRevisionLabel] == sts.Status.UpdateRevision {
+				canaryPods = append(canaryPods, pod.Name)
+				updated = true
+			} else {
+				held = true
+			}
+		}
+		if updated {
+			rs := vmv1beta1.VMClusterRolloutStatefulSet{
+				Name:             t.stsName,
+				PreviousRevision: prevRevision,
+				UpdateRevision:   sts.Status.UpdateRevision,
+			}
+			canarySts = append(canarySts, rs)
+			if held {
+				heldSts = append(heldSts, rs)
+			}
+		}
+	}
+	sort.Strings(canaryPods)
+	return canarySts, canaryPods, heldSts, nil
+}
+
+// findRevertedCanaryPod returns name of canary pod re-created with previous revision, e.g. after eviction
+func findRevertedCanaryPod(ctx context.Context, rclient client.Client, ns string, status *vmv1beta1.VMClusterRolloutStatus) (string, error) {
+	prevRevisions := make(map[string]struct{}, len(status.StatefulSets))
+	for _, sts := range status.StatefulSets {
+		prevRevisions[sts.PreviousRevision] = struct{}{}
+	}
+	for _, podName := range status.CanaryPods {
+		var pod corev1.Pod
+		if err := rclient.Get(ctx, types.NamespacedName{Namespace: ns, Name: podName}, &pod); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return "", fmt.Errorf("cannot get canary pod=%s: %w", podName, err)
+		}
+		if _, ok := prevRevisions[pod.Labels[podRevisionLabel]]; ok {
+			return podName, nil
+		}
+	}
+	return "", nil
+}
+
+func listTargetPods(ctx context.Context, rclient client.Client, ns string, t rolloutTarget) ([]corev1.Pod, error) {
+	var podList corev1.PodList
+	if err := rclient.List(ctx, &podList, &client.ListOptions{Namespace: ns, LabelSelector: labels.SelectorFromSet(t.podLabels)}); err != nil {
+		return nil, fmt.Errorf("cannot list pods of statefulset=%s: %w", t.stsName, err)
+	}
+	return podList.Items, nil
+}
+
+// fetchCanaryMetrics returns request counters of canary pods at the start of bake time.
+// It returns reason of canary failure if metrics cannot be fetched
+func fetchCanaryMetrics(ctx context.Context, rclient client.Client, ns string, targets []rolloutTarget, canaryPods []string, maxErrorRate float64) ([]vmv1beta1.VMClusterRolloutPodMetrics, string, error) {
+	if maxErrorRate < 0 || k8stools.IsDryRun(rclient) {
+		return nil, "", nil
+	}
+	result := make([]vmv1beta1.VMClusterRolloutPodMetrics, 0, len(canaryPods))
+	for _, podName := range canaryPods {
+		var pod corev1.Pod
+		if err := rclient.Get(ctx, types.NamespacedName{Namespace: ns, Name: podName}, &pod); err != nil {
+			if errors.IsNotFound(err) {
+				return nil, fmt.Sprintf("canary pod=%s is missing", podName), nil
+			}
+			return nil, "", fmt.Errorf("cannot get canary pod=%s: %w", podName, err)
+		}
+		pm, reason := fetchCanaryPodMetrics(ctx, targets, &pod)
+		if reason != "" {
+			return nil, reason, nil
+		}
+		result = append(result, pm)
+	}
+	return result, "", nil
+}
+
+// fetchCanaryPodMetrics returns request counters of canary pod or reason of canary failure
+func fetchCanaryPodMetrics(ctx context.Context, targets []rolloutTarget, pod *corev1.Pod) (vmv1beta1.VMClusterRolloutPodMetrics, string) {
+	t := findPodTarget(targets, pod)
+	if t == nil {
+		return vmv1beta1.VMClusterRolloutPodMetrics{}, fmt.Sprintf("canary pod=%s doesn't belong to updated statefulsets", pod.Name)
+	}
+	metrics, err := fetchPodMetrics(ctx, t, pod.Status.PodIP)
+	if err != nil {
+		return vmv1beta1.VMClusterRolloutPodMetrics{}, fmt.Sprintf("cannot fetch metrics of canary pod=%s: %s", pod.Name, err)
+	}
+	return vmv1beta1.VMClusterRolloutPodMetrics{
+		Pod:           pod.Name,
+		Requests:      int64(metrics[rolloutRequestsMetricName]),
+		RequestErrors: int64(metrics[rolloutRequestErrorMetricName]),
+	}, ""
+}
+
+// counterIncrease returns increase of counter, which could be reset by container restart
+func counterIncrease(start, end int64) int64 {
+	if end < start {
+		return end
+	}
+	return end - start
+}
+
+// checkCanaryHealth returns reason of canary failure or empty string if canary is healthy.
+// Readiness and error rate of canary pods are checked only once bake time passes.
+// Error rate is calculated from increase of request counters since the start of bake time
+func checkCanaryHealth(ctx context.Context, rclient client.Client, ns string, targets []rolloutTarget, status *vmv1beta1.VMClusterRolloutStatus, policy *vmv1beta1.VMClusterRolloutPolicy, maxErrorRate float64, bakeFinished bool) (string, error) {
+	startMetrics := make(map[string]vmv1beta1.VMClusterRolloutPodMetrics, len(status.CanaryMetrics))
+	for _, pm := range status.CanaryMetrics {
+		startMetrics[pm.Pod] = pm
+	}
+	var requests, requestErrors int64
+	for _, podName := range status.CanaryPods {
+		var pod corev1.Pod
+		if err := rclient.Get(ctx, types.NamespacedName{Namespace: ns, Name: podName}, &pod); err != nil {
+			if errors.IsNotFound(err) {
+				if bakeFinished {
+					return fmt.Sprintf("canary pod=%s is missing", podName), nil
+				}
+				continue
+			}
+			return "", fmt.Errorf("cannot get canary pod=%s: %w", podName, err)
+		}
+		var restarts int32
+		for _, cs := range pod.Status.ContainerStatuses {
+			restarts += cs.RestartCount
+		}
+		if restarts > policy.MaxRestarts {
+			return fmt.Sprintf("canary pod=%s has %d restarts, max allowed: %d", podName, restarts, policy.MaxRestarts), nil
+		}
+		if !bakeFinished {
+			continue
+		}
+		if !reconcile.PodIsReady(&pod, 0) {
+			return fmt.Sprintf("canary pod=%s is not ready", podName), nil
+		}
+		if maxErrorRate < 0 || k8stools.IsDryRun(rclient) {
+			continue
+		}
+		pm, reason := fetchCanaryPodMetrics(ctx, targets, &pod)
+		if reason != "" {
+			return reason, nil
+		}
+		start := startMetrics[podName]
+		requests += counterIncrease(start.Requests, pm.Requests)
+		requestErrors += counterIncrease(start.RequestErrors, pm.RequestErrors)
+	}
+	if bakeFinished && maxErrorRate >= 0 && requests > 0 {
+		if rate := float64(requestErrors) / float64(requests); rate > maxErrorRate {
+			return fmt.Sprintf("canary pods error rate=%.4f exceeds maxErrorRate=%s", rate, policy.MaxErrorRate), nil
+		}
+	}
+	return "", nil
+}
+
+func findPodTarget(targets []rolloutTarget, pod *corev1.Pod) *rolloutTarget {
+	for i := range targets {
+		if labels.SelectorFromSet(targets[i].podLabels).Matches(labels.Set(pod.Labels)) {
+			return &targets[i]
+		}
+	}
+	return nil
+}
+
+// fetchPodMetrics returns sums of rollout health metrics exposed by the pod
+func fetchPodMetrics(ctx context.Context, t *rolloutTarget, podIP string) (map[string]float64, error) {
+	u := fmt.Sprintf("%s://%s%s", strings.ToLower(t.scheme), net.JoinHostPort(podIP, t.port), t.metricPath)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot build request: %w", err)
+	}
+	resp, err := rolloutHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot request metrics: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code for metrics request: %d", resp.StatusCode)
+	}
+	result := make(map[string]float64)
+	sc := bufio.NewScanner(resp.Body)
+	sc.Buffer(nil, 1024*1024)
+	for sc.Scan() {
+		line := sc.Text()
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, ok := parseMetricLine(line)
+		if !ok {
+			continue
+		}
+		if name == rolloutRequestsMetricName || name == rolloutRequestErrorMetricName {
+			result[name] += value
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read metrics response: %w", err)
+	}
+	return result, nil
+}
+
+// parseMetricLine parses metric name and value from Prometheus exposition format line
+func parseMetricLine(line string) (string, float64, bool) {
+	n := strings.IndexAny(line, "{ ")
+	if n <= 0 {
+		return "", 0, false
+	}
+	name, tail := line[:n], line[n:]
+	if tail[0] == '{' {
+		end := strings.LastIndexByte(tail, '}')
+		if end < 0 {
+			return "", 0, false
+		}
+		tail = tail[end+1:]
+	}
+	fields := strings.Fields(tail)
+	if len(fields) == 0 {
+		return "", 0, false
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return name, value, true
+}
+
+// updateRolloutStatus patches cluster status with given rollout progress and CanaryHealthy condition if it was changed
+func updateRolloutStatus(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMCluster, status *vmv1beta1.VMClusterRolloutStatus) error {
+	conditions := rolloutConditions(cr.Status.Conditions, cr.Generation, status)
+	if equality.Semantic.DeepEqual(cr.Status.Rollout, status) && equality.Semantic.DeepEqual(cr.Status.Conditions, conditions) {
+		return nil
+	}
+	data := []byte("null")
+	if status != nil {
+		// merge patch keeps omitted fields, so optional fields of the previous rollout must be cleared explicitly
+		fields := map[string]any{"reason": nil, "canaryPods": nil, "statefulSets": nil, "canaryMetrics": nil}
+		raw, err := json.Marshal(status)
+		if err != nil {
+			return fmt.Errorf("BUG: cannot serialize rollout status: %w", err)
+		}
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return fmt.Errorf("BUG: cannot parse rollout status: %w", err)
+		}
+		if data, err = json.Marshal(fields); err != nil {
+			return fmt.Errorf("BUG: cannot serialize rollout status: %w", err)
+		}
+	}
+	conditionsData, err := json.Marshal(conditions)
+	if err != nil {
+		return fmt.Errorf("BUG: cannot serialize status conditions: %w", err)
+	}
+	pt := client.RawPatch(types.MergePatchType, []byte(fmt.Sprintf(`{"status": {"rollout": %s, "conditions": %s}}`, data, conditionsData)))
+	if err := rclient.Status().Patch(ctx, cr.DeepCopy(), pt); err != nil {
+		return fmt.Errorf("cannot patch rollout status: %w", err)
+	}
+	cr.Status.Rollout = status
+	cr.Status.Conditions = conditions
+	return nil
+}
+
+// rolloutConditions returns copy of conditions with CanaryHealthy condition for the given rollout status
+func rolloutConditions(conditions []metav1.Condition, generation int64, status *vmv1beta1.VMClusterRolloutStatus) []metav1.Condition {
+	result := append([]metav1.Condition(nil), conditions...)
+	if status == nil {
+		meta.RemoveStatusCondition(&result, vmv1beta1.ConditionCanaryHealthy)
+		return result
+	}
+	cond := metav1.Condition{
+		Type:               vmv1beta1.ConditionCanaryHealthy,
+		Status:             metav1.ConditionUnknown,
+		Reason:             string(status.Stage),
+		ObservedGeneration: generation,
+	}
+	switch status.Stage {
+	case vmv1beta1.VMClusterRolloutCompleted:
+		cond.Status = metav1.ConditionTrue
+	case vmv1beta1.VMClusterRolloutHalted, vmv1beta1.VMClusterRolloutRolledBack:
+		cond.Status = metav1.ConditionFalse
+		cond.Message = status.Reason
+	}
+	meta.SetStatusCondition(&result, cond)
+	return result
+}
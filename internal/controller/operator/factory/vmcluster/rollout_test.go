@@ -0,0 +1,495 @@
+package vmcluster
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCreateOrUpdateWithRollout(t *testing.T) {
+	type opts struct {
+		policy         *vmv1beta1.VMClusterRolloutPolicy
+		status         *vmv1beta1.VMClusterRolloutStatus
+		podRestarts    int32
+		metrics        string
+		wantStage      vmv1beta1.VMClusterRolloutStage
+		wantCondition  metav1.ConditionStatus
+		wantMetrics    []vmv1beta1.VMClusterRolloutPodMetrics
+		wantBakeSince  time.Duration
+		wantCanaryRuns int
+		wantFullRuns   int
+		wantErr        bool
+	}
+	f := func(o opts) {
+		t.Helper()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, o.metrics)
+		}))
+		defer srv.Close()
+		_, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+		if err != nil {
+			t.Fatalf("cannot parse test server address: %s", err)
+		}
+		cr := &vmv1beta1.VMCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-1", Namespace: "default", Generation: 2},
+			Spec:       vmv1beta1.VMClusterSpec{RolloutPolicy: o.policy},
+			Status:     vmv1beta1.VMClusterStatus{Rollout: o.status},
+		}
+		target := rolloutTarget{
+			stsName:    "vmstorage-cluster-1",
+			podLabels:  map[string]string{"app": "vmstorage"},
+			port:       port,
+			scheme:     "http",
+			metricPath: "/metrics",
+		}
+		sts := &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: target.stsName, Namespace: cr.Namespace},
+			Status:     appsv1.StatefulSetStatus{CurrentRevision: "rev1", UpdateRevision: "rev1"},
+		}
+		podRevision := "rev1"
+		if o.status != nil && o.status.Generation == cr.Generation && o.status.Stage == vmv1beta1.VMClusterRolloutBaking {
+			// canary pod is already updated
+			podRevision = "rev2"
+		}
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "vmstorage-cluster-1-0",
+				Namespace: cr.Namespace,
+				Labels:    map[string]string{"app": "vmstorage", podRevisionLabel: podRevision},
+			},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				PodIP:             "127.0.0.1",
+				Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: "True"}},
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "vmstorage", RestartCount: o.podRestarts}},
+			},
+		}
+		revision := &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{Name: "rev1", Namespace: cr.Namespace},
+			Data:       runtime.RawExtension{Raw: []byte(`{"spec":{"template":{"spec":{"containers":[{"name":"vmstorage"}]},"$patch":"replace"}}}`)},
+		}
+		fclient := k8stools.GetTestClientWithObjects([]runtime.Object{cr.DeepCopy(), sts, revision, pod})
+		ctx := context.Background()
+		var canaryRuns, fullRuns int
+		canaryStage := func() error {
+			canaryRuns++
+			// emulate canary pod update
+			sts.Status.UpdateRevision = "rev2"
+			if err := fclient.Status().Update(ctx, sts); err != nil {
+				return err
+			}
+			pod.Labels[podRevisionLabel] = "rev2"
+			return fclient.Update(ctx, pod)
+		}
+		fullStage := func() error {
+			fullRuns++
+			return nil
+		}
+		err = createOrUpdateWithRollout(ctx, fclient, cr, []rolloutTarget{target}, canaryStage, fullStage)
+		if (err != nil) != o.wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, o.wantErr)
+		}
+		if canaryRuns != o.wantCanaryRuns {
+			t.Fatalf("unexpected canary stage runs, got: %d, want: %d", canaryRuns, o.wantCanaryRuns)
+		}
+		if fullRuns != o.wantFullRuns {
+			t.Fatalf("unexpected full stage runs, got: %d, want: %d", fullRuns, o.wantFullRuns)
+		}
+		var stored vmv1beta1.VMCluster
+		if err := fclient.Get(ctx, types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, &stored); err != nil {
+			t.Fatalf("cannot get vmcluster: %s", err)
+		}
+		if o.wantStage == "" {
+			if stored.Status.Rollout != nil {
+				t.Fatalf("expected empty rollout status, got: %v", stored.Status.Rollout)
+			}
+			return
+		}
+		if stored.Status.Rollout == nil {
+			t.Fatalf("expected rollout status with stage=%s", o.wantStage)
+		}
+		if stored.Status.Rollout.Stage != o.wantStage {
+			t.Fatalf("unexpected rollout stage, got: %s, want: %s, reason: %s", stored.Status.Rollout.Stage, o.wantStage, stored.Status.Rollout.Reason)
+		}
+		if stored.Status.Rollout.Generation != cr.Generation {
+			t.Fatalf("unexpected rollout generation, got: %d, want: %d", stored.Status.Rollout.Generation, cr.Generation)
+		}
+		if o.wantCondition != "" {
+			cond := meta.FindStatusCondition(stored.Status.Conditions, vmv1beta1.ConditionCanaryHealthy)
+			if cond == nil || cond.Status != o.wantCondition || cond.Reason != string(o.wantStage) {
+				t.Fatalf("unexpected %s condition: %v, want status: %s", vmv1beta1.ConditionCanaryHealthy, cond, o.wantCondition)
+			}
+		}
+		if !cmp.Equal(stored.Status.Rollout.CanaryMetrics, o.wantMetrics) {
+			t.Fatalf("unexpected canary metrics: %s", cmp.Diff(stored.Status.Rollout.CanaryMetrics, o.wantMetrics))
+		}
+		if o.wantBakeSince > 0 && time.Since(stored.Status.Rollout.LastTransitionTime.Time) < o.wantBakeSince {
+			t.Fatalf("bake time must not be restarted, last transition time: %s", stored.Status.Rollout.LastTransitionTime)
+		}
+		if o.wantStage == vmv1beta1.VMClusterRolloutBaking {
+			if !cmp.Equal(stored.Status.Rollout.CanaryPods, []string{pod.Name}) {
+				t.Fatalf("unexpected canary pods: %s", cmp.Diff(stored.Status.Rollout.CanaryPods, []string{pod.Name}))
+			}
+			wantSts := []vmv1beta1.VMClusterRolloutStatefulSet{{Name: sts.Name, PreviousRevision: "rev1", UpdateRevision: "rev2"}}
+			if !cmp.Equal(stored.Status.Rollout.StatefulSets, wantSts) {
+				t.Fatalf("unexpected canary statefulsets: %s", cmp.Diff(stored.Status.Rollout.StatefulSets, wantSts))
+			}
+		}
+	}
+	baking := func(since time.Duration, canaryMetrics ...vmv1beta1.VMClusterRolloutPodMetrics) *vmv1beta1.VMClusterRolloutStatus {
+		return &vmv1beta1.VMClusterRolloutStatus{
+			Generation:         2,
+			Stage:              vmv1beta1.VMClusterRolloutBaking,
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-since).Truncate(time.Second)),
+			CanaryPods:         []string{"vmstorage-cluster-1-0"},
+			StatefulSets:       []vmv1beta1.VMClusterRolloutStatefulSet{{Name: "vmstorage-cluster-1", PreviousRevision: "rev1", UpdateRevision: "rev2"}},
+			CanaryMetrics:      canaryMetrics,
+		}
+	}
+
+	// rollout policy is not set
+	f(opts{
+		wantFullRuns: 1,
+	})
+
+	// new generation updates canary pods
+	f(opts{
+		policy:         &vmv1beta1.VMClusterRolloutPolicy{BakeTime: "30m"},
+		status:         &vmv1beta1.VMClusterRolloutStatus{Generation: 1, Stage: vmv1beta1.VMClusterRolloutCompleted},
+		wantStage:      vmv1beta1.VMClusterRolloutBaking,
+		wantCondition:  metav1.ConditionUnknown,
+		wantCanaryRuns: 1,
+	})
+
+	// request counters of canary pods are saved at the start of bake time
+	f(opts{
+		policy:         &vmv1beta1.VMClusterRolloutPolicy{BakeTime: "30m", MaxErrorRate: "0.05"},
+		status:         &vmv1beta1.VMClusterRolloutStatus{Generation: 1, Stage: vmv1beta1.VMClusterRolloutCompleted},
+		metrics:        "vm_http_requests_total{path=\"/api/v1/query\"} 100\nvm_http_requests_total{path=\"/api/v1/write\"} 20\nvm_http_request_errors_total{path=\"/api/v1/query\",reason=\"timeout\"} 3\n",
+		wantStage:      vmv1beta1.VMClusterRolloutBaking,
+		wantMetrics:    []vmv1beta1.VMClusterRolloutPodMetrics{{Pod: "vmstorage-cluster-1-0", Requests: 120, RequestErrors: 3}},
+		wantCanaryRuns: 1,
+	})
+
+	// spec change during bake time doesn't affect canary pods
+	f(opts{
+		policy: &vmv1beta1.VMClusterRolloutPolicy{BakeTime: "30m", MaxErrorRate: "0.05"},
+		status: func() *vmv1beta1.VMClusterRolloutStatus {
+			st := baking(20*time.Minute, vmv1beta1.VMClusterRolloutPodMetrics{Pod: "vmstorage-cluster-1-0", Requests: 10})
+			st.Generation = 1
+			return st
+		}(),
+		wantStage:      vmv1beta1.VMClusterRolloutBaking,
+		wantMetrics:    []vmv1beta1.VMClusterRolloutPodMetrics{{Pod: "vmstorage-cluster-1-0", Requests: 10}},
+		wantBakeSince:  20 * time.Minute,
+		wantCanaryRuns: 1,
+	})
+
+	// bake time isn't passed
+	f(opts{
+		policy:    &vmv1beta1.VMClusterRolloutPolicy{BakeTime: "30m"},
+		status:    baking(time.Minute),
+		wantStage: vmv1beta1.VMClusterRolloutBaking,
+	})
+
+	// canary is healthy after bake time
+	f(opts{
+		policy:        &vmv1beta1.VMClusterRolloutPolicy{BakeTime: "30m", MaxErrorRate: "0.05"},
+		status:        baking(time.Hour),
+		metrics:       "vm_http_requests_total{path=\"/api/v1/query\"} 100\nvm_http_request_errors_total{path=\"/api/v1/query\",reason=\"timeout\"} 1\n",
+		wantStage:     vmv1beta1.VMClusterRolloutCompleted,
+		wantCondition: metav1.ConditionTrue,
+		wantFullRuns:  1,
+	})
+
+	// errors before bake time are not counted
+	f(opts{
+		policy:       &vmv1beta1.VMClusterRolloutPolicy{BakeTime: "30m", MaxErrorRate: "0.05"},
+		status:       baking(time.Hour, vmv1beta1.VMClusterRolloutPodMetrics{Pod: "vmstorage-cluster-1-0", Requests: 1000, RequestErrors: 100}),
+		metrics:      "vm_http_requests_total{path=\"/api/v1/query\"} 1100\nvm_http_request_errors_total{path=\"/api/v1/query\",reason=\"timeout\"} 101\n",
+		wantStage:    vmv1beta1.VMClusterRolloutCompleted,
+		wantFullRuns: 1,
+	})
+
+	// canary pod restarts during bake time
+	f(opts{
+		policy:        &vmv1beta1.VMClusterRolloutPolicy{BakeTime: "30m"},
+		status:        baking(time.Minute),
+		podRestarts:   2,
+		wantStage:     vmv1beta1.VMClusterRolloutHalted,
+		wantCondition: metav1.ConditionFalse,
+	})
+
+	// canary error rate exceeds limit during bake time
+	f(opts{
+		policy:        &vmv1beta1.VMClusterRolloutPolicy{BakeTime: "30m", MaxErrorRate: "0.05"},
+		status:        baking(time.Hour, vmv1beta1.VMClusterRolloutPodMetrics{Pod: "vmstorage-cluster-1-0", Requests: 1000}),
+		metrics:       "# TYPE vm_http_requests_total counter\nvm_http_requests_total{path=\"/api/v1/query\"} 1100\nvm_http_request_errors_total{path=\"/api/v1/query\",reason=\"timeout\"} 10\n",
+		wantStage:     vmv1beta1.VMClusterRolloutHalted,
+		wantCondition: metav1.ConditionFalse,
+	})
+
+	// halted rollout isn't resumed for the same generation
+	f(opts{
+		policy: &vmv1beta1.VMClusterRolloutPolicy{BakeTime: "30m"},
+		status: &vmv1beta1.VMClusterRolloutStatus{
+			Generation: 2,
+			Stage:      vmv1beta1.VMClusterRolloutHalted,
+			Reason:     "canary pod restarts",
+		},
+		wantStage: vmv1beta1.VMClusterRolloutHalted,
+	})
+
+	// rollout is finished
+	f(opts{
+		policy:       &vmv1beta1.VMClusterRolloutPolicy{BakeTime: "30m"},
+		status:       &vmv1beta1.VMClusterRolloutStatus{Generation: 2, Stage: vmv1beta1.VMClusterRolloutCompleted},
+		wantStage:    vmv1beta1.VMClusterRolloutCompleted,
+		wantFullRuns: 1,
+	})
+}
+
+func TestCreateOrUpdateWithRolloutWithoutPodChanges(t *testing.T) {
+	cr := &vmv1beta1.VMCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-1", Namespace: "default", Generation: 1},
+		Spec:       vmv1beta1.VMClusterSpec{RolloutPolicy: &vmv1beta1.VMClusterRolloutPolicy{BakeTime: "30m"}},
+	}
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "vmstorage-cluster-1", Namespace: cr.Namespace},
+		Status:     appsv1.StatefulSetStatus{CurrentRevision: "rev1", UpdateRevision: "rev1"},
+	}
+	fclient := k8stools.GetTestClientWithObjects([]runtime.Object{cr.DeepCopy(), sts})
+	ctx := context.Background()
+	var fullRuns int
+	targets := []rolloutTarget{{stsName: sts.Name, podLabels: map[string]string{"app": "vmstorage"}}}
+	if err := createOrUpdateWithRollout(ctx, fclient, cr, targets, func() error { return nil }, func() error {
+		fullRuns++
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if fullRuns != 1 {
+		t.Fatalf("expected full stage to run once, got: %d", fullRuns)
+	}
+	var stored vmv1beta1.VMCluster
+	if err := fclient.Get(ctx, client.ObjectKeyFromObject(cr), &stored); err != nil {
+		t.Fatalf("cannot get vmcluster: %s", err)
+	}
+	if stored.Status.Rollout == nil || stored.Status.Rollout.Stage != vmv1beta1.VMClusterRolloutCompleted {
+		t.Fatalf("expected completed rollout, got: %v", stored.Status.Rollout)
+	}
+}
+
+func TestCreateOrUpdateWithRolloutHoldsNonCanaryPods(t *testing.T) {
+	type opts struct {
+		status         *vmv1beta1.VMClusterRolloutStatus
+		canaryErr      error
+		pod0Revision   string
+		wantStage      vmv1beta1.VMClusterRolloutStage
+		wantImage      string
+		wantCanaryRuns int
+	}
+	f := func(o opts) {
+		t.Helper()
+		cr := &vmv1beta1.VMCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-1", Namespace: "default", Generation: 2},
+			Spec:       vmv1beta1.VMClusterSpec{RolloutPolicy: &vmv1beta1.VMClusterRolloutPolicy{BakeTime: "30m"}},
+			Status:     vmv1beta1.VMClusterStatus{Rollout: o.status},
+		}
+		podLabels := map[string]string{"app": "vmstorage"}
+		template := func(image string) corev1.PodTemplateSpec {
+			return corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "vmstorage", Image: image}}},
+			}
+		}
+		sts := &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "vmstorage-cluster-1", Namespace: cr.Namespace},
+			Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(int32(2)), Template: template("vmstorage:v1")},
+			Status:     appsv1.StatefulSetStatus{CurrentRevision: "rev1", UpdateRevision: "rev1"},
+		}
+		revision := &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{Name: "rev1", Namespace: cr.Namespace},
+			Data: runtime.RawExtension{
+				Raw: []byte(`{"spec":{"template":{"metadata":{"labels":{"app":"vmstorage"}},"spec":{"containers":[{"name":"vmstorage","image":"vmstorage:v1"}]},"$patch":"replace"}}}`),
+			},
+		}
+		pod := func(name, revision string) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: cr.Namespace,
+					Labels:    map[string]string{"app": "vmstorage", podRevisionLabel: revision},
+				},
+				Status: corev1.PodStatus{
+					Phase:      corev1.PodRunning,
+					Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: "True"}},
+				},
+			}
+		}
+		pod0Revision := o.pod0Revision
+		if pod0Revision == "" {
+			pod0Revision = "rev1"
+		}
+		pod0 := pod("vmstorage-cluster-1-0", pod0Revision)
+		fclient := k8stools.GetTestClientWithObjects([]runtime.Object{cr.DeepCopy(), sts, revision, pod0, pod("vmstorage-cluster-1-1", "rev1")})
+		ctx := context.Background()
+		var canaryRuns int
+		canaryStage := func() error {
+			canaryRuns++
+			// emulate update of canary pod, the rest of pods keep previous revision
+			sts.Spec.Template = template("vmstorage:v2")
+			if err := fclient.Update(ctx, sts); err != nil {
+				return err
+			}
+			sts.Status.UpdateRevision = "rev2"
+			if err := fclient.Status().Update(ctx, sts); err != nil {
+				return err
+			}
+			pod0.Labels[podRevisionLabel] = "rev2"
+			if err := fclient.Update(ctx, pod0); err != nil {
+				return err
+			}
+			return o.canaryErr
+		}
+		targets := []rolloutTarget{{stsName: sts.Name, podLabels: podLabels}}
+		if err := createOrUpdateWithRollout(ctx, fclient, cr, targets, canaryStage, func() error { return nil }); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if canaryRuns != o.wantCanaryRuns {
+			t.Fatalf("unexpected canary stage runs, got: %d, want: %d", canaryRuns, o.wantCanaryRuns)
+		}
+		var stored vmv1beta1.VMCluster
+		if err := fclient.Get(ctx, client.ObjectKeyFromObject(cr), &stored); err != nil {
+			t.Fatalf("cannot get vmcluster: %s", err)
+		}
+		if stored.Status.Rollout == nil || stored.Status.Rollout.Stage != o.wantStage {
+			t.Fatalf("unexpected rollout status: %v, want stage: %s", stored.Status.Rollout, o.wantStage)
+		}
+		var storedSts appsv1.StatefulSet
+		if err := fclient.Get(ctx, client.ObjectKeyFromObject(sts), &storedSts); err != nil {
+			t.Fatalf("cannot get statefulset: %s", err)
+		}
+		if image := storedSts.Spec.Template.Spec.Containers[0].Image; image != o.wantImage {
+			t.Fatalf("unexpected pod template image, got: %s, want: %s", image, o.wantImage)
+		}
+	}
+	baking := &vmv1beta1.VMClusterRolloutStatus{
+		Generation:         2,
+		Stage:              vmv1beta1.VMClusterRolloutBaking,
+		LastTransitionTime: metav1.Now(),
+		CanaryPods:         []string{"vmstorage-cluster-1-0"},
+		StatefulSets:       []vmv1beta1.VMClusterRolloutStatefulSet{{Name: "vmstorage-cluster-1", PreviousRevision: "rev1", UpdateRevision: "rev2"}},
+	}
+
+	// non-canary pods are re-created with previous template during bake time
+	f(opts{
+		status:         &vmv1beta1.VMClusterRolloutStatus{Generation: 1, Stage: vmv1beta1.VMClusterRolloutCompleted},
+		wantStage:      vmv1beta1.VMClusterRolloutBaking,
+		wantImage:      "vmstorage:v1",
+		wantCanaryRuns: 1,
+	})
+
+	// halted rollout keeps previous template for non-canary pods
+	f(opts{
+		status:         &vmv1beta1.VMClusterRolloutStatus{Generation: 1, Stage: vmv1beta1.VMClusterRolloutCompleted},
+		canaryErr:      vmv1beta1.NewRolloutError(fmt.Errorf("canary pod is not ready")),
+		wantStage:      vmv1beta1.VMClusterRolloutHalted,
+		wantImage:      "vmstorage:v1",
+		wantCanaryRuns: 1,
+	})
+
+	// canary pod was evicted and re-created with previous revision
+	f(opts{
+		status:    baking,
+		wantStage: vmv1beta1.VMClusterRolloutCanary,
+		wantImage: "vmstorage:v1",
+	})
+
+	// canary pod keeps the new revision
+	f(opts{
+		status:       baking,
+		pod0Revision: "rev2",
+		wantStage:    vmv1beta1.VMClusterRolloutBaking,
+		wantImage:    "vmstorage:v1",
+	})
+}
+
+func TestParseMetricLine(t *testing.T) {
+	f := func(line, wantName string, wantValue float64, wantOk bool) {
+		t.Helper()
+		name, value, ok := parseMetricLine(line)
+		if ok != wantOk {
+			t.Fatalf("unexpected ok for line=%q, got: %v, want: %v", line, ok, wantOk)
+		}
+		if name != wantName || value != wantValue {
+			t.Fatalf("unexpected result for line=%q, got: %s=%v, want: %s=%v", line, name, value, wantName, wantValue)
+		}
+	}
+	f(`vm_http_requests_total 10`, "vm_http_requests_total", 10, true)
+	f(`vm_http_requests_total{path="/api/v1/query"} 5 1700000000000`, "vm_http_requests_total", 5, true)
+	f(`vm_http_request_errors_total{path="/a b",reason="x}"} 3`, "vm_http_request_errors_total", 3, true)
+	f(`vm_http_requests_total{path="/"} NaN-value`, "", 0, false)
+	f(``, "", 0, false)
+}
+
+func TestCreateOrUpdateCanaryComponents(t *testing.T) {
+	cr := &vmv1beta1.VMCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-1", Namespace: "default"},
+		Spec: vmv1beta1.VMClusterSpec{
+			RetentionPeriod: "1",
+			VMStorage: &vmv1beta1.VMStorage{
+				CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{ReplicaCount: ptr.To(int32(0))},
+			},
+			VMSelect: &vmv1beta1.VMSelect{
+				CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{ReplicaCount: ptr.To(int32(0))},
+			},
+			VMInsert: &vmv1beta1.VMInsert{
+				CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{ReplicaCount: ptr.To(int32(0))},
+			},
+		},
+	}
+	fclient := k8stools.GetTestClientWithObjects(nil)
+	ctx := context.Background()
+	if err := createOrUpdateCanaryComponents(ctx, cr, fclient); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var stsList appsv1.StatefulSetList
+	if err := fclient.List(ctx, &stsList); err != nil {
+		t.Fatalf("cannot list statefulsets: %s", err)
+	}
+	if len(stsList.Items) != 2 {
+		t.Fatalf("expected vmstorage and vmselect statefulsets, got: %d", len(stsList.Items))
+	}
+	// vminsert and services must be updated only after canary is healthy
+	var deployList appsv1.DeploymentList
+	if err := fclient.List(ctx, &deployList); err != nil {
+		t.Fatalf("cannot list deployments: %s", err)
+	}
+	if len(deployList.Items) != 0 {
+		t.Fatalf("unexpected deployments at canary stage: %d", len(deployList.Items))
+	}
+	var svcList corev1.ServiceList
+	if err := fclient.List(ctx, &svcList); err != nil {
+		t.Fatalf("cannot list services: %s", err)
+	}
+	if len(svcList.Items) != 0 {
+		t.Fatalf("unexpected services at canary stage: %d", len(svcList.Items))
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
@@ -42,6 +43,15 @@ func CreateOrUpdateVMCluster(ctx context.Context, cr *vmv1beta1.VMCluster, rclie
 			return fmt.Errorf("failed create service account: %w", err)
 		}
 	}
+	if cr.IsZoned() {
+		// the first zone is used as canary for staged rollout
+		canaryZone := buildZoneCluster(cr, &cr.Spec.Zones.Items[0])
+		return createOrUpdateWithRollout(ctx, rclient, cr, clusterRolloutTargets(canaryZone), func() error {
+			return createOrUpdateComponents(ctx, canaryZone, rclient)
+		}, func() error {
+			return createOrUpdateZones(ctx, cr, rclient)
+		})
+	}
 	// handle case for loadbalancing
 	if cr.Spec.RequestsLoadBalancer.Enabled {
 		// create vmauth deployment
@@ -49,7 +59,47 @@ func CreateOrUpdateVMCluster(ctx context.Context, cr *vmv1beta1.VMCluster, rclie
 			return err
 		}
 	}
+	if cr.Spec.VMStorage != nil {
+		// departing vmstorage nodes must be excluded from vminsert and vmselect routing before removal
+		var err error
+		cr, err = handleVMStorageScaleDown(ctx, rclient, cr)
+		if err != nil {
+			return err
+		}
+	}
+	if err := createOrUpdateWithRollout(ctx, rclient, cr, clusterRolloutTargets(cr), func() error {
+		canaryCR := cr.DeepCopy()
+		canaryCR.CanaryPods = cr.Spec.RolloutPolicy.GetCanaryPods()
+		return createOrUpdateCanaryComponents(ctx, canaryCR, rclient)
+	}, func() error {
+		return createOrUpdateComponents(ctx, cr, rclient)
+	}); err != nil {
+		return err
+	}
+	if err := deletePrevZones(ctx, cr, rclient); err != nil {
+		return fmt.Errorf("failed to remove zones from previous cluster state: %w", err)
+	}
+	return nil
+}
 
+// createOrUpdateCanaryComponents updates canary pods of vmstorage and vmselect statefulsets.
+// vminsert, services and the rest of components are updated only after canary passes health checks
+func createOrUpdateCanaryComponents(ctx context.Context, cr *vmv1beta1.VMCluster, rclient client.Client) error {
+	if cr.Spec.VMStorage != nil {
+		if err := createOrUpdateVMStorage(ctx, cr, rclient); err != nil {
+			return err
+		}
+	}
+	if cr.Spec.VMSelect != nil {
+		if err := createOrUpdateVMSelect(ctx, cr, rclient); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createOrUpdateComponents reconciles vmstorage, vmselect and vminsert components of the cluster
+func createOrUpdateComponents(ctx context.Context, cr *vmv1beta1.VMCluster, rclient client.Client) error {
 	if cr.Spec.VMStorage != nil {
 		if cr.Spec.VMStorage.PodDisruptionBudget != nil {
 			err := createOrUpdatePodDisruptionBudgetForVMStorage(ctx, cr, rclient)
@@ -60,11 +110,19 @@ func CreateOrUpdateVMCluster(ctx context.Context, cr *vmv1beta1.VMCluster, rclie
 		if err := createOrUpdateVMStorage(ctx, cr, rclient); err != nil {
 			return err
 		}
+		if err := createOrUpdateVMStorageVPA(ctx, rclient, cr); err != nil {
+			return err
+		}
 
 		storageSvc, err := createOrUpdateVMStorageService(ctx, cr, rclient)
 		if err != nil {
 			return err
 		}
+		if cr.Spec.NetworkPolicy != nil {
+			if err := createOrUpdateVMStorageNetworkPolicy(ctx, rclient, cr, storageSvc); err != nil {
+				return err
+			}
+		}
 		if !ptr.Deref(cr.Spec.VMStorage.DisableSelfServiceScrape, false) {
 			err := reconcile.VMServiceScrapeForCRD(ctx, rclient, build.VMServiceScrapeForServiceWithSpec(storageSvc, cr.Spec.VMStorage, "http", "vmbackupmanager"))
 			if err != nil {
@@ -86,11 +144,19 @@ func CreateOrUpdateVMCluster(ctx context.Context, cr *vmv1beta1.VMCluster, rclie
 		if err := createOrUpdateVMSelectHPA(ctx, rclient, cr); err != nil {
 			return err
 		}
+		if err := createOrUpdateVMSelectVPA(ctx, rclient, cr); err != nil {
+			return err
+		}
 		// create vmselect service
 		selectSvc, err := createOrUpdateVMSelectService(ctx, cr, rclient)
 		if err != nil {
 			return err
 		}
+		if cr.Spec.NetworkPolicy != nil {
+			if err := createOrUpdateVMSelectNetworkPolicy(ctx, rclient, cr, selectSvc); err != nil {
+				return err
+			}
+		}
 		if !ptr.Deref(cr.Spec.VMSelect.DisableSelfServiceScrape, false) {
 
 			svs := build.VMServiceScrapeForServiceWithSpec(selectSvc, cr.Spec.VMSelect, "http")
@@ -118,9 +184,17 @@ func CreateOrUpdateVMCluster(ctx context.Context, cr *vmv1beta1.VMCluster, rclie
 		if err != nil {
 			return err
 		}
+		if cr.Spec.NetworkPolicy != nil {
+			if err := createOrUpdateVMInsertNetworkPolicy(ctx, rclient, cr, insertSvc); err != nil {
+				return err
+			}
+		}
 		if err := createOrUpdateVMInsertHPA(ctx, rclient, cr); err != nil {
 			return err
 		}
+		if err := createOrUpdateVMInsertVPA(ctx, rclient, cr); err != nil {
+			return err
+		}
 		if !ptr.Deref(cr.Spec.VMInsert.DisableSelfServiceScrape, false) {
 			svs := build.VMServiceScrapeForServiceWithSpec(insertSvc, cr.Spec.VMInsert, "http")
 			if cr.Spec.RequestsLoadBalancer.Enabled && !cr.Spec.RequestsLoadBalancer.DisableInsertBalancing {
@@ -154,13 +228,14 @@ func createOrUpdateVMSelect(ctx context.Context, cr *vmv1beta1.VMCluster, rclien
 	}
 	newSts, err := genVMSelectSpec(cr)
 	if err != nil {
-		return err
+		return vmv1beta1.NewConfigError(err)
 	}
 
 	stsOpts := reconcile.STSOptions{
 		HasClaim:       len(newSts.Spec.VolumeClaimTemplates) > 0,
 		SelectorLabels: cr.VMSelectSelectorLabels,
 		HPA:            cr.Spec.VMSelect.HPA,
+		CanaryPods:     cr.CanaryPods,
 		UpdateReplicaCount: func(count *int32) {
 			if cr.Spec.VMSelect.HPA != nil && count != nil {
 				cr.Spec.VMSelect.ReplicaCount = count
@@ -269,7 +344,7 @@ func createOrUpdateVMInsert(ctx context.Context, cr *vmv1beta1.VMCluster, rclien
 	}
 	newDeployment, err := genVMInsertSpec(cr)
 	if err != nil {
-		return err
+		return vmv1beta1.NewConfigError(err)
 	}
 	return reconcile.Deployment(ctx, rclient, newDeployment, prevDeploy, cr.Spec.VMInsert.HPA != nil)
 }
@@ -353,12 +428,13 @@ func createOrUpdateVMStorage(ctx context.Context, cr *vmv1beta1.VMCluster, rclie
 	}
 	newSts, err := buildVMStorageSpec(ctx, cr)
 	if err != nil {
-		return err
+		return vmv1beta1.NewConfigError(err)
 	}
 
 	stsOpts := reconcile.STSOptions{
 		HasClaim:       len(newSts.Spec.VolumeClaimTemplates) > 0,
 		SelectorLabels: cr.VMStorageSelectorLabels,
+		CanaryPods:     cr.CanaryPods,
 	}
 	return reconcile.HandleSTSUpdate(ctx, rclient, stsOpts, newSts, prevSts)
 }
@@ -1158,6 +1234,45 @@ func createOrUpdateVMSelectHPA(ctx context.Context, rclient client.Client, clust
 	return reconcile.HPA(ctx, rclient, defaultHPA)
 }
 
+func createOrUpdateVMInsertVPA(ctx context.Context, rclient client.Client, cluster *vmv1beta1.VMCluster) error {
+	if cluster.Spec.VMInsert.VPA == nil {
+		return nil
+	}
+	targetRef := autoscalingv1.CrossVersionObjectReference{
+		Name:       cluster.GetInsertName(),
+		Kind:       "Deployment",
+		APIVersion: "apps/v1",
+	}
+	vpa := build.VPA(targetRef, "vminsert", cluster.Spec.VMInsert.VPA, cluster.AsOwner(), cluster.VMInsertSelectorLabels(), cluster.Namespace)
+	return reconcile.VPA(ctx, rclient, vpa)
+}
+
+func createOrUpdateVMSelectVPA(ctx context.Context, rclient client.Client, cluster *vmv1beta1.VMCluster) error {
+	if cluster.Spec.VMSelect.VPA == nil {
+		return nil
+	}
+	targetRef := autoscalingv1.CrossVersionObjectReference{
+		Name:       cluster.GetSelectName(),
+		Kind:       "StatefulSet",
+		APIVersion: "apps/v1",
+	}
+	vpa := build.VPA(targetRef, "vmselect", cluster.Spec.VMSelect.VPA, cluster.AsOwner(), cluster.VMSelectSelectorLabels(), cluster.Namespace)
+	return reconcile.VPA(ctx, rclient, vpa)
+}
+
+func createOrUpdateVMStorageVPA(ctx context.Context, rclient client.Client, cluster *vmv1beta1.VMCluster) error {
+	if cluster.Spec.VMStorage.VPA == nil {
+		return nil
+	}
+	targetRef := autoscalingv1.CrossVersionObjectReference{
+		Name:       cluster.Spec.VMStorage.GetNameWithPrefix(cluster.Name),
+		Kind:       "StatefulSet",
+		APIVersion: "apps/v1",
+	}
+	vpa := build.VPA(targetRef, "vmstorage", cluster.Spec.VMStorage.VPA, cluster.AsOwner(), cluster.VMStorageSelectorLabels(), cluster.Namespace)
+	return reconcile.VPA(ctx, rclient, vpa)
+}
+
 type clusterSvcBuilder struct {
 	*vmv1beta1.VMCluster
 	prefixedName      string
@@ -1215,6 +1330,12 @@ func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VMCluster, rcli
 					return fmt.Errorf("cannot remove serviceScrape from prev storage: %w", err)
 				}
 			}
+			if err := reconcile.RemoveOrphanedNetworkPolicy(ctx, rclient, commonObjMeta.Name, cr.Namespace, prevSpec.NetworkPolicy, cr.Spec.NetworkPolicy); err != nil {
+				return fmt.Errorf("cannot remove network policy from prev storage: %w", err)
+			}
+			if err := reconcile.RemoveOrphanedVPA(ctx, rclient, commonObjMeta.Name, cr.Namespace, prevSt.VPA, vmst.VPA); err != nil {
+				return fmt.Errorf("cannot remove VPA from prev storage: %w", err)
+			}
 			prevSvc, currSvc := prevSt.ServiceSpec, vmst.ServiceSpec
 			if err := reconcile.AdditionalServices(ctx, rclient, vmst.GetNameWithPrefix(cr.Name), cr.Namespace, prevSvc, currSvc); err != nil {
 				return fmt.Errorf("cannot remove vmstorage additional service: %w", err)
@@ -1245,6 +1366,12 @@ func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VMCluster, rcli
 					return fmt.Errorf("cannot remove serviceScrape from prev select: %w", err)
 				}
 			}
+			if err := reconcile.RemoveOrphanedNetworkPolicy(ctx, rclient, commonObjMeta.Name, cr.Namespace, prevSpec.NetworkPolicy, cr.Spec.NetworkPolicy); err != nil {
+				return fmt.Errorf("cannot remove network policy from prev select: %w", err)
+			}
+			if err := reconcile.RemoveOrphanedVPA(ctx, rclient, commonObjMeta.Name, cr.Namespace, prevSe.VPA, vmse.VPA); err != nil {
+				return fmt.Errorf("cannot remove VPA from prev select: %w", err)
+			}
 			prevSvc, currSvc := prevSe.ServiceSpec, vmse.ServiceSpec
 			if err := reconcile.AdditionalServices(ctx, rclient, cr.GetSelectName(), cr.Namespace, prevSvc, currSvc); err != nil {
 				return fmt.Errorf("cannot remove vmselect additional service: %w", err)
@@ -1311,6 +1438,12 @@ func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VMCluster, rcli
 					return fmt.Errorf("cannot remove serviceScrape from prev insert: %w", err)
 				}
 			}
+			if err := reconcile.RemoveOrphanedNetworkPolicy(ctx, rclient, commonObjMeta.Name, cr.Namespace, prevSpec.NetworkPolicy, cr.Spec.NetworkPolicy); err != nil {
+				return fmt.Errorf("cannot remove network policy from prev insert: %w", err)
+			}
+			if err := reconcile.RemoveOrphanedVPA(ctx, rclient, commonObjMeta.Name, cr.Namespace, prevIs.VPA, vmis.VPA); err != nil {
+				return fmt.Errorf("cannot remove VPA from prev insert: %w", err)
+			}
 			prevSvc, currSvc := prevIs.ServiceSpec, vmis.ServiceSpec
 			if err := reconcile.AdditionalServices(ctx, rclient, cr.GetInsertName(), cr.Namespace, prevSvc, currSvc); err != nil {
 				return fmt.Errorf("cannot remove vminsert additional service: %w", err)
@@ -1375,6 +1508,9 @@ func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VMCluster, rcli
 				return fmt.Errorf("cannot delete PodDisruptionBudget for cluster lb: %w", err)
 			}
 		}
+		if err := reconcile.RemoveOrphanedVPA(ctx, rclient, cr.GetVMAuthLBName(), cr.Namespace, prevLBSpec.VPA, lbSpec.VPA); err != nil {
+			return fmt.Errorf("cannot delete VPA for cluster lb: %w", err)
+		}
 	}
 
 	return nil
@@ -1561,7 +1697,7 @@ func createOrUpdateVMAuthLB(ctx context.Context, rclient client.Client, cr *vmv1
 	}
 	lbDep, err := buildVMauthLBDeployment(cr)
 	if err != nil {
-		return fmt.Errorf("cannot build deployment for vmauth loadbalancing: %w", err)
+		return vmv1beta1.NewConfigError(fmt.Errorf("cannot build deployment for vmauth loadbalancing: %w", err))
 	}
 	var prevLB *appsv1.Deployment
 	if cr.ParsedLastAppliedSpec != nil && cr.ParsedLastAppliedSpec.RequestsLoadBalancer.Enabled {
@@ -1575,6 +1711,16 @@ func createOrUpdateVMAuthLB(ctx context.Context, rclient client.Client, cr *vmv1
 	if err := reconcile.Deployment(ctx, rclient, lbDep, prevLB, false); err != nil {
 		return fmt.Errorf("cannot reconcile vmauth lb deployment: %w", err)
 	}
+	if vpa := cr.Spec.RequestsLoadBalancer.Spec.VPA; vpa != nil {
+		targetRef := autoscalingv1.CrossVersionObjectReference{
+			Name:       cr.GetVMAuthLBName(),
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		}
+		if err := reconcile.VPA(ctx, rclient, build.VPA(targetRef, "vmauth", vpa, cr.AsOwner(), cr.VMAuthLBSelectorLabels(), cr.Namespace)); err != nil {
+			return fmt.Errorf("cannot reconcile vmauth lb vpa: %w", err)
+		}
+	}
 	if err := createOrUpdateVMAuthLBService(ctx, rclient, cr); err != nil {
 		return err
 	}
@@ -0,0 +1,170 @@
+package vmcluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/VictoriaMetrics/metricsql"
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultRetentionPeriod matches vmstorage default for -retentionPeriod flag
+const defaultRetentionPeriod = "1"
+
+// handleVMStorageScaleDown tracks removal of vmstorage nodes in cluster status
+// and returns a copy of cluster with adjusted storage nodes routing.
+//
+// Departing nodes go through the following phases:
+// ReadOnly - node is excluded from vminsert -storageNode list.
+// Detached - after readOnlyPeriod node is excluded from vmselect -storageNode list.
+// Once all departing nodes were detached at the previous reconcile and vmselect is rolled out without them,
+// vmstorage statefulset is shrunk to the requested replicaCount.
+func handleVMStorageScaleDown(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMCluster) (*vmv1beta1.VMCluster, error) {
+	if !cr.Spec.VMStorage.IsScaleDownEnabled() || cr.Spec.VMStorage.ReplicaCount == nil {
+		return cr, updateStorageScaleDownStatus(ctx, rclient, cr, nil)
+	}
+	var sts appsv1.StatefulSet
+	if err := rclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Spec.VMStorage.GetNameWithPrefix(cr.Name)}, &sts); err != nil {
+		if errors.IsNotFound(err) {
+			return cr, updateStorageScaleDownStatus(ctx, rclient, cr, nil)
+		}
+		return nil, fmt.Errorf("cannot get vmstorage statefulset: %w", err)
+	}
+	currentReplicas := ptr.Deref(sts.Spec.Replicas, 1)
+	targetReplicas := *cr.Spec.VMStorage.ReplicaCount
+	if targetReplicas >= currentReplicas {
+		// scale-down is finished or was cancelled
+		return cr, updateStorageScaleDownStatus(ctx, rclient, cr, nil)
+	}
+	readOnlyPeriod, err := getReadOnlyPeriod(cr)
+	if err != nil {
+		return nil, vmv1beta1.NewConfigError(err)
+	}
+
+	prevNodes := make(map[int32]vmv1beta1.VMStorageNodeScaleDownStatus)
+	if cr.Status.StorageScaleDown != nil {
+		for _, node := range cr.Status.StorageScaleDown.Nodes {
+			prevNodes[node.ID] = node
+		}
+	}
+	now := metav1.Now()
+	status := &vmv1beta1.VMStorageScaleDownStatus{TargetReplicaCount: targetReplicas}
+	// nodes detached at this reconcile are still known by vmselect,
+	// it's updated after vmstorage and statefulset could be shrunk only at the next reconcile
+	wasDetached := true
+	for id := targetReplicas; id < currentReplicas; id++ {
+		node, ok := prevNodes[id]
+		if !ok {
+			node = vmv1beta1.VMStorageNodeScaleDownStatus{
+				ID:                 id,
+				Phase:              vmv1beta1.VMStorageNodeReadOnly,
+				LastTransitionTime: now,
+			}
+		}
+		if node.Phase != vmv1beta1.VMStorageNodeDetached {
+			wasDetached = false
+		}
+		if node.Phase == vmv1beta1.VMStorageNodeReadOnly && now.Sub(node.LastTransitionTime.Time) >= readOnlyPeriod {
+			node.Phase = vmv1beta1.VMStorageNodeDetached
+			node.LastTransitionTime = now
+		}
+		status.Nodes = append(status.Nodes, node)
+	}
+	if err := updateStorageScaleDownStatus(ctx, rclient, cr, status); err != nil {
+		return nil, err
+	}
+	if wasDetached {
+		rolledOut, err := isVMSelectRolledOut(ctx, rclient, cr)
+		if err != nil {
+			return nil, err
+		}
+		if rolledOut {
+			logger.WithContext(ctx).Info("all departing vmstorage nodes are detached, shrinking statefulset", "replicas", targetReplicas)
+			return cr, nil
+		}
+	}
+
+	// keep departing nodes running and exclude them from requests routing
+	effective := cr.DeepCopy()
+	effective.Spec.VMStorage.ReplicaCount = &currentReplicas
+	for _, node := range status.Nodes {
+		effective.Spec.VMStorage.MaintenanceInsertNodeIDs = appendNodeID(effective.Spec.VMStorage.MaintenanceInsertNodeIDs, node.ID)
+		if node.Phase == vmv1beta1.VMStorageNodeDetached {
+			effective.Spec.VMStorage.MaintenanceSelectNodeIDs = appendNodeID(effective.Spec.VMStorage.MaintenanceSelectNodeIDs, node.ID)
+		}
+	}
+	return effective, nil
+}
+
+// isVMSelectRolledOut checks if vmselect statefulset has no pending updates
+// it ensures, that vmselect pods don't query departing vmstorage nodes anymore
+func isVMSelectRolledOut(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMCluster) (bool, error) {
+	if cr.Spec.VMSelect == nil {
+		return true, nil
+	}
+	var sts appsv1.StatefulSet
+	if err := rclient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.GetSelectName()}, &sts); err != nil {
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("cannot get vmselect statefulset: %w", err)
+	}
+	return sts.Status.ObservedGeneration >= sts.Generation && sts.Status.UpdatedReplicas >= ptr.Deref(sts.Spec.Replicas, 1), nil
+}
+
+// updateStorageScaleDownStatus patches cluster status with given scale-down progress if it was changed
+func updateStorageScaleDownStatus(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMCluster, status *vmv1beta1.VMStorageScaleDownStatus) error {
+	if equality.Semantic.DeepEqual(cr.Status.StorageScaleDown, status) {
+		return nil
+	}
+	data, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("BUG: cannot serialize storage scale-down status: %w", err)
+	}
+	pt := client.RawPatch(types.MergePatchType, []byte(fmt.Sprintf(`{"status": {"storageScaleDown": %s}}`, data)))
+	if err := rclient.Status().Patch(ctx, cr.DeepCopy(), pt); err != nil {
+		return fmt.Errorf("cannot patch storage scale-down status: %w", err)
+	}
+	cr.Status.StorageScaleDown = status
+	return nil
+}
+
+// getReadOnlyPeriod returns duration for departing nodes to stay at ReadOnly phase
+func getReadOnlyPeriod(cr *vmv1beta1.VMCluster) (time.Duration, error) {
+	period := cr.Spec.VMStorage.ScaleDown.ReadOnlyPeriod
+	if period == "" {
+		period = cr.Spec.RetentionPeriod
+	}
+	if period == "" {
+		period = defaultRetentionPeriod
+	}
+	// value without suffix is a number of months
+	if months, err := strconv.ParseFloat(period, 64); err == nil {
+		return time.Duration(months * 31 * 24 * float64(time.Hour)), nil
+	}
+	ms, err := metricsql.DurationValue(period, 0)
+	if err != nil {
+		return 0, fmt.Errorf("cannot parse vmstorage scaleDown readOnlyPeriod=%q: %w", period, err)
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+func appendNodeID(dst []int32, id int32) []int32 {
+	for _, v := range dst {
+		if v == id {
+			return dst
+		}
+	}
+	return append(dst, id)
+}
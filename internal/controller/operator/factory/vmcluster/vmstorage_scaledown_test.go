@@ -0,0 +1,269 @@
+package vmcluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+func TestHandleVMStorageScaleDown(t *testing.T) {
+	type opts struct {
+		replicas      int32
+		stsReplicas   int32
+		scaleDown     *vmv1beta1.VMStorageScaleDown
+		status        *vmv1beta1.VMStorageScaleDownStatus
+		selectStatus  *appsv1.StatefulSetStatus
+		wantReplicas  int32
+		wantInsertIDs []int32
+		wantSelectIDs []int32
+		wantPhases    map[int32]vmv1beta1.VMStorageNodeScaleDownPhase
+		wantErr       bool
+	}
+	f := func(o opts) {
+		t.Helper()
+		cr := &vmv1beta1.VMCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-1", Namespace: "default"},
+			Spec: vmv1beta1.VMClusterSpec{
+				RetentionPeriod: "1d",
+				VMStorage: &vmv1beta1.VMStorage{
+					CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+						ReplicaCount: ptr.To(o.replicas),
+					},
+					ScaleDown: o.scaleDown,
+				},
+			},
+			Status: vmv1beta1.VMClusterStatus{StorageScaleDown: o.status},
+		}
+		sts := &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: cr.Spec.VMStorage.GetNameWithPrefix(cr.Name), Namespace: cr.Namespace},
+			Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(o.stsReplicas)},
+		}
+		objects := []runtime.Object{sts}
+		if o.selectStatus != nil {
+			cr.Spec.VMSelect = &vmv1beta1.VMSelect{}
+			objects = append(objects, &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: cr.GetSelectName(), Namespace: cr.Namespace, Generation: 2},
+				Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(int32(2))},
+				Status:     *o.selectStatus,
+			})
+		}
+		fclient := k8stools.GetTestClientWithObjects(append(objects, cr.DeepCopy()))
+		ctx := context.Background()
+		got, err := handleVMStorageScaleDown(ctx, fclient, cr)
+		if (err != nil) != o.wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, o.wantErr)
+		}
+		if o.wantErr {
+			return
+		}
+		if *got.Spec.VMStorage.ReplicaCount != o.wantReplicas {
+			t.Fatalf("unexpected replicas, got: %d, want: %d", *got.Spec.VMStorage.ReplicaCount, o.wantReplicas)
+		}
+		if !cmp.Equal(got.Spec.VMStorage.MaintenanceInsertNodeIDs, o.wantInsertIDs) {
+			t.Fatalf("unexpected maintenance insert node ids: %s", cmp.Diff(got.Spec.VMStorage.MaintenanceInsertNodeIDs, o.wantInsertIDs))
+		}
+		if !cmp.Equal(got.Spec.VMStorage.MaintenanceSelectNodeIDs, o.wantSelectIDs) {
+			t.Fatalf("unexpected maintenance select node ids: %s", cmp.Diff(got.Spec.VMStorage.MaintenanceSelectNodeIDs, o.wantSelectIDs))
+		}
+		if *cr.Spec.VMStorage.ReplicaCount != o.replicas {
+			t.Fatalf("original spec must not be modified")
+		}
+		var stored vmv1beta1.VMCluster
+		if err := fclient.Get(ctx, types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, &stored); err != nil {
+			t.Fatalf("cannot get vmcluster: %s", err)
+		}
+		if len(o.wantPhases) == 0 {
+			if stored.Status.StorageScaleDown != nil {
+				t.Fatalf("expected empty scale-down status, got: %v", stored.Status.StorageScaleDown)
+			}
+			return
+		}
+		if stored.Status.StorageScaleDown == nil {
+			t.Fatalf("expected scale-down status to be set")
+		}
+		gotPhases := make(map[int32]vmv1beta1.VMStorageNodeScaleDownPhase)
+		for _, node := range stored.Status.StorageScaleDown.Nodes {
+			gotPhases[node.ID] = node.Phase
+		}
+		if !cmp.Equal(gotPhases, o.wantPhases) {
+			t.Fatalf("unexpected node phases: %s", cmp.Diff(gotPhases, o.wantPhases))
+		}
+		if stored.Status.StorageScaleDown.TargetReplicaCount != o.replicas {
+			t.Fatalf("unexpected target replicas: %d", stored.Status.StorageScaleDown.TargetReplicaCount)
+		}
+	}
+	hourAgo := metav1.NewTime(time.Now().Add(-time.Hour))
+	weekAgo := metav1.NewTime(time.Now().Add(-7 * 24 * time.Hour))
+
+	// scale-down is disabled
+	f(opts{
+		replicas:     2,
+		stsReplicas:  3,
+		wantReplicas: 2,
+	})
+
+	// scale-down started
+	f(opts{
+		replicas:      2,
+		stsReplicas:   4,
+		scaleDown:     &vmv1beta1.VMStorageScaleDown{Enabled: true},
+		wantReplicas:  4,
+		wantInsertIDs: []int32{2, 3},
+		wantPhases: map[int32]vmv1beta1.VMStorageNodeScaleDownPhase{
+			2: vmv1beta1.VMStorageNodeReadOnly,
+			3: vmv1beta1.VMStorageNodeReadOnly,
+		},
+	})
+
+	// read-only period is not passed yet
+	f(opts{
+		replicas:    2,
+		stsReplicas: 3,
+		scaleDown:   &vmv1beta1.VMStorageScaleDown{Enabled: true},
+		status: &vmv1beta1.VMStorageScaleDownStatus{
+			TargetReplicaCount: 2,
+			Nodes: []vmv1beta1.VMStorageNodeScaleDownStatus{
+				{ID: 2, Phase: vmv1beta1.VMStorageNodeReadOnly, LastTransitionTime: hourAgo},
+			},
+		},
+		wantReplicas:  3,
+		wantInsertIDs: []int32{2},
+		wantPhases: map[int32]vmv1beta1.VMStorageNodeScaleDownPhase{
+			2: vmv1beta1.VMStorageNodeReadOnly,
+		},
+	})
+
+	// read-only period is passed, node detached from vmselect
+	f(opts{
+		replicas:    2,
+		stsReplicas: 4,
+		scaleDown:   &vmv1beta1.VMStorageScaleDown{Enabled: true, ReadOnlyPeriod: "30m"},
+		status: &vmv1beta1.VMStorageScaleDownStatus{
+			TargetReplicaCount: 2,
+			Nodes: []vmv1beta1.VMStorageNodeScaleDownStatus{
+				{ID: 2, Phase: vmv1beta1.VMStorageNodeReadOnly, LastTransitionTime: hourAgo},
+			},
+		},
+		wantReplicas:  4,
+		wantInsertIDs: []int32{2, 3},
+		wantSelectIDs: []int32{2},
+		wantPhases: map[int32]vmv1beta1.VMStorageNodeScaleDownPhase{
+			2: vmv1beta1.VMStorageNodeDetached,
+			3: vmv1beta1.VMStorageNodeReadOnly,
+		},
+	})
+
+	// all nodes detached, statefulset must be kept until vmselect is updated
+	f(opts{
+		replicas:    2,
+		stsReplicas: 3,
+		scaleDown:   &vmv1beta1.VMStorageScaleDown{Enabled: true},
+		status: &vmv1beta1.VMStorageScaleDownStatus{
+			TargetReplicaCount: 2,
+			Nodes: []vmv1beta1.VMStorageNodeScaleDownStatus{
+				{ID: 2, Phase: vmv1beta1.VMStorageNodeReadOnly, LastTransitionTime: weekAgo},
+			},
+		},
+		selectStatus:  &appsv1.StatefulSetStatus{ObservedGeneration: 2, UpdatedReplicas: 2},
+		wantReplicas:  3,
+		wantInsertIDs: []int32{2},
+		wantSelectIDs: []int32{2},
+		wantPhases: map[int32]vmv1beta1.VMStorageNodeScaleDownPhase{
+			2: vmv1beta1.VMStorageNodeDetached,
+		},
+	})
+
+	// nodes were detached at the previous reconcile, vmselect isn't rolled out yet
+	f(opts{
+		replicas:    2,
+		stsReplicas: 3,
+		scaleDown:   &vmv1beta1.VMStorageScaleDown{Enabled: true},
+		status: &vmv1beta1.VMStorageScaleDownStatus{
+			TargetReplicaCount: 2,
+			Nodes: []vmv1beta1.VMStorageNodeScaleDownStatus{
+				{ID: 2, Phase: vmv1beta1.VMStorageNodeDetached, LastTransitionTime: hourAgo},
+			},
+		},
+		selectStatus:  &appsv1.StatefulSetStatus{ObservedGeneration: 2, UpdatedReplicas: 1},
+		wantReplicas:  3,
+		wantInsertIDs: []int32{2},
+		wantSelectIDs: []int32{2},
+		wantPhases: map[int32]vmv1beta1.VMStorageNodeScaleDownPhase{
+			2: vmv1beta1.VMStorageNodeDetached,
+		},
+	})
+
+	// nodes were detached at the previous reconcile, statefulset must be shrunk
+	f(opts{
+		replicas:    2,
+		stsReplicas: 3,
+		scaleDown:   &vmv1beta1.VMStorageScaleDown{Enabled: true},
+		status: &vmv1beta1.VMStorageScaleDownStatus{
+			TargetReplicaCount: 2,
+			Nodes: []vmv1beta1.VMStorageNodeScaleDownStatus{
+				{ID: 2, Phase: vmv1beta1.VMStorageNodeDetached, LastTransitionTime: hourAgo},
+			},
+		},
+		selectStatus: &appsv1.StatefulSetStatus{ObservedGeneration: 2, UpdatedReplicas: 2},
+		wantReplicas: 2,
+		wantPhases: map[int32]vmv1beta1.VMStorageNodeScaleDownPhase{
+			2: vmv1beta1.VMStorageNodeDetached,
+		},
+	})
+
+	// scale-down finished
+	f(opts{
+		replicas:    2,
+		stsReplicas: 2,
+		scaleDown:   &vmv1beta1.VMStorageScaleDown{Enabled: true},
+		status: &vmv1beta1.VMStorageScaleDownStatus{
+			TargetReplicaCount: 2,
+			Nodes: []vmv1beta1.VMStorageNodeScaleDownStatus{
+				{ID: 2, Phase: vmv1beta1.VMStorageNodeDetached, LastTransitionTime: hourAgo},
+			},
+		},
+		wantReplicas: 2,
+	})
+
+	// incorrect read-only period
+	f(opts{
+		replicas:    2,
+		stsReplicas: 3,
+		scaleDown:   &vmv1beta1.VMStorageScaleDown{Enabled: true, ReadOnlyPeriod: "bad-value"},
+		wantErr:     true,
+	})
+}
+
+func TestGetReadOnlyPeriod(t *testing.T) {
+	f := func(retentionPeriod, readOnlyPeriod string, want time.Duration) {
+		t.Helper()
+		cr := &vmv1beta1.VMCluster{
+			Spec: vmv1beta1.VMClusterSpec{
+				RetentionPeriod: retentionPeriod,
+				VMStorage: &vmv1beta1.VMStorage{
+					ScaleDown: &vmv1beta1.VMStorageScaleDown{Enabled: true, ReadOnlyPeriod: readOnlyPeriod},
+				},
+			},
+		}
+		got, err := getReadOnlyPeriod(cr)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got != want {
+			t.Fatalf("unexpected period, got: %s, want: %s", got, want)
+		}
+	}
+	f("", "", 31*24*time.Hour)
+	f("2", "", 62*24*time.Hour)
+	f("14d", "", 14*24*time.Hour)
+	f("2", "1h", time.Hour)
+}
@@ -0,0 +1,271 @@
+package vmcluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	zoneLabel              = "operator.victoriametrics.com/zone"
+	zoneClusterLabel       = "operator.victoriametrics.com/vmcluster"
+	defaultZoneTopologyKey = "topology.kubernetes.io/zone"
+)
+
+// createOrUpdateZones reconciles separate vmstorage, vmselect and vminsert components per each cluster zone
+func createOrUpdateZones(ctx context.Context, cr *vmv1beta1.VMCluster, rclient client.Client) error {
+	if cr.Spec.RequestsLoadBalancer.Enabled {
+		return vmv1beta1.NewConfigError(fmt.Errorf("requestsLoadBalancer cannot be used with zones"))
+	}
+	for i := range cr.Spec.Zones.Items {
+		zone := &cr.Spec.Zones.Items[i]
+		if err := createOrUpdateComponents(ctx, buildZoneCluster(cr, zone), rclient); err != nil {
+			return fmt.Errorf("cannot reconcile zone=%q: %w", zone.Name, err)
+		}
+	}
+	if cr.Spec.VMSelect != nil {
+		if err := createOrUpdateZonesVMSelectService(ctx, cr, rclient); err != nil {
+			return err
+		}
+	} else if cr.ParsedLastAppliedSpec != nil && cr.ParsedLastAppliedSpec.VMSelect != nil {
+		if err := finalize.SafeDeleteWithFinalizer(ctx, rclient, buildZonesVMSelectService(cr)); err != nil {
+			return fmt.Errorf("cannot remove zones vmselect service: %w", err)
+		}
+	}
+	if err := deletePrevZones(ctx, cr, rclient); err != nil {
+		return fmt.Errorf("failed to remove zones from previous cluster state: %w", err)
+	}
+	return nil
+}
+
+// buildZoneCluster returns zone view of the cluster with zone specific configuration for the current and last applied specs
+func buildZoneCluster(cr *vmv1beta1.VMCluster, zone *vmv1beta1.VMClusterZone) *vmv1beta1.VMCluster {
+	zc := cr.ZoneView(zone.Name)
+	applyZoneSpec(zc, cr.Spec.Zones, zone)
+	if cr.ParsedLastAppliedSpec != nil {
+		if prevZone := findZone(cr.ParsedLastAppliedSpec.Zones, zone.Name); prevZone != nil {
+			prevCR := cr.DeepCopy()
+			prevCR.Spec = *cr.ParsedLastAppliedSpec
+			pzc := prevCR.ZoneView(zone.Name)
+			applyZoneSpec(pzc, prevCR.Spec.Zones, prevZone)
+			zc.ParsedLastAppliedSpec = &pzc.Spec
+		}
+	}
+	return zc
+}
+
+// applyZoneSpec pins zone cluster components to the zone nodes
+// and configures vmselect to query vmstorage nodes of all active zones
+func applyZoneSpec(zc *vmv1beta1.VMCluster, zones *vmv1beta1.VMClusterZones, zone *vmv1beta1.VMClusterZone) {
+	topologyKey := zones.TopologyKey
+	if topologyKey == "" {
+		topologyKey = defaultZoneTopologyKey
+	}
+	zoneLabels := map[string]string{
+		zoneLabel:        zone.Name,
+		zoneClusterLabel: zc.ParentName,
+	}
+	if vms := zc.Spec.VMStorage; vms != nil {
+		vms.Affinity = addZoneNodeAffinity(vms.Affinity, topologyKey, zone.GetTopologyValue())
+		vms.PodMetadata = addZoneLabels(vms.PodMetadata, zoneLabels)
+		vms.ServiceSpec = addZoneServiceSuffix(vms.ServiceSpec, zone.Name)
+	}
+	if vmse := zc.Spec.VMSelect; vmse != nil {
+		vmse.Affinity = addZoneNodeAffinity(vmse.Affinity, topologyKey, zone.GetTopologyValue())
+		vmse.PodMetadata = addZoneLabels(vmse.PodMetadata, zoneLabels)
+		vmse.ServiceSpec = addZoneServiceSuffix(vmse.ServiceSpec, zone.Name)
+		if zone.Drain {
+			vmse.ReplicaCount = ptr.To(int32(0))
+			vmse.HPA = nil
+		}
+		addZonesStorageNodes(zc, zones)
+	}
+	if vmi := zc.Spec.VMInsert; vmi != nil {
+		vmi.Affinity = addZoneNodeAffinity(vmi.Affinity, topologyKey, zone.GetTopologyValue())
+		vmi.PodMetadata = addZoneLabels(vmi.PodMetadata, zoneLabels)
+		vmi.ServiceSpec = addZoneServiceSuffix(vmi.ServiceSpec, zone.Name)
+		if zone.Drain {
+			vmi.ReplicaCount = ptr.To(int32(0))
+			vmi.HPA = nil
+		}
+	}
+}
+
+// addZonesStorageNodes configures vmselect with vmstorage groups of all not drained zones
+//
+// See https://docs.victoriametrics.com/cluster-victoriametrics/#vmstorage-groups-at-vmselect
+func addZonesStorageNodes(zc *vmv1beta1.VMCluster, zones *vmv1beta1.VMClusterZones) {
+	vmse := zc.Spec.VMSelect
+	if zc.Spec.VMStorage == nil || zc.Spec.VMStorage.ReplicaCount == nil {
+		return
+	}
+	if _, ok := vmse.ExtraArgs["storageNode"]; ok {
+		// user defined value has priority
+		return
+	}
+	var storageNodes, groupsReplicationFactor []string
+	var activeZones int32
+	for _, zone := range zones.Items {
+		if zone.Drain {
+			continue
+		}
+		activeZones++
+		storageName := zc.Spec.VMStorage.GetNameWithPrefix(fmt.Sprintf("%s-%s", zc.ParentName, zone.Name))
+		for _, id := range zc.AvailableStorageNodeIDs("select") {
+			addr := zc.Spec.VMStorage.BuildPodName(storageName, id, zc.Namespace, zc.Spec.VMStorage.VMSelectPort, zc.Spec.ClusterDomainName)
+			storageNodes = append(storageNodes, fmt.Sprintf("%s/%s", zone.Name, strings.TrimSuffix(addr, ",")))
+		}
+		if zc.Spec.ReplicationFactor != nil && *zc.Spec.ReplicationFactor > 1 {
+			groupsReplicationFactor = append(groupsReplicationFactor, fmt.Sprintf("%s:%d", zone.Name, *zc.Spec.ReplicationFactor))
+		}
+	}
+	if len(storageNodes) == 0 {
+		return
+	}
+	globalReplicationFactor := activeZones
+	if zones.GlobalReplicationFactor != nil && *zones.GlobalReplicationFactor < activeZones {
+		globalReplicationFactor = *zones.GlobalReplicationFactor
+	}
+	extraArgs := make(map[string]string, len(vmse.ExtraArgs)+4)
+	for k, v := range vmse.ExtraArgs {
+		extraArgs[k] = v
+	}
+	extraArgs["storageNode"] = strings.Join(storageNodes, ",")
+	if _, ok := extraArgs["globalReplicationFactor"]; !ok {
+		extraArgs["globalReplicationFactor"] = fmt.Sprintf("%d", globalReplicationFactor)
+	}
+	if _, ok := extraArgs["replicationFactor"]; !ok && len(groupsReplicationFactor) > 0 {
+		extraArgs["replicationFactor"] = strings.Join(groupsReplicationFactor, ",")
+	}
+	if _, ok := extraArgs["dedup.minScrapeInterval"]; !ok && globalReplicationFactor > 1 {
+		extraArgs["dedup.minScrapeInterval"] = "1ms"
+	}
+	vmse.ExtraArgs = extraArgs
+}
+
+// addZoneNodeAffinity adds required node affinity for the zone topology label
+func addZoneNodeAffinity(affinity *corev1.Affinity, topologyKey, topologyValue string) *corev1.Affinity {
+	if affinity == nil {
+		affinity = &corev1.Affinity{}
+	}
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	na := affinity.NodeAffinity
+	zoneRequirement := corev1.NodeSelectorRequirement{
+		Key:      topologyKey,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{topologyValue},
+	}
+	if na.RequiredDuringSchedulingIgnoredDuringExecution == nil || len(na.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms) == 0 {
+		na.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{}},
+		}
+	}
+	// node selector terms are ORed, so requirement must be added to each of them
+	terms := na.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	for i := range terms {
+		terms[i].MatchExpressions = append(terms[i].MatchExpressions, zoneRequirement)
+	}
+	return affinity
+}
+
+func addZoneLabels(meta *vmv1beta1.EmbeddedObjectMetadata, zoneLabels map[string]string) *vmv1beta1.EmbeddedObjectMetadata {
+	if meta == nil {
+		meta = &vmv1beta1.EmbeddedObjectMetadata{}
+	}
+	if meta.Labels == nil {
+		meta.Labels = make(map[string]string, len(zoneLabels))
+	}
+	for k, v := range zoneLabels {
+		meta.Labels[k] = v
+	}
+	return meta
+}
+
+// addZoneServiceSuffix prevents name collisions for additional services of different zones
+func addZoneServiceSuffix(ss *vmv1beta1.AdditionalServiceSpec, zoneName string) *vmv1beta1.AdditionalServiceSpec {
+	if ss == nil || ss.Name == "" {
+		return ss
+	}
+	ss.Name = fmt.Sprintf("%s-%s", ss.Name, zoneName)
+	return ss
+}
+
+func findZone(zones *vmv1beta1.VMClusterZones, name string) *vmv1beta1.VMClusterZone {
+	if zones == nil {
+		return nil
+	}
+	for i := range zones.Items {
+		if zones.Items[i].Name == name {
+			return &zones.Items[i]
+		}
+	}
+	return nil
+}
+
+// buildZonesVMSelectService builds service, which balances requests across vmselect pods of all zones
+func buildZonesVMSelectService(cr *vmv1beta1.VMCluster) *corev1.Service {
+	svc := buildVMSelectService(cr)
+	svc.Spec.Selector = map[string]string{
+		"app.kubernetes.io/name":      "vmselect",
+		"app.kubernetes.io/component": "monitoring",
+		"managed-by":                  "vm-operator",
+		zoneClusterLabel:              cr.Name,
+	}
+	return svc
+}
+
+func createOrUpdateZonesVMSelectService(ctx context.Context, cr *vmv1beta1.VMCluster, rclient client.Client) error {
+	svc := buildZonesVMSelectService(cr)
+	var prevService *corev1.Service
+	if cr.ParsedLastAppliedSpec != nil && cr.ParsedLastAppliedSpec.VMSelect != nil {
+		prevCR := cr.DeepCopy()
+		prevCR.Spec = *cr.ParsedLastAppliedSpec
+		prevService = buildZonesVMSelectService(prevCR)
+	}
+	if err := reconcile.Service(ctx, rclient, svc, prevService); err != nil {
+		return fmt.Errorf("cannot reconcile zones vmselect service: %w", err)
+	}
+	return nil
+}
+
+// deletePrevZones removes components of zones, which were removed from the cluster spec.
+// It also removes components of not zoned cluster on transition to zones
+func deletePrevZones(ctx context.Context, cr *vmv1beta1.VMCluster, rclient client.Client) error {
+	if cr.ParsedLastAppliedSpec == nil {
+		return nil
+	}
+	prevCR := cr.DeepCopy()
+	prevCR.Spec = *cr.ParsedLastAppliedSpec
+	if !prevCR.IsZoned() {
+		if !cr.IsZoned() {
+			return nil
+		}
+		if err := finalize.OnVMClusterComponentsDelete(ctx, rclient, prevCR); err != nil {
+			return fmt.Errorf("cannot remove not zoned components: %w", err)
+		}
+		if prevCR.Spec.RequestsLoadBalancer.Enabled {
+			if err := finalize.OnVMClusterLoadBalancerDelete(ctx, rclient, prevCR); err != nil {
+				return fmt.Errorf("cannot remove loadbalancer components: %w", err)
+			}
+		}
+		return nil
+	}
+	for _, prevZone := range prevCR.Spec.Zones.Items {
+		if cr.IsZoned() && findZone(cr.Spec.Zones, prevZone.Name) != nil {
+			continue
+		}
+		if err := finalize.OnVMClusterComponentsDelete(ctx, rclient, prevCR.ZoneView(prevZone.Name)); err != nil {
+			return fmt.Errorf("cannot remove components of zone=%q: %w", prevZone.Name, err)
+		}
+	}
+	return nil
+}
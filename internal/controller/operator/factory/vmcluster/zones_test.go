@@ -0,0 +1,229 @@
+package vmcluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+func TestBuildZoneCluster(t *testing.T) {
+	f := func(cr *vmv1beta1.VMCluster, zoneName string, validate func(zc *vmv1beta1.VMCluster) error) {
+		t.Helper()
+		zc := buildZoneCluster(cr, findZone(cr.Spec.Zones, zoneName))
+		if err := validate(zc); err != nil {
+			t.Fatalf("validation failed: %s", err)
+		}
+	}
+	newCluster := func(zones ...vmv1beta1.VMClusterZone) *vmv1beta1.VMCluster {
+		return &vmv1beta1.VMCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default", UID: "uid"},
+			Spec: vmv1beta1.VMClusterSpec{
+				ReplicationFactor: ptr.To(int32(2)),
+				VMStorage: &vmv1beta1.VMStorage{
+					CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{ReplicaCount: ptr.To(int32(2))},
+					CommonDefaultableParams:           vmv1beta1.CommonDefaultableParams{Port: "8482"},
+					VMSelectPort:                      "8401",
+				},
+				VMSelect: &vmv1beta1.VMSelect{
+					CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{ReplicaCount: ptr.To(int32(2))},
+				},
+				VMInsert: &vmv1beta1.VMInsert{
+					CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{ReplicaCount: ptr.To(int32(2))},
+				},
+				Zones: &vmv1beta1.VMClusterZones{Items: zones},
+			},
+		}
+	}
+
+	// active zones
+	f(newCluster(vmv1beta1.VMClusterZone{Name: "a"}, vmv1beta1.VMClusterZone{Name: "b", TopologyValue: "eu-west-1b"}), "b", func(zc *vmv1beta1.VMCluster) error {
+		if zc.Name != "main-b" || zc.Spec.VMStorage.GetNameWithPrefix(zc.Name) != "vmstorage-main-b" {
+			return fmt.Errorf("unexpected zone cluster name: %s", zc.Name)
+		}
+		if owner := zc.AsOwner()[0]; owner.Name != "main" || owner.UID != "uid" {
+			return fmt.Errorf("unexpected owner reference: %v", owner)
+		}
+		if zc.GetServiceAccountName() != "vmcluster-main" {
+			return fmt.Errorf("unexpected service account name: %s", zc.GetServiceAccountName())
+		}
+		term := zc.Spec.VMStorage.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0]
+		if term.MatchExpressions[0].Key != defaultZoneTopologyKey || term.MatchExpressions[0].Values[0] != "eu-west-1b" {
+			return fmt.Errorf("unexpected node affinity: %v", term)
+		}
+		if zc.VMInsertPodLabels()[zoneLabel] != "b" || zc.VMSelectPodLabels()[zoneClusterLabel] != "main" {
+			return fmt.Errorf("expected zone labels at pods, got: %v", zc.VMSelectPodLabels())
+		}
+		args := zc.Spec.VMSelect.ExtraArgs
+		wantNodes := "a/vmstorage-main-a-0.vmstorage-main-a.default:8401,a/vmstorage-main-a-1.vmstorage-main-a.default:8401," +
+			"b/vmstorage-main-b-0.vmstorage-main-b.default:8401,b/vmstorage-main-b-1.vmstorage-main-b.default:8401"
+		if args["storageNode"] != wantNodes {
+			return fmt.Errorf("unexpected storageNode, got: %s, want: %s", args["storageNode"], wantNodes)
+		}
+		if args["globalReplicationFactor"] != "2" || args["replicationFactor"] != "a:2,b:2" || args["dedup.minScrapeInterval"] != "1ms" {
+			return fmt.Errorf("unexpected vmselect args: %v", args)
+		}
+		return nil
+	})
+
+	// drained zone
+	f(newCluster(vmv1beta1.VMClusterZone{Name: "a"}, vmv1beta1.VMClusterZone{Name: "b", Drain: true}, vmv1beta1.VMClusterZone{Name: "c"}), "b", func(zc *vmv1beta1.VMCluster) error {
+		if *zc.Spec.VMSelect.ReplicaCount != 0 || *zc.Spec.VMInsert.ReplicaCount != 0 {
+			return fmt.Errorf("expected vmselect and vminsert of drained zone to be scaled to 0")
+		}
+		if *zc.Spec.VMStorage.ReplicaCount != 2 {
+			return fmt.Errorf("vmstorage of drained zone must be kept")
+		}
+		args := zc.Spec.VMSelect.ExtraArgs
+		if strings.Contains(args["storageNode"], "b/") {
+			return fmt.Errorf("unexpected drained zone at storageNode: %s", args["storageNode"])
+		}
+		if args["globalReplicationFactor"] != "2" {
+			return fmt.Errorf("unexpected globalReplicationFactor: %s", args["globalReplicationFactor"])
+		}
+		return nil
+	})
+
+	// user defined node affinity and storage nodes
+	cr := newCluster(vmv1beta1.VMClusterZone{Name: "a"})
+	cr.Spec.Zones.TopologyKey = "zone"
+	cr.Spec.VMSelect.ExtraArgs = map[string]string{"storageNode": "custom:8401"}
+	cr.Spec.VMInsert.Affinity = &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{
+			{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "disk", Operator: corev1.NodeSelectorOpIn, Values: []string{"ssd"}}}},
+			{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "disk", Operator: corev1.NodeSelectorOpIn, Values: []string{"nvme"}}}},
+		}},
+	}}
+	f(cr, "a", func(zc *vmv1beta1.VMCluster) error {
+		if len(zc.Spec.VMSelect.ExtraArgs) != 1 || zc.Spec.VMSelect.ExtraArgs["storageNode"] != "custom:8401" {
+			return fmt.Errorf("user defined storageNode must be kept, got: %v", zc.Spec.VMSelect.ExtraArgs)
+		}
+		for _, term := range zc.Spec.VMInsert.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+			if len(term.MatchExpressions) != 2 || term.MatchExpressions[1].Key != "zone" {
+				return fmt.Errorf("expected zone requirement at each node selector term, got: %v", term)
+			}
+		}
+		if len(cr.Spec.VMInsert.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions) != 1 {
+			return fmt.Errorf("origin cluster spec must not be modified")
+		}
+		return nil
+	})
+}
+
+func TestCreateOrUpdateVMClusterZones(t *testing.T) {
+	f := func(cr *vmv1beta1.VMCluster, wantDeployments, wantRemoved []string, predefinedObjects ...runtime.Object) {
+		t.Helper()
+		fclient := k8stools.GetTestClientWithObjects(predefinedObjects)
+		build.AddDefaults(fclient.Scheme())
+		fclient.Scheme().Default(cr)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var wg sync.WaitGroup
+		for _, name := range wantDeployments {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				tc := time.NewTicker(time.Millisecond * 100)
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-tc.C:
+						var dep appsv1.Deployment
+						if err := fclient.Get(ctx, types.NamespacedName{Name: name, Namespace: cr.Namespace}, &dep); err != nil {
+							if errors.IsNotFound(err) {
+								continue
+							}
+							t.Errorf("cannot get deployment: %s", err)
+							return
+						}
+						dep.Status.Conditions = append(dep.Status.Conditions, appsv1.DeploymentCondition{
+							Type:   appsv1.DeploymentProgressing,
+							Reason: "NewReplicaSetAvailable",
+							Status: "True",
+						})
+						dep.Status.Replicas = ptr.Deref(dep.Spec.Replicas, 0)
+						dep.Status.UpdatedReplicas = dep.Status.Replicas
+						dep.Status.AvailableReplicas = dep.Status.Replicas
+						if err := fclient.Status().Update(ctx, &dep); err != nil {
+							t.Errorf("cannot update deployment status: %s", err)
+						}
+						return
+					}
+				}
+			}()
+		}
+		err := CreateOrUpdateVMCluster(ctx, cr, fclient)
+		cancel()
+		wg.Wait()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		for _, name := range wantDeployments {
+			var dep appsv1.Deployment
+			if err := fclient.Get(context.Background(), types.NamespacedName{Name: name, Namespace: cr.Namespace}, &dep); err != nil {
+				t.Fatalf("cannot get deployment=%q: %s", name, err)
+			}
+			if dep.Spec.Template.Spec.Affinity == nil || dep.Spec.Template.Spec.Affinity.NodeAffinity == nil {
+				t.Fatalf("expected node affinity for deployment=%q", name)
+			}
+			if dep.OwnerReferences[0].Name != cr.Name {
+				t.Fatalf("unexpected owner reference for deployment=%q: %v", name, dep.OwnerReferences)
+			}
+		}
+		for _, name := range wantRemoved {
+			var dep appsv1.Deployment
+			err := fclient.Get(context.Background(), types.NamespacedName{Name: name, Namespace: cr.Namespace}, &dep)
+			if !errors.IsNotFound(err) {
+				t.Fatalf("expected deployment=%q to be removed, got err: %v", name, err)
+			}
+		}
+	}
+	newCluster := func(zones ...string) *vmv1beta1.VMCluster {
+		cr := &vmv1beta1.VMCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+			Spec: vmv1beta1.VMClusterSpec{
+				RetentionPeriod: "1",
+				VMInsert: &vmv1beta1.VMInsert{
+					CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{ReplicaCount: ptr.To(int32(1))},
+				},
+			},
+		}
+		if len(zones) > 0 {
+			cr.Spec.Zones = &vmv1beta1.VMClusterZones{}
+			for _, zone := range zones {
+				cr.Spec.Zones.Items = append(cr.Spec.Zones.Items, vmv1beta1.VMClusterZone{Name: zone})
+			}
+		}
+		return cr
+	}
+	deployment := func(name string) *appsv1.Deployment {
+		return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	}
+
+	// new zoned cluster
+	f(newCluster("a", "b"), []string{"vminsert-main-a", "vminsert-main-b"}, []string{"vminsert-main"})
+
+	// zone removed
+	cr := newCluster("a")
+	cr.ParsedLastAppliedSpec = &newCluster("a", "b").Spec
+	f(cr, []string{"vminsert-main-a"}, []string{"vminsert-main-b"}, deployment("vminsert-main-b"))
+
+	// transition from not zoned cluster
+	cr = newCluster("a")
+	cr.ParsedLastAppliedSpec = &newCluster().Spec
+	f(cr, []string{"vminsert-main-a"}, []string{"vminsert-main"}, deployment("vminsert-main"))
+}
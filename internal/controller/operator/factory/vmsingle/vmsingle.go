@@ -15,6 +15,7 @@ import (
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
 	"gopkg.in/yaml.v2"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -78,6 +79,11 @@ func CreateOrUpdateVMSingle(ctx context.Context, cr *vmv1beta1.VMSingle, rclient
 	if err != nil {
 		return err
 	}
+	if cr.Spec.NetworkPolicy != nil {
+		if err := createOrUpdateNetworkPolicy(ctx, rclient, cr, svc); err != nil {
+			return err
+		}
+	}
 
 	if !ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) {
 		err := reconcile.VMServiceScrapeForCRD(ctx, rclient, build.VMServiceScrapeForServiceWithSpec(svc, cr))
@@ -85,6 +91,15 @@ func CreateOrUpdateVMSingle(ctx context.Context, cr *vmv1beta1.VMSingle, rclient
 			return fmt.Errorf("cannot create serviceScrape for vmsingle: %w", err)
 		}
 	}
+	if cr.Spec.HTTPRoute != nil {
+		route, err := build.HTTPRoute(cr, cr.Spec.HTTPRoute, cr.Spec.Port, nil)
+		if err != nil {
+			return vmv1beta1.NewConfigError(fmt.Errorf("cannot build route for vmsingle: %w", err))
+		}
+		if err := reconcile.HTTPRoute(ctx, rclient, route); err != nil {
+			return fmt.Errorf("cannot create route for vmsingle: %w", err)
+		}
+	}
 	var prevDeploy *appsv1.Deployment
 	if cr.ParsedLastAppliedSpec != nil {
 		prevCR := cr.DeepCopy()
@@ -96,10 +111,24 @@ func CreateOrUpdateVMSingle(ctx context.Context, cr *vmv1beta1.VMSingle, rclient
 	}
 	newDeploy, err := newDeployForVMSingle(ctx, cr)
 	if err != nil {
-		return fmt.Errorf("cannot generate new deploy for vmsingle: %w", err)
+		return vmv1beta1.NewConfigError(fmt.Errorf("cannot generate new deploy for vmsingle: %w", err))
 	}
 
-	return reconcile.Deployment(ctx, rclient, newDeploy, prevDeploy, false)
+	if err := reconcile.Deployment(ctx, rclient, newDeploy, prevDeploy, false); err != nil {
+		return err
+	}
+	if cr.Spec.VPA != nil {
+		targetRef := autoscalingv1.CrossVersionObjectReference{
+			Name:       newDeploy.Name,
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		}
+		vpa := build.VPA(targetRef, "vmsingle", cr.Spec.VPA, cr.AsOwner(), cr.SelectorLabels(), cr.Namespace)
+		if err := reconcile.VPA(ctx, rclient, vpa); err != nil {
+			return fmt.Errorf("cannot update vpa for vmsingle: %w", err)
+		}
+	}
+	return nil
 }
 
 func newDeployForVMSingle(ctx context.Context, cr *vmv1beta1.VMSingle) (*appsv1.Deployment, error) {
@@ -413,7 +442,7 @@ func buildVMSingleStreamAggrConfig(ctx context.Context, cr *vmv1beta1.VMSingle,
 	if len(cr.Spec.StreamAggrConfig.Rules) > 0 {
 		data, err := yaml.Marshal(cr.Spec.StreamAggrConfig.Rules)
 		if err != nil {
-			return nil, fmt.Errorf("cannot serialize relabelConfig as yaml: %w", err)
+			return nil, vmv1beta1.NewConfigError(fmt.Errorf("cannot serialize relabelConfig as yaml: %w", err))
 		}
 		if len(data) > 0 {
 			cfgCM.Data[streamAggrSecretKey] = string(data)
@@ -445,6 +474,22 @@ func CreateOrUpdateVMSingleStreamAggrConfig(ctx context.Context, cr *vmv1beta1.V
 	return reconcile.ConfigMap(ctx, rclient, streamAggrCM)
 }
 
+// createOrUpdateNetworkPolicy allows access to vmsingle service ports
+func createOrUpdateNetworkPolicy(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMSingle, svc *corev1.Service) error {
+	peers, err := build.NetworkPolicyScrapePeers(ctx, rclient, cr.Spec.NetworkPolicy, cr.Namespace)
+	if err != nil {
+		return err
+	}
+	rules := &build.NetworkPolicyRules{
+		Ports: build.NetworkPolicyPortsFromService(svc),
+		Peers: peers,
+	}
+	if err := reconcile.NetworkPolicy(ctx, rclient, build.NetworkPolicy(cr, cr.Spec.NetworkPolicy, rules)); err != nil {
+		return fmt.Errorf("cannot update network policy for vmsingle: %w", err)
+	}
+	return nil
+}
+
 func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VMSingle, rclient client.Client) error {
 	if cr.ParsedLastAppliedSpec == nil {
 		return nil
@@ -456,6 +501,15 @@ func deletePrevStateResources(ctx context.Context, cr *vmv1beta1.VMSingle, rclie
 	if err := reconcile.AdditionalServices(ctx, rclient, cr.PrefixedName(), cr.Namespace, prevSvc, currSvc); err != nil {
 		return fmt.Errorf("cannot remove additional service: %w", err)
 	}
+	if err := reconcile.RemoveOrphanedHTTPRoute(ctx, rclient, cr.PrefixedName(), cr.Namespace, cr.ParsedLastAppliedSpec.HTTPRoute, cr.Spec.HTTPRoute); err != nil {
+		return err
+	}
+	if err := reconcile.RemoveOrphanedNetworkPolicy(ctx, rclient, cr.PrefixedName(), cr.Namespace, cr.ParsedLastAppliedSpec.NetworkPolicy, cr.Spec.NetworkPolicy); err != nil {
+		return err
+	}
+	if err := reconcile.RemoveOrphanedVPA(ctx, rclient, cr.PrefixedName(), cr.Namespace, cr.ParsedLastAppliedSpec.VPA, cr.Spec.VPA); err != nil {
+		return err
+	}
 
 	objMeta := metav1.ObjectMeta{Name: cr.PrefixedName(), Namespace: cr.Namespace}
 	if ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) && !ptr.Deref(cr.ParsedLastAppliedSpec.DisableSelfServiceScrape, false) {
@@ -48,6 +48,7 @@ func newCollector() *objectCollector {
 	registeredObjects := []string{
 		"vmagent", "vmalert", "vmsingle", "vmcluster", "vmalertmanager", "vmauth", "vlogs",
 		"vmalertmanagerconfig", "vmrule", "vmuser", "vmservicescrape", "vmstaticscrape", "vmnodescrape", "vmpodscrape", "vmprobescrape", "vmscrapeconfig",
+		"vmbackupjob", "vmrestorejob", "vlcluster", "vmanomaly", "vmtenant", "vmalertmanagersilence", "vmruletest", "vmblackboxexporter",
 	}
 	for _, controller := range registeredObjects {
 		oc.objectsByController[controller] = map[string]struct{}{}
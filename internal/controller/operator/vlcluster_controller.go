@@ -0,0 +1,97 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vlcluster"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VLClusterReconciler reconciles a VLCluster object
+type VLClusterReconciler struct {
+	Client       client.Client
+	Log          logr.Logger
+	OriginScheme *runtime.Scheme
+	BaseConf     *config.BaseOperatorConf
+}
+
+// Init implements crdController interface
+func (r *VLClusterReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme, cf *config.BaseOperatorConf) {
+	r.Client = rclient
+	r.Log = l.WithName("controller").WithName("VLCluster")
+	r.OriginScheme = sc
+	r.BaseConf = cf
+}
+
+// Scheme implements interface.
+func (r *VLClusterReconciler) Scheme() *runtime.Scheme {
+	return r.OriginScheme
+}
+
+// Reconcile general reconcile method for controller
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vlclusters,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vlclusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vlclusters/finalizers,verbs=*
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=*
+func (r *VLClusterReconciler) Reconcile(ctx context.Context, request ctrl.Request) (result ctrl.Result, err error) {
+	reqLogger := log.WithValues("vlcluster", request.Name, "namespace", request.Namespace)
+	ctx = logger.AddToContext(ctx, reqLogger)
+	instance := &vmv1beta1.VLCluster{}
+
+	defer func() {
+		result, err = handleReconcileErr(ctx, r.Client, instance, result, err)
+	}()
+
+	if err := r.Client.Get(ctx, request.NamespacedName, instance); err != nil {
+		return result, &getError{err, "vlcluster", request}
+	}
+
+	RegisterObjectStat(instance, "vlcluster")
+
+	if !instance.DeletionTimestamp.IsZero() {
+		if err := finalize.OnVLClusterDelete(ctx, r.Client, instance); err != nil {
+			return result, err
+		}
+		return result, nil
+	}
+	if instance.Spec.ParsingError != "" {
+		return result, &parsingError{instance.Spec.ParsingError, "vlcluster"}
+	}
+	if err := finalize.AddFinalizer(ctx, r.Client, instance); err != nil {
+		return result, err
+	}
+	r.Client.Scheme().Default(instance)
+
+	result, err = reconcileAndTrackStatus(ctx, r.Client, instance, func() (ctrl.Result, error) {
+		err = vlcluster.CreateOrUpdateVLCluster(ctx, instance, r.Client)
+		if err != nil {
+			return result, fmt.Errorf("failed create or update vlcluster: %w", err)
+		}
+		return result, nil
+	})
+	if err != nil {
+		return
+	}
+
+	result.RequeueAfter = r.BaseConf.ResyncAfterDuration()
+	return
+}
+
+// SetupWithManager general setup method
+func (r *VLClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&vmv1beta1.VLCluster{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&appsv1.StatefulSet{}).
+		WithOptions(getDefaultOptions()).
+		Complete(r)
+}
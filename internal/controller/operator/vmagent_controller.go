@@ -19,6 +19,7 @@ package operator
 import (
 	"context"
 	"sync"
+	"time"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/config"
@@ -35,6 +36,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const vmAgentShardAutoscalingRequeueInterval = time.Minute
+
 var (
 	vmAgentSync           sync.Mutex
 	vmAgentReconcileLimit = limiter.NewRateLimiter("vmagent", 5)
@@ -61,6 +64,7 @@ func (r *VMAgentReconciler) Init(rclient client.Client, l logr.Logger, sc *runti
 // +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmagents/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmagents/finalizers,verbs=*
 // +kubebuilder:rbac:groups="",resources=pods,verbs=*
+// +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=*
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=get;watch;list
 // +kubebuilder:rbac:groups="",resources=nodes/proxy,verbs=get;watch;list
 // +kubebuilder:rbac:groups="networking.k8s.io",resources=ingresses,verbs=get;watch;list
@@ -119,6 +123,10 @@ func (r *VMAgentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 		return
 	}
 	result.RequeueAfter = r.BaseConf.ResyncAfterDuration()
+	// shards load must be re-checked periodically
+	if instance.Spec.ShardAutoscaling != nil && (result.RequeueAfter == 0 || result.RequeueAfter > vmAgentShardAutoscalingRequeueInterval) {
+		result.RequeueAfter = vmAgentShardAutoscalingRequeueInterval
+	}
 
 	return
 }
@@ -134,6 +142,7 @@ func (r *VMAgentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&vmv1beta1.VMAgent{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&appsv1.StatefulSet{}).
+		Owns(&appsv1.DaemonSet{}).
 		Owns(&v1.ServiceAccount{}).
 		WithOptions(getDefaultOptions()).
 		Complete(r)
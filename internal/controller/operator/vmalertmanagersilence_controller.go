@@ -0,0 +1,108 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/alertmanager"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+)
+
+// VMAlertmanagerSilenceReconciler reconciles a VMAlertmanagerSilence object
+type VMAlertmanagerSilenceReconciler struct {
+	client.Client
+	Log          logr.Logger
+	OriginScheme *runtime.Scheme
+	BaseConf     *config.BaseOperatorConf
+}
+
+// Init implements crdController interface
+func (r *VMAlertmanagerSilenceReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme, cf *config.BaseOperatorConf) {
+	r.Client = rclient
+	r.Log = l.WithName("controller").WithName("VMAlertmanagerSilence")
+	r.OriginScheme = sc
+	r.BaseConf = cf
+}
+
+// Scheme implements interface.
+func (r *VMAlertmanagerSilenceReconciler) Scheme() *runtime.Scheme {
+	return r.OriginScheme
+}
+
+// Reconcile general reconcile method for controller
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmalertmanagersilences,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmalertmanagersilences/status,verbs=get;update;patch
+func (r *VMAlertmanagerSilenceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	reqLogger := r.Log.WithValues("vmalertmanagersilence", req.Name, "namespace", req.Namespace)
+	ctx = logger.AddToContext(ctx, reqLogger)
+	instance := &vmv1beta1.VMAlertmanagerSilence{}
+
+	defer func() {
+		result, err = handleReconcileErr(ctx, r.Client, nil, result, err)
+	}()
+
+	if err := r.Get(ctx, req.NamespacedName, instance); err != nil {
+		return result, &getError{err, "vmalertmanagersilence", req}
+	}
+
+	RegisterObjectStat(instance, "vmalertmanagersilence")
+	if !instance.DeletionTimestamp.IsZero() {
+		// silence must be expired at alertmanagers before object removal
+		if err := alertmanager.DeleteSilence(ctx, r, instance); err != nil {
+			return result, fmt.Errorf("cannot expire silence: %w", err)
+		}
+		if err := finalize.RemoveFinalizer(ctx, r, instance); err != nil {
+			return result, fmt.Errorf("cannot remove finalizer for vmalertmanagersilence: %w", err)
+		}
+		return
+	}
+	if err := finalize.AddFinalizer(ctx, r.Client, instance); err != nil {
+		return result, err
+	}
+
+	if err := alertmanager.CreateOrUpdateSilence(ctx, r, instance); err != nil {
+		if updateErr := instance.SetUpdateStatusTo(ctx, r, vmv1beta1.UpdateStatusFailed, err); updateErr != nil {
+			return result, fmt.Errorf("failed to update object status: %q, origin err: %w", updateErr, err)
+		}
+		return result, fmt.Errorf("failed create or update vmalertmanagersilence: %w", err)
+	}
+	if err := instance.SetUpdateStatusTo(ctx, r, vmv1beta1.UpdateStatusOperational, nil); err != nil {
+		return result, fmt.Errorf("failed to update vmalertmanagersilence status: %w", err)
+	}
+	// alertmanager replicas could lose silences after restart with empty storage
+	result.RequeueAfter = r.BaseConf.ResyncAfterDuration()
+
+	return
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VMAlertmanagerSilenceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&vmv1beta1.VMAlertmanagerSilence{}).
+		WithOptions(getDefaultOptions()).
+		Complete(r)
+}
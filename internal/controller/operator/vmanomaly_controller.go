@@ -0,0 +1,97 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmanomaly"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VMAnomalyReconciler reconciles a VMAnomaly object
+type VMAnomalyReconciler struct {
+	Client       client.Client
+	Log          logr.Logger
+	OriginScheme *runtime.Scheme
+	BaseConf     *config.BaseOperatorConf
+}
+
+// Init implements crdController interface
+func (r *VMAnomalyReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme, cf *config.BaseOperatorConf) {
+	r.Client = rclient
+	r.Log = l.WithName("controller").WithName("VMAnomaly")
+	r.OriginScheme = sc
+	r.BaseConf = cf
+}
+
+// Scheme implements interface.
+func (r *VMAnomalyReconciler) Scheme() *runtime.Scheme {
+	return r.OriginScheme
+}
+
+// Reconcile general reconcile method for controller
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmanomalies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmanomalies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmanomalies/finalizers,verbs=*
+func (r *VMAnomalyReconciler) Reconcile(ctx context.Context, request ctrl.Request) (result ctrl.Result, err error) {
+	reqLogger := log.WithValues("vmanomaly", request.Name, "namespace", request.Namespace)
+	ctx = logger.AddToContext(ctx, reqLogger)
+	instance := &vmv1beta1.VMAnomaly{}
+
+	defer func() {
+		result, err = handleReconcileErr(ctx, r.Client, instance, result, err)
+	}()
+
+	if err := r.Client.Get(ctx, request.NamespacedName, instance); err != nil {
+		return result, &getError{err, "vmanomaly", request}
+	}
+
+	RegisterObjectStat(instance, "vmanomaly")
+
+	if !instance.DeletionTimestamp.IsZero() {
+		if err := finalize.OnVMAnomalyDelete(ctx, r.Client, instance); err != nil {
+			return result, err
+		}
+		return result, nil
+	}
+	if instance.Spec.ParsingError != "" {
+		return result, &parsingError{instance.Spec.ParsingError, "vmanomaly"}
+	}
+	if err := finalize.AddFinalizer(ctx, r.Client, instance); err != nil {
+		return result, err
+	}
+	r.Client.Scheme().Default(instance)
+
+	result, err = reconcileAndTrackStatus(ctx, r.Client, instance, func() (ctrl.Result, error) {
+		err = vmanomaly.CreateOrUpdateVMAnomaly(ctx, instance, r.Client)
+		if err != nil {
+			return result, fmt.Errorf("failed create or update vmanomaly: %w", err)
+		}
+		return result, nil
+	})
+	if err != nil {
+		return
+	}
+
+	result.RequeueAfter = r.BaseConf.ResyncAfterDuration()
+	return
+}
+
+// SetupWithManager general setup method
+func (r *VMAnomalyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&vmv1beta1.VMAnomaly{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&corev1.ServiceAccount{}).
+		WithOptions(getDefaultOptions()).
+		Complete(r)
+}
@@ -0,0 +1,102 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmbackup"
+)
+
+// VMBackupJobReconciler reconciles a VMBackupJob object
+type VMBackupJobReconciler struct {
+	client.Client
+	Log          logr.Logger
+	OriginScheme *runtime.Scheme
+	BaseConf     *config.BaseOperatorConf
+}
+
+// Init implements crdController interface
+func (r *VMBackupJobReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme, cf *config.BaseOperatorConf) {
+	r.Client = rclient
+	r.Log = l.WithName("controller").WithName("VMBackupJob")
+	r.OriginScheme = sc
+	r.BaseConf = cf
+}
+
+// Scheme implements interface.
+func (r *VMBackupJobReconciler) Scheme() *runtime.Scheme {
+	return r.OriginScheme
+}
+
+// Reconcile general reconcile method for controller
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmbackupjobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmbackupjobs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=*
+// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=*
+func (r *VMBackupJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	reqLogger := r.Log.WithValues("vmbackupjob", req.Name, "namespace", req.Namespace)
+	ctx = logger.AddToContext(ctx, reqLogger)
+	instance := &vmv1beta1.VMBackupJob{}
+
+	defer func() {
+		result, err = handleReconcileErr(ctx, r.Client, nil, result, err)
+	}()
+
+	if err := r.Get(ctx, req.NamespacedName, instance); err != nil {
+		return result, &getError{err, "vmbackupjob", req}
+	}
+
+	RegisterObjectStat(instance, "vmbackupjob")
+	if !instance.DeletionTimestamp.IsZero() {
+		// jobs are removed by garbage collector with owner reference
+		return
+	}
+	r.Client.Scheme().Default(instance)
+
+	if err := vmbackup.CreateOrUpdateVMBackupJob(ctx, r, instance); err != nil {
+		if updateErr := instance.SetUpdateStatusTo(ctx, r, vmv1beta1.UpdateStatusFailed, err); updateErr != nil {
+			return result, fmt.Errorf("failed to update object status: %q, origin err: %w", updateErr, err)
+		}
+		return result, fmt.Errorf("failed create or update vmbackupjob: %w", err)
+	}
+	if instance.IsScheduled() {
+		result.RequeueAfter = r.BaseConf.ResyncAfterDuration()
+	}
+
+	return
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VMBackupJobReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&vmv1beta1.VMBackupJob{}).
+		Owns(&batchv1.Job{}).
+		Owns(&batchv1.CronJob{}).
+		WithOptions(getDefaultOptions()).
+		Complete(r)
+}
@@ -0,0 +1,120 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmblackboxexporter"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// VMBlackboxExporterReconciler reconciles a VMBlackboxExporter object
+type VMBlackboxExporterReconciler struct {
+	Client       client.Client
+	Log          logr.Logger
+	OriginScheme *runtime.Scheme
+	BaseConf     *config.BaseOperatorConf
+}
+
+// Init implements crdController interface
+func (r *VMBlackboxExporterReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme, cf *config.BaseOperatorConf) {
+	r.Client = rclient
+	r.Log = l.WithName("controller").WithName("VMBlackboxExporter")
+	r.OriginScheme = sc
+	r.BaseConf = cf
+}
+
+// Scheme implements interface.
+func (r *VMBlackboxExporterReconciler) Scheme() *runtime.Scheme {
+	return r.OriginScheme
+}
+
+// Reconcile general reconcile method for controller
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmblackboxexporters,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmblackboxexporters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmblackboxexporters/finalizers,verbs=*
+func (r *VMBlackboxExporterReconciler) Reconcile(ctx context.Context, request ctrl.Request) (result ctrl.Result, err error) {
+	reqLogger := log.WithValues("vmblackboxexporter", request.Name, "namespace", request.Namespace)
+	ctx = logger.AddToContext(ctx, reqLogger)
+	instance := &vmv1beta1.VMBlackboxExporter{}
+
+	defer func() {
+		result, err = handleReconcileErr(ctx, r.Client, instance, result, err)
+	}()
+
+	if err := r.Client.Get(ctx, request.NamespacedName, instance); err != nil {
+		return result, &getError{err, "vmblackboxexporter", request}
+	}
+
+	RegisterObjectStat(instance, "vmblackboxexporter")
+
+	if !instance.DeletionTimestamp.IsZero() {
+		if err := finalize.OnVMBlackboxExporterDelete(ctx, r.Client, instance); err != nil {
+			return result, err
+		}
+		return result, nil
+	}
+	if instance.Spec.ParsingError != "" {
+		return result, &parsingError{instance.Spec.ParsingError, "vmblackboxexporter"}
+	}
+	if err := finalize.AddFinalizer(ctx, r.Client, instance); err != nil {
+		return result, err
+	}
+	r.Client.Scheme().Default(instance)
+
+	result, err = reconcileAndTrackStatus(ctx, r.Client, instance, func() (ctrl.Result, error) {
+		err = vmblackboxexporter.CreateOrUpdateVMBlackboxExporter(ctx, instance, r.Client)
+		if err != nil {
+			return result, fmt.Errorf("failed create or update vmblackboxexporter: %w", err)
+		}
+		return result, nil
+	})
+	if err != nil {
+		return
+	}
+
+	result.RequeueAfter = r.BaseConf.ResyncAfterDuration()
+	return
+}
+
+// SetupWithManager general setup method
+func (r *VMBlackboxExporterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&vmv1beta1.VMBlackboxExporter{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&corev1.ServiceAccount{}).
+		Watches(&vmv1beta1.VMProbe{}, handler.EnqueueRequestsFromMapFunc(probeToBlackboxExporter)).
+		WithOptions(getDefaultOptions()).
+		Complete(r)
+}
+
+// probeToBlackboxExporter maps VMProbe to the referenced VMBlackboxExporter,
+// so its status.probes is updated on VMProbe changes.
+// Update events are mapped for both old and new objects, which handles reference changes
+func probeToBlackboxExporter(_ context.Context, obj client.Object) []reconcile.Request {
+	probe, ok := obj.(*vmv1beta1.VMProbe)
+	if !ok {
+		return nil
+	}
+	ref := probe.Spec.VMProberSpec.BlackboxExporterRef
+	if ref == nil {
+		return nil
+	}
+	ns := ref.Namespace
+	if ns == "" {
+		ns = probe.Namespace
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: ns, Name: ref.Name}}}
+}
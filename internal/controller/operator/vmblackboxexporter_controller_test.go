@@ -0,0 +1,115 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+var _ = Describe("VMBlackboxExporter Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-resource"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default", // TODO(user):Modify as needed
+		}
+		vmblackboxexporter := &vmv1beta1.VMBlackboxExporter{}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind VMBlackboxExporter")
+			err := k8sClient.Get(ctx, typeNamespacedName, vmblackboxexporter)
+			if err != nil && errors.IsNotFound(err) {
+				resource := &vmv1beta1.VMBlackboxExporter{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resourceName,
+						Namespace: "default",
+					},
+					// TODO(user): Specify other spec details if needed.
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			// TODO(user): Cleanup logic after each test, like removing the resource instance.
+			resource := &vmv1beta1.VMBlackboxExporter{}
+			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Cleanup the specific resource instance VMBlackboxExporter")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+		It("should successfully reconcile the resource", func() {
+			By("Reconciling the created resource")
+			controllerReconciler := &VMBlackboxExporterReconciler{
+				Client:       k8sClient,
+				OriginScheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
+			// Example: If you expect a certain status condition after reconciliation, verify it here.
+		})
+	})
+})
+
+func Test_probeToBlackboxExporter(t *testing.T) {
+	f := func(probe *vmv1beta1.VMProbe, want []reconcile.Request) {
+		t.Helper()
+		got := probeToBlackboxExporter(context.Background(), probe)
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("unexpected requests, got: %v, want: %v", got, want)
+		}
+	}
+
+	// no reference
+	f(&vmv1beta1.VMProbe{ObjectMeta: metav1.ObjectMeta{Name: "probe", Namespace: "default"}}, nil)
+
+	// reference at the same namespace
+	f(&vmv1beta1.VMProbe{
+		ObjectMeta: metav1.ObjectMeta{Name: "probe", Namespace: "default"},
+		Spec: vmv1beta1.VMProbeSpec{
+			VMProberSpec: vmv1beta1.VMProberSpec{BlackboxExporterRef: &vmv1beta1.VMBlackboxExporterRef{Name: "exporter"}},
+		},
+	}, []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "default", Name: "exporter"}}})
+
+	// reference at the other namespace
+	f(&vmv1beta1.VMProbe{
+		ObjectMeta: metav1.ObjectMeta{Name: "probe", Namespace: "default"},
+		Spec: vmv1beta1.VMProbeSpec{
+			VMProberSpec: vmv1beta1.VMProberSpec{BlackboxExporterRef: &vmv1beta1.VMBlackboxExporterRef{Name: "exporter", Namespace: "monitoring"}},
+		},
+	}, []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "monitoring", Name: "exporter"}}})
+}
@@ -3,6 +3,7 @@ package operator
 import (
 	"context"
 	"fmt"
+	"time"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/config"
@@ -19,6 +20,11 @@ import (
 
 var log = logf.Log.WithName("controller")
 
+const (
+	storageScaleDownRequeueInterval = time.Minute
+	rolloutRequeueInterval          = time.Minute
+)
+
 // VMClusterReconciler reconciles a VMCluster object
 type VMClusterReconciler struct {
 	Client       client.Client
@@ -45,6 +51,7 @@ func (r *VMClusterReconciler) Scheme() *runtime.Scheme {
 // +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmclusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmclusters/finalizers,verbs=*
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=*
+// +kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get;list;watch
 func (r *VMClusterReconciler) Reconcile(ctx context.Context, request ctrl.Request) (result ctrl.Result, err error) {
 	reqLogger := log.WithValues("vmcluster", request.Name, "namespace", request.Namespace)
 	ctx = logger.AddToContext(ctx, reqLogger)
@@ -86,6 +93,14 @@ func (r *VMClusterReconciler) Reconcile(ctx context.Context, request ctrl.Reques
 	}
 
 	result.RequeueAfter = r.BaseConf.ResyncAfterDuration()
+	// vmstorage scale-down phases are time based and must be re-checked periodically
+	if instance.Status.StorageScaleDown != nil && (result.RequeueAfter == 0 || result.RequeueAfter > storageScaleDownRequeueInterval) {
+		result.RequeueAfter = storageScaleDownRequeueInterval
+	}
+	// canary bake time and health checks must be re-checked periodically
+	if instance.Status.Rollout.IsInProgress() && (result.RequeueAfter == 0 || result.RequeueAfter > rolloutRequeueInterval) {
+		result.RequeueAfter = rolloutRequeueInterval
+	}
 	return
 }
 
@@ -66,6 +66,10 @@ type ConverterController struct {
 	amConfigInf     cache.SharedInformer
 	probeInf        cache.SharedIndexInformer
 	scrapeConfigInf cache.SharedIndexInformer
+	promInf         cache.SharedIndexInformer
+	promAgentInf    cache.SharedIndexInformer
+	amInf           cache.SharedIndexInformer
+	thanosRulerInf  cache.SharedIndexInformer
 	baseConf        *config.BaseOperatorConf
 }
 
@@ -232,6 +236,106 @@ func NewConverterController(ctx context.Context, baseClient *kubernetes.Clientse
 	}); err != nil {
 		return nil, fmt.Errorf("cannot add scrapeConfig handler: %w", err)
 	}
+	c.promInf = cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				var objects promv1.PrometheusList
+				if err := k8stools.ListObjectsByNamespace(ctx, rclient, config.MustGetWatchNamespaces(), func(dst *promv1.PrometheusList) {
+					objects.Items = append(objects.Items, dst.Items...)
+				}); err != nil {
+					return nil, fmt.Errorf("cannot list prometheuses: %w", err)
+				}
+				return &objects, nil
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return k8stools.NewObjectWatcherForNamespaces[promv1.PrometheusList](ctx, rclient, "prometheuses", config.MustGetWatchNamespaces())
+			},
+		},
+		&promv1.Prometheus{},
+		resyncPeriod,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+	)
+	if _, err := c.promInf.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.CreatePrometheus,
+		UpdateFunc: c.UpdatePrometheus,
+	}); err != nil {
+		return nil, fmt.Errorf("cannot add prometheus handler: %w", err)
+	}
+	c.promAgentInf = cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				var objects promv1alpha1.PrometheusAgentList
+				if err := k8stools.ListObjectsByNamespace(ctx, rclient, config.MustGetWatchNamespaces(), func(dst *promv1alpha1.PrometheusAgentList) {
+					objects.Items = append(objects.Items, dst.Items...)
+				}); err != nil {
+					return nil, fmt.Errorf("cannot list prometheus_agents: %w", err)
+				}
+				return &objects, nil
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return k8stools.NewObjectWatcherForNamespaces[promv1alpha1.PrometheusAgentList](ctx, rclient, "prometheus_agents", config.MustGetWatchNamespaces())
+			},
+		},
+		&promv1alpha1.PrometheusAgent{},
+		resyncPeriod,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+	)
+	if _, err := c.promAgentInf.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.CreatePrometheusAgent,
+		UpdateFunc: c.UpdatePrometheusAgent,
+	}); err != nil {
+		return nil, fmt.Errorf("cannot add prometheus_agent handler: %w", err)
+	}
+	c.amInf = cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				var objects promv1.AlertmanagerList
+				if err := k8stools.ListObjectsByNamespace(ctx, rclient, config.MustGetWatchNamespaces(), func(dst *promv1.AlertmanagerList) {
+					objects.Items = append(objects.Items, dst.Items...)
+				}); err != nil {
+					return nil, fmt.Errorf("cannot list alertmanagers: %w", err)
+				}
+				return &objects, nil
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return k8stools.NewObjectWatcherForNamespaces[promv1.AlertmanagerList](ctx, rclient, "alertmanagers", config.MustGetWatchNamespaces())
+			},
+		},
+		&promv1.Alertmanager{},
+		resyncPeriod,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+	)
+	if _, err := c.amInf.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.CreateAlertmanager,
+		UpdateFunc: c.UpdateAlertmanager,
+	}); err != nil {
+		return nil, fmt.Errorf("cannot add alertmanager handler: %w", err)
+	}
+	c.thanosRulerInf = cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				var objects promv1.ThanosRulerList
+				if err := k8stools.ListObjectsByNamespace(ctx, rclient, config.MustGetWatchNamespaces(), func(dst *promv1.ThanosRulerList) {
+					objects.Items = append(objects.Items, dst.Items...)
+				}); err != nil {
+					return nil, fmt.Errorf("cannot list thanos_rulers: %w", err)
+				}
+				return &objects, nil
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return k8stools.NewObjectWatcherForNamespaces[promv1.ThanosRulerList](ctx, rclient, "thanos_rulers", config.MustGetWatchNamespaces())
+			},
+		},
+		&promv1.ThanosRuler{},
+		resyncPeriod,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+	)
+	if _, err := c.thanosRulerInf.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.CreateThanosRuler,
+		UpdateFunc: c.UpdateThanosRuler,
+	}); err != nil {
+		return nil, fmt.Errorf("cannot add thanos_ruler handler: %w", err)
+	}
 	return c, nil
 }
 
@@ -317,6 +421,26 @@ func (c *ConverterController) Run(ctx context.Context, group *errgroup.Group) {
 			return c.runInformerWithDiscovery(ctx, promv1alpha1.SchemeGroupVersion.String(), promv1alpha1.ScrapeConfigsKind, c.scrapeConfigInf.Run)
 		})
 	}
+	if c.baseConf.EnabledPrometheusConverter.Prometheus {
+		group.Go(func() error {
+			return c.runInformerWithDiscovery(ctx, promv1.SchemeGroupVersion.String(), promv1.PrometheusesKind, c.promInf.Run)
+		})
+	}
+	if c.baseConf.EnabledPrometheusConverter.PrometheusAgent {
+		group.Go(func() error {
+			return c.runInformerWithDiscovery(ctx, promv1alpha1.SchemeGroupVersion.String(), promv1alpha1.PrometheusAgentsKind, c.promAgentInf.Run)
+		})
+	}
+	if c.baseConf.EnabledPrometheusConverter.Alertmanager {
+		group.Go(func() error {
+			return c.runInformerWithDiscovery(ctx, promv1.SchemeGroupVersion.String(), promv1.AlertmanagersKind, c.amInf.Run)
+		})
+	}
+	if c.baseConf.EnabledPrometheusConverter.ThanosRuler {
+		group.Go(func() error {
+			return c.runInformerWithDiscovery(ctx, promv1.SchemeGroupVersion.String(), promv1.ThanosRulerKind, c.thanosRulerInf.Run)
+		})
+	}
 }
 
 // CreatePrometheusRule converts prometheus rule to vmrule
@@ -738,6 +862,250 @@ func (c *ConverterController) UpdateScrapeConfig(_, newObj interface{}) {
 	}
 }
 
+// CreatePrometheus converts Prometheus to VMAgent and VMSingle
+func (c *ConverterController) CreatePrometheus(obj interface{}) {
+	prom := obj.(*promv1.Prometheus)
+	vmAgent, vmSingle := converter.ConvertPrometheus(prom, c.baseConf)
+	c.createVMAgent(vmAgent)
+	c.createVMSingle(vmSingle)
+}
+
+// UpdatePrometheus updates VMAgent and VMSingle
+func (c *ConverterController) UpdatePrometheus(_, new interface{}) {
+	prom := new.(*promv1.Prometheus)
+	vmAgent, vmSingle := converter.ConvertPrometheus(prom, c.baseConf)
+	c.updateVMAgent(vmAgent)
+	c.updateVMSingle(vmSingle)
+}
+
+// CreatePrometheusAgent converts PrometheusAgent to VMAgent
+func (c *ConverterController) CreatePrometheusAgent(obj interface{}) {
+	promAgent := obj.(*promv1alpha1.PrometheusAgent)
+	c.createVMAgent(converterv1alpha1.ConvertPrometheusAgent(promAgent, c.baseConf))
+}
+
+// UpdatePrometheusAgent updates VMAgent
+func (c *ConverterController) UpdatePrometheusAgent(_, new interface{}) {
+	promAgent := new.(*promv1alpha1.PrometheusAgent)
+	c.updateVMAgent(converterv1alpha1.ConvertPrometheusAgent(promAgent, c.baseConf))
+}
+
+func (c *ConverterController) createVMAgent(vmAgent *vmv1beta1.VMAgent) {
+	l := log.WithValues("kind", "vmAgent", "name", vmAgent.Name, "ns", vmAgent.Namespace)
+	err := c.rclient.Create(c.ctx, vmAgent)
+	if err != nil {
+		if errors.IsAlreadyExists(err) {
+			c.updateVMAgent(vmAgent)
+			return
+		}
+		l.Error(err, "cannot create vmAgent")
+		return
+	}
+}
+
+func (c *ConverterController) updateVMAgent(vmAgent *vmv1beta1.VMAgent) {
+	l := log.WithValues("kind", "vmAgent", "name", vmAgent.Name, "ns", vmAgent.Namespace)
+	ctx := context.Background()
+	existingVMAgent := &vmv1beta1.VMAgent{}
+	err := c.rclient.Get(ctx, types.NamespacedName{Name: vmAgent.Name, Namespace: vmAgent.Namespace}, existingVMAgent)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			if err = c.rclient.Create(ctx, vmAgent); err == nil {
+				return
+			}
+		}
+		l.Error(err, "cannot get existing vmAgent")
+		return
+	}
+	if existingVMAgent.Annotations[IgnoreConversionLabel] == IgnoreConversion {
+		l.Info("syncing for object was disabled by annotation", "annotation", IgnoreConversionLabel)
+		return
+	}
+
+	mergeStrategy := getMetaMergeStrategy(existingVMAgent.Annotations)
+	vmAgent.Annotations = mergeLabelsWithStrategy(existingVMAgent.Annotations, vmAgent.Annotations, mergeStrategy)
+	vmAgent.Labels = mergeLabelsWithStrategy(existingVMAgent.Labels, vmAgent.Labels, mergeStrategy)
+	if equality.Semantic.DeepEqual(vmAgent.Spec, existingVMAgent.Spec) &&
+		isMetaEqual(vmAgent, existingVMAgent) {
+		return
+	}
+
+	existingVMAgent.Labels = vmAgent.Labels
+	existingVMAgent.Annotations = vmAgent.Annotations
+	existingVMAgent.OwnerReferences = vmAgent.OwnerReferences
+	existingVMAgent.Spec = vmAgent.Spec
+	err = c.rclient.Update(ctx, existingVMAgent)
+	if err != nil {
+		l.Error(err, "cannot update vmAgent")
+		return
+	}
+}
+
+func (c *ConverterController) createVMSingle(vmSingle *vmv1beta1.VMSingle) {
+	l := log.WithValues("kind", "vmSingle", "name", vmSingle.Name, "ns", vmSingle.Namespace)
+	err := c.rclient.Create(c.ctx, vmSingle)
+	if err != nil {
+		if errors.IsAlreadyExists(err) {
+			c.updateVMSingle(vmSingle)
+			return
+		}
+		l.Error(err, "cannot create vmSingle")
+		return
+	}
+}
+
+func (c *ConverterController) updateVMSingle(vmSingle *vmv1beta1.VMSingle) {
+	l := log.WithValues("kind", "vmSingle", "name", vmSingle.Name, "ns", vmSingle.Namespace)
+	ctx := context.Background()
+	existingVMSingle := &vmv1beta1.VMSingle{}
+	err := c.rclient.Get(ctx, types.NamespacedName{Name: vmSingle.Name, Namespace: vmSingle.Namespace}, existingVMSingle)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			if err = c.rclient.Create(ctx, vmSingle); err == nil {
+				return
+			}
+		}
+		l.Error(err, "cannot get existing vmSingle")
+		return
+	}
+	if existingVMSingle.Annotations[IgnoreConversionLabel] == IgnoreConversion {
+		l.Info("syncing for object was disabled by annotation", "annotation", IgnoreConversionLabel)
+		return
+	}
+
+	mergeStrategy := getMetaMergeStrategy(existingVMSingle.Annotations)
+	vmSingle.Annotations = mergeLabelsWithStrategy(existingVMSingle.Annotations, vmSingle.Annotations, mergeStrategy)
+	vmSingle.Labels = mergeLabelsWithStrategy(existingVMSingle.Labels, vmSingle.Labels, mergeStrategy)
+	if equality.Semantic.DeepEqual(vmSingle.Spec, existingVMSingle.Spec) &&
+		isMetaEqual(vmSingle, existingVMSingle) {
+		return
+	}
+
+	existingVMSingle.Labels = vmSingle.Labels
+	existingVMSingle.Annotations = vmSingle.Annotations
+	existingVMSingle.OwnerReferences = vmSingle.OwnerReferences
+	existingVMSingle.Spec = vmSingle.Spec
+	err = c.rclient.Update(ctx, existingVMSingle)
+	if err != nil {
+		l.Error(err, "cannot update vmSingle")
+		return
+	}
+}
+
+// CreateAlertmanager converts Alertmanager to VMAlertmanager
+func (c *ConverterController) CreateAlertmanager(obj interface{}) {
+	am := obj.(*promv1.Alertmanager)
+	l := log.WithValues("kind", "vmAlertmanager", "name", am.Name, "ns", am.Namespace)
+	vmAlertmanager := converter.ConvertAlertmanager(am, c.baseConf)
+	err := c.rclient.Create(c.ctx, vmAlertmanager)
+	if err != nil {
+		if errors.IsAlreadyExists(err) {
+			c.UpdateAlertmanager(nil, am)
+			return
+		}
+		l.Error(err, "cannot create vmAlertmanager")
+		return
+	}
+}
+
+// UpdateAlertmanager updates VMAlertmanager
+func (c *ConverterController) UpdateAlertmanager(_, new interface{}) {
+	am := new.(*promv1.Alertmanager)
+	l := log.WithValues("kind", "vmAlertmanager", "name", am.Name, "ns", am.Namespace)
+	vmAlertmanager := converter.ConvertAlertmanager(am, c.baseConf)
+	ctx := context.Background()
+	existingVMAlertmanager := &vmv1beta1.VMAlertmanager{}
+	err := c.rclient.Get(ctx, types.NamespacedName{Name: vmAlertmanager.Name, Namespace: vmAlertmanager.Namespace}, existingVMAlertmanager)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			if err = c.rclient.Create(ctx, vmAlertmanager); err == nil {
+				return
+			}
+		}
+		l.Error(err, "cannot get existing vmAlertmanager")
+		return
+	}
+	if existingVMAlertmanager.Annotations[IgnoreConversionLabel] == IgnoreConversion {
+		l.Info("syncing for object was disabled by annotation", "annotation", IgnoreConversionLabel)
+		return
+	}
+
+	mergeStrategy := getMetaMergeStrategy(existingVMAlertmanager.Annotations)
+	vmAlertmanager.Annotations = mergeLabelsWithStrategy(existingVMAlertmanager.Annotations, vmAlertmanager.Annotations, mergeStrategy)
+	vmAlertmanager.Labels = mergeLabelsWithStrategy(existingVMAlertmanager.Labels, vmAlertmanager.Labels, mergeStrategy)
+	if equality.Semantic.DeepEqual(vmAlertmanager.Spec, existingVMAlertmanager.Spec) &&
+		isMetaEqual(vmAlertmanager, existingVMAlertmanager) {
+		return
+	}
+
+	existingVMAlertmanager.Labels = vmAlertmanager.Labels
+	existingVMAlertmanager.Annotations = vmAlertmanager.Annotations
+	existingVMAlertmanager.OwnerReferences = vmAlertmanager.OwnerReferences
+	existingVMAlertmanager.Spec = vmAlertmanager.Spec
+	err = c.rclient.Update(ctx, existingVMAlertmanager)
+	if err != nil {
+		l.Error(err, "cannot update vmAlertmanager")
+		return
+	}
+}
+
+// CreateThanosRuler converts ThanosRuler to VMAlert
+func (c *ConverterController) CreateThanosRuler(obj interface{}) {
+	tr := obj.(*promv1.ThanosRuler)
+	l := log.WithValues("kind", "vmAlert", "name", tr.Name, "ns", tr.Namespace)
+	vmAlert := converter.ConvertThanosRuler(tr, c.baseConf)
+	err := c.rclient.Create(c.ctx, vmAlert)
+	if err != nil {
+		if errors.IsAlreadyExists(err) {
+			c.UpdateThanosRuler(nil, tr)
+			return
+		}
+		l.Error(err, "cannot create vmAlert")
+		return
+	}
+}
+
+// UpdateThanosRuler updates VMAlert
+func (c *ConverterController) UpdateThanosRuler(_, new interface{}) {
+	tr := new.(*promv1.ThanosRuler)
+	l := log.WithValues("kind", "vmAlert", "name", tr.Name, "ns", tr.Namespace)
+	vmAlert := converter.ConvertThanosRuler(tr, c.baseConf)
+	ctx := context.Background()
+	existingVMAlert := &vmv1beta1.VMAlert{}
+	err := c.rclient.Get(ctx, types.NamespacedName{Name: vmAlert.Name, Namespace: vmAlert.Namespace}, existingVMAlert)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			if err = c.rclient.Create(ctx, vmAlert); err == nil {
+				return
+			}
+		}
+		l.Error(err, "cannot get existing vmAlert")
+		return
+	}
+	if existingVMAlert.Annotations[IgnoreConversionLabel] == IgnoreConversion {
+		l.Info("syncing for object was disabled by annotation", "annotation", IgnoreConversionLabel)
+		return
+	}
+
+	mergeStrategy := getMetaMergeStrategy(existingVMAlert.Annotations)
+	vmAlert.Annotations = mergeLabelsWithStrategy(existingVMAlert.Annotations, vmAlert.Annotations, mergeStrategy)
+	vmAlert.Labels = mergeLabelsWithStrategy(existingVMAlert.Labels, vmAlert.Labels, mergeStrategy)
+	if equality.Semantic.DeepEqual(vmAlert.Spec, existingVMAlert.Spec) &&
+		isMetaEqual(vmAlert, existingVMAlert) {
+		return
+	}
+
+	existingVMAlert.Labels = vmAlert.Labels
+	existingVMAlert.Annotations = vmAlert.Annotations
+	existingVMAlert.OwnerReferences = vmAlert.OwnerReferences
+	existingVMAlert.Spec = vmAlert.Spec
+	err = c.rclient.Update(ctx, existingVMAlert)
+	if err != nil {
+		l.Error(err, "cannot update vmAlert")
+		return
+	}
+}
+
 func isMetaEqual(left, right metav1.Object) bool {
 	return equality.Semantic.DeepEqual(left.GetLabels(), right.GetLabels()) &&
 		equality.Semantic.DeepEqual(left.GetAnnotations(), right.GetAnnotations()) &&
@@ -0,0 +1,102 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmauth"
+)
+
+// VMTenantReconciler reconciles a VMTenant object
+type VMTenantReconciler struct {
+	client.Client
+	Log          logr.Logger
+	OriginScheme *runtime.Scheme
+	BaseConf     *config.BaseOperatorConf
+}
+
+// Init implements crdController interface
+func (r *VMTenantReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme, cf *config.BaseOperatorConf) {
+	r.Client = rclient
+	r.Log = l.WithName("controller").WithName("VMTenant")
+	r.OriginScheme = sc
+	r.BaseConf = cf
+}
+
+// Scheme implements interface.
+func (r *VMTenantReconciler) Scheme() *runtime.Scheme {
+	return r.OriginScheme
+}
+
+// Reconcile general reconcile method for controller
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmtenants,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmtenants/status,verbs=get;update;patch
+func (r *VMTenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	reqLogger := r.Log.WithValues("vmtenant", req.Name, "namespace", req.Namespace)
+	ctx = logger.AddToContext(ctx, reqLogger)
+	instance := &vmv1beta1.VMTenant{}
+
+	defer func() {
+		result, err = handleReconcileErr(ctx, r.Client, nil, result, err)
+	}()
+
+	if err := r.Get(ctx, req.NamespacedName, instance); err != nil {
+		return result, &getError{err, "vmtenant", req}
+	}
+
+	RegisterObjectStat(instance, "vmtenant")
+	if !instance.DeletionTimestamp.IsZero() {
+		// generated users and secrets are removed by garbage collector with owner reference
+		return
+	}
+
+	if err := vmauth.CreateOrUpdateVMTenant(ctx, r, instance); err != nil {
+		if updateErr := instance.SetUpdateStatusTo(ctx, r, vmv1beta1.UpdateStatusFailed, err); updateErr != nil {
+			return result, fmt.Errorf("failed to update object status: %q, origin err: %w", updateErr, err)
+		}
+		return result, fmt.Errorf("failed create or update vmtenant: %w", err)
+	}
+	if err := instance.SetUpdateStatusTo(ctx, r, vmv1beta1.UpdateStatusOperational, nil); err != nil {
+		return result, fmt.Errorf("failed to update vmtenant status: %w", err)
+	}
+	// cluster urls could be changed without tenant update
+	result.RequeueAfter = r.BaseConf.ResyncAfterDuration()
+
+	return
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VMTenantReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&vmv1beta1.VMTenant{}).
+		Owns(&vmv1beta1.VMUser{}).
+		Owns(&corev1.Secret{}, builder.OnlyMetadata).
+		WithOptions(getDefaultOptions()).
+		Complete(r)
+}
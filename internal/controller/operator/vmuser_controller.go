@@ -19,6 +19,7 @@ package operator
 import (
 	"context"
 	"fmt"
+	"time"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/config"
@@ -81,6 +82,12 @@ func (r *VMUserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 		if err := finalize.AddFinalizer(ctx, r.Client, &instance); err != nil {
 			return result, err
 		}
+		// password rotation is performed during vmauth config reconcile
+		// requeue user at the next rotation deadline in order to apply it in time
+		result.RequeueAfter, err = vmauth.PasswordRotationRequeueAfter(ctx, r.Client, &instance, time.Now())
+		if err != nil {
+			return result, err
+		}
 	}
 
 	if vmauthRateLimiter.MustThrottleReconcile() {
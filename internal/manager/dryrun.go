@@ -0,0 +1,148 @@
+package manager
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	vmcontroller "github.com/VictoriaMetrics/operator/internal/controller/operator"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+// DryRunCommand is the name of operator subcommand, which renders changes of child objects without applying
+const DryRunCommand = "dry-run"
+
+var (
+	dryRunFlags     = flag.NewFlagSet(DryRunCommand, flag.ExitOnError)
+	dryRunKinds     = dryRunFlags.String("kind", "", "Comma-separated list of object kinds to reconcile. By default, all supported kinds are reconciled: "+strings.Join(vmcontroller.DryRunKinds(), ","))
+	dryRunNamespace = dryRunFlags.String("namespace", "", "Namespace of objects to reconcile. By default, objects from all namespaces are reconciled")
+	dryRunName      = dryRunFlags.String("name", "", "Name of object to reconcile. Requires -namespace and single -kind")
+	dryRunOutput    = dryRunFlags.String("output", "diff", "Output format. Can be diff or summary")
+	dryRunVerbose   = dryRunFlags.Bool("verbose", false, "Write operator logs to stderr")
+)
+
+// RunDryRun executes object reconcile functions against live objects without applying changes
+// and writes diff of child objects into stdout
+func RunDryRun(ctx context.Context, args []string) error {
+	dryRunFlags.Parse(args)
+	if *dryRunOutput != "diff" && *dryRunOutput != "summary" {
+		return fmt.Errorf("unsupported -output=%q, supported values: diff, summary", *dryRunOutput)
+	}
+	kinds := vmcontroller.DryRunKinds()
+	if len(*dryRunKinds) > 0 {
+		kinds = strings.Split(*dryRunKinds, ",")
+	}
+	if len(*dryRunName) > 0 && (len(kinds) != 1 || len(*dryRunNamespace) == 0) {
+		return fmt.Errorf("-name requires -namespace and single -kind")
+	}
+	if *dryRunVerbose {
+		l := logger.New(zap.New(zap.WriteTo(os.Stderr)).GetSink())
+		logf.SetLogger(l)
+		ctrl.SetLogger(l)
+	}
+
+	baseConfig := config.MustGetBaseConfig()
+	vmv1beta1.SetLabelAndAnnotationPrefixes(baseConfig.FilterChildLabelPrefixes, baseConfig.FilterChildAnnotationPrefixes)
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return fmt.Errorf("cannot get kubernetes client config: %w", err)
+	}
+	baseClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("cannot build kubernetes client: %w", err)
+	}
+	k8sServerVersion, err := baseClient.ServerVersion()
+	if err != nil {
+		return fmt.Errorf("cannot get kubernetes server version: %w", err)
+	}
+	if err := k8stools.SetKubernetesVersionWithDefaults(k8sServerVersion, *defaultKubernetesMinorVersion, *defaultKubernetesMajorVersion); err != nil {
+		return fmt.Errorf("cannot parse kubernetes version: %w", err)
+	}
+	rclient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("cannot build client: %w", err)
+	}
+
+	drc := k8stools.NewDryRunClient(rclient)
+	for _, kind := range kinds {
+		objects, err := getDryRunObjects(ctx, rclient, kind)
+		if err != nil {
+			return err
+		}
+		for _, obj := range objects {
+			if err := vmcontroller.ReconcileDryRun(ctx, drc, kind, obj); err != nil {
+				return err
+			}
+		}
+	}
+	changes, err := drc.Changes(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot collect changes: %w", err)
+	}
+	return writeDryRunChanges(os.Stdout, changes, *dryRunOutput == "diff")
+}
+
+func getDryRunObjects(ctx context.Context, rclient client.Client, kind string) ([]client.Object, error) {
+	if len(*dryRunName) > 0 {
+		obj, err := vmcontroller.NewDryRunObject(kind)
+		if err != nil {
+			return nil, err
+		}
+		if err := rclient.Get(ctx, client.ObjectKey{Namespace: *dryRunNamespace, Name: *dryRunName}, obj); err != nil {
+			return nil, fmt.Errorf("cannot get %s=%s/%s: %w", kind, *dryRunNamespace, *dryRunName, err)
+		}
+		return []client.Object{obj}, nil
+	}
+	list, err := vmcontroller.NewDryRunList(kind)
+	if err != nil {
+		return nil, err
+	}
+	if err := rclient.List(ctx, list, client.InNamespace(*dryRunNamespace)); err != nil {
+		return nil, fmt.Errorf("cannot list %s objects: %w", kind, err)
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]client.Object, 0, len(items))
+	for _, item := range items {
+		obj := item.(client.Object)
+		// objects under deletion and paused objects are not reconciled by operator
+		if !obj.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if p, ok := obj.(interface{ Paused() bool }); ok && p.Paused() {
+			continue
+		}
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+func writeDryRunChanges(w io.Writer, changes []k8stools.ObjectChange, withDiff bool) error {
+	for _, change := range changes {
+		fmt.Fprintf(w, "# %s\n", change.String())
+		if !withDiff {
+			continue
+		}
+		diff, err := change.Diff()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, diff)
+	}
+	fmt.Fprintf(w, "# total changes: %d\n", len(changes))
+	return nil
+}
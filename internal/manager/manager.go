@@ -253,6 +253,7 @@ func RunManager(ctx context.Context) error {
 	}
 
 	if *enableWebhooks {
+		vmv1beta1.SetRuleLintPolicy(ruleLintPolicyFromConfig(baseConfig))
 		if err = addWebhooks(mgr); err != nil {
 			l.Error(err, "cannot register webhooks")
 			return err
@@ -309,6 +310,24 @@ func RunManager(ctx context.Context) error {
 	return nil
 }
 
+// ruleLintPolicyFromConfig converts operator config into VMRule lint policy
+// config values must be already validated
+func ruleLintPolicyFromConfig(cfg *config.BaseOperatorConf) vmv1beta1.RuleLintPolicy {
+	policy := vmv1beta1.RuleLintPolicy{
+		Mode:                   vmv1beta1.RuleLintMode(cfg.RuleLint.Mode),
+		NamespaceModes:         make(map[string]vmv1beta1.RuleLintMode, len(cfg.RuleLint.NamespaceModes)),
+		EnforcedNamespaceLabel: cfg.RuleLint.EnforcedNamespaceLabel,
+		MaxLookbehindWindow:    cfg.RuleLint.MaxLookbehindWindow,
+	}
+	for ns, mode := range cfg.RuleLint.NamespaceModes {
+		policy.NamespaceModes[ns] = vmv1beta1.RuleLintMode(mode)
+	}
+	if cfg.RuleLint.RecordNameRegex != "" {
+		policy.RecordNameRegex = regexp.MustCompile(cfg.RuleLint.RecordNameRegex)
+	}
+	return policy
+}
+
 func addWebhooks(mgr ctrl.Manager) error {
 	f := func(objs []client.Object) error {
 		var err error
@@ -330,6 +349,11 @@ func addWebhooks(mgr ctrl.Manager) error {
 		&vmv1beta1.VMAuth{},
 		&vmv1beta1.VMUser{},
 		&vmv1beta1.VMRule{},
+		&vmv1beta1.VMAlertmanagerSilence{},
+		&vmv1beta1.VMRuleTest{},
+		&vmv1beta1.VMBlackboxExporter{},
+		&vmv1beta1.VMBackupJob{},
+		&vmv1beta1.VMRestoreJob{},
 	})
 }
 
@@ -452,22 +476,30 @@ type crdController interface {
 }
 
 var controllersByName = map[string]crdController{
-	"VMCluster":            &vmcontroller.VMClusterReconciler{},
-	"VMAgent":              &vmcontroller.VMAgentReconciler{},
-	"VMAuth":               &vmcontroller.VMAuthReconciler{},
-	"VMSingle":             &vmcontroller.VMSingleReconciler{},
-	"VLogs":                &vmcontroller.VLogsReconciler{},
-	"VMAlertmanager":       &vmcontroller.VMAlertmanagerReconciler{},
-	"VMAlert":              &vmcontroller.VMAlertReconciler{},
-	"VMUser":               &vmcontroller.VMUserReconciler{},
-	"VMRule":               &vmcontroller.VMRuleReconciler{},
-	"VMAlertmanagerConfig": &vmcontroller.VMAlertmanagerConfigReconciler{},
-	"VMServiceScrape":      &vmcontroller.VMServiceScrapeReconciler{},
-	"VMPodScrape":          &vmcontroller.VMPodScrapeReconciler{},
-	"VMProbe":              &vmcontroller.VMProbeReconciler{},
-	"VMNodeScrape":         &vmcontroller.VMNodeScrapeReconciler{},
-	"VMStaticScrape":       &vmcontroller.VMStaticScrapeReconciler{},
-	"VMScrapeConfig":       &vmcontroller.VMScrapeConfigReconciler{},
+	"VMCluster":             &vmcontroller.VMClusterReconciler{},
+	"VMAgent":               &vmcontroller.VMAgentReconciler{},
+	"VMAuth":                &vmcontroller.VMAuthReconciler{},
+	"VMSingle":              &vmcontroller.VMSingleReconciler{},
+	"VLogs":                 &vmcontroller.VLogsReconciler{},
+	"VMAlertmanager":        &vmcontroller.VMAlertmanagerReconciler{},
+	"VMAlert":               &vmcontroller.VMAlertReconciler{},
+	"VMUser":                &vmcontroller.VMUserReconciler{},
+	"VMRule":                &vmcontroller.VMRuleReconciler{},
+	"VMAlertmanagerConfig":  &vmcontroller.VMAlertmanagerConfigReconciler{},
+	"VMServiceScrape":       &vmcontroller.VMServiceScrapeReconciler{},
+	"VMPodScrape":           &vmcontroller.VMPodScrapeReconciler{},
+	"VMProbe":               &vmcontroller.VMProbeReconciler{},
+	"VMNodeScrape":          &vmcontroller.VMNodeScrapeReconciler{},
+	"VMStaticScrape":        &vmcontroller.VMStaticScrapeReconciler{},
+	"VMScrapeConfig":        &vmcontroller.VMScrapeConfigReconciler{},
+	"VMBackupJob":           &vmcontroller.VMBackupJobReconciler{},
+	"VMRestoreJob":          &vmcontroller.VMRestoreJobReconciler{},
+	"VLCluster":             &vmcontroller.VLClusterReconciler{},
+	"VMAnomaly":             &vmcontroller.VMAnomalyReconciler{},
+	"VMTenant":              &vmcontroller.VMTenantReconciler{},
+	"VMAlertmanagerSilence": &vmcontroller.VMAlertmanagerSilenceReconciler{},
+	"VMRuleTest":            &vmcontroller.VMRuleTestReconciler{},
+	"VMBlackboxExporter":    &vmcontroller.VMBlackboxExporterReconciler{},
 }
 
 func initControllers(mgr ctrl.Manager, l logr.Logger, bs *config.BaseOperatorConf) error {
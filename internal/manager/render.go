@@ -0,0 +1,267 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	vmcontroller "github.com/VictoriaMetrics/operator/internal/controller/operator"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+// RenderCommand is the name of operator subcommand, which renders child objects from files without cluster access
+const RenderCommand = "render"
+
+type fileFlags []string
+
+// String implements flag.Value interface
+func (ff *fileFlags) String() string {
+	return strings.Join(*ff, ",")
+}
+
+// Set implements flag.Value interface
+func (ff *fileFlags) Set(value string) error {
+	*ff = append(*ff, value)
+	return nil
+}
+
+var (
+	renderFlags              = flag.NewFlagSet(RenderCommand, flag.ExitOnError)
+	renderFiles              fileFlags
+	renderNamespace          = renderFlags.String("namespace", "default", "Namespace for objects without namespace")
+	renderKubernetesMinor    = renderFlags.Uint64("kubernetesVersion.minor", 30, "Minor version of kubernetes server used for rendering")
+	renderKubernetesMajor    = renderFlags.Uint64("kubernetesVersion.major", 1, "Major version of kubernetes server used for rendering")
+	renderIncludeInputObject = renderFlags.Bool("includeInputObjects", false, "Whether to print objects from input files, if they were changed by the operator")
+	renderVerbose            = renderFlags.Bool("verbose", false, "Write operator logs to stderr")
+)
+
+func init() {
+	renderFlags.Var(&renderFiles, "f", "Path to the file or directory with objects manifests. Use - for stdin. Could be specified multiple times. "+
+		"Directories are read recursively, only files with .yaml, .yml and .json extensions are used")
+}
+
+// RunRender reads objects from files and writes child objects created by the operator into stdout
+// It requires no access to kubernetes cluster, all referenced objects, like Secrets, ConfigMaps and scrape objects must be provided with files
+func RunRender(ctx context.Context, args []string) error {
+	renderFlags.Parse(args)
+	if len(renderFiles) == 0 {
+		return fmt.Errorf("at least one -f flag must be provided")
+	}
+	if *renderVerbose {
+		l := logger.New(zap.New(zap.WriteTo(os.Stderr)).GetSink())
+		logf.SetLogger(l)
+		ctrl.SetLogger(l)
+	}
+	baseConfig := config.MustGetBaseConfig()
+	vmv1beta1.SetLabelAndAnnotationPrefixes(baseConfig.FilterChildLabelPrefixes, baseConfig.FilterChildAnnotationPrefixes)
+	k8stools.ServerMajorVersion = *renderKubernetesMajor
+	k8stools.ServerMinorVersion = *renderKubernetesMinor
+
+	objects, err := readRenderObjects(renderFiles, *renderNamespace)
+	if err != nil {
+		return err
+	}
+	return renderObjects(ctx, os.Stdout, objects, *renderIncludeInputObject)
+}
+
+func renderObjects(ctx context.Context, w io.Writer, objects []client.Object, includeInputObjects bool) error {
+	kinds := vmcontroller.DryRunKinds()
+	var roots []client.Object
+	inputs := make(map[string]struct{})
+	namespaces := make(map[string]struct{})
+	for _, obj := range objects {
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil {
+			return err
+		}
+		inputs[gvk.Kind+"/"+client.ObjectKeyFromObject(obj).String()] = struct{}{}
+		if gvk.Kind == "Namespace" {
+			namespaces[obj.GetName()] = struct{}{}
+		}
+		if gvk.Group == vmv1beta1.GroupVersion.Group && slices.Contains(kinds, gvk.Kind) {
+			roots = append(roots, obj)
+		}
+	}
+	if len(roots) == 0 {
+		return fmt.Errorf("input files must contain at least one of objects: %s", strings.Join(kinds, ","))
+	}
+	// namespaces are required for objects selection with namespace selectors
+	initObjects := slices.Clone(objects)
+	for _, obj := range objects {
+		ns := obj.GetNamespace()
+		if _, ok := namespaces[ns]; ok || len(ns) == 0 {
+			continue
+		}
+		namespaces[ns] = struct{}{}
+		initObjects = append(initObjects, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}})
+	}
+
+	origin := fake.NewClientBuilder().WithScheme(scheme).WithObjects(initObjects...).Build()
+	drc := k8stools.NewDryRunClient(origin)
+	for _, root := range roots {
+		gvk, err := apiutil.GVKForObject(root, scheme)
+		if err != nil {
+			return err
+		}
+		obj, err := vmcontroller.NewDryRunObject(gvk.Kind)
+		if err != nil {
+			return err
+		}
+		if err := drc.Get(ctx, client.ObjectKeyFromObject(root), obj); err != nil {
+			return fmt.Errorf("cannot get %s=%s: %w", gvk.Kind, client.ObjectKeyFromObject(root), err)
+		}
+		if err := vmcontroller.ReconcileDryRun(ctx, drc, gvk.Kind, obj); err != nil {
+			return err
+		}
+	}
+	changes, err := drc.Changes(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot collect rendered objects: %w", err)
+	}
+	for _, change := range changes {
+		if change.After == nil {
+			continue
+		}
+		if _, ok := inputs[change.GVK.Kind+"/"+change.Key.String()]; ok && !includeInputObjects {
+			continue
+		}
+		data, err := k8stools.RenderObject(change.GVK, change.After)
+		if err != nil {
+			return fmt.Errorf("cannot render %s: %w", change.String(), err)
+		}
+		fmt.Fprintf(w, "---\n%s", data)
+	}
+	return nil
+}
+
+// readRenderObjects reads objects from given files and directories
+func readRenderObjects(paths []string, defaultNamespace string) ([]client.Object, error) {
+	var objects []client.Object
+	for _, path := range paths {
+		if path == "-" {
+			objs, err := decodeRenderObjects(os.Stdin, defaultNamespace)
+			if err != nil {
+				return nil, fmt.Errorf("cannot read objects from stdin: %w", err)
+			}
+			objects = append(objects, objs...)
+			continue
+		}
+		err := filepath.WalkDir(path, func(name string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			// explicitly specified files are read regardless of extension
+			if name != path {
+				switch filepath.Ext(name) {
+				case ".yaml", ".yml", ".json":
+				default:
+					return nil
+				}
+			}
+			f, err := os.Open(name)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			objs, err := decodeRenderObjects(f, defaultNamespace)
+			if err != nil {
+				return fmt.Errorf("cannot read objects from file=%q: %w", name, err)
+			}
+			objects = append(objects, objs...)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return objects, nil
+}
+
+// decodeRenderObjects decodes multi-document yaml or json stream into typed objects
+func decodeRenderObjects(r io.Reader, defaultNamespace string) ([]client.Object, error) {
+	var objects []client.Object
+	decoder := utilyaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		var u unstructured.Unstructured
+		if err := decoder.Decode(&u.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return objects, nil
+			}
+			return nil, err
+		}
+		if len(u.Object) == 0 {
+			continue
+		}
+		items := []unstructured.Unstructured{u}
+		if u.IsList() {
+			list, err := u.ToList()
+			if err != nil {
+				return nil, err
+			}
+			items = list.Items
+		}
+		for _, item := range items {
+			obj, err := toTypedObject(&item, defaultNamespace)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, obj)
+		}
+	}
+}
+
+func toTypedObject(u *unstructured.Unstructured, defaultNamespace string) (client.Object, error) {
+	gvk := u.GroupVersionKind()
+	ro, err := scheme.New(gvk)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported object kind=%q apiVersion=%q: %w", gvk.Kind, gvk.GroupVersion(), err)
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, ro); err != nil {
+		return nil, fmt.Errorf("cannot parse %s=%s: %w", gvk.Kind, u.GetName(), err)
+	}
+	obj, ok := ro.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("unsupported object kind=%q", gvk.Kind)
+	}
+	if len(obj.GetName()) == 0 {
+		return nil, fmt.Errorf("%s object must have a name", gvk.Kind)
+	}
+	if len(obj.GetNamespace()) == 0 && gvk.Kind != "Namespace" {
+		obj.SetNamespace(defaultNamespace)
+	}
+	// secrets and configmaps could be defined with string values
+	if secret, ok := obj.(*corev1.Secret); ok && len(secret.StringData) > 0 {
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+		for k, v := range secret.StringData {
+			secret.Data[k] = []byte(v)
+		}
+		secret.StringData = nil
+	}
+	return obj, nil
+}
@@ -0,0 +1,99 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRenderObjects(t *testing.T) {
+	f := func(input string, wantErr bool, wantContains, wantNotContains []string) {
+		t.Helper()
+		objects, err := decodeRenderObjects(strings.NewReader(input), "default")
+		if err != nil {
+			t.Fatalf("cannot decode objects: %s", err)
+		}
+		var buf bytes.Buffer
+		err = renderObjects(context.Background(), &buf, objects, false)
+		if (err != nil) != wantErr {
+			t.Fatalf("unexpected error: %v, wantErr: %v", err, wantErr)
+		}
+		got := buf.String()
+		for _, want := range wantContains {
+			if !strings.Contains(got, want) {
+				t.Fatalf("expected output to contain %q, got:\n%s", want, got)
+			}
+		}
+		for _, notWant := range wantNotContains {
+			if strings.Contains(got, notWant) {
+				t.Fatalf("expected output not to contain %q, got:\n%s", notWant, got)
+			}
+		}
+	}
+
+	// no objects to reconcile
+	f(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+`, true, nil, nil)
+
+	// vmagent with scrape objects
+	f(`
+apiVersion: operator.victoriametrics.com/v1beta1
+kind: VMAgent
+metadata:
+  name: main
+spec:
+  selectAllByDefault: true
+  remoteWrite:
+  - url: http://vmsingle:8429/api/v1/write
+---
+apiVersion: operator.victoriametrics.com/v1beta1
+kind: VMServiceScrape
+metadata:
+  name: app
+  namespace: apps
+spec:
+  selector:
+    matchLabels:
+      app: app
+  endpoints:
+  - port: http
+`, false, []string{
+		"kind: Deployment\nmetadata:",
+		"name: vmagent-main",
+		"  vmagent.yaml: |",
+		"job_name: serviceScrape/apps/app/0",
+	}, []string{
+		"---\napiVersion: operator.victoriametrics.com/v1beta1\nkind: VMAgent\n",
+		"vmagent.yaml.gz: ",
+	})
+
+	// vmalertmanager with config secret
+	f(`
+apiVersion: operator.victoriametrics.com/v1beta1
+kind: VMAlertmanager
+metadata:
+  name: main
+spec:
+  configSecret: am-config
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: am-config
+stringData:
+  alertmanager.yaml: |
+    route:
+      receiver: blackhole
+    receivers:
+    - name: blackhole
+`, false, []string{
+		"name: vmalertmanager-main-config",
+		"receiver: blackhole",
+		"kind: StatefulSet",
+	}, nil)
+}